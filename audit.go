@@ -0,0 +1,98 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// ops for audit log errors.
+const (
+	OpRecordAuditEvent = "RecordAuditEvent"
+	OpFindAuditEvents  = "FindAuditEvents"
+)
+
+// AuditAction is the kind of mutation of a resource recorded by an audit event.
+type AuditAction string
+
+// Audit actions.
+const (
+	CreateAuditAction AuditAction = "create"
+	UpdateAuditAction AuditAction = "update"
+	DeleteAuditAction AuditAction = "delete"
+)
+
+// AuditOutcome is the result of the operation recorded by an audit event.
+type AuditOutcome string
+
+// Audit outcomes.
+const (
+	// AuditSuccess is the outcome of operations that succeeded.
+	AuditSuccess AuditOutcome = "success"
+	// AuditDenied is the outcome of operations the authorizer was not allowed to perform.
+	AuditDenied AuditOutcome = "denied"
+	// AuditFailure is the outcome of authorized operations that failed.
+	AuditFailure AuditOutcome = "failure"
+)
+
+// AuditEvent records a mutating operation on a resource.
+type AuditEvent struct {
+	ID   ID        `json:"id"`
+	Time time.Time `json:"time"`
+
+	// UserID is the user that performed the operation.
+	UserID ID `json:"userID,omitempty"`
+	// AuthorizerKind and AuthorizerID identify the authorization, such as
+	// the token, or the session the operation was performed with.
+	AuthorizerKind string `json:"authorizerKind,omitempty"`
+	AuthorizerID   ID     `json:"authorizerID,omitempty"`
+
+	Action       AuditAction  `json:"action"`
+	ResourceType ResourceType `json:"resourceType"`
+	ResourceID   ID           `json:"resourceID,omitempty"`
+	OrgID        ID           `json:"orgID,omitempty"`
+
+	Outcome AuditOutcome `json:"outcome"`
+	Error   string       `json:"error,omitempty"`
+}
+
+// AuditEventFilter represents a set of filters that restrict the returned audit events.
+type AuditEventFilter struct {
+	// Start and Stop restrict the events to those recorded in [Start, Stop).
+	// They are ignored when zero.
+	Start time.Time
+	Stop  time.Time
+
+	OrgID        *ID
+	UserID       *ID
+	ResourceType *ResourceType
+	ResourceID   *ID
+}
+
+// Match returns true if the event e matches the filter.
+func (f AuditEventFilter) Match(e *AuditEvent) bool {
+	switch {
+	case !f.Start.IsZero() && e.Time.Before(f.Start):
+		return false
+	case !f.Stop.IsZero() && !e.Time.Before(f.Stop):
+		return false
+	case f.OrgID != nil && *f.OrgID != e.OrgID:
+		return false
+	case f.UserID != nil && *f.UserID != e.UserID:
+		return false
+	case f.ResourceType != nil && *f.ResourceType != e.ResourceType:
+		return false
+	case f.ResourceID != nil && *f.ResourceID != e.ResourceID:
+		return false
+	}
+	return true
+}
+
+// AuditService records and retrieves the audit events of mutating operations.
+type AuditService interface {
+	// RecordAuditEvent records the event e, setting its ID, and its time if it is zero.
+	RecordAuditEvent(ctx context.Context, e *AuditEvent) error
+
+	// FindAuditEvents returns the events matching the filter, ordered by time.
+	// The events are in descending order when opts are descending.
+	FindAuditEvents(ctx context.Context, filter AuditEventFilter, opts ...FindOptions) ([]*AuditEvent, int, error)
+}
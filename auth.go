@@ -3,6 +3,7 @@ package influxdb
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 // AuthorizationKind is returned by (*Authorization).Kind().
@@ -25,6 +26,18 @@ type Authorization struct {
 	OrgID       ID           `json:"orgID"`
 	UserID      ID           `json:"userID,omitempty"`
 	Permissions []Permission `json:"permissions"`
+
+	// ExpiresAt is the time the authorization expires. It never expires if
+	// ExpiresAt is nil.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// LastUsedAt is the last time the authorization authenticated a request.
+	// It is recorded periodically, so it may lag behind.
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+
+	// PreviousToken is the token replaced by the last rotation of the
+	// authorization. It authenticates requests until PreviousTokenExpiresAt.
+	PreviousToken          string     `json:"previousToken,omitempty"`
+	PreviousTokenExpiresAt *time.Time `json:"previousTokenExpiresAt,omitempty"`
 }
 
 // Valid ensures that the authorization is valid.
@@ -51,6 +64,13 @@ func (a *Authorization) Allowed(p Permission) bool {
 	return PermissionAllowed(p, a.Permissions)
 }
 
+// Predicate returns the predicate restricting the series of the bucket that
+// the authorization is allowed p on. It is empty if the series are not
+// restricted.
+func (a *Authorization) Predicate(p Permission) string {
+	return PermissionPredicate(p, a.Permissions)
+}
+
 // IsActive is a stub for idpe.
 func IsActive(a *Authorization) bool {
 	return a.IsActive()
@@ -61,6 +81,21 @@ func (a *Authorization) IsActive() bool {
 	return a.Status == Active
 }
 
+// IsExpired returns true if the authorization has expired at now.
+func (a *Authorization) IsExpired(now time.Time) bool {
+	return a.ExpiresAt != nil && !now.Before(*a.ExpiresAt)
+}
+
+// HasToken returns true if t is a token of the authorization at now. Besides
+// its token, the previous token of a rotated authorization is one until the
+// end of the grace period of the rotation.
+func (a *Authorization) HasToken(t string, now time.Time) bool {
+	if t == a.Token {
+		return true
+	}
+	return t == a.PreviousToken && a.PreviousTokenExpiresAt != nil && now.Before(*a.PreviousTokenExpiresAt)
+}
+
 // GetUserID returns the user id.
 func (a *Authorization) GetUserID() ID {
 	return a.UserID
@@ -80,6 +115,7 @@ const (
 	OpCreateAuthorization      = "CreateAuthorization"
 	OpSetAuthorizationStatus   = "SetAuthorizationStatus"
 	OpDeleteAuthorization      = "DeleteAuthorization"
+	OpRotateAuthorization      = "RotateAuthorization"
 )
 
 // AuthorizationService represents a service for managing authorization data.
@@ -103,6 +139,18 @@ type AuthorizationService interface {
 
 	// Removes a authorization by token.
 	DeleteAuthorization(ctx context.Context, id ID) error
+
+	// RotateAuthorization replaces the token of the authorization with a new
+	// one, keeping its ID and permissions. The token replaced still
+	// authenticates requests for the grace period.
+	RotateAuthorization(ctx context.Context, id ID, grace time.Duration) (*Authorization, error)
+}
+
+// AuthorizationUseRecorder records the use of authorizations.
+type AuthorizationUseRecorder interface {
+	// RecordAuthorizationUse records that the authorization id authenticated
+	// a request at t.
+	RecordAuthorizationUse(id ID, t time.Time)
 }
 
 // AuthorizationFilter represents a set of filter that restrict the returned results.
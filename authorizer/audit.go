@@ -0,0 +1,80 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"go.uber.org/zap"
+)
+
+// Auditor records the mutating operations of the services of this package,
+// and whether they were allowed and succeeded, to an audit log. The zero
+// value records nothing.
+type Auditor struct {
+	AuditService influxdb.AuditService
+
+	// Logger logs the events that cannot be recorded.
+	Logger *zap.Logger
+}
+
+// SetAuditService sets the service that the audit events are recorded to,
+// and the logger of the events that cannot be recorded.
+func (a *Auditor) SetAuditService(s influxdb.AuditService, log *zap.Logger) {
+	a.AuditService = s
+	a.Logger = log
+}
+
+// Record records the action on the resource with the authorizer on context.
+// It is meant to be deferred with the address of the error returned by the
+// operation. The operation has already taken place, so its result is kept
+// when the event cannot be recorded, and the event is logged instead.
+func (a *Auditor) Record(ctx context.Context, action influxdb.AuditAction, rt influxdb.ResourceType, orgID, id influxdb.ID, errp *error) {
+	if a.AuditService == nil {
+		return
+	}
+
+	e := &influxdb.AuditEvent{
+		Action:       action,
+		ResourceType: rt,
+		ResourceID:   id,
+		OrgID:        orgID,
+		Outcome:      influxdb.AuditSuccess,
+	}
+
+	if auth, err := influxdbcontext.GetAuthorizer(ctx); err == nil {
+		e.UserID = auth.GetUserID()
+		e.AuthorizerKind = auth.Kind()
+		e.AuthorizerID = auth.Identifier()
+	}
+
+	if err := *errp; err != nil {
+		e.Outcome = influxdb.AuditFailure
+		if isDenied(err) {
+			e.Outcome = influxdb.AuditDenied
+		}
+		e.Error = err.Error()
+	}
+
+	if err := a.AuditService.RecordAuditEvent(ctx, e); err != nil && a.Logger != nil {
+		a.Logger.Error("Failed to record audit event",
+			zap.String("action", string(e.Action)),
+			zap.String("resource_type", string(e.ResourceType)),
+			zap.Stringer("resource_id", e.ResourceID),
+			zap.Stringer("org_id", e.OrgID),
+			zap.Stringer("user_id", e.UserID),
+			zap.String("outcome", string(e.Outcome)),
+			zap.Error(err))
+	}
+}
+
+// isDenied returns true if err is an authorization error, either of this
+// package, or of another one that exposes it with an AuthzError method,
+// such as the task service validator.
+func isDenied(err error) bool {
+	if _, ok := err.(interface{ AuthzError() error }); ok {
+		return true
+	}
+	code := influxdb.ErrorCode(err)
+	return code == influxdb.EUnauthorized || code == influxdb.EForbidden
+}
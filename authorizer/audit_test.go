@@ -0,0 +1,80 @@
+package authorizer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// auditService records the events, or fails to when err is set.
+type auditService struct {
+	influxdb.AuditService
+	events []*influxdb.AuditEvent
+	err    error
+}
+
+func (s *auditService) RecordAuditEvent(ctx context.Context, e *influxdb.AuditEvent) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.events = append(s.events, e)
+	return nil
+}
+
+func TestAuditor_Record(t *testing.T) {
+	ctx := influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{})
+	denied := &influxdb.Error{Code: influxdb.EUnauthorized, Msg: "denied"}
+
+	tests := []struct {
+		name    string
+		err     error
+		outcome influxdb.AuditOutcome
+	}{
+		{name: "success", outcome: influxdb.AuditSuccess},
+		{name: "failure", err: errors.New("failed"), outcome: influxdb.AuditFailure},
+		{name: "denied", err: denied, outcome: influxdb.AuditDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &auditService{}
+			a := &authorizer.Auditor{}
+			a.SetAuditService(s, zap.NewNop())
+
+			err := tt.err
+			a.Record(ctx, influxdb.CreateAuditAction, influxdb.BucketsResourceType, 1, 3, &err)
+			if err != tt.err {
+				t.Errorf("expected the error of the operation %v, got %v", tt.err, err)
+			}
+			if len(s.events) != 1 {
+				t.Fatalf("expected an event, got %v", s.events)
+			}
+			if e := s.events[0]; e.Outcome != tt.outcome || e.UserID != 2 || e.ResourceID != 3 || e.OrgID != 1 {
+				t.Errorf("unexpected event %+v", e)
+			}
+		})
+	}
+
+	t.Run("events that cannot be recorded are logged", func(t *testing.T) {
+		core, logs := observer.New(zapcore.ErrorLevel)
+		a := &authorizer.Auditor{}
+		a.SetAuditService(&auditService{err: errors.New("disk full")}, zap.New(core))
+
+		// The operation has taken place, so its result is kept.
+		var err error
+		a.Record(ctx, influxdb.DeleteAuditAction, influxdb.BucketsResourceType, 1, 3, &err)
+		if err != nil {
+			t.Errorf("expected the operation to succeed, got %v", err)
+		}
+		if logs.Len() != 1 {
+			t.Fatalf("expected the event to be logged, got %v", logs.All())
+		}
+	})
+}
@@ -3,8 +3,10 @@ package authorizer
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/influxdata/influxdb"
+	influxdbcontext "github.com/influxdata/influxdb/context"
 )
 
 var _ influxdb.AuthorizationService = (*AuthorizationService)(nil)
@@ -12,6 +14,8 @@ var _ influxdb.AuthorizationService = (*AuthorizationService)(nil)
 // AuthorizationService wraps a influxdb.AuthorizationService and authorizes actions
 // against it appropriately.
 type AuthorizationService struct {
+	Auditor
+
 	s influxdb.AuthorizationService
 }
 
@@ -116,7 +120,11 @@ func (s *AuthorizationService) FindAuthorizations(ctx context.Context, filter in
 }
 
 // CreateAuthorization checks to see if the authorizer on context has write access to the global authorizations resource.
-func (s *AuthorizationService) CreateAuthorization(ctx context.Context, a *influxdb.Authorization) error {
+func (s *AuthorizationService) CreateAuthorization(ctx context.Context, a *influxdb.Authorization) (err error) {
+	defer func() {
+		s.Record(ctx, influxdb.CreateAuditAction, influxdb.AuthorizationsResourceType, a.OrgID, a.ID, &err)
+	}()
+
 	if err := authorizeWriteAuthorization(ctx, a.UserID); err != nil {
 		return err
 	}
@@ -129,6 +137,7 @@ func (s *AuthorizationService) CreateAuthorization(ctx context.Context, a *influ
 }
 
 // VerifyPermission ensures that an authorization is allowed all of the appropriate permissions.
+// A permission the authorizer is only allowed on some series must be restricted to them too.
 func VerifyPermissions(ctx context.Context, ps []influxdb.Permission) error {
 	for _, p := range ps {
 		if err := IsAllowed(ctx, p); err != nil {
@@ -138,17 +147,34 @@ func VerifyPermissions(ctx context.Context, ps []influxdb.Permission) error {
 				Code: influxdb.EForbidden,
 			}
 		}
+
+		a, err := influxdbcontext.GetAuthorizer(ctx)
+		if err != nil {
+			return err
+		}
+		if pred := influxdb.AuthorizerPredicate(a, p); pred != "" && pred != p.Predicate {
+			return &influxdb.Error{
+				Msg:  fmt.Sprintf("permission %s is not allowed; it must be restricted to %s", p, pred),
+				Code: influxdb.EForbidden,
+			}
+		}
 	}
 
 	return nil
 }
 
 // SetAuthorizationStatus checks to see if the authorizer on context has write access to the authorization provided.
-func (s *AuthorizationService) SetAuthorizationStatus(ctx context.Context, id influxdb.ID, st influxdb.Status) error {
+func (s *AuthorizationService) SetAuthorizationStatus(ctx context.Context, id influxdb.ID, st influxdb.Status) (err error) {
+	var orgID influxdb.ID
+	defer func() {
+		s.Record(ctx, influxdb.UpdateAuditAction, influxdb.AuthorizationsResourceType, orgID, id, &err)
+	}()
+
 	a, err := s.s.FindAuthorizationByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	orgID = a.OrgID
 
 	if err := authorizeWriteAuthorization(ctx, a.UserID); err != nil {
 		return err
@@ -157,12 +183,38 @@ func (s *AuthorizationService) SetAuthorizationStatus(ctx context.Context, id in
 	return s.s.SetAuthorizationStatus(ctx, id, st)
 }
 
+// RotateAuthorization checks to see if the authorizer on context has write access to the authorization provided.
+func (s *AuthorizationService) RotateAuthorization(ctx context.Context, id influxdb.ID, grace time.Duration) (a *influxdb.Authorization, err error) {
+	var orgID influxdb.ID
+	defer func() {
+		s.Record(ctx, influxdb.UpdateAuditAction, influxdb.AuthorizationsResourceType, orgID, id, &err)
+	}()
+
+	a, err = s.s.FindAuthorizationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	orgID = a.OrgID
+
+	if err := authorizeWriteAuthorization(ctx, a.UserID); err != nil {
+		return nil, err
+	}
+
+	return s.s.RotateAuthorization(ctx, id, grace)
+}
+
 // DeleteAuthorization checks to see if the authorizer on context has write access to the authorization provided.
-func (s *AuthorizationService) DeleteAuthorization(ctx context.Context, id influxdb.ID) error {
+func (s *AuthorizationService) DeleteAuthorization(ctx context.Context, id influxdb.ID) (err error) {
+	var orgID influxdb.ID
+	defer func() {
+		s.Record(ctx, influxdb.DeleteAuditAction, influxdb.AuthorizationsResourceType, orgID, id, &err)
+	}()
+
 	a, err := s.s.FindAuthorizationByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	orgID = a.OrgID
 
 	if err := authorizeWriteAuthorization(ctx, a.UserID); err != nil {
 		return err
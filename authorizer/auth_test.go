@@ -270,3 +270,64 @@ func TestAuthorizationService_WriteAuthorization(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyPermissions_Predicate(t *testing.T) {
+	read := func(predicate string) influxdb.Permission {
+		return influxdb.Permission{
+			Action: influxdb.ReadAction,
+			Resource: influxdb.Resource{
+				Type:  influxdb.BucketsResourceType,
+				OrgID: influxdbtesting.IDPtr(1),
+				ID:    influxdbtesting.IDPtr(2),
+			},
+			Predicate: predicate,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		permission influxdb.Permission
+		granted    influxdb.Permission
+		wantErr    bool
+	}{
+		{
+			name:       "not restricted",
+			permission: read(""),
+			granted:    read(`host="a"`),
+		},
+		{
+			name:       "same restriction",
+			permission: read(`host="a"`),
+			granted:    read(`host="a"`),
+		},
+		{
+			name:       "without restriction",
+			permission: read(`host="a"`),
+			granted:    read(""),
+			wantErr:    true,
+		},
+		{
+			name:       "other restriction",
+			permission: read(`host="a"`),
+			granted:    read(`host="b"`),
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := influxdbcontext.SetAuthorizer(context.Background(), &influxdb.Authorization{
+				Status:      influxdb.Active,
+				Permissions: []influxdb.Permission{tt.permission},
+			})
+
+			err := authorizer.VerifyPermissions(ctx, []influxdb.Permission{tt.granted})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("VerifyPermissions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && influxdb.ErrorCode(err) != influxdb.EForbidden {
+				t.Errorf("unexpected error code %q", influxdb.ErrorCode(err))
+			}
+		})
+	}
+}
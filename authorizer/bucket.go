@@ -11,6 +11,8 @@ var _ influxdb.BucketService = (*BucketService)(nil)
 // BucketService wraps a influxdb.BucketService and authorizes actions
 // against it appropriately.
 type BucketService struct {
+	Auditor
+
 	s influxdb.BucketService
 }
 
@@ -108,7 +110,11 @@ func (s *BucketService) FindBuckets(ctx context.Context, filter influxdb.BucketF
 }
 
 // CreateBucket checks to see if the authorizer on context has write access to the global buckets resource.
-func (s *BucketService) CreateBucket(ctx context.Context, b *influxdb.Bucket) error {
+func (s *BucketService) CreateBucket(ctx context.Context, b *influxdb.Bucket) (err error) {
+	defer func() {
+		s.Record(ctx, influxdb.CreateAuditAction, influxdb.BucketsResourceType, b.OrganizationID, b.ID, &err)
+	}()
+
 	p, err := influxdb.NewPermission(influxdb.WriteAction, influxdb.BucketsResourceType, b.OrganizationID)
 	if err != nil {
 		return err
@@ -122,11 +128,15 @@ func (s *BucketService) CreateBucket(ctx context.Context, b *influxdb.Bucket) er
 }
 
 // UpdateBucket checks to see if the authorizer on context has write access to the bucket provided.
-func (s *BucketService) UpdateBucket(ctx context.Context, id influxdb.ID, upd influxdb.BucketUpdate) (*influxdb.Bucket, error) {
+func (s *BucketService) UpdateBucket(ctx context.Context, id influxdb.ID, upd influxdb.BucketUpdate) (_ *influxdb.Bucket, err error) {
+	var orgID influxdb.ID
+	defer func() { s.Record(ctx, influxdb.UpdateAuditAction, influxdb.BucketsResourceType, orgID, id, &err) }()
+
 	b, err := s.s.FindBucketByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	orgID = b.OrganizationID
 
 	if err := authorizeWriteBucket(ctx, b.OrganizationID, id); err != nil {
 		return nil, err
@@ -136,11 +146,15 @@ func (s *BucketService) UpdateBucket(ctx context.Context, id influxdb.ID, upd in
 }
 
 // DeleteBucket checks to see if the authorizer on context has write access to the bucket provided.
-func (s *BucketService) DeleteBucket(ctx context.Context, id influxdb.ID) error {
+func (s *BucketService) DeleteBucket(ctx context.Context, id influxdb.ID) (err error) {
+	var orgID influxdb.ID
+	defer func() { s.Record(ctx, influxdb.DeleteAuditAction, influxdb.BucketsResourceType, orgID, id, &err) }()
+
 	b, err := s.s.FindBucketByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	orgID = b.OrganizationID
 
 	if err := authorizeWriteBucket(ctx, b.OrganizationID, id); err != nil {
 		return err
@@ -0,0 +1,31 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CardinalityService = (*CardinalityService)(nil)
+
+// CardinalityService wraps a influxdb.CardinalityService and authorizes actions
+// against it appropriately.
+type CardinalityService struct {
+	s influxdb.CardinalityService
+}
+
+// NewCardinalityService constructs an instance of an authorizing cardinality service.
+func NewCardinalityService(s influxdb.CardinalityService) *CardinalityService {
+	return &CardinalityService{
+		s: s,
+	}
+}
+
+// BucketCardinality checks to see if the authorizer on context has read access to the bucket.
+func (s *CardinalityService) BucketCardinality(ctx context.Context, orgID, bucketID influxdb.ID) (*influxdb.BucketCardinality, error) {
+	if err := authorizeReadBucket(ctx, orgID, bucketID); err != nil {
+		return nil, err
+	}
+
+	return s.s.BucketCardinality(ctx, orgID, bucketID)
+}
@@ -0,0 +1,81 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+type cardinalityService struct{}
+
+func (cardinalityService) BucketCardinality(ctx context.Context, orgID, bucketID influxdb.ID) (*influxdb.BucketCardinality, error) {
+	return &influxdb.BucketCardinality{OrganizationID: orgID, BucketID: bucketID}, nil
+}
+
+func TestCardinalityService_BucketCardinality(t *testing.T) {
+	type args struct {
+		permission influxdb.Permission
+		orgID      influxdb.ID
+		bucketID   influxdb.ID
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to read cardinality of bucket",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.BucketsResourceType,
+						ID:   influxdbtesting.IDPtr(1),
+					},
+				},
+				orgID:    10,
+				bucketID: 1,
+			},
+		},
+		{
+			name: "unauthorized to read cardinality of bucket",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.BucketsResourceType,
+						ID:   influxdbtesting.IDPtr(1),
+					},
+				},
+				orgID:    10,
+				bucketID: 2,
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "read:orgs/000000000000000a/buckets/0000000000000002 is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewCardinalityService(cardinalityService{})
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			_, err := s.BucketCardinality(ctx, tt.args.orgID, tt.args.bucketID)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
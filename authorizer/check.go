@@ -0,0 +1,174 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckService = (*CheckService)(nil)
+
+// CheckService wraps a influxdb.CheckService and authorizes actions
+// against it appropriately.
+type CheckService struct {
+	Auditor
+
+	s influxdb.CheckService
+}
+
+// NewCheckService constructs an instance of an authorizing check service.
+func NewCheckService(s influxdb.CheckService) *CheckService {
+	return &CheckService{
+		s: s,
+	}
+}
+
+func newCheckPermission(a influxdb.Action, orgID, id influxdb.ID) (*influxdb.Permission, error) {
+	return influxdb.NewPermissionAtID(id, a, influxdb.ChecksResourceType, orgID)
+}
+
+func authorizeReadCheck(ctx context.Context, orgID, id influxdb.ID) error {
+	p, err := newCheckPermission(influxdb.ReadAction, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func authorizeWriteCheck(ctx context.Context, orgID, id influxdb.ID) error {
+	p, err := newCheckPermission(influxdb.WriteAction, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// authorizeCheckedTask checks that the runs of the task of a task check may
+// be read by the authorizer on context.
+func authorizeCheckedTask(ctx context.Context, c *influxdb.Check) error {
+	if c.Type != influxdb.TaskCheckType {
+		return nil
+	}
+
+	p, err := influxdb.NewPermissionAtID(c.TaskID, influxdb.ReadAction, influxdb.TasksResourceType, c.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	return IsAllowed(ctx, *p)
+}
+
+// FindCheckByID checks to see if the authorizer on context has read access to the id provided.
+func (s *CheckService) FindCheckByID(ctx context.Context, id influxdb.ID) (*influxdb.Check, error) {
+	c, err := s.s.FindCheckByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeReadCheck(ctx, c.OrganizationID, id); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// FindChecks retrieves all checks that match the provided filter and then filters the list down to only the resources that are authorized.
+func (s *CheckService) FindChecks(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]*influxdb.Check, int, error) {
+	// TODO: we'll likely want to push this operation into the database since fetching the whole list of data will likely be expensive.
+	cs, _, err := s.s.FindChecks(ctx, filter, opt...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// This filters without allocating
+	// https://github.com/golang/go/wiki/SliceTricks#filtering-without-allocating
+	checks := cs[:0]
+	for _, c := range cs {
+		err := authorizeReadCheck(ctx, c.OrganizationID, c.ID)
+		if err != nil && influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+			return nil, 0, err
+		}
+
+		if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
+			continue
+		}
+
+		checks = append(checks, c)
+	}
+
+	return checks, len(checks), nil
+}
+
+// CreateCheck checks to see if the authorizer on context has write access to the global check resource.
+func (s *CheckService) CreateCheck(ctx context.Context, c *influxdb.Check) (err error) {
+	defer func() {
+		s.Record(ctx, influxdb.CreateAuditAction, influxdb.ChecksResourceType, c.OrganizationID, c.ID, &err)
+	}()
+
+	p, err := influxdb.NewPermission(influxdb.WriteAction, influxdb.ChecksResourceType, c.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return err
+	}
+
+	if err := authorizeCheckedTask(ctx, c); err != nil {
+		return err
+	}
+
+	return s.s.CreateCheck(ctx, c)
+}
+
+// UpdateCheck checks to see if the authorizer on context has write access to the check provided.
+func (s *CheckService) UpdateCheck(ctx context.Context, id influxdb.ID, upd *influxdb.Check) (_ *influxdb.Check, err error) {
+	var orgID influxdb.ID
+	defer func() { s.Record(ctx, influxdb.UpdateAuditAction, influxdb.ChecksResourceType, orgID, id, &err) }()
+
+	c, err := s.FindCheckByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	orgID = c.OrganizationID
+
+	if err := authorizeWriteCheck(ctx, c.OrganizationID, id); err != nil {
+		return nil, err
+	}
+
+	// The organization of a check cannot be changed.
+	upd.OrganizationID = c.OrganizationID
+	if err := authorizeCheckedTask(ctx, upd); err != nil {
+		return nil, err
+	}
+
+	return s.s.UpdateCheck(ctx, id, upd)
+}
+
+// DeleteCheck checks to see if the authorizer on context has write access to the check provided.
+func (s *CheckService) DeleteCheck(ctx context.Context, id influxdb.ID) (err error) {
+	var orgID influxdb.ID
+	defer func() { s.Record(ctx, influxdb.DeleteAuditAction, influxdb.ChecksResourceType, orgID, id, &err) }()
+
+	c, err := s.FindCheckByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	orgID = c.OrganizationID
+
+	if err := authorizeWriteCheck(ctx, c.OrganizationID, id); err != nil {
+		return err
+	}
+
+	return s.s.DeleteCheck(ctx, id)
+}
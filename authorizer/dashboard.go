@@ -11,6 +11,8 @@ var _ influxdb.DashboardService = (*DashboardService)(nil)
 // DashboardService wraps a influxdb.DashboardService and authorizes actions
 // against it appropriately.
 type DashboardService struct {
+	Auditor
+
 	s influxdb.DashboardService
 }
 
@@ -94,7 +96,11 @@ func (s *DashboardService) FindDashboards(ctx context.Context, filter influxdb.D
 }
 
 // CreateDashboard checks to see if the authorizer on context has write access to the global dashboards resource.
-func (s *DashboardService) CreateDashboard(ctx context.Context, b *influxdb.Dashboard) error {
+func (s *DashboardService) CreateDashboard(ctx context.Context, b *influxdb.Dashboard) (err error) {
+	defer func() {
+		s.Record(ctx, influxdb.CreateAuditAction, influxdb.DashboardsResourceType, b.OrganizationID, b.ID, &err)
+	}()
+
 	p, err := influxdb.NewPermission(influxdb.WriteAction, influxdb.DashboardsResourceType, b.OrganizationID)
 	if err != nil {
 		return err
@@ -108,11 +114,15 @@ func (s *DashboardService) CreateDashboard(ctx context.Context, b *influxdb.Dash
 }
 
 // UpdateDashboard checks to see if the authorizer on context has write access to the dashboard provided.
-func (s *DashboardService) UpdateDashboard(ctx context.Context, id influxdb.ID, upd influxdb.DashboardUpdate) (*influxdb.Dashboard, error) {
+func (s *DashboardService) UpdateDashboard(ctx context.Context, id influxdb.ID, upd influxdb.DashboardUpdate) (_ *influxdb.Dashboard, err error) {
+	var orgID influxdb.ID
+	defer func() { s.Record(ctx, influxdb.UpdateAuditAction, influxdb.DashboardsResourceType, orgID, id, &err) }()
+
 	b, err := s.s.FindDashboardByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	orgID = b.OrganizationID
 
 	if err := authorizeWriteDashboard(ctx, b.OrganizationID, id); err != nil {
 		return nil, err
@@ -122,11 +132,15 @@ func (s *DashboardService) UpdateDashboard(ctx context.Context, id influxdb.ID,
 }
 
 // DeleteDashboard checks to see if the authorizer on context has write access to the dashboard provided.
-func (s *DashboardService) DeleteDashboard(ctx context.Context, id influxdb.ID) error {
+func (s *DashboardService) DeleteDashboard(ctx context.Context, id influxdb.ID) (err error) {
+	var orgID influxdb.ID
+	defer func() { s.Record(ctx, influxdb.DeleteAuditAction, influxdb.DashboardsResourceType, orgID, id, &err) }()
+
 	b, err := s.s.FindDashboardByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	orgID = b.OrganizationID
 
 	if err := authorizeWriteDashboard(ctx, b.OrganizationID, id); err != nil {
 		return err
@@ -135,11 +149,15 @@ func (s *DashboardService) DeleteDashboard(ctx context.Context, id influxdb.ID)
 	return s.s.DeleteDashboard(ctx, id)
 }
 
-func (s *DashboardService) AddDashboardCell(ctx context.Context, id influxdb.ID, c *influxdb.Cell, opts influxdb.AddDashboardCellOptions) error {
+func (s *DashboardService) AddDashboardCell(ctx context.Context, id influxdb.ID, c *influxdb.Cell, opts influxdb.AddDashboardCellOptions) (err error) {
+	var orgID influxdb.ID
+	defer func() { s.Record(ctx, influxdb.UpdateAuditAction, influxdb.DashboardsResourceType, orgID, id, &err) }()
+
 	b, err := s.s.FindDashboardByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	orgID = b.OrganizationID
 
 	if err := authorizeWriteDashboard(ctx, b.OrganizationID, id); err != nil {
 		return err
@@ -148,11 +166,17 @@ func (s *DashboardService) AddDashboardCell(ctx context.Context, id influxdb.ID,
 	return s.s.AddDashboardCell(ctx, id, c, opts)
 }
 
-func (s *DashboardService) RemoveDashboardCell(ctx context.Context, dashboardID influxdb.ID, cellID influxdb.ID) error {
+func (s *DashboardService) RemoveDashboardCell(ctx context.Context, dashboardID influxdb.ID, cellID influxdb.ID) (err error) {
+	var orgID influxdb.ID
+	defer func() {
+		s.Record(ctx, influxdb.UpdateAuditAction, influxdb.DashboardsResourceType, orgID, dashboardID, &err)
+	}()
+
 	b, err := s.s.FindDashboardByID(ctx, dashboardID)
 	if err != nil {
 		return err
 	}
+	orgID = b.OrganizationID
 
 	if err := authorizeWriteDashboard(ctx, b.OrganizationID, dashboardID); err != nil {
 		return err
@@ -161,11 +185,17 @@ func (s *DashboardService) RemoveDashboardCell(ctx context.Context, dashboardID
 	return s.s.RemoveDashboardCell(ctx, dashboardID, cellID)
 }
 
-func (s *DashboardService) UpdateDashboardCell(ctx context.Context, dashboardID influxdb.ID, cellID influxdb.ID, upd influxdb.CellUpdate) (*influxdb.Cell, error) {
+func (s *DashboardService) UpdateDashboardCell(ctx context.Context, dashboardID influxdb.ID, cellID influxdb.ID, upd influxdb.CellUpdate) (_ *influxdb.Cell, err error) {
+	var orgID influxdb.ID
+	defer func() {
+		s.Record(ctx, influxdb.UpdateAuditAction, influxdb.DashboardsResourceType, orgID, dashboardID, &err)
+	}()
+
 	b, err := s.s.FindDashboardByID(ctx, dashboardID)
 	if err != nil {
 		return nil, err
 	}
+	orgID = b.OrganizationID
 
 	if err := authorizeWriteDashboard(ctx, b.OrganizationID, dashboardID); err != nil {
 		return nil, err
@@ -187,11 +217,17 @@ func (s *DashboardService) GetDashboardCellView(ctx context.Context, dashboardID
 	return s.s.GetDashboardCellView(ctx, dashboardID, cellID)
 }
 
-func (s *DashboardService) UpdateDashboardCellView(ctx context.Context, dashboardID influxdb.ID, cellID influxdb.ID, upd influxdb.ViewUpdate) (*influxdb.View, error) {
+func (s *DashboardService) UpdateDashboardCellView(ctx context.Context, dashboardID influxdb.ID, cellID influxdb.ID, upd influxdb.ViewUpdate) (_ *influxdb.View, err error) {
+	var orgID influxdb.ID
+	defer func() {
+		s.Record(ctx, influxdb.UpdateAuditAction, influxdb.DashboardsResourceType, orgID, dashboardID, &err)
+	}()
+
 	b, err := s.s.FindDashboardByID(ctx, dashboardID)
 	if err != nil {
 		return nil, err
 	}
+	orgID = b.OrganizationID
 
 	if err := authorizeWriteDashboard(ctx, b.OrganizationID, dashboardID); err != nil {
 		return nil, err
@@ -200,11 +236,15 @@ func (s *DashboardService) UpdateDashboardCellView(ctx context.Context, dashboar
 	return s.s.UpdateDashboardCellView(ctx, dashboardID, cellID, upd)
 }
 
-func (s *DashboardService) ReplaceDashboardCells(ctx context.Context, id influxdb.ID, c []*influxdb.Cell) error {
+func (s *DashboardService) ReplaceDashboardCells(ctx context.Context, id influxdb.ID, c []*influxdb.Cell) (err error) {
+	var orgID influxdb.ID
+	defer func() { s.Record(ctx, influxdb.UpdateAuditAction, influxdb.DashboardsResourceType, orgID, id, &err) }()
+
 	b, err := s.s.FindDashboardByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	orgID = b.OrganizationID
 
 	if err := authorizeWriteDashboard(ctx, b.OrganizationID, id); err != nil {
 		return err
@@ -0,0 +1,354 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestDBRPMappingService_FindBy(t *testing.T) {
+	type fields struct {
+		DBRPMappingService influxdb.DBRPMappingService
+	}
+	type args struct {
+		permission influxdb.Permission
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "authorized to access the bucket of the mapping",
+			fields: fields{
+				DBRPMappingService: &mock.DBRPMappingService{
+					FindByFn: func(ctx context.Context, cluster, db, rp string) (*influxdb.DBRPMapping, error) {
+						return &influxdb.DBRPMapping{
+							Cluster:         cluster,
+							Database:        db,
+							RetentionPolicy: rp,
+							OrganizationID:  10,
+							BucketID:        1,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type:  influxdb.BucketsResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+					},
+				},
+			},
+			wants: wants{
+				err: nil,
+			},
+		},
+		{
+			name: "unauthorized to access the bucket of the mapping",
+			fields: fields{
+				DBRPMappingService: &mock.DBRPMappingService{
+					FindByFn: func(ctx context.Context, cluster, db, rp string) (*influxdb.DBRPMapping, error) {
+						return &influxdb.DBRPMapping{
+							Cluster:         cluster,
+							Database:        db,
+							RetentionPolicy: rp,
+							OrganizationID:  10,
+							BucketID:        1,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.BucketsResourceType,
+						ID:   influxdbtesting.IDPtr(2),
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "read:orgs/000000000000000a/buckets/0000000000000001 is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewDBRPMappingService(tt.fields.DBRPMappingService)
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			_, err := s.FindBy(ctx, influxdb.DefaultDBRPCluster, "db", "rp")
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
+
+func TestDBRPMappingService_FindMany(t *testing.T) {
+	mappings := []*influxdb.DBRPMapping{
+		{Database: "db1", OrganizationID: 10, BucketID: 1},
+		{Database: "db2", OrganizationID: 10, BucketID: 2},
+		{Database: "db3", OrganizationID: 11, BucketID: 3},
+	}
+
+	type args struct {
+		permission influxdb.Permission
+	}
+	type wants struct {
+		err      error
+		mappings []*influxdb.DBRPMapping
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to see all mappings",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.BucketsResourceType,
+					},
+				},
+			},
+			wants: wants{
+				mappings: mappings,
+			},
+		},
+		{
+			name: "authorized to see the mappings of an organization",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type:  influxdb.BucketsResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+					},
+				},
+			},
+			wants: wants{
+				mappings: mappings[:2],
+			},
+		},
+		{
+			name: "authorized to see the mapping of a bucket",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.BucketsResourceType,
+						ID:   influxdbtesting.IDPtr(3),
+					},
+				},
+			},
+			wants: wants{
+				mappings: mappings[2:],
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mock.DBRPMappingService{
+				FindManyFn: func(ctx context.Context, filter influxdb.DBRPMappingFilter, opt ...influxdb.FindOptions) ([]*influxdb.DBRPMapping, int, error) {
+					// Copy the mappings, since they are filtered in place.
+					ms := append([]*influxdb.DBRPMapping(nil), mappings...)
+					return ms, len(ms), nil
+				},
+			}
+			s := authorizer.NewDBRPMappingService(svc)
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			ms, _, err := s.FindMany(ctx, influxdb.DBRPMappingFilter{})
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+
+			if diff := cmp.Diff(ms, tt.wants.mappings); diff != "" {
+				t.Errorf("mappings are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+func TestDBRPMappingService_Create(t *testing.T) {
+	type args struct {
+		permission influxdb.Permission
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to write the bucket of the mapping",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type: influxdb.BucketsResourceType,
+						ID:   influxdbtesting.IDPtr(1),
+					},
+				},
+			},
+			wants: wants{
+				err: nil,
+			},
+		},
+		{
+			name: "unauthorized to write the bucket of the mapping",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.BucketsResourceType,
+						ID:   influxdbtesting.IDPtr(1),
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "write:orgs/000000000000000a/buckets/0000000000000001 is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewDBRPMappingService(mock.NewDBRPMappingService())
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			err := s.Create(ctx, &influxdb.DBRPMapping{
+				Cluster:         influxdb.DefaultDBRPCluster,
+				Database:        "db",
+				RetentionPolicy: "rp",
+				OrganizationID:  10,
+				BucketID:        1,
+			})
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
+
+func TestDBRPMappingService_Delete(t *testing.T) {
+	type args struct {
+		permission influxdb.Permission
+		db         string
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to write the bucket of the mapping",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type:  influxdb.BucketsResourceType,
+						OrgID: influxdbtesting.IDPtr(10),
+					},
+				},
+				db: "db",
+			},
+			wants: wants{
+				err: nil,
+			},
+		},
+		{
+			name: "unauthorized to write the bucket of the mapping",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type: influxdb.BucketsResourceType,
+						ID:   influxdbtesting.IDPtr(2),
+					},
+				},
+				db: "db",
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "write:orgs/000000000000000a/buckets/0000000000000001 is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+		{
+			name: "deleting a mapping that does not exist is not an error",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type: influxdb.BucketsResourceType,
+						ID:   influxdbtesting.IDPtr(2),
+					},
+				},
+				db: "missing",
+			},
+			wants: wants{
+				err: nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := mock.NewDBRPMappingService()
+			svc.FindByFn = func(ctx context.Context, cluster, db, rp string) (*influxdb.DBRPMapping, error) {
+				if db != "db" {
+					return nil, influxdb.ErrDBRPMappingNotFound
+				}
+				return &influxdb.DBRPMapping{
+					Cluster:         cluster,
+					Database:        db,
+					RetentionPolicy: rp,
+					OrganizationID:  10,
+					BucketID:        1,
+				}, nil
+			}
+			s := authorizer.NewDBRPMappingService(svc)
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			err := s.Delete(ctx, influxdb.DefaultDBRPCluster, tt.args.db, "rp")
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
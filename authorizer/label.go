@@ -11,6 +11,8 @@ var _ influxdb.LabelService = (*LabelService)(nil)
 // LabelService wraps a influxdb.LabelService and authorizes actions
 // against it appropriately.
 type LabelService struct {
+	Auditor
+
 	s influxdb.LabelService
 }
 
@@ -160,7 +162,11 @@ func (s *LabelService) FindResourceLabels(ctx context.Context, filter influxdb.L
 }
 
 // CreateLabel checks to see if the authorizer on context has write access to the global labels resource.
-func (s *LabelService) CreateLabel(ctx context.Context, l *influxdb.Label) error {
+func (s *LabelService) CreateLabel(ctx context.Context, l *influxdb.Label) (err error) {
+	defer func() {
+		s.Record(ctx, influxdb.CreateAuditAction, influxdb.LabelsResourceType, influxdb.ID(0), l.ID, &err)
+	}()
+
 	p, err := influxdb.NewGlobalPermission(influxdb.WriteAction, influxdb.LabelsResourceType)
 	if err != nil {
 		return err
@@ -174,7 +180,11 @@ func (s *LabelService) CreateLabel(ctx context.Context, l *influxdb.Label) error
 }
 
 // CreateLabelMapping checks to see if the authorizer on context has write access to the label and the resource contained by the label mapping in creation.
-func (s *LabelService) CreateLabelMapping(ctx context.Context, m *influxdb.LabelMapping) error {
+func (s *LabelService) CreateLabelMapping(ctx context.Context, m *influxdb.LabelMapping) (err error) {
+	defer func() {
+		s.Record(ctx, influxdb.UpdateAuditAction, influxdb.LabelsResourceType, influxdb.ID(0), m.LabelID, &err)
+	}()
+
 	if err := authorizeWriteLabel(ctx, m.LabelID); err != nil {
 		return err
 	}
@@ -187,8 +197,12 @@ func (s *LabelService) CreateLabelMapping(ctx context.Context, m *influxdb.Label
 }
 
 // UpdateLabel checks to see if the authorizer on context has write access to the label provided.
-func (s *LabelService) UpdateLabel(ctx context.Context, id influxdb.ID, upd influxdb.LabelUpdate) (*influxdb.Label, error) {
-	_, err := s.s.FindLabelByID(ctx, id)
+func (s *LabelService) UpdateLabel(ctx context.Context, id influxdb.ID, upd influxdb.LabelUpdate) (_ *influxdb.Label, err error) {
+	defer func() {
+		s.Record(ctx, influxdb.UpdateAuditAction, influxdb.LabelsResourceType, influxdb.ID(0), id, &err)
+	}()
+
+	_, err = s.s.FindLabelByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -201,8 +215,12 @@ func (s *LabelService) UpdateLabel(ctx context.Context, id influxdb.ID, upd infl
 }
 
 // DeleteLabel checks to see if the authorizer on context has write access to the label provided.
-func (s *LabelService) DeleteLabel(ctx context.Context, id influxdb.ID) error {
-	_, err := s.s.FindLabelByID(ctx, id)
+func (s *LabelService) DeleteLabel(ctx context.Context, id influxdb.ID) (err error) {
+	defer func() {
+		s.Record(ctx, influxdb.DeleteAuditAction, influxdb.LabelsResourceType, influxdb.ID(0), id, &err)
+	}()
+
+	_, err = s.s.FindLabelByID(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -215,8 +233,12 @@ func (s *LabelService) DeleteLabel(ctx context.Context, id influxdb.ID) error {
 }
 
 // DeleteLabelMapping checks to see if the authorizer on context has write access to the label and the resource of the label mapping to delete.
-func (s *LabelService) DeleteLabelMapping(ctx context.Context, m *influxdb.LabelMapping) error {
-	_, err := s.s.FindLabelByID(ctx, m.LabelID)
+func (s *LabelService) DeleteLabelMapping(ctx context.Context, m *influxdb.LabelMapping) (err error) {
+	defer func() {
+		s.Record(ctx, influxdb.UpdateAuditAction, influxdb.LabelsResourceType, influxdb.ID(0), m.LabelID, &err)
+	}()
+
+	_, err = s.s.FindLabelByID(ctx, m.LabelID)
 	if err != nil {
 		return err
 	}
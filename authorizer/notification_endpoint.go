@@ -0,0 +1,153 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.NotificationEndpointService = (*NotificationEndpointService)(nil)
+
+// NotificationEndpointService wraps a influxdb.NotificationEndpointService and authorizes actions
+// against it appropriately.
+type NotificationEndpointService struct {
+	Auditor
+
+	s influxdb.NotificationEndpointService
+}
+
+// NewNotificationEndpointService constructs an instance of an authorizing notification endpoint service.
+func NewNotificationEndpointService(s influxdb.NotificationEndpointService) *NotificationEndpointService {
+	return &NotificationEndpointService{
+		s: s,
+	}
+}
+
+func newNotificationEndpointPermission(a influxdb.Action, orgID, id influxdb.ID) (*influxdb.Permission, error) {
+	return influxdb.NewPermissionAtID(id, a, influxdb.NotificationEndpointsResourceType, orgID)
+}
+
+func authorizeReadNotificationEndpoint(ctx context.Context, orgID, id influxdb.ID) error {
+	p, err := newNotificationEndpointPermission(influxdb.ReadAction, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func authorizeWriteNotificationEndpoint(ctx context.Context, orgID, id influxdb.ID) error {
+	p, err := newNotificationEndpointPermission(influxdb.WriteAction, orgID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// FindNotificationEndpointByID checks to see if the authorizer on context has read access to the id provided.
+func (s *NotificationEndpointService) FindNotificationEndpointByID(ctx context.Context, id influxdb.ID) (*influxdb.NotificationEndpoint, error) {
+	e, err := s.s.FindNotificationEndpointByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorizeReadNotificationEndpoint(ctx, e.OrganizationID, id); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// FindNotificationEndpoints retrieves all notification endpoints that match the provided filter and then filters the list down to only the resources that are authorized.
+func (s *NotificationEndpointService) FindNotificationEndpoints(ctx context.Context, filter influxdb.NotificationEndpointFilter, opt ...influxdb.FindOptions) ([]*influxdb.NotificationEndpoint, int, error) {
+	// TODO: we'll likely want to push this operation into the database since fetching the whole list of data will likely be expensive.
+	es, _, err := s.s.FindNotificationEndpoints(ctx, filter, opt...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// This filters without allocating
+	// https://github.com/golang/go/wiki/SliceTricks#filtering-without-allocating
+	endpoints := es[:0]
+	for _, e := range es {
+		err := authorizeReadNotificationEndpoint(ctx, e.OrganizationID, e.ID)
+		if err != nil && influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+			return nil, 0, err
+		}
+
+		if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
+			continue
+		}
+
+		endpoints = append(endpoints, e)
+	}
+
+	return endpoints, len(endpoints), nil
+}
+
+// CreateNotificationEndpoint checks to see if the authorizer on context has write access to the global notification endpoint resource.
+func (s *NotificationEndpointService) CreateNotificationEndpoint(ctx context.Context, e *influxdb.NotificationEndpoint) (err error) {
+	defer func() {
+		s.Record(ctx, influxdb.CreateAuditAction, influxdb.NotificationEndpointsResourceType, e.OrganizationID, e.ID, &err)
+	}()
+
+	p, err := influxdb.NewPermission(influxdb.WriteAction, influxdb.NotificationEndpointsResourceType, e.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return err
+	}
+
+	return s.s.CreateNotificationEndpoint(ctx, e)
+}
+
+// UpdateNotificationEndpoint checks to see if the authorizer on context has write access to the notification endpoint provided.
+func (s *NotificationEndpointService) UpdateNotificationEndpoint(ctx context.Context, id influxdb.ID, upd *influxdb.NotificationEndpoint) (_ *influxdb.NotificationEndpoint, err error) {
+	var orgID influxdb.ID
+	defer func() {
+		s.Record(ctx, influxdb.UpdateAuditAction, influxdb.NotificationEndpointsResourceType, orgID, id, &err)
+	}()
+
+	e, err := s.FindNotificationEndpointByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	orgID = e.OrganizationID
+
+	if err := authorizeWriteNotificationEndpoint(ctx, e.OrganizationID, id); err != nil {
+		return nil, err
+	}
+
+	return s.s.UpdateNotificationEndpoint(ctx, id, upd)
+}
+
+// DeleteNotificationEndpoint checks to see if the authorizer on context has write access to the notification endpoint provided.
+func (s *NotificationEndpointService) DeleteNotificationEndpoint(ctx context.Context, id influxdb.ID) (err error) {
+	var orgID influxdb.ID
+	defer func() {
+		s.Record(ctx, influxdb.DeleteAuditAction, influxdb.NotificationEndpointsResourceType, orgID, id, &err)
+	}()
+
+	e, err := s.FindNotificationEndpointByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	orgID = e.OrganizationID
+
+	if err := authorizeWriteNotificationEndpoint(ctx, e.OrganizationID, id); err != nil {
+		return err
+	}
+
+	return s.s.DeleteNotificationEndpoint(ctx, id)
+}
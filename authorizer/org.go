@@ -11,6 +11,8 @@ var _ influxdb.OrganizationService = (*OrgService)(nil)
 // OrgService wraps a influxdb.OrganizationService and authorizes actions
 // against it appropriately.
 type OrgService struct {
+	Auditor
+
 	s influxdb.OrganizationService
 }
 
@@ -111,7 +113,9 @@ func (s *OrgService) FindOrganizations(ctx context.Context, filter influxdb.Orga
 }
 
 // CreateOrganization checks to see if the authorizer on context has write access to the global orgs resource.
-func (s *OrgService) CreateOrganization(ctx context.Context, o *influxdb.Organization) error {
+func (s *OrgService) CreateOrganization(ctx context.Context, o *influxdb.Organization) (err error) {
+	defer func() { s.Record(ctx, influxdb.CreateAuditAction, influxdb.OrgsResourceType, o.ID, o.ID, &err) }()
+
 	p, err := influxdb.NewGlobalPermission(influxdb.WriteAction, influxdb.OrgsResourceType)
 	if err != nil {
 		return err
@@ -125,7 +129,9 @@ func (s *OrgService) CreateOrganization(ctx context.Context, o *influxdb.Organiz
 }
 
 // UpdateOrganization checks to see if the authorizer on context has write access to the organization provided.
-func (s *OrgService) UpdateOrganization(ctx context.Context, id influxdb.ID, upd influxdb.OrganizationUpdate) (*influxdb.Organization, error) {
+func (s *OrgService) UpdateOrganization(ctx context.Context, id influxdb.ID, upd influxdb.OrganizationUpdate) (_ *influxdb.Organization, err error) {
+	defer func() { s.Record(ctx, influxdb.UpdateAuditAction, influxdb.OrgsResourceType, id, id, &err) }()
+
 	if err := authorizeWriteOrg(ctx, id); err != nil {
 		return nil, err
 	}
@@ -134,7 +140,9 @@ func (s *OrgService) UpdateOrganization(ctx context.Context, id influxdb.ID, upd
 }
 
 // DeleteOrganization checks to see if the authorizer on context has write access to the organization provided.
-func (s *OrgService) DeleteOrganization(ctx context.Context, id influxdb.ID) error {
+func (s *OrgService) DeleteOrganization(ctx context.Context, id influxdb.ID) (err error) {
+	defer func() { s.Record(ctx, influxdb.DeleteAuditAction, influxdb.OrgsResourceType, id, id, &err) }()
+
 	if err := authorizeWriteOrg(ctx, id); err != nil {
 		return err
 	}
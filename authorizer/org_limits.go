@@ -0,0 +1,52 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.OrgLimitsService = (*OrgLimitsService)(nil)
+
+// OrgLimitsService wraps a influxdb.OrgLimitsService and authorizes actions
+// against it appropriately.
+type OrgLimitsService struct {
+	Auditor
+
+	s influxdb.OrgLimitsService
+}
+
+// NewOrgLimitsService constructs an instance of an authorizing org limits service.
+func NewOrgLimitsService(s influxdb.OrgLimitsService) *OrgLimitsService {
+	return &OrgLimitsService{
+		s: s,
+	}
+}
+
+// FindOrgLimits checks to see if the authorizer on context has read access to the organization.
+func (s *OrgLimitsService) FindOrgLimits(ctx context.Context, orgID influxdb.ID) (*influxdb.OrgLimits, error) {
+	if err := authorizeReadOrg(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	return s.s.FindOrgLimits(ctx, orgID)
+}
+
+// UpdateOrgLimits checks to see if the authorizer on context has write access to all organizations,
+// so that the members of an organization cannot raise its limits.
+func (s *OrgLimitsService) UpdateOrgLimits(ctx context.Context, orgID influxdb.ID, upd influxdb.OrgLimitsUpdate) (l *influxdb.OrgLimits, err error) {
+	defer func() {
+		s.Record(ctx, influxdb.UpdateAuditAction, influxdb.OrgsResourceType, orgID, orgID, &err)
+	}()
+
+	p, err := influxdb.NewGlobalPermission(influxdb.WriteAction, influxdb.OrgsResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := IsAllowed(ctx, *p); err != nil {
+		return nil, err
+	}
+
+	return s.s.UpdateOrgLimits(ctx, orgID, upd)
+}
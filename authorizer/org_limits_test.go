@@ -0,0 +1,134 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestOrgLimitsService_FindOrgLimits(t *testing.T) {
+	type args struct {
+		permission influxdb.Permission
+		org        influxdb.ID
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to read limits of org",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.OrgsResourceType,
+						ID:   influxdbtesting.IDPtr(10),
+					},
+				},
+				org: influxdb.ID(10),
+			},
+		},
+		{
+			name: "unauthorized to read limits of org",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "read",
+					Resource: influxdb.Resource{
+						Type: influxdb.OrgsResourceType,
+						ID:   influxdbtesting.IDPtr(10),
+					},
+				},
+				org: influxdb.ID(2),
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "read:orgs/0000000000000002 is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewOrgLimitsService(mock.NewOrgLimitsService())
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			_, err := s.FindOrgLimits(ctx, tt.args.org)
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
+
+func TestOrgLimitsService_UpdateOrgLimits(t *testing.T) {
+	type args struct {
+		permission influxdb.Permission
+		org        influxdb.ID
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "authorized to write all orgs",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type: influxdb.OrgsResourceType,
+					},
+				},
+				org: influxdb.ID(10),
+			},
+		},
+		{
+			name: "unauthorized with write permission on the org",
+			args: args{
+				permission: influxdb.Permission{
+					Action: "write",
+					Resource: influxdb.Resource{
+						Type: influxdb.OrgsResourceType,
+						ID:   influxdbtesting.IDPtr(10),
+					},
+				},
+				org: influxdb.ID(10),
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "write:orgs is unauthorized",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewOrgLimitsService(mock.NewOrgLimitsService())
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, &Authorizer{[]influxdb.Permission{tt.args.permission}})
+
+			buckets := 1
+			_, err := s.UpdateOrgLimits(ctx, tt.args.org, influxdb.OrgLimitsUpdate{MaxBuckets: &buckets})
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+		})
+	}
+}
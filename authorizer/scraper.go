@@ -11,6 +11,8 @@ var _ influxdb.ScraperTargetStoreService = (*ScraperTargetStoreService)(nil)
 // ScraperTargetStoreService wraps a influxdb.ScraperTargetStoreService and authorizes actions
 // against it appropriately.
 type ScraperTargetStoreService struct {
+	Auditor
+
 	influxdb.UserResourceMappingService
 	s influxdb.ScraperTargetStoreService
 }
@@ -53,6 +55,16 @@ func authorizeWriteScraper(ctx context.Context, orgID, id influxdb.ID) error {
 	return nil
 }
 
+// authorizeTargetSecrets checks that the authorizer on context may read the
+// secrets of the organization if the target refers to any of them, since its
+// scrapes send them to the URL of the target.
+func authorizeTargetSecrets(ctx context.Context, orgID influxdb.ID, st *influxdb.ScraperTarget) error {
+	if st.PasswordSecret == "" && st.BearerTokenSecret == "" && (st.TLS == nil || st.TLS.ClientKeySecret == "") {
+		return nil
+	}
+	return authorizeReadSecret(ctx, orgID)
+}
+
 // GetTargetByID checks to see if the authorizer on context has read access to the id provided.
 func (s *ScraperTargetStoreService) GetTargetByID(ctx context.Context, id influxdb.ID) (*influxdb.ScraperTarget, error) {
 	st, err := s.s.GetTargetByID(ctx, id)
@@ -95,8 +107,11 @@ func (s *ScraperTargetStoreService) ListTargets(ctx context.Context) ([]influxdb
 	return scrapers, nil
 }
 
-// AddTarget checks to see if the authorizer on context has write access to the global scraper target resource.
-func (s *ScraperTargetStoreService) AddTarget(ctx context.Context, st *influxdb.ScraperTarget, userID influxdb.ID) error {
+// AddTarget checks to see if the authorizer on context has write access to the global scraper target resource,
+// and read access to the secrets of the organization if the target refers to any.
+func (s *ScraperTargetStoreService) AddTarget(ctx context.Context, st *influxdb.ScraperTarget, userID influxdb.ID) (err error) {
+	defer func() { s.Record(ctx, influxdb.CreateAuditAction, influxdb.ScraperResourceType, st.OrgID, st.ID, &err) }()
+
 	p, err := influxdb.NewPermission(influxdb.WriteAction, influxdb.ScraperResourceType, st.OrgID)
 	if err != nil {
 		return err
@@ -106,29 +121,50 @@ func (s *ScraperTargetStoreService) AddTarget(ctx context.Context, st *influxdb.
 		return err
 	}
 
+	if err := authorizeTargetSecrets(ctx, st.OrgID, st); err != nil {
+		return err
+	}
+
 	return s.s.AddTarget(ctx, st, userID)
 }
 
-// UpdateTarget checks to see if the authorizer on context has write access to the scraper target provided.
-func (s *ScraperTargetStoreService) UpdateTarget(ctx context.Context, upd *influxdb.ScraperTarget, userID influxdb.ID) (*influxdb.ScraperTarget, error) {
+// UpdateTarget checks to see if the authorizer on context has write access to the scraper target provided,
+// and read access to the secrets of the organization if the update refers to any.
+func (s *ScraperTargetStoreService) UpdateTarget(ctx context.Context, upd *influxdb.ScraperTarget, userID influxdb.ID) (_ *influxdb.ScraperTarget, err error) {
+	var orgID influxdb.ID
+	defer func() { s.Record(ctx, influxdb.UpdateAuditAction, influxdb.ScraperResourceType, orgID, upd.ID, &err) }()
+
 	st, err := s.s.GetTargetByID(ctx, upd.ID)
 	if err != nil {
 		return nil, err
 	}
+	orgID = st.OrgID
 
 	if err := authorizeWriteScraper(ctx, st.OrgID, upd.ID); err != nil {
 		return nil, err
 	}
 
+	secretsOrgID := st.OrgID
+	if upd.OrgID.Valid() {
+		secretsOrgID = upd.OrgID
+	}
+	if err := authorizeTargetSecrets(ctx, secretsOrgID, upd); err != nil {
+		return nil, err
+	}
+
 	return s.s.UpdateTarget(ctx, upd, userID)
 }
 
 // RemoveTarget checks to see if the authorizer on context has write access to the scraper target provided.
-func (s *ScraperTargetStoreService) RemoveTarget(ctx context.Context, id influxdb.ID) error {
+func (s *ScraperTargetStoreService) RemoveTarget(ctx context.Context, id influxdb.ID) (err error) {
+	var orgID influxdb.ID
+	defer func() { s.Record(ctx, influxdb.DeleteAuditAction, influxdb.ScraperResourceType, orgID, id, &err) }()
+
 	st, err := s.s.GetTargetByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	orgID = st.OrgID
 
 	if err := authorizeWriteScraper(ctx, st.OrgID, id); err != nil {
 		return err
@@ -540,3 +540,89 @@ func TestScraperTargetStoreService_AddTarget(t *testing.T) {
 		})
 	}
 }
+
+func TestScraperTargetStoreService_TargetSecrets(t *testing.T) {
+	var (
+		write = influxdb.Permission{
+			Action: "write",
+			Resource: influxdb.Resource{
+				Type:  influxdb.ScraperResourceType,
+				OrgID: influxdbtesting.IDPtr(10),
+			},
+		}
+		readSecrets = influxdb.Permission{
+			Action: "read",
+			Resource: influxdb.Resource{
+				Type:  influxdb.SecretsResourceType,
+				OrgID: influxdbtesting.IDPtr(10),
+			},
+		}
+		unauthorized = &influxdb.Error{
+			Msg:  "read:orgs/000000000000000a/secrets is unauthorized",
+			Code: influxdb.EUnauthorized,
+		}
+	)
+
+	tests := []struct {
+		name        string
+		target      influxdb.ScraperTarget
+		permissions []influxdb.Permission
+		err         error
+	}{
+		{
+			name:        "target without secrets",
+			target:      influxdb.ScraperTarget{Username: "user"},
+			permissions: []influxdb.Permission{write},
+		},
+		{
+			name:        "password secret without access to secrets",
+			target:      influxdb.ScraperTarget{Username: "user", PasswordSecret: "password"},
+			permissions: []influxdb.Permission{write},
+			err:         unauthorized,
+		},
+		{
+			name:        "bearer token secret without access to secrets",
+			target:      influxdb.ScraperTarget{BearerTokenSecret: "token"},
+			permissions: []influxdb.Permission{write},
+			err:         unauthorized,
+		},
+		{
+			name:        "client key secret without access to secrets",
+			target:      influxdb.ScraperTarget{TLS: &influxdb.ScraperTLSConfig{ClientKeySecret: "key"}},
+			permissions: []influxdb.Permission{write},
+			err:         unauthorized,
+		},
+		{
+			name:        "secrets with access to secrets",
+			target:      influxdb.ScraperTarget{BearerTokenSecret: "token"},
+			permissions: []influxdb.Permission{write, readSecrets},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewScraperTargetStoreService(&mock.ScraperTargetStoreService{
+				AddTargetF: func(ctx context.Context, st *influxdb.ScraperTarget, userID influxdb.ID) error {
+					return nil
+				},
+				GetTargetByIDF: func(ctx context.Context, id influxdb.ID) (*influxdb.ScraperTarget, error) {
+					return &influxdb.ScraperTarget{ID: id, OrgID: 10}, nil
+				},
+				UpdateTargetF: func(ctx context.Context, upd *influxdb.ScraperTarget, userID influxdb.ID) (*influxdb.ScraperTarget, error) {
+					return upd, nil
+				},
+			}, mock.NewUserResourceMappingService())
+			ctx := influxdbcontext.SetAuthorizer(context.Background(), &Authorizer{tt.permissions})
+
+			st := tt.target
+			st.OrgID = 10
+			err := s.AddTarget(ctx, &st, influxdb.ID(1))
+			influxdbtesting.ErrorsEqual(t, err, tt.err)
+
+			upd := tt.target
+			upd.ID = 1
+			_, err = s.UpdateTarget(ctx, &upd, influxdb.ID(1))
+			influxdbtesting.ErrorsEqual(t, err, tt.err)
+		})
+	}
+}
@@ -11,6 +11,8 @@ var _ influxdb.SecretService = (*SecretService)(nil)
 // SecretService wraps a influxdb.SecretService and authorizes actions
 // against it appropriately.
 type SecretService struct {
+	Auditor
+
 	s influxdb.SecretService
 }
 
@@ -80,12 +82,16 @@ func (s *SecretService) GetSecretKeys(ctx context.Context, orgID influxdb.ID) ([
 }
 
 // PutSecret checks to see if the authorizer on context has write access to the secret key provided.
-func (s *SecretService) PutSecret(ctx context.Context, orgID influxdb.ID, key string, val string) error {
+func (s *SecretService) PutSecret(ctx context.Context, orgID influxdb.ID, key string, val string) (err error) {
+	defer func() {
+		s.Record(ctx, influxdb.UpdateAuditAction, influxdb.SecretsResourceType, orgID, influxdb.ID(0), &err)
+	}()
+
 	if err := authorizeWriteSecret(ctx, orgID); err != nil {
 		return err
 	}
 
-	err := s.s.PutSecret(ctx, orgID, key, val)
+	err = s.s.PutSecret(ctx, orgID, key, val)
 	if err != nil {
 		return err
 	}
@@ -94,7 +100,11 @@ func (s *SecretService) PutSecret(ctx context.Context, orgID influxdb.ID, key st
 }
 
 // PutSecrets checks to see if the authorizer on context has read and write access to the secret keys provided.
-func (s *SecretService) PutSecrets(ctx context.Context, orgID influxdb.ID, m map[string]string) error {
+func (s *SecretService) PutSecrets(ctx context.Context, orgID influxdb.ID, m map[string]string) (err error) {
+	defer func() {
+		s.Record(ctx, influxdb.UpdateAuditAction, influxdb.SecretsResourceType, orgID, influxdb.ID(0), &err)
+	}()
+
 	// PutSecrets operates on intersection betwen m and keys beloging to orgID.
 	// We need to have read access to those secrets since it deletes the secrets (within the intersection) that have not be overridden.
 	if err := authorizeReadSecret(ctx, orgID); err != nil {
@@ -105,7 +115,7 @@ func (s *SecretService) PutSecrets(ctx context.Context, orgID influxdb.ID, m map
 		return err
 	}
 
-	err := s.s.PutSecrets(ctx, orgID, m)
+	err = s.s.PutSecrets(ctx, orgID, m)
 	if err != nil {
 		return err
 	}
@@ -114,12 +124,16 @@ func (s *SecretService) PutSecrets(ctx context.Context, orgID influxdb.ID, m map
 }
 
 // PatchSecrets checks to see if the authorizer on context has write access to the secret keys provided.
-func (s *SecretService) PatchSecrets(ctx context.Context, orgID influxdb.ID, m map[string]string) error {
+func (s *SecretService) PatchSecrets(ctx context.Context, orgID influxdb.ID, m map[string]string) (err error) {
+	defer func() {
+		s.Record(ctx, influxdb.UpdateAuditAction, influxdb.SecretsResourceType, orgID, influxdb.ID(0), &err)
+	}()
+
 	if err := authorizeWriteSecret(ctx, orgID); err != nil {
 		return err
 	}
 
-	err := s.s.PatchSecrets(ctx, orgID, m)
+	err = s.s.PatchSecrets(ctx, orgID, m)
 	if err != nil {
 		return err
 	}
@@ -128,12 +142,16 @@ func (s *SecretService) PatchSecrets(ctx context.Context, orgID influxdb.ID, m m
 }
 
 // DeleteSecret checks to see if the authorizer on context has write access to the secret keys provided.
-func (s *SecretService) DeleteSecret(ctx context.Context, orgID influxdb.ID, keys ...string) error {
+func (s *SecretService) DeleteSecret(ctx context.Context, orgID influxdb.ID, keys ...string) (err error) {
+	defer func() {
+		s.Record(ctx, influxdb.DeleteAuditAction, influxdb.SecretsResourceType, orgID, influxdb.ID(0), &err)
+	}()
+
 	if err := authorizeWriteSecret(ctx, orgID); err != nil {
 		return err
 	}
 
-	err := s.s.DeleteSecret(ctx, orgID, keys...)
+	err = s.s.DeleteSecret(ctx, orgID, keys...)
 	if err != nil {
 		return err
 	}
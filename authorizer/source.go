@@ -12,6 +12,8 @@ var _ influxdb.SourceService = (*SourceService)(nil)
 // SourceService wraps a influxdb.SourceService and authorizes actions
 // against it appropriately.
 type SourceService struct {
+	Auditor
+
 	s influxdb.SourceService
 }
 
@@ -115,7 +117,11 @@ func (s *SourceService) FindSources(ctx context.Context, opts influxdb.FindOptio
 }
 
 // CreateSource checks to see if the authorizer on context has write access to the global source resource.
-func (s *SourceService) CreateSource(ctx context.Context, src *influxdb.Source) error {
+func (s *SourceService) CreateSource(ctx context.Context, src *influxdb.Source) (err error) {
+	defer func() {
+		s.Record(ctx, influxdb.CreateAuditAction, influxdb.SourcesResourceType, src.OrganizationID, src.ID, &err)
+	}()
+
 	p, err := influxdb.NewPermission(influxdb.WriteAction, influxdb.SourcesResourceType, src.OrganizationID)
 	if err != nil {
 		return err
@@ -129,11 +135,15 @@ func (s *SourceService) CreateSource(ctx context.Context, src *influxdb.Source)
 }
 
 // UpdateSource checks to see if the authorizer on context has write access to the source provided.
-func (s *SourceService) UpdateSource(ctx context.Context, id influxdb.ID, upd influxdb.SourceUpdate) (*influxdb.Source, error) {
+func (s *SourceService) UpdateSource(ctx context.Context, id influxdb.ID, upd influxdb.SourceUpdate) (_ *influxdb.Source, err error) {
+	var orgID influxdb.ID
+	defer func() { s.Record(ctx, influxdb.UpdateAuditAction, influxdb.SourcesResourceType, orgID, id, &err) }()
+
 	src, err := s.s.FindSourceByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	orgID = src.OrganizationID
 
 	if err := authorizeWriteSource(ctx, src.OrganizationID, id); err != nil {
 		return nil, err
@@ -143,11 +153,15 @@ func (s *SourceService) UpdateSource(ctx context.Context, id influxdb.ID, upd in
 }
 
 // DeleteSource checks to see if the authorizer on context has write access to the source provided.
-func (s *SourceService) DeleteSource(ctx context.Context, id influxdb.ID) error {
+func (s *SourceService) DeleteSource(ctx context.Context, id influxdb.ID) (err error) {
+	var orgID influxdb.ID
+	defer func() { s.Record(ctx, influxdb.DeleteAuditAction, influxdb.SourcesResourceType, orgID, id, &err) }()
+
 	m, err := s.s.FindSourceByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	orgID = m.OrganizationID
 
 	if err := authorizeWriteSource(ctx, m.OrganizationID, id); err != nil {
 		return err
@@ -11,6 +11,8 @@ var _ influxdb.TelegrafConfigStore = (*TelegrafConfigService)(nil)
 // TelegrafConfigService wraps a influxdb.TelegrafConfigStore and authorizes actions
 // against it appropriately.
 type TelegrafConfigService struct {
+	Auditor
+
 	s influxdb.TelegrafConfigStore
 	influxdb.UserResourceMappingService
 }
@@ -96,7 +98,11 @@ func (s *TelegrafConfigService) FindTelegrafConfigs(ctx context.Context, filter
 }
 
 // CreateTelegrafConfig checks to see if the authorizer on context has write access to the global telegraf config resource.
-func (s *TelegrafConfigService) CreateTelegrafConfig(ctx context.Context, tc *influxdb.TelegrafConfig, userID influxdb.ID) error {
+func (s *TelegrafConfigService) CreateTelegrafConfig(ctx context.Context, tc *influxdb.TelegrafConfig, userID influxdb.ID) (err error) {
+	defer func() {
+		s.Record(ctx, influxdb.CreateAuditAction, influxdb.TelegrafsResourceType, tc.OrganizationID, tc.ID, &err)
+	}()
+
 	p, err := influxdb.NewPermission(influxdb.WriteAction, influxdb.TelegrafsResourceType, tc.OrganizationID)
 	if err != nil {
 		return err
@@ -110,11 +116,15 @@ func (s *TelegrafConfigService) CreateTelegrafConfig(ctx context.Context, tc *in
 }
 
 // UpdateTelegrafConfig checks to see if the authorizer on context has write access to the telegraf config provided.
-func (s *TelegrafConfigService) UpdateTelegrafConfig(ctx context.Context, id influxdb.ID, upd *influxdb.TelegrafConfig, userID influxdb.ID) (*influxdb.TelegrafConfig, error) {
+func (s *TelegrafConfigService) UpdateTelegrafConfig(ctx context.Context, id influxdb.ID, upd *influxdb.TelegrafConfig, userID influxdb.ID) (_ *influxdb.TelegrafConfig, err error) {
+	var orgID influxdb.ID
+	defer func() { s.Record(ctx, influxdb.UpdateAuditAction, influxdb.TelegrafsResourceType, orgID, id, &err) }()
+
 	tc, err := s.FindTelegrafConfigByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	orgID = tc.OrganizationID
 
 	if err := authorizeWriteTelegraf(ctx, tc.OrganizationID, id); err != nil {
 		return nil, err
@@ -124,11 +134,15 @@ func (s *TelegrafConfigService) UpdateTelegrafConfig(ctx context.Context, id inf
 }
 
 // DeleteTelegrafConfig checks to see if the authorizer on context has write access to the telegraf config provided.
-func (s *TelegrafConfigService) DeleteTelegrafConfig(ctx context.Context, id influxdb.ID) error {
+func (s *TelegrafConfigService) DeleteTelegrafConfig(ctx context.Context, id influxdb.ID) (err error) {
+	var orgID influxdb.ID
+	defer func() { s.Record(ctx, influxdb.DeleteAuditAction, influxdb.TelegrafsResourceType, orgID, id, &err) }()
+
 	tc, err := s.FindTelegrafConfigByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	orgID = tc.OrganizationID
 
 	if err := authorizeWriteTelegraf(ctx, tc.OrganizationID, id); err != nil {
 		return err
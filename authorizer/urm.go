@@ -11,6 +11,8 @@ type OrganizationService interface {
 }
 
 type URMService struct {
+	Auditor
+
 	s          influxdb.UserResourceMappingService
 	orgService OrganizationService
 }
@@ -75,8 +77,11 @@ func (s *URMService) FindUserResourceMappings(ctx context.Context, filter influx
 	return mappings, len(mappings), nil
 }
 
-func (s *URMService) CreateUserResourceMapping(ctx context.Context, m *influxdb.UserResourceMapping) error {
-	orgID, err := s.orgService.FindResourceOrganizationID(ctx, m.ResourceType, m.ResourceID)
+func (s *URMService) CreateUserResourceMapping(ctx context.Context, m *influxdb.UserResourceMapping) (err error) {
+	var orgID influxdb.ID
+	defer func() { s.Record(ctx, influxdb.UpdateAuditAction, m.ResourceType, orgID, m.ResourceID, &err) }()
+
+	orgID, err = s.orgService.FindResourceOrganizationID(ctx, m.ResourceType, m.ResourceID)
 	if err != nil {
 		return err
 	}
@@ -96,19 +101,26 @@ func (s *URMService) DeleteUserResourceMapping(ctx context.Context, resourceID i
 	}
 
 	for _, urm := range urms {
-		orgID, err := s.orgService.FindResourceOrganizationID(ctx, urm.ResourceType, urm.ResourceID)
-		if err != nil {
+		if err := s.deleteUserResourceMapping(ctx, urm); err != nil {
 			return err
 		}
+	}
 
-		if err := authorizeWriteURM(ctx, urm.ResourceType, orgID, urm.ResourceID); err != nil {
-			return err
-		}
+	return nil
+}
 
-		if err := s.s.DeleteUserResourceMapping(ctx, urm.ResourceID, urm.UserID); err != nil {
-			return err
-		}
+func (s *URMService) deleteUserResourceMapping(ctx context.Context, urm *influxdb.UserResourceMapping) (err error) {
+	var orgID influxdb.ID
+	defer func() { s.Record(ctx, influxdb.UpdateAuditAction, urm.ResourceType, orgID, urm.ResourceID, &err) }()
+
+	orgID, err = s.orgService.FindResourceOrganizationID(ctx, urm.ResourceType, urm.ResourceID)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	if err := authorizeWriteURM(ctx, urm.ResourceType, orgID, urm.ResourceID); err != nil {
+		return err
+	}
+
+	return s.s.DeleteUserResourceMapping(ctx, urm.ResourceID, urm.UserID)
 }
@@ -0,0 +1,47 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.UsageService = (*UsageService)(nil)
+
+// UsageService wraps a influxdb.UsageService and authorizes actions
+// against it appropriately.
+type UsageService struct {
+	s influxdb.UsageService
+}
+
+// NewUsageService constructs an instance of an authorizing usage service.
+func NewUsageService(s influxdb.UsageService) *UsageService {
+	return &UsageService{
+		s: s,
+	}
+}
+
+// GetUsage checks to see if the authorizer on context has read access to the bucket or organization
+// of the filter, or to all organizations if the usage of every organization is requested.
+func (s *UsageService) GetUsage(ctx context.Context, filter influxdb.UsageFilter) (map[influxdb.UsageMetric]*influxdb.Usage, error) {
+	switch {
+	case filter.OrgID != nil && filter.BucketID != nil:
+		if err := authorizeReadBucket(ctx, *filter.OrgID, *filter.BucketID); err != nil {
+			return nil, err
+		}
+	case filter.OrgID != nil:
+		if err := authorizeReadOrg(ctx, *filter.OrgID); err != nil {
+			return nil, err
+		}
+	default:
+		p, err := influxdb.NewGlobalPermission(influxdb.ReadAction, influxdb.OrgsResourceType)
+		if err != nil {
+			return nil, err
+		}
+		if err := IsAllowed(ctx, *p); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.s.GetUsage(ctx, filter)
+}
@@ -11,6 +11,8 @@ var _ influxdb.UserService = (*UserService)(nil)
 // UserService wraps a influxdb.UserService and authorizes actions
 // against it appropriately.
 type UserService struct {
+	Auditor
+
 	s influxdb.UserService
 }
 
@@ -111,7 +113,11 @@ func (s *UserService) FindUsers(ctx context.Context, filter influxdb.UserFilter,
 }
 
 // CreateUser checks to see if the authorizer on context has write access to the global users resource.
-func (s *UserService) CreateUser(ctx context.Context, o *influxdb.User) error {
+func (s *UserService) CreateUser(ctx context.Context, o *influxdb.User) (err error) {
+	defer func() {
+		s.Record(ctx, influxdb.CreateAuditAction, influxdb.UsersResourceType, influxdb.ID(0), o.ID, &err)
+	}()
+
 	p, err := influxdb.NewGlobalPermission(influxdb.WriteAction, influxdb.UsersResourceType)
 	if err != nil {
 		return err
@@ -125,7 +131,11 @@ func (s *UserService) CreateUser(ctx context.Context, o *influxdb.User) error {
 }
 
 // UpdateUser checks to see if the authorizer on context has write access to the user provided.
-func (s *UserService) UpdateUser(ctx context.Context, id influxdb.ID, upd influxdb.UserUpdate) (*influxdb.User, error) {
+func (s *UserService) UpdateUser(ctx context.Context, id influxdb.ID, upd influxdb.UserUpdate) (_ *influxdb.User, err error) {
+	defer func() {
+		s.Record(ctx, influxdb.UpdateAuditAction, influxdb.UsersResourceType, influxdb.ID(0), id, &err)
+	}()
+
 	if err := authorizeWriteUser(ctx, id); err != nil {
 		return nil, err
 	}
@@ -134,7 +144,11 @@ func (s *UserService) UpdateUser(ctx context.Context, id influxdb.ID, upd influx
 }
 
 // DeleteUser checks to see if the authorizer on context has write access to the user provided.
-func (s *UserService) DeleteUser(ctx context.Context, id influxdb.ID) error {
+func (s *UserService) DeleteUser(ctx context.Context, id influxdb.ID) (err error) {
+	defer func() {
+		s.Record(ctx, influxdb.DeleteAuditAction, influxdb.UsersResourceType, influxdb.ID(0), id, &err)
+	}()
+
 	if err := authorizeWriteUser(ctx, id); err != nil {
 		return err
 	}
@@ -11,6 +11,8 @@ var _ influxdb.VariableService = (*VariableService)(nil)
 // VariableService wraps a influxdb.VariableService and authorizes actions
 // against it appropriately.
 type VariableService struct {
+	Auditor
+
 	s influxdb.VariableService
 }
 
@@ -93,7 +95,11 @@ func (s *VariableService) FindVariables(ctx context.Context, filter influxdb.Var
 }
 
 // CreateVariable checks to see if the authorizer on context has write access to the global variable resource.
-func (s *VariableService) CreateVariable(ctx context.Context, m *influxdb.Variable) error {
+func (s *VariableService) CreateVariable(ctx context.Context, m *influxdb.Variable) (err error) {
+	defer func() {
+		s.Record(ctx, influxdb.CreateAuditAction, influxdb.VariablesResourceType, m.OrganizationID, m.ID, &err)
+	}()
+
 	p, err := influxdb.NewPermission(influxdb.WriteAction, influxdb.VariablesResourceType, m.OrganizationID)
 	if err != nil {
 		return err
@@ -107,11 +113,15 @@ func (s *VariableService) CreateVariable(ctx context.Context, m *influxdb.Variab
 }
 
 // UpdateVariable checks to see if the authorizer on context has write access to the variable provided.
-func (s *VariableService) UpdateVariable(ctx context.Context, id influxdb.ID, upd *influxdb.VariableUpdate) (*influxdb.Variable, error) {
+func (s *VariableService) UpdateVariable(ctx context.Context, id influxdb.ID, upd *influxdb.VariableUpdate) (_ *influxdb.Variable, err error) {
+	var orgID influxdb.ID
+	defer func() { s.Record(ctx, influxdb.UpdateAuditAction, influxdb.VariablesResourceType, orgID, id, &err) }()
+
 	m, err := s.FindVariableByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	orgID = m.OrganizationID
 
 	if err := authorizeWriteVariable(ctx, m.OrganizationID, id); err != nil {
 		return nil, err
@@ -121,13 +131,17 @@ func (s *VariableService) UpdateVariable(ctx context.Context, id influxdb.ID, up
 }
 
 // ReplaceVariable checks to see if the authorizer on context has write access to the variable provided.
-func (s *VariableService) ReplaceVariable(ctx context.Context, m *influxdb.Variable) error {
-	m, err := s.FindVariableByID(ctx, m.ID)
+func (s *VariableService) ReplaceVariable(ctx context.Context, m *influxdb.Variable) (err error) {
+	var orgID influxdb.ID
+	defer func() { s.Record(ctx, influxdb.UpdateAuditAction, influxdb.VariablesResourceType, orgID, m.ID, &err) }()
+
+	v, err := s.FindVariableByID(ctx, m.ID)
 	if err != nil {
 		return err
 	}
+	orgID = v.OrganizationID
 
-	if err := authorizeWriteVariable(ctx, m.OrganizationID, m.ID); err != nil {
+	if err := authorizeWriteVariable(ctx, v.OrganizationID, m.ID); err != nil {
 		return err
 	}
 
@@ -135,11 +149,15 @@ func (s *VariableService) ReplaceVariable(ctx context.Context, m *influxdb.Varia
 }
 
 // DeleteVariable checks to see if the authorizer on context has write access to the variable provided.
-func (s *VariableService) DeleteVariable(ctx context.Context, id influxdb.ID) error {
+func (s *VariableService) DeleteVariable(ctx context.Context, id influxdb.ID) (err error) {
+	var orgID influxdb.ID
+	defer func() { s.Record(ctx, influxdb.DeleteAuditAction, influxdb.VariablesResourceType, orgID, id, &err) }()
+
 	m, err := s.FindVariableByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	orgID = m.OrganizationID
 
 	if err := authorizeWriteVariable(ctx, m.OrganizationID, id); err != nil {
 		return err
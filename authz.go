@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 )
 
 var (
@@ -40,6 +41,41 @@ func PermissionAllowed(perm Permission, ps []Permission) bool {
 	return false
 }
 
+// PermissionPredicate returns the predicate restricting the series of a bucket
+// that perm is allowed by ps. The predicates of the permissions matching perm
+// are ORed, and the predicate is empty if any of them is not restricted. As
+// the predicate is also empty if no permission matches, callers check that the
+// permission is allowed first.
+func PermissionPredicate(perm Permission, ps []Permission) string {
+	var preds []string
+	for _, p := range ps {
+		if !p.Matches(perm) {
+			continue
+		}
+		if p.Predicate == "" {
+			return ""
+		}
+		preds = append(preds, p.Predicate)
+	}
+
+	if len(preds) == 1 {
+		return preds[0]
+	}
+	for i, pred := range preds {
+		preds[i] = "(" + pred + ")"
+	}
+	return strings.Join(preds, " OR ")
+}
+
+// AuthorizerPredicate returns the predicate restricting the series of a bucket
+// that the authorizer is allowed perm on. Only authorizations are restricted.
+func AuthorizerPredicate(a Authorizer, perm Permission) string {
+	if auth, ok := a.(*Authorization); ok {
+		return auth.Predicate(perm)
+	}
+	return ""
+}
+
 // Action is an enum defining all possible resource operations
 type Action string
 
@@ -121,35 +157,43 @@ const (
 	LabelsResourceType = ResourceType("labels") // 11
 	// ViewsResourceType gives permission to one or more views.
 	ViewsResourceType = ResourceType("views") // 12
+	// ChecksResourceType gives permission to one or more checks.
+	ChecksResourceType = ResourceType("checks") // 13
+	// NotificationEndpointsResourceType gives permission to one or more notification endpoints.
+	NotificationEndpointsResourceType = ResourceType("notificationEndpoints") // 14
 )
 
 // AllResourceTypes is the list of all known resource types.
 var AllResourceTypes = []ResourceType{
-	AuthorizationsResourceType, // 0
-	BucketsResourceType,        // 1
-	DashboardsResourceType,     // 2
-	OrgsResourceType,           // 3
-	SourcesResourceType,        // 4
-	TasksResourceType,          // 5
-	TelegrafsResourceType,      // 6
-	UsersResourceType,          // 7
-	VariablesResourceType,      // 8
-	ScraperResourceType,        // 9
-	SecretsResourceType,        // 10
-	LabelsResourceType,         // 11
-	ViewsResourceType,          // 12
+	AuthorizationsResourceType,        // 0
+	BucketsResourceType,               // 1
+	DashboardsResourceType,            // 2
+	OrgsResourceType,                  // 3
+	SourcesResourceType,               // 4
+	TasksResourceType,                 // 5
+	TelegrafsResourceType,             // 6
+	UsersResourceType,                 // 7
+	VariablesResourceType,             // 8
+	ScraperResourceType,               // 9
+	SecretsResourceType,               // 10
+	LabelsResourceType,                // 11
+	ViewsResourceType,                 // 12
+	ChecksResourceType,                // 13
+	NotificationEndpointsResourceType, // 14
 }
 
 // OrgResourceTypes is the list of all known resource types that belong to an organization.
 var OrgResourceTypes = []ResourceType{
-	BucketsResourceType,    // 1
-	DashboardsResourceType, // 2
-	SourcesResourceType,    // 4
-	TasksResourceType,      // 5
-	TelegrafsResourceType,  // 6
-	UsersResourceType,      // 7
-	VariablesResourceType,  // 8
-	SecretsResourceType,    // 10
+	BucketsResourceType,               // 1
+	DashboardsResourceType,            // 2
+	SourcesResourceType,               // 4
+	TasksResourceType,                 // 5
+	TelegrafsResourceType,             // 6
+	UsersResourceType,                 // 7
+	VariablesResourceType,             // 8
+	SecretsResourceType,               // 10
+	ChecksResourceType,                // 13
+	NotificationEndpointsResourceType, // 14
 }
 
 // Valid checks if the resource type is a member of the ResourceType enum.
@@ -173,6 +217,8 @@ func (t ResourceType) Valid() (err error) {
 	case SecretsResourceType: // 10
 	case LabelsResourceType: // 11
 	case ViewsResourceType: // 12
+	case ChecksResourceType: // 13
+	case NotificationEndpointsResourceType: // 14
 	default:
 		err = ErrInvalidResourceType
 	}
@@ -184,6 +230,11 @@ func (t ResourceType) Valid() (err error) {
 type Permission struct {
 	Action   Action   `json:"action"`
 	Resource Resource `json:"resource"`
+
+	// Predicate optionally restricts a bucket permission to the series that
+	// match it, such as _measurement=~/^public_/. Its syntax is the one of the
+	// predicates of deletes. The permission applies to all series if it is empty.
+	Predicate string `json:"predicate,omitempty"`
 }
 
 // Matches returns whether or not one permission matches the other.
@@ -224,6 +275,9 @@ func (p Permission) Matches(perm Permission) bool {
 }
 
 func (p Permission) String() string {
+	if p.Predicate != "" {
+		return fmt.Sprintf("%s:%s where %s", p.Action, p.Resource, p.Predicate)
+	}
 	return fmt.Sprintf("%s:%s", p.Action, p.Resource)
 }
 
@@ -261,6 +315,13 @@ func (p *Permission) Valid() error {
 		}
 	}
 
+	if p.Predicate != "" && p.Resource.Type != BucketsResourceType {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "predicates are only supported on bucket permissions",
+		}
+	}
+
 	return nil
 }
 
@@ -329,7 +390,7 @@ func OwnerPermissions(orgID ID) []Permission {
 
 	// TODO(desa): this is likely just a thing for the alpha. We'll likely want a limited number of users about to
 	// create organizations. https://github.com/influxdata/influxdb/issues/11344
-	ps = append(ps, Permission{Action: WriteAction, Resource: Resource{Type: OrgsResourceType}}, Permission{ReadAction, Resource{Type: OrgsResourceType}})
+	ps = append(ps, Permission{Action: WriteAction, Resource: Resource{Type: OrgsResourceType}}, Permission{Action: ReadAction, Resource: Resource{Type: OrgsResourceType}})
 
 	return ps
 }
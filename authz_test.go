@@ -243,8 +243,9 @@ func TestAuthorizer_PermissionAllowed(t *testing.T) {
 
 func TestPermission_Valid(t *testing.T) {
 	type fields struct {
-		Action   platform.Action
-		Resource platform.Resource
+		Action    platform.Action
+		Resource  platform.Resource
+		Predicate string
 	}
 	tests := []struct {
 		name    string
@@ -302,12 +303,37 @@ func TestPermission_Valid(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid bucket permission with a predicate",
+			fields: fields{
+				Action: platform.ReadAction,
+				Resource: platform.Resource{
+					Type:  platform.BucketsResourceType,
+					ID:    validID(),
+					OrgID: influxdbtesting.IDPtr(1),
+				},
+				Predicate: `_measurement=~/^public_/`,
+			},
+		},
+		{
+			name: "invalid dashboard permission with a predicate",
+			fields: fields{
+				Action: platform.ReadAction,
+				Resource: platform.Resource{
+					Type:  platform.DashboardsResourceType,
+					OrgID: influxdbtesting.IDPtr(1),
+				},
+				Predicate: `_measurement=~/^public_/`,
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := &platform.Permission{
-				Action:   tt.fields.Action,
-				Resource: tt.fields.Resource,
+				Action:    tt.fields.Action,
+				Resource:  tt.fields.Resource,
+				Predicate: tt.fields.Predicate,
 			}
 			if err := p.Valid(); (err != nil) != tt.wantErr {
 				t.Errorf("Permission.Valid() error = %v, wantErr %v", err, tt.wantErr)
@@ -431,6 +457,56 @@ func TestPermission_String(t *testing.T) {
 	}
 }
 
+func TestPermissionPredicate(t *testing.T) {
+	read := func(id platform.ID, predicate string) platform.Permission {
+		return platform.Permission{
+			Action: platform.ReadAction,
+			Resource: platform.Resource{
+				Type:  platform.BucketsResourceType,
+				OrgID: influxdbtesting.IDPtr(1),
+				ID:    &id,
+			},
+			Predicate: predicate,
+		}
+	}
+
+	tests := []struct {
+		name string
+		ps   []platform.Permission
+		want string
+	}{
+		{
+			name: "not restricted",
+			ps:   []platform.Permission{read(2, "")},
+		},
+		{
+			name: "restricted",
+			ps:   []platform.Permission{read(2, `_measurement=~/^public_/`)},
+			want: `_measurement=~/^public_/`,
+		},
+		{
+			name: "restricted twice",
+			ps:   []platform.Permission{read(2, `host="a"`), read(3, `host="c"`), read(2, `host="b" OR rack="r"`)},
+			want: `(host="a") OR (host="b" OR rack="r")`,
+		},
+		{
+			name: "restricted and not restricted",
+			ps:   []platform.Permission{read(2, `host="a"`), read(2, "")},
+		},
+		{
+			name: "restricted on another bucket",
+			ps:   []platform.Permission{read(3, `host="a"`), read(2, "")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := platform.PermissionPredicate(read(2, ""), tt.ps); got != tt.want {
+				t.Errorf("PermissionPredicate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func validID() *platform.ID {
 	id := platform.ID(100)
 	return &id
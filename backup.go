@@ -0,0 +1,47 @@
+package influxdb
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+const (
+	// BackupBoltFile is the name of the snapshot of the bolt metadata store in a backup.
+	BackupBoltFile = "influxd.bolt"
+
+	// BackupManifestFile is the name of the list of the files of the storage
+	// engine at the time of a backup, including those of earlier backups.
+	BackupManifestFile = "manifest.json"
+)
+
+// ops for backup error.
+var (
+	OpCreateBackup    = "CreateBackup"
+	OpFetchBackupFile = "FetchBackupFile"
+	OpRemoveBackup    = "RemoveBackup"
+)
+
+// BackupService represents the data backup functions of InfluxDB.
+type BackupService interface {
+	// CreateBackup creates a point-in-time copy of the data of the server.
+	// When since is not zero, only the files of the time series data modified
+	// after since are part of the backup. It returns the ID of the backup and
+	// the paths of its files, relative to the backup.
+	CreateBackup(ctx context.Context, since time.Time) (backupID int, backupFiles []string, err error)
+
+	// FetchBackupFile writes the contents of a file of a backup to w.
+	FetchBackupFile(ctx context.Context, backupID int, backupFile string, w io.Writer) error
+
+	// RemoveBackup removes a backup and its files.
+	RemoveBackup(ctx context.Context, backupID int) error
+
+	// InternalBackupPath returns the local directory of the files of a backup.
+	InternalBackupPath(backupID int) string
+}
+
+// KVBackupService represents the backup functions of the metadata store.
+type KVBackupService interface {
+	// Backup writes a consistent snapshot of the metadata store to w.
+	Backup(ctx context.Context, w io.Writer) error
+}
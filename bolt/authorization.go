@@ -3,6 +3,7 @@ package bolt
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/coreos/bbolt"
 	platform "github.com/influxdata/influxdb"
@@ -103,7 +104,21 @@ func (c *Client) findAuthorizationByToken(ctx context.Context, tx *bolt.Tx, n st
 			Err:  err,
 		}
 	}
-	return c.findAuthorizationByID(ctx, tx, id)
+
+	auth, pe := c.findAuthorizationByID(ctx, tx, id)
+	if pe != nil {
+		return nil, pe
+	}
+
+	// The token replaced by a rotation stays in the index until the next
+	// rotation, but is only a token of the authorization for the grace period.
+	if !auth.HasToken(n, c.time()) {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  "authorization not found",
+		}
+	}
+	return auth, nil
 }
 
 func filterAuthorizationsFn(filter platform.AuthorizationFilter) func(a *platform.Authorization) bool {
@@ -367,6 +382,13 @@ func (c *Client) deleteAuthorization(ctx context.Context, tx *bolt.Tx, id platfo
 			Err: err,
 		}
 	}
+	if a.PreviousToken != "" {
+		if err := tx.Bucket(authorizationIndex).Delete(authorizationIndexKey(a.PreviousToken)); err != nil {
+			return &platform.Error{
+				Err: err,
+			}
+		}
+	}
 	encodedID, err := id.Encode()
 	if err != nil {
 		return &platform.Error{
@@ -424,3 +446,71 @@ func (c *Client) updateAuthorization(ctx context.Context, tx *bolt.Tx, id platfo
 	}
 	return nil
 }
+
+// RotateAuthorization replaces the token of the authorization with a new one.
+// The token replaced is still found for the grace period, if any.
+func (c *Client) RotateAuthorization(ctx context.Context, id platform.ID, grace time.Duration) (*platform.Authorization, error) {
+	var a *platform.Authorization
+	err := c.db.Update(func(tx *bolt.Tx) error {
+		auth, pe := c.rotateAuthorization(ctx, tx, id, grace)
+		if pe != nil {
+			return &platform.Error{
+				Err: pe,
+				Op:  getOp(platform.OpRotateAuthorization),
+			}
+		}
+		a = auth
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (c *Client) rotateAuthorization(ctx context.Context, tx *bolt.Tx, id platform.ID, grace time.Duration) (*platform.Authorization, *platform.Error) {
+	a, pe := c.findAuthorizationByID(ctx, tx, id)
+	if pe != nil {
+		return nil, pe
+	}
+
+	idx := tx.Bucket(authorizationIndex)
+
+	// Only the token replaced by the last rotation is kept.
+	if a.PreviousToken != "" {
+		if err := idx.Delete(authorizationIndexKey(a.PreviousToken)); err != nil {
+			return nil, &platform.Error{
+				Err: err,
+			}
+		}
+	}
+
+	if grace > 0 {
+		expiresAt := c.time().Add(grace)
+		a.PreviousToken, a.PreviousTokenExpiresAt = a.Token, &expiresAt
+	} else {
+		if err := idx.Delete(authorizationIndexKey(a.Token)); err != nil {
+			return nil, &platform.Error{
+				Err: err,
+			}
+		}
+		a.PreviousToken, a.PreviousTokenExpiresAt = "", nil
+	}
+
+	token, err := c.TokenGenerator.Token()
+	if err != nil {
+		return nil, &platform.Error{
+			Err: err,
+		}
+	}
+	a.Token = token
+
+	if unique := c.uniqueAuthorizationToken(ctx, tx, a); !unique {
+		return nil, platform.ErrUnableToCreateToken
+	}
+
+	if pe := c.putAuthorization(ctx, tx, a); pe != nil {
+		return nil, pe
+	}
+	return a, nil
+}
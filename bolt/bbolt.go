@@ -3,6 +3,7 @@ package bolt
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"time"
@@ -181,6 +182,22 @@ func (c *Client) initialize(ctx context.Context) error {
 	return nil
 }
 
+// Backup writes a consistent snapshot of the bolt database to w, using a read
+// transaction so that writes may continue while the snapshot is written.
+func (c *Client) Backup(ctx context.Context, w io.Writer) error {
+	err := c.db.View(func(tx *bolt.Tx) error {
+		_, err := tx.WriteTo(w)
+		return err
+	})
+	if err != nil {
+		return &platform.Error{
+			Op:  getOp(platform.OpCreateBackup),
+			Err: err,
+		}
+	}
+	return nil
+}
+
 // Close the connection to the bolt database
 func (c *Client) Close() error {
 	if c.db != nil {
@@ -93,3 +93,34 @@ func NewTestKVStore() (*bolt.KVStore, func(), error) {
 
 	return s, close, nil
 }
+
+func TestClientBackup(t *testing.T) {
+	c, closeFn, err := NewTestClient()
+	if err != nil {
+		t.Fatalf("failed to create new bolt client: %v", err)
+	}
+	defer closeFn()
+
+	f, err := ioutil.TempFile("", "influxdata-platform-bolt-backup-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+
+	if err := c.Backup(context.Background(), f); err != nil {
+		t.Fatalf("unable to backup: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The snapshot is a bolt database of its own.
+	restored := bolt.NewClient()
+	restored.Path = f.Name()
+	if err := restored.Open(context.Background()); err != nil {
+		t.Fatalf("unable to open backup: %v", err)
+	}
+	if err := restored.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -322,6 +322,19 @@ func (c *Client) CreateBucket(ctx context.Context, b *platform.Bucket) error {
 	var err error
 	op := getOp(platform.OpCreateBucket)
 	return c.db.Update(func(tx *bolt.Tx) error {
+		if err := b.ValidSchema(); err != nil {
+			return &platform.Error{
+				Err: err,
+				Op:  op,
+			}
+		}
+		if err := b.ValidDownsampling(); err != nil {
+			return &platform.Error{
+				Err: err,
+				Op:  op,
+			}
+		}
+
 		if b.OrganizationID.Valid() {
 			_, pe := c.findOrganizationByID(ctx, tx, b.OrganizationID)
 			if pe != nil {
@@ -527,6 +540,22 @@ func (c *Client) updateBucket(ctx context.Context, tx *bolt.Tx, id platform.ID,
 		b.RetentionPeriod = *upd.RetentionPeriod
 	}
 
+	if upd.ShardGroupDuration != nil {
+		b.ShardGroupDuration = *upd.ShardGroupDuration
+	}
+
+	if upd.MaxSeries != nil {
+		b.MaxSeries = *upd.MaxSeries
+	}
+
+	if err := upd.ApplySchema(b); err != nil {
+		return nil, err
+	}
+
+	if err := upd.ApplyDownsampling(b); err != nil {
+		return nil, err
+	}
+
 	if upd.Name != nil {
 		key, err := bucketIndexKey(b)
 		if err != nil {
@@ -26,6 +26,19 @@ type Bucket struct {
 	Name                string        `json:"name"`
 	RetentionPolicyName string        `json:"rp,omitempty"` // This to support v1 sources
 	RetentionPeriod     time.Duration `json:"retentionPeriod"`
+	ShardGroupDuration  time.Duration `json:"shardGroupDuration,omitempty"`
+
+	// MaxSeries is the maximum number of series of the bucket. Points that
+	// would create series beyond it are dropped. Zero is unlimited.
+	MaxSeries int64 `json:"maxSeries,omitempty"`
+
+	// SchemaType is implicit unless set to explicit, in which case only the
+	// points of the MeasurementSchemas are written to the bucket.
+	SchemaType         SchemaType          `json:"schemaType,omitempty"`
+	MeasurementSchemas []MeasurementSchema `json:"measurementSchemas,omitempty"`
+
+	// DownsamplingPolicies aggregate the data of the bucket into other buckets.
+	DownsamplingPolicies []DownsamplingPolicy `json:"downsamplingPolicies,omitempty"`
 }
 
 // ops for buckets error and buckets op logs.
@@ -64,8 +77,15 @@ type BucketService interface {
 // BucketUpdate represents updates to a bucket.
 // Only fields which are set are updated.
 type BucketUpdate struct {
-	Name            *string        `json:"name,omitempty"`
-	RetentionPeriod *time.Duration `json:"retentionPeriod,omitempty"`
+	Name               *string        `json:"name,omitempty"`
+	RetentionPeriod    *time.Duration `json:"retentionPeriod,omitempty"`
+	ShardGroupDuration *time.Duration `json:"shardGroupDuration,omitempty"`
+	MaxSeries          *int64         `json:"maxSeries,omitempty"`
+
+	SchemaType         *SchemaType          `json:"schemaType,omitempty"`
+	MeasurementSchemas *[]MeasurementSchema `json:"measurementSchemas,omitempty"`
+
+	DownsamplingPolicies *[]DownsamplingPolicy `json:"downsamplingPolicies,omitempty"`
 }
 
 // BucketFilter represents a set of filter that restrict the returned results.
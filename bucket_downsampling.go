@@ -0,0 +1,169 @@
+package influxdb
+
+import (
+	"fmt"
+	"time"
+)
+
+// DownsamplingPolicy continuously aggregates the data of a bucket, its source
+// bucket, into windows written to a destination bucket.
+type DownsamplingPolicy struct {
+	// DestinationBucketID is the bucket the aggregated data is written to.
+	DestinationBucketID ID `json:"destinationBucketID"`
+
+	// Window is the duration of the windows the data is aggregated by.
+	Window time.Duration `json:"window"`
+
+	// Aggregates are the aggregate functions the fields of each type are
+	// aggregated with. The fields of types without one are not downsampled.
+	Aggregates map[SchemaFieldType]string `json:"aggregates"`
+
+	// DestinationRetention is the retention period set on the destination
+	// bucket. Zero leaves the retention period of the bucket unchanged.
+	DestinationRetention time.Duration `json:"destinationRetention,omitempty"`
+
+	// TaskID is the task downsampling the data. It is managed by the server.
+	TaskID ID `json:"taskID,omitempty"`
+}
+
+// numericAggregates are the aggregate functions of numeric fields.
+var numericAggregates = map[string]bool{
+	"mean":   true,
+	"median": true,
+	"sum":    true,
+	"min":    true,
+	"max":    true,
+	"first":  true,
+	"last":   true,
+	"count":  true,
+}
+
+// selectorAggregates are the aggregate functions of string and boolean fields.
+var selectorAggregates = map[string]bool{
+	"first": true,
+	"last":  true,
+	"count": true,
+}
+
+// AggregatesAnyFieldType returns true if the aggregate function fn aggregates
+// fields of any type.
+func AggregatesAnyFieldType(fn string) bool {
+	return selectorAggregates[fn]
+}
+
+// Valid returns an error if the downsampling policy is invalid.
+func (p *DownsamplingPolicy) Valid() error {
+	if !p.DestinationBucketID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "downsampling policy requires a destination bucket",
+		}
+	}
+	if p.Window < time.Second || p.Window%time.Second != 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "window of downsampling policy must be a whole number of seconds",
+		}
+	}
+	if p.DestinationRetention < 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "retention of downsampling policy must not be negative",
+		}
+	}
+	if len(p.Aggregates) == 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "downsampling policy requires at least one aggregate",
+		}
+	}
+
+	for typ, fn := range p.Aggregates {
+		var aggregates map[string]bool
+		switch typ {
+		case SchemaFieldTypeFloat, SchemaFieldTypeInteger, SchemaFieldTypeUnsigned:
+			aggregates = numericAggregates
+		case SchemaFieldTypeString, SchemaFieldTypeBoolean:
+			aggregates = selectorAggregates
+		default:
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("downsampling policy has aggregate of unknown field type %q", typ),
+			}
+		}
+		if !aggregates[fn] {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("%s fields cannot be downsampled with %q", typ, fn),
+			}
+		}
+	}
+	return nil
+}
+
+// Aggregate returns the aggregate function the fields of a bucket with an
+// implicit schema are aggregated with. Their types are not known, so they
+// can only be aggregated with a single function.
+func (p *DownsamplingPolicy) Aggregate() (string, bool) {
+	var aggregate string
+	for _, fn := range p.Aggregates {
+		if aggregate != "" && fn != aggregate {
+			return "", false
+		}
+		aggregate = fn
+	}
+	return aggregate, aggregate != ""
+}
+
+// DownsamplingPolicy returns the downsampling policy of the bucket that writes
+// to the bucket destinationID.
+func (b *Bucket) DownsamplingPolicy(destinationID ID) (*DownsamplingPolicy, bool) {
+	for i := range b.DownsamplingPolicies {
+		if b.DownsamplingPolicies[i].DestinationBucketID == destinationID {
+			return &b.DownsamplingPolicies[i], true
+		}
+	}
+	return nil, false
+}
+
+// ValidDownsampling returns an error if the downsampling policies of the
+// bucket are invalid.
+func (b *Bucket) ValidDownsampling() error {
+	destinations := make(map[ID]bool, len(b.DownsamplingPolicies))
+	for i := range b.DownsamplingPolicies {
+		p := &b.DownsamplingPolicies[i]
+		if err := p.Valid(); err != nil {
+			return err
+		}
+		if b.ID.Valid() && p.DestinationBucketID == b.ID {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "bucket cannot be downsampled to itself",
+			}
+		}
+		if destinations[p.DestinationBucketID] {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("duplicate downsampling policy to bucket %s", p.DestinationBucketID),
+			}
+		}
+		destinations[p.DestinationBucketID] = true
+
+		if _, ok := p.Aggregate(); !b.ExplicitSchema() && !ok {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "fields of bucket with an implicit schema can only be downsampled with a single aggregate",
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyDownsampling applies the downsampling policies of upd to b, and returns
+// an error if the resulting policies are invalid.
+func (upd BucketUpdate) ApplyDownsampling(b *Bucket) error {
+	if upd.DownsamplingPolicies != nil {
+		b.DownsamplingPolicies = *upd.DownsamplingPolicies
+	}
+	return b.ValidDownsampling()
+}
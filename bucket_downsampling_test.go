@@ -0,0 +1,115 @@
+package influxdb_test
+
+import (
+	"testing"
+	"time"
+
+	platform "github.com/influxdata/influxdb"
+)
+
+func TestBucketValidDownsampling(t *testing.T) {
+	policy := func(dst platform.ID, window time.Duration, aggregates map[platform.SchemaFieldType]string) platform.DownsamplingPolicy {
+		return platform.DownsamplingPolicy{
+			DestinationBucketID: dst,
+			Window:              window,
+			Aggregates:          aggregates,
+		}
+	}
+	mean := map[platform.SchemaFieldType]string{platform.SchemaFieldTypeFloat: "mean"}
+
+	tests := []struct {
+		name    string
+		bucket  platform.Bucket
+		wantErr bool
+	}{
+		{
+			name:   "no policies",
+			bucket: platform.Bucket{},
+		},
+		{
+			name: "valid policy",
+			bucket: platform.Bucket{
+				ID:                   1,
+				DownsamplingPolicies: []platform.DownsamplingPolicy{policy(2, time.Hour, mean)},
+			},
+		},
+		{
+			name: "policy requires a destination",
+			bucket: platform.Bucket{
+				DownsamplingPolicies: []platform.DownsamplingPolicy{policy(0, time.Hour, mean)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bucket cannot be downsampled to itself",
+			bucket: platform.Bucket{
+				ID:                   1,
+				DownsamplingPolicies: []platform.DownsamplingPolicy{policy(1, time.Hour, mean)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "window must be whole seconds",
+			bucket: platform.Bucket{
+				DownsamplingPolicies: []platform.DownsamplingPolicy{policy(2, 1500*time.Millisecond, mean)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "policy requires an aggregate",
+			bucket: platform.Bucket{
+				DownsamplingPolicies: []platform.DownsamplingPolicy{policy(2, time.Hour, nil)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "string fields cannot be averaged",
+			bucket: platform.Bucket{
+				SchemaType: platform.SchemaTypeExplicit,
+				DownsamplingPolicies: []platform.DownsamplingPolicy{
+					policy(2, time.Hour, map[platform.SchemaFieldType]string{platform.SchemaFieldTypeString: "mean"}),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate destination",
+			bucket: platform.Bucket{
+				DownsamplingPolicies: []platform.DownsamplingPolicy{policy(2, time.Hour, mean), policy(2, time.Minute, mean)},
+			},
+			wantErr: true,
+		},
+		{
+			name: "implicit schema requires a single aggregate",
+			bucket: platform.Bucket{
+				DownsamplingPolicies: []platform.DownsamplingPolicy{
+					policy(2, time.Hour, map[platform.SchemaFieldType]string{
+						platform.SchemaFieldTypeFloat:   "mean",
+						platform.SchemaFieldTypeInteger: "sum",
+					}),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "explicit schema allows an aggregate per type",
+			bucket: platform.Bucket{
+				SchemaType: platform.SchemaTypeExplicit,
+				DownsamplingPolicies: []platform.DownsamplingPolicy{
+					policy(2, time.Hour, map[platform.SchemaFieldType]string{
+						platform.SchemaFieldTypeFloat:   "mean",
+						platform.SchemaFieldTypeInteger: "sum",
+						platform.SchemaFieldTypeBoolean: "last",
+					}),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.bucket.ValidDownsampling(); (err != nil) != tt.wantErr {
+				t.Errorf("Bucket.ValidDownsampling() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
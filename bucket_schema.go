@@ -0,0 +1,179 @@
+package influxdb
+
+import (
+	"fmt"
+)
+
+// SchemaType is the type of the schema of a bucket.
+type SchemaType string
+
+const (
+	// SchemaTypeImplicit accepts any measurements, tags and fields, and
+	// infers the type of fields from the first points written.
+	SchemaTypeImplicit SchemaType = "implicit"
+	// SchemaTypeExplicit only accepts the measurements, tags and fields of
+	// the measurement schemas of the bucket.
+	SchemaTypeExplicit SchemaType = "explicit"
+)
+
+// SchemaFieldType is the type of the values of a field of a measurement schema.
+type SchemaFieldType string
+
+// The types of fields of measurement schemas.
+const (
+	SchemaFieldTypeFloat    SchemaFieldType = "float"
+	SchemaFieldTypeInteger  SchemaFieldType = "integer"
+	SchemaFieldTypeUnsigned SchemaFieldType = "unsigned"
+	SchemaFieldTypeString   SchemaFieldType = "string"
+	SchemaFieldTypeBoolean  SchemaFieldType = "boolean"
+)
+
+// MeasurementSchema is the set of tags and fields allowed for a measurement
+// of a bucket with an explicit schema.
+type MeasurementSchema struct {
+	Name   string                   `json:"name"`
+	Tags   []string                 `json:"tags"`
+	Fields []MeasurementSchemaField `json:"fields"`
+}
+
+// MeasurementSchemaField is a field of a measurement schema.
+type MeasurementSchemaField struct {
+	Name string          `json:"name"`
+	Type SchemaFieldType `json:"type"`
+}
+
+// HasTag returns true if the tag key is allowed by the schema.
+func (s *MeasurementSchema) HasTag(key string) bool {
+	for _, t := range s.Tags {
+		if t == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Field returns the field of the schema named name.
+func (s *MeasurementSchema) Field(name string) (*MeasurementSchemaField, bool) {
+	for i := range s.Fields {
+		if s.Fields[i].Name == name {
+			return &s.Fields[i], true
+		}
+	}
+	return nil, false
+}
+
+// Valid returns an error if the measurement schema is invalid.
+func (s *MeasurementSchema) Valid() error {
+	if s.Name == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "measurement schema requires a name",
+		}
+	}
+	if len(s.Fields) == 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("measurement schema %q requires at least one field", s.Name),
+		}
+	}
+
+	names := make(map[string]bool, len(s.Tags)+len(s.Fields))
+	for _, t := range s.Tags {
+		if t == "" {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("tags of measurement schema %q require a name", s.Name),
+			}
+		}
+		if names[t] {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("measurement schema %q has duplicate tag or field %q", s.Name, t),
+			}
+		}
+		names[t] = true
+	}
+	for _, f := range s.Fields {
+		if f.Name == "" {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("fields of measurement schema %q require a name", s.Name),
+			}
+		}
+		if names[f.Name] {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("measurement schema %q has duplicate tag or field %q", s.Name, f.Name),
+			}
+		}
+		names[f.Name] = true
+
+		switch f.Type {
+		case SchemaFieldTypeFloat, SchemaFieldTypeInteger, SchemaFieldTypeUnsigned,
+			SchemaFieldTypeString, SchemaFieldTypeBoolean:
+		default:
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("field %q of measurement schema %q has unknown type %q", f.Name, s.Name, f.Type),
+			}
+		}
+	}
+	return nil
+}
+
+// ExplicitSchema returns true if the bucket only accepts the points of its
+// measurement schemas.
+func (b *Bucket) ExplicitSchema() bool {
+	return b.SchemaType == SchemaTypeExplicit
+}
+
+// MeasurementSchema returns the schema of the measurement named name.
+func (b *Bucket) MeasurementSchema(name string) (*MeasurementSchema, bool) {
+	for i := range b.MeasurementSchemas {
+		if b.MeasurementSchemas[i].Name == name {
+			return &b.MeasurementSchemas[i], true
+		}
+	}
+	return nil, false
+}
+
+// ValidSchema returns an error if the schema type or the measurement
+// schemas of the bucket are invalid.
+func (b *Bucket) ValidSchema() error {
+	switch b.SchemaType {
+	case "", SchemaTypeImplicit, SchemaTypeExplicit:
+	default:
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("unknown schema type %q", b.SchemaType),
+		}
+	}
+
+	names := make(map[string]bool, len(b.MeasurementSchemas))
+	for i := range b.MeasurementSchemas {
+		s := &b.MeasurementSchemas[i]
+		if err := s.Valid(); err != nil {
+			return err
+		}
+		if names[s.Name] {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("duplicate measurement schema %q", s.Name),
+			}
+		}
+		names[s.Name] = true
+	}
+	return nil
+}
+
+// ApplySchema applies the schema changes of upd to b, and returns an error
+// if the resulting schema is invalid.
+func (upd BucketUpdate) ApplySchema(b *Bucket) error {
+	if upd.SchemaType != nil {
+		b.SchemaType = *upd.SchemaType
+	}
+	if upd.MeasurementSchemas != nil {
+		b.MeasurementSchemas = *upd.MeasurementSchemas
+	}
+	return b.ValidSchema()
+}
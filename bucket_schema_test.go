@@ -0,0 +1,86 @@
+package influxdb_test
+
+import (
+	"testing"
+
+	platform "github.com/influxdata/influxdb"
+)
+
+func TestBucketValidSchema(t *testing.T) {
+	cpu := func(fields ...platform.MeasurementSchemaField) platform.MeasurementSchema {
+		return platform.MeasurementSchema{
+			Name:   "cpu",
+			Tags:   []string{"host"},
+			Fields: fields,
+		}
+	}
+	usage := platform.MeasurementSchemaField{Name: "usage", Type: platform.SchemaFieldTypeFloat}
+
+	tests := []struct {
+		name    string
+		bucket  platform.Bucket
+		wantErr bool
+	}{
+		{
+			name:   "implicit schema by default",
+			bucket: platform.Bucket{},
+		},
+		{
+			name: "valid explicit schema",
+			bucket: platform.Bucket{
+				SchemaType:         platform.SchemaTypeExplicit,
+				MeasurementSchemas: []platform.MeasurementSchema{cpu(usage)},
+			},
+		},
+		{
+			name: "unknown schema type",
+			bucket: platform.Bucket{
+				SchemaType: "strict",
+			},
+			wantErr: true,
+		},
+		{
+			name: "measurement schema requires a field",
+			bucket: platform.Bucket{
+				SchemaType:         platform.SchemaTypeExplicit,
+				MeasurementSchemas: []platform.MeasurementSchema{cpu()},
+			},
+			wantErr: true,
+		},
+		{
+			name: "field requires a known type",
+			bucket: platform.Bucket{
+				SchemaType: platform.SchemaTypeExplicit,
+				MeasurementSchemas: []platform.MeasurementSchema{
+					cpu(platform.MeasurementSchemaField{Name: "usage", Type: "decimal"}),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "field cannot share the name of a tag",
+			bucket: platform.Bucket{
+				SchemaType: platform.SchemaTypeExplicit,
+				MeasurementSchemas: []platform.MeasurementSchema{
+					cpu(platform.MeasurementSchemaField{Name: "host", Type: platform.SchemaFieldTypeString}),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate measurement schema",
+			bucket: platform.Bucket{
+				SchemaType:         platform.SchemaTypeExplicit,
+				MeasurementSchemas: []platform.MeasurementSchema{cpu(usage), cpu(usage)},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.bucket.ValidSchema(); (err != nil) != tt.wantErr {
+				t.Errorf("Bucket.ValidSchema() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
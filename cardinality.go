@@ -0,0 +1,32 @@
+package influxdb
+
+import "context"
+
+// BucketCardinality is the series cardinality of a bucket.
+type BucketCardinality struct {
+	OrganizationID ID `json:"orgID"`
+	BucketID       ID `json:"bucketID"`
+
+	// Series is the number of series of the bucket.
+	Series int64 `json:"series"`
+
+	// MaxSeries is the maximum number of series of the bucket, or zero if the
+	// series of the bucket are not limited.
+	MaxSeries int64 `json:"maxSeries"`
+
+	// Measurements is the cardinality of the measurements of the bucket,
+	// sorted from the highest cardinality to the lowest.
+	Measurements []MeasurementCardinality `json:"measurements"`
+}
+
+// MeasurementCardinality is the estimated series cardinality of a measurement.
+type MeasurementCardinality struct {
+	Name   string `json:"name"`
+	Series int64  `json:"series"`
+}
+
+// CardinalityService reports the series cardinality of buckets.
+type CardinalityService interface {
+	// BucketCardinality returns the series cardinality of a bucket.
+	BucketCardinality(ctx context.Context, orgID, bucketID ID) (*BucketCardinality, error)
+}
@@ -0,0 +1,267 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ErrCheckNotFound is the error msg for a missing check.
+const ErrCheckNotFound = "check not found"
+
+// ops for checks error.
+const (
+	OpFindCheckByID = "FindCheckByID"
+	OpFindChecks    = "FindChecks"
+	OpCreateCheck   = "CreateCheck"
+	OpUpdateCheck   = "UpdateCheck"
+	OpDeleteCheck   = "DeleteCheck"
+)
+
+// CheckService describes a service for managing checks.
+type CheckService interface {
+	// FindCheckByID returns a single check by ID.
+	FindCheckByID(ctx context.Context, id ID) (*Check, error)
+
+	// FindChecks returns a list of checks that match filter and the total count of matching checks.
+	// Additional options provide pagination & sorting.
+	FindChecks(ctx context.Context, filter CheckFilter, opt ...FindOptions) ([]*Check, int, error)
+
+	// CreateCheck creates a new check and sets c.ID with the new identifier.
+	CreateCheck(ctx context.Context, c *Check) error
+
+	// UpdateCheck replaces the check with the ID id by c. The organization
+	// and the authorization of the check cannot be changed.
+	UpdateCheck(ctx context.Context, id ID, c *Check) (*Check, error)
+
+	// DeleteCheck removes a check by ID.
+	DeleteCheck(ctx context.Context, id ID) error
+}
+
+// CheckType is the kind of evaluation of a check.
+type CheckType string
+
+// Check types.
+const (
+	// ThresholdCheckType checks that the last values of the query of the
+	// check are within thresholds.
+	ThresholdCheckType CheckType = "threshold"
+	// DeadmanCheckType checks that the query of the check returns data
+	// recent enough.
+	DeadmanCheckType CheckType = "deadman"
+	// TaskCheckType checks that the runs of a task succeed.
+	TaskCheckType CheckType = "task"
+)
+
+// CheckLevel is the status level of a check.
+type CheckLevel string
+
+// Check levels, from the least to the most severe.
+const (
+	CheckUnknown CheckLevel = "unknown"
+	CheckOK      CheckLevel = "ok"
+	CheckInfo    CheckLevel = "info"
+	CheckWarn    CheckLevel = "warn"
+	CheckCrit    CheckLevel = "crit"
+)
+
+// Severity returns the order of the level, from the least to the most
+// severe, or -1 for invalid levels.
+func (l CheckLevel) Severity() int {
+	switch l {
+	case CheckUnknown:
+		return 0
+	case CheckOK:
+		return 1
+	case CheckInfo:
+		return 2
+	case CheckWarn:
+		return 3
+	case CheckCrit:
+		return 4
+	}
+	return -1
+}
+
+// CheckThreshold is the level of a threshold check when a value is in the
+// range [Min, Max]. A range with no Min or no Max is unbounded on that side.
+type CheckThreshold struct {
+	Level CheckLevel `json:"level"`
+	Min   *float64   `json:"min,omitempty"`
+	Max   *float64   `json:"max,omitempty"`
+}
+
+// Contains returns true if v is in the range of the threshold.
+func (t CheckThreshold) Contains(v float64) bool {
+	return (t.Min == nil || v >= *t.Min) && (t.Max == nil || v <= *t.Max)
+}
+
+// Check is a query evaluated on a schedule, whose status level is notified
+// to notification endpoints when it changes.
+type Check struct {
+	ID             ID        `json:"id,omitempty"`
+	OrganizationID ID        `json:"orgID,omitempty"`
+	Name           string    `json:"name"`
+	Description    string    `json:"description,omitempty"`
+	Type           CheckType `json:"type"`
+	Status         Status    `json:"status"`
+
+	// Query is the Flux query of the data checked, and Every the interval,
+	// such as 1m, at which it is evaluated.
+	Query string `json:"query"`
+	Every string `json:"every"`
+
+	// Thresholds are the levels of a threshold check. The level of each
+	// table of the query is that of the most severe threshold containing its
+	// last value, or ok when there is none. The level of the check is the
+	// most severe level of its tables.
+	Thresholds []CheckThreshold `json:"thresholds,omitempty"`
+
+	// TimeSince and Level are the level of a deadman check when the query
+	// returns no data more recent than TimeSince, such as 5m, ago.
+	TimeSince string     `json:"timeSince,omitempty"`
+	Level     CheckLevel `json:"level,omitempty"`
+
+	// TaskID is the task of a task check, which has no query. The check is
+	// at its Level when a run of the task fails, and ok when one succeeds.
+	TaskID ID `json:"taskID,omitempty"`
+
+	// NotificationEndpointIDs are the endpoints notified when the level of
+	// the check changes.
+	NotificationEndpointIDs []ID `json:"notificationEndpointIDs,omitempty"`
+
+	// AuthorizationID is the authorization the query is run with.
+	AuthorizationID ID `json:"authorizationID,omitempty"`
+}
+
+// Valid returns an error if the check is not valid.
+func (c *Check) Valid() error {
+	if c.Name == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "check name is empty",
+		}
+	}
+	if c.Status != "" {
+		if err := c.Status.Valid(); err != nil {
+			return err
+		}
+	}
+	if c.Type != TaskCheckType {
+		if c.Query == "" {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "check query is empty",
+			}
+		}
+		if _, err := c.EveryDuration(); err != nil {
+			return err
+		}
+	}
+
+	switch c.Type {
+	case ThresholdCheckType:
+		if len(c.Thresholds) == 0 {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "threshold check has no thresholds",
+			}
+		}
+		for _, t := range c.Thresholds {
+			if err := validCheckLevel(t.Level); err != nil {
+				return err
+			}
+			if t.Min == nil && t.Max == nil {
+				return &Error{
+					Code: EInvalid,
+					Msg:  "check threshold has neither a min nor a max",
+				}
+			}
+		}
+	case DeadmanCheckType:
+		if _, err := c.TimeSinceDuration(); err != nil {
+			return err
+		}
+		if err := validCheckLevel(c.Level); err != nil {
+			return err
+		}
+	case TaskCheckType:
+		if !c.TaskID.Valid() {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "task check has no task",
+			}
+		}
+		if err := validCheckLevel(c.Level); err != nil {
+			return err
+		}
+	default:
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("unknown check type %q", c.Type),
+		}
+	}
+	return nil
+}
+
+// validCheckLevel returns an error if l is not a level thresholds, deadman
+// and task checks may be set to.
+func validCheckLevel(l CheckLevel) error {
+	switch l {
+	case CheckInfo, CheckWarn, CheckCrit:
+		return nil
+	}
+	return &Error{
+		Code: EInvalid,
+		Msg:  fmt.Sprintf("check level must be one of info, warn or crit, got %q", l),
+	}
+}
+
+// EveryDuration returns the interval at which the check is evaluated.
+func (c *Check) EveryDuration() (time.Duration, error) {
+	return parseCheckDuration("every", c.Every)
+}
+
+// TimeSinceDuration returns the time since which a deadman check expects data.
+func (c *Check) TimeSinceDuration() (time.Duration, error) {
+	return parseCheckDuration("timeSince", c.TimeSince)
+}
+
+func parseCheckDuration(name, s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("check %s must be a positive duration, got %q", name, s),
+		}
+	}
+	return d, nil
+}
+
+// CheckFilter represents a set of filter that restrict the returned results.
+type CheckFilter struct {
+	ID             *ID
+	OrganizationID *ID
+	Name           *string
+}
+
+// QueryParams implements PagingFilter.
+//
+// It converts CheckFilter fields to url query params.
+func (f CheckFilter) QueryParams() map[string][]string {
+	qp := url.Values{}
+	if f.ID != nil {
+		qp.Add("id", f.ID.String())
+	}
+
+	if f.OrganizationID != nil {
+		qp.Add("orgID", f.OrganizationID.String())
+	}
+
+	if f.Name != nil {
+		qp.Add("name", *f.Name)
+	}
+
+	return qp
+}
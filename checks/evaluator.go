@@ -0,0 +1,558 @@
+// Package checks evaluates checks on the schedule of a task scheduler,
+// records the changes of their status levels, and notifies notification
+// endpoints of them.
+package checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/storage"
+	"github.com/influxdata/influxdb/task/backend"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+const (
+	// MonitoringBucketName is the name of the bucket of each organization
+	// the statuses of its checks are written to.
+	MonitoringBucketName = "_monitoring"
+
+	// MonitoringRetentionPeriod is the retention period of the monitoring
+	// buckets created by the evaluator.
+	MonitoringRetentionPeriod = 7 * 24 * time.Hour
+
+	// StatusMeasurement is the measurement of the points written for statuses.
+	StatusMeasurement = "statuses"
+
+	// runQueueSize is the number of finished task runs that may wait for
+	// the evaluation of their task checks.
+	runQueueSize = 100
+)
+
+// Evaluator evaluates checks. The evaluation of each active check is a task,
+// scheduled on the schedule of the check by a task scheduler that the
+// evaluator is both the executor and desired state of.
+//
+// Task checks are not scheduled, but evaluated when the runs of their task,
+// written through the log writer of the evaluator, finish.
+//
+// When the level of a check changes, its status is written to the monitoring
+// bucket of its organization, and sent to its notification endpoints.
+type Evaluator struct {
+	Logger *zap.Logger
+
+	CheckService                influxdb.CheckService
+	NotificationEndpointService influxdb.NotificationEndpointService
+	AuthorizationService        influxdb.AuthorizationService
+	BucketService               influxdb.BucketService
+	QueryService                query.QueryService
+	PointsWriter                storage.PointsWriter
+	Notifier                    *Notifier
+
+	// store holds the task of each scheduled check.
+	store     backend.Store
+	scheduler *backend.TickScheduler
+	wg        sync.WaitGroup
+
+	// runs queues the finished task runs until their task checks are
+	// evaluated, in the order the runs finished.
+	runs   chan finishedRun
+	done   chan struct{}
+	runsWG sync.WaitGroup
+
+	mu         sync.Mutex
+	tasks      map[influxdb.ID]influxdb.ID         // tasks by check
+	checks     map[influxdb.ID]influxdb.ID         // checks by task
+	taskChecks map[influxdb.ID]influxdb.ID         // checked tasks by task check
+	levels     map[influxdb.ID]influxdb.CheckLevel // levels by check
+	buckets    map[influxdb.ID]influxdb.ID         // monitoring buckets by org
+}
+
+// finishedRun is a task run that succeeded or failed.
+type finishedRun struct {
+	base  backend.RunLogBase
+	state backend.RunStatus
+	when  time.Time
+}
+
+var _ backend.Executor = (*Evaluator)(nil)
+
+// Open starts the scheduler of the evaluator, and schedules the active checks.
+func (e *Evaluator) Open(ctx context.Context) error {
+	if e.Logger == nil {
+		e.Logger = zap.NewNop()
+	}
+	if e.Notifier == nil {
+		e.Notifier = &Notifier{}
+	}
+
+	e.store = backend.NewInMemStore()
+	e.tasks = make(map[influxdb.ID]influxdb.ID)
+	e.checks = make(map[influxdb.ID]influxdb.ID)
+	e.taskChecks = make(map[influxdb.ID]influxdb.ID)
+	e.levels = make(map[influxdb.ID]influxdb.CheckLevel)
+	e.buckets = make(map[influxdb.ID]influxdb.ID)
+
+	e.runs = make(chan finishedRun, runQueueSize)
+	e.done = make(chan struct{})
+	e.runsWG.Add(1)
+	go e.evaluateRuns()
+
+	e.scheduler = backend.NewScheduler(e.store, e, backend.NopLogWriter{}, time.Now().UTC().Unix(),
+		backend.WithTicker(ctx, time.Second), backend.WithLogger(e.Logger))
+	e.scheduler.Start(ctx)
+
+	cs, _, err := e.CheckService.FindChecks(ctx, influxdb.CheckFilter{})
+	if err != nil {
+		return err
+	}
+	for _, c := range cs {
+		if err := e.Schedule(ctx, c); err != nil {
+			e.Logger.Error("Failed to schedule check", zap.Stringer("check_id", c.ID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// Close stops the scheduler and the evaluation of task checks, and waits for
+// the evaluations in progress.
+func (e *Evaluator) Close() {
+	if e.scheduler != nil {
+		e.scheduler.Stop()
+	}
+	e.wg.Wait()
+	if e.done != nil {
+		close(e.done)
+		e.runsWG.Wait()
+	}
+}
+
+// taskRequest returns the request of the task of the check c.
+func taskRequest(c *influxdb.Check, now time.Time) backend.CreateTaskRequest {
+	return backend.CreateTaskRequest{
+		Org:             c.OrganizationID,
+		AuthorizationID: c.AuthorizationID,
+		Script:          fmt.Sprintf("option task = {name: %q, every: %s}\n\n%s", c.Name, c.Every, c.Query),
+		ScheduleAfter:   now.Unix(),
+		Status:          backend.TaskActive,
+	}
+}
+
+// Validate returns an error if the check c cannot be scheduled, such as
+// when its query is not valid.
+func Validate(c *influxdb.Check) error {
+	if err := c.Valid(); err != nil {
+		return err
+	}
+	if c.Type == influxdb.TaskCheckType {
+		return nil
+	}
+	if _, err := backend.StoreValidator.CreateArgs(taskRequest(c, time.Now())); err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid check",
+			Err:  err,
+		}
+	}
+	return nil
+}
+
+// Schedule schedules the evaluation of the check c, replacing its previous
+// schedule. Inactive checks are unscheduled.
+func (e *Evaluator) Schedule(ctx context.Context, c *influxdb.Check) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.unschedule(ctx, c.ID); err != nil {
+		return err
+	}
+	if c.Status == influxdb.Inactive {
+		return nil
+	}
+	if c.Type == influxdb.TaskCheckType {
+		e.taskChecks[c.ID] = c.TaskID
+		return nil
+	}
+
+	id, err := e.store.CreateTask(ctx, taskRequest(c, time.Now()))
+	if err != nil {
+		return err
+	}
+	t, m, err := e.store.FindTaskByIDWithMeta(ctx, id)
+	if err != nil {
+		return err
+	}
+	// Checks are evaluated one at a time, so that their levels change in order.
+	m.MaxConcurrency = 1
+
+	if err := e.scheduler.ClaimTask(t, m); err != nil {
+		e.store.DeleteTask(ctx, id)
+		return err
+	}
+
+	e.tasks[c.ID] = id
+	e.checks[id] = c.ID
+	return nil
+}
+
+// Unschedule stops the evaluation of the check with the ID id.
+func (e *Evaluator) Unschedule(ctx context.Context, id influxdb.ID) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	delete(e.levels, id)
+	return e.unschedule(ctx, id)
+}
+
+func (e *Evaluator) unschedule(ctx context.Context, id influxdb.ID) error {
+	delete(e.taskChecks, id)
+
+	taskID, ok := e.tasks[id]
+	if !ok {
+		return nil
+	}
+	delete(e.tasks, id)
+	delete(e.checks, taskID)
+
+	if err := e.scheduler.ReleaseTask(taskID); err != nil && err != backend.ErrTaskNotClaimed {
+		return err
+	}
+	_, err := e.store.DeleteTask(ctx, taskID)
+	return err
+}
+
+// Execute starts the evaluation of the check of the task of the run.
+func (e *Evaluator) Execute(ctx context.Context, run backend.QueuedRun) (backend.RunPromise, error) {
+	e.mu.Lock()
+	id, ok := e.checks[run.TaskID]
+	e.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("no check is scheduled by task %s", run.TaskID)
+	}
+
+	p := newEvaluation(ctx, run)
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		p.finish(e.Evaluate(p.ctx, id, time.Unix(run.Now, 0)))
+	}()
+	return p, nil
+}
+
+// Wait blocks until the evaluations in progress have finished.
+func (e *Evaluator) Wait() {
+	e.wg.Wait()
+}
+
+// Evaluate evaluates the check with the ID id at now. When its level
+// changes, its status is recorded and notified. The error of the query of
+// the check is returned, after recording the check as unknown.
+func (e *Evaluator) Evaluate(ctx context.Context, id influxdb.ID, now time.Time) error {
+	c, err := e.CheckService.FindCheckByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	lvl, msg, qerr := e.evaluate(ctx, c, now)
+	e.record(ctx, c, lvl, msg, now)
+	return qerr
+}
+
+// LogWriter returns a log writer that writes the logs of task runs to lw,
+// and queues the runs that finish for the evaluation of their task checks.
+func (e *Evaluator) LogWriter(lw backend.LogWriter) backend.LogWriter {
+	return &runLogWriter{LogWriter: lw, e: e}
+}
+
+type runLogWriter struct {
+	backend.LogWriter
+	e *Evaluator
+}
+
+func (w *runLogWriter) UpdateRunState(ctx context.Context, base backend.RunLogBase, when time.Time, state backend.RunStatus) error {
+	err := w.LogWriter.UpdateRunState(ctx, base, when, state)
+	if state != backend.RunSuccess && state != backend.RunFail {
+		return err
+	}
+
+	// The run is not held up by the evaluation, nor by a full queue.
+	select {
+	case w.e.runs <- finishedRun{base: base, state: state, when: when}:
+	case <-w.e.done:
+	default:
+		w.e.Logger.Error("Failed to queue task run for the evaluation of its checks", zap.Stringer("task_id", base.Task.ID), zap.Stringer("run_id", base.RunID))
+	}
+	return err
+}
+
+// evaluateRuns evaluates the task checks of the queued runs until the
+// evaluator is closed.
+func (e *Evaluator) evaluateRuns() {
+	defer e.runsWG.Done()
+	for {
+		select {
+		case r := <-e.runs:
+			e.EvaluateRun(context.Background(), r.base, r.state, r.when)
+		case <-e.done:
+			return
+		}
+	}
+}
+
+// EvaluateRun evaluates the task checks of the task of a run that finished
+// in state at now. The checks are at their level when the run failed, and ok
+// when it succeeded.
+func (e *Evaluator) EvaluateRun(ctx context.Context, base backend.RunLogBase, state backend.RunStatus, now time.Time) {
+	var ids []influxdb.ID
+	e.mu.Lock()
+	for id, taskID := range e.taskChecks {
+		if taskID == base.Task.ID {
+			ids = append(ids, id)
+		}
+	}
+	e.mu.Unlock()
+
+	for _, id := range ids {
+		c, err := e.CheckService.FindCheckByID(ctx, id)
+		if err != nil {
+			e.Logger.Error("Failed to find task check", zap.Stringer("check_id", id), zap.Error(err))
+			continue
+		}
+		// Checks may only check the tasks of their organization.
+		if c.OrganizationID != base.Task.Org {
+			continue
+		}
+
+		lvl, msg := influxdb.CheckOK, fmt.Sprintf("run %s of task %s succeeded", base.RunID, base.Task.Name)
+		if state == backend.RunFail {
+			lvl, msg = c.Level, fmt.Sprintf("run %s of task %s failed", base.RunID, base.Task.Name)
+		}
+		e.record(ctx, c, lvl, msg, now)
+	}
+}
+
+// record sets the level of the check c. When it changes, the status of the
+// check is written and notified.
+func (e *Evaluator) record(ctx context.Context, c *influxdb.Check, lvl influxdb.CheckLevel, msg string, now time.Time) {
+	e.mu.Lock()
+	prev, seen := e.levels[c.ID]
+	e.levels[c.ID] = lvl
+	e.mu.Unlock()
+	if seen && prev == lvl {
+		return
+	}
+
+	s := &Status{
+		CheckID:       c.ID,
+		CheckName:     c.Name,
+		OrgID:         c.OrganizationID,
+		Level:         lvl,
+		PreviousLevel: prev,
+		Message:       msg,
+		Time:          now.UTC(),
+	}
+
+	if err := e.writeStatus(ctx, s); err != nil {
+		e.Logger.Error("Failed to write check status", zap.Stringer("check_id", c.ID), zap.Error(err))
+	}
+
+	// The first status of a check is only notified when it is not ok, so
+	// that restarting the evaluator does not notify every check.
+	if seen || lvl != influxdb.CheckOK {
+		e.notify(ctx, c, s)
+	}
+}
+
+// evaluate runs the query of the check c and returns its level.
+func (e *Evaluator) evaluate(ctx context.Context, c *influxdb.Check, now time.Time) (influxdb.CheckLevel, string, error) {
+	ss, err := e.query(ctx, c, now)
+	if err != nil {
+		return influxdb.CheckUnknown, fmt.Sprintf("the query failed: %v", err), err
+	}
+	lvl, msg := level(c, ss, now)
+	return lvl, msg, nil
+}
+
+func (e *Evaluator) query(ctx context.Context, c *influxdb.Check, now time.Time) ([]series, error) {
+	auth, err := e.AuthorizationService.FindAuthorizationByID(ctx, c.AuthorizationID)
+	if err != nil {
+		return nil, err
+	}
+	if !auth.IsActive() {
+		return nil, errors.New("the authorization of the check is inactive")
+	}
+	ctx = icontext.SetAuthorizer(ctx, auth)
+
+	spec, err := flux.Compile(ctx, c.Query, now)
+	if err != nil {
+		return nil, err
+	}
+
+	it, err := e.QueryService.Query(ctx, &query.Request{
+		Authorization:  auth,
+		OrganizationID: c.OrganizationID,
+		Compiler:       lang.SpecCompiler{Spec: spec},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+
+	return readSeries(ctx, it)
+}
+
+// notify sends the status s to the active endpoints of the check c.
+func (e *Evaluator) notify(ctx context.Context, c *influxdb.Check, s *Status) {
+	for _, id := range c.NotificationEndpointIDs {
+		ep, err := e.NotificationEndpointService.FindNotificationEndpointByID(ctx, id)
+		if err != nil {
+			e.Logger.Error("Failed to find notification endpoint", zap.Stringer("check_id", c.ID), zap.Stringer("endpoint_id", id), zap.Error(err))
+			continue
+		}
+		// Checks may only notify the endpoints of their organization, whose
+		// secrets they may use.
+		if ep.Status == influxdb.Inactive || ep.OrganizationID != c.OrganizationID {
+			continue
+		}
+
+		if err := e.Notifier.Notify(ctx, ep, s); err != nil {
+			e.Logger.Error("Failed to notify endpoint", zap.Stringer("check_id", c.ID), zap.Stringer("endpoint_id", id), zap.Error(err))
+		}
+	}
+}
+
+// writeStatus writes the status s as a point to the monitoring bucket of
+// the organization of its check.
+func (e *Evaluator) writeStatus(ctx context.Context, s *Status) error {
+	bucketID, err := e.monitoringBucket(ctx, s.OrgID)
+	if err != nil {
+		return err
+	}
+
+	tags := models.NewTags(map[string]string{
+		"checkID":   s.CheckID.String(),
+		"checkName": s.CheckName,
+		"level":     string(s.Level),
+	})
+	fields := models.Fields{
+		"message": s.Message,
+	}
+	if s.PreviousLevel != "" {
+		fields["previousLevel"] = string(s.PreviousLevel)
+	}
+
+	pt, err := models.NewPoint(StatusMeasurement, tags, fields, s.Time)
+	if err != nil {
+		return err
+	}
+
+	pts, err := tsdb.ExplodePoints(s.OrgID, bucketID, []models.Point{pt})
+	if err != nil {
+		return err
+	}
+	return e.PointsWriter.WritePoints(ctx, pts)
+}
+
+// monitoringBucket returns the ID of the monitoring bucket of the
+// organization orgID, creating it if it does not exist.
+func (e *Evaluator) monitoringBucket(ctx context.Context, orgID influxdb.ID) (influxdb.ID, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if id, ok := e.buckets[orgID]; ok {
+		return id, nil
+	}
+
+	name := MonitoringBucketName
+	b, err := e.BucketService.FindBucket(ctx, influxdb.BucketFilter{OrganizationID: &orgID, Name: &name})
+	if influxdb.ErrorCode(err) == influxdb.ENotFound {
+		b = &influxdb.Bucket{
+			OrganizationID:  orgID,
+			Name:            MonitoringBucketName,
+			RetentionPeriod: MonitoringRetentionPeriod,
+		}
+		err = e.BucketService.CreateBucket(ctx, b)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	e.buckets[orgID] = b.ID
+	return b.ID, nil
+}
+
+// evaluation is the run promise, and result, of the evaluation of a check.
+type evaluation struct {
+	run    backend.QueuedRun
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	once  sync.Once
+	ready chan struct{}
+	err   error
+}
+
+var _ backend.RunPromise = (*evaluation)(nil)
+var _ backend.RunResult = (*evaluation)(nil)
+
+func newEvaluation(ctx context.Context, run backend.QueuedRun) *evaluation {
+	ctx, cancel := context.WithCancel(ctx)
+	return &evaluation{
+		run:    run,
+		ctx:    ctx,
+		cancel: cancel,
+		ready:  make(chan struct{}),
+	}
+}
+
+func (p *evaluation) finish(err error) {
+	p.once.Do(func() {
+		p.err = err
+		p.cancel()
+		close(p.ready)
+	})
+}
+
+// Run returns the run of the evaluation.
+func (p *evaluation) Run() backend.QueuedRun {
+	return p.run
+}
+
+// Wait blocks until the evaluation has finished.
+func (p *evaluation) Wait() (backend.RunResult, error) {
+	<-p.ready
+	if p.err == backend.ErrRunCanceled {
+		return nil, p.err
+	}
+	return p, nil
+}
+
+// Cancel interrupts the evaluation.
+func (p *evaluation) Cancel() {
+	p.finish(backend.ErrRunCanceled)
+}
+
+// Err returns the error of the query of the check, if it failed.
+func (p *evaluation) Err() error {
+	return p.err
+}
+
+// IsRetryable returns false, as checks are evaluated again on their schedule.
+func (p *evaluation) IsRetryable() bool {
+	return false
+}
+
+// Statistics returns no statistics.
+func (p *evaluation) Statistics() flux.Statistics {
+	return flux.Statistics{}
+}
@@ -0,0 +1,111 @@
+package checks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/inmem"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/task/backend"
+)
+
+func TestEvaluator_TaskCheck(t *testing.T) {
+	statuses := make(chan Status, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var s Status
+		if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+			t.Errorf("failed to decode notification: %v", err)
+		}
+		statuses <- s
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	svc := kv.NewService(inmem.NewKVStore())
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatal(err)
+	}
+	org := &influxdb.Organization{Name: "o"}
+	if err := svc.CreateOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+	ep := &influxdb.NotificationEndpoint{
+		OrganizationID: org.ID,
+		Name:           "ops",
+		Type:           influxdb.HTTPEndpointType,
+		URL:            srv.URL,
+	}
+	if err := svc.CreateNotificationEndpoint(ctx, ep); err != nil {
+		t.Fatal(err)
+	}
+	c := &influxdb.Check{
+		OrganizationID:          org.ID,
+		Name:                    "downsampling",
+		Type:                    influxdb.TaskCheckType,
+		TaskID:                  10,
+		Level:                   influxdb.CheckCrit,
+		NotificationEndpointIDs: []influxdb.ID{ep.ID},
+	}
+	if err := svc.CreateCheck(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+
+	pw := &mock.PointsWriter{}
+	e := &Evaluator{
+		CheckService:                svc,
+		NotificationEndpointService: svc,
+		BucketService:               svc,
+		PointsWriter:                pw,
+	}
+	if err := e.Open(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	lw := e.LogWriter(backend.NopLogWriter{})
+	finish := func(taskID, orgID influxdb.ID, state backend.RunStatus) {
+		base := backend.RunLogBase{
+			Task:  &backend.StoreTask{ID: taskID, Org: orgID, Name: "downsample"},
+			RunID: 20,
+		}
+		if err := lw.UpdateRunState(ctx, base, time.Now(), state); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expect := func(lvl, prev influxdb.CheckLevel) {
+		t.Helper()
+		select {
+		case s := <-statuses:
+			if s.CheckID != c.ID || s.Level != lvl || s.PreviousLevel != prev {
+				t.Errorf("expected check %s to change from %q to %s, got %+v", c.ID, prev, lvl, s)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected check to change to %s", lvl)
+		}
+	}
+
+	// The runs of other tasks, and of the tasks of other organizations,
+	// are not checked.
+	finish(11, org.ID, backend.RunFail)
+	finish(10, org.ID+1, backend.RunFail)
+
+	finish(10, org.ID, backend.RunFail)
+	expect(influxdb.CheckCrit, "")
+
+	// Only the changes of the level are notified.
+	finish(10, org.ID, backend.RunFail)
+	finish(10, org.ID, backend.RunSuccess)
+	expect(influxdb.CheckOK, influxdb.CheckCrit)
+
+	select {
+	case s := <-statuses:
+		t.Errorf("unexpected notification %+v", s)
+	default:
+	}
+}
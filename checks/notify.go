@@ -0,0 +1,135 @@
+package checks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+// DefaultNotifyTimeout is the default timeout of the requests sent to
+// notification endpoints.
+const DefaultNotifyTimeout = 10 * time.Second
+
+// defaultClient sends the requests of notifiers without a client, so that an
+// endpoint that does not respond cannot block the evaluation of a check.
+var defaultClient = &http.Client{Timeout: DefaultNotifyTimeout}
+
+// Notifier sends the statuses of checks to notification endpoints.
+type Notifier struct {
+	// SecretService resolves the secrets of the URLs and tokens of endpoints.
+	SecretService influxdb.SecretService
+
+	// Client sends the requests to the endpoints. It should have a timeout;
+	// the requests time out after DefaultNotifyTimeout when it is nil.
+	Client *http.Client
+}
+
+// Notify sends the status s to the endpoint e.
+func (n *Notifier) Notify(ctx context.Context, e *influxdb.NotificationEndpoint, s *Status) error {
+	url := e.URL
+	if e.URLSecret != "" {
+		var err error
+		if url, err = n.loadSecret(ctx, e, e.URLSecret); err != nil {
+			return err
+		}
+	}
+
+	var body interface{} = s
+	if e.Type == influxdb.SlackEndpointType {
+		body = newSlackMessage(e, s)
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	method := "POST"
+	if e.Type == influxdb.HTTPEndpointType && e.Method != "" {
+		method = e.Method
+	}
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	if e.Type == influxdb.HTTPEndpointType {
+		for k, v := range e.Headers {
+			req.Header.Set(k, v)
+		}
+		if e.TokenSecret != "" {
+			token, err := n.loadSecret(ctx, e, e.TokenSecret)
+			if err != nil {
+				return err
+			}
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+	}
+
+	hc := defaultClient
+	if n.Client != nil {
+		hc = n.Client
+	}
+	resp, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("notification endpoint %s returned status %s", e.ID, resp.Status)
+	}
+	return nil
+}
+
+func (n *Notifier) loadSecret(ctx context.Context, e *influxdb.NotificationEndpoint, key string) (string, error) {
+	if n.SecretService == nil {
+		return "", fmt.Errorf("cannot load secret %q of notification endpoint %s: no secret service", key, e.ID)
+	}
+	return n.SecretService.LoadSecret(ctx, e.OrganizationID, key)
+}
+
+// slackMessage is the payload of Slack incoming webhooks.
+type slackMessage struct {
+	Channel     string            `json:"channel,omitempty"`
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+type slackAttachment struct {
+	Color string `json:"color"`
+	Text  string `json:"text"`
+}
+
+func newSlackMessage(e *influxdb.NotificationEndpoint, s *Status) *slackMessage {
+	text := fmt.Sprintf("Check *%s* is %s", s.CheckName, s.Level)
+	if s.PreviousLevel != "" {
+		text += fmt.Sprintf(" (was %s)", s.PreviousLevel)
+	}
+
+	return &slackMessage{
+		Channel: e.Channel,
+		Text:    text,
+		Attachments: []slackAttachment{
+			{Color: slackColor(s.Level), Text: s.Message},
+		},
+	}
+}
+
+func slackColor(l influxdb.CheckLevel) string {
+	switch l {
+	case influxdb.CheckOK:
+		return "good"
+	case influxdb.CheckWarn:
+		return "warning"
+	case influxdb.CheckCrit:
+		return "danger"
+	}
+	return "#bebebe"
+}
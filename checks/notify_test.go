@@ -0,0 +1,134 @@
+package checks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+)
+
+func TestNotifier_Notify(t *testing.T) {
+	var (
+		method string
+		header http.Header
+		body   map[string]interface{}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, header = r.Method, r.Header
+		body = nil
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode notification: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	secrets := mock.NewSecretService()
+	secrets.LoadSecretFn = func(ctx context.Context, orgID influxdb.ID, k string) (string, error) {
+		switch k {
+		case "url":
+			return srv.URL, nil
+		case "token":
+			return "secret-token", nil
+		}
+		return "", &influxdb.Error{Code: influxdb.ENotFound, Msg: "secret not found"}
+	}
+	n := &Notifier{SecretService: secrets}
+
+	s := &Status{
+		CheckID:       1,
+		CheckName:     "cpu",
+		OrgID:         2,
+		Level:         influxdb.CheckCrit,
+		PreviousLevel: influxdb.CheckOK,
+		Message:       "the last value of host=a is 95",
+		Time:          time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("http", func(t *testing.T) {
+		e := &influxdb.NotificationEndpoint{
+			ID:             3,
+			OrganizationID: 2,
+			Type:           influxdb.HTTPEndpointType,
+			URL:            srv.URL,
+			Method:         "PUT",
+			Headers:        map[string]string{"X-Team": "ops"},
+			TokenSecret:    "token",
+		}
+		if err := n.Notify(context.Background(), e, s); err != nil {
+			t.Fatal(err)
+		}
+
+		if method != "PUT" {
+			t.Errorf("got method %s, want PUT", method)
+		}
+		if got := header.Get("Authorization"); got != "Bearer secret-token" {
+			t.Errorf("got authorization %q", got)
+		}
+		if got := header.Get("X-Team"); got != "ops" {
+			t.Errorf("got header X-Team %q", got)
+		}
+		if body["level"] != "crit" || body["previousLevel"] != "ok" || body["checkName"] != "cpu" {
+			t.Errorf("unexpected status %v", body)
+		}
+	})
+
+	t.Run("slack", func(t *testing.T) {
+		e := &influxdb.NotificationEndpoint{
+			ID:             4,
+			OrganizationID: 2,
+			Type:           influxdb.SlackEndpointType,
+			URLSecret:      "url",
+			Channel:        "#alerts",
+		}
+		if err := n.Notify(context.Background(), e, s); err != nil {
+			t.Fatal(err)
+		}
+
+		if method != "POST" {
+			t.Errorf("got method %s, want POST", method)
+		}
+		if body["channel"] != "#alerts" || body["text"] != "Check *cpu* is crit (was ok)" {
+			t.Errorf("unexpected slack message %v", body)
+		}
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer failing.Close()
+
+		e := &influxdb.NotificationEndpoint{
+			ID:   5,
+			Type: influxdb.HTTPEndpointType,
+			URL:  failing.URL,
+		}
+		if err := n.Notify(context.Background(), e, s); err == nil {
+			t.Fatal("expected error for failing endpoint")
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		done := make(chan struct{})
+		hanging := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-done
+		}))
+		defer hanging.Close()
+		defer close(done)
+
+		e := &influxdb.NotificationEndpoint{
+			ID:   6,
+			Type: influxdb.HTTPEndpointType,
+			URL:  hanging.URL,
+		}
+		n := &Notifier{Client: &http.Client{Timeout: 10 * time.Millisecond}}
+		if err := n.Notify(context.Background(), e, s); err == nil {
+			t.Fatal("expected error for endpoint that does not respond")
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package checks
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.CheckService = (*CheckService)(nil)
+
+// CheckService wraps a influxdb.CheckService and keeps the schedule of the
+// evaluator in sync with the checks it stores.
+type CheckService struct {
+	influxdb.CheckService
+
+	e *Evaluator
+}
+
+// NewCheckService returns a check service that schedules the checks of s
+// on the evaluator e.
+func NewCheckService(s influxdb.CheckService, e *Evaluator) *CheckService {
+	return &CheckService{
+		CheckService: s,
+		e:            e,
+	}
+}
+
+// CreateCheck creates the check c and schedules its evaluation.
+func (s *CheckService) CreateCheck(ctx context.Context, c *influxdb.Check) error {
+	if err := Validate(c); err != nil {
+		return err
+	}
+
+	if err := s.CheckService.CreateCheck(ctx, c); err != nil {
+		return err
+	}
+
+	return s.e.Schedule(ctx, c)
+}
+
+// UpdateCheck replaces the check with the ID id, and reschedules its evaluation.
+func (s *CheckService) UpdateCheck(ctx context.Context, id influxdb.ID, upd *influxdb.Check) (*influxdb.Check, error) {
+	if err := Validate(upd); err != nil {
+		return nil, err
+	}
+
+	c, err := s.CheckService.UpdateCheck(ctx, id, upd)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.e.Schedule(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// DeleteCheck deletes the check with the ID id, and stops its evaluation.
+func (s *CheckService) DeleteCheck(ctx context.Context, id influxdb.ID) error {
+	if err := s.CheckService.DeleteCheck(ctx, id); err != nil {
+		return err
+	}
+
+	return s.e.Unschedule(ctx, id)
+}
@@ -0,0 +1,172 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/influxdb"
+)
+
+// Status is the status level of a check at the time it was evaluated.
+type Status struct {
+	CheckID   influxdb.ID `json:"checkID"`
+	CheckName string      `json:"checkName"`
+	OrgID     influxdb.ID `json:"orgID"`
+
+	Level influxdb.CheckLevel `json:"level"`
+	// PreviousLevel is empty for the first status of a check.
+	PreviousLevel influxdb.CheckLevel `json:"previousLevel,omitempty"`
+
+	Message string    `json:"message"`
+	Time    time.Time `json:"time"`
+}
+
+// series is the last row of a table of the results of the query of a check.
+type series struct {
+	// key identifies the series by the values of its group key.
+	key string
+
+	// value is the last non null value of the series, if valid.
+	value float64
+	valid bool
+
+	// last is the time of the last row of the series.
+	last time.Time
+}
+
+// readSeries reads the last value and time of the tables of the results.
+func readSeries(ctx context.Context, it flux.ResultIterator) ([]series, error) {
+	var ss []series
+	for it.More() {
+		res := it.Next()
+		err := res.Tables().Do(func(tbl flux.Table) error {
+			s := series{key: seriesKey(tbl.Key())}
+			valueIdx := execute.ColIdx(execute.DefaultValueColLabel, tbl.Cols())
+			timeIdx := execute.ColIdx(execute.DefaultTimeColLabel, tbl.Cols())
+
+			err := tbl.Do(func(cr flux.ColReader) error {
+				for i := 0; i < cr.Len(); i++ {
+					if timeIdx >= 0 && tbl.Cols()[timeIdx].Type == flux.TTime {
+						if ts := cr.Times(timeIdx); ts.IsValid(i) {
+							if t := time.Unix(0, ts.Value(i)); t.After(s.last) {
+								s.last = t
+							}
+						}
+					}
+					if valueIdx < 0 {
+						continue
+					}
+					if v, ok := floatValue(cr, tbl.Cols()[valueIdx].Type, valueIdx, i); ok {
+						s.value, s.valid = v, true
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			ss = append(ss, s)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ss, it.Err()
+}
+
+// floatValue returns the value of the row i of the column j as a float, if
+// it is a valid number.
+func floatValue(cr flux.ColReader, typ flux.ColType, j, i int) (float64, bool) {
+	switch typ {
+	case flux.TFloat:
+		if vs := cr.Floats(j); vs.IsValid(i) {
+			return vs.Value(i), true
+		}
+	case flux.TInt:
+		if vs := cr.Ints(j); vs.IsValid(i) {
+			return float64(vs.Value(i)), true
+		}
+	case flux.TUInt:
+		if vs := cr.UInts(j); vs.IsValid(i) {
+			return float64(vs.Value(i)), true
+		}
+	}
+	return 0, false
+}
+
+// seriesKey returns the string columns of the group key, such as the
+// measurement and tags of a series, sorted by label.
+func seriesKey(key flux.GroupKey) string {
+	var pairs []string
+	for j, c := range key.Cols() {
+		if c.Type != flux.TString || key.IsNull(j) {
+			continue
+		}
+		pairs = append(pairs, c.Label+"="+key.ValueString(j))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// level returns the level of the check c for the series of its query
+// evaluated at now, and a message describing it.
+func level(c *influxdb.Check, ss []series, now time.Time) (influxdb.CheckLevel, string) {
+	switch c.Type {
+	case influxdb.ThresholdCheckType:
+		return thresholdLevel(c, ss)
+	case influxdb.DeadmanCheckType:
+		return deadmanLevel(c, ss, now)
+	}
+	return influxdb.CheckUnknown, fmt.Sprintf("unknown check type %q", c.Type)
+}
+
+func thresholdLevel(c *influxdb.Check, ss []series) (influxdb.CheckLevel, string) {
+	lvl, msg := influxdb.CheckUnknown, "the query returned no values"
+	for _, s := range ss {
+		if !s.valid {
+			continue
+		}
+
+		l := influxdb.CheckOK
+		for _, t := range c.Thresholds {
+			if t.Contains(s.value) && t.Level.Severity() > l.Severity() {
+				l = t.Level
+			}
+		}
+
+		if l.Severity() > lvl.Severity() {
+			lvl = l
+			msg = fmt.Sprintf("the last value of %s is %g", s.key, s.value)
+		}
+	}
+	return lvl, msg
+}
+
+func deadmanLevel(c *influxdb.Check, ss []series, now time.Time) (influxdb.CheckLevel, string) {
+	since, err := c.TimeSinceDuration()
+	if err != nil {
+		return influxdb.CheckUnknown, err.Error()
+	}
+
+	var last time.Time
+	for _, s := range ss {
+		if s.last.After(last) {
+			last = s.last
+		}
+	}
+
+	switch {
+	case last.IsZero():
+		return c.Level, "the query returned no data"
+	case now.Sub(last) > since:
+		return c.Level, fmt.Sprintf("the query returned no data since %s", last.UTC().Format(time.RFC3339))
+	}
+	return influxdb.CheckOK, fmt.Sprintf("the query returned data at %s", last.UTC().Format(time.RFC3339))
+}
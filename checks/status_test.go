@@ -0,0 +1,103 @@
+package checks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+func float(f float64) *float64 { return &f }
+
+func TestLevel_Threshold(t *testing.T) {
+	c := &influxdb.Check{
+		Type: influxdb.ThresholdCheckType,
+		Thresholds: []influxdb.CheckThreshold{
+			{Level: influxdb.CheckWarn, Min: float(80)},
+			{Level: influxdb.CheckCrit, Min: float(90)},
+		},
+	}
+
+	tests := []struct {
+		name string
+		ss   []series
+		want influxdb.CheckLevel
+	}{
+		{
+			name: "no values",
+			want: influxdb.CheckUnknown,
+		},
+		{
+			name: "null values",
+			ss:   []series{{key: "host=a"}},
+			want: influxdb.CheckUnknown,
+		},
+		{
+			name: "ok",
+			ss:   []series{{key: "host=a", value: 50, valid: true}},
+			want: influxdb.CheckOK,
+		},
+		{
+			name: "most severe threshold",
+			ss:   []series{{key: "host=a", value: 95, valid: true}},
+			want: influxdb.CheckCrit,
+		},
+		{
+			name: "most severe series",
+			ss: []series{
+				{key: "host=a", value: 50, valid: true},
+				{key: "host=b", value: 85, valid: true},
+			},
+			want: influxdb.CheckWarn,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, msg := level(c, tt.ss, time.Now()); got != tt.want {
+				t.Errorf("got level %s (%s), want %s", got, msg, tt.want)
+			}
+		})
+	}
+}
+
+func TestLevel_Deadman(t *testing.T) {
+	now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := &influxdb.Check{
+		Type:      influxdb.DeadmanCheckType,
+		TimeSince: "5m",
+		Level:     influxdb.CheckCrit,
+	}
+
+	tests := []struct {
+		name string
+		ss   []series
+		want influxdb.CheckLevel
+	}{
+		{
+			name: "no data",
+			want: influxdb.CheckCrit,
+		},
+		{
+			name: "stale data",
+			ss:   []series{{key: "host=a", last: now.Add(-10 * time.Minute)}},
+			want: influxdb.CheckCrit,
+		},
+		{
+			name: "recent data",
+			ss: []series{
+				{key: "host=a", last: now.Add(-10 * time.Minute)},
+				{key: "host=b", last: now.Add(-time.Minute)},
+			},
+			want: influxdb.CheckOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, msg := level(c, tt.ss, now); got != tt.want {
+				t.Errorf("got level %s (%s), want %s", got, msg, tt.want)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"time"
 
 	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/bolt"
@@ -34,6 +36,7 @@ type AuthorizationCreateFlags struct {
 
 	writeBucketPermissions []string
 	readBucketPermissions  []string
+	bucketPredicate        string
 
 	writeTasksPermission bool
 	readTasksPermission  bool
@@ -46,6 +49,8 @@ type AuthorizationCreateFlags struct {
 
 	writeDashboardsPermission bool
 	readDashboardsPermission  bool
+
+	expiresIn time.Duration
 }
 
 var authorizationCreateFlags AuthorizationCreateFlags
@@ -70,6 +75,7 @@ func init() {
 
 	authorizationCreateCmd.Flags().StringArrayVarP(&authorizationCreateFlags.writeBucketPermissions, "write-bucket", "", []string{}, "The bucket id")
 	authorizationCreateCmd.Flags().StringArrayVarP(&authorizationCreateFlags.readBucketPermissions, "read-bucket", "", []string{}, "The bucket id")
+	authorizationCreateCmd.Flags().StringVarP(&authorizationCreateFlags.bucketPredicate, "predicate", "", "", "Restricts the bucket permissions to the series matching the predicate, e.g. _measurement=~/^public_/")
 
 	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.writeTasksPermission, "write-tasks", "", false, "Grants the permission to create tasks")
 	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.readTasksPermission, "read-tasks", "", false, "Grants the permission to read tasks")
@@ -83,6 +89,8 @@ func init() {
 	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.writeDashboardsPermission, "write-dashboards", "", false, "Grants the permission to create dashboards")
 	authorizationCreateCmd.Flags().BoolVarP(&authorizationCreateFlags.readDashboardsPermission, "read-dashboards", "", false, "Grants the permission to read dashboards")
 
+	authorizationCreateCmd.Flags().DurationVarP(&authorizationCreateFlags.expiresIn, "expires-in", "", 0, "The duration after which the authorization expires, e.g. 720h; it does not expire if unset")
+
 	authorizationCmd.AddCommand(authorizationCreateCmd)
 }
 
@@ -159,6 +167,19 @@ func authorizationCreateF(cmd *cobra.Command, args []string) error {
 		permissions = append(permissions, *p)
 	}
 
+	if authorizationCreateFlags.bucketPredicate != "" {
+		restricted := 0
+		for i := range permissions {
+			if permissions[i].Resource.Type == platform.BucketsResourceType {
+				permissions[i].Predicate = authorizationCreateFlags.bucketPredicate
+				restricted++
+			}
+		}
+		if restricted == 0 {
+			return fmt.Errorf("a predicate requires bucket permissions")
+		}
+	}
+
 	if authorizationCreateFlags.writeTasksPermission {
 		p, err := platform.NewPermission(platform.WriteAction, platform.TasksResourceType, o.ID)
 		if err != nil {
@@ -228,6 +249,14 @@ func authorizationCreateF(cmd *cobra.Command, args []string) error {
 		OrgID:       o.ID,
 	}
 
+	if authorizationCreateFlags.expiresIn < 0 {
+		return fmt.Errorf("expires-in must not be negative")
+	}
+	if authorizationCreateFlags.expiresIn > 0 {
+		expiresAt := time.Now().Add(authorizationCreateFlags.expiresIn).UTC()
+		authorization.ExpiresAt = &expiresAt
+	}
+
 	s, err := newAuthorizationService(flags)
 	if err != nil {
 		return err
@@ -244,6 +273,7 @@ func authorizationCreateF(cmd *cobra.Command, args []string) error {
 		"Status",
 		"UserID",
 		"Permissions",
+		"ExpiresAt",
 	)
 
 	ps := []string{}
@@ -257,6 +287,7 @@ func authorizationCreateF(cmd *cobra.Command, args []string) error {
 		"Status":      authorization.Status,
 		"UserID":      authorization.UserID.String(),
 		"Permissions": ps,
+		"ExpiresAt":   formatAuthorizationTime(authorization.ExpiresAt),
 	})
 
 	w.Flush()
@@ -345,6 +376,8 @@ func authorizationFindF(cmd *cobra.Command, args []string) error {
 		"User",
 		"UserID",
 		"Permissions",
+		"ExpiresAt",
+		"LastUsedAt",
 	)
 
 	for _, a := range authorizations {
@@ -359,6 +392,8 @@ func authorizationFindF(cmd *cobra.Command, args []string) error {
 			"Status":      a.Status,
 			"UserID":      a.UserID.String(),
 			"Permissions": permissions,
+			"ExpiresAt":   formatAuthorizationTime(a.ExpiresAt),
+			"LastUsedAt":  formatAuthorizationTime(a.LastUsedAt),
 		})
 	}
 
@@ -573,3 +608,81 @@ func authorizationInactiveF(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// AuthorizationRotateFlags are command line args used when rotating the token of an authorization
+type AuthorizationRotateFlags struct {
+	id    string
+	grace time.Duration
+}
+
+var authorizationRotateFlags AuthorizationRotateFlags
+
+func init() {
+	authorizationRotateCmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Issue a new token for an authorization",
+		RunE:  wrapCheckSetup(authorizationRotateF),
+	}
+
+	authorizationRotateCmd.Flags().StringVarP(&authorizationRotateFlags.id, "id", "i", "", "The authorization ID (required)")
+	authorizationRotateCmd.MarkFlagRequired("id")
+	authorizationRotateCmd.Flags().DurationVarP(&authorizationRotateFlags.grace, "grace", "", 0, "The duration during which the previous token is still valid, e.g. 1h")
+
+	authorizationCmd.AddCommand(authorizationRotateCmd)
+}
+
+func authorizationRotateF(cmd *cobra.Command, args []string) error {
+	s, err := newAuthorizationService(flags)
+	if err != nil {
+		return err
+	}
+
+	var id platform.ID
+	if err := id.DecodeFromString(authorizationRotateFlags.id); err != nil {
+		return err
+	}
+
+	if authorizationRotateFlags.grace < 0 {
+		return fmt.Errorf("grace must not be negative")
+	}
+
+	a, err := s.RotateAuthorization(context.Background(), id, authorizationRotateFlags.grace)
+	if err != nil {
+		return err
+	}
+
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"ID",
+		"Token",
+		"Status",
+		"UserID",
+		"Permissions",
+		"ExpiresAt",
+	)
+
+	ps := []string{}
+	for _, p := range a.Permissions {
+		ps = append(ps, p.String())
+	}
+
+	w.Write(map[string]interface{}{
+		"ID":          a.ID.String(),
+		"Token":       a.Token,
+		"Status":      a.Status,
+		"UserID":      a.UserID.String(),
+		"Permissions": ps,
+		"ExpiresAt":   formatAuthorizationTime(a.ExpiresAt),
+	})
+
+	w.Flush()
+
+	return nil
+}
+
+func formatAuthorizationTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/http"
+	"github.com/influxdata/influxdb/internal/fs"
+	"github.com/influxdata/influxdb/storage"
+	"github.com/spf13/cobra"
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Backup the data in InfluxDB",
+	Long: `Backup the metadata and time series data of InfluxDB into a directory.
+When --since is given, only the time series data modified after it is part of
+the backup, which can be restored on top of an earlier backup.`,
+	Args: cobra.NoArgs,
+	RunE: wrapCheckSetup(backupF),
+}
+
+var backupFlags struct {
+	Path  string
+	Since string
+}
+
+func init() {
+	backupCmd.PersistentFlags().StringVarP(&backupFlags.Path, "path", "p", "", "The directory to write the backup to; it must not exist or be empty")
+	backupCmd.MarkPersistentFlagRequired("path")
+	backupCmd.PersistentFlags().StringVar(&backupFlags.Since, "since", "", "Only backup the time series data modified after this time, in RFC3339 format, e.g. 2009-01-02T23:00:00Z")
+}
+
+func backupF(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	var since time.Time
+	if backupFlags.Since != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339Nano, backupFlags.Since); err != nil {
+			cmd.Usage()
+			return fmt.Errorf("invalid since time: %v", err)
+		}
+	}
+
+	if err := ensureEmptyDir(backupFlags.Path); err != nil {
+		return err
+	}
+
+	s := &http.BackupService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	id, files, err := s.CreateBackup(ctx, since)
+	if err != nil {
+		return fmt.Errorf("failed to create backup: %v", err)
+	}
+
+	for _, file := range files {
+		if err := fetchBackupFile(ctx, s, id, file); err != nil {
+			s.RemoveBackup(ctx, id)
+			return fmt.Errorf("failed to fetch backup file %q: %v", file, err)
+		}
+	}
+
+	if err := s.RemoveBackup(ctx, id); err != nil {
+		return fmt.Errorf("failed to remove backup from server: %v", err)
+	}
+
+	fmt.Printf("Backup of %d files written to %s\n", len(files), backupFlags.Path)
+	return nil
+}
+
+func fetchBackupFile(ctx context.Context, s *http.BackupService, id int, file string) error {
+	path := filepath.Join(backupFlags.Path, filepath.FromSlash(file))
+	if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+
+	if err := s.FetchBackupFile(ctx, id, file, f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restore a backup of InfluxDB",
+	Long: `Restore one or more backups created by influx backup into the local
+bolt and engine paths of influxd, which must not be running. The inputs are
+restored in order, so a full backup must be followed by its incremental backups.`,
+	Args: cobra.NoArgs,
+	RunE: wrapErrorFmt(restoreF),
+}
+
+var restoreFlags struct {
+	Inputs     []string
+	BoltPath   string
+	EnginePath string
+}
+
+func init() {
+	dir, err := fs.InfluxDir()
+	if err != nil {
+		panic(fmt.Sprintf("failed to determine influx directory: %v", err))
+	}
+
+	restoreCmd.PersistentFlags().StringArrayVarP(&restoreFlags.Inputs, "input", "i", nil, "The directory of a backup to restore; may be repeated, from the oldest to the newest backup")
+	restoreCmd.MarkPersistentFlagRequired("input")
+	restoreCmd.PersistentFlags().StringVar(&restoreFlags.BoltPath, "bolt-path", filepath.Join(dir, "influxd.bolt"), "The path to restore the boltdb database to")
+	restoreCmd.PersistentFlags().StringVar(&restoreFlags.EnginePath, "engine-path", filepath.Join(dir, "engine"), "The path to restore the engine files to")
+}
+
+func restoreF(cmd *cobra.Command, args []string) error {
+	if _, err := os.Stat(restoreFlags.BoltPath); err == nil {
+		return fmt.Errorf("cannot restore into %s: file already exists", restoreFlags.BoltPath)
+	}
+
+	if err := storage.RestoreBackup(restoreFlags.EnginePath, storage.NewConfig(), restoreFlags.Inputs...); err != nil {
+		return fmt.Errorf("failed to restore engine: %v", err)
+	}
+
+	// The metadata is restored from the newest backup.
+	last := restoreFlags.Inputs[len(restoreFlags.Inputs)-1]
+	if err := os.MkdirAll(filepath.Dir(restoreFlags.BoltPath), 0700); err != nil {
+		return err
+	}
+	if err := copyFile(filepath.Join(last, influxdb.BackupBoltFile), restoreFlags.BoltPath); err != nil {
+		return fmt.Errorf("failed to restore bolt database: %v", err)
+	}
+
+	fmt.Printf("Restored %d backups to %s and %s\n", len(restoreFlags.Inputs), restoreFlags.BoltPath, restoreFlags.EnginePath)
+	return nil
+}
+
+// ensureEmptyDir creates the directory path if it does not exist, and returns
+// an error if it exists and is not empty.
+func ensureEmptyDir(path string) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return os.MkdirAll(path, 0777)
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if names, _ := f.Readdirnames(1); len(names) > 0 {
+		return fmt.Errorf("cannot backup into %s: directory is not empty", path)
+	}
+	return nil
+}
+
+// copyFile copies the file src to dst, which must not exist.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
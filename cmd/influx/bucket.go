@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	platform "github.com/influxdata/influxdb"
@@ -27,10 +28,12 @@ func bucketF(cmd *cobra.Command, args []string) {
 
 // BucketCreateFlags define the Create Command
 type BucketCreateFlags struct {
-	name      string
-	org       string
-	orgID     string
-	retention time.Duration
+	name               string
+	org                string
+	orgID              string
+	retention          time.Duration
+	shardGroupDuration time.Duration
+	maxSeries          int64
 }
 
 var bucketCreateFlags BucketCreateFlags
@@ -44,6 +47,8 @@ func init() {
 
 	bucketCreateCmd.Flags().StringVarP(&bucketCreateFlags.name, "name", "n", "", "Name of bucket that will be created")
 	bucketCreateCmd.Flags().DurationVarP(&bucketCreateFlags.retention, "retention", "r", 0, "Duration in nanoseconds data will live in bucket")
+	bucketCreateCmd.Flags().DurationVarP(&bucketCreateFlags.shardGroupDuration, "shard-group-duration", "", 0, "Duration of the shard groups expired data is dropped by; derived from the retention by default")
+	bucketCreateCmd.Flags().Int64VarP(&bucketCreateFlags.maxSeries, "max-series", "", 0, "Maximum number of series of the bucket; unlimited by default")
 	bucketCreateCmd.Flags().StringVarP(&bucketCreateFlags.org, "org", "o", "", "Name of the organization that owns the bucket")
 	bucketCreateCmd.Flags().StringVarP(&bucketCreateFlags.orgID, "org-id", "", "", "The ID of the organization that owns the bucket")
 	bucketCreateCmd.MarkFlagRequired("name")
@@ -83,8 +88,10 @@ func bucketCreateF(cmd *cobra.Command, args []string) error {
 	}
 
 	b := &platform.Bucket{
-		Name:            bucketCreateFlags.name,
-		RetentionPeriod: bucketCreateFlags.retention,
+		Name:               bucketCreateFlags.name,
+		RetentionPeriod:    bucketCreateFlags.retention,
+		ShardGroupDuration: bucketCreateFlags.shardGroupDuration,
+		MaxSeries:          bucketCreateFlags.maxSeries,
 	}
 
 	if bucketCreateFlags.org != "" {
@@ -195,6 +202,7 @@ func bucketFindF(cmd *cobra.Command, args []string) error {
 		"Retention",
 		"Organization",
 		"OrganizationID",
+		"Downsampling",
 	)
 	for _, b := range buckets {
 		w.Write(map[string]interface{}{
@@ -203,6 +211,7 @@ func bucketFindF(cmd *cobra.Command, args []string) error {
 			"Retention":      b.RetentionPeriod,
 			"Organization":   b.Organization,
 			"OrganizationID": b.OrganizationID.String(),
+			"Downsampling":   formatDownsamplingPolicies(b.DownsamplingPolicies),
 		})
 	}
 	w.Flush()
@@ -210,11 +219,22 @@ func bucketFindF(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// formatDownsamplingPolicies lists the destination and window of each policy.
+func formatDownsamplingPolicies(ps []platform.DownsamplingPolicy) string {
+	policies := make([]string, 0, len(ps))
+	for _, p := range ps {
+		policies = append(policies, fmt.Sprintf("%s/%s", p.DestinationBucketID, p.Window))
+	}
+	return strings.Join(policies, ",")
+}
+
 // BucketUpdateFlags define the Update Command
 type BucketUpdateFlags struct {
-	id        string
-	name      string
-	retention time.Duration
+	id                 string
+	name               string
+	retention          time.Duration
+	shardGroupDuration time.Duration
+	maxSeries          int64
 }
 
 var bucketUpdateFlags BucketUpdateFlags
@@ -229,6 +249,8 @@ func init() {
 	bucketUpdateCmd.Flags().StringVarP(&bucketUpdateFlags.id, "id", "i", "", "The bucket ID (required)")
 	bucketUpdateCmd.Flags().StringVarP(&bucketUpdateFlags.name, "name", "n", "", "New bucket name")
 	bucketUpdateCmd.Flags().DurationVarP(&bucketUpdateFlags.retention, "retention", "r", 0, "New duration data will live in bucket")
+	bucketUpdateCmd.Flags().DurationVarP(&bucketUpdateFlags.shardGroupDuration, "shard-group-duration", "", 0, "New duration of the shard groups expired data is dropped by")
+	bucketUpdateCmd.Flags().Int64VarP(&bucketUpdateFlags.maxSeries, "max-series", "", 0, "New maximum number of series of the bucket; 0 removes the limit")
 	bucketUpdateCmd.MarkFlagRequired("id")
 
 	bucketCmd.AddCommand(bucketUpdateCmd)
@@ -252,6 +274,12 @@ func bucketUpdateF(cmd *cobra.Command, args []string) error {
 	if bucketUpdateFlags.retention != 0 {
 		update.RetentionPeriod = &bucketUpdateFlags.retention
 	}
+	if bucketUpdateFlags.shardGroupDuration != 0 {
+		update.ShardGroupDuration = &bucketUpdateFlags.shardGroupDuration
+	}
+	if cmd.Flags().Changed("max-series") {
+		update.MaxSeries = &bucketUpdateFlags.maxSeries
+	}
 
 	b, err := s.UpdateBucket(context.Background(), id, update)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/influxdata/flux/repl"
 	platform "github.com/influxdata/influxdb"
@@ -48,9 +49,20 @@ func runF(cmd *cobra.Command, args []string) {
 	cmd.Usage()
 }
 
+var backfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "Backfill related commands",
+	Run:   backfillF,
+}
+
+func backfillF(cmd *cobra.Command, args []string) {
+	cmd.Usage()
+}
+
 func init() {
 	taskCmd.AddCommand(runCmd)
 	taskCmd.AddCommand(logCmd)
+	taskCmd.AddCommand(backfillCmd)
 }
 
 // TaskCreateFlags define the Create Command
@@ -585,3 +597,169 @@ func runRetryF(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+type BackfillCreateFlags struct {
+	taskID, start, stop string
+}
+
+var backfillCreateFlags BackfillCreateFlags
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "run a task for each of its schedules within a past time range",
+		RunE:  wrapCheckSetup(backfillCreateF),
+	}
+
+	cmd.Flags().StringVarP(&backfillCreateFlags.taskID, "task-id", "i", "", "task id (required)")
+	cmd.Flags().StringVarP(&backfillCreateFlags.start, "start", "", "", "earliest schedule to run, RFC3339 (required)")
+	cmd.Flags().StringVarP(&backfillCreateFlags.stop, "stop", "", "", "latest schedule to run, RFC3339; defaults to the current time of the server")
+	cmd.MarkFlagRequired("task-id")
+	cmd.MarkFlagRequired("start")
+
+	backfillCmd.AddCommand(cmd)
+}
+
+func backfillCreateF(cmd *cobra.Command, args []string) error {
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var taskID platform.ID
+	if err := taskID.DecodeFromString(backfillCreateFlags.taskID); err != nil {
+		return err
+	}
+
+	start, err := time.Parse(time.RFC3339, backfillCreateFlags.start)
+	if err != nil {
+		return err
+	}
+	// The server defaults the stop to its current time.
+	var stop int64
+	if backfillCreateFlags.stop != "" {
+		t, err := time.Parse(time.RFC3339, backfillCreateFlags.stop)
+		if err != nil {
+			return err
+		}
+		stop = t.Unix()
+	}
+
+	b, err := s.CreateBackfill(context.Background(), taskID, start.Unix(), stop)
+	if err != nil {
+		return err
+	}
+
+	writeBackfills(b)
+	return nil
+}
+
+type BackfillFindFlags struct {
+	taskID string
+}
+
+var backfillFindFlags BackfillFindFlags
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "find",
+		Short: "find the backfills of a task in progress",
+		RunE:  wrapCheckSetup(backfillFindF),
+	}
+
+	cmd.Flags().StringVarP(&backfillFindFlags.taskID, "task-id", "i", "", "task id (required)")
+	cmd.MarkFlagRequired("task-id")
+
+	backfillCmd.AddCommand(cmd)
+}
+
+func backfillFindF(cmd *cobra.Command, args []string) error {
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var taskID platform.ID
+	if err := taskID.DecodeFromString(backfillFindFlags.taskID); err != nil {
+		return err
+	}
+
+	bs, _, err := s.FindBackfills(context.Background(), taskID)
+	if err != nil {
+		return err
+	}
+
+	writeBackfills(bs...)
+	return nil
+}
+
+type BackfillCancelFlags struct {
+	taskID, backfillID string
+}
+
+var backfillCancelFlags BackfillCancelFlags
+
+func init() {
+	cmd := &cobra.Command{
+		Use:   "cancel",
+		Short: "cancel a backfill",
+		RunE:  wrapCheckSetup(backfillCancelF),
+	}
+
+	cmd.Flags().StringVarP(&backfillCancelFlags.taskID, "task-id", "i", "", "task id (required)")
+	cmd.Flags().StringVarP(&backfillCancelFlags.backfillID, "backfill-id", "b", "", "backfill id (required)")
+	cmd.MarkFlagRequired("task-id")
+	cmd.MarkFlagRequired("backfill-id")
+
+	backfillCmd.AddCommand(cmd)
+}
+
+func backfillCancelF(cmd *cobra.Command, args []string) error {
+	s := &http.TaskService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}
+
+	var taskID, backfillID platform.ID
+	if err := taskID.DecodeFromString(backfillCancelFlags.taskID); err != nil {
+		return err
+	}
+	if err := backfillID.DecodeFromString(backfillCancelFlags.backfillID); err != nil {
+		return err
+	}
+
+	if err := s.CancelBackfill(context.Background(), taskID, backfillID); err != nil {
+		return err
+	}
+
+	fmt.Printf("Backfill %s of task %s canceled.\n", backfillID, taskID)
+
+	return nil
+}
+
+func writeBackfills(bs ...*platform.Backfill) {
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"ID",
+		"TaskID",
+		"Start",
+		"Stop",
+		"RequestedAt",
+		"Runs",
+		"Completed",
+		"Running",
+	)
+	for _, b := range bs {
+		w.Write(map[string]interface{}{
+			"ID":          b.ID,
+			"TaskID":      b.TaskID,
+			"Start":       b.Start,
+			"Stop":        b.Stop,
+			"RequestedAt": b.RequestedAt,
+			"Runs":        b.Runs,
+			"Completed":   b.Completed,
+			"Running":     b.Running,
+		})
+	}
+	w.Flush()
+}
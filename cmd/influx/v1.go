@@ -0,0 +1,217 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/cmd/influx/internal"
+	"github.com/influxdata/influxdb/http"
+	"github.com/spf13/cobra"
+)
+
+// v1 Command
+var v1Cmd = &cobra.Command{
+	Use:   "v1",
+	Short: "InfluxDB 1.x compatibility commands",
+	Run:   v1F,
+}
+
+func v1F(cmd *cobra.Command, args []string) {
+	cmd.Usage()
+}
+
+var dbrpCmd = &cobra.Command{
+	Use:   "dbrp",
+	Short: "Mappings of 1.x databases and retention policies to buckets",
+	Long: `Mappings of 1.x databases and retention policies to buckets.
+
+Creating a bucket maps the database named after it, and its retention
+policy or autogen, to the bucket, unless that database and retention policy
+are mapped already. Databases are shared by all organizations, so the first
+bucket created with a name claims its database; buckets of the same name
+created later must be mapped explicitly under another database.`,
+	Run: dbrpF,
+}
+
+func dbrpF(cmd *cobra.Command, args []string) {
+	cmd.Usage()
+}
+
+func init() {
+	v1Cmd.AddCommand(dbrpCmd)
+}
+
+func newDBRPMappingService() (platform.DBRPMappingService, error) {
+	if flags.local {
+		return nil, fmt.Errorf("local flag not supported for dbrp command")
+	}
+
+	return &http.DBRPMappingService{
+		Addr:  flags.host,
+		Token: flags.token,
+	}, nil
+}
+
+func writeDBRPMappings(ms ...*platform.DBRPMapping) {
+	w := internal.NewTabWriter(os.Stdout)
+	w.WriteHeaders(
+		"Database",
+		"RetentionPolicy",
+		"Default",
+		"OrganizationID",
+		"BucketID",
+	)
+	for _, m := range ms {
+		w.Write(map[string]interface{}{
+			"Database":        m.Database,
+			"RetentionPolicy": m.RetentionPolicy,
+			"Default":         m.Default,
+			"OrganizationID":  m.OrganizationID.String(),
+			"BucketID":        m.BucketID.String(),
+		})
+	}
+	w.Flush()
+}
+
+// DBRPCreateFlags define the Create Command
+type DBRPCreateFlags struct {
+	db        string
+	rp        string
+	bucketID  string
+	isDefault bool
+}
+
+var dbrpCreateFlags DBRPCreateFlags
+
+func init() {
+	dbrpCreateCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Map a database and retention policy to a bucket",
+		RunE:  wrapCheckSetup(dbrpCreateF),
+	}
+
+	dbrpCreateCmd.Flags().StringVarP(&dbrpCreateFlags.db, "db", "d", "", "Name of the database (required)")
+	dbrpCreateCmd.Flags().StringVarP(&dbrpCreateFlags.rp, "rp", "r", "", "Name of the retention policy (required)")
+	dbrpCreateCmd.Flags().StringVarP(&dbrpCreateFlags.bucketID, "bucket-id", "b", "", "The ID of the bucket to map to (required)")
+	dbrpCreateCmd.Flags().BoolVarP(&dbrpCreateFlags.isDefault, "default", "", false, "Use the retention policy when a request to the database does not name one")
+	dbrpCreateCmd.MarkFlagRequired("db")
+	dbrpCreateCmd.MarkFlagRequired("rp")
+	dbrpCreateCmd.MarkFlagRequired("bucket-id")
+
+	dbrpCmd.AddCommand(dbrpCreateCmd)
+}
+
+func dbrpCreateF(cmd *cobra.Command, args []string) error {
+	s, err := newDBRPMappingService()
+	if err != nil {
+		return err
+	}
+
+	var bucketID platform.ID
+	if err := bucketID.DecodeFromString(dbrpCreateFlags.bucketID); err != nil {
+		return fmt.Errorf("failed to decode bucket id %q: %v", dbrpCreateFlags.bucketID, err)
+	}
+
+	m := &platform.DBRPMapping{
+		Cluster:         platform.DefaultDBRPCluster,
+		Database:        dbrpCreateFlags.db,
+		RetentionPolicy: dbrpCreateFlags.rp,
+		Default:         dbrpCreateFlags.isDefault,
+		BucketID:        bucketID,
+	}
+	if err := s.Create(context.Background(), m); err != nil {
+		return fmt.Errorf("failed to create dbrp mapping: %v", err)
+	}
+
+	writeDBRPMappings(m)
+	return nil
+}
+
+// DBRPFindFlags define the Find Command
+type DBRPFindFlags struct {
+	db string
+	rp string
+}
+
+var dbrpFindFlags DBRPFindFlags
+
+func init() {
+	dbrpFindCmd := &cobra.Command{
+		Use:   "find",
+		Short: "Find the mappings of databases and retention policies",
+		RunE:  wrapCheckSetup(dbrpFindF),
+	}
+
+	dbrpFindCmd.Flags().StringVarP(&dbrpFindFlags.db, "db", "d", "", "Name of the database")
+	dbrpFindCmd.Flags().StringVarP(&dbrpFindFlags.rp, "rp", "r", "", "Name of the retention policy")
+
+	dbrpCmd.AddCommand(dbrpFindCmd)
+}
+
+func dbrpFindF(cmd *cobra.Command, args []string) error {
+	s, err := newDBRPMappingService()
+	if err != nil {
+		return err
+	}
+
+	filter := platform.DBRPMappingFilter{}
+	if dbrpFindFlags.db != "" {
+		filter.Database = &dbrpFindFlags.db
+	}
+	if dbrpFindFlags.rp != "" {
+		filter.RetentionPolicy = &dbrpFindFlags.rp
+	}
+
+	ms, _, err := s.FindMany(context.Background(), filter)
+	if err != nil {
+		return fmt.Errorf("failed to find dbrp mappings: %v", err)
+	}
+
+	writeDBRPMappings(ms...)
+	return nil
+}
+
+// DBRPDeleteFlags define the Delete Command
+type DBRPDeleteFlags struct {
+	db string
+	rp string
+}
+
+var dbrpDeleteFlags DBRPDeleteFlags
+
+func init() {
+	dbrpDeleteCmd := &cobra.Command{
+		Use:   "delete",
+		Short: "Delete the mapping of a database and retention policy",
+		RunE:  wrapCheckSetup(dbrpDeleteF),
+	}
+
+	dbrpDeleteCmd.Flags().StringVarP(&dbrpDeleteFlags.db, "db", "d", "", "Name of the database (required)")
+	dbrpDeleteCmd.Flags().StringVarP(&dbrpDeleteFlags.rp, "rp", "r", "", "Name of the retention policy (required)")
+	dbrpDeleteCmd.MarkFlagRequired("db")
+	dbrpDeleteCmd.MarkFlagRequired("rp")
+
+	dbrpCmd.AddCommand(dbrpDeleteCmd)
+}
+
+func dbrpDeleteF(cmd *cobra.Command, args []string) error {
+	s, err := newDBRPMappingService()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	m, err := s.FindBy(ctx, platform.DefaultDBRPCluster, dbrpDeleteFlags.db, dbrpDeleteFlags.rp)
+	if err != nil {
+		return fmt.Errorf("failed to find dbrp mapping: %v", err)
+	}
+
+	if err := s.Delete(ctx, platform.DefaultDBRPCluster, dbrpDeleteFlags.db, dbrpDeleteFlags.rp); err != nil {
+		return fmt.Errorf("failed to delete dbrp mapping: %v", err)
+	}
+
+	writeDBRPMappings(m)
+	return nil
+}
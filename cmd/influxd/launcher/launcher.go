@@ -16,7 +16,9 @@ import (
 	"github.com/influxdata/flux/execute"
 	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/bolt"
+	"github.com/influxdata/influxdb/checks"
 	"github.com/influxdata/influxdb/chronograf/server"
+	"github.com/influxdata/influxdb/downsampling"
 	protofs "github.com/influxdata/influxdb/fs"
 	"github.com/influxdata/influxdb/gather"
 	"github.com/influxdata/influxdb/http"
@@ -25,7 +27,10 @@ import (
 	"github.com/influxdata/influxdb/kit/cli"
 	"github.com/influxdata/influxdb/kit/prom"
 	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/ldap"
+	"github.com/influxdata/influxdb/limits"
 	influxlogger "github.com/influxdata/influxdb/logger"
+	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/nats"
 	infprom "github.com/influxdata/influxdb/prometheus"
 	"github.com/influxdata/influxdb/proto"
@@ -83,10 +88,32 @@ type Launcher struct {
 	protosPath      string
 	secretStore     string
 
+	auditOrgID    string
+	auditBucketID string
+
+	usageOrgID         string
+	usageBucketID      string
+	usageFlushInterval time.Duration
+
+	maxWriteErrors    int
+	maxWriteBodySize  int
+	maxWriteBatchSize int
+
+	notifyTimeout time.Duration
+
+	oauth2Config http.OAuth2Config
+
+	ldapConfig ldap.Config
+	ldapGroups []string
+
 	boltClient *bolt.Client
 	kvService  *kv.Service
 	engine     *storage.Engine
 
+	authorizationUseRecorder *kv.AuthorizationUseRecorder
+
+	usageService *storage.UsageService
+
 	queryController *pcontrol.Controller
 
 	httpPort   int
@@ -95,6 +122,7 @@ type Launcher struct {
 	natsServer *nats.Server
 
 	scheduler *taskbackend.TickScheduler
+	evaluator *checks.Evaluator
 	taskStore taskbackend.Store
 
 	jaegerTracerCloser io.Closer
@@ -164,9 +192,17 @@ func (m *Launcher) Shutdown(ctx context.Context) {
 	m.logger.Info("Stopping", zap.String("service", "task"))
 	m.scheduler.Stop()
 
+	m.logger.Info("Stopping", zap.String("service", "checks"))
+	m.evaluator.Close()
+
 	m.logger.Info("Stopping", zap.String("service", "nats"))
 	m.natsServer.Close()
 
+	m.logger.Info("Stopping", zap.String("service", "authorization-use"))
+	if err := m.authorizationUseRecorder.Close(); err != nil {
+		m.logger.Error("failed to write the use of authorizations", zap.Error(err))
+	}
+
 	m.logger.Info("Stopping", zap.String("service", "bolt"))
 	if err := m.boltClient.Close(); err != nil {
 		m.logger.Info("failed closing bolt", zap.Error(err))
@@ -177,6 +213,13 @@ func (m *Launcher) Shutdown(ctx context.Context) {
 		m.logger.Info("Failed closing query service", zap.Error(err))
 	}
 
+	if m.usageService != nil {
+		m.logger.Info("Stopping", zap.String("service", "usage"))
+		if err := m.usageService.Close(); err != nil {
+			m.logger.Error("failed to write usage", zap.Error(err))
+		}
+	}
+
 	m.logger.Info("Stopping", zap.String("service", "storage-engine"))
 	if err := m.engine.Close(); err != nil {
 		m.logger.Error("failed to close engine", zap.Error(err))
@@ -272,6 +315,225 @@ func (m *Launcher) Run(ctx context.Context, args ...string) error {
 				Default: false,
 				Desc:    "disable sending telemetry data to https://telemetry.influxdata.com every 8 hours",
 			},
+			{
+				DestP: &m.auditOrgID,
+				Flag:  "audit-org-id",
+				Desc:  "ID of the organization of the bucket the audit log is also written to",
+			},
+			{
+				DestP: &m.auditBucketID,
+				Flag:  "audit-bucket-id",
+				Desc:  "ID of the bucket the audit log is also written to; the audit log is only kept in the bolt database when unset",
+			},
+			{
+				DestP: &m.usageOrgID,
+				Flag:  "usage-org-id",
+				Desc:  "ID of the organization of the bucket the usage of organizations is written to",
+			},
+			{
+				DestP: &m.usageBucketID,
+				Flag:  "usage-bucket-id",
+				Desc:  "ID of the bucket the usage of organizations is written to; usage is not recorded when unset",
+			},
+			{
+				DestP:   &m.usageFlushInterval,
+				Flag:    "usage-flush-interval",
+				Default: storage.DefaultUsageFlushInterval,
+				Desc:    "interval at which the usage recorded is written to the usage bucket",
+			},
+			{
+				DestP:   &m.maxWriteErrors,
+				Flag:    "max-write-errors",
+				Default: http.DefaultMaxWriteErrors,
+				Desc:    "maximum number of rejected lines listed in the response to a write",
+			},
+			{
+				DestP: &m.maxWriteBodySize,
+				Flag:  "max-write-body-size",
+				Desc:  "maximum size in bytes of the uncompressed body of a write; writes are not limited when unset",
+			},
+			{
+				DestP:   &m.maxWriteBatchSize,
+				Flag:    "max-write-batch-size",
+				Default: models.DefaultScanBatchSize,
+				Desc:    "maximum number of points of a write written to the storage engine at once",
+			},
+			{
+				DestP:   &m.notifyTimeout,
+				Flag:    "notification-timeout",
+				Default: checks.DefaultNotifyTimeout,
+				Desc:    "timeout of the requests sent to notification endpoints",
+			},
+			{
+				DestP: &m.oauth2Config.Provider,
+				Flag:  "oauth2-provider",
+				Desc:  "provider users can sign in through: github, google, auth0, heroku or generic; users only sign in with a password when unset",
+			},
+			{
+				DestP: &m.oauth2Config.ClientID,
+				Flag:  "oauth2-client-id",
+				Desc:  "client ID of influxd registered with the oauth2 provider",
+			},
+			{
+				DestP: &m.oauth2Config.ClientSecret,
+				Flag:  "oauth2-client-secret",
+				Desc:  "client secret of influxd registered with the oauth2 provider",
+			},
+			{
+				DestP: &m.oauth2Config.PublicURL,
+				Flag:  "oauth2-public-url",
+				Desc:  "URL users reach influxd at, used to build the oauth2 callback URL",
+			},
+			{
+				DestP: &m.oauth2Config.TokenSecret,
+				Flag:  "oauth2-token-secret",
+				Desc:  "secret signing the state of oauth2 signins and verifying HS256 id tokens",
+			},
+			{
+				DestP: &m.oauth2Config.JwksURL,
+				Flag:  "oauth2-jwks-url",
+				Desc:  "URL of the keys verifying RS256 id tokens",
+			},
+			{
+				DestP: &m.oauth2Config.UseIDToken,
+				Flag:  "oauth2-use-id-token",
+				Desc:  "read the identity of users from the id token returned by the oauth2 provider",
+			},
+			{
+				DestP: &m.oauth2Config.Domains,
+				Flag:  "oauth2-domains",
+				Desc:  "email domains users of the google and generic providers are required to have",
+			},
+			{
+				DestP: &m.oauth2Config.Orgs,
+				Flag:  "oauth2-orgs",
+				Desc:  "organizations users of the github, auth0 and heroku providers are required to be members of",
+			},
+			{
+				DestP: &m.oauth2Config.Auth0Domain,
+				Flag:  "oauth2-auth0-domain",
+				Desc:  "domain of the Auth0 tenant",
+			},
+			{
+				DestP: &m.oauth2Config.Name,
+				Flag:  "oauth2-generic-name",
+				Desc:  "name of the generic oauth2 provider",
+			},
+			{
+				DestP: &m.oauth2Config.Scopes,
+				Flag:  "oauth2-generic-scopes",
+				Desc:  "scopes requested from the generic oauth2 provider",
+			},
+			{
+				DestP: &m.oauth2Config.AuthURL,
+				Flag:  "oauth2-generic-auth-url",
+				Desc:  "authorization URL of the generic oauth2 provider",
+			},
+			{
+				DestP: &m.oauth2Config.TokenURL,
+				Flag:  "oauth2-generic-token-url",
+				Desc:  "token URL of the generic oauth2 provider",
+			},
+			{
+				DestP: &m.oauth2Config.APIURL,
+				Flag:  "oauth2-generic-api-url",
+				Desc:  "URL returning the identity of users of the generic oauth2 provider",
+			},
+			{
+				DestP: &m.oauth2Config.APIKey,
+				Flag:  "oauth2-generic-api-key",
+				Desc:  "key of the email of users in the response of the generic oauth2 provider API URL",
+			},
+			{
+				DestP: &m.oauth2Config.ProvisionOrg,
+				Flag:  "oauth2-provision-org",
+				Desc:  "name of the organization users signing in through oauth2 for the first time are added to; users have to exist beforehand when unset",
+			},
+			{
+				DestP: &m.oauth2Config.ProvisionDomains,
+				Flag:  "oauth2-provision-domains",
+				Desc:  "email domains of the users added to the provisioning organization; all users are added when neither domains nor groups are set",
+			},
+			{
+				DestP: &m.oauth2Config.ProvisionGroups,
+				Flag:  "oauth2-provision-groups",
+				Desc:  "groups or organizations reported by the oauth2 provider whose users are added to the provisioning organization",
+			},
+			{
+				DestP: &m.ldapConfig.URL,
+				Flag:  "ldap-url",
+				Desc:  "URL of the LDAP directory users authenticate against, such as ldap://localhost:389; users authenticate with local passwords when unset",
+			},
+			{
+				DestP: &m.ldapConfig.InsecureSkipVerify,
+				Flag:  "ldap-insecure-skip-verify",
+				Desc:  "skip the verification of the certificate of ldaps directories",
+			},
+			{
+				DestP:   &m.ldapConfig.Timeout,
+				Flag:    "ldap-timeout",
+				Default: ldap.DefaultTimeout,
+				Desc:    "timeout of the operations on the LDAP directory",
+			},
+			{
+				DestP: &m.ldapConfig.BindDN,
+				Flag:  "ldap-bind-dn",
+				Desc:  "DN searching the LDAP directory for users and groups; searches are anonymous when unset",
+			},
+			{
+				DestP: &m.ldapConfig.BindPassword,
+				Flag:  "ldap-bind-password",
+				Desc:  "password of the LDAP bind DN",
+			},
+			{
+				DestP: &m.ldapConfig.UserDN,
+				Flag:  "ldap-user-dn",
+				Desc:  "template of the DN of users, where %s is replaced with their name, such as uid=%s,ou=people,dc=example,dc=com; users are searched when unset",
+			},
+			{
+				DestP: &m.ldapConfig.UserSearchBase,
+				Flag:  "ldap-user-search-base",
+				Desc:  "DN beneath which users are searched",
+			},
+			{
+				DestP:   &m.ldapConfig.UserAttribute,
+				Flag:    "ldap-user-attribute",
+				Default: ldap.DefaultUserAttribute,
+				Desc:    "attribute of users holding their name",
+			},
+			{
+				DestP: &m.ldapConfig.GroupSearchBase,
+				Flag:  "ldap-group-search-base",
+				Desc:  "DN beneath which the groups of users are searched; groups are not synced when unset",
+			},
+			{
+				DestP:   &m.ldapConfig.GroupMemberAttribute,
+				Flag:    "ldap-group-member-attribute",
+				Default: ldap.DefaultGroupMemberAttribute,
+				Desc:    "attribute of groups holding the DN of their members",
+			},
+			{
+				DestP:   &m.ldapConfig.GroupNameAttribute,
+				Flag:    "ldap-group-name-attribute",
+				Default: ldap.DefaultGroupNameAttribute,
+				Desc:    "attribute of groups holding their name",
+			},
+			{
+				DestP: &m.ldapGroups,
+				Flag:  "ldap-groups",
+				Desc:  "roles of the members of LDAP groups in organizations, written as group:org:role with role either owner or member",
+			},
+			{
+				DestP:   &m.ldapConfig.CacheTTL,
+				Flag:    "ldap-cache-ttl",
+				Default: 5 * time.Minute,
+				Desc:    "how long an authentication with the LDAP directory is reused for",
+			},
+			{
+				DestP: &m.ldapConfig.LocalUsers,
+				Flag:  "ldap-local-users",
+				Desc:  "users authenticating with their local password rather than with the LDAP directory, such as the user created during setup",
+			},
 		},
 	}
 
@@ -368,6 +630,20 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		return err
 	}
 
+	m.authorizationUseRecorder = kv.NewAuthorizationUseRecorder(m.kvService)
+	m.authorizationUseRecorder.Logger = m.logger.With(zap.String("service", "authorization-use"))
+	if err := m.authorizationUseRecorder.Open(ctx); err != nil {
+		m.logger.Error("failed to open authorization use recorder", zap.Error(err))
+		return err
+	}
+
+	if m.oauth2Config.Provider != "" {
+		if err := m.oauth2Config.Validate(); err != nil {
+			m.logger.Error("invalid oauth2 configuration", zap.Error(err))
+			return err
+		}
+	}
+
 	m.reg = prom.NewRegistry()
 	m.reg.MustRegister(
 		prometheus.NewGoCollector(),
@@ -397,8 +673,30 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		labelSvc         platform.LabelService                    = m.kvService
 		secretSvc        platform.SecretService                   = m.kvService
 		lookupSvc        platform.LookupService                   = m.kvService
+		auditSvc         platform.AuditService                    = m.kvService
+		dbrpMappingSvc   platform.DBRPMappingService              = m.kvService
 	)
 
+	// The limits of organizations are enforced by a single limiter so that
+	// updates through the API apply at once.
+	orgLimiter := limits.NewLimiter(m.kvService)
+
+	if m.ldapConfig.URL != "" {
+		for _, g := range m.ldapGroups {
+			mapping, err := ldap.ParseGroupMapping(g)
+			if err != nil {
+				m.logger.Error("invalid ldap group mapping", zap.Error(err))
+				return err
+			}
+			m.ldapConfig.Groups = append(m.ldapConfig.Groups, mapping)
+		}
+
+		// Users other than the local ones authenticate against the directory.
+		svc := ldap.NewPasswordsService(m.ldapConfig, m.kvService, userSvc, orgSvc, userResourceSvc)
+		svc.Logger = m.logger.With(zap.String("service", "ldap"))
+		passwdsSvc = svc
+	}
+
 	switch m.secretStore {
 	case "bolt":
 		// If it is bolt, then we already set it above.
@@ -451,7 +749,49 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		// The Engine's metrics must be registered after it opens.
 		m.reg.MustRegister(m.engine.PrometheusCollectors()...)
 
-		pointsWriter = m.engine
+		// Points not conforming to the explicit schema of their bucket, or
+		// creating series beyond the limit of their organization, are
+		// dropped, whatever writes them.
+		pointsWriter = storage.NewSchemaPointsWriter(
+			storage.NewSeriesLimitPointsWriter(m.engine, orgLimiter, m.engine),
+			bucketSvc,
+		)
+
+		if m.auditBucketID != "" {
+			var orgID, bucketID platform.ID
+			if err := orgID.DecodeFromString(m.auditOrgID); err != nil {
+				m.logger.Error("invalid audit organization ID", zap.Error(err))
+				return err
+			}
+			if err := bucketID.DecodeFromString(m.auditBucketID); err != nil {
+				m.logger.Error("invalid audit bucket ID", zap.Error(err))
+				return err
+			}
+			svc := storage.NewAuditService(auditSvc, pointsWriter, orgID, bucketID)
+			svc.Logger = m.logger.With(zap.String("service", "audit"))
+			auditSvc = svc
+		}
+
+		if m.usageBucketID != "" {
+			var orgID, bucketID platform.ID
+			if err := orgID.DecodeFromString(m.usageOrgID); err != nil {
+				m.logger.Error("invalid usage organization ID", zap.Error(err))
+				return err
+			}
+			if err := bucketID.DecodeFromString(m.usageBucketID); err != nil {
+				m.logger.Error("invalid usage bucket ID", zap.Error(err))
+				return err
+			}
+			// Usage is written directly to the engine, so that it is not
+			// limited like the writes it accounts for.
+			m.usageService = storage.NewUsageService(m.engine, m.engine, orgID, bucketID)
+			m.usageService.Logger = m.logger.With(zap.String("service", "usage"))
+			m.usageService.FlushInterval = m.usageFlushInterval
+			if err := m.usageService.Open(ctx); err != nil {
+				m.logger.Error("failed to open usage service", zap.Error(err))
+				return err
+			}
+		}
 
 		const (
 			concurrencyQuota = 10
@@ -476,8 +816,33 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		m.reg.MustRegister(m.queryController.PrometheusCollectors()...)
 	}
 
+	var checkSvc platform.CheckService
+	{
+		m.evaluator = &checks.Evaluator{
+			Logger:                      m.logger.With(zap.String("service", "checks")),
+			CheckService:                m.kvService,
+			NotificationEndpointService: m.kvService,
+			AuthorizationService:        authSvc,
+			BucketService:               bucketSvc,
+			QueryService:                query.QueryServiceBridge{AsyncQueryService: m.queryController},
+			PointsWriter:                pointsWriter,
+			Notifier: &checks.Notifier{
+				SecretService: secretSvc,
+				Client:        &nethttp.Client{Timeout: m.notifyTimeout},
+			},
+		}
+		if err := m.evaluator.Open(ctx); err != nil {
+			m.logger.Error("failed to start check evaluator", zap.Error(err))
+			return err
+		}
+		checkSvc = checks.NewCheckService(m.kvService, m.evaluator)
+	}
+
 	var storageQueryService = readservice.NewProxyQueryService(m.queryController)
-	var taskSvc platform.TaskService
+	var (
+		taskSvc         platform.TaskService
+		taskCoordinator *coordinator.Coordinator
+	)
 	{
 		var (
 			store taskbackend.Store
@@ -495,15 +860,17 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 
 		executor := taskexecutor.NewAsyncQueryServiceExecutor(m.logger.With(zap.String("service", "task-executor")), m.queryController, authSvc, store)
 
-		lw := taskbackend.NewPointLogWriter(pointsWriter)
+		// The runs of the tasks are also evaluated by the task checks.
+		lw := m.evaluator.LogWriter(taskbackend.NewPointLogWriter(pointsWriter))
 		m.scheduler = taskbackend.NewScheduler(store, executor, lw, time.Now().UTC().Unix(), taskbackend.WithTicker(ctx, 100*time.Millisecond), taskbackend.WithLogger(m.logger))
 		m.scheduler.Start(ctx)
 		m.reg.MustRegister(m.scheduler.PrometheusCollectors()...)
 
 		queryService := query.QueryServiceBridge{AsyncQueryService: m.queryController}
 		lr := taskbackend.NewQueryLogReader(queryService)
-		taskSvc = task.PlatformAdapter(coordinator.New(m.logger.With(zap.String("service", "task-coordinator")), m.scheduler, store), lr, m.scheduler, authSvc, userResourceSvc, orgSvc)
-		taskSvc = task.NewValidator(taskSvc, bucketSvc)
+		taskCoordinator = coordinator.New(m.logger.With(zap.String("service", "task-coordinator")), m.scheduler, store)
+		taskSvc = task.PlatformAdapter(taskCoordinator, lr, m.scheduler, authSvc, userResourceSvc, orgSvc)
+		taskSvc = task.NewValidator(taskSvc, bucketSvc, task.WithAuditService(auditSvc, m.logger.With(zap.String("service", "task"))))
 		m.taskStore = store
 	}
 
@@ -533,7 +900,7 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 			Writer: pointsWriter,
 		},
 	})
-	scraperScheduler, err := gather.NewScheduler(10, m.logger, scraperTargetSvc, publisher, subscriber, 10*time.Second, 30*time.Second)
+	scraperScheduler, err := gather.NewScheduler(10, m.logger, scraperTargetSvc, secretSvc, publisher, subscriber, 10*time.Second, 30*time.Second)
 	if err != nil {
 		m.logger.Error("failed to create scraper subscriber", zap.Error(err))
 		return err
@@ -553,15 +920,31 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		Addr: m.httpBindAddress,
 	}
 
+	var (
+		usageSvc      platform.UsageService
+		usageRecorder platform.UsageRecorder
+	)
+	if m.usageService != nil {
+		usageSvc, usageRecorder = m.usageService, m.usageService
+	}
+
+	downsamplingBucketSvc := downsampling.NewBucketService(bucketSvc, taskSvc, taskCoordinator)
+	downsamplingBucketSvc.Logger = m.logger.With(zap.String("service", "downsampling"))
+
 	m.apibackend = &http.APIBackend{
 		AssetsPath:           m.assetsPath,
 		Logger:               m.logger,
 		NewBucketService:     source.NewBucketService,
 		NewQueryService:      source.NewQueryService,
 		PointsWriter:         pointsWriter,
+		PredicateDeleter:     m.engine,
+		BackupService:        m.engine,
+		KVBackupService:      m.boltClient,
+		AuditService:         auditSvc,
 		AuthorizationService: authSvc,
-		// Wrap the BucketService in a storage backed one that will ensure deleted buckets are removed from the storage engine.
-		BucketService:                   storage.NewBucketService(bucketSvc, m.engine),
+		// Wrap the BucketService in a storage backed one that will ensure deleted buckets are removed from the storage engine,
+		// in one enforcing the limit of buckets of organizations, and in one managing the downsampling tasks of buckets.
+		BucketService:                   storage.NewBucketService(limits.NewBucketService(downsamplingBucketSvc, orgSvc, orgLimiter), m.engine),
 		SessionService:                  sessionSvc,
 		UserService:                     userSvc,
 		OrganizationService:             orgSvc,
@@ -574,6 +957,8 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		OrganizationOperationLogService: orgLogSvc,
 		SourceService:                   sourceSvc,
 		VariableService:                 variableSvc,
+		CheckService:                    checkSvc,
+		NotificationEndpointService:     m.kvService,
 		PasswordsService:                passwdsSvc,
 		OnboardingService:               onboardingSvc,
 		InfluxQLService:                 nil, // No InfluxQL support
@@ -586,6 +971,17 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		LookupService:                   lookupSvc,
 		ProtoService:                    protoSvc,
 		OrgLookupService:                m.kvService,
+		DBRPMappingService:              dbrpMappingSvc,
+		OrgLimitsService:                orgLimiter,
+		OrgLimiter:                      orgLimiter,
+		UsageService:                    usageSvc,
+		UsageRecorder:                   usageRecorder,
+		AuthorizationUseRecorder:        m.authorizationUseRecorder,
+		CardinalityService:              m.engine,
+		MaxWriteErrors:                  m.maxWriteErrors,
+		MaxWriteBodySize:                int64(m.maxWriteBodySize),
+		MaxWriteBatchSize:               m.maxWriteBatchSize,
+		OAuth2Config:                    &m.oauth2Config,
 	}
 
 	// HTTP server
@@ -8,6 +8,37 @@ import (
 	"unicode"
 )
 
+// DefaultDBRPCluster is the cluster of the dbrp mappings used to resolve the
+// databases and retention policies of the 1.x compatible API.
+const DefaultDBRPCluster = "default"
+
+// DefaultDBRPRetentionPolicy is the retention policy of the mapping created
+// for a bucket that does not name one.
+const DefaultDBRPRetentionPolicy = "autogen"
+
+// ops for dbrp mapping errors.
+const (
+	OpFindDBRPMapping   = "FindDBRPMapping"
+	OpFindDBRPMappings  = "FindDBRPMappings"
+	OpCreateDBRPMapping = "CreateDBRPMapping"
+	OpDeleteDBRPMapping = "DeleteDBRPMapping"
+)
+
+var (
+	// ErrDBRPMappingNotFound is used when the dbrp mapping cannot be found.
+	ErrDBRPMappingNotFound = &Error{
+		Code: ENotFound,
+		Msg:  "dbrp mapping not found",
+	}
+
+	// ErrDBRPMappingAlreadyExists is used when a different mapping of the
+	// same cluster, database and retention policy exists.
+	ErrDBRPMappingAlreadyExists = &Error{
+		Code: EConflict,
+		Msg:  "dbrp mapping already exists",
+	}
+)
+
 // DBRPMappingService provides a mapping of cluster, database and retention policy to an organization ID and bucket ID.
 type DBRPMappingService interface {
 	// FindBy returns the dbrp mapping the for cluster, db and rp.
@@ -0,0 +1,345 @@
+package downsampling
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
+	"github.com/influxdata/influxdb/task/backend"
+	"go.uber.org/zap"
+)
+
+// DefaultBackfillPeriod is the period of history backfilled by the policies
+// of buckets with an infinite retention period.
+const DefaultBackfillPeriod = 30 * 24 * time.Hour
+
+var _ influxdb.BucketService = (*BucketService)(nil)
+
+// A RunRequester implementation is capable of requesting the runs of a task
+// scheduled within a time range, such as a backend.Store.
+type RunRequester interface {
+	ManuallyRunTimeRange(ctx context.Context, taskID influxdb.ID, start, end, requestedAt int64) (*backend.StoreTaskMetaManualRun, error)
+}
+
+// BucketService wraps an influxdb.BucketService and manages the tasks of the
+// downsampling policies of buckets.
+//
+// A task is created for each policy of a bucket, and its runs over the
+// retention period of the bucket are requested to backfill the history of
+// the destination bucket. The script of the task is updated when the policy
+// or the schema of the bucket changes, and the task is deleted with the
+// policy or the bucket.
+type BucketService struct {
+	influxdb.BucketService
+
+	Logger *zap.Logger
+
+	// BackfillPeriod is the period of history backfilled by the policies of
+	// buckets with an infinite retention period.
+	BackfillPeriod time.Duration
+
+	tasks influxdb.TaskService
+	runs  RunRequester
+	now   func() time.Time
+}
+
+// NewBucketService returns a new BucketService managing the downsampling
+// tasks of the buckets of s with ts. The history of the destination buckets
+// is backfilled by requesting runs from r, unless r is nil.
+func NewBucketService(s influxdb.BucketService, ts influxdb.TaskService, r RunRequester) *BucketService {
+	return &BucketService{
+		BucketService:  s,
+		Logger:         zap.NewNop(),
+		BackfillPeriod: DefaultBackfillPeriod,
+		tasks:          ts,
+		runs:           r,
+		now:            time.Now,
+	}
+}
+
+// CreateBucket creates a bucket and the tasks of its downsampling policies.
+// The bucket is deleted if its tasks cannot be created.
+func (s *BucketService) CreateBucket(ctx context.Context, b *influxdb.Bucket) error {
+	for i := range b.DownsamplingPolicies {
+		b.DownsamplingPolicies[i].TaskID = 0
+	}
+	if err := validScripts(b); err != nil {
+		return err
+	}
+	if err := s.validDestinations(ctx, b, nil); err != nil {
+		return err
+	}
+
+	if err := s.BucketService.CreateBucket(ctx, b); err != nil {
+		return err
+	}
+	if len(b.DownsamplingPolicies) == 0 {
+		return nil
+	}
+
+	if err := s.syncTasks(ctx, &influxdb.Bucket{}, b); err != nil {
+		for _, p := range b.DownsamplingPolicies {
+			s.deleteTask(ctx, p.TaskID)
+		}
+		if derr := s.BucketService.DeleteBucket(ctx, b.ID); derr != nil {
+			s.Logger.Error("Failed to delete bucket of failed downsampling policies", zap.Stringer("bucket_id", b.ID), zap.Error(derr))
+		}
+		return err
+	}
+	return nil
+}
+
+// UpdateBucket updates a bucket, and creates, updates and deletes the tasks
+// of its downsampling policies as needed.
+func (s *BucketService) UpdateBucket(ctx context.Context, id influxdb.ID, upd influxdb.BucketUpdate) (*influxdb.Bucket, error) {
+	old, err := s.BucketService.FindBucketByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if upd.DownsamplingPolicies != nil {
+		// The tasks of policies are managed by the service, whatever the
+		// update sets.
+		policies := make([]influxdb.DownsamplingPolicy, len(*upd.DownsamplingPolicies))
+		copy(policies, *upd.DownsamplingPolicies)
+		for i := range policies {
+			policies[i].TaskID = 0
+			if p, ok := old.DownsamplingPolicy(policies[i].DestinationBucketID); ok {
+				policies[i].TaskID = p.TaskID
+			}
+		}
+		upd.DownsamplingPolicies = &policies
+	}
+
+	// The scripts of the policies are checked before the bucket is updated,
+	// since a change of schema may leave a policy without fields.
+	next := *old
+	if err := upd.ApplySchema(&next); err != nil {
+		return nil, err
+	}
+	if err := upd.ApplyDownsampling(&next); err != nil {
+		return nil, err
+	}
+	if err := validScripts(&next); err != nil {
+		return nil, err
+	}
+	if err := s.validDestinations(ctx, &next, old); err != nil {
+		return nil, err
+	}
+
+	b, err := s.BucketService.UpdateBucket(ctx, id, upd)
+	if err != nil {
+		return nil, err
+	}
+	if len(old.DownsamplingPolicies) == 0 && len(b.DownsamplingPolicies) == 0 {
+		return b, nil
+	}
+
+	if err := s.syncTasks(ctx, old, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// DeleteBucket deletes a bucket and the tasks of its downsampling policies.
+func (s *BucketService) DeleteBucket(ctx context.Context, id influxdb.ID) error {
+	b, err := s.BucketService.FindBucketByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.BucketService.DeleteBucket(ctx, id); err != nil {
+		return err
+	}
+	for _, p := range b.DownsamplingPolicies {
+		s.deleteTask(ctx, p.TaskID)
+	}
+	return nil
+}
+
+// validScripts returns an error if the script of a policy of b is invalid.
+func validScripts(b *influxdb.Bucket) error {
+	for i := range b.DownsamplingPolicies {
+		if _, err := Script(b, &b.DownsamplingPolicies[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validDestinations returns an error if the destination of a policy of b that
+// old does not have, or whose retention period it changes, is not a bucket of
+// the organization of b that the authorizer on ctx may write.
+//
+// The destination buckets are found with the wrapped service, so the write
+// permission is checked here: the task of a policy writes to its destination,
+// and the policy sets the retention period of the destination.
+func (s *BucketService) validDestinations(ctx context.Context, b, old *influxdb.Bucket) error {
+	for _, p := range b.DownsamplingPolicies {
+		if old != nil {
+			if oldp, ok := old.DownsamplingPolicy(p.DestinationBucketID); ok && oldp.DestinationRetention == p.DestinationRetention {
+				continue
+			}
+		}
+
+		dst, err := s.BucketService.FindBucketByID(ctx, p.DestinationBucketID)
+		if err != nil {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("destination bucket %s of downsampling policy not found", p.DestinationBucketID),
+				Err:  err,
+			}
+		}
+		if b.OrganizationID.Valid() && dst.OrganizationID != b.OrganizationID {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("destination bucket %s of downsampling policy belongs to another organization", p.DestinationBucketID),
+			}
+		}
+
+		perm, err := influxdb.NewPermissionAtID(dst.ID, influxdb.WriteAction, influxdb.BucketsResourceType, dst.OrganizationID)
+		if err != nil {
+			return err
+		}
+		if err := authorizer.IsAllowed(ctx, *perm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncTasks deletes the tasks of the policies of old that b does not have,
+// updates the tasks of the policies whose script changed, and creates the
+// tasks of the new policies of b. The IDs of the new tasks are saved with the
+// policies of b, even if the tasks of other policies fail.
+func (s *BucketService) syncTasks(ctx context.Context, old, b *influxdb.Bucket) error {
+	for _, p := range old.DownsamplingPolicies {
+		if _, ok := b.DownsamplingPolicy(p.DestinationBucketID); !ok {
+			s.deleteTask(ctx, p.TaskID)
+		}
+	}
+
+	var (
+		created bool
+		err     error
+	)
+	for i := range b.DownsamplingPolicies {
+		p := &b.DownsamplingPolicies[i]
+		if oldp, ok := old.DownsamplingPolicy(p.DestinationBucketID); ok && p.TaskID.Valid() {
+			err = s.updateTask(ctx, old, b, oldp, p)
+		} else {
+			err = s.createTask(ctx, b, p)
+			created = created || p.TaskID.Valid()
+		}
+		if err != nil {
+			break
+		}
+	}
+
+	if created {
+		policies := b.DownsamplingPolicies
+		if _, uerr := s.BucketService.UpdateBucket(ctx, b.ID, influxdb.BucketUpdate{DownsamplingPolicies: &policies}); uerr != nil && err == nil {
+			err = uerr
+		}
+	}
+	return err
+}
+
+// createTask creates the task of the policy p of b, sets the retention period
+// of its destination bucket, and backfills its history.
+func (s *BucketService) createTask(ctx context.Context, b *influxdb.Bucket, p *influxdb.DownsamplingPolicy) error {
+	script, err := Script(b, p)
+	if err != nil {
+		return err
+	}
+
+	t, err := s.tasks.CreateTask(ctx, influxdb.TaskCreate{
+		Flux:           script,
+		OrganizationID: b.OrganizationID,
+	})
+	if err != nil {
+		return err
+	}
+	p.TaskID = t.ID
+
+	if err := s.setRetention(ctx, p); err != nil {
+		return err
+	}
+	s.backfill(ctx, b, p)
+	return nil
+}
+
+// updateTask updates the script of the task of the policy p of b if it
+// differs from the script of the policy oldp of old, and sets the retention
+// period of its destination bucket if it changed.
+func (s *BucketService) updateTask(ctx context.Context, old, b *influxdb.Bucket, oldp, p *influxdb.DownsamplingPolicy) error {
+	script, err := Script(b, p)
+	if err != nil {
+		return err
+	}
+
+	if oldScript, err := Script(old, oldp); err != nil || oldScript != script {
+		if _, err := s.tasks.UpdateTask(ctx, p.TaskID, influxdb.TaskUpdate{Flux: &script}); err != nil {
+			return err
+		}
+	}
+
+	if p.DestinationRetention != oldp.DestinationRetention {
+		return s.setRetention(ctx, p)
+	}
+	return nil
+}
+
+// setRetention sets the retention period of the destination bucket of p. The
+// permission to write the destination is checked by validDestinations.
+func (s *BucketService) setRetention(ctx context.Context, p *influxdb.DownsamplingPolicy) error {
+	if p.DestinationRetention == 0 {
+		return nil
+	}
+	rp := p.DestinationRetention
+	_, err := s.BucketService.UpdateBucket(ctx, p.DestinationBucketID, influxdb.BucketUpdate{RetentionPeriod: &rp})
+	return err
+}
+
+// backfill requests the runs of the task of p downsampling the data of b
+// written before the task was created. Failures are logged, since the task
+// downsamples the new data regardless.
+func (s *BucketService) backfill(ctx context.Context, b *influxdb.Bucket, p *influxdb.DownsamplingPolicy) {
+	if s.runs == nil {
+		return
+	}
+
+	period := b.RetentionPeriod
+	if period == 0 {
+		period = s.BackfillPeriod
+	}
+	now := s.now()
+	end := now.Truncate(p.Window)
+	start := end.Add(-period).Truncate(p.Window)
+	if !start.Before(end) {
+		return
+	}
+
+	// The runs of a range are scheduled a window after each other, starting a
+	// window after the second before the start of the range. Each run
+	// downsamples the window preceding the time it is scheduled for.
+	if _, err := s.runs.ManuallyRunTimeRange(ctx, p.TaskID, start.Unix()+1, end.Unix(), now.Unix()); err != nil {
+		s.Logger.Error("Failed to backfill downsampling policy",
+			zap.Stringer("bucket_id", b.ID),
+			zap.Stringer("task_id", p.TaskID),
+			zap.Error(err))
+	}
+}
+
+// deleteTask deletes the task of a policy. Failures are logged, since the
+// policy is deleted regardless.
+func (s *BucketService) deleteTask(ctx context.Context, id influxdb.ID) {
+	if !id.Valid() {
+		return
+	}
+	if err := s.tasks.DeleteTask(ctx, id); err != nil && influxdb.ErrorCode(err) != influxdb.ENotFound {
+		s.Logger.Error("Failed to delete downsampling task", zap.Stringer("task_id", id), zap.Error(err))
+	}
+}
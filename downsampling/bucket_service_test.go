@@ -0,0 +1,184 @@
+package downsampling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	icontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/inmem"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/task/backend"
+)
+
+type runRequester struct {
+	taskID     influxdb.ID
+	start, end int64
+}
+
+func (r *runRequester) ManuallyRunTimeRange(ctx context.Context, taskID influxdb.ID, start, end, requestedAt int64) (*backend.StoreTaskMetaManualRun, error) {
+	r.taskID, r.start, r.end = taskID, start, end
+	return &backend.StoreTaskMetaManualRun{Start: start, End: end, RequestedAt: requestedAt}, nil
+}
+
+func TestBucketService(t *testing.T) {
+	ctx := context.Background()
+	bs := inmem.NewService()
+
+	org := &influxdb.Organization{Name: "o"}
+	if err := bs.CreateOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+	ctx = icontext.SetAuthorizer(ctx, &influxdb.Authorization{
+		Status:      influxdb.Active,
+		Permissions: influxdb.OwnerPermissions(org.ID),
+	})
+	dst := &influxdb.Bucket{OrganizationID: org.ID, Name: "dst"}
+	if err := bs.CreateBucket(ctx, dst); err != nil {
+		t.Fatal(err)
+	}
+
+	tasks := make(map[influxdb.ID]string)
+	var nextTaskID influxdb.ID = 100
+	ts := &mock.TaskService{
+		CreateTaskFn: func(ctx context.Context, tc influxdb.TaskCreate) (*influxdb.Task, error) {
+			if tc.OrganizationID != org.ID {
+				t.Fatalf("got task of org %s, expected %s", tc.OrganizationID, org.ID)
+			}
+			nextTaskID++
+			tasks[nextTaskID] = tc.Flux
+			return &influxdb.Task{ID: nextTaskID, Flux: tc.Flux}, nil
+		},
+		UpdateTaskFn: func(ctx context.Context, id influxdb.ID, upd influxdb.TaskUpdate) (*influxdb.Task, error) {
+			tasks[id] = *upd.Flux
+			return &influxdb.Task{ID: id, Flux: *upd.Flux}, nil
+		},
+		DeleteTaskFn: func(ctx context.Context, id influxdb.ID) error {
+			delete(tasks, id)
+			return nil
+		},
+	}
+
+	runs := &runRequester{}
+	s := NewBucketService(bs, ts, runs)
+	s.now = func() time.Time { return time.Date(2019, 3, 4, 10, 17, 0, 0, time.UTC) }
+
+	src := &influxdb.Bucket{
+		OrganizationID:  org.ID,
+		Name:            "src",
+		RetentionPeriod: 24 * time.Hour,
+		DownsamplingPolicies: []influxdb.DownsamplingPolicy{
+			{
+				DestinationBucketID:  dst.ID,
+				Window:               time.Hour,
+				Aggregates:           map[influxdb.SchemaFieldType]string{influxdb.SchemaFieldTypeFloat: "last"},
+				DestinationRetention: 30 * 24 * time.Hour,
+			},
+		},
+	}
+	if err := s.CreateBucket(ctx, src); err != nil {
+		t.Fatal(err)
+	}
+
+	// The task is created, saved with the policy, and backfills the
+	// retention period of the source bucket.
+	b, err := bs.FindBucketByID(ctx, src.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	taskID := b.DownsamplingPolicies[0].TaskID
+	if _, ok := tasks[taskID]; !ok || len(tasks) != 1 {
+		t.Fatalf("got tasks %v, expected task %s", tasks, taskID)
+	}
+	end := time.Date(2019, 3, 4, 10, 0, 0, 0, time.UTC).Unix()
+	if runs.taskID != taskID || runs.start != end-24*60*60+1 || runs.end != end {
+		t.Fatalf("unexpected backfill %+v", runs)
+	}
+
+	d, err := bs.FindBucketByID(ctx, dst.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := d.RetentionPeriod, 30*24*time.Hour; got != exp {
+		t.Fatalf("got destination retention %s, expected %s", got, exp)
+	}
+
+	// Changing the window updates the script of the task.
+	policies := []influxdb.DownsamplingPolicy{b.DownsamplingPolicies[0]}
+	policies[0].Window = 10 * time.Minute
+	policies[0].TaskID = 0
+	b, err = s.UpdateBucket(ctx, src.ID, influxdb.BucketUpdate{DownsamplingPolicies: &policies})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := b.DownsamplingPolicies[0].TaskID; got != taskID {
+		t.Fatalf("got task %s, expected %s", got, taskID)
+	}
+	exp, err := Script(b, &b.DownsamplingPolicies[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tasks[taskID] != exp {
+		t.Fatalf("got script\n%s\nexpected\n%s", tasks[taskID], exp)
+	}
+
+	// A policy to another organization is rejected.
+	otherOrg := &influxdb.Organization{Name: "other"}
+	if err := bs.CreateOrganization(ctx, otherOrg); err != nil {
+		t.Fatal(err)
+	}
+	other := &influxdb.Bucket{OrganizationID: otherOrg.ID, Name: "other"}
+	if err := bs.CreateBucket(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	invalid := []influxdb.DownsamplingPolicy{policies[0]}
+	invalid[0].DestinationBucketID = other.ID
+	if _, err := s.UpdateBucket(ctx, src.ID, influxdb.BucketUpdate{DownsamplingPolicies: &invalid}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("got error %v, expected invalid destination", err)
+	}
+
+	// A caller who may only write the source bucket can neither downsample
+	// into, nor set the retention period of, a bucket they may not write.
+	srcPerms := make([]influxdb.Permission, 0, 2)
+	for _, action := range []influxdb.Action{influxdb.ReadAction, influxdb.WriteAction} {
+		p, err := influxdb.NewPermissionAtID(src.ID, action, influxdb.BucketsResourceType, org.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		srcPerms = append(srcPerms, *p)
+	}
+	srcCtx := icontext.SetAuthorizer(context.Background(), &influxdb.Authorization{
+		Status:      influxdb.Active,
+		Permissions: srcPerms,
+	})
+	retention := []influxdb.DownsamplingPolicy{policies[0]}
+	retention[0].DestinationRetention = time.Hour
+	if _, err := s.UpdateBucket(srcCtx, src.ID, influxdb.BucketUpdate{DownsamplingPolicies: &retention}); influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+		t.Fatalf("got error %v, expected unauthorized destination", err)
+	}
+	dst2 := &influxdb.Bucket{OrganizationID: org.ID, Name: "dst2"}
+	if err := bs.CreateBucket(ctx, dst2); err != nil {
+		t.Fatal(err)
+	}
+	added := []influxdb.DownsamplingPolicy{policies[0], policies[0]}
+	added[1].DestinationBucketID = dst2.ID
+	if _, err := s.UpdateBucket(srcCtx, src.ID, influxdb.BucketUpdate{DownsamplingPolicies: &added}); influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+		t.Fatalf("got error %v, expected unauthorized destination", err)
+	}
+	d, err = bs.FindBucketByID(ctx, dst.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := d.RetentionPeriod, 30*24*time.Hour; got != exp {
+		t.Fatalf("got destination retention %s, expected %s", got, exp)
+	}
+
+	// Deleting the bucket deletes the task.
+	if err := s.DeleteBucket(ctx, src.ID); err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 0 {
+		t.Fatalf("got tasks %v, expected none", tasks)
+	}
+}
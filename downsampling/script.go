@@ -0,0 +1,134 @@
+package downsampling
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+// fieldTypes are the types of fields in the order their data is downsampled.
+var fieldTypes = []influxdb.SchemaFieldType{
+	influxdb.SchemaFieldTypeFloat,
+	influxdb.SchemaFieldTypeInteger,
+	influxdb.SchemaFieldTypeUnsigned,
+	influxdb.SchemaFieldTypeString,
+	influxdb.SchemaFieldTypeBoolean,
+}
+
+// Script returns the Flux script of the task downsampling the data of the
+// bucket b by the policy p.
+//
+// Each run of the task aggregates the window of data preceding the time it is
+// scheduled for. The fields of a bucket with an explicit schema are aggregated
+// with the aggregate of their type; the fields of a bucket with an implicit
+// schema are all aggregated with the single aggregate of the policy. Since
+// the types of those fields are not known, the aggregate must apply to
+// fields of any type.
+func Script(b *influxdb.Bucket, p *influxdb.DownsamplingPolicy) (string, error) {
+	if err := p.Valid(); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "option task = {name: %s, every: %s}\n",
+		fluxString(fmt.Sprintf("Downsample %s to %s", b.ID, p.DestinationBucketID)), fluxDuration(p.Window))
+
+	if !b.ExplicitSchema() {
+		fn, ok := p.Aggregate()
+		if !ok {
+			return "", &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "fields of bucket with an implicit schema can only be downsampled with a single aggregate",
+			}
+		}
+		if !influxdb.AggregatesAnyFieldType(fn) {
+			return "", &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("fields of bucket with an implicit schema cannot be downsampled with %s, which only aggregates numeric fields; use first, last or count, or define an explicit schema", fn),
+			}
+		}
+		writePipeline(&sb, b, p, "", fn, "_result")
+		return sb.String(), nil
+	}
+
+	var n int
+	for _, typ := range fieldTypes {
+		fn, ok := p.Aggregates[typ]
+		if !ok {
+			continue
+		}
+		pred := schemaPredicate(b, typ)
+		if pred == "" {
+			continue
+		}
+		writePipeline(&sb, b, p, pred, fn, string(typ))
+		n++
+	}
+	if n == 0 {
+		return "", &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("bucket %s has no fields downsampled by the policy", b.ID),
+		}
+	}
+	return sb.String(), nil
+}
+
+// writePipeline writes the pipeline aggregating the data of b matching the
+// predicate pred with the aggregate fn, and writing it to the destination of p.
+func writePipeline(sb *strings.Builder, b *influxdb.Bucket, p *influxdb.DownsamplingPolicy, pred, fn, name string) {
+	fmt.Fprintf(sb, "\nfrom(bucketID: %s)\n", fluxString(b.ID.String()))
+	sb.WriteString("\t|> range(start: -task.every)\n")
+	if pred != "" {
+		fmt.Fprintf(sb, "\t|> filter(fn: (r) => %s)\n", pred)
+	}
+	fmt.Fprintf(sb, "\t|> window(every: %s)\n", fluxDuration(p.Window))
+	fmt.Fprintf(sb, "\t|> %s()\n", fn)
+	sb.WriteString("\t|> duplicate(column: \"_stop\", as: \"_time\")\n")
+	sb.WriteString("\t|> window(every: inf)\n")
+	fmt.Fprintf(sb, "\t|> to(bucketID: %s, orgID: %s)\n", fluxString(p.DestinationBucketID.String()), fluxString(b.OrganizationID.String()))
+	fmt.Fprintf(sb, "\t|> yield(name: %s)\n", fluxString(name))
+}
+
+// schemaPredicate returns the predicate matching the fields of type typ of
+// the measurement schemas of b, or an empty string if there are none.
+func schemaPredicate(b *influxdb.Bucket, typ influxdb.SchemaFieldType) string {
+	schemas := make([]influxdb.MeasurementSchema, len(b.MeasurementSchemas))
+	copy(schemas, b.MeasurementSchemas)
+	sort.Slice(schemas, func(i, j int) bool { return schemas[i].Name < schemas[j].Name })
+
+	var measurements []string
+	for _, s := range schemas {
+		var fields []string
+		for _, f := range s.Fields {
+			if f.Type == typ {
+				fields = append(fields, fmt.Sprintf("r._field == %s", fluxString(f.Name)))
+			}
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		sort.Strings(fields)
+		measurements = append(measurements, fmt.Sprintf("(r._measurement == %s and (%s))", fluxString(s.Name), strings.Join(fields, " or ")))
+	}
+	return strings.Join(measurements, " or ")
+}
+
+// fluxString returns s as a Flux string literal.
+func fluxString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// fluxDuration returns the whole number of seconds d as a Flux duration literal.
+func fluxDuration(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	default:
+		return fmt.Sprintf("%ds", d/time.Second)
+	}
+}
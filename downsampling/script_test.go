@@ -0,0 +1,114 @@
+package downsampling_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/downsampling"
+	_ "github.com/influxdata/influxdb/query/builtin"
+	"github.com/influxdata/influxdb/task/options"
+)
+
+func TestScript(t *testing.T) {
+	policy := influxdb.DownsamplingPolicy{
+		DestinationBucketID: 2,
+		Window:              time.Hour,
+		Aggregates: map[influxdb.SchemaFieldType]string{
+			influxdb.SchemaFieldTypeFloat:  "mean",
+			influxdb.SchemaFieldTypeString: "last",
+		},
+	}
+
+	t.Run("explicit schema", func(t *testing.T) {
+		b := &influxdb.Bucket{
+			ID:             1,
+			OrganizationID: 3,
+			SchemaType:     influxdb.SchemaTypeExplicit,
+			MeasurementSchemas: []influxdb.MeasurementSchema{
+				{
+					Name: "cpu",
+					Tags: []string{"host"},
+					Fields: []influxdb.MeasurementSchemaField{
+						{Name: "usage", Type: influxdb.SchemaFieldTypeFloat},
+						{Name: "state", Type: influxdb.SchemaFieldTypeString},
+						{Name: "count", Type: influxdb.SchemaFieldTypeInteger},
+					},
+				},
+			},
+		}
+
+		script, err := downsampling.Script(b, &policy)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		exp := `option task = {name: "Downsample 0000000000000001 to 0000000000000002", every: 1h}
+
+from(bucketID: "0000000000000001")
+	|> range(start: -task.every)
+	|> filter(fn: (r) => (r._measurement == "cpu" and (r._field == "usage")))
+	|> window(every: 1h)
+	|> mean()
+	|> duplicate(column: "_stop", as: "_time")
+	|> window(every: inf)
+	|> to(bucketID: "0000000000000002", orgID: "0000000000000003")
+	|> yield(name: "float")
+
+from(bucketID: "0000000000000001")
+	|> range(start: -task.every)
+	|> filter(fn: (r) => (r._measurement == "cpu" and (r._field == "state")))
+	|> window(every: 1h)
+	|> last()
+	|> duplicate(column: "_stop", as: "_time")
+	|> window(every: inf)
+	|> to(bucketID: "0000000000000002", orgID: "0000000000000003")
+	|> yield(name: "string")
+`
+		if script != exp {
+			t.Fatalf("got script\n%s\nexpected\n%s", script, exp)
+		}
+		compileScript(t, script)
+	})
+
+	t.Run("implicit schema", func(t *testing.T) {
+		b := &influxdb.Bucket{ID: 1, OrganizationID: 3}
+
+		if _, err := downsampling.Script(b, &policy); err == nil {
+			t.Fatal("expected error downsampling implicit schema with several aggregates")
+		}
+
+		p := policy
+		p.Aggregates = map[influxdb.SchemaFieldType]string{influxdb.SchemaFieldTypeFloat: "max"}
+		if _, err := downsampling.Script(b, &p); err == nil {
+			t.Fatal("expected error downsampling implicit schema with a numeric aggregate")
+		}
+
+		p.Aggregates = map[influxdb.SchemaFieldType]string{influxdb.SchemaFieldTypeFloat: "last"}
+		p.Window = 90 * time.Second
+		script, err := downsampling.Script(b, &p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts := compileScript(t, script)
+		if got, exp := opts.Every, 90*time.Second; got != exp {
+			t.Fatalf("got every %s, expected %s", got, exp)
+		}
+	})
+}
+
+// compileScript compiles the script of a task, and returns its options.
+func compileScript(t *testing.T, script string) options.Options {
+	t.Helper()
+
+	opts, err := options.FromScript(script)
+	if err != nil {
+		t.Fatalf("invalid task options: %v\n%s", err, script)
+	}
+	if _, err := flux.Compile(context.Background(), script, time.Now()); err != nil {
+		t.Fatalf("invalid script: %v\n%s", err, script)
+	}
+	return opts
+}
@@ -21,6 +21,7 @@ const (
 	EForbidden           = "forbidden"
 	EUnauthorized        = "unauthorized"
 	EMethodNotAllowed    = "method not allowed"
+	ETooManyRequests     = "too many requests" // a limit of the organization is exceeded
 )
 
 // Error is the error struct of platform.
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/nats"
@@ -12,13 +13,15 @@ import (
 
 // handler implents nats Handler interface.
 type handler struct {
-	Scraper   Scraper
+	Client    *TargetClient
 	Publisher nats.Publisher
 	Logger    *zap.Logger
+	// Timeout is the timeout of the scrapes of targets without a timeout.
+	Timeout time.Duration
 }
 
 // Process consumes scraper target from scraper target queue,
-// call the scraper to gather, and publish to metrics queue.
+// call the scraper of its type to gather, and publish to metrics queue.
 func (h *handler) Process(s nats.Subscription, m nats.Message) {
 	defer m.Ack()
 
@@ -29,7 +32,20 @@ func (h *handler) Process(s nats.Subscription, m nats.Message) {
 		return
 	}
 
-	ms, err := h.Scraper.Gather(context.TODO(), *req)
+	scraper, err := NewScraper(req.Type, h.Client)
+	if err != nil {
+		h.Logger.Error("unable to gather", zap.Error(err))
+		return
+	}
+
+	ctx := context.Background()
+	if timeout := req.ScrapeTimeout(h.Timeout); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	ms, err := scraper.Gather(ctx, *req)
 	if err != nil {
 		h.Logger.Error("unable to gather", zap.Error(err))
 		return
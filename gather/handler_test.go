@@ -0,0 +1,58 @@
+package gather
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"go.uber.org/zap"
+)
+
+type message []byte
+
+func (m message) Data() []byte { return m }
+func (m message) Ack() error   { return nil }
+
+func TestHandler_DefaultTimeout(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	}))
+	defer ts.Close()
+
+	publisher, _ := mock.NewNats()
+	h := &handler{
+		Client:    &TargetClient{},
+		Publisher: publisher,
+		Logger:    zap.NewNop(),
+		Timeout:   10 * time.Millisecond,
+	}
+
+	// The target has no timeout, so the timeout of the handler applies.
+	data, err := json.Marshal(influxdb.ScraperTarget{
+		Type: influxdb.PrometheusScraperType,
+		URL:  ts.URL + "/metrics",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	processed := make(chan struct{})
+	go func() {
+		h.Process(nil, message(data))
+		close(processed)
+	}()
+	select {
+	case <-processed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the scrape to time out")
+	}
+}
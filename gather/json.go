@@ -0,0 +1,116 @@
+package gather
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+// jsonScraper maps the values of the JSON document of a target to a point,
+// using the field and tag mappings of the target.
+type jsonScraper struct {
+	Client *TargetClient
+}
+
+// Gather maps the JSON document of the target url to a point.
+func (p *jsonScraper) Gather(ctx context.Context, target influxdb.ScraperTarget) (collected MetricsCollection, err error) {
+	if target.JSON == nil {
+		return collected, fmt.Errorf("json scraper target %s has no field mappings", target.URL)
+	}
+
+	resp, err := p.Client.Get(ctx, target, "application/json")
+	if err != nil {
+		return collected, err
+	}
+	defer resp.Body.Close()
+
+	m, err := parseJSON(resp.Body, target, time.Now())
+	if err != nil {
+		return collected, err
+	}
+
+	return MetricsCollection{
+		MetricsSlice: MetricsSlice{m},
+		OrgID:        target.OrgID,
+		BucketID:     target.BucketID,
+	}, nil
+}
+
+func parseJSON(r io.Reader, target influxdb.ScraperTarget, now time.Time) (Metrics, error) {
+	var doc interface{}
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return Metrics{}, fmt.Errorf("reading json document failed: %s", err)
+	}
+
+	cfg := target.JSON
+	m := Metrics{
+		Name:      cfg.Measurement,
+		Tags:      make(map[string]string),
+		Fields:    make(map[string]interface{}),
+		Timestamp: now,
+		Type:      MetricTypeUntyped,
+	}
+	if m.Name == "" {
+		m.Name = target.Name
+	}
+	if m.Name == "" {
+		return Metrics{}, fmt.Errorf("json scraper target %s has no measurement or name", target.URL)
+	}
+
+	for _, mapping := range cfg.Tags {
+		switch v := lookupJSONPath(doc, mapping.Path).(type) {
+		case string:
+			m.Tags[mapping.Name] = v
+		case json.Number:
+			m.Tags[mapping.Name] = v.String()
+		case bool:
+			m.Tags[mapping.Name] = strconv.FormatBool(v)
+		}
+	}
+
+	for _, mapping := range cfg.Fields {
+		switch v := lookupJSONPath(doc, mapping.Path).(type) {
+		case string, bool:
+			m.Fields[mapping.Name] = v
+		case json.Number:
+			f, err := v.Float64()
+			if err != nil {
+				return Metrics{}, fmt.Errorf("invalid number at %q: %v", mapping.Path, err)
+			}
+			m.Fields[mapping.Name] = f
+		}
+	}
+
+	if len(m.Fields) == 0 {
+		return Metrics{}, fmt.Errorf("json document of %s has none of the mapped fields", target.URL)
+	}
+	return m, nil
+}
+
+// lookupJSONPath returns the value at the dot separated path of doc, or nil
+// if there is none. Array elements are addressed by their index.
+func lookupJSONPath(doc interface{}, path string) interface{} {
+	for _, key := range strings.Split(path, ".") {
+		switch v := doc.(type) {
+		case map[string]interface{}:
+			doc = v[key]
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil
+			}
+			doc = v[i]
+		default:
+			return nil
+		}
+	}
+	return doc
+}
@@ -0,0 +1,318 @@
+package gather
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+)
+
+const openMetricsAccept = "application/openmetrics-text; version=1.0.0, text/plain; version=0.0.4; q=0.5"
+
+// openMetricsScraper parses metrics in the OpenMetrics text format,
+// including the exemplars of their samples.
+type openMetricsScraper struct {
+	Client *TargetClient
+}
+
+// Gather parses the metrics of the target url.
+func (p *openMetricsScraper) Gather(ctx context.Context, target influxdb.ScraperTarget) (collected MetricsCollection, err error) {
+	resp, err := p.Client.Get(ctx, target, openMetricsAccept)
+	if err != nil {
+		return collected, err
+	}
+	defer resp.Body.Close()
+
+	ms, err := parseOpenMetrics(resp.Body, time.Now())
+	if err != nil {
+		return collected, err
+	}
+
+	return MetricsCollection{
+		MetricsSlice: ms,
+		OrgID:        target.OrgID,
+		BucketID:     target.BucketID,
+	}, nil
+}
+
+// openMetricsSample is a sample line of the OpenMetrics text format.
+type openMetricsSample struct {
+	name      string
+	labels    map[string]string
+	value     float64
+	timestamp time.Time
+
+	exemplar *openMetricsExemplar
+}
+
+type openMetricsExemplar struct {
+	labels map[string]string
+	value  float64
+}
+
+// parseOpenMetrics parses the metrics of r. The samples of a metric family
+// with the same labels are gathered into one Metrics, whose fields are named
+// like those of the prometheus scraper: the quantiles and bucket bounds of
+// summaries and histograms, and counter, gauge or value otherwise. The value
+// and labels of an exemplar are written to the fields <field>_exemplar and
+// <field>_exemplar_<label> of the sample they belong to.
+func parseOpenMetrics(r io.Reader, now time.Time) (MetricsSlice, error) {
+	types := make(map[string]string)
+	metrics := make(map[string]*Metrics)
+	var keys []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		if line[0] == '#' {
+			parts := strings.Fields(line)
+			if len(parts) >= 2 && parts[1] == "EOF" {
+				break
+			}
+			if len(parts) >= 4 && parts[1] == "TYPE" {
+				types[parts[2]] = parts[3]
+			}
+			continue
+		}
+
+		s, err := parseOpenMetricsSample(line)
+		if err != nil {
+			return nil, err
+		}
+		if math.IsNaN(s.value) {
+			continue
+		}
+
+		family, typ, field := openMetricsField(types, s)
+
+		key := family + "\x00" + string(models.NewTags(s.labels).HashKey())
+		m, ok := metrics[key]
+		if !ok {
+			m = &Metrics{
+				Name:      family,
+				Tags:      s.labels,
+				Fields:    make(map[string]interface{}),
+				Timestamp: now,
+				Type:      openMetricsType(typ),
+			}
+			if !s.timestamp.IsZero() {
+				m.Timestamp = s.timestamp
+			}
+			metrics[key] = m
+			keys = append(keys, key)
+		}
+
+		m.Fields[field] = s.value
+		if e := s.exemplar; e != nil {
+			m.Fields[field+"_exemplar"] = e.value
+			for k, v := range e.labels {
+				m.Fields[field+"_exemplar_"+k] = v
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	ms := make(MetricsSlice, 0, len(keys))
+	for _, key := range keys {
+		ms = append(ms, *metrics[key])
+	}
+	return ms, nil
+}
+
+// openMetricsSuffixes are the suffixes of the names of samples of metric families.
+var openMetricsSuffixes = []string{"_total", "_created", "_bucket", "_count", "_sum", "_gcount", "_gsum", "_info"}
+
+// openMetricsField returns the metric family, its type and the field of the
+// sample s. The labels of s that become part of the field, le and quantile,
+// are removed from it.
+func openMetricsField(types map[string]string, s *openMetricsSample) (family, typ, field string) {
+	if typ, ok := types[s.name]; ok {
+		switch typ {
+		case "summary":
+			if q, ok := s.labels["quantile"]; ok {
+				delete(s.labels, "quantile")
+				return s.name, typ, openMetricsBound(q)
+			}
+		case "counter":
+			return s.name, typ, "counter"
+		case "gauge", "stateset":
+			return s.name, typ, "gauge"
+		case "info":
+			return s.name, typ, "info"
+		}
+		return s.name, typ, "value"
+	}
+
+	for _, suffix := range openMetricsSuffixes {
+		if !strings.HasSuffix(s.name, suffix) {
+			continue
+		}
+		family := strings.TrimSuffix(s.name, suffix)
+		typ, ok := types[family]
+		if !ok {
+			continue
+		}
+
+		switch suffix {
+		case "_bucket":
+			le := s.labels["le"]
+			delete(s.labels, "le")
+			return family, typ, openMetricsBound(le)
+		case "_total":
+			return family, typ, "counter"
+		case "_count", "_gcount":
+			return family, typ, "count"
+		case "_sum", "_gsum":
+			return family, typ, "sum"
+		default:
+			return family, typ, strings.TrimPrefix(suffix, "_")
+		}
+	}
+	return s.name, "unknown", "value"
+}
+
+// openMetricsBound formats the bound of a bucket or quantile like the prometheus scraper.
+func openMetricsBound(s string) string {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return s
+	}
+	return fmt.Sprint(f)
+}
+
+func openMetricsType(typ string) MetricType {
+	switch typ {
+	case "counter":
+		return MetricTypeCounter
+	case "gauge", "stateset", "info":
+		return MetricTypeGauge
+	case "summary":
+		return MetricTypeSummary
+	case "histogram", "gaugehistogram":
+		return MetricTypeHistogrm
+	default:
+		return MetricTypeUntyped
+	}
+}
+
+// parseOpenMetricsSample parses a line such as:
+//
+//	foo_bucket{le="0.5"} 3 1520879607.789 # {trace_id="KOO5S4vxi0o"} 0.67
+func parseOpenMetricsSample(line string) (*openMetricsSample, error) {
+	i := strings.IndexAny(line, "{ ")
+	if i <= 0 {
+		return nil, fmt.Errorf("invalid openmetrics sample %q", line)
+	}
+	s := &openMetricsSample{name: line[:i], labels: make(map[string]string)}
+
+	rest := line[i:]
+	if rest[0] == '{' {
+		labels, n, err := parseOpenMetricsLabels(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid openmetrics sample %q: %v", line, err)
+		}
+		s.labels, rest = labels, rest[n:]
+	}
+
+	var exemplar string
+	if j := strings.Index(rest, " # "); j >= 0 {
+		rest, exemplar = rest[:j], strings.TrimSpace(rest[j+3:])
+	}
+
+	parts := strings.Fields(rest)
+	if len(parts) == 0 || len(parts) > 2 {
+		return nil, fmt.Errorf("invalid openmetrics sample %q", line)
+	}
+
+	var err error
+	if s.value, err = strconv.ParseFloat(parts[0], 64); err != nil {
+		return nil, fmt.Errorf("invalid openmetrics sample value %q", parts[0])
+	}
+	if len(parts) == 2 {
+		ts, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid openmetrics sample timestamp %q", parts[1])
+		}
+		s.timestamp = time.Unix(0, int64(ts*1e9))
+	}
+
+	if exemplar != "" {
+		if exemplar[0] != '{' {
+			return nil, fmt.Errorf("invalid openmetrics exemplar %q", exemplar)
+		}
+		labels, n, err := parseOpenMetricsLabels(exemplar)
+		if err != nil {
+			return nil, fmt.Errorf("invalid openmetrics exemplar %q: %v", exemplar, err)
+		}
+		parts := strings.Fields(exemplar[n:])
+		if len(parts) == 0 {
+			return nil, fmt.Errorf("invalid openmetrics exemplar %q", exemplar)
+		}
+		value, err := strconv.ParseFloat(parts[0], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid openmetrics exemplar value %q", parts[0])
+		}
+		s.exemplar = &openMetricsExemplar{labels: labels, value: value}
+	}
+	return s, nil
+}
+
+// parseOpenMetricsLabels parses the label set at the start of s, such as
+// {a="1",b="2"}, and returns the number of bytes it spans.
+func parseOpenMetricsLabels(s string) (map[string]string, int, error) {
+	labels := make(map[string]string)
+	i := 1
+	for {
+		if i >= len(s) {
+			return nil, 0, fmt.Errorf("unterminated label set")
+		}
+		if s[i] == '}' {
+			return labels, i + 1, nil
+		}
+
+		eq := strings.IndexByte(s[i:], '=')
+		if eq <= 0 || i+eq+1 >= len(s) || s[i+eq+1] != '"' {
+			return nil, 0, fmt.Errorf("invalid label at offset %d", i)
+		}
+		name := s[i : i+eq]
+		i += eq + 2
+
+		var value strings.Builder
+		for ; i < len(s) && s[i] != '"'; i++ {
+			if s[i] == '\\' && i+1 < len(s) {
+				i++
+				switch s[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(s[i])
+				}
+				continue
+			}
+			value.WriteByte(s[i])
+		}
+		if i >= len(s) {
+			return nil, 0, fmt.Errorf("unterminated label value")
+		}
+		labels[name] = value.String()
+		i++
+
+		if i < len(s) && s[i] == ',' {
+			i++
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package gather
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseOpenMetrics(t *testing.T) {
+	now := time.Unix(100, 0)
+	ms, err := parseOpenMetrics(strings.NewReader(sampleOpenMetrics), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := MetricsSlice{
+		{
+			Name: "http_requests",
+			Type: MetricTypeCounter,
+			Tags: map[string]string{"code": "200"},
+			Fields: map[string]interface{}{
+				"counter":                   1027.0,
+				"created":                   1.52e+09,
+				"counter_exemplar":          1.0,
+				"counter_exemplar_trace_id": "KOO5S4vxi0o",
+			},
+			Timestamp: now,
+		},
+		{
+			Name: "request_duration_seconds",
+			Type: MetricTypeHistogrm,
+			Tags: map[string]string{},
+			Fields: map[string]interface{}{
+				"0.5":                   3.0,
+				"0.5_exemplar":          0.42,
+				"0.5_exemplar_trace_id": "a\"b",
+				"+Inf":                  5.0,
+				"count":                 5.0,
+				"sum":                   3.1,
+			},
+			Timestamp: time.Unix(1520879607, 0),
+		},
+		{
+			Name:      "temperature",
+			Type:      MetricTypeGauge,
+			Tags:      map[string]string{"room": "a,b"},
+			Fields:    map[string]interface{}{"gauge": 21.5},
+			Timestamp: now,
+		},
+		{
+			Name:      "rpc_latency",
+			Type:      MetricTypeSummary,
+			Tags:      map[string]string{},
+			Fields:    map[string]interface{}{"0.9": 0.8, "count": 10.0, "sum": 4.0},
+			Timestamp: now,
+		},
+		{
+			Name:      "untyped_metric",
+			Type:      MetricTypeUntyped,
+			Tags:      map[string]string{},
+			Fields:    map[string]interface{}{"value": 7.0},
+			Timestamp: now,
+		},
+	}
+	if diff := cmp.Diff(want, ms); diff != "" {
+		t.Fatalf("unexpected metrics: -want/+got\n%s", diff)
+	}
+
+	if _, err := parseOpenMetrics(strings.NewReader(`foo{a="b" 1`), now); err == nil {
+		t.Fatal("expected error parsing an unterminated label set")
+	}
+}
+
+const sampleOpenMetrics = `# TYPE http_requests counter
+# HELP http_requests Total number of HTTP requests.
+http_requests_total{code="200"} 1027 # {trace_id="KOO5S4vxi0o"} 1
+http_requests_created{code="200"} 1.52e+09
+# TYPE request_duration_seconds histogram
+# UNIT request_duration_seconds seconds
+request_duration_seconds_bucket{le="0.5"} 3 1520879607 # {trace_id="a\"b"} 0.42 1520879606.5
+request_duration_seconds_bucket{le="+Inf"} 5 1520879607
+request_duration_seconds_count 5 1520879607
+request_duration_seconds_sum 3.1 1520879607
+# TYPE temperature gauge
+temperature{room="a,b"} 21.5
+# TYPE rpc_latency summary
+rpc_latency{quantile="0.9"} 0.8
+rpc_latency{quantile="0.99"} NaN
+rpc_latency_count 10
+rpc_latency_sum 4
+untyped_metric 7
+# EOF
+`
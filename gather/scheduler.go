@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"fmt"
 	"time"
 
 	"github.com/influxdata/influxdb"
@@ -14,16 +13,20 @@ import (
 
 // nats subjects
 const (
-	MetricsSubject    = "metrics"
-	promTargetSubject = "promTarget"
+	MetricsSubject       = "metrics"
+	scraperTargetSubject = "scraperTarget"
 )
 
+// schedulerTick is the longest duration between two checks for targets to scrape.
+const schedulerTick = time.Second
+
 // Scheduler is struct to run scrape jobs.
 type Scheduler struct {
 	Targets influxdb.ScraperTargetStoreService
-	// Interval is between each metrics gathering event.
+	// Interval is between each metrics gathering event of targets without an interval.
 	Interval time.Duration
-	// Timeout is the maxisium time duration allowed by each TCP request
+	// Timeout is the maxisium time duration allowed by each TCP request, and
+	// the timeout of the scrapes of targets without a timeout.
 	Timeout time.Duration
 
 	// Publisher will send the gather requests and gathered metrics to the queue.
@@ -32,6 +35,8 @@ type Scheduler struct {
 	Logger *zap.Logger
 
 	gather chan struct{}
+	// scraped is the time each target was last requested to be scraped.
+	scraped map[influxdb.ID]time.Time
 }
 
 // NewScheduler creates a new Scheduler and subscriptions for scraper jobs.
@@ -39,6 +44,7 @@ func NewScheduler(
 	numScrapers int,
 	l *zap.Logger,
 	targets influxdb.ScraperTargetStoreService,
+	secrets influxdb.SecretService,
 	p nats.Publisher,
 	s nats.Subscriber,
 	interval time.Duration,
@@ -57,13 +63,16 @@ func NewScheduler(
 		Publisher: p,
 		Logger:    l,
 		gather:    make(chan struct{}, 100),
+		scraped:   make(map[influxdb.ID]time.Time),
 	}
 
+	client := &TargetClient{Secrets: secrets}
 	for i := 0; i < numScrapers; i++ {
-		err := s.Subscribe(promTargetSubject, "metrics", &handler{
-			Scraper:   new(prometheusScraper),
+		err := s.Subscribe(scraperTargetSubject, "metrics", &handler{
+			Client:    client,
 			Publisher: p,
 			Logger:    l,
+			Timeout:   timeout,
 		})
 		if err != nil {
 			return nil, err
@@ -74,14 +83,19 @@ func NewScheduler(
 }
 
 // Run will retrieve scraper targets from the target storage,
-// and publish them to nats job queue for gather.
+// and publish the targets that are due to nats job queue for gather.
 func (s *Scheduler) Run(ctx context.Context) error {
+	tick := s.Interval
+	if tick > schedulerTick {
+		tick = schedulerTick
+	}
+
 	go func(s *Scheduler, ctx context.Context) {
 		for {
 			select {
 			case <-ctx.Done():
 				return
-			case <-time.After(s.Interval): // TODO: change to ticker because of garbage collection
+			case <-time.After(tick): // TODO: change to ticker because of garbage collection
 				s.gather <- struct{}{}
 			}
 		}
@@ -102,24 +116,35 @@ func (s *Scheduler) run(ctx context.Context) error {
 				s.Logger.Error("cannot list targets", zap.Error(err))
 				continue
 			}
-			for _, target := range targets {
-				if err := requestScrape(target, s.Publisher); err != nil {
-					s.Logger.Error("json encoding error", zap.Error(err))
-				}
-			}
+			s.scrapeDue(targets, time.Now())
 		}
 	}
 }
 
+// scrapeDue requests the scrape of the targets whose interval has elapsed
+// since they were last scraped.
+func (s *Scheduler) scrapeDue(targets []influxdb.ScraperTarget, now time.Time) {
+	scraped := make(map[influxdb.ID]time.Time, len(targets))
+	for _, target := range targets {
+		last, ok := s.scraped[target.ID]
+		if ok && now.Sub(last) < target.ScrapeInterval(s.Interval) {
+			scraped[target.ID] = last
+			continue
+		}
+
+		scraped[target.ID] = now
+		if err := requestScrape(target, s.Publisher); err != nil {
+			s.Logger.Error("json encoding error", zap.Error(err))
+		}
+	}
+	s.scraped = scraped
+}
+
 func requestScrape(t influxdb.ScraperTarget, publisher nats.Publisher) error {
 	buf := new(bytes.Buffer)
 	err := json.NewEncoder(buf).Encode(t)
 	if err != nil {
 		return err
 	}
-	switch t.Type {
-	case influxdb.PrometheusScraperType:
-		return publisher.Publish(promTargetSubject, buf)
-	}
-	return fmt.Errorf("unsupported target scrape type: %s", t.Type)
+	return publisher.Publish(scraperTargetSubject, buf)
 }
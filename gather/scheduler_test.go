@@ -48,7 +48,7 @@ func TestScheduler(t *testing.T) {
 	})
 
 	scheduler, err := NewScheduler(10, logger,
-		storage, publisher, subscriber, time.Millisecond, time.Microsecond)
+		storage, nil, publisher, subscriber, time.Millisecond, time.Second)
 
 	go func() {
 		err = scheduler.run(ctx)
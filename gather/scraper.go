@@ -2,6 +2,11 @@ package gather
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"sync"
 
 	"github.com/influxdata/influxdb"
 )
@@ -10,3 +15,189 @@ import (
 type Scraper interface {
 	Gather(ctx context.Context, target influxdb.ScraperTarget) (collected MetricsCollection, err error)
 }
+
+// ScraperFactory creates a Scraper that makes its requests to targets with c.
+type ScraperFactory func(c *TargetClient) Scraper
+
+var (
+	scrapersMu sync.RWMutex
+	scrapers   = map[influxdb.ScraperType]ScraperFactory{
+		influxdb.PrometheusScraperType: func(c *TargetClient) Scraper {
+			return &prometheusScraper{Client: c}
+		},
+		influxdb.OpenMetricsScraperType: func(c *TargetClient) Scraper {
+			return &openMetricsScraper{Client: c}
+		},
+		influxdb.JSONScraperType: func(c *TargetClient) Scraper {
+			return &jsonScraper{Client: c}
+		},
+		influxdb.StatsDScraperType: func(c *TargetClient) Scraper {
+			return &statsdScraper{Client: c}
+		},
+	}
+)
+
+// RegisterScraper registers the scraper of targets of type typ, replacing
+// any scraper previously registered for it.
+func RegisterScraper(typ influxdb.ScraperType, f ScraperFactory) {
+	scrapersMu.Lock()
+	defer scrapersMu.Unlock()
+	scrapers[typ] = f
+}
+
+// NewScraper returns the scraper of targets of type typ.
+func NewScraper(typ influxdb.ScraperType, c *TargetClient) (Scraper, error) {
+	scrapersMu.RLock()
+	f, ok := scrapers[typ]
+	scrapersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported target scrape type: %s", typ)
+	}
+	return f(c), nil
+}
+
+// TargetClient makes the HTTP requests of scrapers to their targets, with the
+// credentials and TLS options of each target.
+type TargetClient struct {
+	// Secrets resolves the secrets of the credentials of targets.
+	Secrets influxdb.SecretService
+
+	mu         sync.Mutex
+	transports map[influxdb.ID]*targetTransport
+}
+
+// targetTransport is the transport of the requests to a target with TLS
+// options, reused while its options and client key are unchanged.
+type targetTransport struct {
+	opts      influxdb.ScraperTLSConfig
+	key       string
+	transport *http.Transport
+}
+
+// Get requests the URL of the target, accepting the media type accept.
+// A nil TargetClient makes requests without credentials.
+func (c *TargetClient) Get(ctx context.Context, target influxdb.ScraperTarget, accept string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", target.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	if target.Username != "" {
+		password, err := c.loadSecret(ctx, target, target.PasswordSecret)
+		if err != nil {
+			return nil, err
+		}
+		req.SetBasicAuth(target.Username, password)
+	}
+	if target.BearerTokenSecret != "" {
+		token, err := c.loadSecret(ctx, target, target.BearerTokenSecret)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	hc := http.DefaultClient
+	if target.TLS != nil {
+		tr, err := c.transport(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		hc = &http.Client{Transport: tr}
+	}
+
+	resp, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("scraper target %s returned status %s", target.URL, resp.Status)
+	}
+	return resp, nil
+}
+
+func (c *TargetClient) loadSecret(ctx context.Context, target influxdb.ScraperTarget, key string) (string, error) {
+	if key == "" {
+		return "", nil
+	}
+	if c == nil || c.Secrets == nil {
+		return "", fmt.Errorf("cannot load secret %q of scraper target: no secret service", key)
+	}
+	return c.Secrets.LoadSecret(ctx, target.OrgID, key)
+}
+
+// transport returns the transport of the requests to a target with TLS
+// options. The transport of a target, and its connections, are reused by
+// its scrapes until its options change. A nil TargetClient does not keep
+// the connections alive instead.
+func (c *TargetClient) transport(ctx context.Context, target influxdb.ScraperTarget) (*http.Transport, error) {
+	var key string
+	if target.TLS.ClientCert != "" {
+		var err error
+		if key, err = c.loadSecret(ctx, target, target.TLS.ClientKeySecret); err != nil {
+			return nil, err
+		}
+	}
+
+	if c != nil {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if t, ok := c.transports[target.ID]; ok {
+			if t.opts == *target.TLS && t.key == key {
+				return t.transport, nil
+			}
+			t.transport.CloseIdleConnections()
+			delete(c.transports, target.ID)
+		}
+	}
+
+	cfg, err := tlsConfig(target, key)
+	if err != nil {
+		return nil, err
+	}
+	tr := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: cfg,
+	}
+	if c == nil {
+		tr.DisableKeepAlives = true
+		return tr, nil
+	}
+
+	if c.transports == nil {
+		c.transports = make(map[influxdb.ID]*targetTransport)
+	}
+	c.transports[target.ID] = &targetTransport{opts: *target.TLS, key: key, transport: tr}
+	return tr, nil
+}
+
+// tlsConfig returns the TLS configuration of the target, with the private
+// key key of its client certificate.
+func tlsConfig(target influxdb.ScraperTarget, key string) (*tls.Config, error) {
+	opts := target.TLS
+	cfg := &tls.Config{
+		ServerName:         opts.ServerName,
+		InsecureSkipVerify: opts.InsecureSkipVerify,
+	}
+
+	if opts.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(opts.CACert)) {
+			return nil, fmt.Errorf("invalid CA certificate of scraper target %s", target.URL)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if opts.ClientCert != "" {
+		cert, err := tls.X509KeyPair([]byte(opts.ClientCert), []byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate of scraper target %s: %v", target.URL, err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
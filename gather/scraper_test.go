@@ -2,6 +2,9 @@ package gather
 
 import (
 	"context"
+	"io"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -11,6 +14,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
 )
 
 var (
@@ -155,6 +159,170 @@ func TestPrometheusScraper(t *testing.T) {
 	}
 }
 
+func TestScrapers(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/json":
+			w.Write([]byte(`{"status":{"version":"1.2","connections":[{"active":3},{"active":4}],"up":true}}`))
+		case "/statsd":
+			w.Write([]byte("requests:1|c|#route:home\nrequests:2|c|@0.5|#route:home\nload:3|g\nload:-1|g\nlatency:10|ms\nlatency:30|ms\nusers:a|s\nusers:b|s\nusers:a|s\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client := &TargetClient{Secrets: newSecretService(map[string]string{"password": "secret"})}
+	gather := func(target influxdb.ScraperTarget) MetricsSlice {
+		t.Helper()
+		target.URL = ts.URL + target.URL
+		target.Username, target.PasswordSecret = "user", "password"
+		if err := target.Validate(); err != nil {
+			t.Fatal(err)
+		}
+
+		scraper, err := NewScraper(target.Type, client)
+		if err != nil {
+			t.Fatal(err)
+		}
+		collected, err := scraper.Gather(context.Background(), target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range collected.MetricsSlice {
+			collected.MetricsSlice[i].Timestamp = time.Time{}
+		}
+		return collected.MetricsSlice
+	}
+
+	ms := gather(influxdb.ScraperTarget{
+		Name: "app",
+		Type: influxdb.JSONScraperType,
+		URL:  "/json",
+		JSON: &influxdb.ScraperJSONConfig{
+			Tags: []influxdb.ScraperJSONMapping{{Name: "version", Path: "status.version"}},
+			Fields: []influxdb.ScraperJSONMapping{
+				{Name: "active", Path: "status.connections.1.active"},
+				{Name: "up", Path: "status.up"},
+				{Name: "missing", Path: "status.missing"},
+			},
+		},
+	})
+	want := MetricsSlice{{
+		Name:   "app",
+		Type:   MetricTypeUntyped,
+		Tags:   map[string]string{"version": "1.2"},
+		Fields: map[string]interface{}{"active": 4.0, "up": true},
+	}}
+	if !reflect.DeepEqual(ms, want) {
+		t.Fatalf("unexpected json metrics: got %v, want %v", ms, want)
+	}
+
+	ms = gather(influxdb.ScraperTarget{
+		Type: influxdb.StatsDScraperType,
+		URL:  "/statsd",
+	})
+	want = MetricsSlice{
+		{Name: "requests", Type: MetricTypeCounter, Tags: map[string]string{"route": "home"}, Fields: map[string]interface{}{"counter": 5.0}},
+		{Name: "load", Type: MetricTypeGauge, Tags: map[string]string{}, Fields: map[string]interface{}{"gauge": 2.0}},
+		{Name: "latency", Type: MetricTypeSummary, Tags: map[string]string{}, Fields: map[string]interface{}{"count": 2.0, "sum": 40.0, "min": 10.0, "max": 30.0}},
+		{Name: "users", Type: MetricTypeGauge, Tags: map[string]string{}, Fields: map[string]interface{}{"unique": 2.0}},
+	}
+	if !reflect.DeepEqual(ms, want) {
+		t.Fatalf("unexpected statsd metrics: got %v, want %v", ms, want)
+	}
+
+	// Without the secret of its password the target refuses the scrape.
+	scraper, _ := NewScraper(influxdb.StatsDScraperType, &TargetClient{Secrets: newSecretService(nil)})
+	if _, err := scraper.Gather(context.Background(), influxdb.ScraperTarget{
+		Type:           influxdb.StatsDScraperType,
+		URL:            ts.URL + "/statsd",
+		Username:       "user",
+		PasswordSecret: "password",
+	}); err == nil {
+		t.Fatal("expected error scraping without the password")
+	}
+
+	if _, err := NewScraper("unknown", client); err == nil {
+		t.Fatal("expected error for an unsupported scraper type")
+	}
+}
+
+func TestTargetClient_TLSConnections(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		conns int
+	)
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("up 1\n"))
+	}))
+	ts.Config.ConnState = func(c net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			mu.Lock()
+			conns++
+			mu.Unlock()
+		}
+	}
+	ts.StartTLS()
+	defer ts.Close()
+
+	client := &TargetClient{}
+	target := influxdb.ScraperTarget{
+		ID:  1,
+		URL: ts.URL,
+		TLS: &influxdb.ScraperTLSConfig{InsecureSkipVerify: true},
+	}
+	get := func() {
+		t.Helper()
+		resp, err := client.Get(context.Background(), target, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}
+	count := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return conns
+	}
+
+	// The scrapes of a target reuse its connection.
+	for i := 0; i < 3; i++ {
+		get()
+	}
+	if n := count(); n != 1 {
+		t.Fatalf("expected the scrapes to reuse a connection, got %d connections", n)
+	}
+
+	// Changing the options of the target replaces its transport.
+	target.TLS = &influxdb.ScraperTLSConfig{InsecureSkipVerify: true, ServerName: "example.com"}
+	get()
+	if n := count(); n != 2 {
+		t.Fatalf("expected a new connection, got %d connections", n)
+	}
+	if len(client.transports) != 1 {
+		t.Fatalf("expected a transport per target, got %d", len(client.transports))
+	}
+}
+
+// newSecretService returns a secret service holding the secrets of m.
+func newSecretService(m map[string]string) *mock.SecretService {
+	s := mock.NewSecretService()
+	s.LoadSecretFn = func(ctx context.Context, orgID influxdb.ID, k string) (string, error) {
+		v, ok := m[k]
+		if !ok {
+			return "", &influxdb.Error{Code: influxdb.ENotFound, Msg: "secret not found"}
+		}
+		return v, nil
+	}
+	return s
+}
+
 const sampleResp = `
 # 	HELP go_gc_duration_seconds A summary of the GC invocation durations.
 # TYPE go_gc_duration_seconds summary
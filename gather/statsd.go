@@ -0,0 +1,159 @@
+package gather
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+)
+
+// statsdScraper parses metrics in the StatsD line format, such as
+// "requests:1|c|@0.5|#route:home", one per line. The lines of a metric with
+// the same tags are aggregated: counters are summed, the last gauge wins,
+// timings and histograms are summarized and sets count their unique values.
+type statsdScraper struct {
+	Client *TargetClient
+}
+
+// Gather parses the metrics of the target url.
+func (p *statsdScraper) Gather(ctx context.Context, target influxdb.ScraperTarget) (collected MetricsCollection, err error) {
+	resp, err := p.Client.Get(ctx, target, "text/plain")
+	if err != nil {
+		return collected, err
+	}
+	defer resp.Body.Close()
+
+	ms, err := parseStatsD(resp.Body, time.Now())
+	if err != nil {
+		return collected, err
+	}
+
+	return MetricsCollection{
+		MetricsSlice: ms,
+		OrgID:        target.OrgID,
+		BucketID:     target.BucketID,
+	}, nil
+}
+
+type statsdMetric struct {
+	Metrics
+	set map[string]struct{}
+}
+
+func parseStatsD(r io.Reader, now time.Time) (MetricsSlice, error) {
+	metrics := make(map[string]*statsdMetric)
+	var keys []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		// name:value|type[|@rate][|#tag:value,...]
+		parts := strings.Split(line, "|")
+		colon := strings.LastIndexByte(parts[0], ':')
+		if len(parts) < 2 || colon <= 0 {
+			return nil, fmt.Errorf("invalid statsd line %q", line)
+		}
+		name, raw, typ := parts[0][:colon], parts[0][colon+1:], parts[1]
+
+		rate := 1.0
+		tags := make(map[string]string)
+		for _, part := range parts[2:] {
+			switch {
+			case strings.HasPrefix(part, "@"):
+				v, err := strconv.ParseFloat(part[1:], 64)
+				if err != nil || v <= 0 || v > 1 {
+					return nil, fmt.Errorf("invalid statsd sample rate in %q", line)
+				}
+				rate = v
+			case strings.HasPrefix(part, "#"):
+				for _, tag := range strings.Split(part[1:], ",") {
+					if kv := strings.SplitN(tag, ":", 2); len(kv) == 2 {
+						tags[kv[0]] = kv[1]
+					} else if tag != "" {
+						tags[tag] = "true"
+					}
+				}
+			}
+		}
+
+		key := typ + "\x00" + name + "\x00" + string(models.NewTags(tags).HashKey())
+		m, ok := metrics[key]
+		if !ok {
+			m = &statsdMetric{Metrics: Metrics{
+				Name:      name,
+				Tags:      tags,
+				Fields:    make(map[string]interface{}),
+				Timestamp: now,
+			}}
+			metrics[key] = m
+			keys = append(keys, key)
+		}
+
+		if typ == "s" {
+			if m.set == nil {
+				m.set = make(map[string]struct{})
+			}
+			m.set[raw] = struct{}{}
+			m.Type = MetricTypeGauge
+			m.Fields["unique"] = float64(len(m.set))
+			continue
+		}
+
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid statsd value in %q", line)
+		}
+
+		switch typ {
+		case "c":
+			m.Type = MetricTypeCounter
+			counter, _ := m.Fields["counter"].(float64)
+			m.Fields["counter"] = counter + value/rate
+		case "g":
+			m.Type = MetricTypeGauge
+			// Signed gauges are relative to the previous value.
+			if gauge, ok := m.Fields["gauge"].(float64); ok && (raw[0] == '+' || raw[0] == '-') {
+				value += gauge
+			}
+			m.Fields["gauge"] = value
+		case "ms", "h", "d":
+			m.Type = MetricTypeSummary
+			count, _ := m.Fields["count"].(float64)
+			sum, _ := m.Fields["sum"].(float64)
+			min, ok := m.Fields["min"].(float64)
+			if !ok {
+				min = math.Inf(1)
+			}
+			max, ok := m.Fields["max"].(float64)
+			if !ok {
+				max = math.Inf(-1)
+			}
+			m.Fields["count"] = count + 1/rate
+			m.Fields["sum"] = sum + value/rate
+			m.Fields["min"] = math.Min(min, value)
+			m.Fields["max"] = math.Max(max, value)
+		default:
+			return nil, fmt.Errorf("unsupported statsd metric type %q in %q", typ, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	ms := make(MetricsSlice, 0, len(keys))
+	for _, key := range keys {
+		ms = append(ms, metrics[key].Metrics)
+	}
+	return ms, nil
+}
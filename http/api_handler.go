@@ -14,25 +14,35 @@ import (
 
 // APIHandler is a collection of all the service handlers.
 type APIHandler struct {
-	BucketHandler        *BucketHandler
-	UserHandler          *UserHandler
-	OrgHandler           *OrgHandler
-	AuthorizationHandler *AuthorizationHandler
-	DashboardHandler     *DashboardHandler
-	LabelHandler         *LabelHandler
-	AssetHandler         *AssetHandler
-	ChronografHandler    *ChronografHandler
-	ScraperHandler       *ScraperHandler
-	SourceHandler        *SourceHandler
-	VariableHandler      *VariableHandler
-	TaskHandler          *TaskHandler
-	TelegrafHandler      *TelegrafHandler
-	QueryHandler         *FluxHandler
-	ProtoHandler         *ProtoHandler
-	WriteHandler         *WriteHandler
-	SetupHandler         *SetupHandler
-	SessionHandler       *SessionHandler
-	SwaggerHandler       http.HandlerFunc
+	BucketHandler               *BucketHandler
+	UserHandler                 *UserHandler
+	OrgHandler                  *OrgHandler
+	AuthorizationHandler        *AuthorizationHandler
+	DashboardHandler            *DashboardHandler
+	DBRPMappingHandler          *DBRPMappingHandler
+	LabelHandler                *LabelHandler
+	AssetHandler                *AssetHandler
+	ChronografHandler           *ChronografHandler
+	ScraperHandler              *ScraperHandler
+	SourceHandler               *SourceHandler
+	VariableHandler             *VariableHandler
+	TaskHandler                 *TaskHandler
+	TelegrafHandler             *TelegrafHandler
+	QueryHandler                *FluxHandler
+	ProtoHandler                *ProtoHandler
+	WriteHandler                *WriteHandler
+	DeleteHandler               *DeleteHandler
+	BackupHandler               *BackupHandler
+	AuditHandler                *AuditHandler
+	UsageHandler                *UsageHandler
+	CheckHandler                *CheckHandler
+	NotificationEndpointHandler *NotificationEndpointHandler
+	V1WriteHandler              *V1WriteHandler
+	V1QueryHandler              *V1QueryHandler
+	SetupHandler                *SetupHandler
+	SessionHandler              *SessionHandler
+	OAuth2Handler               *OAuth2Handler
+	SwaggerHandler              http.HandlerFunc
 }
 
 // APIBackend is all services and associated parameters required to construct
@@ -45,6 +55,10 @@ type APIBackend struct {
 	NewQueryService  func(*influxdb.Source) (query.ProxyQueryService, error)
 
 	PointsWriter                    storage.PointsWriter
+	PredicateDeleter                storage.PredicateDeleter
+	BackupService                   influxdb.BackupService
+	KVBackupService                 influxdb.KVBackupService
+	AuditService                    influxdb.AuditService
 	AuthorizationService            influxdb.AuthorizationService
 	BucketService                   influxdb.BucketService
 	SessionService                  influxdb.SessionService
@@ -59,6 +73,8 @@ type APIBackend struct {
 	OrganizationOperationLogService influxdb.OrganizationOperationLogService
 	SourceService                   influxdb.SourceService
 	VariableService                 influxdb.VariableService
+	CheckService                    influxdb.CheckService
+	NotificationEndpointService     influxdb.NotificationEndpointService
 	PasswordsService                influxdb.PasswordsService
 	OnboardingService               influxdb.OnboardingService
 	InfluxQLService                 query.ProxyQueryService
@@ -68,10 +84,29 @@ type APIBackend struct {
 	ScraperTargetStoreService       influxdb.ScraperTargetStoreService
 	SecretService                   influxdb.SecretService
 	LookupService                   influxdb.LookupService
+	DBRPMappingService              influxdb.DBRPMappingService
 	ChronografService               *server.Service
 	ProtoService                    influxdb.ProtoService
 	OrgLookupService                authorizer.OrganizationService
 	ViewService                     influxdb.ViewService
+	OrgLimitsService                influxdb.OrgLimitsService
+	OrgLimiter                      influxdb.OrgLimiter
+	UsageService                    influxdb.UsageService
+	UsageRecorder                   influxdb.UsageRecorder
+	AuthorizationUseRecorder        influxdb.AuthorizationUseRecorder
+	CardinalityService              influxdb.CardinalityService
+
+	// OAuth2Config configures the signin of users through an OAuth2 provider.
+	// Users only sign in with a username and password when it is nil.
+	OAuth2Config *OAuth2Config
+
+	// MaxWriteErrors is the maximum number of rejected lines listed in the
+	// response to a write.
+	MaxWriteErrors int
+	// MaxWriteBodySize is the maximum size in bytes of the body of a write.
+	MaxWriteBodySize int64
+	// MaxWriteBatchSize is the maximum number of points written at once.
+	MaxWriteBatchSize int
 }
 
 // NewAPIHandler constructs all api handlers beneath it and returns an APIHandler
@@ -79,41 +114,72 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 	h := &APIHandler{}
 
 	internalURM := b.UserResourceMappingService
-	b.UserResourceMappingService = authorizer.NewURMService(b.OrgLookupService, b.UserResourceMappingService)
+	urmService := authorizer.NewURMService(b.OrgLookupService, b.UserResourceMappingService)
+	urmService.SetAuditService(b.AuditService, b.Logger)
+	b.UserResourceMappingService = urmService
 
 	sessionBackend := NewSessionBackend(b)
 	h.SessionHandler = NewSessionHandler(sessionBackend)
 
+	oauth2Backend := NewOAuth2Backend(b)
+	oauth2Backend.UserResourceMappingService = internalURM
+	h.OAuth2Handler = NewOAuth2Handler(oauth2Backend)
+
 	bucketBackend := NewBucketBackend(b)
-	bucketBackend.BucketService = authorizer.NewBucketService(b.BucketService)
+	bucketService := authorizer.NewBucketService(b.BucketService)
+	bucketService.SetAuditService(b.AuditService, b.Logger)
+	bucketBackend.BucketService = bucketService
+	if b.CardinalityService != nil {
+		bucketBackend.CardinalityService = authorizer.NewCardinalityService(b.CardinalityService)
+	}
 	h.BucketHandler = NewBucketHandler(bucketBackend)
 
 	orgBackend := NewOrgBackend(b)
-	orgBackend.OrganizationService = authorizer.NewOrgService(b.OrganizationService)
+	organizationService := authorizer.NewOrgService(b.OrganizationService)
+	organizationService.SetAuditService(b.AuditService, b.Logger)
+	orgBackend.OrganizationService = organizationService
+	secretService := authorizer.NewSecretService(b.SecretService)
+	secretService.SetAuditService(b.AuditService, b.Logger)
+	orgBackend.SecretService = secretService
+	orgLimitsService := authorizer.NewOrgLimitsService(b.OrgLimitsService)
+	orgLimitsService.SetAuditService(b.AuditService, b.Logger)
+	orgBackend.OrgLimitsService = orgLimitsService
 	h.OrgHandler = NewOrgHandler(orgBackend)
 
 	userBackend := NewUserBackend(b)
-	userBackend.UserService = authorizer.NewUserService(b.UserService)
+	userService := authorizer.NewUserService(b.UserService)
+	userService.SetAuditService(b.AuditService, b.Logger)
+	userBackend.UserService = userService
 	h.UserHandler = NewUserHandler(userBackend)
 
 	dashboardBackend := NewDashboardBackend(b)
-	dashboardBackend.DashboardService = authorizer.NewDashboardService(b.DashboardService)
+	dashboardService := authorizer.NewDashboardService(b.DashboardService)
+	dashboardService.SetAuditService(b.AuditService, b.Logger)
+	dashboardBackend.DashboardService = dashboardService
 	h.DashboardHandler = NewDashboardHandler(dashboardBackend)
 
 	variableBackend := NewVariableBackend(b)
-	variableBackend.VariableService = authorizer.NewVariableService(b.VariableService)
+	variableService := authorizer.NewVariableService(b.VariableService)
+	variableService.SetAuditService(b.AuditService, b.Logger)
+	variableBackend.VariableService = variableService
 	h.VariableHandler = NewVariableHandler(variableBackend)
 
 	authorizationBackend := NewAuthorizationBackend(b)
-	authorizationBackend.AuthorizationService = authorizer.NewAuthorizationService(b.AuthorizationService)
+	authorizationService := authorizer.NewAuthorizationService(b.AuthorizationService)
+	authorizationService.SetAuditService(b.AuditService, b.Logger)
+	authorizationBackend.AuthorizationService = authorizationService
 	h.AuthorizationHandler = NewAuthorizationHandler(authorizationBackend)
 
 	scraperBackend := NewScraperBackend(b)
-	scraperBackend.ScraperStorageService = authorizer.NewScraperTargetStoreService(b.ScraperTargetStoreService, b.UserResourceMappingService)
+	scraperService := authorizer.NewScraperTargetStoreService(b.ScraperTargetStoreService, b.UserResourceMappingService)
+	scraperService.SetAuditService(b.AuditService, b.Logger)
+	scraperBackend.ScraperStorageService = scraperService
 	h.ScraperHandler = NewScraperHandler(scraperBackend)
 
 	sourceBackend := NewSourceBackend(b)
-	sourceBackend.SourceService = authorizer.NewSourceService(b.SourceService)
+	sourceService := authorizer.NewSourceService(b.SourceService)
+	sourceService.SetAuditService(b.AuditService, b.Logger)
+	sourceBackend.SourceService = sourceService
 	sourceBackend.NewBucketService = b.NewBucketService
 	sourceBackend.NewQueryService = b.NewQueryService
 	h.SourceHandler = NewSourceHandler(sourceBackend)
@@ -126,15 +192,53 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 	h.TaskHandler.UserResourceMappingService = internalURM
 
 	telegrafBackend := NewTelegrafBackend(b)
-	telegrafBackend.TelegrafService = authorizer.NewTelegrafConfigService(b.TelegrafService, b.UserResourceMappingService)
+	telegrafService := authorizer.NewTelegrafConfigService(b.TelegrafService, b.UserResourceMappingService)
+	telegrafService.SetAuditService(b.AuditService, b.Logger)
+	telegrafBackend.TelegrafService = telegrafService
 	h.TelegrafHandler = NewTelegrafHandler(telegrafBackend)
 
 	writeBackend := NewWriteBackend(b)
 	h.WriteHandler = NewWriteHandler(writeBackend)
 
+	deleteBackend := NewDeleteBackend(b)
+	h.DeleteHandler = NewDeleteHandler(deleteBackend)
+
+	backupBackend := NewBackupBackend(b)
+	h.BackupHandler = NewBackupHandler(backupBackend)
+
+	auditBackend := NewAuditBackend(b)
+	h.AuditHandler = NewAuditHandler(auditBackend)
+
+	dbrpMappingBackend := NewDBRPMappingBackend(b)
+	dbrpMappingService := authorizer.NewDBRPMappingService(b.DBRPMappingService)
+	dbrpMappingService.SetAuditService(b.AuditService, b.Logger)
+	dbrpMappingBackend.DBRPMappingService = dbrpMappingService
+	h.DBRPMappingHandler = NewDBRPMappingHandler(dbrpMappingBackend)
+
+	usageBackend := NewUsageBackend(b)
+	if b.UsageService != nil {
+		usageBackend.UsageService = authorizer.NewUsageService(b.UsageService)
+	}
+	h.UsageHandler = NewUsageHandler(usageBackend)
+
+	checkBackend := NewCheckBackend(b)
+	checkService := authorizer.NewCheckService(b.CheckService)
+	checkService.SetAuditService(b.AuditService, b.Logger)
+	checkBackend.CheckService = checkService
+	h.CheckHandler = NewCheckHandler(checkBackend)
+
+	notificationEndpointBackend := NewNotificationEndpointBackend(b)
+	notificationEndpointService := authorizer.NewNotificationEndpointService(b.NotificationEndpointService)
+	notificationEndpointService.SetAuditService(b.AuditService, b.Logger)
+	notificationEndpointBackend.NotificationEndpointService = notificationEndpointService
+	h.NotificationEndpointHandler = NewNotificationEndpointHandler(notificationEndpointBackend)
+
 	fluxBackend := NewFluxBackend(b)
 	h.QueryHandler = NewFluxHandler(fluxBackend)
 
+	h.V1WriteHandler = NewV1WriteHandler(NewV1WriteBackend(b))
+	h.V1QueryHandler = NewV1QueryHandler(NewV1QueryBackend(b))
+
 	h.ProtoHandler = NewProtoHandler(NewProtoBackend(b))
 	h.ChronografHandler = NewChronografHandler(b.ChronografService)
 	h.SwaggerHandler = SwaggerHandler()
@@ -146,17 +250,23 @@ func NewAPIHandler(b *APIBackend) *APIHandler {
 var apiLinks = map[string]interface{}{
 	// when adding new links, please take care to keep this list alphabetical
 	// as this makes it easier to verify values against the swagger document.
+	"audit":          "/api/v2/audit",
 	"authorizations": "/api/v2/authorizations",
+	"backup":         "/api/v2/backup",
 	"buckets":        "/api/v2/buckets",
+	"checks":         "/api/v2/checks",
 	"dashboards":     "/api/v2/dashboards",
+	"dbrps":          "/api/v2/dbrps",
+	"delete":         "/api/v2/delete",
 	"external": map[string]string{
 		"statusFeed": "https://www.influxdata.com/feed/json",
 	},
-	"labels":    "/api/v2/labels",
-	"variables": "/api/v2/variables",
-	"me":        "/api/v2/me",
-	"orgs":      "/api/v2/orgs",
-	"protos":    "/api/v2/protos",
+	"labels":                "/api/v2/labels",
+	"variables":             "/api/v2/variables",
+	"me":                    "/api/v2/me",
+	"notificationEndpoints": "/api/v2/notificationEndpoints",
+	"orgs":                  "/api/v2/orgs",
+	"protos":                "/api/v2/protos",
 	"query": map[string]string{
 		"self":        "/api/v2/query",
 		"ast":         "/api/v2/query/ast",
@@ -177,6 +287,7 @@ var apiLinks = map[string]interface{}{
 	},
 	"tasks":     "/api/v2/tasks",
 	"telegrafs": "/api/v2/telegrafs",
+	"usage":     "/api/v2/usage",
 	"users":     "/api/v2/users",
 	"write":     "/api/v2/write",
 }
@@ -202,6 +313,11 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, oauth2SigninPath) {
+		h.OAuth2Handler.ServeHTTP(w, r)
+		return
+	}
+
 	if r.URL.Path == "/api/v2/signin" || r.URL.Path == "/api/v2/signout" {
 		h.SessionHandler.ServeHTTP(w, r)
 		return
@@ -217,11 +333,46 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, "/api/v2/delete") {
+		h.DeleteHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/backup") {
+		h.BackupHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/audit") {
+		h.AuditHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/dbrps") {
+		h.DBRPMappingHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/usage") {
+		h.UsageHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/api/v2/query") {
 		h.QueryHandler.ServeHTTP(w, r)
 		return
 	}
 
+	if r.URL.Path == v1WritePath {
+		h.V1WriteHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if r.URL.Path == v1QueryPath {
+		h.V1QueryHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/api/v2/buckets") {
 		h.BucketHandler.ServeHTTP(w, r)
 		return
@@ -277,6 +428,16 @@ func (h *APIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if strings.HasPrefix(r.URL.Path, "/api/v2/checks") {
+		h.CheckHandler.ServeHTTP(w, r)
+		return
+	}
+
+	if strings.HasPrefix(r.URL.Path, "/api/v2/notificationEndpoints") {
+		h.NotificationEndpointHandler.ServeHTTP(w, r)
+		return
+	}
+
 	if strings.HasPrefix(r.URL.Path, "/api/v2/variables") {
 		h.VariableHandler.ServeHTTP(w, r)
 		return
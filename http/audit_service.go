@@ -0,0 +1,282 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// AuditBackend is all services and associated parameters required to construct
+// the AuditHandler.
+type AuditBackend struct {
+	Logger *zap.Logger
+
+	AuditService influxdb.AuditService
+}
+
+// NewAuditBackend returns a new instance of AuditBackend.
+func NewAuditBackend(b *APIBackend) *AuditBackend {
+	return &AuditBackend{
+		Logger: b.Logger.With(zap.String("handler", "audit")),
+
+		AuditService: b.AuditService,
+	}
+}
+
+// AuditHandler serves the audit log of the mutating operations of the API.
+type AuditHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	AuditService influxdb.AuditService
+}
+
+const (
+	auditPath = "/api/v2/audit"
+)
+
+// NewAuditHandler creates a new handler at /api/v2/audit to find audit events.
+func NewAuditHandler(b *AuditBackend) *AuditHandler {
+	h := &AuditHandler{
+		Router: NewRouter(),
+		Logger: b.Logger,
+
+		AuditService: b.AuditService,
+	}
+
+	h.HandlerFunc("GET", auditPath, h.handleGetAuditEvents)
+	return h
+}
+
+type auditEventsResponse struct {
+	Links  *influxdb.PagingLinks  `json:"links"`
+	Events []*influxdb.AuditEvent `json:"events"`
+}
+
+// handleGetAuditEvents is the HTTP handler for the GET /api/v2/audit route.
+func (h *AuditHandler) handleGetAuditEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetAuditEventsRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := authorizeAuditEvents(ctx, req.filter); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	es, n, err := h.AuditService.FindAuditEvents(ctx, req.filter, req.opts)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	res := auditEventsResponse{
+		Links:  newPagingLinks(auditPath, req.opts, auditEventFilter(req.filter), n),
+		Events: es,
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+type getAuditEventsRequest struct {
+	filter influxdb.AuditEventFilter
+	opts   influxdb.FindOptions
+}
+
+func decodeGetAuditEventsRequest(ctx context.Context, r *http.Request) (*getAuditEventsRequest, error) {
+	qp := r.URL.Query()
+	req := &getAuditEventsRequest{}
+
+	opts, err := decodeFindOptions(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	req.opts = *opts
+
+	for _, t := range []struct {
+		name string
+		dst  *time.Time
+	}{
+		{"start", &req.filter.Start},
+		{"stop", &req.filter.Stop},
+	} {
+		if s := qp.Get(t.name); s != "" {
+			if *t.dst, err = time.Parse(time.RFC3339Nano, s); err != nil {
+				return nil, &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  t.name + " must be in RFC3339 format",
+					Err:  err,
+				}
+			}
+		}
+	}
+
+	for _, t := range []struct {
+		name string
+		dst  **influxdb.ID
+	}{
+		{"orgID", &req.filter.OrgID},
+		{"userID", &req.filter.UserID},
+		{"resourceID", &req.filter.ResourceID},
+	} {
+		if s := qp.Get(t.name); s != "" {
+			id, err := influxdb.IDFromString(s)
+			if err != nil {
+				return nil, err
+			}
+			*t.dst = id
+		}
+	}
+
+	if s := qp.Get("resourceType"); s != "" {
+		rt := influxdb.ResourceType(s)
+		if err := rt.Valid(); err != nil {
+			return nil, err
+		}
+		req.filter.ResourceType = &rt
+	}
+
+	return req, nil
+}
+
+// authorizeAuditEvents ensures that the authorizer of the request may read
+// the audit events of the organization of the filter, which requires reading
+// its authorizations, or the events of every organization when the filter
+// has none.
+func authorizeAuditEvents(ctx context.Context, filter influxdb.AuditEventFilter) error {
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return err
+	}
+
+	var perms []influxdb.Permission
+	if filter.OrgID != nil {
+		p, err := influxdb.NewPermission(influxdb.ReadAction, influxdb.AuthorizationsResourceType, *filter.OrgID)
+		if err != nil {
+			return err
+		}
+		perms = append(perms, *p)
+	} else {
+		for _, p := range influxdb.OperPermissions() {
+			if p.Action == influxdb.ReadAction {
+				perms = append(perms, p)
+			}
+		}
+	}
+
+	for _, p := range perms {
+		if !a.Allowed(p) {
+			return &influxdb.Error{
+				Code: influxdb.EForbidden,
+				Op:   "http/authorizeAuditEvents",
+				Msg:  "insufficient permissions to read audit events",
+			}
+		}
+	}
+	return nil
+}
+
+// auditEventFilter returns the query parameters of the paging links of audit events.
+type auditEventFilter influxdb.AuditEventFilter
+
+func (f auditEventFilter) QueryParams() map[string][]string {
+	qp := map[string][]string{}
+	if !f.Start.IsZero() {
+		qp["start"] = []string{f.Start.Format(time.RFC3339Nano)}
+	}
+	if !f.Stop.IsZero() {
+		qp["stop"] = []string{f.Stop.Format(time.RFC3339Nano)}
+	}
+	if f.OrgID != nil {
+		qp["orgID"] = []string{f.OrgID.String()}
+	}
+	if f.UserID != nil {
+		qp["userID"] = []string{f.UserID.String()}
+	}
+	if f.ResourceType != nil {
+		qp["resourceType"] = []string{string(*f.ResourceType)}
+	}
+	if f.ResourceID != nil {
+		qp["resourceID"] = []string{f.ResourceID.String()}
+	}
+	return qp
+}
+
+// AuditService finds audit events over HTTP. Events are recorded by the
+// server, so RecordAuditEvent is not supported.
+type AuditService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+var _ influxdb.AuditService = (*AuditService)(nil)
+
+// RecordAuditEvent returns an error, as events cannot be recorded over HTTP.
+func (s *AuditService) RecordAuditEvent(ctx context.Context, e *influxdb.AuditEvent) error {
+	return &influxdb.Error{
+		Code: influxdb.EMethodNotAllowed,
+		Op:   influxdb.OpRecordAuditEvent,
+		Msg:  "audit events cannot be recorded over HTTP",
+	}
+}
+
+// FindAuditEvents returns the audit events matching the filter.
+func (s *AuditService) FindAuditEvents(ctx context.Context, filter influxdb.AuditEventFilter, opt ...influxdb.FindOptions) ([]*influxdb.AuditEvent, int, error) {
+	u, err := newURL(s.Addr, auditPath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := u.Query()
+	for k, vs := range auditEventFilter(filter).QueryParams() {
+		for _, v := range vs {
+			query.Add(k, v)
+		}
+	}
+	if len(opt) > 0 {
+		for k, vs := range opt[0].QueryParams() {
+			for _, v := range vs {
+				query.Add(k, v)
+			}
+		}
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req.URL.RawQuery = query.Encode()
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, 0, err
+	}
+
+	var res auditEventsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, 0, err
+	}
+	return res.Events, len(res.Events), nil
+}
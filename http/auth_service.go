@@ -6,13 +6,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"path"
+	"time"
 
 	"go.uber.org/zap"
 
 	platform "github.com/influxdata/influxdb"
 	platcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/storage/reads"
 	"github.com/julienschmidt/httprouter"
 )
 
@@ -67,6 +70,7 @@ func NewAuthorizationHandler(b *AuthorizationBackend) *AuthorizationHandler {
 	h.HandlerFunc("GET", "/api/v2/authorizations/:id", h.handleGetAuthorization)
 	h.HandlerFunc("PATCH", "/api/v2/authorizations/:id", h.handleSetAuthorizationStatus)
 	h.HandlerFunc("DELETE", "/api/v2/authorizations/:id", h.handleDeleteAuthorization)
+	h.HandlerFunc("POST", "/api/v2/authorizations/:id/rotate", h.handleRotateAuthorization)
 	return h
 }
 
@@ -80,6 +84,8 @@ type authResponse struct {
 	UserID      platform.ID          `json:"userID"`
 	User        string               `json:"user"`
 	Permissions []permissionResponse `json:"permissions"`
+	ExpiresAt   *time.Time           `json:"expiresAt,omitempty"`
+	LastUsedAt  *time.Time           `json:"lastUsedAt,omitempty"`
 	Links       map[string]string    `json:"links"`
 }
 
@@ -94,6 +100,8 @@ func newAuthResponse(a *platform.Authorization, org *platform.Organization, user
 		User:        user.Name,
 		Org:         org.Name,
 		Permissions: ps,
+		ExpiresAt:   a.ExpiresAt,
+		LastUsedAt:  a.LastUsedAt,
 		Links: map[string]string{
 			"self": fmt.Sprintf("/api/v2/authorizations/%s", a.ID),
 			"user": fmt.Sprintf("/api/v2/users/%s", a.UserID),
@@ -110,16 +118,19 @@ func (a *authResponse) toPlatform() *platform.Authorization {
 		Description: a.Description,
 		OrgID:       a.OrgID,
 		UserID:      a.UserID,
+		ExpiresAt:   a.ExpiresAt,
+		LastUsedAt:  a.LastUsedAt,
 	}
 	for _, p := range a.Permissions {
-		res.Permissions = append(res.Permissions, platform.Permission{Action: p.Action, Resource: p.Resource.Resource})
+		res.Permissions = append(res.Permissions, platform.Permission{Action: p.Action, Resource: p.Resource.Resource, Predicate: p.Predicate})
 	}
 	return res
 }
 
 type permissionResponse struct {
-	Action   platform.Action  `json:"action"`
-	Resource resourceResponse `json:"resource"`
+	Action    platform.Action  `json:"action"`
+	Resource  resourceResponse `json:"resource"`
+	Predicate string           `json:"predicate,omitempty"`
 }
 
 type resourceResponse struct {
@@ -136,6 +147,7 @@ func newPermissionsResponse(ctx context.Context, ps []platform.Permission, svc p
 			Resource: resourceResponse{
 				Resource: p.Resource,
 			},
+			Predicate: p.Predicate,
 		}
 
 		if p.Resource.ID != nil {
@@ -225,6 +237,7 @@ type postAuthorizationRequest struct {
 	UserID      *platform.ID          `json:"userID,omitempty"`
 	Description string                `json:"description"`
 	Permissions []platform.Permission `json:"permissions"`
+	ExpiresAt   *time.Time            `json:"expiresAt,omitempty"`
 }
 
 func (p *postAuthorizationRequest) toPlatform(userID platform.ID) *platform.Authorization {
@@ -234,6 +247,7 @@ func (p *postAuthorizationRequest) toPlatform(userID platform.ID) *platform.Auth
 		Description: p.Description,
 		Permissions: p.Permissions,
 		UserID:      userID,
+		ExpiresAt:   p.ExpiresAt,
 	}
 }
 
@@ -243,6 +257,7 @@ func newPostAuthorizationRequest(a *platform.Authorization) (*postAuthorizationR
 		Description: a.Description,
 		Permissions: a.Permissions,
 		Status:      a.Status,
+		ExpiresAt:   a.ExpiresAt,
 	}
 
 	if a.UserID.Valid() {
@@ -274,6 +289,16 @@ func (p *postAuthorizationRequest) Validate() error {
 				Err: err,
 			}
 		}
+
+		if perm.Predicate != "" {
+			if _, err := reads.ParsePredicate(perm.Predicate); err != nil {
+				return &platform.Error{
+					Code: platform.EInvalid,
+					Msg:  fmt.Sprintf("invalid predicate of permission %s: %v", perm, err),
+					Err:  err,
+				}
+			}
+		}
 	}
 
 	if !p.OrgID.Valid() {
@@ -583,6 +608,97 @@ func decodeDeleteAuthorizationRequest(ctx context.Context, r *http.Request) (*de
 	}, nil
 }
 
+// handleRotateAuthorization is the HTTP handler for the POST /api/v2/authorizations/:id/rotate route.
+func (h *AuthorizationHandler) handleRotateAuthorization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeRotateAuthorizationRequest(ctx, r)
+	if err != nil {
+		h.Logger.Info("failed to decode request", zap.String("handler", "rotateAuthorization"), zap.Error(err))
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	a, err := h.AuthorizationService.RotateAuthorization(ctx, req.ID, req.Grace)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	o, err := h.OrganizationService.FindOrganizationByID(ctx, a.OrgID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	u, err := h.UserService.FindUserByID(ctx, a.UserID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	ps, err := newPermissionsResponse(ctx, a.Permissions, h.LookupService)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newAuthResponse(a, o, u, ps)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+type rotateAuthorizationRequest struct {
+	ID    platform.ID
+	Grace time.Duration
+}
+
+type rotateAuthorizationBody struct {
+	// GraceSeconds is the number of seconds during which the previous token
+	// of the authorization is still valid.
+	GraceSeconds int64 `json:"graceSeconds"`
+}
+
+func decodeRotateAuthorizationRequest(ctx context.Context, r *http.Request) (*rotateAuthorizationRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+
+	var i platform.ID
+	if err := i.DecodeFromString(id); err != nil {
+		return nil, err
+	}
+
+	b := &rotateAuthorizationBody{}
+	// The body is optional; rotating without one revokes the previous token
+	// immediately.
+	if err := json.NewDecoder(r.Body).Decode(b); err != nil && err != io.EOF {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid json structure",
+			Err:  err,
+		}
+	}
+
+	if b.GraceSeconds < 0 {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "grace period must not be negative",
+		}
+	}
+
+	return &rotateAuthorizationRequest{
+		ID:    i,
+		Grace: time.Duration(b.GraceSeconds) * time.Second,
+	}, nil
+}
+
 func getAuthorizedUser(r *http.Request, svc platform.UserService) (*platform.User, error) {
 	ctx := r.Context()
 
@@ -809,6 +925,49 @@ func (s *AuthorizationService) DeleteAuthorization(ctx context.Context, id platf
 	return CheckError(resp)
 }
 
+// RotateAuthorization issues a new token for the authorization. The previous
+// token is still valid for the grace period.
+func (s *AuthorizationService) RotateAuthorization(ctx context.Context, id platform.ID, grace time.Duration) (*platform.Authorization, error) {
+	u, err := newURL(s.Addr, path.Join(authorizationIDPath(id), "rotate"))
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(rotateAuthorizationBody{
+		GraceSeconds: int64(grace / time.Second),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var a authResponse
+	if err := json.NewDecoder(resp.Body).Decode(&a); err != nil {
+		return nil, err
+	}
+
+	return a.toPlatform(), nil
+}
+
 func authorizationIDPath(id platform.ID) string {
 	return path.Join(authorizationPath, id.String())
 }
@@ -520,6 +520,115 @@ func TestService_handlePostAuthorization(t *testing.T) {
   "user": "u1",
   "userID": "aaaaaaaaaaaaaaaa"
 }
+`,
+			},
+		},
+		{
+			name: "create an authorization restricted to some series",
+			fields: fields{
+				AuthorizationService: &mock.AuthorizationService{
+					CreateAuthorizationFn: func(ctx context.Context, c *platform.Authorization) error {
+						c.ID = platformtesting.MustIDBase16("020f755c3c082000")
+						c.Token = "new-test-token"
+						return nil
+					},
+				},
+				LookupService: &mock.LookupService{
+					NameFn: func(ctx context.Context, resource platform.ResourceType, id platform.ID) (string, error) {
+						switch resource {
+						case platform.BucketsResourceType:
+							return "b1", nil
+						case platform.OrgsResourceType:
+							return "o1", nil
+						}
+						return "", fmt.Errorf("bad resource type %s", resource)
+					},
+				},
+				UserService: &mock.UserService{
+					FindUserByIDFn: func(ctx context.Context, id platform.ID) (*platform.User, error) {
+						if !id.Valid() {
+							return nil, platform.ErrInvalidID
+						}
+						return &platform.User{
+							ID:   id,
+							Name: "u1",
+						}, nil
+					},
+				},
+				OrganizationService: &mock.OrganizationService{
+					FindOrganizationByIDF: func(ctx context.Context, id platform.ID) (*platform.Organization, error) {
+						if !id.Valid() {
+							return nil, platform.ErrInvalidID
+						}
+						return &platform.Organization{
+							ID:   id,
+							Name: "o1",
+						}, nil
+					},
+				},
+			},
+			args: args{
+				session: &platform.Authorization{
+					Token:       "session-token",
+					ID:          platformtesting.MustIDBase16("020f755c3c082000"),
+					UserID:      platformtesting.MustIDBase16("aaaaaaaaaaaaaaaa"),
+					OrgID:       platformtesting.MustIDBase16("020f755c3c083000"),
+					Description: "can write to authorization resource",
+					Permissions: []platform.Permission{
+						{
+							Action: platform.WriteAction,
+							Resource: platform.Resource{
+								Type:  platform.AuthorizationsResourceType,
+								OrgID: platformtesting.IDPtr(platformtesting.MustIDBase16("020f755c3c083000")),
+							},
+						},
+					},
+				},
+				authorization: &platform.Authorization{
+					ID:          platformtesting.MustIDBase16("020f755c3c082000"),
+					OrgID:       platformtesting.MustIDBase16("020f755c3c083000"),
+					Description: "only read public measurements",
+					Permissions: []platform.Permission{
+						{
+							Action: platform.ReadAction,
+							Resource: platform.Resource{
+								Type:  platform.BucketsResourceType,
+								OrgID: platformtesting.IDPtr(platformtesting.MustIDBase16("020f755c3c083000")),
+							},
+							Predicate: `_measurement=~/^public_/`,
+						},
+					},
+				},
+			},
+			wants: wants{
+				statusCode:  http.StatusCreated,
+				contentType: "application/json; charset=utf-8",
+				body: `
+{
+  "description": "only read public measurements",
+  "id": "020f755c3c082000",
+  "links": {
+    "self": "/api/v2/authorizations/020f755c3c082000",
+    "user": "/api/v2/users/aaaaaaaaaaaaaaaa"
+  },
+  "org": "o1",
+  "orgID": "020f755c3c083000",
+  "permissions": [
+    {
+      "action": "read",
+			"resource": {
+				"type": "buckets",
+				"orgID": "020f755c3c083000",
+				"org": "o1"
+			},
+			"predicate": "_measurement=~/^public_/"
+    }
+  ],
+  "status": "active",
+  "token": "new-test-token",
+  "user": "u1",
+  "userID": "aaaaaaaaaaaaaaaa"
+}
 `,
 			},
 		},
@@ -780,6 +889,10 @@ func TestAuthorizationService_UpdateAuthorizationStatus(t *testing.T) {
 	platformtesting.UpdateAuthorizationStatus(initAuthorizationService, t)
 }
 
+func TestAuthorizationService_RotateAuthorization(t *testing.T) {
+	platformtesting.RotateAuthorization(initAuthorizationService, t)
+}
+
 func MustMarshal(o interface{}) []byte {
 	b, _ := json.Marshal(o)
 	return b
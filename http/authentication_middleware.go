@@ -19,6 +19,10 @@ type AuthenticationHandler struct {
 	AuthorizationService platform.AuthorizationService
 	SessionService       platform.SessionService
 
+	// AuthorizationUseRecorder records the use of the authorizations of
+	// requests authenticated by token. The use is not recorded when it is nil.
+	AuthorizationUseRecorder platform.AuthorizationUseRecorder
+
 	// This is only really used for it's lookup method the specific http
 	// hanlder used to register routes does not matter.
 	noAuthRouter *httprouter.Router
@@ -48,7 +52,7 @@ const (
 
 // ProbeAuthScheme probes the http request for the requests for token or cookie session.
 func ProbeAuthScheme(r *http.Request) (string, error) {
-	_, tokenErr := GetToken(r)
+	_, tokenErr := getRequestToken(r)
 	_, sessErr := decodeCookieSession(r.Context(), r)
 
 	if tokenErr != nil && sessErr != nil {
@@ -98,8 +102,17 @@ func (h *AuthenticationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	UnauthorizedError(ctx, w)
 }
 
+// getRequestToken parses the token of the request, accepting the 1.x ways of
+// passing credentials on the 1.x compatible routes.
+func getRequestToken(r *http.Request) (string, error) {
+	if r.URL.Path == v1WritePath || r.URL.Path == v1QueryPath {
+		return GetV1Token(r)
+	}
+	return GetToken(r)
+}
+
 func (h *AuthenticationHandler) extractAuthorization(ctx context.Context, r *http.Request) (context.Context, error) {
-	t, err := GetToken(r)
+	t, err := getRequestToken(r)
 	if err != nil {
 		return ctx, err
 	}
@@ -109,6 +122,18 @@ func (h *AuthenticationHandler) extractAuthorization(ctx context.Context, r *htt
 		return ctx, err
 	}
 
+	now := time.Now()
+	if a.IsExpired(now) {
+		return ctx, &platform.Error{
+			Code: platform.EUnauthorized,
+			Msg:  "token has expired",
+		}
+	}
+
+	if h.AuthorizationUseRecorder != nil {
+		h.AuthorizationUseRecorder.RecordAuthorizationUse(a.ID, now)
+	}
+
 	return platcontext.SetAuthorizer(ctx, a), nil
 }
 
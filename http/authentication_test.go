@@ -86,6 +86,42 @@ func TestAuthenticationHandler(t *testing.T) {
 				code: http.StatusOK,
 			},
 		},
+		{
+			name: "token not expired",
+			fields: fields{
+				AuthorizationService: &mock.AuthorizationService{
+					FindAuthorizationByTokenFn: func(ctx context.Context, token string) (*platform.Authorization, error) {
+						expiresAt := time.Now().Add(time.Hour)
+						return &platform.Authorization{ExpiresAt: &expiresAt}, nil
+					},
+				},
+				SessionService: mock.NewSessionService(),
+			},
+			args: args{
+				token: "abc123",
+			},
+			wants: wants{
+				code: http.StatusOK,
+			},
+		},
+		{
+			name: "token expired",
+			fields: fields{
+				AuthorizationService: &mock.AuthorizationService{
+					FindAuthorizationByTokenFn: func(ctx context.Context, token string) (*platform.Authorization, error) {
+						expiresAt := time.Now().Add(-time.Hour)
+						return &platform.Authorization{ExpiresAt: &expiresAt}, nil
+					},
+				},
+				SessionService: mock.NewSessionService(),
+			},
+			args: args{
+				token: "abc123",
+			},
+			wants: wants{
+				code: http.StatusUnauthorized,
+			},
+		},
 		{
 			name: "token does not exist",
 			fields: fields{
@@ -147,6 +183,43 @@ func TestAuthenticationHandler(t *testing.T) {
 	}
 }
 
+type authorizationUseRecorder map[platform.ID]time.Time
+
+func (r authorizationUseRecorder) RecordAuthorizationUse(id platform.ID, t time.Time) {
+	r[id] = t
+}
+
+func TestAuthenticationHandler_RecordsAuthorizationUse(t *testing.T) {
+	id := platform.ID(1)
+	used := authorizationUseRecorder{}
+
+	h := platformhttp.NewAuthenticationHandler()
+	h.AuthorizationService = &mock.AuthorizationService{
+		FindAuthorizationByTokenFn: func(ctx context.Context, token string) (*platform.Authorization, error) {
+			return &platform.Authorization{ID: id}, nil
+		},
+	}
+	h.SessionService = mock.NewSessionService()
+	h.AuthorizationUseRecorder = used
+	h.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "http://any.url", nil)
+	platformhttp.SetToken("abc123", r)
+
+	before := time.Now()
+	h.ServeHTTP(w, r)
+
+	if got, want := w.Code, http.StatusOK; got != want {
+		t.Fatalf("expected status code to be %d got %d", want, got)
+	}
+	if last, ok := used[id]; !ok || last.Before(before) {
+		t.Errorf("expected the use of authorization %s to be recorded, got %v", id, used)
+	}
+}
+
 func TestProbeAuthScheme(t *testing.T) {
 	type args struct {
 		token   string
@@ -0,0 +1,313 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// BackupBackend is all services and associated parameters required to construct
+// the BackupHandler.
+type BackupBackend struct {
+	Logger *zap.Logger
+
+	BackupService   influxdb.BackupService
+	KVBackupService influxdb.KVBackupService
+}
+
+// NewBackupBackend returns a new instance of BackupBackend.
+func NewBackupBackend(b *APIBackend) *BackupBackend {
+	return &BackupBackend{
+		Logger: b.Logger.With(zap.String("handler", "backup")),
+
+		BackupService:   b.BackupService,
+		KVBackupService: b.KVBackupService,
+	}
+}
+
+// BackupHandler creates backups of the metadata and time series data of the
+// server, and serves their files.
+type BackupHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	BackupService   influxdb.BackupService
+	KVBackupService influxdb.KVBackupService
+}
+
+const (
+	backupPath       = "/api/v2/backup"
+	backupIDPath     = "/api/v2/backup/:backup_id"
+	backupIDFilePath = "/api/v2/backup/:backup_id/file/*backup_file"
+)
+
+// NewBackupHandler creates a new handler at /api/v2/backup to create, fetch
+// and remove backups.
+func NewBackupHandler(b *BackupBackend) *BackupHandler {
+	h := &BackupHandler{
+		Router: NewRouter(),
+		Logger: b.Logger,
+
+		BackupService:   b.BackupService,
+		KVBackupService: b.KVBackupService,
+	}
+
+	h.HandlerFunc("POST", backupPath, h.handleCreateBackup)
+	h.HandlerFunc("GET", backupIDFilePath, h.handleFetchBackupFile)
+	h.HandlerFunc("DELETE", backupIDPath, h.handleRemoveBackup)
+	return h
+}
+
+type backup struct {
+	ID    int      `json:"id"`
+	Files []string `json:"files"`
+}
+
+// handleCreateBackup is the HTTP handler for the POST /api/v2/backup route.
+func (h *BackupHandler) handleCreateBackup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if err := authorizeBackup(ctx); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	var since time.Time
+	if s := r.URL.Query().Get("since"); s != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339Nano, s); err != nil {
+			EncodeError(ctx, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Op:   "http/handleCreateBackup",
+				Msg:  "since must be in RFC3339 format",
+				Err:  err,
+			}, w)
+			return
+		}
+	}
+
+	id, files, err := h.BackupService.CreateBackup(ctx, since)
+	if err != nil {
+		h.Logger.Error("Error creating backup", zap.Error(err))
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	// The metadata is snapshotted into the backup too, so that it is
+	// consistent with the time series data.
+	if err := h.backupKV(ctx, id); err != nil {
+		h.Logger.Error("Error creating backup of metadata", zap.Error(err))
+		EncodeError(ctx, err, w)
+		return
+	}
+	files = append(files, influxdb.BackupBoltFile)
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, backup{ID: id, Files: files}); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func (h *BackupHandler) backupKV(ctx context.Context, id int) error {
+	f, err := os.Create(filepath.Join(h.BackupService.InternalBackupPath(id), influxdb.BackupBoltFile))
+	if err != nil {
+		return err
+	}
+
+	if err := h.KVBackupService.Backup(ctx, f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// handleFetchBackupFile is the HTTP handler for the GET /api/v2/backup/:backup_id/file/*backup_file route.
+func (h *BackupHandler) handleFetchBackupFile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if err := authorizeBackup(ctx); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	params := httprouter.ParamsFromContext(ctx)
+	id, err := decodeBackupID(params)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	// The catch-all parameter includes the leading slash.
+	file := params.ByName("backup_file")
+	if len(file) > 0 && file[0] == '/' {
+		file = file[1:]
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	if err := h.BackupService.FetchBackupFile(ctx, id, file, w); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+}
+
+// handleRemoveBackup is the HTTP handler for the DELETE /api/v2/backup/:backup_id route.
+func (h *BackupHandler) handleRemoveBackup(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if err := authorizeBackup(ctx); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	id, err := decodeBackupID(httprouter.ParamsFromContext(ctx))
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.BackupService.RemoveBackup(ctx, id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authorizeBackup ensures that the authorizer of the request may read every
+// resource of every organization, as a backup contains all of them.
+func authorizeBackup(ctx context.Context) error {
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range influxdb.OperPermissions() {
+		if p.Action == influxdb.ReadAction && !a.Allowed(p) {
+			return &influxdb.Error{
+				Code: influxdb.EForbidden,
+				Op:   "http/authorizeBackup",
+				Msg:  "insufficient permissions to backup",
+			}
+		}
+	}
+	return nil
+}
+
+func decodeBackupID(params httprouter.Params) (int, error) {
+	id, err := strconv.Atoi(params.ByName("backup_id"))
+	if err != nil {
+		return 0, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid backup id",
+			Err:  err,
+		}
+	}
+	return id, nil
+}
+
+// BackupService creates and fetches backups of influxdb over HTTP.
+type BackupService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+// CreateBackup creates a backup of the server, of the time series data
+// modified after since when since is not zero.
+func (s *BackupService) CreateBackup(ctx context.Context, since time.Time) (int, []string, error) {
+	u, err := newURL(s.Addr, backupPath)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if !since.IsZero() {
+		u.RawQuery = url.Values{"since": []string{since.Format(time.RFC3339Nano)}}.Encode()
+	}
+
+	req, err := http.NewRequest("POST", u.String(), nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return 0, nil, err
+	}
+
+	var b backup
+	if err := json.NewDecoder(resp.Body).Decode(&b); err != nil {
+		return 0, nil, err
+	}
+	return b.ID, b.Files, nil
+}
+
+// FetchBackupFile writes the contents of a file of a backup to w.
+func (s *BackupService) FetchBackupFile(ctx context.Context, backupID int, backupFile string, w io.Writer) error {
+	u, err := newURL(s.Addr, path.Join(backupPath, strconv.Itoa(backupID), "file", backupFile))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// RemoveBackup removes a backup and its files from the server.
+func (s *BackupService) RemoveBackup(ctx context.Context, backupID int) error {
+	u, err := newURL(s.Addr, path.Join(backupPath, strconv.Itoa(backupID)))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return CheckError(resp)
+}
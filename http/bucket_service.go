@@ -25,6 +25,7 @@ type BucketBackend struct {
 	LabelService               influxdb.LabelService
 	UserService                influxdb.UserService
 	OrganizationService        influxdb.OrganizationService
+	CardinalityService         influxdb.CardinalityService
 }
 
 // NewBucketBackend returns a new instance of BucketBackend.
@@ -38,6 +39,7 @@ func NewBucketBackend(b *APIBackend) *BucketBackend {
 		LabelService:               b.LabelService,
 		UserService:                b.UserService,
 		OrganizationService:        b.OrganizationService,
+		CardinalityService:         b.CardinalityService,
 	}
 }
 
@@ -53,18 +55,20 @@ type BucketHandler struct {
 	LabelService               influxdb.LabelService
 	UserService                influxdb.UserService
 	OrganizationService        influxdb.OrganizationService
+	CardinalityService         influxdb.CardinalityService
 }
 
 const (
-	bucketsPath            = "/api/v2/buckets"
-	bucketsIDPath          = "/api/v2/buckets/:id"
-	bucketsIDLogPath       = "/api/v2/buckets/:id/log"
-	bucketsIDMembersPath   = "/api/v2/buckets/:id/members"
-	bucketsIDMembersIDPath = "/api/v2/buckets/:id/members/:userID"
-	bucketsIDOwnersPath    = "/api/v2/buckets/:id/owners"
-	bucketsIDOwnersIDPath  = "/api/v2/buckets/:id/owners/:userID"
-	bucketsIDLabelsPath    = "/api/v2/buckets/:id/labels"
-	bucketsIDLabelsIDPath  = "/api/v2/buckets/:id/labels/:lid"
+	bucketsPath              = "/api/v2/buckets"
+	bucketsIDPath            = "/api/v2/buckets/:id"
+	bucketsIDLogPath         = "/api/v2/buckets/:id/log"
+	bucketsIDCardinalityPath = "/api/v2/buckets/:id/cardinality"
+	bucketsIDMembersPath     = "/api/v2/buckets/:id/members"
+	bucketsIDMembersIDPath   = "/api/v2/buckets/:id/members/:userID"
+	bucketsIDOwnersPath      = "/api/v2/buckets/:id/owners"
+	bucketsIDOwnersIDPath    = "/api/v2/buckets/:id/owners/:userID"
+	bucketsIDLabelsPath      = "/api/v2/buckets/:id/labels"
+	bucketsIDLabelsIDPath    = "/api/v2/buckets/:id/labels/:lid"
 )
 
 // NewBucketHandler returns a new instance of BucketHandler.
@@ -79,12 +83,14 @@ func NewBucketHandler(b *BucketBackend) *BucketHandler {
 		LabelService:               b.LabelService,
 		UserService:                b.UserService,
 		OrganizationService:        b.OrganizationService,
+		CardinalityService:         b.CardinalityService,
 	}
 
 	h.HandlerFunc("POST", bucketsPath, h.handlePostBucket)
 	h.HandlerFunc("GET", bucketsPath, h.handleGetBuckets)
 	h.HandlerFunc("GET", bucketsIDPath, h.handleGetBucket)
 	h.HandlerFunc("GET", bucketsIDLogPath, h.handleGetBucketLog)
+	h.HandlerFunc("GET", bucketsIDCardinalityPath, h.handleGetBucketCardinality)
 	h.HandlerFunc("PATCH", bucketsIDPath, h.handlePatchBucket)
 	h.HandlerFunc("DELETE", bucketsIDPath, h.handleDeleteBucket)
 
@@ -131,12 +137,107 @@ type bucket struct {
 	Name                string          `json:"name"`
 	RetentionPolicyName string          `json:"rp,omitempty"` // This to support v1 sources
 	RetentionRules      []retentionRule `json:"retentionRules"`
+	MaxSeries           int64           `json:"maxSeries,omitempty"`
+
+	SchemaType         influxdb.SchemaType          `json:"schemaType,omitempty"`
+	MeasurementSchemas []influxdb.MeasurementSchema `json:"measurementSchemas,omitempty"`
+
+	DownsamplingPolicies []downsamplingPolicy `json:"downsamplingPolicies,omitempty"`
+}
+
+// downsamplingPolicy is the downsampling policy of a bucket, with durations
+// in seconds.
+type downsamplingPolicy struct {
+	SourceBucketID              influxdb.ID                         `json:"sourceBucketID,omitempty"`
+	DestinationBucketID         influxdb.ID                         `json:"destinationBucketID"`
+	WindowSeconds               int64                               `json:"windowSeconds"`
+	Aggregates                  map[influxdb.SchemaFieldType]string `json:"aggregates"`
+	DestinationRetentionSeconds int64                               `json:"destinationRetentionSeconds,omitempty"`
+	TaskID                      influxdb.ID                         `json:"taskID,omitempty"`
+}
+
+func (p downsamplingPolicy) toInfluxDB() influxdb.DownsamplingPolicy {
+	return influxdb.DownsamplingPolicy{
+		DestinationBucketID:  p.DestinationBucketID,
+		Window:               time.Duration(p.WindowSeconds) * time.Second,
+		Aggregates:           p.Aggregates,
+		DestinationRetention: time.Duration(p.DestinationRetentionSeconds) * time.Second,
+		TaskID:               p.TaskID,
+	}
+}
+
+func toInfluxDBDownsamplingPolicies(ps []downsamplingPolicy) []influxdb.DownsamplingPolicy {
+	if ps == nil {
+		return nil
+	}
+	policies := make([]influxdb.DownsamplingPolicy, 0, len(ps))
+	for _, p := range ps {
+		policies = append(policies, p.toInfluxDB())
+	}
+	return policies
+}
+
+func newDownsamplingPolicies(sourceID influxdb.ID, ps []influxdb.DownsamplingPolicy) []downsamplingPolicy {
+	if ps == nil {
+		return nil
+	}
+	policies := make([]downsamplingPolicy, 0, len(ps))
+	for _, p := range ps {
+		policies = append(policies, downsamplingPolicy{
+			SourceBucketID:              sourceID,
+			DestinationBucketID:         p.DestinationBucketID,
+			WindowSeconds:               int64(p.Window.Round(time.Second) / time.Second),
+			Aggregates:                  p.Aggregates,
+			DestinationRetentionSeconds: int64(p.DestinationRetention.Round(time.Second) / time.Second),
+			TaskID:                      p.TaskID,
+		})
+	}
+	return policies
 }
 
 // retentionRule is the retention rule action for a bucket.
 type retentionRule struct {
-	Type         string `json:"type"`
-	EverySeconds int64  `json:"everySeconds"`
+	Type                      string `json:"type"`
+	EverySeconds              int64  `json:"everySeconds"`
+	ShardGroupDurationSeconds int64  `json:"shardGroupDurationSeconds,omitempty"`
+}
+
+// retentionRuleDurations returns the retention period and shard group
+// duration of the first of the retention rules.
+func retentionRuleDurations(rules []retentionRule) (rp, sgd time.Duration, err error) {
+	// Only support a single retention period for the moment
+	if len(rules) == 0 {
+		return 0, 0, nil
+	}
+
+	rp = time.Duration(rules[0].EverySeconds) * time.Second
+	if rp < time.Second {
+		return 0, 0, &influxdb.Error{
+			Code: influxdb.EUnprocessableEntity,
+			Msg:  "expiration seconds must be greater than or equal to one second",
+		}
+	}
+
+	sgd = time.Duration(rules[0].ShardGroupDurationSeconds) * time.Second
+	if sgd < 0 {
+		return 0, 0, &influxdb.Error{
+			Code: influxdb.EUnprocessableEntity,
+			Msg:  "shard group duration seconds must not be negative",
+		}
+	}
+	return rp, sgd, nil
+}
+
+// validateMaxSeries returns an error if the maximum number of series of a
+// bucket is negative.
+func validateMaxSeries(n int64) error {
+	if n < 0 {
+		return &influxdb.Error{
+			Code: influxdb.EUnprocessableEntity,
+			Msg:  "max series must not be negative",
+		}
+	}
+	return nil
 }
 
 func (b *bucket) toInfluxDB() (*influxdb.Bucket, error) {
@@ -144,17 +245,13 @@ func (b *bucket) toInfluxDB() (*influxdb.Bucket, error) {
 		return nil, nil
 	}
 
-	var d time.Duration // zero value implies infinite retention policy
-
-	// Only support a single retention period for the moment
-	if len(b.RetentionRules) > 0 {
-		d = time.Duration(b.RetentionRules[0].EverySeconds) * time.Second
-		if d < time.Second {
-			return nil, &influxdb.Error{
-				Code: influxdb.EUnprocessableEntity,
-				Msg:  "expiration seconds must be greater than or equal to one second",
-			}
-		}
+	// zero value implies infinite retention policy
+	d, sgd, err := retentionRuleDurations(b.RetentionRules)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMaxSeries(b.MaxSeries); err != nil {
+		return nil, err
 	}
 
 	return &influxdb.Bucket{
@@ -164,6 +261,12 @@ func (b *bucket) toInfluxDB() (*influxdb.Bucket, error) {
 		Name:                b.Name,
 		RetentionPolicyName: b.RetentionPolicyName,
 		RetentionPeriod:     d,
+		ShardGroupDuration:  sgd,
+		MaxSeries:           b.MaxSeries,
+		SchemaType:          b.SchemaType,
+		MeasurementSchemas:  b.MeasurementSchemas,
+
+		DownsamplingPolicies: toInfluxDBDownsamplingPolicies(b.DownsamplingPolicies),
 	}, nil
 }
 
@@ -176,8 +279,9 @@ func newBucket(pb *influxdb.Bucket) *bucket {
 	rp := int64(pb.RetentionPeriod.Round(time.Second) / time.Second)
 	if rp > 0 {
 		rules = append(rules, retentionRule{
-			Type:         "expire",
-			EverySeconds: rp,
+			Type:                      "expire",
+			EverySeconds:              rp,
+			ShardGroupDurationSeconds: int64(pb.ShardGroupDuration.Round(time.Second) / time.Second),
 		})
 	}
 
@@ -188,6 +292,11 @@ func newBucket(pb *influxdb.Bucket) *bucket {
 		Name:                pb.Name,
 		RetentionPolicyName: pb.RetentionPolicyName,
 		RetentionRules:      rules,
+		MaxSeries:           pb.MaxSeries,
+		SchemaType:          pb.SchemaType,
+		MeasurementSchemas:  pb.MeasurementSchemas,
+
+		DownsamplingPolicies: newDownsamplingPolicies(pb.ID, pb.DownsamplingPolicies),
 	}
 }
 
@@ -195,6 +304,12 @@ func newBucket(pb *influxdb.Bucket) *bucket {
 type bucketUpdate struct {
 	Name           *string         `json:"name,omitempty"`
 	RetentionRules []retentionRule `json:"retentionRules,omitempty"`
+	MaxSeries      *int64          `json:"maxSeries,omitempty"`
+
+	SchemaType         *influxdb.SchemaType          `json:"schemaType,omitempty"`
+	MeasurementSchemas *[]influxdb.MeasurementSchema `json:"measurementSchemas,omitempty"`
+
+	DownsamplingPolicies *[]downsamplingPolicy `json:"downsamplingPolicies,omitempty"`
 }
 
 func (b *bucketUpdate) toInfluxDB() (*influxdb.BucketUpdate, error) {
@@ -203,21 +318,29 @@ func (b *bucketUpdate) toInfluxDB() (*influxdb.BucketUpdate, error) {
 	}
 
 	// For now, only use a single retention rule.
-	var d time.Duration
-	if len(b.RetentionRules) > 0 {
-		d = time.Duration(b.RetentionRules[0].EverySeconds) * time.Second
-		if d < time.Second {
-			return nil, &influxdb.Error{
-				Code: influxdb.EUnprocessableEntity,
-				Msg:  "expiration seconds must be greater than or equal to one second",
-			}
+	d, sgd, err := retentionRuleDurations(b.RetentionRules)
+	if err != nil {
+		return nil, err
+	}
+	if b.MaxSeries != nil {
+		if err := validateMaxSeries(*b.MaxSeries); err != nil {
+			return nil, err
 		}
 	}
 
-	return &influxdb.BucketUpdate{
-		Name:            b.Name,
-		RetentionPeriod: &d,
-	}, nil
+	upd := &influxdb.BucketUpdate{
+		Name:               b.Name,
+		RetentionPeriod:    &d,
+		ShardGroupDuration: &sgd,
+		MaxSeries:          b.MaxSeries,
+		SchemaType:         b.SchemaType,
+		MeasurementSchemas: b.MeasurementSchemas,
+	}
+	if b.DownsamplingPolicies != nil {
+		policies := toInfluxDBDownsamplingPolicies(*b.DownsamplingPolicies)
+		upd.DownsamplingPolicies = &policies
+	}
+	return upd, nil
 }
 
 func newBucketUpdate(pb *influxdb.BucketUpdate) *bucketUpdate {
@@ -226,16 +349,31 @@ func newBucketUpdate(pb *influxdb.BucketUpdate) *bucketUpdate {
 	}
 
 	up := &bucketUpdate{
-		Name:           pb.Name,
-		RetentionRules: []retentionRule{},
+		Name:               pb.Name,
+		RetentionRules:     []retentionRule{},
+		MaxSeries:          pb.MaxSeries,
+		SchemaType:         pb.SchemaType,
+		MeasurementSchemas: pb.MeasurementSchemas,
+	}
+	if pb.DownsamplingPolicies != nil {
+		// An empty list of policies deletes the policies of the bucket.
+		policies := newDownsamplingPolicies(0, *pb.DownsamplingPolicies)
+		if policies == nil {
+			policies = []downsamplingPolicy{}
+		}
+		up.DownsamplingPolicies = &policies
 	}
 
 	if pb.RetentionPeriod != nil {
 		d := int64((*pb.RetentionPeriod).Round(time.Second) / time.Second)
-		up.RetentionRules = append(up.RetentionRules, retentionRule{
+		rule := retentionRule{
 			Type:         "expire",
 			EverySeconds: d,
-		})
+		}
+		if pb.ShardGroupDuration != nil {
+			rule.ShardGroupDurationSeconds = int64((*pb.ShardGroupDuration).Round(time.Second) / time.Second)
+		}
+		up.RetentionRules = append(up.RetentionRules, rule)
 	}
 	return up
 }
@@ -370,6 +508,57 @@ func (h *BucketHandler) handleGetBucket(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+type bucketCardinalityResponse struct {
+	Links map[string]string `json:"links"`
+	influxdb.BucketCardinality
+}
+
+func newBucketCardinalityResponse(c *influxdb.BucketCardinality) *bucketCardinalityResponse {
+	return &bucketCardinalityResponse{
+		Links: map[string]string{
+			"self":   fmt.Sprintf("/api/v2/buckets/%s/cardinality", c.BucketID),
+			"bucket": fmt.Sprintf("/api/v2/buckets/%s", c.BucketID),
+		},
+		BucketCardinality: *c,
+	}
+}
+
+// handleGetBucketCardinality is the HTTP handler for the GET /api/v2/buckets/:id/cardinality route.
+func (h *BucketHandler) handleGetBucketCardinality(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if h.CardinalityService == nil {
+		EncodeError(ctx, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  "cardinality is not reported",
+		}, w)
+		return
+	}
+
+	req, err := decodeGetBucketRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	b, err := h.BucketService.FindBucketByID(ctx, req.BucketID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	c, err := h.CardinalityService.BucketCardinality(ctx, b.OrganizationID, b.ID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newBucketCardinalityResponse(c)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
 type getBucketRequest struct {
 	BucketID influxdb.ID
 }
@@ -339,6 +339,116 @@ func TestService_handleGetBucket(t *testing.T) {
 	}
 }
 
+func TestService_handleGetBucketCardinality(t *testing.T) {
+	type fields struct {
+		BucketService      platform.BucketService
+		CardinalityService platform.CardinalityService
+	}
+	type wants struct {
+		statusCode int
+		body       string
+	}
+
+	bucketService := &mock.BucketService{
+		FindBucketByIDFn: func(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+			return &platform.Bucket{
+				ID:             id,
+				OrganizationID: platformtesting.MustIDBase16("020f755c3c082001"),
+				Name:           "hello",
+			}, nil
+		},
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		wants  wants
+	}{
+		{
+			name: "get the cardinality of a bucket",
+			fields: fields{
+				BucketService: bucketService,
+				CardinalityService: &mock.CardinalityService{
+					BucketCardinalityFn: func(ctx context.Context, orgID, bucketID platform.ID) (*platform.BucketCardinality, error) {
+						return &platform.BucketCardinality{
+							OrganizationID: orgID,
+							BucketID:       bucketID,
+							Series:         12,
+							MaxSeries:      100,
+							Measurements: []platform.MeasurementCardinality{
+								{Name: "cpu", Series: 10},
+								{Name: "mem", Series: 2},
+							},
+						}, nil
+					},
+				},
+			},
+			wants: wants{
+				statusCode: http.StatusOK,
+				body: `
+		{
+		  "links": {
+		    "self": "/api/v2/buckets/020f755c3c082000/cardinality",
+		    "bucket": "/api/v2/buckets/020f755c3c082000"
+		  },
+		  "orgID": "020f755c3c082001",
+		  "bucketID": "020f755c3c082000",
+		  "series": 12,
+		  "maxSeries": 100,
+		  "measurements": [
+		    {"name": "cpu", "series": 10},
+		    {"name": "mem", "series": 2}
+		  ]
+		}
+		`,
+			},
+		},
+		{
+			name: "cardinality is not reported",
+			fields: fields{
+				BucketService: bucketService,
+			},
+			wants: wants{
+				statusCode: http.StatusNotFound,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bucketBackend := NewMockBucketBackend()
+			bucketBackend.BucketService = tt.fields.BucketService
+			bucketBackend.CardinalityService = tt.fields.CardinalityService
+			h := NewBucketHandler(bucketBackend)
+
+			r := httptest.NewRequest("GET", "http://any.url", nil)
+			r = r.WithContext(context.WithValue(
+				context.Background(),
+				httprouter.ParamsKey,
+				httprouter.Params{
+					{
+						Key:   "id",
+						Value: "020f755c3c082000",
+					},
+				}))
+
+			w := httptest.NewRecorder()
+
+			h.handleGetBucketCardinality(w, r)
+
+			res := w.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+
+			if res.StatusCode != tt.wants.statusCode {
+				t.Errorf("%q. handleGetBucketCardinality() = %v, want %v", tt.name, res.StatusCode, tt.wants.statusCode)
+			}
+			if eq, diff, _ := jsonEqual(string(body), tt.wants.body); tt.wants.body != "" && !eq {
+				t.Errorf("%q. handleGetBucketCardinality() = ***%s***", tt.name, diff)
+			}
+		})
+	}
+}
+
 func TestService_handlePostBucket(t *testing.T) {
 	type fields struct {
 		BucketService       platform.BucketService
@@ -0,0 +1,358 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/influxdata/influxdb"
+	pctx "github.com/influxdata/influxdb/context"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+const (
+	checksPath = "/api/v2/checks"
+)
+
+// CheckBackend is all services and associated parameters required to construct
+// the CheckHandler.
+type CheckBackend struct {
+	Logger       *zap.Logger
+	CheckService influxdb.CheckService
+}
+
+// NewCheckBackend returns a new instance of CheckBackend.
+func NewCheckBackend(b *APIBackend) *CheckBackend {
+	return &CheckBackend{
+		Logger:       b.Logger.With(zap.String("handler", "check")),
+		CheckService: b.CheckService,
+	}
+}
+
+// CheckHandler is the handler for the check service
+type CheckHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	CheckService influxdb.CheckService
+}
+
+// NewCheckHandler creates a new CheckHandler
+func NewCheckHandler(b *CheckBackend) *CheckHandler {
+	h := &CheckHandler{
+		Router: NewRouter(),
+		Logger: b.Logger,
+
+		CheckService: b.CheckService,
+	}
+
+	entityPath := fmt.Sprintf("%s/:id", checksPath)
+
+	h.HandlerFunc("GET", checksPath, h.handleGetChecks)
+	h.HandlerFunc("POST", checksPath, h.handlePostCheck)
+	h.HandlerFunc("GET", entityPath, h.handleGetCheck)
+	h.HandlerFunc("PUT", entityPath, h.handlePutCheck)
+	h.HandlerFunc("DELETE", entityPath, h.handleDeleteCheck)
+
+	return h
+}
+
+type checkLinks struct {
+	Self string `json:"self"`
+	Org  string `json:"org"`
+}
+
+type checkResponse struct {
+	*influxdb.Check
+	Links checkLinks `json:"links"`
+}
+
+func newCheckResponse(c *influxdb.Check) checkResponse {
+	return checkResponse{
+		Check: c,
+		Links: checkLinks{
+			Self: checkIDPath(c.ID),
+			Org:  fmt.Sprintf("/api/v2/orgs/%s", c.OrganizationID),
+		},
+	}
+}
+
+type getChecksResponse struct {
+	Checks []checkResponse       `json:"checks"`
+	Links  *influxdb.PagingLinks `json:"links"`
+}
+
+func (r getChecksResponse) toInfluxDB() []*influxdb.Check {
+	cs := make([]*influxdb.Check, len(r.Checks))
+	for i := range r.Checks {
+		cs[i] = r.Checks[i].Check
+	}
+	return cs
+}
+
+func newGetChecksResponse(cs []*influxdb.Check, f influxdb.CheckFilter, opts influxdb.FindOptions) getChecksResponse {
+	resp := getChecksResponse{
+		Checks: make([]checkResponse, 0, len(cs)),
+		Links:  newPagingLinks(checksPath, opts, f, len(cs)),
+	}
+
+	for _, c := range cs {
+		resp.Checks = append(resp.Checks, newCheckResponse(c))
+	}
+
+	return resp
+}
+
+type getChecksRequest struct {
+	filter influxdb.CheckFilter
+	opts   influxdb.FindOptions
+}
+
+func decodeGetChecksRequest(ctx context.Context, r *http.Request) (*getChecksRequest, error) {
+	qp := r.URL.Query()
+	req := &getChecksRequest{}
+
+	opts, err := decodeFindOptions(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	req.opts = *opts
+
+	if orgID := qp.Get("orgID"); orgID != "" {
+		id, err := influxdb.IDFromString(orgID)
+		if err != nil {
+			return nil, err
+		}
+		req.filter.OrganizationID = id
+	}
+
+	if name := qp.Get("name"); name != "" {
+		req.filter.Name = &name
+	}
+
+	return req, nil
+}
+
+func (h *CheckHandler) handleGetChecks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetChecksRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	cs, _, err := h.CheckService.FindChecks(ctx, req.filter, req.opts)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newGetChecksResponse(cs, req.filter, req.opts)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func requestCheckID(ctx context.Context) (influxdb.ID, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	urlID := params.ByName("id")
+	if urlID == "" {
+		return influxdb.InvalidID(), &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+
+	id, err := influxdb.IDFromString(urlID)
+	if err != nil {
+		return influxdb.InvalidID(), &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+
+	return *id, nil
+}
+
+func (h *CheckHandler) handleGetCheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestCheckID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	c, err := h.CheckService.FindCheckByID(ctx, id)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newCheckResponse(c)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func decodeCheck(r *http.Request) (*influxdb.Check, error) {
+	c := &influxdb.Check{}
+	if err := json.NewDecoder(r.Body).Decode(c); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+
+	if err := c.Valid(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (h *CheckHandler) handlePostCheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	c, err := decodeCheck(r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	// Checks query with the permissions of the token that created them.
+	a, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	auth, ok := a.(*influxdb.Authorization)
+	if !ok {
+		EncodeError(ctx, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "checks must be created with a token",
+		}, w)
+		return
+	}
+	c.AuthorizationID = auth.ID
+
+	if err := h.CheckService.CreateCheck(ctx, c); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newCheckResponse(c)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func (h *CheckHandler) handlePutCheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestCheckID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	upd, err := decodeCheck(r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	c, err := h.CheckService.UpdateCheck(ctx, id, upd)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newCheckResponse(c)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func (h *CheckHandler) handleDeleteCheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestCheckID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.CheckService.DeleteCheck(ctx, id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CheckService is a check service over HTTP to the influxdb server.
+type CheckService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+var _ influxdb.CheckService = (*CheckService)(nil)
+
+// FindCheckByID returns a single check by ID.
+func (s *CheckService) FindCheckByID(ctx context.Context, id influxdb.ID) (*influxdb.Check, error) {
+	var cr checkResponse
+	if err := s.do(ctx, "GET", checkIDPath(id), nil, nil, &cr); err != nil {
+		return nil, err
+	}
+	return cr.Check, nil
+}
+
+// FindChecks returns a list of checks that match filter.
+func (s *CheckService) FindChecks(ctx context.Context, filter influxdb.CheckFilter, opts ...influxdb.FindOptions) ([]*influxdb.Check, int, error) {
+	var resp getChecksResponse
+	if err := s.do(ctx, "GET", checksPath, filter.QueryParams(), nil, &resp); err != nil {
+		return nil, 0, err
+	}
+
+	cs := resp.toInfluxDB()
+	return cs, len(cs), nil
+}
+
+// CreateCheck creates a new check and sets c.ID with the new identifier.
+func (s *CheckService) CreateCheck(ctx context.Context, c *influxdb.Check) error {
+	var cr checkResponse
+	if err := s.do(ctx, "POST", checksPath, nil, c, &cr); err != nil {
+		return err
+	}
+	*c = *cr.Check
+	return nil
+}
+
+// UpdateCheck replaces a single check.
+func (s *CheckService) UpdateCheck(ctx context.Context, id influxdb.ID, upd *influxdb.Check) (*influxdb.Check, error) {
+	var cr checkResponse
+	if err := s.do(ctx, "PUT", checkIDPath(id), nil, upd, &cr); err != nil {
+		return nil, err
+	}
+	return cr.Check, nil
+}
+
+// DeleteCheck removes a check by ID.
+func (s *CheckService) DeleteCheck(ctx context.Context, id influxdb.ID) error {
+	return s.do(ctx, "DELETE", checkIDPath(id), nil, nil, nil)
+}
+
+func (s *CheckService) do(ctx context.Context, method, p string, params map[string][]string, body, v interface{}) error {
+	return doJSON(ctx, s.Addr, s.Token, s.InsecureSkipVerify, method, p, params, body, v)
+}
+
+func checkIDPath(id influxdb.ID) string {
+	return path.Join(checksPath, id.String())
+}
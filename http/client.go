@@ -1,7 +1,10 @@
 package http
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
+	"encoding/json"
 	"net/http"
 	"net/url"
 )
@@ -98,3 +101,53 @@ func (c *traceClient) Do(r *http.Request) (*http.Response, error) {
 	InjectTrace(r)
 	return c.Client.Do(r)
 }
+
+// doJSON sends a request with the JSON encoding of body, if any, to the
+// path p of the server at addr, and decodes the response into v, if any.
+func doJSON(ctx context.Context, addr, token string, insecureSkipVerify bool, method, p string, params map[string][]string, body, v interface{}) error {
+	u, err := newURL(addr, p)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, u.String(), &buf)
+	if err != nil {
+		return err
+	}
+	if params != nil {
+		qp := req.URL.Query()
+		for k, vs := range params {
+			for _, v := range vs {
+				qp.Add(k, v)
+			}
+		}
+		req.URL.RawQuery = qp.Encode()
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	SetToken(token, req)
+
+	hc := newClient(u.Scheme, insecureSkipVerify)
+	resp, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return err
+	}
+
+	if v == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,474 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// DBRPMappingBackend is all services and associated parameters required to construct
+// the DBRPMappingHandler.
+type DBRPMappingBackend struct {
+	Logger *zap.Logger
+
+	DBRPMappingService platform.DBRPMappingService
+	BucketService      platform.BucketService
+}
+
+// NewDBRPMappingBackend returns a new instance of DBRPMappingBackend.
+func NewDBRPMappingBackend(b *APIBackend) *DBRPMappingBackend {
+	return &DBRPMappingBackend{
+		Logger: b.Logger.With(zap.String("handler", "dbrp")),
+
+		DBRPMappingService: b.DBRPMappingService,
+		BucketService:      b.BucketService,
+	}
+}
+
+// DBRPMappingHandler represents an HTTP API handler for the mappings of the
+// databases and retention policies of the 1.x compatible API to buckets.
+// Mappings are always of the default cluster.
+type DBRPMappingHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	DBRPMappingService platform.DBRPMappingService
+	BucketService      platform.BucketService
+}
+
+const (
+	dbrpsPath     = "/api/v2/dbrps"
+	dbrpsDBRPPath = "/api/v2/dbrps/:db/:rp"
+)
+
+// NewDBRPMappingHandler returns a new instance of DBRPMappingHandler.
+func NewDBRPMappingHandler(b *DBRPMappingBackend) *DBRPMappingHandler {
+	h := &DBRPMappingHandler{
+		Router: NewRouter(),
+		Logger: b.Logger,
+
+		DBRPMappingService: b.DBRPMappingService,
+		BucketService:      b.BucketService,
+	}
+
+	h.HandlerFunc("GET", dbrpsPath, h.handleGetDBRPMappings)
+	h.HandlerFunc("POST", dbrpsPath, h.handlePostDBRPMapping)
+	h.HandlerFunc("GET", dbrpsDBRPPath, h.handleGetDBRPMapping)
+	h.HandlerFunc("DELETE", dbrpsDBRPPath, h.handleDeleteDBRPMapping)
+	return h
+}
+
+type dbrpMappingRequest struct {
+	Database        string      `json:"database"`
+	RetentionPolicy string      `json:"retentionPolicy"`
+	Default         bool        `json:"default"`
+	BucketID        platform.ID `json:"bucketID"`
+}
+
+type dbrpMappingResponse struct {
+	Links           map[string]string `json:"links"`
+	Database        string            `json:"database"`
+	RetentionPolicy string            `json:"retentionPolicy"`
+	Default         bool              `json:"default"`
+	OrganizationID  platform.ID       `json:"orgID"`
+	BucketID        platform.ID       `json:"bucketID"`
+}
+
+func newDBRPMappingResponse(m *platform.DBRPMapping) *dbrpMappingResponse {
+	return &dbrpMappingResponse{
+		Links: map[string]string{
+			"self":   dbrpPath(m.Database, m.RetentionPolicy),
+			"bucket": fmt.Sprintf("/api/v2/buckets/%s", m.BucketID),
+			"org":    fmt.Sprintf("/api/v2/orgs/%s", m.OrganizationID),
+		},
+		Database:        m.Database,
+		RetentionPolicy: m.RetentionPolicy,
+		Default:         m.Default,
+		OrganizationID:  m.OrganizationID,
+		BucketID:        m.BucketID,
+	}
+}
+
+func (r *dbrpMappingResponse) toPlatform() *platform.DBRPMapping {
+	return &platform.DBRPMapping{
+		Cluster:         platform.DefaultDBRPCluster,
+		Database:        r.Database,
+		RetentionPolicy: r.RetentionPolicy,
+		Default:         r.Default,
+		OrganizationID:  r.OrganizationID,
+		BucketID:        r.BucketID,
+	}
+}
+
+type dbrpMappingsResponse struct {
+	Links        map[string]string      `json:"links"`
+	DBRPMappings []*dbrpMappingResponse `json:"dbrps"`
+}
+
+func newDBRPMappingsResponse(ms []*platform.DBRPMapping) *dbrpMappingsResponse {
+	res := &dbrpMappingsResponse{
+		Links: map[string]string{
+			"self": dbrpsPath,
+		},
+		DBRPMappings: make([]*dbrpMappingResponse, 0, len(ms)),
+	}
+	for _, m := range ms {
+		res.DBRPMappings = append(res.DBRPMappings, newDBRPMappingResponse(m))
+	}
+	return res
+}
+
+// handlePostDBRPMapping is the HTTP handler for the POST /api/v2/dbrps route.
+func (h *DBRPMappingHandler) handlePostDBRPMapping(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePostDBRPMappingRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	// The mapping belongs to the organization of its bucket.
+	b, err := h.BucketService.FindBucketByID(ctx, req.BucketID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	m := &platform.DBRPMapping{
+		Cluster:         platform.DefaultDBRPCluster,
+		Database:        req.Database,
+		RetentionPolicy: req.RetentionPolicy,
+		Default:         req.Default,
+		OrganizationID:  b.OrganizationID,
+		BucketID:        b.ID,
+	}
+
+	if err := h.DBRPMappingService.Create(ctx, m); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+	h.Logger.Debug("dbrp mapping created", zap.String("db", m.Database), zap.String("rp", m.RetentionPolicy))
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newDBRPMappingResponse(m)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func decodePostDBRPMappingRequest(ctx context.Context, r *http.Request) (*dbrpMappingRequest, error) {
+	req := &dbrpMappingRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "failed to decode request",
+			Err:  err,
+		}
+	}
+
+	if !req.BucketID.Valid() {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "bucketID is required",
+		}
+	}
+
+	return req, nil
+}
+
+// handleGetDBRPMappings is the HTTP handler for the GET /api/v2/dbrps route.
+func (h *DBRPMappingHandler) handleGetDBRPMappings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter, err := decodeGetDBRPMappingsRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	ms, _, err := h.DBRPMappingService.FindMany(ctx, filter)
+	if err != nil {
+		// A lookup of a single mapping that does not exist finds nothing.
+		if platform.ErrorCode(err) != platform.ENotFound {
+			EncodeError(ctx, err, w)
+			return
+		}
+		ms = nil
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newDBRPMappingsResponse(ms)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func decodeGetDBRPMappingsRequest(ctx context.Context, r *http.Request) (platform.DBRPMappingFilter, error) {
+	cluster := platform.DefaultDBRPCluster
+	filter := platform.DBRPMappingFilter{
+		Cluster: &cluster,
+	}
+
+	qp := r.URL.Query()
+	if db := qp.Get("db"); db != "" {
+		filter.Database = &db
+	}
+	if rp := qp.Get("rp"); rp != "" {
+		filter.RetentionPolicy = &rp
+	}
+	if d := qp.Get("default"); d != "" {
+		isDefault, err := strconv.ParseBool(d)
+		if err != nil {
+			return filter, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  "default must be a boolean",
+				Err:  err,
+			}
+		}
+		filter.Default = &isDefault
+	}
+
+	return filter, nil
+}
+
+// handleGetDBRPMapping is the HTTP handler for the GET /api/v2/dbrps/:db/:rp route.
+func (h *DBRPMappingHandler) handleGetDBRPMapping(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := httprouter.ParamsFromContext(ctx)
+	m, err := h.DBRPMappingService.FindBy(ctx, platform.DefaultDBRPCluster, params.ByName("db"), params.ByName("rp"))
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newDBRPMappingResponse(m)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// handleDeleteDBRPMapping is the HTTP handler for the DELETE /api/v2/dbrps/:db/:rp route.
+func (h *DBRPMappingHandler) handleDeleteDBRPMapping(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	params := httprouter.ParamsFromContext(ctx)
+	if err := h.DBRPMappingService.Delete(ctx, platform.DefaultDBRPCluster, params.ByName("db"), params.ByName("rp")); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func dbrpPath(db, rp string) string {
+	return path.Join(dbrpsPath, db, rp)
+}
+
+// DBRPMappingService connects to Influx via HTTP using tokens to manage dbrp mappings.
+// Only the mappings of the default cluster can be managed.
+type DBRPMappingService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+var _ platform.DBRPMappingService = (*DBRPMappingService)(nil)
+
+func checkDBRPCluster(cluster string) error {
+	if cluster != platform.DefaultDBRPCluster {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  fmt.Sprintf("only the mappings of cluster %q can be managed", platform.DefaultDBRPCluster),
+		}
+	}
+	return nil
+}
+
+// FindBy returns the dbrp mapping of the database and retention policy.
+func (s *DBRPMappingService) FindBy(ctx context.Context, cluster, db, rp string) (*platform.DBRPMapping, error) {
+	if err := checkDBRPCluster(cluster); err != nil {
+		return nil, err
+	}
+
+	u, err := newURL(s.Addr, dbrpPath(db, rp))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var m dbrpMappingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return nil, err
+	}
+
+	return m.toPlatform(), nil
+}
+
+// Find returns the first dbrp mapping that matches filter.
+func (s *DBRPMappingService) Find(ctx context.Context, filter platform.DBRPMappingFilter) (*platform.DBRPMapping, error) {
+	ms, n, err := s.FindMany(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if n == 0 {
+		return nil, platform.ErrDBRPMappingNotFound
+	}
+
+	return ms[0], nil
+}
+
+// FindMany returns the dbrp mappings that match filter and their total count.
+func (s *DBRPMappingService) FindMany(ctx context.Context, filter platform.DBRPMappingFilter, opt ...platform.FindOptions) ([]*platform.DBRPMapping, int, error) {
+	if filter.Cluster != nil {
+		if err := checkDBRPCluster(*filter.Cluster); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	u, err := newURL(s.Addr, dbrpsPath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	qp := u.Query()
+	if filter.Database != nil {
+		qp.Add("db", *filter.Database)
+	}
+	if filter.RetentionPolicy != nil {
+		qp.Add("rp", *filter.RetentionPolicy)
+	}
+	if filter.Default != nil {
+		qp.Add("default", strconv.FormatBool(*filter.Default))
+	}
+	u.RawQuery = qp.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, 0, err
+	}
+
+	var res dbrpMappingsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, 0, err
+	}
+
+	ms := make([]*platform.DBRPMapping, 0, len(res.DBRPMappings))
+	for _, m := range res.DBRPMappings {
+		ms = append(ms, m.toPlatform())
+	}
+
+	return ms, len(ms), nil
+}
+
+// Create creates a dbrp mapping of the bucket of m, and sets the organization of m to the one of the bucket.
+func (s *DBRPMappingService) Create(ctx context.Context, m *platform.DBRPMapping) error {
+	if err := checkDBRPCluster(m.Cluster); err != nil {
+		return err
+	}
+
+	u, err := newURL(s.Addr, dbrpsPath)
+	if err != nil {
+		return err
+	}
+
+	octets, err := json.Marshal(dbrpMappingRequest{
+		Database:        m.Database,
+		RetentionPolicy: m.RetentionPolicy,
+		Default:         m.Default,
+		BucketID:        m.BucketID,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(octets))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return err
+	}
+
+	var res dbrpMappingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return err
+	}
+	m.OrganizationID = res.OrganizationID
+
+	return nil
+}
+
+// Delete removes the dbrp mapping of the database and retention policy.
+func (s *DBRPMappingService) Delete(ctx context.Context, cluster, db, rp string) error {
+	if err := checkDBRPCluster(cluster); err != nil {
+		return err
+	}
+
+	u, err := newURL(s.Addr, dbrpPath(db, rp))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	SetToken(s.Token, req)
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return CheckError(resp)
+}
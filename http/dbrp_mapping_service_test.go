@@ -0,0 +1,104 @@
+package http
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/inmem"
+	"github.com/influxdata/influxdb/kv"
+	"go.uber.org/zap"
+)
+
+func initDBRPMappingService(t *testing.T) (*DBRPMappingService, *kv.Service, func()) {
+	t.Helper()
+
+	svc := kv.NewService(inmem.NewKVStore())
+	ctx := context.Background()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	handler := NewDBRPMappingHandler(&DBRPMappingBackend{
+		Logger:             zap.NewNop(),
+		DBRPMappingService: svc,
+		BucketService:      svc,
+	})
+	server := httptest.NewServer(handler)
+	client := &DBRPMappingService{
+		Addr: server.URL,
+	}
+
+	return client, svc, server.Close
+}
+
+func TestDBRPMappingService(t *testing.T) {
+	client, svc, done := initDBRPMappingService(t)
+	defer done()
+
+	ctx := context.Background()
+	o := &platform.Organization{Name: "o"}
+	if err := svc.CreateOrganization(ctx, o); err != nil {
+		t.Fatal(err)
+	}
+	b := &platform.Bucket{OrganizationID: o.ID, Name: "telegraf"}
+	if err := svc.CreateBucket(ctx, b); err != nil {
+		t.Fatal(err)
+	}
+
+	// The bucket is mapped when it is created.
+	m, err := client.FindBy(ctx, platform.DefaultDBRPCluster, "telegraf", platform.DefaultDBRPRetentionPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.Default || m.BucketID != b.ID || m.OrganizationID != o.ID {
+		t.Fatalf("unexpected mapping of bucket: %+v", m)
+	}
+
+	weekly := &platform.DBRPMapping{
+		Cluster:         platform.DefaultDBRPCluster,
+		Database:        "telegraf",
+		RetentionPolicy: "weekly",
+		BucketID:        b.ID,
+	}
+	if err := client.Create(ctx, weekly); err != nil {
+		t.Fatal(err)
+	}
+	if weekly.OrganizationID != o.ID {
+		t.Fatalf("expected mapping to be of the organization of its bucket, got %s", weekly.OrganizationID)
+	}
+
+	// The database has a default retention policy already.
+	weekly.Default = true
+	if err := client.Create(ctx, weekly); platform.ErrorCode(err) != platform.EConflict {
+		t.Fatalf("expected a second default retention policy to conflict; got %v", err)
+	}
+
+	isDefault := false
+	db := "telegraf"
+	ms, n, err := client.FindMany(ctx, platform.DBRPMappingFilter{Database: &db, Default: &isDefault})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 || ms[0].RetentionPolicy != "weekly" {
+		t.Fatalf("expected to find the weekly retention policy, got %+v", ms)
+	}
+
+	if err := client.Delete(ctx, platform.DefaultDBRPCluster, "telegraf", "weekly"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.FindBy(ctx, platform.DefaultDBRPCluster, "telegraf", "weekly"); platform.ErrorCode(err) != platform.ENotFound {
+		t.Fatalf("expected deleted mapping to be not found; got %v", err)
+	}
+
+	missing := &platform.DBRPMapping{
+		Cluster:         platform.DefaultDBRPCluster,
+		Database:        "db",
+		RetentionPolicy: "rp",
+		BucketID:        b.ID + 1,
+	}
+	if err := client.Create(ctx, missing); platform.ErrorCode(err) != platform.ENotFound {
+		t.Fatalf("expected mapping to a missing bucket to be not found; got %v", err)
+	}
+}
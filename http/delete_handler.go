@@ -0,0 +1,272 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	platform "github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/storage"
+	"github.com/influxdata/influxdb/storage/reads"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/tsdb/tsm1"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// DeleteBackend is all services and associated parameters required to construct
+// the DeleteHandler.
+type DeleteBackend struct {
+	Logger *zap.Logger
+
+	PredicateDeleter    storage.PredicateDeleter
+	BucketService       platform.BucketService
+	OrganizationService platform.OrganizationService
+}
+
+// NewDeleteBackend returns a new instance of DeleteBackend.
+func NewDeleteBackend(b *APIBackend) *DeleteBackend {
+	return &DeleteBackend{
+		Logger: b.Logger.With(zap.String("handler", "delete")),
+
+		PredicateDeleter:    b.PredicateDeleter,
+		BucketService:       b.BucketService,
+		OrganizationService: b.OrganizationService,
+	}
+}
+
+// DeleteHandler deletes the data of a bucket in a time range that matches a predicate.
+type DeleteHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	BucketService       platform.BucketService
+	OrganizationService platform.OrganizationService
+
+	PredicateDeleter storage.PredicateDeleter
+}
+
+const (
+	deletePath = "/api/v2/delete"
+)
+
+// NewDeleteHandler creates a new handler at /api/v2/delete to delete data by predicate.
+func NewDeleteHandler(b *DeleteBackend) *DeleteHandler {
+	h := &DeleteHandler{
+		Router: NewRouter(),
+		Logger: b.Logger,
+
+		PredicateDeleter:    b.PredicateDeleter,
+		BucketService:       b.BucketService,
+		OrganizationService: b.OrganizationService,
+	}
+
+	h.HandlerFunc("POST", deletePath, h.handleDelete)
+	return h
+}
+
+func (h *DeleteHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	defer r.Body.Close()
+
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	req, err := decodeDeleteRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	logger := h.Logger.With(zap.String("org", req.Org), zap.String("bucket", req.Bucket))
+
+	org, err := findOrganization(ctx, h.OrganizationService, req.Org)
+	if err != nil {
+		logger.Info("Failed to find organization", zap.Error(err))
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	bucket, err := findBucket(ctx, h.BucketService, org.ID, req.Bucket)
+	if err != nil {
+		EncodeError(ctx, &platform.Error{
+			Op:  "http/handleDelete",
+			Err: err,
+		}, w)
+		return
+	}
+
+	p, err := platform.NewPermissionAtID(bucket.ID, platform.WriteAction, platform.BucketsResourceType, org.ID)
+	if err != nil {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInternal,
+			Op:   "http/handleDelete",
+			Msg:  fmt.Sprintf("unable to create permission for bucket: %v", err),
+			Err:  err,
+		}, w)
+		return
+	}
+
+	if !a.Allowed(*p) {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EForbidden,
+			Op:   "http/handleDelete",
+			Msg:  "insufficient permissions to delete",
+		}, w)
+		return
+	}
+
+	// The deletes of authorizations restricted to some series of the bucket
+	// are restricted to them as well.
+	scope, err := scopePredicate(a, *p)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	var pred platform.Predicate
+	if pb := reads.AndPredicates(scope, req.Predicate); pb != nil {
+		if pred, err = tsm1.NewProtobufPredicate(pb); err != nil {
+			EncodeError(ctx, &platform.Error{
+				Code: platform.EInvalid,
+				Op:   "http/handleDelete",
+				Msg:  fmt.Sprintf("invalid predicate: %v", err),
+				Err:  err,
+			}, w)
+			return
+		}
+	}
+
+	if err := h.PredicateDeleter.DeleteBucketRangePredicate(org.ID, bucket.ID, req.Start, req.Stop, pred); err != nil {
+		logger.Error("Error deleting data", zap.Error(err))
+		EncodeError(ctx, &platform.Error{
+			Code: platform.EInternal,
+			Op:   "http/handleDelete",
+			Msg:  fmt.Sprintf("unable to delete data: %v", err),
+			Err:  err,
+		}, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// deleteRequestBody is the JSON body of a delete request.
+type deleteRequestBody struct {
+	Start     time.Time `json:"start"`
+	Stop      time.Time `json:"stop"`
+	Predicate string    `json:"predicate,omitempty"`
+}
+
+type deleteRequest struct {
+	Org         string
+	Bucket      string
+	Start, Stop int64
+	Predicate   *datatypes.Predicate
+}
+
+func decodeDeleteRequest(ctx context.Context, r *http.Request) (*deleteRequest, error) {
+	qp := r.URL.Query()
+	req := &deleteRequest{
+		Org:    qp.Get("org"),
+		Bucket: qp.Get("bucket"),
+	}
+
+	var body deleteRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/decodeDeleteRequest",
+			Msg:  "invalid request body",
+			Err:  err,
+		}
+	}
+
+	if body.Start.IsZero() || body.Stop.IsZero() {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/decodeDeleteRequest",
+			Msg:  "start and stop are required",
+		}
+	}
+
+	if body.Stop.Before(body.Start) {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/decodeDeleteRequest",
+			Msg:  "stop must not be before start",
+		}
+	}
+	req.Start, req.Stop = body.Start.UnixNano(), body.Stop.UnixNano()
+
+	if body.Predicate != "" {
+		pred, err := reads.ParsePredicate(body.Predicate)
+		if err != nil {
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Op:   "http/decodeDeleteRequest",
+				Msg:  fmt.Sprintf("invalid predicate: %v", err),
+				Err:  err,
+			}
+		}
+		req.Predicate = pred
+	}
+
+	return req, nil
+}
+
+// DeleteService sends predicate delete requests to influxdb over HTTP.
+type DeleteService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+// DeleteBucketRangePredicate deletes the data of a bucket between start and stop
+// that matches the textual predicate. An empty predicate deletes all data in the range.
+func (s *DeleteService) DeleteBucketRangePredicate(ctx context.Context, orgID, bucketID platform.ID, start, stop time.Time, predicate string) error {
+	u, err := newURL(s.Addr, deletePath)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(deleteRequestBody{
+		Start:     start,
+		Stop:      stop,
+		Predicate: predicate,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SetToken(s.Token, req)
+
+	params := req.URL.Query()
+	params.Set("org", orgID.String())
+	params.Set("bucket", bucketID.String())
+	req.URL.RawQuery = params.Encode()
+
+	hc := newClient(u.Scheme, s.InsecureSkipVerify)
+
+	resp, err := hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return CheckError(resp)
+}
@@ -0,0 +1,166 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	platform "github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"go.uber.org/zap"
+)
+
+type predicateDeleterFunc func(orgID, bucketID platform.ID, min, max int64, pred platform.Predicate) error
+
+func (fn predicateDeleterFunc) DeleteBucketRangePredicate(orgID, bucketID platform.ID, min, max int64, pred platform.Predicate) error {
+	return fn(orgID, bucketID, min, max, pred)
+}
+
+func TestDeleteHandler_handleDelete(t *testing.T) {
+	const (
+		orgID    = platform.ID(1)
+		bucketID = platform.ID(2)
+	)
+
+	scopedPermission := func(predicate string) platform.Permission {
+		p, err := platform.NewPermissionAtID(bucketID, platform.WriteAction, platform.BucketsResourceType, orgID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.Predicate = predicate
+		return *p
+	}
+
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	stop := time.Date(2019, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	type called struct {
+		min, max int64
+		badBox   bool
+		goodBox  bool
+	}
+
+	tests := []struct {
+		name        string
+		body        string
+		permissions []platform.Permission
+		status      int
+		called      *called
+	}{
+		{
+			name:   "delete with predicate",
+			body:   `{"start":"2019-01-01T00:00:00Z","stop":"2019-01-02T00:00:00Z","predicate":"host=\"bad-box\""}`,
+			status: http.StatusNoContent,
+			called: &called{min: start.UnixNano(), max: stop.UnixNano(), badBox: true},
+		},
+		{
+			name:   "delete without predicate",
+			body:   `{"start":"2019-01-01T00:00:00Z","stop":"2019-01-02T00:00:00Z"}`,
+			status: http.StatusNoContent,
+			called: &called{min: start.UnixNano(), max: stop.UnixNano(), badBox: true, goodBox: true},
+		},
+		{
+			name:   "invalid predicate",
+			body:   `{"start":"2019-01-01T00:00:00Z","stop":"2019-01-02T00:00:00Z","predicate":"host="}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "missing stop",
+			body:   `{"start":"2019-01-01T00:00:00Z"}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:        "delete restricted to scope",
+			body:        `{"start":"2019-01-01T00:00:00Z","stop":"2019-01-02T00:00:00Z"}`,
+			permissions: []platform.Permission{scopedPermission(`host="good-box"`)},
+			status:      http.StatusNoContent,
+			called:      &called{min: start.UnixNano(), max: stop.UnixNano(), goodBox: true},
+		},
+		{
+			name:        "delete with predicate outside of scope",
+			body:        `{"start":"2019-01-01T00:00:00Z","stop":"2019-01-02T00:00:00Z","predicate":"host=\"bad-box\""}`,
+			permissions: []platform.Permission{scopedPermission(`host="good-box"`)},
+			status:      http.StatusNoContent,
+			called:      &called{min: start.UnixNano(), max: stop.UnixNano()},
+		},
+		{
+			name:        "insufficient permissions",
+			body:        `{"start":"2019-01-01T00:00:00Z","stop":"2019-01-02T00:00:00Z"}`,
+			permissions: []platform.Permission{},
+			status:      http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgs := mock.NewOrganizationService()
+			orgs.FindOrganizationByIDF = func(ctx context.Context, id platform.ID) (*platform.Organization, error) {
+				return &platform.Organization{ID: id, Name: "org"}, nil
+			}
+
+			buckets := mock.NewBucketService()
+			buckets.FindBucketFn = func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+				return &platform.Bucket{ID: *filter.ID, OrganizationID: *filter.OrganizationID}, nil
+			}
+
+			var got *called
+			deleter := predicateDeleterFunc(func(o, b platform.ID, min, max int64, pred platform.Predicate) error {
+				if o != orgID || b != bucketID {
+					t.Errorf("unexpected org/bucket: %v/%v", o, b)
+				}
+				got = &called{min: min, max: max, badBox: true, goodBox: true}
+				if pred != nil {
+					got.badBox = pred.Matches([]byte("m,_f=v,_m=cpu,host=bad-box"))
+					got.goodBox = pred.Matches([]byte("m,_f=v,_m=cpu,host=good-box"))
+				}
+				return nil
+			})
+
+			h := NewDeleteHandler(&DeleteBackend{
+				Logger:              zap.NewNop(),
+				PredicateDeleter:    deleter,
+				BucketService:       buckets,
+				OrganizationService: orgs,
+			})
+
+			permissions := tt.permissions
+			if permissions == nil {
+				p, err := platform.NewPermissionAtID(bucketID, platform.WriteAction, platform.BucketsResourceType, orgID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				permissions = []platform.Permission{*p}
+			}
+
+			r := httptest.NewRequest("POST", "/api/v2/delete?org="+orgID.String()+"&bucket="+bucketID.String(), strings.NewReader(tt.body))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{
+				Status:      platform.Active,
+				Permissions: permissions,
+			}))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if got, want := w.Code, tt.status; got != want {
+				t.Fatalf("unexpected status code: got %d, want %d: %s", got, want, w.Body.String())
+			}
+
+			if tt.called == nil {
+				if got != nil {
+					t.Fatalf("unexpected call to delete: %+v", got)
+				}
+				return
+			}
+
+			if got == nil {
+				t.Fatal("expected delete to be called")
+			} else if *got != *tt.called {
+				t.Fatalf("unexpected delete: got %+v, want %+v", *got, *tt.called)
+			}
+		})
+	}
+}
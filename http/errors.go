@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 
 	platform "github.com/influxdata/influxdb"
 )
@@ -80,6 +82,7 @@ func EncodeError(ctx context.Context, err error, w http.ResponseWriter) {
 	}
 	w.Header().Set(PlatformErrorCodeHeader, code)
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	setRetryAfter(w, err)
 	w.WriteHeader(httpCode)
 	var e error
 	if pe, ok := err.(*platform.Error); ok {
@@ -119,4 +122,13 @@ var statusCodePlatformError = map[string]int{
 	platform.EForbidden:           http.StatusForbidden,
 	platform.EUnauthorized:        http.StatusUnauthorized,
 	platform.EMethodNotAllowed:    http.StatusMethodNotAllowed,
+	platform.ETooManyRequests:     http.StatusTooManyRequests,
+}
+
+// setRetryAfter sets the Retry-After header of the response to an operation
+// that failed with err if the operation may be retried later.
+func setRetryAfter(w http.ResponseWriter, err error) {
+	if d := platform.RetryAfter(err); d > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(d.Seconds()))))
+	}
 }
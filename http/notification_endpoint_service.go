@@ -0,0 +1,341 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/influxdata/influxdb"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+const (
+	notificationEndpointsPath = "/api/v2/notificationEndpoints"
+)
+
+// NotificationEndpointBackend is all services and associated parameters required to construct
+// the NotificationEndpointHandler.
+type NotificationEndpointBackend struct {
+	Logger                      *zap.Logger
+	NotificationEndpointService influxdb.NotificationEndpointService
+}
+
+// NewNotificationEndpointBackend returns a new instance of NotificationEndpointBackend.
+func NewNotificationEndpointBackend(b *APIBackend) *NotificationEndpointBackend {
+	return &NotificationEndpointBackend{
+		Logger:                      b.Logger.With(zap.String("handler", "notification_endpoint")),
+		NotificationEndpointService: b.NotificationEndpointService,
+	}
+}
+
+// NotificationEndpointHandler is the handler for the notification endpoint service
+type NotificationEndpointHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	NotificationEndpointService influxdb.NotificationEndpointService
+}
+
+// NewNotificationEndpointHandler creates a new NotificationEndpointHandler
+func NewNotificationEndpointHandler(b *NotificationEndpointBackend) *NotificationEndpointHandler {
+	h := &NotificationEndpointHandler{
+		Router: NewRouter(),
+		Logger: b.Logger,
+
+		NotificationEndpointService: b.NotificationEndpointService,
+	}
+
+	entityPath := fmt.Sprintf("%s/:id", notificationEndpointsPath)
+
+	h.HandlerFunc("GET", notificationEndpointsPath, h.handleGetNotificationEndpoints)
+	h.HandlerFunc("POST", notificationEndpointsPath, h.handlePostNotificationEndpoint)
+	h.HandlerFunc("GET", entityPath, h.handleGetNotificationEndpoint)
+	h.HandlerFunc("PUT", entityPath, h.handlePutNotificationEndpoint)
+	h.HandlerFunc("DELETE", entityPath, h.handleDeleteNotificationEndpoint)
+
+	return h
+}
+
+type notificationEndpointLinks struct {
+	Self string `json:"self"`
+	Org  string `json:"org"`
+}
+
+type notificationEndpointResponse struct {
+	*influxdb.NotificationEndpoint
+	Links notificationEndpointLinks `json:"links"`
+}
+
+func newNotificationEndpointResponse(e *influxdb.NotificationEndpoint) notificationEndpointResponse {
+	return notificationEndpointResponse{
+		NotificationEndpoint: e,
+		Links: notificationEndpointLinks{
+			Self: notificationEndpointIDPath(e.ID),
+			Org:  fmt.Sprintf("/api/v2/orgs/%s", e.OrganizationID),
+		},
+	}
+}
+
+type getNotificationEndpointsResponse struct {
+	NotificationEndpoints []notificationEndpointResponse `json:"notificationEndpoints"`
+	Links                 *influxdb.PagingLinks          `json:"links"`
+}
+
+func (r getNotificationEndpointsResponse) toInfluxDB() []*influxdb.NotificationEndpoint {
+	es := make([]*influxdb.NotificationEndpoint, len(r.NotificationEndpoints))
+	for i := range r.NotificationEndpoints {
+		es[i] = r.NotificationEndpoints[i].NotificationEndpoint
+	}
+	return es
+}
+
+func newGetNotificationEndpointsResponse(es []*influxdb.NotificationEndpoint, f influxdb.NotificationEndpointFilter, opts influxdb.FindOptions) getNotificationEndpointsResponse {
+	resp := getNotificationEndpointsResponse{
+		NotificationEndpoints: make([]notificationEndpointResponse, 0, len(es)),
+		Links:                 newPagingLinks(notificationEndpointsPath, opts, f, len(es)),
+	}
+
+	for _, e := range es {
+		resp.NotificationEndpoints = append(resp.NotificationEndpoints, newNotificationEndpointResponse(e))
+	}
+
+	return resp
+}
+
+type getNotificationEndpointsRequest struct {
+	filter influxdb.NotificationEndpointFilter
+	opts   influxdb.FindOptions
+}
+
+func decodeGetNotificationEndpointsRequest(ctx context.Context, r *http.Request) (*getNotificationEndpointsRequest, error) {
+	qp := r.URL.Query()
+	req := &getNotificationEndpointsRequest{}
+
+	opts, err := decodeFindOptions(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	req.opts = *opts
+
+	if orgID := qp.Get("orgID"); orgID != "" {
+		id, err := influxdb.IDFromString(orgID)
+		if err != nil {
+			return nil, err
+		}
+		req.filter.OrganizationID = id
+	}
+
+	if name := qp.Get("name"); name != "" {
+		req.filter.Name = &name
+	}
+
+	return req, nil
+}
+
+func (h *NotificationEndpointHandler) handleGetNotificationEndpoints(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetNotificationEndpointsRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	es, _, err := h.NotificationEndpointService.FindNotificationEndpoints(ctx, req.filter, req.opts)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newGetNotificationEndpointsResponse(es, req.filter, req.opts)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func requestNotificationEndpointID(ctx context.Context) (influxdb.ID, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	urlID := params.ByName("id")
+	if urlID == "" {
+		return influxdb.InvalidID(), &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+
+	id, err := influxdb.IDFromString(urlID)
+	if err != nil {
+		return influxdb.InvalidID(), &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+
+	return *id, nil
+}
+
+func (h *NotificationEndpointHandler) handleGetNotificationEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestNotificationEndpointID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	e, err := h.NotificationEndpointService.FindNotificationEndpointByID(ctx, id)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationEndpointResponse(e)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func decodeNotificationEndpoint(r *http.Request) (*influxdb.NotificationEndpoint, error) {
+	e := &influxdb.NotificationEndpoint{}
+	if err := json.NewDecoder(r.Body).Decode(e); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  err.Error(),
+		}
+	}
+
+	if err := e.Valid(); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+func (h *NotificationEndpointHandler) handlePostNotificationEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	e, err := decodeNotificationEndpoint(r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.NotificationEndpointService.CreateNotificationEndpoint(ctx, e); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, newNotificationEndpointResponse(e)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func (h *NotificationEndpointHandler) handlePutNotificationEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestNotificationEndpointID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	upd, err := decodeNotificationEndpoint(r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	e, err := h.NotificationEndpointService.UpdateNotificationEndpoint(ctx, id, upd)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newNotificationEndpointResponse(e)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func (h *NotificationEndpointHandler) handleDeleteNotificationEndpoint(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := requestNotificationEndpointID(ctx)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := h.NotificationEndpointService.DeleteNotificationEndpoint(ctx, id); err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// NotificationEndpointService is a notification endpoint service over HTTP to the influxdb server.
+type NotificationEndpointService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+var _ influxdb.NotificationEndpointService = (*NotificationEndpointService)(nil)
+
+// FindNotificationEndpointByID returns a single notification endpoint by ID.
+func (s *NotificationEndpointService) FindNotificationEndpointByID(ctx context.Context, id influxdb.ID) (*influxdb.NotificationEndpoint, error) {
+	var er notificationEndpointResponse
+	if err := s.do(ctx, "GET", notificationEndpointIDPath(id), nil, nil, &er); err != nil {
+		return nil, err
+	}
+	return er.NotificationEndpoint, nil
+}
+
+// FindNotificationEndpoints returns a list of notification endpoints that match filter.
+func (s *NotificationEndpointService) FindNotificationEndpoints(ctx context.Context, filter influxdb.NotificationEndpointFilter, opts ...influxdb.FindOptions) ([]*influxdb.NotificationEndpoint, int, error) {
+	var resp getNotificationEndpointsResponse
+	if err := s.do(ctx, "GET", notificationEndpointsPath, filter.QueryParams(), nil, &resp); err != nil {
+		return nil, 0, err
+	}
+
+	es := resp.toInfluxDB()
+	return es, len(es), nil
+}
+
+// CreateNotificationEndpoint creates a new notification endpoint and sets e.ID with the new identifier.
+func (s *NotificationEndpointService) CreateNotificationEndpoint(ctx context.Context, e *influxdb.NotificationEndpoint) error {
+	var er notificationEndpointResponse
+	if err := s.do(ctx, "POST", notificationEndpointsPath, nil, e, &er); err != nil {
+		return err
+	}
+	*e = *er.NotificationEndpoint
+	return nil
+}
+
+// UpdateNotificationEndpoint replaces a single notification endpoint.
+func (s *NotificationEndpointService) UpdateNotificationEndpoint(ctx context.Context, id influxdb.ID, upd *influxdb.NotificationEndpoint) (*influxdb.NotificationEndpoint, error) {
+	var er notificationEndpointResponse
+	if err := s.do(ctx, "PUT", notificationEndpointIDPath(id), nil, upd, &er); err != nil {
+		return nil, err
+	}
+	return er.NotificationEndpoint, nil
+}
+
+// DeleteNotificationEndpoint removes a notification endpoint by ID.
+func (s *NotificationEndpointService) DeleteNotificationEndpoint(ctx context.Context, id influxdb.ID) error {
+	return s.do(ctx, "DELETE", notificationEndpointIDPath(id), nil, nil, nil)
+}
+
+func (s *NotificationEndpointService) do(ctx context.Context, method, p string, params map[string][]string, body, v interface{}) error {
+	return doJSON(ctx, s.Addr, s.Token, s.InsecureSkipVerify, method, p, params, body, v)
+}
+
+func notificationEndpointIDPath(id influxdb.ID) string {
+	return path.Join(notificationEndpointsPath, id.String())
+}
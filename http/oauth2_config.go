@@ -0,0 +1,230 @@
+package http
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/influxdata/influxdb/chronograf"
+	"github.com/influxdata/influxdb/chronograf/oauth2"
+	"go.uber.org/zap"
+)
+
+// OAuth2 providers users can sign in through.
+const (
+	OAuth2ProviderGithub  = "github"
+	OAuth2ProviderGoogle  = "google"
+	OAuth2ProviderAuth0   = "auth0"
+	OAuth2ProviderHeroku  = "heroku"
+	OAuth2ProviderGeneric = "generic"
+)
+
+const oauth2SigninPath = "/api/v2/signin/oauth"
+
+// OAuth2Config configures the signin of users through an OAuth2 provider.
+type OAuth2Config struct {
+	// Provider is one of github, google, auth0, heroku or generic.
+	// Users can only sign in with a username and password when it is empty.
+	Provider     string
+	ClientID     string
+	ClientSecret string
+	// PublicURL is the URL users reach the API at. The provider redirects
+	// users to the callback of the provider beneath it.
+	PublicURL string
+	// TokenSecret signs the state of signins. It also verifies id tokens signed with HS256.
+	TokenSecret string
+	// JwksURL is the URL of the keys that verify id tokens signed with RS256.
+	JwksURL string
+	// UseIDToken reads the identity of users from the id token returned
+	// by the provider rather than from its API.
+	UseIDToken bool
+
+	// Domains are the email domains users are required to have by google and generic providers.
+	Domains []string
+	// Orgs are the organizations users are required to be members of by github, auth0 and heroku providers.
+	Orgs []string
+
+	// Auth0Domain is the domain of the Auth0 tenant.
+	Auth0Domain string
+
+	// Name is the name of the generic provider, in its routes as well.
+	Name     string
+	Scopes   []string
+	AuthURL  string
+	TokenURL string
+	// APIURL returns the identity of users, and APIKey is the key of their email in its response.
+	APIURL string
+	APIKey string
+
+	// ProvisionOrg is the name of the organization users signing in for the
+	// first time are added to as members. Users have to exist before they
+	// sign in when it is empty.
+	ProvisionOrg string
+	// ProvisionDomains and ProvisionGroups restrict the users added to
+	// ProvisionOrg to those with an email of one of the domains or in one of
+	// the groups or organizations reported by the provider. Every user the
+	// provider signs in is added when both are empty.
+	ProvisionDomains []string
+	ProvisionGroups  []string
+}
+
+// Validate returns an error if the provider cannot be configured.
+func (c *OAuth2Config) Validate() error {
+	_, err := c.newProvider(&chronograf.NoopLogger{})
+	return err
+}
+
+func (c *OAuth2Config) newProvider(log chronograf.Logger) (oauth2.Provider, error) {
+	if c.ClientID == "" || c.ClientSecret == "" {
+		return nil, fmt.Errorf("oauth2 client id and secret are required")
+	}
+	if c.TokenSecret == "" {
+		return nil, fmt.Errorf("oauth2 token secret is required")
+	}
+
+	switch c.Provider {
+	case OAuth2ProviderGithub:
+		return &oauth2.Github{
+			ClientID:     c.ClientID,
+			ClientSecret: c.ClientSecret,
+			Orgs:         c.Orgs,
+			Logger:       log,
+		}, nil
+	case OAuth2ProviderGoogle:
+		redirectURL, err := c.callbackURL(OAuth2ProviderGoogle)
+		if err != nil {
+			return nil, err
+		}
+		return &oauth2.Google{
+			ClientID:     c.ClientID,
+			ClientSecret: c.ClientSecret,
+			Domains:      c.Domains,
+			RedirectURL:  redirectURL,
+			Logger:       log,
+		}, nil
+	case OAuth2ProviderAuth0:
+		if c.Auth0Domain == "" {
+			return nil, fmt.Errorf("auth0 domain is required")
+		}
+		redirectURL, err := c.callbackURL(OAuth2ProviderAuth0)
+		if err != nil {
+			return nil, err
+		}
+		p, err := oauth2.NewAuth0(c.Auth0Domain, c.ClientID, c.ClientSecret, redirectURL, c.Orgs, log)
+		if err != nil {
+			return nil, err
+		}
+		return &p, nil
+	case OAuth2ProviderHeroku:
+		return &oauth2.Heroku{
+			ClientID:      c.ClientID,
+			ClientSecret:  c.ClientSecret,
+			Organizations: c.Orgs,
+			Logger:        log,
+		}, nil
+	case OAuth2ProviderGeneric:
+		if c.AuthURL == "" || c.TokenURL == "" {
+			return nil, fmt.Errorf("oauth2 auth and token urls are required by the generic provider")
+		}
+		p := &oauth2.Generic{
+			PageName:       c.Name,
+			ClientID:       c.ClientID,
+			ClientSecret:   c.ClientSecret,
+			RequiredScopes: c.Scopes,
+			Domains:        c.Domains,
+			AuthURL:        c.AuthURL,
+			TokenURL:       c.TokenURL,
+			APIURL:         c.APIURL,
+			APIKey:         c.APIKey,
+			Logger:         log,
+		}
+		redirectURL, err := c.callbackURL(p.Name())
+		if err != nil {
+			return nil, err
+		}
+		p.RedirectURL = redirectURL
+		return p, nil
+	default:
+		return nil, fmt.Errorf("unknown oauth2 provider %q", c.Provider)
+	}
+}
+
+func (c *OAuth2Config) callbackURL(provider string) (string, error) {
+	if c.PublicURL == "" {
+		return "", fmt.Errorf("public url is required by the %s provider", provider)
+	}
+	u, err := url.Parse(c.PublicURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid public url %q: %v", c.PublicURL, err)
+	}
+	u.Path = path.Join(u.Path, oauth2SigninPath, provider, "callback")
+	return u.String(), nil
+}
+
+// provisions reports whether a user signing in for the first time is added to ProvisionOrg.
+func (c *OAuth2Config) provisions(p oauth2.Principal) bool {
+	if c.ProvisionOrg == "" {
+		return false
+	}
+	if len(c.ProvisionDomains) == 0 && len(c.ProvisionGroups) == 0 {
+		return true
+	}
+
+	if i := strings.LastIndex(p.Subject, "@"); i >= 0 {
+		domain := p.Subject[i+1:]
+		for _, d := range c.ProvisionDomains {
+			if strings.EqualFold(d, domain) {
+				return true
+			}
+		}
+	}
+
+	for _, g := range strings.Split(p.Group, ",") {
+		for _, want := range c.ProvisionGroups {
+			if g != "" && g == want {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// chronografLogger logs the messages of the chronograf oauth2 package through zap.
+type chronografLogger struct {
+	log *zap.SugaredLogger
+}
+
+func newChronografLogger(log *zap.Logger) chronograf.Logger {
+	return &chronografLogger{log: log.Sugar()}
+}
+
+func (l *chronografLogger) Debug(args ...interface{}) {
+	l.log.Debug(args...)
+}
+
+func (l *chronografLogger) Info(args ...interface{}) {
+	l.log.Info(args...)
+}
+
+func (l *chronografLogger) Error(args ...interface{}) {
+	l.log.Error(args...)
+}
+
+func (l *chronografLogger) WithField(key string, value interface{}) chronograf.Logger {
+	return &chronografLogger{log: l.log.With(key, value)}
+}
+
+func (l *chronografLogger) Writer() *io.PipeWriter {
+	r, w := io.Pipe()
+	go func() {
+		s := bufio.NewScanner(r)
+		for s.Scan() {
+			l.log.Info(s.Text())
+		}
+	}()
+	return w
+}
@@ -0,0 +1,305 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/chronograf/oauth2"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+const (
+	// oauth2SigninSuccessPath is where users are redirected to after signing in.
+	oauth2SigninSuccessPath = "/"
+	// oauth2SigninFailurePath is where users are redirected to when signing in fails.
+	oauth2SigninFailurePath = "/signin"
+)
+
+// OAuth2Backend is all services and associated parameters required to construct
+// the OAuth2Handler.
+type OAuth2Backend struct {
+	Logger *zap.Logger
+
+	// Config configures the provider users sign in through. Users cannot sign in through OAuth2 when it is nil.
+	Config *OAuth2Config
+
+	SessionService             platform.SessionService
+	UserService                platform.UserService
+	OrganizationService        platform.OrganizationService
+	UserResourceMappingService platform.UserResourceMappingService
+}
+
+// NewOAuth2Backend returns a new instance of OAuth2Backend.
+func NewOAuth2Backend(b *APIBackend) *OAuth2Backend {
+	return &OAuth2Backend{
+		Logger: b.Logger.With(zap.String("handler", "oauth2")),
+
+		Config:                     b.OAuth2Config,
+		SessionService:             b.SessionService,
+		UserService:                b.UserService,
+		OrganizationService:        b.OrganizationService,
+		UserResourceMappingService: b.UserResourceMappingService,
+	}
+}
+
+// OAuth2Handler signs users in through OAuth2 providers. Users signing in are
+// given a session of the user with the identity reported by the provider.
+type OAuth2Handler struct {
+	*httprouter.Router
+	Logger *zap.Logger
+
+	muxes map[string]oauth2.Mux
+}
+
+// NewOAuth2Handler returns a new instance of OAuth2Handler.
+func NewOAuth2Handler(b *OAuth2Backend) *OAuth2Handler {
+	h := &OAuth2Handler{
+		Router: NewRouter(),
+		Logger: b.Logger,
+
+		muxes: make(map[string]oauth2.Mux),
+	}
+
+	if b.Config != nil && b.Config.Provider != "" {
+		log := newChronografLogger(b.Logger)
+		p, err := b.Config.newProvider(log)
+		if err != nil {
+			b.Logger.Error("failed to configure oauth2 provider", zap.String("provider", b.Config.Provider), zap.Error(err))
+		} else {
+			auth := &oauth2SessionAuthenticator{
+				config:                     b.Config,
+				SessionService:             b.SessionService,
+				UserService:                b.UserService,
+				OrganizationService:        b.OrganizationService,
+				UserResourceMappingService: b.UserResourceMappingService,
+			}
+			tokens := oauth2.NewJWT(b.Config.TokenSecret, b.Config.JwksURL)
+			mux := oauth2.NewAuthMux(p, auth, tokens, "", log, b.Config.UseIDToken)
+			mux.SuccessURL = oauth2SigninSuccessPath
+			mux.FailureURL = oauth2SigninFailurePath
+			h.muxes[p.Name()] = mux
+		}
+	}
+
+	h.HandlerFunc("GET", oauth2SigninPath, h.handleGetProviders)
+	h.HandlerFunc("GET", oauth2SigninPath+"/:provider", h.handleLogin)
+	h.HandlerFunc("GET", oauth2SigninPath+"/:provider/callback", h.handleCallback)
+	return h
+}
+
+type oauth2ProviderResponse struct {
+	Name  string            `json:"name"`
+	Links map[string]string `json:"links"`
+}
+
+type oauth2ProvidersResponse struct {
+	Providers []oauth2ProviderResponse `json:"providers"`
+}
+
+// handleGetProviders is the HTTP handler for the GET /api/v2/signin/oauth route.
+func (h *OAuth2Handler) handleGetProviders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	res := oauth2ProvidersResponse{
+		Providers: []oauth2ProviderResponse{},
+	}
+	for name := range h.muxes {
+		res.Providers = append(res.Providers, oauth2ProviderResponse{
+			Name: name,
+			Links: map[string]string{
+				"login":    path.Join(oauth2SigninPath, name),
+				"callback": path.Join(oauth2SigninPath, name, "callback"),
+			},
+		})
+	}
+	sort.Slice(res.Providers, func(i, j int) bool {
+		return res.Providers[i].Name < res.Providers[j].Name
+	})
+
+	if err := encodeResponse(ctx, w, http.StatusOK, res); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+// handleLogin is the HTTP handler for the GET /api/v2/signin/oauth/:provider route.
+// It redirects users to the provider.
+func (h *OAuth2Handler) handleLogin(w http.ResponseWriter, r *http.Request) {
+	mux, err := h.findMux(r)
+	if err != nil {
+		EncodeError(r.Context(), err, w)
+		return
+	}
+
+	mux.Login().ServeHTTP(w, r)
+}
+
+// handleCallback is the HTTP handler for the GET /api/v2/signin/oauth/:provider/callback route.
+// Providers redirect users to it once they have signed in.
+func (h *OAuth2Handler) handleCallback(w http.ResponseWriter, r *http.Request) {
+	mux, err := h.findMux(r)
+	if err != nil {
+		EncodeError(r.Context(), err, w)
+		return
+	}
+
+	mux.Callback().ServeHTTP(w, r)
+}
+
+func (h *OAuth2Handler) findMux(r *http.Request) (oauth2.Mux, error) {
+	name := httprouter.ParamsFromContext(r.Context()).ByName("provider")
+	mux, ok := h.muxes[name]
+	if !ok {
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  fmt.Sprintf("oauth2 provider %q not found", name),
+		}
+	}
+	return mux, nil
+}
+
+var _ oauth2.Authenticator = (*oauth2SessionAuthenticator)(nil)
+
+// oauth2SessionAuthenticator gives the principals signed in by a provider a
+// session of the user named after them, creating the user when provisioning
+// is configured.
+type oauth2SessionAuthenticator struct {
+	config *OAuth2Config
+
+	SessionService             platform.SessionService
+	UserService                platform.UserService
+	OrganizationService        platform.OrganizationService
+	UserResourceMappingService platform.UserResourceMappingService
+}
+
+// Validate returns the principal of the user of the session of the request.
+func (a *oauth2SessionAuthenticator) Validate(ctx context.Context, r *http.Request) (oauth2.Principal, error) {
+	key, err := decodeCookieSession(ctx, r)
+	if err != nil {
+		return oauth2.Principal{}, oauth2.ErrAuthentication
+	}
+
+	s, e := a.SessionService.FindSession(ctx, key)
+	if e != nil {
+		return oauth2.Principal{}, oauth2.ErrAuthentication
+	}
+
+	u, e := a.UserService.FindUserByID(ctx, s.UserID)
+	if e != nil {
+		return oauth2.Principal{}, oauth2.ErrAuthentication
+	}
+
+	return oauth2.Principal{
+		Subject:   u.Name,
+		IssuedAt:  s.CreatedAt,
+		ExpiresAt: s.ExpiresAt,
+	}, nil
+}
+
+// Authorize creates a session for the user of the principal and sets its cookie.
+func (a *oauth2SessionAuthenticator) Authorize(ctx context.Context, w http.ResponseWriter, p oauth2.Principal) error {
+	u, err := a.findOrProvisionUser(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	s, err := a.SessionService.CreateSession(ctx, u.Name)
+	if err != nil {
+		return err
+	}
+
+	// The callback is beneath the API, so the cookie needs an explicit path
+	// for the browser to send it along with the other requests to the API.
+	// Scripts cannot read the session, and it is only sent over https if the
+	// API is reached over https.
+	http.SetCookie(w, &http.Cookie{
+		Name:     cookieSessionName,
+		Value:    s.Key,
+		Path:     "/",
+		Expires:  s.ExpiresAt,
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(a.config.PublicURL, "https"),
+	})
+	return nil
+}
+
+// Extend leaves the principal unchanged; sessions are renewed as they are used.
+func (a *oauth2SessionAuthenticator) Extend(ctx context.Context, w http.ResponseWriter, p oauth2.Principal) (oauth2.Principal, error) {
+	return p, nil
+}
+
+// Expire removes the cookie of the session.
+func (a *oauth2SessionAuthenticator) Expire(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:   cookieSessionName,
+		Value:  "",
+		Path:   "/",
+		MaxAge: -1,
+	})
+}
+
+// findOrProvisionUser returns the user signed in as the principal. Only the
+// users linked to the identity of the principal at the provider sign in, so
+// that the provider cannot sign in as a user of the same name created in
+// another way, such as the user created during onboarding.
+func (a *oauth2SessionAuthenticator) findOrProvisionUser(ctx context.Context, p oauth2.Principal) (*platform.User, error) {
+	if p.Subject == "" {
+		return nil, oauth2.ErrAuthentication
+	}
+	oauthID := oauth2ID(p)
+
+	u, err := a.UserService.FindUser(ctx, platform.UserFilter{Name: &p.Subject})
+	if err == nil {
+		if u.OAuthID != oauthID {
+			return nil, &platform.Error{
+				Code: platform.EForbidden,
+				Msg:  fmt.Sprintf("user %q is not linked to %s", p.Subject, p.Issuer),
+			}
+		}
+		return u, nil
+	}
+	if platform.ErrorCode(err) != platform.ENotFound {
+		return nil, err
+	}
+
+	if !a.config.provisions(p) {
+		return nil, &platform.Error{
+			Code: platform.EForbidden,
+			Msg:  fmt.Sprintf("user %q signed in through %s does not exist", p.Subject, p.Issuer),
+		}
+	}
+
+	o, err := a.OrganizationService.FindOrganization(ctx, platform.OrganizationFilter{Name: &a.config.ProvisionOrg})
+	if err != nil {
+		return nil, err
+	}
+
+	u = &platform.User{Name: p.Subject, OAuthID: oauthID}
+	if err := a.UserService.CreateUser(ctx, u); err != nil {
+		return nil, err
+	}
+
+	m := &platform.UserResourceMapping{
+		UserID:       u.ID,
+		UserType:     platform.Member,
+		ResourceType: platform.OrgsResourceType,
+		ResourceID:   o.ID,
+	}
+	if err := a.UserResourceMappingService.CreateUserResourceMapping(ctx, m); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// oauth2ID returns the identity of the principal at the provider that signed
+// it in, which links users to the provider.
+func oauth2ID(p oauth2.Principal) string {
+	return p.Issuer + ":" + p.Subject
+}
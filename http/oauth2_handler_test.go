@@ -0,0 +1,341 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/chronograf/oauth2"
+	"github.com/influxdata/influxdb/inmem"
+	"github.com/influxdata/influxdb/kv"
+	"go.uber.org/zap"
+	goauth2 "golang.org/x/oauth2"
+)
+
+// fakeOAuth2Provider signs in the same principal whatever the code exchanged.
+type fakeOAuth2Provider struct {
+	tokenURL string
+	subject  string
+	group    string
+}
+
+func (p *fakeOAuth2Provider) ID() string       { return "id" }
+func (p *fakeOAuth2Provider) Secret() string   { return "secret" }
+func (p *fakeOAuth2Provider) Scopes() []string { return nil }
+func (p *fakeOAuth2Provider) Name() string     { return "fake" }
+
+func (p *fakeOAuth2Provider) Config() *goauth2.Config {
+	return &goauth2.Config{
+		ClientID:     p.ID(),
+		ClientSecret: p.Secret(),
+		Endpoint: goauth2.Endpoint{
+			AuthURL:  "https://provider.example.com/authorize",
+			TokenURL: p.tokenURL,
+		},
+	}
+}
+
+func (p *fakeOAuth2Provider) PrincipalID(*http.Client) (string, error) { return p.subject, nil }
+func (p *fakeOAuth2Provider) Group(*http.Client) (string, error)       { return p.group, nil }
+
+func newOAuth2TestHandler(t *testing.T, svc *kv.Service, config *OAuth2Config, provider oauth2.Provider) *OAuth2Handler {
+	t.Helper()
+
+	h := NewOAuth2Handler(&OAuth2Backend{
+		Logger:                     zap.NewNop(),
+		SessionService:             svc,
+		UserService:                svc,
+		OrganizationService:        svc,
+		UserResourceMappingService: svc,
+	})
+
+	auth := &oauth2SessionAuthenticator{
+		config:                     config,
+		SessionService:             svc,
+		UserService:                svc,
+		OrganizationService:        svc,
+		UserResourceMappingService: svc,
+	}
+	log := newChronografLogger(zap.NewNop())
+	mux := oauth2.NewAuthMux(provider, auth, oauth2.NewJWT(config.TokenSecret, ""), "", log, false)
+	mux.SuccessURL = oauth2SigninSuccessPath
+	mux.FailureURL = oauth2SigninFailurePath
+	h.muxes[provider.Name()] = mux
+	return h
+}
+
+// signinThroughOAuth2 follows the redirects of a signin through the provider,
+// and returns the redirect and the cookies of the callback.
+func signinThroughOAuth2(t *testing.T, h http.Handler) (string, []*http.Cookie) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "http://any.url/api/v2/signin/oauth/fake", nil))
+	if w.Code != http.StatusTemporaryRedirect {
+		t.Fatalf("expected login to redirect to the provider, got status %d", w.Code)
+	}
+	loc, err := url.Parse(w.Header().Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q := url.Values{}
+	q.Set("state", loc.Query().Get("state"))
+	q.Set("code", "code")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "http://any.url/api/v2/signin/oauth/fake/callback?"+q.Encode(), nil))
+	if w.Code != http.StatusTemporaryRedirect {
+		t.Fatalf("expected callback to redirect, got status %d", w.Code)
+	}
+
+	return w.Header().Get("Location"), w.Result().Cookies()
+}
+
+func TestOAuth2Handler_Signin(t *testing.T) {
+	tokens := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"token","token_type":"bearer"}`))
+	}))
+	defer tokens.Close()
+
+	tests := []struct {
+		name      string
+		subject   string
+		group     string
+		config    OAuth2Config
+		existing  bool
+		oauthID   string
+		signedIn  bool
+		provision bool
+	}{
+		{
+			name:     "users linked to the provider sign in",
+			subject:  "alice@example.com",
+			config:   OAuth2Config{TokenSecret: "secret", PublicURL: "https://influx.example.com"},
+			existing: true,
+			oauthID:  "fake:alice@example.com",
+			signedIn: true,
+		},
+		{
+			name:    "existing users not linked to the provider cannot be claimed",
+			subject: "alice@example.com",
+			config: OAuth2Config{
+				TokenSecret:      "secret",
+				ProvisionOrg:     "o",
+				ProvisionDomains: []string{"example.com"},
+			},
+			existing: true,
+		},
+		{
+			name:    "unknown users are refused without provisioning",
+			subject: "alice@example.com",
+			config:  OAuth2Config{TokenSecret: "secret"},
+		},
+		{
+			name:    "users are provisioned by their email domain",
+			subject: "alice@example.com",
+			config: OAuth2Config{
+				TokenSecret:      "secret",
+				ProvisionOrg:     "o",
+				ProvisionDomains: []string{"example.com"},
+			},
+			signedIn:  true,
+			provision: true,
+		},
+		{
+			name:    "users are provisioned by their groups",
+			subject: "alice@other.com",
+			group:   "ops,eng",
+			config: OAuth2Config{
+				TokenSecret:      "secret",
+				ProvisionOrg:     "o",
+				ProvisionDomains: []string{"example.com"},
+				ProvisionGroups:  []string{"eng"},
+			},
+			signedIn:  true,
+			provision: true,
+		},
+		{
+			name:    "users outside the domains and groups are refused",
+			subject: "alice@other.com",
+			group:   "ops",
+			config: OAuth2Config{
+				TokenSecret:      "secret",
+				ProvisionOrg:     "o",
+				ProvisionDomains: []string{"example.com"},
+				ProvisionGroups:  []string{"eng"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			svc := kv.NewService(inmem.NewKVStore())
+			if err := svc.Initialize(ctx); err != nil {
+				t.Fatal(err)
+			}
+			o := &platform.Organization{Name: "o"}
+			if err := svc.CreateOrganization(ctx, o); err != nil {
+				t.Fatal(err)
+			}
+			if tt.existing {
+				if err := svc.CreateUser(ctx, &platform.User{Name: tt.subject, OAuthID: tt.oauthID}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			provider := &fakeOAuth2Provider{tokenURL: tokens.URL, subject: tt.subject, group: tt.group}
+			h := newOAuth2TestHandler(t, svc, &tt.config, provider)
+
+			loc, cookies := signinThroughOAuth2(t, h)
+
+			if !tt.signedIn {
+				if loc != oauth2SigninFailurePath {
+					t.Errorf("expected redirect to %s, got %s", oauth2SigninFailurePath, loc)
+				}
+				if len(cookies) != 0 {
+					t.Errorf("expected no session cookie, got %v", cookies)
+				}
+				u, err := svc.FindUser(ctx, platform.UserFilter{Name: &tt.subject})
+				switch {
+				case !tt.existing && platform.ErrorCode(err) != platform.ENotFound:
+					t.Errorf("expected user not to be created, got %v", err)
+				case tt.existing && err != nil:
+					t.Fatal(err)
+				case tt.existing && u.OAuthID != tt.oauthID:
+					t.Errorf("expected user to stay linked to %q, got %q", tt.oauthID, u.OAuthID)
+				}
+				return
+			}
+
+			if loc != oauth2SigninSuccessPath {
+				t.Errorf("expected redirect to %s, got %s", oauth2SigninSuccessPath, loc)
+			}
+			if len(cookies) != 1 || cookies[0].Name != cookieSessionName {
+				t.Fatalf("expected a session cookie, got %v", cookies)
+			}
+			s, err := svc.FindSession(ctx, cookies[0].Value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			c := cookies[0]
+			if secure := strings.HasPrefix(tt.config.PublicURL, "https"); !c.HttpOnly || c.Secure != secure {
+				t.Errorf("expected an http only session cookie, secure %v, got %+v", secure, c)
+			}
+			if c.Expires.Unix() != s.ExpiresAt.Unix() {
+				t.Errorf("expected session cookie to expire at %v, got %v", s.ExpiresAt, c.Expires)
+			}
+			u, err := svc.FindUserByID(ctx, s.UserID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if u.Name != tt.subject {
+				t.Errorf("expected session of user %s, got %s", tt.subject, u.Name)
+			}
+			if want := "fake:" + tt.subject; u.OAuthID != want {
+				t.Errorf("expected user linked to %q, got %q", want, u.OAuthID)
+			}
+
+			ms, _, err := svc.FindUserResourceMappings(ctx, platform.UserResourceMappingFilter{
+				UserID:       u.ID,
+				ResourceType: platform.OrgsResourceType,
+				ResourceID:   o.ID,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.provision && (len(ms) != 1 || ms[0].UserType != platform.Member) {
+				t.Errorf("expected user to be a member of the organization, got %v", ms)
+			}
+		})
+	}
+}
+
+func TestOAuth2Handler_Providers(t *testing.T) {
+	h := NewOAuth2Handler(&OAuth2Backend{
+		Logger: zap.NewNop(),
+		Config: &OAuth2Config{
+			Provider:     OAuth2ProviderGithub,
+			ClientID:     "id",
+			ClientSecret: "secret",
+			TokenSecret:  "secret",
+		},
+	})
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "http://any.url/api/v2/signin/oauth", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status %d", w.Code)
+	}
+	var res oauth2ProvidersResponse
+	if err := json.NewDecoder(w.Body).Decode(&res); err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Providers) != 1 || res.Providers[0].Name != "github" || res.Providers[0].Links["login"] != "/api/v2/signin/oauth/github" {
+		t.Errorf("unexpected providers %+v", res.Providers)
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "http://any.url/api/v2/signin/oauth/google", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected unconfigured provider to be not found, got status %d", w.Code)
+	}
+}
+
+func TestOAuth2Config_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  OAuth2Config
+		wantErr bool
+	}{
+		{
+			name:   "github",
+			config: OAuth2Config{Provider: "github", ClientID: "id", ClientSecret: "secret", TokenSecret: "secret"},
+		},
+		{
+			name:    "missing token secret",
+			config:  OAuth2Config{Provider: "github", ClientID: "id", ClientSecret: "secret"},
+			wantErr: true,
+		},
+		{
+			name:    "google without public url",
+			config:  OAuth2Config{Provider: "google", ClientID: "id", ClientSecret: "secret", TokenSecret: "secret"},
+			wantErr: true,
+		},
+		{
+			name:    "generic without auth url",
+			config:  OAuth2Config{Provider: "generic", ClientID: "id", ClientSecret: "secret", TokenSecret: "secret", PublicURL: "http://localhost:9999"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown provider",
+			config:  OAuth2Config{Provider: "myspace", ClientID: "id", ClientSecret: "secret", TokenSecret: "secret"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.config.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOAuth2Config_CallbackURL(t *testing.T) {
+	c := &OAuth2Config{PublicURL: "https://influx.example.com/base"}
+	u, err := c.callbackURL("google")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "https://influx.example.com/base/api/v2/signin/oauth/google/callback"; u != want {
+		t.Errorf("expected %s, got %s", want, u)
+	}
+}
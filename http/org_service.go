@@ -24,6 +24,7 @@ type OrgBackend struct {
 	SecretService                   influxdb.SecretService
 	LabelService                    influxdb.LabelService
 	UserService                     influxdb.UserService
+	OrgLimitsService                influxdb.OrgLimitsService
 }
 
 func NewOrgBackend(b *APIBackend) *OrgBackend {
@@ -36,6 +37,7 @@ func NewOrgBackend(b *APIBackend) *OrgBackend {
 		SecretService:                   b.SecretService,
 		LabelService:                    b.LabelService,
 		UserService:                     b.UserService,
+		OrgLimitsService:                b.OrgLimitsService,
 	}
 }
 
@@ -51,6 +53,7 @@ type OrgHandler struct {
 	SecretService                   influxdb.SecretService
 	LabelService                    influxdb.LabelService
 	UserService                     influxdb.UserService
+	OrgLimitsService                influxdb.OrgLimitsService
 }
 
 const (
@@ -66,6 +69,7 @@ const (
 	organizationsIDSecretsDeletePath = "/api/v2/orgs/:id/secrets/delete"
 	organizationsIDLabelsPath        = "/api/v2/orgs/:id/labels"
 	organizationsIDLabelsIDPath      = "/api/v2/orgs/:id/labels/:lid"
+	organizationsIDLimitsPath        = "/api/v2/orgs/:id/limits"
 )
 
 // NewOrgHandler returns a new instance of OrgHandler.
@@ -80,6 +84,7 @@ func NewOrgHandler(b *OrgBackend) *OrgHandler {
 		SecretService:                   b.SecretService,
 		LabelService:                    b.LabelService,
 		UserService:                     b.UserService,
+		OrgLimitsService:                b.OrgLimitsService,
 	}
 
 	h.HandlerFunc("POST", organizationsPath, h.handlePostOrg)
@@ -116,6 +121,9 @@ func NewOrgHandler(b *OrgBackend) *OrgHandler {
 	// TODO(desa): need a way to specify which secrets to delete. this should work for now
 	h.HandlerFunc("POST", organizationsIDSecretsDeletePath, h.handleDeleteSecrets)
 
+	h.HandlerFunc("GET", organizationsIDLimitsPath, h.handleGetLimits)
+	h.HandlerFunc("PATCH", organizationsIDLimitsPath, h.handlePatchLimits)
+
 	labelBackend := &LabelBackend{
 		Logger:       b.Logger.With(zap.String("handler", "label")),
 		LabelService: b.LabelService,
@@ -191,6 +199,21 @@ func newSecretsResponse(orgID influxdb.ID, ks []string) *secretsResponse {
 	}
 }
 
+type orgLimitsResponse struct {
+	Links map[string]string `json:"links"`
+	influxdb.OrgLimits
+}
+
+func newOrgLimitsResponse(l *influxdb.OrgLimits) *orgLimitsResponse {
+	return &orgLimitsResponse{
+		Links: map[string]string{
+			"org":  fmt.Sprintf("/api/v2/orgs/%s", l.OrgID),
+			"self": fmt.Sprintf("/api/v2/orgs/%s/limits", l.OrgID),
+		},
+		OrgLimits: *l,
+	}
+}
+
 // handlePostOrg is the HTTP handler for the POST /api/v2/orgs route.
 func (h *OrgHandler) handlePostOrg(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -510,6 +533,107 @@ func decodePatchSecretsRequest(ctx context.Context, r *http.Request) (*patchSecr
 	return req, nil
 }
 
+// handleGetLimits is the HTTP handler for the GET /api/v2/orgs/:id/limits route.
+func (h *OrgHandler) handleGetLimits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetLimitsRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	l, err := h.OrgLimitsService.FindOrgLimits(ctx, req.orgID)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newOrgLimitsResponse(l)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+type getLimitsRequest struct {
+	orgID influxdb.ID
+}
+
+func decodeGetLimitsRequest(ctx context.Context, r *http.Request) (*getLimitsRequest, error) {
+	req := &getLimitsRequest{}
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+
+	var i influxdb.ID
+	if err := i.DecodeFromString(id); err != nil {
+		return nil, err
+	}
+	req.orgID = i
+
+	return req, nil
+}
+
+// handlePatchLimits is the HTTP handler for the PATCH /api/v2/orgs/:id/limits route.
+func (h *OrgHandler) handlePatchLimits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePatchLimitsRequest(ctx, r)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	l, err := h.OrgLimitsService.UpdateOrgLimits(ctx, req.orgID, req.update)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newOrgLimitsResponse(l)); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+type patchLimitsRequest struct {
+	orgID  influxdb.ID
+	update influxdb.OrgLimitsUpdate
+}
+
+func decodePatchLimitsRequest(ctx context.Context, r *http.Request) (*patchLimitsRequest, error) {
+	req := &patchLimitsRequest{}
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+
+	var i influxdb.ID
+	if err := i.DecodeFromString(id); err != nil {
+		return nil, err
+	}
+	req.orgID = i
+
+	if err := json.NewDecoder(r.Body).Decode(&req.update); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid json",
+			Err:  err,
+		}
+	}
+
+	return req, nil
+}
+
 // handleDeleteSecrets is the HTTP handler for the DELETE /api/v2/orgs/:id/secrets route.
 func (h *OrgHandler) handleDeleteSecrets(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -28,10 +28,14 @@ func NewPlatformHandler(b *APIBackend) *PlatformHandler {
 	h.Handler = NewAPIHandler(b)
 	h.AuthorizationService = b.AuthorizationService
 	h.SessionService = b.SessionService
+	h.AuthorizationUseRecorder = b.AuthorizationUseRecorder
 
 	h.RegisterNoAuthRoute("GET", "/api/v2")
 	h.RegisterNoAuthRoute("POST", "/api/v2/signin")
 	h.RegisterNoAuthRoute("POST", "/api/v2/signout")
+	h.RegisterNoAuthRoute("GET", oauth2SigninPath)
+	h.RegisterNoAuthRoute("GET", oauth2SigninPath+"/:provider")
+	h.RegisterNoAuthRoute("GET", oauth2SigninPath+"/:provider/callback")
 	h.RegisterNoAuthRoute("POST", "/api/v2/setup")
 	h.RegisterNoAuthRoute("GET", "/api/v2/setup")
 	h.RegisterNoAuthRoute("GET", "/api/v2/swagger.json")
@@ -59,8 +63,9 @@ func (h *PlatformHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Serve the chronograf assets for any basepath that does not start with addressable parts
-	// of the platform API.
-	if !strings.HasPrefix(r.URL.Path, "/v1") &&
+	// of the platform API or is one of the 1.x compatible endpoints.
+	if r.URL.Path != v1WritePath && r.URL.Path != v1QueryPath &&
+		!strings.HasPrefix(r.URL.Path, "/v1") &&
 		!strings.HasPrefix(r.URL.Path, "/api/v2") &&
 		!strings.HasPrefix(r.URL.Path, "/chronograf/") {
 		h.AssetHandler.ServeHTTP(w, r)
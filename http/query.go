@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 	"unicode/utf8"
 
@@ -20,10 +21,14 @@ import (
 	"github.com/influxdata/flux/parser"
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/arrowipc"
+	iql "github.com/influxdata/influxdb/query/influxql"
+	"github.com/influxdata/influxdb/query/lineprotocol"
+	"github.com/influxdata/influxdb/query/ndjson"
 	"github.com/influxdata/influxql"
 )
 
-// QueryRequest is a flux query request.
+// QueryRequest is a flux or influxql query request.
 type QueryRequest struct {
 	Extern  *ast.File    `json:"extern,omitempty"`
 	Spec    *flux.Spec   `json:"spec,omitempty"`
@@ -32,11 +37,26 @@ type QueryRequest struct {
 	Type    string       `json:"type"`
 	Dialect QueryDialect `json:"dialect"`
 
+	// Bucket, or DB and RP, are read by the measurements of influxql
+	// queries that do not specify a database.
+	Bucket string `json:"bucket,omitempty"`
+	DB     string `json:"db,omitempty"`
+	RP     string `json:"rp,omitempty"`
+
 	Org *influxdb.Organization `json:"-"`
+
+	// BucketID is the ID of Bucket in Org, and DBRPMappingService resolves
+	// the databases of influxql queries.
+	BucketID           influxdb.ID                 `json:"-"`
+	DBRPMappingService influxdb.DBRPMappingService `json:"-"`
 }
 
 // QueryDialect is the formatting options for the query response.
 type QueryDialect struct {
+	// Type is the format of the response; the other options only apply to
+	// the default annotated CSV.
+	Type string `json:"type,omitempty"`
+
 	Header         *bool    `json:"header"`
 	Delimiter      string   `json:"delimiter"`
 	CommentPrefix  string   `json:"commentPrefix"`
@@ -75,10 +95,50 @@ func (r QueryRequest) Validate() error {
 		}
 	}
 
-	if r.Type != "flux" {
+	switch r.Type {
+	case "flux":
+		if r.Bucket != "" || r.DB != "" || r.RP != "" {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "bucket, db and rp are only valid for influxql queries",
+			}
+		}
+	case "influxql":
+		if r.Query == "" || r.Spec != nil || r.AST != nil || r.Extern != nil {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "influxql queries require a query, and cannot specify a spec, AST or external declarations",
+			}
+		}
+		if r.Bucket != "" && r.DB != "" {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "influxql queries cannot specify both a bucket and a db",
+			}
+		}
+		if r.RP != "" && r.DB == "" {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "influxql queries cannot specify a rp without a db",
+			}
+		}
+	default:
 		return fmt.Errorf(`unknown query type: %s`, r.Type)
 	}
 
+	switch r.Dialect.Type {
+	case "", csv.DialectType, ndjson.DialectType, arrowipc.DialectType, lineprotocol.DialectType:
+	case iql.DialectType:
+		if r.Type != "influxql" {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "the influxql dialect is only valid for influxql queries",
+			}
+		}
+	default:
+		return fmt.Errorf(`unknown dialect type: %s`, r.Dialect.Type)
+	}
+
 	if len(r.Dialect.CommentPrefix) > 1 {
 		return fmt.Errorf("invalid dialect comment prefix: must be length 0 or 1")
 	}
@@ -222,7 +282,17 @@ func (r QueryRequest) proxyRequest(now func() time.Time) (*query.ProxyRequest, e
 	}
 	// Query is preferred over spec
 	var compiler flux.Compiler
-	if r.Query != "" {
+	if r.Type == "influxql" {
+		c := iql.NewCompiler(r.DBRPMappingService)
+		c.Cluster = influxdb.DefaultDBRPCluster
+		c.DB = r.DB
+		c.RP = r.RP
+		c.BucketID = r.BucketID
+		c.Query = r.Query
+		t := now()
+		c.Now = &t
+		compiler = c
+	} else if r.Query != "" {
 		pkg, err := flux.Parse(r.Query)
 		if err != nil {
 			return nil, err
@@ -250,28 +320,45 @@ func (r QueryRequest) proxyRequest(now func() time.Time) (*query.ProxyRequest, e
 		}
 	}
 
-	delimiter, _ := utf8.DecodeRuneInString(r.Dialect.Delimiter)
+	return &query.ProxyRequest{
+		Request: query.Request{
+			OrganizationID: r.Org.ID,
+			Compiler:       compiler,
+		},
+		Dialect: r.Dialect.dialect(),
+	}, nil
+}
+
+func (d QueryDialect) dialect() flux.Dialect {
+	switch d.Type {
+	case ndjson.DialectType:
+		return &ndjson.Dialect{}
+	case arrowipc.DialectType:
+		return &arrowipc.Dialect{}
+	case lineprotocol.DialectType:
+		return &lineprotocol.Dialect{}
+	case iql.DialectType:
+		return &iql.Dialect{
+			Encoding: iql.JSON,
+		}
+	}
+
+	delimiter, _ := utf8.DecodeRuneInString(d.Delimiter)
 
 	noHeader := false
-	if r.Dialect.Header != nil {
-		noHeader = !*r.Dialect.Header
+	if d.Header != nil {
+		noHeader = !*d.Header
 	}
 
 	// TODO(nathanielc): Use commentPrefix and dateTimeFormat
 	// once they are supported.
-	return &query.ProxyRequest{
-		Request: query.Request{
-			OrganizationID: r.Org.ID,
-			Compiler:       compiler,
-		},
-		Dialect: &csv.Dialect{
-			ResultEncoderConfig: csv.ResultEncoderConfig{
-				NoHeader:    noHeader,
-				Delimiter:   delimiter,
-				Annotations: r.Dialect.Annotations,
-			},
+	return &csv.Dialect{
+		ResultEncoderConfig: csv.ResultEncoderConfig{
+			NoHeader:    noHeader,
+			Delimiter:   delimiter,
+			Annotations: d.Annotations,
 		},
-	}, nil
+	}
 }
 
 // QueryRequestFromProxyRequest converts a query.ProxyRequest into a QueryRequest.
@@ -288,6 +375,14 @@ func QueryRequestFromProxyRequest(req *query.ProxyRequest) (*QueryRequest, error
 	case lang.ASTCompiler:
 		qr.Type = "flux"
 		qr.AST = c.AST
+	case *iql.Compiler:
+		if c.BucketID.Valid() {
+			return nil, fmt.Errorf("unsupported influxql compiler reading bucket %s", c.BucketID)
+		}
+		qr.Type = "influxql"
+		qr.Query = c.Query
+		qr.DB = c.DB
+		qr.RP = c.RP
 	default:
 		return nil, fmt.Errorf("unsupported compiler %T", c)
 	}
@@ -299,6 +394,13 @@ func QueryRequestFromProxyRequest(req *query.ProxyRequest) (*QueryRequest, error
 		qr.Dialect.CommentPrefix = "#"
 		qr.Dialect.DateTimeFormat = "RFC3339"
 		qr.Dialect.Annotations = d.ResultEncoderConfig.Annotations
+	case *ndjson.Dialect, *arrowipc.Dialect, *lineprotocol.Dialect:
+		qr.Dialect.Type = string(d.DialectType())
+	case *iql.Dialect:
+		if d.Encoding != iql.JSON {
+			return nil, fmt.Errorf("unsupported influxql dialect encoding %v", d.Encoding)
+		}
+		qr.Dialect.Type = iql.DialectType
 	default:
 		return nil, fmt.Errorf("unsupported dialect %T", d)
 	}
@@ -332,6 +434,10 @@ func decodeQueryRequest(ctx context.Context, r *http.Request, svc influxdb.Organ
 		}
 	}
 
+	if req.Dialect.Type == "" {
+		req.Dialect.Type = acceptedDialectType(r.Header.Get("Accept"), req.Type)
+	}
+
 	req = req.WithDefaults()
 	if err := req.Validate(); err != nil {
 		return nil, err
@@ -341,17 +447,111 @@ func decodeQueryRequest(ctx context.Context, r *http.Request, svc influxdb.Organ
 	return &req, err
 }
 
-func decodeProxyQueryRequest(ctx context.Context, r *http.Request, auth influxdb.Authorizer, svc influxdb.OrganizationService) (*query.ProxyRequest, error) {
+// decodeInfluxQLRequest resolves the bucket and the databases of the
+// influxql query request req.
+func decodeInfluxQLRequest(ctx context.Context, req *QueryRequest, bs influxdb.BucketService, ds influxdb.DBRPMappingService) error {
+	req.DBRPMappingService = ds
+	if req.Bucket == "" {
+		return nil
+	}
+
+	b, err := bs.FindBucket(ctx, influxdb.BucketFilter{
+		OrganizationID: &req.Org.ID,
+		Name:           &req.Bucket,
+	})
+	if err != nil {
+		return err
+	}
+	req.BucketID = b.ID
+	return nil
+}
+
+// acceptedDialectType returns the type of the dialect of the media type the
+// Accept header prefers, by quality and then by order. The 1.x JSON series
+// dialect is only accepted for influxql queries. It returns an empty type,
+// meaning annotated CSV, if the header accepts none of them.
+//
+// Since clients commonly accept text/plain along with other media types,
+// line protocol is only returned if text/plain is strictly preferred to the
+// other media types accepted.
+func acceptedDialectType(accept, queryType string) string {
+	var (
+		typ   string
+		best  float64
+		plain float64
+	)
+	for _, s := range strings.Split(accept, ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(s))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		t, ok := mediaDialectType(mt, queryType)
+		if !ok {
+			continue
+		}
+		if t == lineprotocol.DialectType {
+			if q > plain {
+				plain = q
+			}
+			continue
+		}
+		if q > best {
+			typ, best = t, q
+		}
+	}
+	if plain > best {
+		return lineprotocol.DialectType
+	}
+	return typ
+}
+
+// mediaDialectType returns the type of the dialect of the media type mt, and
+// whether the query can be encoded as mt.
+func mediaDialectType(mt, queryType string) (string, bool) {
+	switch mt {
+	case "text/csv", "text/*", "*/*":
+		return "", true
+	case ndjson.ContentType:
+		return ndjson.DialectType, true
+	case arrowipc.ContentType, "application/vnd.influx.arrow":
+		return arrowipc.DialectType, true
+	case "text/plain":
+		return lineprotocol.DialectType, true
+	case "application/json":
+		if queryType == "influxql" {
+			return iql.DialectType, true
+		}
+	}
+	return "", false
+}
+
+func decodeProxyQueryRequest(ctx context.Context, r *http.Request, auth influxdb.Authorizer, svc influxdb.OrganizationService, bs influxdb.BucketService, ds influxdb.DBRPMappingService) (*query.ProxyRequest, error) {
 	req, err := decodeQueryRequest(ctx, r, svc)
 	if err != nil {
 		return nil, err
 	}
 
+	if req.Type == "influxql" {
+		if err := decodeInfluxQLRequest(ctx, req, bs, ds); err != nil {
+			return nil, err
+		}
+	}
+
 	pr, err := req.ProxyRequest()
 	if err != nil {
 		return nil, err
 	}
 
+	if d, ok := pr.Dialect.(*iql.Dialect); ok && r.URL.Query().Get("pretty") == "true" {
+		d.Encoding = iql.JSONPretty
+	}
+
 	a, ok := auth.(*influxdb.Authorization)
 	if !ok {
 		// TODO(desa): this should go away once we're using influxdb.Authorizers everywhere.
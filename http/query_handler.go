@@ -34,7 +34,11 @@ type FluxBackend struct {
 	Logger *zap.Logger
 
 	OrganizationService platform.OrganizationService
+	BucketService       platform.BucketService
+	DBRPMappingService  platform.DBRPMappingService
 	ProxyQueryService   query.ProxyQueryService
+	OrgLimiter          platform.OrgLimiter
+	UsageRecorder       platform.UsageRecorder
 }
 
 // NewFluxBackend returns a new instance of FluxBackend.
@@ -44,10 +48,14 @@ func NewFluxBackend(b *APIBackend) *FluxBackend {
 
 		ProxyQueryService:   b.FluxService,
 		OrganizationService: b.OrganizationService,
+		BucketService:       b.BucketService,
+		DBRPMappingService:  b.DBRPMappingService,
+		OrgLimiter:          b.OrgLimiter,
+		UsageRecorder:       b.UsageRecorder,
 	}
 }
 
-// FluxHandler implements handling flux queries.
+// FluxHandler implements handling flux and influxql queries.
 type FluxHandler struct {
 	*httprouter.Router
 
@@ -55,10 +63,19 @@ type FluxHandler struct {
 
 	Now                 func() time.Time
 	OrganizationService platform.OrganizationService
+	BucketService       platform.BucketService
+	DBRPMappingService  platform.DBRPMappingService
 	ProxyQueryService   query.ProxyQueryService
+
+	// OrgLimiter limits the concurrent queries of organizations. Queries
+	// are not limited if it is nil.
+	OrgLimiter platform.OrgLimiter
+	// UsageRecorder records the usage of the queries. The usage is not
+	// recorded if it is nil.
+	UsageRecorder platform.UsageRecorder
 }
 
-// NewFluxHandler returns a new handler at /api/v2/query for flux queries.
+// NewFluxHandler returns a new handler at /api/v2/query for flux and influxql queries.
 func NewFluxHandler(b *FluxBackend) *FluxHandler {
 	h := &FluxHandler{
 		Router: NewRouter(),
@@ -67,6 +84,10 @@ func NewFluxHandler(b *FluxBackend) *FluxHandler {
 
 		ProxyQueryService:   b.ProxyQueryService,
 		OrganizationService: b.OrganizationService,
+		BucketService:       b.BucketService,
+		DBRPMappingService:  b.DBRPMappingService,
+		OrgLimiter:          b.OrgLimiter,
+		UsageRecorder:       b.UsageRecorder,
 	}
 
 	h.HandlerFunc("POST", fluxPath, h.handleQuery)
@@ -87,7 +108,7 @@ func (h *FluxHandler) handleQuery(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	req, err := decodeProxyQueryRequest(ctx, r, a, h.OrganizationService)
+	req, err := decodeProxyQueryRequest(ctx, r, a, h.OrganizationService, h.BucketService, h.DBRPMappingService)
 	if err != nil && err != platform.ErrAuthorizerNotSupported {
 		EncodeError(ctx, err, w)
 		return
@@ -98,9 +119,23 @@ func (h *FluxHandler) handleQuery(w http.ResponseWriter, r *http.Request) {
 		EncodeError(ctx, fmt.Errorf("unsupported dialect over HTTP %T", req.Dialect), w)
 		return
 	}
+
+	if h.OrgLimiter != nil && req.Request.OrganizationID.Valid() {
+		done, err := h.OrgLimiter.LimitQuery(ctx, req.Request.OrganizationID)
+		if err != nil {
+			EncodeError(ctx, err, w)
+			return
+		}
+		defer done()
+	}
+
 	hd.SetHeaders(w)
 
 	n, err := h.ProxyQueryService.Query(ctx, w, req)
+	if orgID := req.Request.OrganizationID; orgID.Valid() {
+		recordUsage(ctx, h.UsageRecorder, orgID, nil, platform.UsageQueryRequestCount, 1)
+		recordUsage(ctx, h.UsageRecorder, orgID, nil, platform.UsageQueryRequestBytes, n)
+	}
 	if err != nil {
 		if n == 0 {
 			// Only record the error headers IFF nothing has been written to w.
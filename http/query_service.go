@@ -4,12 +4,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"mime"
 	"net/http"
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/csv"
 	icontext "github.com/influxdata/influxdb/context"
 	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/arrowipc"
+	"github.com/influxdata/influxdb/query/lineprotocol"
+	"github.com/influxdata/influxdb/query/ndjson"
 	"github.com/julienschmidt/httprouter"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -82,22 +86,24 @@ func (h *QueryHandler) handlePostQuery(w http.ResponseWriter, r *http.Request) {
 	// As such we rely on the http.ResponseWriter behavior
 	// to write an StatusOK header with the first write.
 
-	switch r.Header.Get("Accept") {
-	case "text/csv":
-		fallthrough
-	default:
-		h.csvDialect.SetHeaders(w)
-		encoder := h.csvDialect.Encoder()
-		n, err := encoder.Encode(w, results)
-		if err != nil {
-			if n == 0 {
-				// If the encoder did not write anything, we can write an error header.
-				EncodeError(ctx, err, w)
-			} else {
-				h.Logger.Info("Failed to encode client response",
-					zap.Error(err),
-				)
-			}
+	var dialect flux.Dialect = &h.csvDialect
+	if t := acceptedDialectType(r.Header.Get("Accept"), "flux"); t != "" {
+		dialect = QueryDialect{Type: t}.dialect()
+	}
+
+	if hd, ok := dialect.(HTTPDialect); ok {
+		hd.SetHeaders(w)
+	}
+	encoder := dialect.Encoder()
+	n, err := encoder.Encode(w, results)
+	if err != nil {
+		if n == 0 {
+			// If the encoder did not write anything, we can write an error header.
+			EncodeError(ctx, err, w)
+		} else {
+			h.Logger.Info("Failed to encode client response",
+				zap.Error(err),
+			)
 		}
 	}
 
@@ -121,6 +127,10 @@ type QueryService struct {
 	Addr               string
 	Token              string
 	InsecureSkipVerify bool
+
+	// Dialect is the type of the dialect of the responses to request;
+	// it defaults to annotated CSV.
+	Dialect flux.DialectType
 }
 
 // Ping checks to see if the server is responding to a ping request.
@@ -170,6 +180,7 @@ func (s *QueryService) Query(ctx context.Context, req *query.Request) (flux.Resu
 	}
 
 	SetToken(token, hreq)
+	hreq.Header.Set("Accept", dialectContentType(s.Dialect))
 	hreq = hreq.WithContext(ctx)
 
 	hc := newClient(u.Scheme, s.InsecureSkipVerify)
@@ -182,7 +193,14 @@ func (s *QueryService) Query(ctx context.Context, req *query.Request) (flux.Resu
 	}
 
 	var decoder flux.MultiResultDecoder
-	switch resp.Header.Get("Content-Type") {
+	mt, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch mt {
+	case ndjson.ContentType:
+		decoder = ndjson.NewMultiResultDecoder()
+	case arrowipc.ContentType:
+		decoder = arrowipc.NewMultiResultDecoder()
+	case "text/plain":
+		decoder = lineprotocol.NewMultiResultDecoder()
 	case "text/csv":
 		fallthrough
 	default:
@@ -200,6 +218,19 @@ func (s *QueryService) Query(ctx context.Context, req *query.Request) (flux.Resu
 	return statResults, nil
 }
 
+// dialectContentType returns the media type of the responses of the dialect t.
+func dialectContentType(t flux.DialectType) string {
+	switch t {
+	case ndjson.DialectType:
+		return ndjson.ContentType
+	case arrowipc.DialectType:
+		return arrowipc.ContentType
+	case lineprotocol.DialectType:
+		return "text/plain"
+	}
+	return "text/csv"
+}
+
 // statsResultIterator implements flux.ResultIterator and flux.Statisticser by reading the HTTP trailers.
 type statsResultIterator struct {
 	results    flux.ResultIterator
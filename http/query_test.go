@@ -20,6 +20,7 @@ import (
 	"github.com/influxdata/influxdb/mock"
 	"github.com/influxdata/influxdb/query"
 	_ "github.com/influxdata/influxdb/query/builtin"
+	iql "github.com/influxdata/influxdb/query/influxql"
 )
 
 var cmpOptions = cmp.Options{
@@ -461,6 +462,80 @@ func Test_decodeQueryRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "dialect type from accept header",
+			args: args{
+				r: func() *http.Request {
+					r := httptest.NewRequest("POST", "/", bytes.NewBufferString(`{"query": "from()"}`))
+					r.Header.Set("Accept", "application/x-ndjson, text/csv;q=0.5")
+					return r
+				}(),
+				svc: &mock.OrganizationService{
+					FindOrganizationF: func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+						return &platform.Organization{
+							ID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+						}, nil
+					},
+				},
+			},
+			want: &QueryRequest{
+				Query: "from()",
+				Type:  "flux",
+				Dialect: QueryDialect{
+					Type:           "ndjson",
+					Delimiter:      ",",
+					DateTimeFormat: "RFC3339",
+					Header:         func(x bool) *bool { return &x }(true),
+				},
+				Org: &platform.Organization{
+					ID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+				},
+			},
+		},
+		{
+			name: "dialect type overrides accept header",
+			args: args{
+				r: func() *http.Request {
+					r := httptest.NewRequest("POST", "/", bytes.NewBufferString(`{"query": "from()", "dialect": {"type": "arrow"}}`))
+					r.Header.Set("Accept", "text/plain")
+					return r
+				}(),
+				svc: &mock.OrganizationService{
+					FindOrganizationF: func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+						return &platform.Organization{
+							ID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+						}, nil
+					},
+				},
+			},
+			want: &QueryRequest{
+				Query: "from()",
+				Type:  "flux",
+				Dialect: QueryDialect{
+					Type:           "arrow",
+					Delimiter:      ",",
+					DateTimeFormat: "RFC3339",
+					Header:         func(x bool) *bool { return &x }(true),
+				},
+				Org: &platform.Organization{
+					ID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+				},
+			},
+		},
+		{
+			name: "error influxql dialect of flux query",
+			args: args{
+				r: httptest.NewRequest("POST", "/", bytes.NewBufferString(`{"query": "from()", "dialect": {"type": "influxql"}}`)),
+			},
+			wantErr: true,
+		},
+		{
+			name: "error unknown dialect type",
+			args: args{
+				r: httptest.NewRequest("POST", "/", bytes.NewBufferString(`{"query": "from()", "dialect": {"type": "xml"}}`)),
+			},
+			wantErr: true,
+		},
 		{
 			name: "error decoding json",
 			args: args{
@@ -496,6 +571,7 @@ func Test_decodeProxyQueryRequest(t *testing.T) {
 		r    *http.Request
 		auth *platform.Authorization
 		svc  platform.OrganizationService
+		bs   platform.BucketService
 	}
 	tests := []struct {
 		name    string
@@ -680,11 +756,63 @@ func Test_decodeProxyQueryRequest(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "valid influxql query request of a bucket",
+			args: args{
+				r: func() *http.Request {
+					r := httptest.NewRequest("POST", "/", bytes.NewBufferString(`{"type": "influxql", "query": "SELECT value FROM cpu", "bucket": "telegraf"}`))
+					r.Header.Set("Accept", "application/json")
+					return r
+				}(),
+				svc: &mock.OrganizationService{
+					FindOrganizationF: func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+						return &platform.Organization{
+							ID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+						}, nil
+					},
+				},
+				bs: &mock.BucketService{
+					FindBucketFn: func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+						if *filter.Name != "telegraf" {
+							return nil, &platform.Error{Code: platform.ENotFound, Msg: "bucket not found"}
+						}
+						return &platform.Bucket{
+							ID:             func() platform.ID { s, _ := platform.IDFromString("aaaaaaaaaaaaaaaa"); return *s }(),
+							OrganizationID: *filter.OrganizationID,
+						}, nil
+					},
+				},
+			},
+			want: &query.ProxyRequest{
+				Request: query.Request{
+					OrganizationID: func() platform.ID { s, _ := platform.IDFromString("deadbeefdeadbeef"); return *s }(),
+					Compiler: &iql.Compiler{
+						Cluster:  platform.DefaultDBRPCluster,
+						Query:    "SELECT value FROM cpu",
+						BucketID: func() platform.ID { s, _ := platform.IDFromString("aaaaaaaaaaaaaaaa"); return *s }(),
+					},
+				},
+				Dialect: &iql.Dialect{
+					Encoding: iql.JSON,
+				},
+			},
+		},
+		{
+			name: "influxql query request with a bucket and a db",
+			args: args{
+				r: httptest.NewRequest("POST", "/", bytes.NewBufferString(`{"type": "influxql", "query": "SELECT value FROM cpu", "bucket": "telegraf", "db": "telegraf"}`)),
+			},
+			wantErr: true,
+		},
 	}
-	cmpOptions := append(cmpOptions, cmpopts.IgnoreFields(lang.ASTCompiler{}, "Now"))
+	cmpOptions := append(cmpOptions,
+		cmpopts.IgnoreFields(lang.ASTCompiler{}, "Now"),
+		cmpopts.IgnoreFields(iql.Compiler{}, "Now"),
+		cmpopts.IgnoreUnexported(iql.Compiler{}),
+	)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := decodeProxyQueryRequest(tt.args.ctx, tt.args.r, tt.args.auth, tt.args.svc)
+			got, err := decodeProxyQueryRequest(tt.args.ctx, tt.args.r, tt.args.auth, tt.args.svc, tt.args.bs, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("decodeProxyQueryRequest() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -695,3 +823,32 @@ func Test_decodeProxyQueryRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestAcceptedDialectType(t *testing.T) {
+	tests := []struct {
+		accept    string
+		queryType string
+		want      string
+	}{
+		{accept: "", want: ""},
+		{accept: "text/csv", want: ""},
+		{accept: "application/x-ndjson", want: "ndjson"},
+		{accept: "text/plain", want: "lineprotocol"},
+		{accept: "text/csv, text/plain", want: ""},
+		{accept: "text/plain;q=0.5, text/csv", want: ""},
+		{accept: "text/csv;q=0.5, text/plain", want: "lineprotocol"},
+		{accept: "text/plain;q=0, */*", want: ""},
+		{accept: "application/x-ndjson;q=0.2, application/vnd.apache.arrow.stream;q=0.8", want: "arrow"},
+		{accept: "application/json", queryType: "flux", want: ""},
+		{accept: "application/json", queryType: "influxql", want: "influxql"},
+		{accept: "application/json, text/csv;q=0.9", queryType: "flux", want: ""},
+		{accept: "application/json, text/plain, */*", queryType: "flux", want: ""},
+		{accept: "text/plain, application/x-ndjson", want: "ndjson"},
+		{accept: "text/plain, */*;q=0.8", want: "lineprotocol"},
+	}
+	for _, tt := range tests {
+		if got := acceptedDialectType(tt.accept, tt.queryType); got != tt.want {
+			t.Errorf("acceptedDialectType(%q, %q) = %q, want %q", tt.accept, tt.queryType, got, tt.want)
+		}
+	}
+}
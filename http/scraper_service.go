@@ -249,6 +249,12 @@ func decodeScraperTargetUpdateRequest(ctx context.Context, r *http.Request) (*in
 	if err := json.NewDecoder(r.Body).Decode(update); err != nil {
 		return nil, err
 	}
+	// The type may be left out of an update of the other options of a target.
+	if update.Type != "" {
+		if err := update.Validate(); err != nil {
+			return nil, err
+		}
+	}
 	id, err := decodeScraperTargetIDRequest(ctx, r)
 	if err != nil {
 		return nil, err
@@ -262,6 +268,9 @@ func decodeScraperTargetAddRequest(ctx context.Context, r *http.Request) (*influ
 	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
 		return nil, err
 	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	return req, nil
 }
 
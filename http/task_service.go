@@ -81,6 +81,8 @@ const (
 	tasksIDRunsIDRetryPath = "/api/v2/tasks/:id/runs/:rid/retry"
 	tasksIDLabelsPath      = "/api/v2/tasks/:id/labels"
 	tasksIDLabelsIDPath    = "/api/v2/tasks/:id/labels/:lid"
+	tasksIDBackfillsPath   = "/api/v2/tasks/:id/backfills"
+	tasksIDBackfillsIDPath = "/api/v2/tasks/:id/backfills/:bid"
 )
 
 // NewTaskHandler returns a new instance of TaskHandler.
@@ -136,6 +138,10 @@ func NewTaskHandler(b *TaskBackend) *TaskHandler {
 	h.HandlerFunc("POST", tasksIDRunsIDRetryPath, h.handleRetryRun)
 	h.HandlerFunc("DELETE", tasksIDRunsIDPath, h.handleCancelRun)
 
+	h.HandlerFunc("GET", tasksIDBackfillsPath, h.handleGetBackfills)
+	h.HandlerFunc("POST", tasksIDBackfillsPath, h.handlePostBackfill)
+	h.HandlerFunc("DELETE", tasksIDBackfillsIDPath, h.handleCancelBackfill)
+
 	labelBackend := &LabelBackend{
 		Logger:       b.Logger.With(zap.String("handler", "label")),
 		LabelService: b.LabelService,
@@ -257,6 +263,43 @@ func newRunsResponse(rs []*platform.Run, taskID platform.ID) runsResponse {
 	return r
 }
 
+type backfillResponse struct {
+	Links map[string]string `json:"links,omitempty"`
+	platform.Backfill
+}
+
+func newBackfillResponse(b platform.Backfill) backfillResponse {
+	return backfillResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/backfills/%s", b.TaskID, b.ID),
+			"task": fmt.Sprintf("/api/v2/tasks/%s", b.TaskID),
+			"runs": fmt.Sprintf("/api/v2/tasks/%s/runs", b.TaskID),
+		},
+		Backfill: b,
+	}
+}
+
+type backfillsResponse struct {
+	Links     map[string]string   `json:"links"`
+	Backfills []*backfillResponse `json:"backfills"`
+}
+
+func newBackfillsResponse(bs []*platform.Backfill, taskID platform.ID) backfillsResponse {
+	r := backfillsResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/backfills", taskID),
+			"task": fmt.Sprintf("/api/v2/tasks/%s", taskID),
+		},
+		Backfills: make([]*backfillResponse, len(bs)),
+	}
+
+	for i := range bs {
+		b := newBackfillResponse(*bs[i])
+		r.Backfills[i] = &b
+	}
+	return r
+}
+
 func (h *TaskHandler) handleGetTasks(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -1289,6 +1332,212 @@ func decodeRetryRunRequest(ctx context.Context, r *http.Request) (*retryRunReque
 	}, nil
 }
 
+func (h *TaskHandler) handlePostBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodePostBackfillRequest(ctx, r)
+	if err != nil {
+		err = &platform.Error{
+			Err:  err,
+			Code: platform.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	b, err := h.TaskService.CreateBackfill(ctx, req.TaskID, req.Start, req.Stop)
+	if err != nil {
+		err := &platform.Error{
+			Err: err,
+			Msg: "failed to create backfill",
+		}
+		if err.Err == backend.ErrTaskNotFound {
+			err.Code = platform.ENotFound
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+	if err := encodeResponse(ctx, w, http.StatusCreated, newBackfillResponse(*b)); err != nil {
+		logEncodingError(h.logger, r, err)
+		return
+	}
+}
+
+type postBackfillRequest struct {
+	TaskID platform.ID
+	Start  int64
+	Stop   int64
+}
+
+func decodePostBackfillRequest(ctx context.Context, r *http.Request) (*postBackfillRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	tid := params.ByName("id")
+	if tid == "" {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "you must provide a task ID",
+		}
+	}
+
+	var ti platform.ID
+	if err := ti.DecodeFromString(tid); err != nil {
+		return nil, err
+	}
+
+	var req struct {
+		Start string `json:"start"`
+		Stop  string `json:"stop"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, err
+	}
+
+	start, err := time.Parse(time.RFC3339, req.Start)
+	if err != nil {
+		return nil, err
+	}
+
+	// The task service defaults a stop of zero to the current time.
+	var stop int64
+	if req.Stop != "" {
+		t, err := time.Parse(time.RFC3339, req.Stop)
+		if err != nil {
+			return nil, err
+		}
+		stop = t.Unix()
+	}
+
+	return &postBackfillRequest{
+		TaskID: ti,
+		Start:  start.Unix(),
+		Stop:   stop,
+	}, nil
+}
+
+func (h *TaskHandler) handleGetBackfills(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetBackfillsRequest(ctx, r)
+	if err != nil {
+		err = &platform.Error{
+			Err:  err,
+			Code: platform.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	bs, _, err := h.TaskService.FindBackfills(ctx, req.TaskID)
+	if err != nil {
+		err := &platform.Error{
+			Err: err,
+			Msg: "failed to find backfills",
+		}
+		if err.Err == backend.ErrTaskNotFound {
+			err.Code = platform.ENotFound
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, newBackfillsResponse(bs, req.TaskID)); err != nil {
+		logEncodingError(h.logger, r, err)
+		return
+	}
+}
+
+type getBackfillsRequest struct {
+	TaskID platform.ID
+}
+
+func decodeGetBackfillsRequest(ctx context.Context, r *http.Request) (*getBackfillsRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	tid := params.ByName("id")
+	if tid == "" {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "you must provide a task ID",
+		}
+	}
+
+	var ti platform.ID
+	if err := ti.DecodeFromString(tid); err != nil {
+		return nil, err
+	}
+
+	return &getBackfillsRequest{
+		TaskID: ti,
+	}, nil
+}
+
+func (h *TaskHandler) handleCancelBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeCancelBackfillRequest(ctx, r)
+	if err != nil {
+		err = &platform.Error{
+			Err:  err,
+			Code: platform.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	err = h.TaskService.CancelBackfill(ctx, req.TaskID, req.BackfillID)
+	if err != nil {
+		err := &platform.Error{
+			Err: err,
+			Msg: "failed to cancel backfill",
+		}
+		if err.Err == backend.ErrTaskNotFound {
+			err.Code = platform.ENotFound
+		}
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type cancelBackfillRequest struct {
+	TaskID     platform.ID
+	BackfillID platform.ID
+}
+
+func decodeCancelBackfillRequest(ctx context.Context, r *http.Request) (*cancelBackfillRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	tid := params.ByName("id")
+	if tid == "" {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "you must provide a task ID",
+		}
+	}
+	bid := params.ByName("bid")
+	if bid == "" {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "you must provide a backfill ID",
+		}
+	}
+
+	var t platform.ID
+	if err := t.DecodeFromString(tid); err != nil {
+		return nil, err
+	}
+	var b platform.ID
+	if err := b.DecodeFromString(bid); err != nil {
+		return nil, err
+	}
+
+	return &cancelBackfillRequest{
+		TaskID:     t,
+		BackfillID: b,
+	}, nil
+}
+
 func (h *TaskHandler) populateTaskCreateOrg(ctx context.Context, tc *platform.TaskCreate) error {
 	if tc.OrganizationID.Valid() && tc.Organization != "" {
 		return nil
@@ -1805,6 +2054,113 @@ func (t TaskService) CancelRun(ctx context.Context, taskID, runID platform.ID) e
 	return nil
 }
 
+// CreateBackfill queues runs of the task for its schedules between start and stop.
+func (t TaskService) CreateBackfill(ctx context.Context, taskID platform.ID, start, stop int64) (*platform.Backfill, error) {
+	u, err := newURL(t.Addr, taskIDBackfillsPath(taskID))
+	if err != nil {
+		return nil, err
+	}
+
+	// A stop of zero is left to the server, whose clock defines the current time.
+	body := map[string]string{
+		"start": time.Unix(start, 0).UTC().Format(time.RFC3339),
+	}
+	if stop != 0 {
+		body["stop"] = time.Unix(stop, 0).UTC().Format(time.RFC3339)
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", u.String(), bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, err
+	}
+
+	var br backfillResponse
+	if err := json.NewDecoder(resp.Body).Decode(&br); err != nil {
+		return nil, err
+	}
+	return &br.Backfill, nil
+}
+
+// FindBackfills returns the unfinished backfills of a task.
+func (t TaskService) FindBackfills(ctx context.Context, taskID platform.ID) ([]*platform.Backfill, int, error) {
+	u, err := newURL(t.Addr, taskIDBackfillsPath(taskID))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return nil, 0, err
+	}
+
+	var br backfillsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&br); err != nil {
+		return nil, 0, err
+	}
+
+	bs := make([]*platform.Backfill, len(br.Backfills))
+	for i := range br.Backfills {
+		bs[i] = &br.Backfills[i].Backfill
+	}
+	return bs, len(bs), nil
+}
+
+// CancelBackfill cancels the runs of a backfill.
+func (t TaskService) CancelBackfill(ctx context.Context, taskID, backfillID platform.ID) error {
+	u, err := newURL(t.Addr, taskIDBackfillIDPath(taskID, backfillID))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("DELETE", u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	SetToken(t.Token, req)
+
+	hc := newClient(u.Scheme, t.InsecureSkipVerify)
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return CheckError(resp)
+}
+
 func taskIDPath(id platform.ID) string {
 	return path.Join(tasksPath, id.String())
 }
@@ -1816,3 +2172,11 @@ func taskIDRunsPath(id platform.ID) string {
 func taskIDRunIDPath(taskID, runID platform.ID) string {
 	return path.Join(tasksPath, taskID.String(), "runs", runID.String())
 }
+
+func taskIDBackfillsPath(id platform.ID) string {
+	return path.Join(tasksPath, id.String(), "backfills")
+}
+
+func taskIDBackfillIDPath(taskID, backfillID platform.ID) string {
+	return path.Join(tasksPath, taskID.String(), "backfills", backfillID.String())
+}
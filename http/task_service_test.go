@@ -13,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	platform "github.com/influxdata/influxdb"
 	pcontext "github.com/influxdata/influxdb/context"
 	"github.com/influxdata/influxdb/inmem"
@@ -678,7 +679,7 @@ func TestTaskHandler_NotFoundStatus(t *testing.T) {
 	// Create a session to associate with the contexts, so authorization checks pass.
 	authz := &platform.Authorization{Permissions: platform.OperPermissions()}
 
-	const taskID, runID = platform.ID(0xCCCCCC), platform.ID(0xAAAAAA)
+	const taskID, runID, backfillID = platform.ID(0xCCCCCC), platform.ID(0xAAAAAA), platform.ID(0xBBBBBB)
 
 	var (
 		okTask    = []interface{}{taskID}
@@ -692,6 +693,12 @@ func TestTaskHandler_NotFoundStatus(t *testing.T) {
 			{taskID + 1, runID},
 			{taskID + 1, runID + 1},
 		}
+
+		okTaskBackfill       = []interface{}{taskID, backfillID}
+		notFoundTaskBackfill = [][]interface{}{
+			{taskID, backfillID + 1},
+			{taskID + 1, backfillID},
+		}
 	)
 
 	tcs := []struct {
@@ -893,6 +900,58 @@ func TestTaskHandler_NotFoundStatus(t *testing.T) {
 			okPathArgs:       okTaskRun,
 			notFoundPathArgs: notFoundTaskRun,
 		},
+		{
+			name: "create backfill",
+			svc: &mock.TaskService{
+				CreateBackfillFn: func(_ context.Context, tid platform.ID, start, stop int64) (*platform.Backfill, error) {
+					if tid != taskID {
+						return nil, backend.ErrTaskNotFound
+					}
+
+					return &platform.Backfill{ID: backfillID, TaskID: taskID, Runs: 2}, nil
+				},
+			},
+			method:           http.MethodPost,
+			body:             `{"start": "2019-01-01T00:00:00Z", "stop": "2019-01-01T01:00:00Z"}`,
+			pathFmt:          "/tasks/%s/backfills",
+			okPathArgs:       okTask,
+			notFoundPathArgs: notFoundTask,
+		},
+		{
+			name: "get backfills",
+			svc: &mock.TaskService{
+				FindBackfillsFn: func(_ context.Context, tid platform.ID) ([]*platform.Backfill, int, error) {
+					if tid != taskID {
+						return nil, 0, backend.ErrTaskNotFound
+					}
+
+					return []*platform.Backfill{{ID: backfillID, TaskID: taskID, Runs: 2}}, 1, nil
+				},
+			},
+			method:           http.MethodGet,
+			pathFmt:          "/tasks/%s/backfills",
+			okPathArgs:       okTask,
+			notFoundPathArgs: notFoundTask,
+		},
+		{
+			name: "cancel backfill",
+			svc: &mock.TaskService{
+				CancelBackfillFn: func(_ context.Context, tid, bid platform.ID) error {
+					if tid != taskID {
+						return backend.ErrTaskNotFound
+					}
+					if bid != backfillID {
+						return &platform.Error{Code: platform.ENotFound, Msg: "backfill not found"}
+					}
+
+					return nil
+				},
+			},
+			method:           http.MethodDelete,
+			pathFmt:          "/tasks/%s/backfills/%s",
+			okPathArgs:       okTaskBackfill,
+			notFoundPathArgs: notFoundTaskBackfill,
+		},
 	}
 
 	for _, tc := range tcs {
@@ -1604,3 +1663,45 @@ func TestTaskHandler_Sessions(t *testing.T) {
 		}
 	})
 }
+
+func TestTaskService_CreateBackfill(t *testing.T) {
+	const taskID = platform.ID(0xCCCCCC)
+	start := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	stop := start.Add(time.Hour)
+
+	for _, tt := range []struct {
+		name string
+		stop int64
+		exp  map[string]string
+	}{
+		{
+			name: "stop",
+			stop: stop.Unix(),
+			exp:  map[string]string{"start": "2019-06-01T00:00:00Z", "stop": "2019-06-01T01:00:00Z"},
+		},
+		{
+			name: "stop defaults to the time of the server",
+			exp:  map[string]string{"start": "2019-06-01T00:00:00Z"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("failed to decode request: %v", err)
+				}
+				w.WriteHeader(http.StatusCreated)
+				json.NewEncoder(w).Encode(newBackfillResponse(platform.Backfill{ID: 1, TaskID: taskID}))
+			}))
+			defer ts.Close()
+
+			s := TaskService{Addr: ts.URL}
+			if _, err := s.CreateBackfill(context.Background(), taskID, start.Unix(), tt.stop); err != nil {
+				t.Fatal(err)
+			}
+			if !cmp.Equal(body, tt.exp) {
+				t.Errorf("unexpected request body -got/+exp\n%s", cmp.Diff(body, tt.exp))
+			}
+		})
+	}
+}
@@ -27,6 +27,23 @@ func GetToken(r *http.Request) (string, error) {
 	return header[len(tokenScheme):], nil
 }
 
+// GetV1Token will parse the token from a request to the 1.x compatible API.
+// Besides the Authorization Header, the token may be passed as the 1.x password,
+// either with the p query parameter or with basic authentication.
+func GetV1Token(r *http.Request) (string, error) {
+	t, err := GetToken(r)
+	if err == nil {
+		return t, nil
+	}
+	if p := r.URL.Query().Get("p"); p != "" {
+		return p, nil
+	}
+	if _, p, ok := r.BasicAuth(); ok && p != "" {
+		return p, nil
+	}
+	return "", err
+}
+
 // SetToken adds the token to the request.
 func SetToken(token string, req *http.Request) {
 	req.Header.Set("Authorization", fmt.Sprintf("%s%s", tokenScheme, token))
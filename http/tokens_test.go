@@ -76,6 +76,65 @@ func TestGetToken(t *testing.T) {
 
 }
 
+func TestGetV1Token(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    string
+		header string
+		basic  []string
+		err    error
+		result string
+	}{
+		{
+			name: "no credentials",
+			url:  "/query?q=SHOW+DATABASES",
+			err:  ErrAuthHeaderMissing,
+		},
+		{
+			name:   "token header",
+			url:    "/query",
+			header: "Token tok1",
+			result: "tok1",
+		},
+		{
+			name:   "password query parameter",
+			url:    "/write?db=telegraf&u=me&p=tok2",
+			result: "tok2",
+		},
+		{
+			name:   "basic auth password",
+			url:    "/write?db=telegraf",
+			basic:  []string{"me", "tok3"},
+			result: "tok3",
+		},
+		{
+			name:  "basic auth without password",
+			url:   "/write?db=telegraf",
+			basic: []string{"me", ""},
+			err:   ErrAuthBadScheme,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.url, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			if tt.basic != nil {
+				req.SetBasicAuth(tt.basic[0], tt.basic[1])
+			}
+			result, err := GetV1Token(req)
+			if err != tt.err {
+				t.Errorf("err incorrect want %v, got %v", tt.err, err)
+				return
+			}
+			if result != tt.result {
+				t.Errorf("result incorrect want %s, got %s", tt.result, result)
+			}
+		})
+	}
+}
+
 func TestSetToken(t *testing.T) {
 	tests := []struct {
 		name  string
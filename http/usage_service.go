@@ -7,10 +7,28 @@ import (
 	"time"
 
 	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
 	"github.com/julienschmidt/httprouter"
 	"go.uber.org/zap"
 )
 
+// UsageBackend is all services and associated parameters required to construct
+// the UsageHandler.
+type UsageBackend struct {
+	Logger *zap.Logger
+
+	UsageService platform.UsageService
+}
+
+// NewUsageBackend returns a new instance of UsageBackend.
+func NewUsageBackend(b *APIBackend) *UsageBackend {
+	return &UsageBackend{
+		Logger: b.Logger.With(zap.String("handler", "usage")),
+
+		UsageService: b.UsageService,
+	}
+}
+
 // UsageHandler represents an HTTP API handler for usages.
 type UsageHandler struct {
 	*httprouter.Router
@@ -21,10 +39,12 @@ type UsageHandler struct {
 }
 
 // NewUsageHandler returns a new instance of UsageHandler.
-func NewUsageHandler() *UsageHandler {
+func NewUsageHandler(b *UsageBackend) *UsageHandler {
 	h := &UsageHandler{
 		Router: NewRouter(),
-		Logger: zap.NewNop(),
+		Logger: b.Logger,
+
+		UsageService: b.UsageService,
 	}
 
 	h.HandlerFunc("GET", "/api/v2/usage", h.handleGetUsage)
@@ -35,6 +55,15 @@ func NewUsageHandler() *UsageHandler {
 func (h *UsageHandler) handleGetUsage(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	if h.UsageService == nil {
+		EncodeError(ctx, &platform.Error{
+			Code: platform.ENotFound,
+			Op:   "http/handleGetUsage",
+			Msg:  "usage is not recorded",
+		}, w)
+		return
+	}
+
 	req, err := decodeGetUsageRequest(ctx, r)
 	if err != nil {
 		EncodeError(ctx, err, w)
@@ -127,3 +156,40 @@ func roundToMonth(t time.Time) time.Time {
 
 	return t.Add(-1 * delta).Round(time.Minute)
 }
+
+// recordUsage records the usage n of the metric for the organization and
+// bucket with r, unless r is nil.
+func recordUsage(ctx context.Context, r platform.UsageRecorder, orgID platform.ID, bucketID *platform.ID, metric platform.UsageMetric, n int64) {
+	if r == nil {
+		return
+	}
+	r.RecordUsage(ctx, platform.Usage{
+		OrganizationID: &orgID,
+		BucketID:       bucketID,
+		Type:           metric,
+		Value:          float64(n),
+	})
+}
+
+// recordWrittenUsage records the values of the exploded points written to the
+// bucket with r, except for the dropped keys. The series of the bucket are
+// not counted per write, as most of the series written already exist; the
+// usage recorder reports them from the storage engine instead.
+func recordWrittenUsage(ctx context.Context, r platform.UsageRecorder, orgID, bucketID platform.ID, exploded []models.Point, dropped [][]byte) {
+	if r == nil {
+		return
+	}
+
+	skip := make(map[string]bool, len(dropped))
+	for _, key := range dropped {
+		skip[string(key)] = true
+	}
+	var values int64
+	for _, p := range exploded {
+		if !skip[string(p.Key())] {
+			values++
+		}
+	}
+
+	recordUsage(ctx, r, orgID, &bucketID, platform.UsageValues, values)
+}
@@ -0,0 +1,203 @@
+package http
+
+import (
+	"context"
+	"mime"
+	"net/http"
+
+	platform "github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/influxql"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// V1QueryBackend is all services and associated parameters required to construct
+// the V1QueryHandler.
+type V1QueryBackend struct {
+	Logger *zap.Logger
+
+	ProxyQueryService  query.ProxyQueryService
+	DBRPMappingService platform.DBRPMappingService
+	OrgLimiter         platform.OrgLimiter
+	UsageRecorder      platform.UsageRecorder
+}
+
+// NewV1QueryBackend returns a new instance of V1QueryBackend.
+func NewV1QueryBackend(b *APIBackend) *V1QueryBackend {
+	return &V1QueryBackend{
+		Logger: b.Logger.With(zap.String("handler", "v1_query")),
+
+		ProxyQueryService:  b.FluxService,
+		DBRPMappingService: b.DBRPMappingService,
+		OrgLimiter:         b.OrgLimiter,
+		UsageRecorder:      b.UsageRecorder,
+	}
+}
+
+// V1QueryHandler transpiles InfluxQL queries received on the influxdb 1.x
+// compatible /query endpoint and responds in the 1.x JSON or CSV formats.
+type V1QueryHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	ProxyQueryService  query.ProxyQueryService
+	DBRPMappingService platform.DBRPMappingService
+	OrgLimiter         platform.OrgLimiter
+	UsageRecorder      platform.UsageRecorder
+}
+
+const (
+	v1QueryPath = "/query"
+)
+
+// NewV1QueryHandler returns a new handler at /query for InfluxQL queries.
+func NewV1QueryHandler(b *V1QueryBackend) *V1QueryHandler {
+	h := &V1QueryHandler{
+		Router: NewRouter(),
+		Logger: b.Logger,
+
+		ProxyQueryService:  b.ProxyQueryService,
+		DBRPMappingService: b.DBRPMappingService,
+		OrgLimiter:         b.OrgLimiter,
+		UsageRecorder:      b.UsageRecorder,
+	}
+
+	h.HandlerFunc("GET", v1QueryPath, h.handleQuery)
+	h.HandlerFunc("POST", v1QueryPath, h.handleQuery)
+	return h
+}
+
+func (h *V1QueryHandler) handleQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		encodeV1Error(ctx, err, w)
+		return
+	}
+
+	auth, ok := a.(*platform.Authorization)
+	if !ok {
+		encodeV1Error(ctx, &platform.Error{
+			Code: platform.EUnauthorized,
+			Op:   "http/handleV1Query",
+			Msg:  "a token is required for the 1.x query API",
+		}, w)
+		return
+	}
+
+	req, err := decodeV1QueryRequest(ctx, r, auth, h.DBRPMappingService)
+	if err != nil {
+		encodeV1Error(ctx, err, w)
+		return
+	}
+
+	if h.OrgLimiter != nil && req.Request.OrganizationID.Valid() {
+		done, err := h.OrgLimiter.LimitQuery(ctx, req.Request.OrganizationID)
+		if err != nil {
+			encodeV1Error(ctx, err, w)
+			return
+		}
+		defer done()
+	}
+
+	d := req.Dialect.(*influxql.Dialect)
+	d.SetHeaders(w)
+
+	n, err := h.ProxyQueryService.Query(ctx, w, req)
+	if orgID := req.Request.OrganizationID; orgID.Valid() {
+		recordUsage(ctx, h.UsageRecorder, orgID, nil, platform.UsageQueryRequestCount, 1)
+		recordUsage(ctx, h.UsageRecorder, orgID, nil, platform.UsageQueryRequestBytes, n)
+	}
+	if err != nil {
+		if n == 0 {
+			// Only record the error headers IFF nothing has been written to w.
+			encodeV1Error(ctx, err, w)
+			return
+		}
+		h.Logger.Info("Error writing response to client",
+			zap.String("handler", "v1_query"),
+			zap.Error(err),
+		)
+	}
+}
+
+// decodeV1QueryRequest decodes the parameters of a 1.x query into a proxy request
+// for the influxql compiler. The organization of the request is the one the
+// database is mapped to, or the one of the authorization without a database.
+func decodeV1QueryRequest(ctx context.Context, r *http.Request, auth *platform.Authorization, svc platform.DBRPMappingService) (*query.ProxyRequest, error) {
+	q := r.FormValue("q")
+	if q == "" {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/decodeV1QueryRequest",
+			Msg:  `missing required parameter "q"`,
+		}
+	}
+
+	db, rp := r.FormValue("db"), r.FormValue("rp")
+
+	orgID := auth.OrgID
+	if db != "" {
+		mapping, err := findDBRPMapping(ctx, svc, db, rp)
+		if err != nil {
+			return nil, err
+		}
+		orgID = mapping.OrganizationID
+	}
+
+	dialect := &influxql.Dialect{
+		Encoding: influxql.JSON,
+	}
+
+	if mt, _, err := mime.ParseMediaType(r.Header.Get("Accept")); err == nil {
+		switch mt {
+		case "application/csv", "text/csv":
+			dialect.Encoding = influxql.CSV
+		}
+	}
+	if dialect.Encoding == influxql.JSON && r.FormValue("pretty") == "true" {
+		dialect.Encoding = influxql.JSONPretty
+	}
+
+	switch epoch := r.FormValue("epoch"); epoch {
+	case "":
+		dialect.TimeFormat = influxql.RFC3339Nano
+	case "h":
+		dialect.TimeFormat = influxql.Hour
+	case "m":
+		dialect.TimeFormat = influxql.Minute
+	case "s":
+		dialect.TimeFormat = influxql.Second
+	case "ms":
+		dialect.TimeFormat = influxql.Millisecond
+	case "u", "us":
+		dialect.TimeFormat = influxql.Microsecond
+	case "n", "ns":
+		dialect.TimeFormat = influxql.Nanosecond
+	default:
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/decodeV1QueryRequest",
+			Msg:  "invalid epoch; valid epoch units are h, m, s, ms, u, and ns",
+		}
+	}
+
+	compiler := influxql.NewCompiler(svc)
+	compiler.Cluster = platform.DefaultDBRPCluster
+	compiler.DB = db
+	compiler.RP = rp
+	compiler.Query = q
+
+	return &query.ProxyRequest{
+		Request: query.Request{
+			Authorization:  auth,
+			OrganizationID: orgID,
+			Compiler:       compiler,
+		},
+		Dialect: dialect,
+	}, nil
+}
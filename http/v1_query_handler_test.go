@@ -0,0 +1,170 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	platform "github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/query/influxql"
+	"go.uber.org/zap"
+)
+
+func TestV1QueryHandler_handleQuery(t *testing.T) {
+	const (
+		orgID     = platform.ID(1)
+		bucketID  = platform.ID(2)
+		authOrgID = platform.ID(3)
+	)
+
+	mappings := mock.NewDBRPMappingService()
+	mappings.FindFn = func(ctx context.Context, filter platform.DBRPMappingFilter) (*platform.DBRPMapping, error) {
+		if *filter.Database != "telegraf" {
+			return nil, platform.ErrDBRPMappingNotFound
+		}
+		return &platform.DBRPMapping{
+			Cluster:         platform.DefaultDBRPCluster,
+			Database:        "telegraf",
+			RetentionPolicy: "autogen",
+			Default:         true,
+			OrganizationID:  orgID,
+			BucketID:        bucketID,
+		}, nil
+	}
+
+	tests := []struct {
+		name        string
+		method      string
+		url         string
+		accept      string
+		status      int
+		contentType string
+		body        string
+		org         platform.ID
+		encoding    influxql.EncodingFormat
+		timeFormat  influxql.TimeFormat
+	}{
+		{
+			name:        "json query",
+			method:      "GET",
+			url:         "/query?db=telegraf&q=SELECT+*+FROM+cpu",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			org:         orgID,
+			encoding:    influxql.JSON,
+			timeFormat:  influxql.RFC3339Nano,
+		},
+		{
+			name:        "csv query with epoch",
+			method:      "POST",
+			url:         "/query?db=telegraf&rp=autogen&q=SELECT+*+FROM+cpu&epoch=ms",
+			accept:      "application/csv",
+			status:      http.StatusOK,
+			contentType: "text/csv",
+			org:         orgID,
+			encoding:    influxql.CSV,
+			timeFormat:  influxql.Millisecond,
+		},
+		{
+			name:        "query without database",
+			method:      "GET",
+			url:         `/query?q=SELECT+*+FROM+"telegraf"."autogen"."cpu"&pretty=true`,
+			status:      http.StatusOK,
+			contentType: "application/json",
+			org:         authOrgID,
+			encoding:    influxql.JSONPretty,
+			timeFormat:  influxql.RFC3339Nano,
+		},
+		{
+			name:   "missing query",
+			method: "GET",
+			url:    "/query?db=telegraf",
+			status: http.StatusBadRequest,
+			body:   `{"error":"missing required parameter \"q\""}`,
+		},
+		{
+			name:   "unknown database",
+			method: "GET",
+			url:    "/query?db=unknown&q=SELECT+*+FROM+cpu",
+			status: http.StatusNotFound,
+			body:   `{"error":"database not found: \"unknown\""}`,
+		},
+		{
+			name:   "invalid epoch",
+			method: "GET",
+			url:    "/query?db=telegraf&q=SELECT+*+FROM+cpu&epoch=d",
+			status: http.StatusBadRequest,
+			body:   `{"error":"invalid epoch; valid epoch units are h, m, s, ms, u, and ns"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *query.ProxyRequest
+			qs := mock.NewProxyQueryService()
+			qs.QueryFn = func(ctx context.Context, w io.Writer, req *query.ProxyRequest) (int64, error) {
+				got = req
+				return 0, nil
+			}
+
+			h := NewV1QueryHandler(&V1QueryBackend{
+				Logger:             zap.NewNop(),
+				ProxyQueryService:  qs,
+				DBRPMappingService: mappings,
+			})
+
+			r := httptest.NewRequest(tt.method, tt.url, nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{
+				Status: platform.Active,
+				OrgID:  authOrgID,
+			}))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if got, want := w.Code, tt.status; got != want {
+				t.Fatalf("unexpected status code: got %d, want %d: %s", got, want, w.Body.String())
+			}
+			if tt.body != "" {
+				if got, want := w.Body.String(), tt.body; got != want {
+					t.Errorf("unexpected body: got %s, want %s", got, want)
+				}
+				return
+			}
+			if got, want := w.Header().Get("Content-Type"), tt.contentType; got != want {
+				t.Errorf("unexpected content type: got %s, want %s", got, want)
+			}
+
+			if got == nil {
+				t.Fatal("expected query to be called")
+			}
+			if got.Request.OrganizationID != tt.org {
+				t.Errorf("unexpected organization: got %v, want %v", got.Request.OrganizationID, tt.org)
+			}
+
+			compiler, ok := got.Request.Compiler.(*influxql.Compiler)
+			if !ok {
+				t.Fatalf("unexpected compiler type %T", got.Request.Compiler)
+			}
+			if compiler.Cluster != platform.DefaultDBRPCluster || compiler.Query == "" {
+				t.Errorf("unexpected compiler: %+v", compiler)
+			}
+
+			dialect, ok := got.Dialect.(*influxql.Dialect)
+			if !ok {
+				t.Fatalf("unexpected dialect type %T", got.Dialect)
+			}
+			if dialect.Encoding != tt.encoding || dialect.TimeFormat != tt.timeFormat {
+				t.Errorf("unexpected dialect: %+v", dialect)
+			}
+		})
+	}
+}
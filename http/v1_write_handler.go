@@ -0,0 +1,355 @@
+package http
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	platform "github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/query/influxql"
+	"github.com/influxdata/influxdb/storage"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// V1WriteBackend is all services and associated parameters required to construct
+// the V1WriteHandler.
+type V1WriteBackend struct {
+	Logger *zap.Logger
+
+	PointsWriter       storage.PointsWriter
+	DBRPMappingService platform.DBRPMappingService
+	OrgLimiter         platform.OrgLimiter
+	UsageRecorder      platform.UsageRecorder
+}
+
+// NewV1WriteBackend returns a new instance of V1WriteBackend.
+func NewV1WriteBackend(b *APIBackend) *V1WriteBackend {
+	return &V1WriteBackend{
+		Logger: b.Logger.With(zap.String("handler", "v1_write")),
+
+		PointsWriter:       b.PointsWriter,
+		DBRPMappingService: b.DBRPMappingService,
+		OrgLimiter:         b.OrgLimiter,
+		UsageRecorder:      b.UsageRecorder,
+	}
+}
+
+// V1WriteHandler receives line protocol on the influxdb 1.x compatible /write
+// endpoint and writes it to the bucket mapped to the database and retention policy.
+type V1WriteHandler struct {
+	*httprouter.Router
+
+	Logger *zap.Logger
+
+	DBRPMappingService platform.DBRPMappingService
+	OrgLimiter         platform.OrgLimiter
+	UsageRecorder      platform.UsageRecorder
+
+	PointsWriter storage.PointsWriter
+}
+
+const (
+	v1WritePath = "/write"
+)
+
+// NewV1WriteHandler creates a new handler at /write to receive line protocol.
+func NewV1WriteHandler(b *V1WriteBackend) *V1WriteHandler {
+	h := &V1WriteHandler{
+		Router: NewRouter(),
+		Logger: b.Logger,
+
+		PointsWriter:       b.PointsWriter,
+		DBRPMappingService: b.DBRPMappingService,
+		OrgLimiter:         b.OrgLimiter,
+		UsageRecorder:      b.UsageRecorder,
+	}
+
+	h.HandlerFunc("POST", v1WritePath, h.handleWrite)
+	return h
+}
+
+func (h *V1WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	defer r.Body.Close()
+
+	in := r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		var err error
+		in, err = gzip.NewReader(r.Body)
+		if err != nil {
+			encodeV1Error(ctx, &platform.Error{
+				Code: platform.EInvalid,
+				Op:   "http/handleV1Write",
+				Msg:  errInvalidGzipHeader,
+				Err:  err,
+			}, w)
+			return
+		}
+		defer in.Close()
+	}
+
+	a, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		encodeV1Error(ctx, err, w)
+		return
+	}
+
+	req, err := decodeV1WriteRequest(ctx, r)
+	if err != nil {
+		encodeV1Error(ctx, err, w)
+		return
+	}
+
+	logger := h.Logger.With(zap.String("db", req.Database), zap.String("rp", req.RetentionPolicy))
+
+	mapping, err := findDBRPMapping(ctx, h.DBRPMappingService, req.Database, req.RetentionPolicy)
+	if err != nil {
+		logger.Info("Failed to find dbrp mapping", zap.Error(err))
+		encodeV1Error(ctx, err, w)
+		return
+	}
+
+	p, err := platform.NewPermissionAtID(mapping.BucketID, platform.WriteAction, platform.BucketsResourceType, mapping.OrganizationID)
+	if err != nil {
+		encodeV1Error(ctx, &platform.Error{
+			Code: platform.EInternal,
+			Op:   "http/handleV1Write",
+			Msg:  fmt.Sprintf("unable to create permission for bucket: %v", err),
+			Err:  err,
+		}, w)
+		return
+	}
+
+	if !a.Allowed(*p) {
+		encodeV1Error(ctx, &platform.Error{
+			Code: platform.EForbidden,
+			Op:   "http/handleV1Write",
+			Msg:  "insufficient permissions for write",
+		}, w)
+		return
+	}
+
+	scope, err := writeScope(a, *p)
+	if err != nil {
+		encodeV1Error(ctx, err, w)
+		return
+	}
+
+	data, err := ioutil.ReadAll(in)
+	if err != nil {
+		logger.Error("Error reading body", zap.Error(err))
+		encodeV1Error(ctx, &platform.Error{
+			Code: platform.EInternal,
+			Op:   "http/handleV1Write",
+			Msg:  fmt.Sprintf("unable to read data: %v", err),
+			Err:  err,
+		}, w)
+		return
+	}
+
+	if h.OrgLimiter != nil {
+		if err := h.OrgLimiter.LimitWrite(ctx, mapping.OrganizationID, int64(len(data))); err != nil {
+			logger.Info("Write limited", zap.Error(err))
+			encodeV1Error(ctx, err, w)
+			return
+		}
+	}
+
+	recordUsage(ctx, h.UsageRecorder, mapping.OrganizationID, &mapping.BucketID, platform.UsageWriteRequestCount, 1)
+	recordUsage(ctx, h.UsageRecorder, mapping.OrganizationID, &mapping.BucketID, platform.UsageWriteRequestBytes, int64(len(data)))
+
+	points, err := models.ParsePointsWithPrecision(data, time.Now(), req.Precision)
+	if err != nil {
+		logger.Error("Error parsing points", zap.Error(err))
+		encodeV1Error(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/handleV1Write",
+			Msg:  fmt.Sprintf("unable to parse points: %v", err),
+			Err:  err,
+		}, w)
+		return
+	}
+
+	exploded, err := tsdb.ExplodePoints(mapping.OrganizationID, mapping.BucketID, points)
+	if err != nil {
+		logger.Error("Error exploding points", zap.Error(err))
+		encodeV1Error(ctx, &platform.Error{
+			Code: platform.EInternal,
+			Op:   "http/handleV1Write",
+			Msg:  fmt.Sprintf("unable to convert points to internal structures: %v", err),
+			Err:  err,
+		}, w)
+		return
+	}
+
+	// The points outside of the scope of the authorization are dropped, and
+	// reported as a partial write like the points dropped by the engine.
+	writes := exploded
+	var outside tsdb.PartialWriteError
+	if scope != nil {
+		writes, outside = scopePoints(scope, exploded)
+		if outside.Dropped > 0 {
+			logger.Info("Points outside of scope dropped", zap.Int("dropped", outside.Dropped))
+		}
+	}
+
+	if len(writes) == 0 {
+		encodeV1Error(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/handleV1Write",
+			Msg:  outside.Error(),
+			Err:  outside,
+		}, w)
+		return
+	}
+
+	if err := h.PointsWriter.WritePoints(ctx, writes); err != nil {
+		if pwe, ok := err.(tsdb.PartialWriteError); ok {
+			logger.Info("Points dropped", zap.Int("dropped", pwe.Dropped), zap.String("reason", pwe.Reason))
+			recordWrittenUsage(ctx, h.UsageRecorder, mapping.OrganizationID, mapping.BucketID, writes, pwe.DroppedKeys)
+			if outside.Dropped > 0 {
+				pwe.Reason = outside.Reason
+				pwe.Dropped += outside.Dropped
+			}
+			encodeV1Error(ctx, &platform.Error{
+				Code: platform.EInvalid,
+				Op:   "http/handleV1Write",
+				Msg:  pwe.Error(),
+				Err:  pwe,
+			}, w)
+			return
+		}
+
+		logger.Error("Error writing points", zap.Error(err))
+		encodeV1Error(ctx, &platform.Error{
+			Code: platform.EInternal,
+			Op:   "http/handleV1Write",
+			Msg:  fmt.Sprintf("unable to write points to database: %v", err),
+			Err:  err,
+		}, w)
+		return
+	}
+	recordWrittenUsage(ctx, h.UsageRecorder, mapping.OrganizationID, mapping.BucketID, writes, nil)
+
+	if outside.Dropped > 0 {
+		encodeV1Error(ctx, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/handleV1Write",
+			Msg:  outside.Error(),
+			Err:  outside,
+		}, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type postV1WriteRequest struct {
+	Database        string
+	RetentionPolicy string
+	Precision       string
+}
+
+func decodeV1WriteRequest(ctx context.Context, r *http.Request) (*postV1WriteRequest, error) {
+	qp := r.URL.Query()
+	req := &postV1WriteRequest{
+		Database:        qp.Get("db"),
+		RetentionPolicy: qp.Get("rp"),
+	}
+
+	if req.Database == "" {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/decodeV1WriteRequest",
+			Msg:  "database is required",
+		}
+	}
+
+	// 1.x clients abbreviate nanoseconds and microseconds.
+	switch p := qp.Get("precision"); p {
+	case "", "n":
+		req.Precision = "ns"
+	case "u":
+		req.Precision = "us"
+	default:
+		req.Precision = p
+	}
+
+	if !models.ValidPrecision(req.Precision) {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Op:   "http/decodeV1WriteRequest",
+			Msg:  errInvalidPrecision,
+		}
+	}
+
+	return req, nil
+}
+
+// findDBRPMapping finds the mapping of the database and retention policy of a 1.x
+// request. The default retention policy of the database is used if rp is empty.
+func findDBRPMapping(ctx context.Context, svc platform.DBRPMappingService, db, rp string) (*platform.DBRPMapping, error) {
+	cluster := platform.DefaultDBRPCluster
+	filter := platform.DBRPMappingFilter{
+		Cluster:  &cluster,
+		Database: &db,
+	}
+	if rp != "" {
+		filter.RetentionPolicy = &rp
+	} else {
+		isDefault := true
+		filter.Default = &isDefault
+	}
+
+	m, err := svc.Find(ctx, filter)
+	if err != nil {
+		if platform.ErrorCode(err) != platform.ENotFound {
+			return nil, &platform.Error{
+				Op:  "http/findDBRPMapping",
+				Err: err,
+			}
+		}
+		return nil, &platform.Error{
+			Code: platform.ENotFound,
+			Op:   "http/findDBRPMapping",
+			Msg:  fmt.Sprintf("database not found: %q", db),
+			Err:  err,
+		}
+	}
+	return m, nil
+}
+
+// encodeV1Error encodes err with the appropriate status code in the influxdb 1.x
+// response format.
+func encodeV1Error(ctx context.Context, err error, w http.ResponseWriter) {
+	if err == nil {
+		return
+	}
+
+	code := platform.ErrorCode(err)
+	httpCode, ok := statusCodePlatformError[code]
+	if !ok {
+		httpCode = http.StatusBadRequest
+	}
+	w.Header().Set(PlatformErrorCodeHeader, code)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	setRetryAfter(w, err)
+	w.WriteHeader(httpCode)
+
+	// 1.x clients expect to see the cause of the error, such as a query
+	// that failed to compile, so plain errors are not masked.
+	msg := err.Error()
+	if _, ok := err.(*platform.Error); ok {
+		msg = platform.ErrorMessage(err)
+	}
+	b, _ := json.Marshal(influxql.Response{Err: msg})
+	_, _ = w.Write(b)
+}
@@ -0,0 +1,173 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	platform "github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/mock"
+	"go.uber.org/zap"
+)
+
+func TestV1WriteHandler_handleWrite(t *testing.T) {
+	const (
+		orgID    = platform.ID(1)
+		bucketID = platform.ID(2)
+	)
+
+	mappings := mock.NewDBRPMappingService()
+	mappings.FindFn = func(ctx context.Context, filter platform.DBRPMappingFilter) (*platform.DBRPMapping, error) {
+		if *filter.Database == "unavailable" {
+			return nil, errors.New("store is unavailable")
+		}
+		if *filter.Cluster != platform.DefaultDBRPCluster || *filter.Database != "telegraf" {
+			return nil, platform.ErrDBRPMappingNotFound
+		}
+		if filter.RetentionPolicy == nil && (filter.Default == nil || !*filter.Default) {
+			t.Fatal("expected the default retention policy to be requested")
+		}
+		return &platform.DBRPMapping{
+			Cluster:         *filter.Cluster,
+			Database:        "telegraf",
+			RetentionPolicy: "autogen",
+			Default:         true,
+			OrganizationID:  orgID,
+			BucketID:        bucketID,
+		}, nil
+	}
+
+	orgIDVar, bucketIDVar := orgID, bucketID
+	scoped := []platform.Permission{{
+		Action: platform.WriteAction,
+		Resource: platform.Resource{
+			Type:  platform.BucketsResourceType,
+			OrgID: &orgIDVar,
+			ID:    &bucketIDVar,
+		},
+		Predicate: `host="a"`,
+	}}
+
+	tests := []struct {
+		name        string
+		url         string
+		body        string
+		permissions []platform.Permission
+		status      int
+		body1x      string
+		points      int
+	}{
+		{
+			name:   "write to default retention policy",
+			url:    "/write?db=telegraf",
+			body:   "cpu,host=a value=1 1000000000\ncpu,host=b value=2 1000000000",
+			status: http.StatusNoContent,
+			points: 2,
+		},
+		{
+			name:   "write with 1.x precision",
+			url:    "/write?db=telegraf&rp=autogen&precision=u",
+			body:   "cpu,host=a value=1 1000000",
+			status: http.StatusNoContent,
+			points: 1,
+		},
+		{
+			name:   "missing database",
+			url:    "/write",
+			body:   "cpu,host=a value=1",
+			status: http.StatusBadRequest,
+			body1x: `{"error":"database is required"}`,
+		},
+		{
+			name:   "unknown database",
+			url:    "/write?db=unknown",
+			body:   "cpu,host=a value=1",
+			status: http.StatusNotFound,
+			body1x: `{"error":"database not found: \"unknown\""}`,
+		},
+		{
+			name:   "database lookup failure",
+			url:    "/write?db=unavailable",
+			body:   "cpu,host=a value=1",
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:        "insufficient permissions",
+			url:         "/write?db=telegraf",
+			body:        "cpu,host=a value=1",
+			permissions: []platform.Permission{},
+			status:      http.StatusForbidden,
+			body1x:      `{"error":"insufficient permissions for write"}`,
+		},
+		{
+			name:        "write inside the scope of the authorization",
+			url:         "/write?db=telegraf",
+			body:        "cpu,host=a value=1 1000000000",
+			permissions: scoped,
+			status:      http.StatusNoContent,
+			points:      1,
+		},
+		{
+			name:        "write partly outside the scope of the authorization",
+			url:         "/write?db=telegraf",
+			body:        "cpu,host=a value=1 1000000000\ncpu,host=b value=2 1000000000",
+			permissions: scoped,
+			status:      http.StatusBadRequest,
+			body1x:      `{"error":"partial write: point is outside the scope of the authorization dropped=1"}`,
+			points:      1,
+		},
+		{
+			name:        "write outside the scope of the authorization",
+			url:         "/write?db=telegraf",
+			body:        "cpu,host=b value=2 1000000000",
+			permissions: scoped,
+			status:      http.StatusBadRequest,
+			body1x:      `{"error":"partial write: point is outside the scope of the authorization dropped=1"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pw := &mock.PointsWriter{}
+			h := NewV1WriteHandler(&V1WriteBackend{
+				Logger:             zap.NewNop(),
+				PointsWriter:       pw,
+				DBRPMappingService: mappings,
+			})
+
+			permissions := tt.permissions
+			if permissions == nil {
+				p, err := platform.NewPermissionAtID(bucketID, platform.WriteAction, platform.BucketsResourceType, orgID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				permissions = []platform.Permission{*p}
+			}
+
+			r := httptest.NewRequest("POST", tt.url, strings.NewReader(tt.body))
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{
+				Status:      platform.Active,
+				Permissions: permissions,
+			}))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if got, want := w.Code, tt.status; got != want {
+				t.Fatalf("unexpected status code: got %d, want %d: %s", got, want, w.Body.String())
+			}
+			if tt.body1x != "" {
+				if got, want := w.Body.String(), tt.body1x; got != want {
+					t.Errorf("unexpected body: got %s, want %s", got, want)
+				}
+			}
+			if got, want := len(pw.Points), tt.points; got != want {
+				t.Errorf("unexpected number of points written: got %d, want %d", got, want)
+			}
+		})
+	}
+}
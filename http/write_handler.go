@@ -1,19 +1,24 @@
 package http
 
 import (
+	"bufio"
 	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
+	"sort"
 	"time"
 
 	platform "github.com/influxdata/influxdb"
 	pcontext "github.com/influxdata/influxdb/context"
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/storage"
+	"github.com/influxdata/influxdb/storage/reads"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
 	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/tsm1"
 	"github.com/julienschmidt/httprouter"
 	"go.uber.org/zap"
 )
@@ -26,6 +31,22 @@ type WriteBackend struct {
 	PointsWriter        storage.PointsWriter
 	BucketService       platform.BucketService
 	OrganizationService platform.OrganizationService
+	// OrgLimiter limits the writes of organizations. Writes are not
+	// limited if it is nil.
+	OrgLimiter platform.OrgLimiter
+	// UsageRecorder records the usage of the writes. The usage is not
+	// recorded if it is nil.
+	UsageRecorder platform.UsageRecorder
+
+	// MaxWriteErrors is the maximum number of rejected lines listed in the
+	// response to a write. DefaultMaxWriteErrors is used if it is not positive.
+	MaxWriteErrors int
+	// MaxBodySize is the maximum size in bytes of the uncompressed body of a
+	// write. Writes are not limited if it is not positive.
+	MaxBodySize int64
+	// MaxBatchSize is the maximum number of points written to the PointsWriter
+	// at once. models.DefaultScanBatchSize is used if it is not positive.
+	MaxBatchSize int
 }
 
 // NewWriteBackend returns a new instance of WriteBackend.
@@ -36,6 +57,11 @@ func NewWriteBackend(b *APIBackend) *WriteBackend {
 		PointsWriter:        b.PointsWriter,
 		BucketService:       b.BucketService,
 		OrganizationService: b.OrganizationService,
+		OrgLimiter:          b.OrgLimiter,
+		UsageRecorder:       b.UsageRecorder,
+		MaxWriteErrors:      b.MaxWriteErrors,
+		MaxBodySize:         b.MaxWriteBodySize,
+		MaxBatchSize:        b.MaxWriteBatchSize,
 	}
 }
 
@@ -47,8 +73,14 @@ type WriteHandler struct {
 
 	BucketService       platform.BucketService
 	OrganizationService platform.OrganizationService
+	OrgLimiter          platform.OrgLimiter
+	UsageRecorder       platform.UsageRecorder
 
 	PointsWriter storage.PointsWriter
+
+	MaxWriteErrors int
+	MaxBodySize    int64
+	MaxBatchSize   int
 }
 
 const (
@@ -57,6 +89,10 @@ const (
 	errInvalidPrecision  = "invalid precision; valid precision units are ns, us, ms, and s"
 )
 
+// DefaultMaxWriteErrors is the default maximum number of rejected lines listed
+// in the response to a write.
+const DefaultMaxWriteErrors = 100
+
 // NewWriteHandler creates a new handler at /api/v2/write to receive line protocol.
 func NewWriteHandler(b *WriteBackend) *WriteHandler {
 	h := &WriteHandler{
@@ -66,6 +102,11 @@ func NewWriteHandler(b *WriteBackend) *WriteHandler {
 		PointsWriter:        b.PointsWriter,
 		BucketService:       b.BucketService,
 		OrganizationService: b.OrganizationService,
+		OrgLimiter:          b.OrgLimiter,
+		UsageRecorder:       b.UsageRecorder,
+		MaxWriteErrors:      b.MaxWriteErrors,
+		MaxBodySize:         b.MaxBodySize,
+		MaxBatchSize:        b.MaxBatchSize,
 	}
 
 	h.HandlerFunc("POST", writePath, h.handleWrite)
@@ -106,57 +147,20 @@ func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 
 	logger := h.Logger.With(zap.String("org", req.Org), zap.String("bucket", req.Bucket))
 
-	var org *platform.Organization
-	if id, err := platform.IDFromString(req.Org); err == nil {
-		// Decoded ID successfully. Make sure it's a real org.
-		o, err := h.OrganizationService.FindOrganizationByID(ctx, *id)
-		if err == nil {
-			org = o
-		} else if platform.ErrorCode(err) != platform.ENotFound {
-			EncodeError(ctx, err, w)
-			return
-		}
-	}
-	if org == nil {
-		o, err := h.OrganizationService.FindOrganization(ctx, platform.OrganizationFilter{Name: &req.Org})
-		if err != nil {
-			logger.Info("Failed to find organization", zap.Error(err))
-			EncodeError(ctx, err, w)
-			return
-		}
-
-		org = o
-	}
-
-	var bucket *platform.Bucket
-	if id, err := platform.IDFromString(req.Bucket); err == nil {
-		// Decoded ID successfully. Make sure it's a real bucket.
-		b, err := h.BucketService.FindBucket(ctx, platform.BucketFilter{
-			OrganizationID: &org.ID,
-			ID:             id,
-		})
-		if err == nil {
-			bucket = b
-		} else if platform.ErrorCode(err) != platform.ENotFound {
-			EncodeError(ctx, err, w)
-			return
-		}
+	org, err := findOrganization(ctx, h.OrganizationService, req.Org)
+	if err != nil {
+		logger.Info("Failed to find organization", zap.Error(err))
+		EncodeError(ctx, err, w)
+		return
 	}
 
-	if bucket == nil {
-		b, err := h.BucketService.FindBucket(ctx, platform.BucketFilter{
-			OrganizationID: &org.ID,
-			Name:           &req.Bucket,
-		})
-		if err != nil {
-			EncodeError(ctx, &platform.Error{
-				Op:  "http/handleWrite",
-				Err: err,
-			}, w)
-			return
-		}
-
-		bucket = b
+	bucket, err := findBucket(ctx, h.BucketService, org.ID, req.Bucket)
+	if err != nil {
+		EncodeError(ctx, &platform.Error{
+			Op:  "http/handleWrite",
+			Err: err,
+		}, w)
+		return
 	}
 
 	p, err := platform.NewPermissionAtID(bucket.ID, platform.WriteAction, platform.BucketsResourceType, org.ID)
@@ -179,57 +183,471 @@ func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	scope, err := writeScope(a, *p)
+	if err != nil {
+		EncodeError(ctx, err, w)
+		return
+	}
+
+	var body io.Reader = in
+	if h.MaxBodySize > 0 {
+		if r.ContentLength > h.MaxBodySize {
+			h.encodeBodyTooLarge(w, r, 0)
+			return
+		}
+		body = &limitedReader{r: in, n: h.MaxBodySize}
+	}
+
+	counter := &countingReader{r: body}
+	body = counter
+
+	if h.OrgLimiter != nil {
+		// The size of a gzipped body is only known once it is read.
+		size := r.ContentLength
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			size = -1
+		}
+		if err := h.OrgLimiter.LimitWrite(ctx, org.ID, size); err != nil {
+			logger.Info("Write limited", zap.Error(err))
+			EncodeError(ctx, err, w)
+			return
+		}
+		if size < 0 {
+			defer func() {
+				h.OrgLimiter.CountWriteBytes(ctx, org.ID, counter.n)
+			}()
+		}
+	}
+
+	if h.UsageRecorder != nil {
+		defer func() {
+			recordUsage(ctx, h.UsageRecorder, org.ID, &bucket.ID, platform.UsageWriteRequestCount, 1)
+			recordUsage(ctx, h.UsageRecorder, org.ID, &bucket.ID, platform.UsageWriteRequestBytes, counter.n)
+		}()
+	}
+
 	// TODO(jeff): we should be publishing with the org and bucket instead of
 	// parsing, rewriting, and publishing, but the interface isn't quite there yet.
 	// be sure to remove this when it is there!
-	data, err := ioutil.ReadAll(in)
-	if err != nil {
-		logger.Error("Error reading body", zap.Error(err))
-		EncodeError(ctx, &platform.Error{
-			Code: platform.EInternal,
-			Op:   "http/handleWrite",
-			Msg:  fmt.Sprintf("unable to read data: %v", err),
-			Err:  err,
-		}, w)
+	rejected := rejectedLines{max: h.MaxWriteErrors}
+	if rejected.max <= 0 {
+		rejected.max = DefaultMaxWriteErrors
+	}
+	// A batch is only written once the next one is read, so that the last
+	// batch of a body failing to be read, whose last line may be truncated,
+	// is not written. The lines written before an error are reported, so
+	// that they are not written again.
+	var (
+		n       int
+		written int // The number of the first lines of the body written.
+		pending *pointsBatch
+	)
+	scanner := models.NewPointsScanner(body, time.Now(), req.Precision, h.MaxBatchSize)
+	for {
+		var next *pointsBatch
+		if scanner.Scan() {
+			next = &pointsBatch{
+				points:    scanner.Points(),
+				lines:     scanner.Lines(),
+				parseErrs: scanner.LineErrors(),
+				end:       scanner.Line() - 1,
+			}
+		}
+
+		if pending != nil && (next != nil || scanner.Err() == nil) {
+			b := pending
+			n += len(b.points) + len(b.parseErrs)
+
+			errs := make([]writeLineError, 0, len(b.parseErrs))
+			for _, err := range b.parseErrs {
+				errs = append(errs, writeLineError{
+					Line:   err.Line,
+					Reason: fmt.Sprintf("unable to parse line: %v", err.Err),
+				})
+			}
+			if len(b.parseErrs) > 0 {
+				logger.Info("Failed to parse lines", zap.Int("lines", len(b.parseErrs)))
+			}
+
+			if len(b.points) > 0 {
+				dropped, err := h.writeBatch(ctx, logger, org.ID, bucket.ID, scope, b.points, b.lines)
+				if err != nil {
+					EncodeError(ctx, partialWriteError(err, written), w)
+					return
+				}
+				errs = append(errs, dropped...)
+			}
+			rejected.add(errs)
+			written = b.end
+		}
+
+		if next == nil {
+			break
+		}
+		pending = next
+	}
+
+	if err := scanner.Err(); err != nil {
+		switch err {
+		case errBodyTooLarge:
+			h.encodeBodyTooLarge(w, r, written)
+		case bufio.ErrTooLong:
+			EncodeError(ctx, partialWriteError(&platform.Error{
+				Code: platform.EInvalid,
+				Op:   "http/handleWrite",
+				Msg:  fmt.Sprintf("line %d is longer than %d bytes", scanner.Line(), models.MaxScanLineSize),
+				Err:  err,
+			}, written), w)
+		default:
+			logger.Error("Error reading body", zap.Error(err))
+			EncodeError(ctx, partialWriteError(&platform.Error{
+				Code: platform.EInternal,
+				Op:   "http/handleWrite",
+				Msg:  fmt.Sprintf("unable to read data: %v", err),
+				Err:  err,
+			}, written), w)
+		}
 		return
 	}
 
-	points, err := models.ParsePointsWithPrecision(data, time.Now(), req.Precision)
-	if err != nil {
-		logger.Error("Error parsing points", zap.Error(err))
-		EncodeError(ctx, &platform.Error{
-			Code: platform.EInvalid,
-			Op:   "http/handleWrite",
-			Msg:  fmt.Sprintf("unable to parse points: %v", err),
-			Err:  err,
-		}, w)
+	if rejected.lines > 0 {
+		h.encodeWriteError(w, r, rejected, n)
 		return
 	}
 
-	exploded, err := tsdb.ExplodePoints(org.ID, bucket.ID, points)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// pointsBatch is a batch of the lines of the body of a write.
+type pointsBatch struct {
+	points    []models.Point
+	lines     []int // The line of each point.
+	parseErrs []*models.LineError
+	end       int // The last line of the batch.
+}
+
+// partialWriteError returns err, noting the number of the first lines of the
+// body of the write that were written before it, if any.
+func partialWriteError(err error, written int) error {
+	if written == 0 {
+		return err
+	}
+	return &platform.Error{
+		Code: platform.ErrorCode(err),
+		Op:   "http/handleWrite",
+		Msg:  fmt.Sprintf("%s; lines 1 to %d were written", platform.ErrorMessage(err), written),
+		Err:  err,
+	}
+}
+
+// writeBatch writes a batch of the points of a write, and returns the errors of
+// the lines of the points dropped. The points outside of scope, if it is not
+// nil, are dropped without being written.
+func (h *WriteHandler) writeBatch(ctx context.Context, logger *zap.Logger, orgID, bucketID platform.ID, scope platform.Predicate, points []models.Point, lines []int) ([]writeLineError, error) {
+	exploded, err := tsdb.ExplodePoints(orgID, bucketID, points)
 	if err != nil {
 		logger.Error("Error exploding points", zap.Error(err))
-		EncodeError(ctx, &platform.Error{
+		return nil, &platform.Error{
 			Code: platform.EInternal,
 			Op:   "http/handleWrite",
 			Msg:  fmt.Sprintf("unable to convert points to internal structures: %v", err),
 			Err:  err,
-		}, w)
-		return
+		}
 	}
 
-	if err := h.PointsWriter.WritePoints(ctx, exploded); err != nil {
-		logger.Error("Error writing points", zap.Error(err))
-		EncodeError(ctx, &platform.Error{
+	writes := exploded
+	var errs []writeLineError
+	if scope != nil {
+		var outside tsdb.PartialWriteError
+		writes, outside = scopePoints(scope, exploded)
+		if outside.Dropped > 0 {
+			logger.Info("Points outside of scope dropped", zap.Int("dropped", outside.Dropped))
+			errs = droppedLines(points, lines, exploded, outside)
+		}
+		if len(writes) == 0 {
+			return errs, nil
+		}
+	}
+
+	if err := h.PointsWriter.WritePoints(ctx, writes); err != nil {
+		pwe, ok := err.(tsdb.PartialWriteError)
+		if !ok {
+			logger.Error("Error writing points", zap.Error(err))
+			return nil, &platform.Error{
+				Code: platform.EInternal,
+				Op:   "http/handleWrite",
+				Msg:  fmt.Sprintf("unable to write points to database: %v", err),
+				Err:  err,
+			}
+		}
+
+		logger.Info("Points dropped", zap.Int("dropped", pwe.Dropped), zap.String("reason", pwe.Reason))
+		recordWrittenUsage(ctx, h.UsageRecorder, orgID, bucketID, writes, pwe.DroppedKeys)
+		return append(errs, droppedLines(points, lines, exploded, pwe)...), nil
+	}
+	recordWrittenUsage(ctx, h.UsageRecorder, orgID, bucketID, writes, nil)
+	return errs, nil
+}
+
+// scopePredicate returns the predicate restricting the series of the bucket
+// that the authorizer is allowed p on, or nil if they are not restricted.
+func scopePredicate(a platform.Authorizer, p platform.Permission) (*datatypes.Predicate, error) {
+	s := platform.AuthorizerPredicate(a, p)
+	if s == "" {
+		return nil, nil
+	}
+
+	pred, err := reads.ParsePredicate(s)
+	if err != nil {
+		return nil, &platform.Error{
 			Code: platform.EInternal,
-			Op:   "http/handleWrite",
-			Msg:  fmt.Sprintf("unable to write points to database: %v", err),
+			Msg:  fmt.Sprintf("invalid predicate of authorization: %v", err),
 			Err:  err,
-		}, w)
-		return
+		}
+	}
+	return pred, nil
+}
+
+// writeScope returns the predicate restricting the series of the bucket that
+// the authorizer is allowed to write by p, or nil if they are not restricted.
+func writeScope(a platform.Authorizer, p platform.Permission) (platform.Predicate, error) {
+	pred, err := scopePredicate(a, p)
+	if err != nil || pred == nil {
+		return nil, err
 	}
 
-	w.WriteHeader(http.StatusNoContent)
+	scope, err := tsm1.NewProtobufPredicate(pred)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInternal,
+			Msg:  fmt.Sprintf("invalid predicate of authorization: %v", err),
+			Err:  err,
+		}
+	}
+	return scope, nil
+}
+
+// scopePoints returns the points matching scope, and a partial write error
+// accounting for the points outside of it.
+func scopePoints(scope platform.Predicate, points []models.Point) ([]models.Point, tsdb.PartialWriteError) {
+	in := make([]models.Point, 0, len(points))
+	outside := tsdb.PartialWriteError{Reason: "point is outside the scope of the authorization"}
+	for _, p := range points {
+		if scope.Matches(p.Key()) {
+			in = append(in, p)
+			continue
+		}
+		outside.Dropped++
+		outside.DroppedKeys = append(outside.DroppedKeys, p.Key())
+	}
+	return in, outside
+}
+
+// writeLineError is the reason a line of a write was rejected.
+type writeLineError struct {
+	Line   int    `json:"line"`
+	Key    string `json:"key,omitempty"`   // The series key of the line.
+	Field  string `json:"field,omitempty"` // The field dropped, if not the whole line.
+	Reason string `json:"reason"`
+}
+
+// writeErrorResponse is the response to a write that rejected some of its lines.
+// The other lines of the write have been written.
+type writeErrorResponse struct {
+	Code      string           `json:"code"`
+	Message   string           `json:"message"`
+	Op        string           `json:"op"`
+	Line      int              `json:"line"` // The first line rejected.
+	Lines     []writeLineError `json:"lines"`
+	Truncated bool             `json:"truncated,omitempty"`
+}
+
+// droppedLines returns the errors of the lines of the points dropped by a partial
+// write of the exploded points.
+func droppedLines(points []models.Point, lines []int, exploded []models.Point, pwe tsdb.PartialWriteError) []writeLineError {
+	dropped := make(map[string]bool, len(pwe.DroppedKeys))
+	for _, key := range pwe.DroppedKeys {
+		dropped[string(key)] = true
+	}
+
+	var (
+		errs []writeLineError
+		j    int
+	)
+	for i, p := range points {
+		// ExplodePoints creates a point for each field of each point, in order.
+		itr := p.FieldIterator()
+		for itr.Next() && j < len(exploded) {
+			key := exploded[j].Key()
+			j++
+			if !dropped[string(key)] {
+				continue
+			}
+
+			reason, ok := pwe.DroppedReasons[string(key)]
+			if !ok {
+				reason = pwe.Reason
+			}
+			errs = append(errs, writeLineError{
+				Line:   lines[i],
+				Key:    string(p.Key()),
+				Field:  string(itr.FieldKey()),
+				Reason: reason,
+			})
+		}
+	}
+	return errs
+}
+
+// rejectedLines collects the errors of the rejected lines of a write, keeping
+// at most max of them.
+type rejectedLines struct {
+	max       int
+	errs      []writeLineError
+	lines     int // The number of lines rejected.
+	truncated bool
+}
+
+// add adds the errors of the rejected lines of a batch, which follows the
+// batches of the errors already added.
+func (r *rejectedLines) add(errs []writeLineError) {
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Line < errs[j].Line
+	})
+
+	for i, err := range errs {
+		if i == 0 || err.Line != errs[i-1].Line {
+			r.lines++
+		}
+		if len(r.errs) < r.max {
+			r.errs = append(r.errs, err)
+		} else {
+			r.truncated = true
+		}
+	}
+}
+
+// encodeWriteError encodes the errors of the rejected lines of a write of n lines.
+func (h *WriteHandler) encodeWriteError(w http.ResponseWriter, r *http.Request, rejected rejectedLines, n int) {
+	first := rejected.errs[0]
+	resp := writeErrorResponse{
+		Code:      platform.EInvalid,
+		Message:   fmt.Sprintf("%d of %d lines rejected; first at line %d: %s", rejected.lines, n, first.Line, first.Reason),
+		Op:        "http/handleWrite",
+		Line:      first.Line,
+		Lines:     rejected.errs,
+		Truncated: rejected.truncated,
+	}
+
+	w.Header().Set(PlatformErrorCodeHeader, platform.EInvalid)
+	if err := encodeResponse(r.Context(), w, http.StatusBadRequest, resp); err != nil {
+		logEncodingError(h.Logger, r, err)
+	}
+}
+
+// lineProtocolLengthError is the response to a write with a body larger than
+// the maximum size of a write.
+type lineProtocolLengthError struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	MaxLength int64  `json:"maxLength"`
+	Written   int    `json:"written,omitempty"` // The number of the first lines written.
+}
+
+// encodeBodyTooLarge encodes the error of a write with a body larger than
+// MaxBodySize, whose first written lines were written.
+func (h *WriteHandler) encodeBodyTooLarge(w http.ResponseWriter, r *http.Request, written int) {
+	resp := lineProtocolLengthError{
+		Code:      platform.EInvalid,
+		Message:   fmt.Sprintf("request body is larger than the maximum size of a write of %d bytes", h.MaxBodySize),
+		MaxLength: h.MaxBodySize,
+		Written:   written,
+	}
+	if written > 0 {
+		resp.Message += fmt.Sprintf("; lines 1 to %d were written", written)
+	}
+
+	w.Header().Set(PlatformErrorCodeHeader, platform.EInvalid)
+	if err := encodeResponse(r.Context(), w, http.StatusRequestEntityTooLarge, resp); err != nil {
+		logEncodingError(h.Logger, r, err)
+	}
+}
+
+// errBodyTooLarge is the error reading a body larger than the maximum size of a write.
+var errBodyTooLarge = errors.New("request body too large")
+
+// limitedReader reads from r, and fails with errBodyTooLarge once more than
+// n bytes would have been read.
+type limitedReader struct {
+	r io.Reader
+	n int64 // The number of bytes left to read.
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, errBodyTooLarge
+	}
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+
+	n, err := l.r.Read(p)
+	if int64(n) > l.n {
+		n, l.n = int(l.n), -1
+		return n, errBodyTooLarge
+	}
+	l.n -= int64(n)
+	return n, err
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// findOrganization finds the organization identified by s, which may be either
+// the ID or the name of the organization.
+func findOrganization(ctx context.Context, svc platform.OrganizationService, s string) (*platform.Organization, error) {
+	if id, err := platform.IDFromString(s); err == nil {
+		// Decoded ID successfully. Make sure it's a real org.
+		o, err := svc.FindOrganizationByID(ctx, *id)
+		if err == nil {
+			return o, nil
+		} else if platform.ErrorCode(err) != platform.ENotFound {
+			return nil, err
+		}
+	}
+
+	return svc.FindOrganization(ctx, platform.OrganizationFilter{Name: &s})
+}
+
+// findBucket finds the bucket in the organization identified by s, which may be
+// either the ID or the name of the bucket.
+func findBucket(ctx context.Context, svc platform.BucketService, orgID platform.ID, s string) (*platform.Bucket, error) {
+	if id, err := platform.IDFromString(s); err == nil {
+		// Decoded ID successfully. Make sure it's a real bucket.
+		b, err := svc.FindBucket(ctx, platform.BucketFilter{
+			OrganizationID: &orgID,
+			ID:             id,
+		})
+		if err == nil {
+			return b, nil
+		} else if platform.ErrorCode(err) != platform.ENotFound {
+			return nil, err
+		}
+	}
+
+	return svc.FindBucket(ctx, platform.BucketFilter{
+		OrganizationID: &orgID,
+		Name:           &s,
+	})
 }
 
 func decodeWriteRequest(ctx context.Context, r *http.Request) (*postWriteRequest, error) {
@@ -3,14 +3,22 @@ package http
 import (
 	"compress/gzip"
 	"context"
+	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
 
 	platform "github.com/influxdata/influxdb"
+	pcontext "github.com/influxdata/influxdb/context"
+	"github.com/influxdata/influxdb/limits"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/storage"
+	"go.uber.org/zap"
 )
 
 func TestWriteService_Write(t *testing.T) {
@@ -69,3 +77,361 @@ func TestWriteService_Write(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteHandler_handleWrite(t *testing.T) {
+	const (
+		orgID    = platform.ID(1)
+		bucketID = platform.ID(2)
+	)
+
+	orgs := mock.NewOrganizationService()
+	orgs.FindOrganizationF = func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+		return &platform.Organization{ID: orgID, Name: "org"}, nil
+	}
+
+	bucket := &platform.Bucket{
+		ID:             bucketID,
+		OrganizationID: orgID,
+		Name:           "bucket",
+		SchemaType:     platform.SchemaTypeExplicit,
+		MeasurementSchemas: []platform.MeasurementSchema{
+			{
+				Name: "cpu",
+				Tags: []string{"host"},
+				Fields: []platform.MeasurementSchemaField{
+					{Name: "usage", Type: platform.SchemaFieldTypeFloat},
+					{Name: "count", Type: platform.SchemaFieldTypeInteger},
+				},
+			},
+		},
+	}
+	buckets := mock.NewBucketService()
+	buckets.FindBucketFn = func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+		return bucket, nil
+	}
+	buckets.FindBucketByIDFn = func(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+		return bucket, nil
+	}
+
+	tests := []struct {
+		name        string
+		body        string
+		chunked     bool
+		maxErrors   int
+		maxBodySize int64
+		batchSize   int
+		predicate   string
+		status      int
+		lines       []writeLineError
+		truncated   bool
+		points      int
+		batches     int
+		written     int
+	}{
+		{
+			name:    "valid lines",
+			body:    "cpu,host=a usage=1,count=2i 1\ncpu,host=b usage=2 1",
+			status:  http.StatusNoContent,
+			points:  3,
+			batches: 1,
+		},
+		{
+			name:      "batches",
+			body:      "cpu,host=a usage=1 1\ncpu,host=b usage=2 1\ncpu,host= usage=3 1\ncpu,host=c usage=3 1",
+			batchSize: 2,
+			status:    http.StatusBadRequest,
+			lines: []writeLineError{
+				{Line: 3, Reason: "unable to parse line: missing tag value"},
+			},
+			points:  3,
+			batches: 2,
+		},
+		{
+			name:        "body too large",
+			body:        "cpu,host=a usage=1 1\ncpu,host=b usage=2 1",
+			maxBodySize: 30,
+			status:      http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:        "chunked body too large",
+			body:        "cpu,host=a usage=1 1\ncpu,host=b usage=2 1\ncpu,host=c usage=3 1",
+			chunked:     true,
+			maxBodySize: 30,
+			batchSize:   1,
+			status:      http.StatusRequestEntityTooLarge,
+			points:      1,
+			batches:     1,
+			written:     1,
+		},
+		{
+			name:        "truncated line of body too large",
+			body:        "cpu,host=a usage=1 1\ncpu,host=b usage=22 1",
+			chunked:     true,
+			maxBodySize: 39,
+			batchSize:   1,
+			status:      http.StatusRequestEntityTooLarge,
+			points:      1,
+			batches:     1,
+			written:     1,
+		},
+		{
+			name:   "parse errors",
+			body:   "cpu,host=a usage=1 1\ncpu,host=b usage=\n\ncpu,host=c usage=3 1\ncpu,host",
+			status: http.StatusBadRequest,
+			lines: []writeLineError{
+				{Line: 2, Reason: "unable to parse line: missing field value"},
+				{Line: 5, Reason: "unable to parse line: missing tag value"},
+			},
+			points:  2,
+			batches: 1,
+		},
+		{
+			name:   "dropped points",
+			body:   "cpu,host=a usage=1i,count=2i 1\nmem free=1i 1\ncpu,host=b usage=2 1",
+			status: http.StatusBadRequest,
+			lines: []writeLineError{
+				{Line: 1, Key: "cpu,host=a", Field: "usage", Reason: `field "usage" of measurement "cpu" has type integer, the schema requires float`},
+				{Line: 2, Key: "mem", Field: "free", Reason: `measurement "mem" is not in the schema of bucket "bucket"`},
+			},
+			points:  2,
+			batches: 1,
+		},
+		{
+			name:      "outside of scope",
+			body:      "cpu,host=a usage=1 1\ncpu,host=b usage=2 1\nmem free=1i 1",
+			predicate: `_measurement="cpu" AND host!="b"`,
+			status:    http.StatusBadRequest,
+			lines: []writeLineError{
+				{Line: 2, Key: "cpu,host=b", Field: "usage", Reason: "point is outside the scope of the authorization"},
+				{Line: 3, Key: "mem", Field: "free", Reason: "point is outside the scope of the authorization"},
+			},
+			points:  1,
+			batches: 1,
+		},
+		{
+			name:      "all outside of scope",
+			body:      "cpu,host=a usage=1 1",
+			predicate: `_measurement=~/^public_/`,
+			status:    http.StatusBadRequest,
+			lines: []writeLineError{
+				{Line: 1, Key: "cpu,host=a", Field: "usage", Reason: "point is outside the scope of the authorization"},
+			},
+		},
+		{
+			name:      "outside of scope and dropped",
+			body:      "cpu,host=a usage=1i,count=2i 1\ncpu,host=b usage=2 1",
+			predicate: `host="a"`,
+			status:    http.StatusBadRequest,
+			lines: []writeLineError{
+				{Line: 1, Key: "cpu,host=a", Field: "usage", Reason: `field "usage" of measurement "cpu" has type integer, the schema requires float`},
+				{Line: 2, Key: "cpu,host=b", Field: "usage", Reason: "point is outside the scope of the authorization"},
+			},
+			points:  1,
+			batches: 1,
+		},
+		{
+			name:      "errors over the limit",
+			body:      "cpu,host=\nmem free=1i 1\ncpu,host=b usage=2 1",
+			maxErrors: 1,
+			status:    http.StatusBadRequest,
+			lines: []writeLineError{
+				{Line: 1, Reason: "unable to parse line: missing tag value"},
+			},
+			truncated: true,
+			points:    1,
+			batches:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pw := &mock.PointsWriter{}
+			bw := &batchCountingPointsWriter{PointsWriter: storage.NewSchemaPointsWriter(pw, buckets)}
+			h := NewWriteHandler(&WriteBackend{
+				Logger:              zap.NewNop(),
+				PointsWriter:        bw,
+				BucketService:       buckets,
+				OrganizationService: orgs,
+				MaxWriteErrors:      tt.maxErrors,
+				MaxBodySize:         tt.maxBodySize,
+				MaxBatchSize:        tt.batchSize,
+			})
+
+			p, err := platform.NewPermissionAtID(bucketID, platform.WriteAction, platform.BucketsResourceType, orgID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p.Predicate = tt.predicate
+			r := httptest.NewRequest("POST", "/api/v2/write?org=org&bucket=bucket", strings.NewReader(tt.body))
+			if tt.chunked {
+				r.ContentLength = -1
+			}
+			r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{
+				Status:      platform.Active,
+				Permissions: []platform.Permission{*p},
+			}))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			if got, want := w.Code, tt.status; got != want {
+				t.Fatalf("unexpected status code: got %d, want %d: %s", got, want, w.Body.String())
+			}
+			if tt.status == http.StatusBadRequest {
+				var resp writeErrorResponse
+				if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+					t.Fatal(err)
+				}
+				if resp.Code != platform.EInvalid {
+					t.Errorf("unexpected code: got %q, want %q", resp.Code, platform.EInvalid)
+				}
+				if !reflect.DeepEqual(resp.Lines, tt.lines) {
+					t.Errorf("unexpected lines:\ngot  %+v\nwant %+v", resp.Lines, tt.lines)
+				}
+				if resp.Truncated != tt.truncated {
+					t.Errorf("unexpected truncated: got %v, want %v", resp.Truncated, tt.truncated)
+				}
+			}
+			if tt.status == http.StatusRequestEntityTooLarge {
+				var resp lineProtocolLengthError
+				if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+					t.Fatal(err)
+				}
+				if resp.Written != tt.written {
+					t.Errorf("unexpected lines written: got %d, want %d: %s", resp.Written, tt.written, resp.Message)
+				}
+			}
+			if got, want := len(pw.Points), tt.points; got != want {
+				t.Errorf("unexpected number of points written: got %d, want %d", got, want)
+			}
+			if got, want := bw.batches, tt.batches; got != want {
+				t.Errorf("unexpected number of batches written: got %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+type batchCountingPointsWriter struct {
+	storage.PointsWriter
+	batches int
+}
+
+func (w *batchCountingPointsWriter) WritePoints(ctx context.Context, points []models.Point) error {
+	w.batches++
+	return w.PointsWriter.WritePoints(ctx, points)
+}
+
+func TestWriteHandler_handleWrite_limited(t *testing.T) {
+	const (
+		orgID    = platform.ID(1)
+		bucketID = platform.ID(2)
+	)
+
+	orgs := mock.NewOrganizationService()
+	orgs.FindOrganizationF = func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+		return &platform.Organization{ID: orgID, Name: "org"}, nil
+	}
+	buckets := mock.NewBucketService()
+	buckets.FindBucketFn = func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+		return &platform.Bucket{ID: bucketID, OrganizationID: orgID, Name: "bucket"}, nil
+	}
+	orgLimits := mock.NewOrgLimitsService()
+	orgLimits.FindOrgLimitsFn = func(ctx context.Context, id platform.ID) (*platform.OrgLimits, error) {
+		return &platform.OrgLimits{OrgID: id, WritesPerSecond: 1}, nil
+	}
+
+	pw := &mock.PointsWriter{}
+	h := NewWriteHandler(&WriteBackend{
+		Logger:              zap.NewNop(),
+		PointsWriter:        pw,
+		BucketService:       buckets,
+		OrganizationService: orgs,
+		OrgLimiter:          limits.NewLimiter(orgLimits),
+	})
+
+	p, err := platform.NewPermissionAtID(bucketID, platform.WriteAction, platform.BucketsResourceType, orgID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	write := func() *httptest.ResponseRecorder {
+		r := httptest.NewRequest("POST", "/api/v2/write?org=org&bucket=bucket", strings.NewReader("cpu,host=a usage=1 1"))
+		r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{
+			Status:      platform.Active,
+			Permissions: []platform.Permission{*p},
+		}))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	if w := write(); w.Code != http.StatusNoContent {
+		t.Fatalf("unexpected status code: got %d, want %d: %s", w.Code, http.StatusNoContent, w.Body.String())
+	}
+
+	w := write()
+	if got, want := w.Code, http.StatusTooManyRequests; got != want {
+		t.Fatalf("unexpected status code: got %d, want %d: %s", got, want, w.Body.String())
+	}
+	if got, want := w.Header().Get("Retry-After"), "1"; got != want {
+		t.Errorf("unexpected Retry-After header: got %q, want %q", got, want)
+	}
+	if got, want := len(pw.Points), 1; got != want {
+		t.Errorf("unexpected number of points written: got %d, want %d", got, want)
+	}
+}
+
+type usageRecorder map[platform.UsageMetric]float64
+
+func (r usageRecorder) RecordUsage(ctx context.Context, u platform.Usage) {
+	r[u.Type] += u.Value
+}
+
+func TestWriteHandler_handleWrite_usage(t *testing.T) {
+	const (
+		orgID    = platform.ID(1)
+		bucketID = platform.ID(2)
+	)
+
+	orgs := mock.NewOrganizationService()
+	orgs.FindOrganizationF = func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+		return &platform.Organization{ID: orgID, Name: "org"}, nil
+	}
+	buckets := mock.NewBucketService()
+	buckets.FindBucketFn = func(ctx context.Context, filter platform.BucketFilter) (*platform.Bucket, error) {
+		return &platform.Bucket{ID: bucketID, OrganizationID: orgID, Name: "bucket"}, nil
+	}
+
+	usage := usageRecorder{}
+	h := NewWriteHandler(&WriteBackend{
+		Logger:              zap.NewNop(),
+		PointsWriter:        &mock.PointsWriter{},
+		BucketService:       buckets,
+		OrganizationService: orgs,
+		UsageRecorder:       usage,
+	})
+
+	p, err := platform.NewPermissionAtID(bucketID, platform.WriteAction, platform.BucketsResourceType, orgID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body := "cpu,host=a usage=1,idle=2 1\ncpu,host=a usage=3 2\nmem free=1i 1"
+	r := httptest.NewRequest("POST", "/api/v2/write?org=org&bucket=bucket", strings.NewReader(body))
+	r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &platform.Authorization{
+		Status:      platform.Active,
+		Permissions: []platform.Permission{*p},
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("unexpected status code: got %d, want %d: %s", w.Code, http.StatusNoContent, w.Body.String())
+	}
+
+	want := usageRecorder{
+		platform.UsageWriteRequestCount: 1,
+		platform.UsageWriteRequestBytes: float64(len(body)),
+		platform.UsageValues:            4,
+	}
+	if !reflect.DeepEqual(usage, want) {
+		t.Errorf("unexpected usage:\ngot  %v\nwant %v", usage, want)
+	}
+}
@@ -2,6 +2,7 @@ package inmem
 
 import (
 	"context"
+	"time"
 
 	platform "github.com/influxdata/influxdb"
 )
@@ -50,27 +51,31 @@ func (s *Service) FindAuthorizationByID(ctx context.Context, id platform.ID) (*p
 	return a, err
 }
 
-// FindAuthorizationByToken returns an authorization given a token.
+// FindAuthorizationByToken returns an authorization given a token. The token
+// replaced by the last rotation of an authorization is found for the grace
+// period of the rotation.
 func (s *Service) FindAuthorizationByToken(ctx context.Context, t string) (*platform.Authorization, error) {
-	var err error
 	op := OpPrefix + platform.OpFindAuthorizationByToken
-	var n int
-	var as []*platform.Authorization
-	as, n, err = s.FindAuthorizations(ctx, platform.AuthorizationFilter{Token: &t})
+	as, _, err := s.FindAuthorizations(ctx, platform.AuthorizationFilter{})
 	if err != nil {
 		return nil, &platform.Error{
 			Err: err,
 			Op:  op,
 		}
 	}
-	if n < 1 {
-		return nil, &platform.Error{
-			Code: platform.ENotFound,
-			Msg:  "authorization not found",
-			Op:   op,
+
+	now := s.time()
+	for _, a := range as {
+		if a.HasToken(t, now) {
+			return a, nil
 		}
 	}
-	return as[0], nil
+
+	return nil, &platform.Error{
+		Code: platform.ENotFound,
+		Msg:  "authorization not found",
+		Op:   op,
+	}
 }
 
 func filterAuthorizationsFn(filter platform.AuthorizationFilter) func(a *platform.Authorization) bool {
@@ -220,3 +225,37 @@ func (s *Service) SetAuthorizationStatus(ctx context.Context, id platform.ID, st
 	a.Status = status
 	return s.PutAuthorization(ctx, a)
 }
+
+// RotateAuthorization replaces the token of the authorization associated with
+// id with a new one. The token replaced is still found for the grace period.
+func (s *Service) RotateAuthorization(ctx context.Context, id platform.ID, grace time.Duration) (*platform.Authorization, error) {
+	op := OpPrefix + platform.OpRotateAuthorization
+	a, err := s.FindAuthorizationByID(ctx, id)
+	if err != nil {
+		return nil, &platform.Error{
+			Err: err,
+			Op:  op,
+		}
+	}
+
+	a.PreviousToken, a.PreviousTokenExpiresAt = "", nil
+	if grace > 0 {
+		expiresAt := s.time().Add(grace)
+		a.PreviousToken, a.PreviousTokenExpiresAt = a.Token, &expiresAt
+	}
+
+	if a.Token, err = s.TokenGenerator.Token(); err != nil {
+		return nil, &platform.Error{
+			Err: err,
+			Op:  op,
+		}
+	}
+
+	if err := s.PutAuthorization(ctx, a); err != nil {
+		return nil, &platform.Error{
+			Err: err,
+			Op:  op,
+		}
+	}
+	return a, nil
+}
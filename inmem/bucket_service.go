@@ -236,6 +236,19 @@ func (s *Service) FindBuckets(ctx context.Context, filter platform.BucketFilter,
 
 // CreateBucket creates a new bucket and sets b.ID with the new identifier.
 func (s *Service) CreateBucket(ctx context.Context, b *platform.Bucket) error {
+	if err := b.ValidSchema(); err != nil {
+		return &platform.Error{
+			Err: err,
+			Op:  OpPrefix + platform.OpCreateBucket,
+		}
+	}
+	if err := b.ValidDownsampling(); err != nil {
+		return &platform.Error{
+			Err: err,
+			Op:  OpPrefix + platform.OpCreateBucket,
+		}
+	}
+
 	if b.OrganizationID.Valid() {
 		_, pe := s.FindOrganizationByID(ctx, b.OrganizationID)
 		if pe != nil {
@@ -294,7 +307,29 @@ func (s *Service) UpdateBucket(ctx context.Context, id platform.ID, upd platform
 		b.RetentionPeriod = *upd.RetentionPeriod
 	}
 
-	s.bucketKV.Store(b.ID.String(), b)
+	if upd.ShardGroupDuration != nil {
+		b.ShardGroupDuration = *upd.ShardGroupDuration
+	}
+
+	if upd.MaxSeries != nil {
+		b.MaxSeries = *upd.MaxSeries
+	}
+
+	if err := upd.ApplySchema(b); err != nil {
+		return nil, &platform.Error{
+			Op:  OpPrefix + platform.OpUpdateBucket,
+			Err: err,
+		}
+	}
+
+	if err := upd.ApplyDownsampling(b); err != nil {
+		return nil, &platform.Error{
+			Op:  OpPrefix + platform.OpUpdateBucket,
+			Err: err,
+		}
+	}
+
+	s.bucketKV.Store(b.ID.String(), *b)
 
 	return b, nil
 }
@@ -2,17 +2,12 @@ package inmem
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"path"
 
 	platform "github.com/influxdata/influxdb"
 )
 
-var (
-	errDBRPMappingNotFound = fmt.Errorf("dbrp mapping not found")
-)
-
 func encodeDBRPMappingKey(cluster, db, rp string) string {
 	return path.Join(cluster, db, rp)
 }
@@ -20,7 +15,7 @@ func encodeDBRPMappingKey(cluster, db, rp string) string {
 func (c *Service) loadDBRPMapping(ctx context.Context, cluster, db, rp string) (*platform.DBRPMapping, error) {
 	i, ok := c.dbrpMappingKV.Load(encodeDBRPMappingKey(cluster, db, rp))
 	if !ok {
-		return nil, errDBRPMappingNotFound
+		return nil, platform.ErrDBRPMappingNotFound
 	}
 
 	m, ok := i.(platform.DBRPMapping)
@@ -83,7 +78,7 @@ func (s *Service) Find(ctx context.Context, filter platform.DBRPMappingFilter) (
 	}
 
 	if n < 1 {
-		return nil, errDBRPMappingNotFound
+		return nil, platform.ErrDBRPMappingNotFound
 	}
 
 	return mappings[0], nil
@@ -119,18 +114,32 @@ func (s *Service) FindMany(ctx context.Context, filter platform.DBRPMappingFilte
 // Create creates a new dbrp mapping.
 func (s *Service) Create(ctx context.Context, m *platform.DBRPMapping) error {
 	if err := m.Validate(); err != nil {
-		return nil
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
 	}
 	existing, err := s.loadDBRPMapping(ctx, m.Cluster, m.Database, m.RetentionPolicy)
-	if err != nil {
-		if err == errDBRPMappingNotFound {
-			return s.PutDBRPMapping(ctx, m)
-		}
+	if err != nil && err != platform.ErrDBRPMappingNotFound {
 		return err
 	}
+	if existing != nil && !existing.Equal(m) {
+		return platform.ErrDBRPMappingAlreadyExists
+	}
 
-	if !existing.Equal(m) {
-		return errors.New("dbrp mapping already exists")
+	if m.Default {
+		defaults, err := s.filterDBRPMappings(ctx, func(d *platform.DBRPMapping) bool {
+			return d.Default && d.Cluster == m.Cluster && d.Database == m.Database && d.RetentionPolicy != m.RetentionPolicy
+		})
+		if err != nil {
+			return err
+		}
+		if len(defaults) > 0 {
+			return &platform.Error{
+				Code: platform.EConflict,
+				Msg:  fmt.Sprintf("retention policy %q is the default of database %q already", defaults[0].RetentionPolicy, m.Database),
+			}
+		}
 	}
 
 	return s.PutDBRPMapping(ctx, m)
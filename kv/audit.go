@@ -0,0 +1,171 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+)
+
+var (
+	auditBucket = []byte("auditv1")
+)
+
+var _ influxdb.AuditService = (*Service)(nil)
+
+func (s *Service) initializeAudit(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(auditBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// encodeAuditKey returns the key of an audit event. Keys are ordered by the
+// time of the events, and by their ID for events recorded at the same time.
+func encodeAuditKey(e *influxdb.AuditEvent) ([]byte, error) {
+	id, err := e.ID.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	k := make([]byte, 8, 8+len(id))
+	// This needs to be big-endian so that the iteration order is preserved when scanning keys
+	binary.BigEndian.PutUint64(k, uint64(e.Time.UTC().UnixNano()))
+	return append(k, id...), nil
+}
+
+func encodeAuditTime(t int64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, uint64(t))
+	return k
+}
+
+// RecordAuditEvent records the event e, setting its ID, and its time if it is zero.
+func (s *Service) RecordAuditEvent(ctx context.Context, e *influxdb.AuditEvent) error {
+	e.ID = s.IDGenerator.ID()
+	if e.Time.IsZero() {
+		e.Time = s.time()
+	}
+
+	k, err := encodeAuditKey(e)
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Op:   influxdb.OpRecordAuditEvent,
+			Err:  err,
+		}
+	}
+
+	v, err := json.Marshal(e)
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInternal,
+			Op:   influxdb.OpRecordAuditEvent,
+			Err:  err,
+		}
+	}
+
+	return s.kv.Update(func(tx Tx) error {
+		b, err := tx.Bucket(auditBucket)
+		if err != nil {
+			return &influxdb.Error{
+				Op:  influxdb.OpRecordAuditEvent,
+				Err: err,
+			}
+		}
+
+		if err := b.Put(k, v); err != nil {
+			return &influxdb.Error{
+				Op:  influxdb.OpRecordAuditEvent,
+				Err: err,
+			}
+		}
+		return nil
+	})
+}
+
+// FindAuditEvents returns the events matching the filter, ordered by time,
+// and the number of events returned.
+func (s *Service) FindAuditEvents(ctx context.Context, filter influxdb.AuditEventFilter, opts ...influxdb.FindOptions) ([]*influxdb.AuditEvent, int, error) {
+	var opt influxdb.FindOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+
+	es := []*influxdb.AuditEvent{}
+	err := s.kv.View(func(tx Tx) error {
+		var err error
+		es, err = s.findAuditEvents(ctx, tx, filter, opt)
+		return err
+	})
+	if err != nil {
+		return nil, 0, &influxdb.Error{
+			Op:  influxdb.OpFindAuditEvents,
+			Err: err,
+		}
+	}
+
+	return es, len(es), nil
+}
+
+func (s *Service) findAuditEvents(ctx context.Context, tx Tx, filter influxdb.AuditEventFilter, opt influxdb.FindOptions) ([]*influxdb.AuditEvent, error) {
+	b, err := tx.Bucket(auditBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	cur, err := b.Cursor()
+	if err != nil {
+		return nil, err
+	}
+
+	var start, stop []byte
+	if !filter.Start.IsZero() {
+		start = encodeAuditTime(filter.Start.UTC().UnixNano())
+	}
+	if !filter.Stop.IsZero() {
+		stop = encodeAuditTime(filter.Stop.UTC().UnixNano())
+	}
+
+	// before and after report whether a key is outside of the time range of
+	// the filter, in the direction the cursor is moving from and to.
+	first, next := cur.First, cur.Next
+	before := func(k []byte) bool { return start != nil && bytes.Compare(k, start) < 0 }
+	after := func(k []byte) bool { return stop != nil && bytes.Compare(k, stop) >= 0 }
+	if opt.Descending {
+		first, next = cur.Last, cur.Prev
+		before, after = after, before
+	}
+
+	es := []*influxdb.AuditEvent{}
+	seen := 0
+	for k, v := first(); k != nil; k, v = next() {
+		if before(k) {
+			continue
+		}
+		if after(k) {
+			break
+		}
+
+		e := &influxdb.AuditEvent{}
+		if err := json.Unmarshal(v, e); err != nil {
+			return nil, err
+		}
+		if !filter.Match(e) {
+			continue
+		}
+
+		seen++
+		if seen <= opt.Offset {
+			continue
+		}
+		es = append(es, e)
+		if opt.Limit > 0 && len(es) >= opt.Limit {
+			break
+		}
+	}
+
+	return es, nil
+}
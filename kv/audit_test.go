@@ -0,0 +1,110 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kv"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestBoltAuditService(t *testing.T) {
+	s, closeFn, err := NewTestBoltStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+	defer closeFn()
+
+	testAuditService(s, t)
+}
+
+func TestInmemAuditService(t *testing.T) {
+	s, closeFn, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+	defer closeFn()
+
+	testAuditService(s, t)
+}
+
+func testAuditService(s kv.Store, t *testing.T) {
+	svc := kv.NewService(s)
+
+	ctx := context.Background()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing audit service: %v", err)
+	}
+
+	orgA := influxdbtesting.MustIDBase16("020f755c3c082000")
+	orgB := influxdbtesting.MustIDBase16("020f755c3c082001")
+	t0 := time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	events := []*influxdb.AuditEvent{
+		{Time: t0, OrgID: orgA, Action: influxdb.CreateAuditAction, ResourceType: influxdb.BucketsResourceType, Outcome: influxdb.AuditSuccess},
+		{Time: t0.Add(time.Minute), OrgID: orgB, Action: influxdb.UpdateAuditAction, ResourceType: influxdb.BucketsResourceType, Outcome: influxdb.AuditDenied},
+		{Time: t0.Add(2 * time.Minute), OrgID: orgA, Action: influxdb.DeleteAuditAction, ResourceType: influxdb.DashboardsResourceType, Outcome: influxdb.AuditSuccess},
+		{Time: t0.Add(3 * time.Minute), OrgID: orgA, Action: influxdb.UpdateAuditAction, ResourceType: influxdb.BucketsResourceType, Outcome: influxdb.AuditFailure, Error: "oops"},
+	}
+	// Record the events out of order to check they are returned ordered by time.
+	for _, i := range []int{2, 0, 3, 1} {
+		if err := svc.RecordAuditEvent(ctx, events[i]); err != nil {
+			t.Fatalf("failed to record audit event: %v", err)
+		}
+		if !events[i].ID.Valid() {
+			t.Fatalf("expected recorded event to have a valid ID")
+		}
+	}
+
+	buckets := influxdb.BucketsResourceType
+	tests := []struct {
+		name   string
+		filter influxdb.AuditEventFilter
+		opts   []influxdb.FindOptions
+		want   []*influxdb.AuditEvent
+	}{
+		{
+			name: "all",
+			want: events,
+		},
+		{
+			name:   "by org",
+			filter: influxdb.AuditEventFilter{OrgID: &orgA},
+			want:   []*influxdb.AuditEvent{events[0], events[2], events[3]},
+		},
+		{
+			name:   "by time range",
+			filter: influxdb.AuditEventFilter{Start: t0.Add(time.Minute), Stop: t0.Add(3 * time.Minute)},
+			want:   []*influxdb.AuditEvent{events[1], events[2]},
+		},
+		{
+			name:   "by resource type descending",
+			filter: influxdb.AuditEventFilter{ResourceType: &buckets},
+			opts:   []influxdb.FindOptions{{Descending: true}},
+			want:   []*influxdb.AuditEvent{events[3], events[1], events[0]},
+		},
+		{
+			name:   "time range descending with offset and limit",
+			filter: influxdb.AuditEventFilter{Stop: t0.Add(3 * time.Minute)},
+			opts:   []influxdb.FindOptions{{Descending: true, Offset: 1, Limit: 1}},
+			want:   []*influxdb.AuditEvent{events[1]},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, n, err := svc.FindAuditEvents(ctx, tt.filter, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != len(got) {
+				t.Errorf("expected count %d, got %d", len(got), n)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unexpected audit events: -want/+got\n%s", diff)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	influxdb "github.com/influxdata/influxdb"
 )
@@ -124,7 +125,21 @@ func (s *Service) findAuthorizationByToken(ctx context.Context, tx Tx, n string)
 			Err:  err,
 		}
 	}
-	return s.findAuthorizationByID(ctx, tx, id)
+
+	auth, err := s.findAuthorizationByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The token replaced by a rotation stays in the index until the next
+	// rotation, but is only a token of the authorization for the grace period.
+	if !auth.HasToken(n, s.time()) {
+		return nil, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  "authorization not found",
+		}
+	}
+	return auth, nil
 }
 
 func filterAuthorizationsFn(filter influxdb.AuthorizationFilter) func(a *influxdb.Authorization) bool {
@@ -392,6 +407,13 @@ func (s *Service) deleteAuthorization(ctx context.Context, tx Tx, id influxdb.ID
 			Err: err,
 		}
 	}
+	if a.PreviousToken != "" {
+		if err := idx.Delete(authIndexKey(a.PreviousToken)); err != nil {
+			return &influxdb.Error{
+				Err: err,
+			}
+		}
+	}
 	encodedID, err := id.Encode()
 	if err != nil {
 		return &influxdb.Error{
@@ -454,6 +476,106 @@ func (s *Service) updateAuthorization(ctx context.Context, tx Tx, id influxdb.ID
 	return nil
 }
 
+// RotateAuthorization replaces the token of the authorization with a new one.
+// The token replaced is still found for the grace period, if any.
+func (s *Service) RotateAuthorization(ctx context.Context, id influxdb.ID, grace time.Duration) (*influxdb.Authorization, error) {
+	var a *influxdb.Authorization
+	err := s.kv.Update(func(tx Tx) error {
+		auth, err := s.rotateAuthorization(ctx, tx, id, grace)
+		if err != nil {
+			return err
+		}
+
+		a = auth
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+func (s *Service) rotateAuthorization(ctx context.Context, tx Tx, id influxdb.ID, grace time.Duration) (*influxdb.Authorization, error) {
+	a, err := s.findAuthorizationByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := authIndexBucket(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the token replaced by the last rotation is kept.
+	if a.PreviousToken != "" {
+		if err := idx.Delete(authIndexKey(a.PreviousToken)); err != nil {
+			return nil, &influxdb.Error{
+				Err: err,
+			}
+		}
+	}
+
+	if grace > 0 {
+		expiresAt := s.time().Add(grace)
+		a.PreviousToken, a.PreviousTokenExpiresAt = a.Token, &expiresAt
+	} else {
+		if err := idx.Delete(authIndexKey(a.Token)); err != nil {
+			return nil, &influxdb.Error{
+				Err: err,
+			}
+		}
+		a.PreviousToken, a.PreviousTokenExpiresAt = "", nil
+	}
+
+	token, err := s.TokenGenerator.Token()
+	if err != nil {
+		return nil, &influxdb.Error{
+			Err: err,
+		}
+	}
+	a.Token = token
+
+	if err := s.uniqueAuthToken(ctx, tx, a); err != nil {
+		return nil, err
+	}
+
+	if err := s.putAuthorization(ctx, tx, a); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// SetAuthorizationsLastUsed sets the time the authorizations, keyed by ID,
+// were last used, unless they are known to have been used later. The
+// authorizations that no longer exist are skipped.
+func (s *Service) SetAuthorizationsLastUsed(ctx context.Context, used map[influxdb.ID]time.Time) error {
+	return s.kv.Update(func(tx Tx) error {
+		for id, t := range used {
+			a, err := s.findAuthorizationByID(ctx, tx, id)
+			if influxdb.ErrorCode(err) == influxdb.ENotFound {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			if a.LastUsedAt != nil && !t.After(*a.LastUsedAt) {
+				continue
+			}
+			lastUsedAt := t
+			a.LastUsedAt = &lastUsedAt
+
+			if err := s.putAuthorization(ctx, tx, a); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func authIndexBucket(tx Tx) (Bucket, error) {
 	b, err := tx.Bucket([]byte(authIndex))
 	if err != nil {
@@ -3,9 +3,11 @@ package kv_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/mock"
 	influxdbtesting "github.com/influxdata/influxdb/testing"
 )
 
@@ -91,3 +93,117 @@ func initAuthorizationService(s kv.Store, f influxdbtesting.AuthorizationFields,
 		}
 	}
 }
+
+func TestService_RotateAuthorization_Grace(t *testing.T) {
+	s, closeStore, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+	defer closeStore()
+
+	now := time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC)
+	svc := kv.NewService(s)
+	svc.WithTime(func() time.Time { return now })
+	ctx := context.Background()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing authorization service: %v", err)
+	}
+
+	a := &influxdb.Authorization{
+		ID:     influxdb.ID(1),
+		OrgID:  influxdb.ID(2),
+		UserID: influxdb.ID(3),
+		Token:  "old",
+		Status: influxdb.Active,
+	}
+	if err := svc.PutAuthorization(ctx, a); err != nil {
+		t.Fatalf("failed to populate authorization: %v", err)
+	}
+
+	svc.TokenGenerator = mock.NewTokenGenerator("new", nil)
+	if _, err := svc.RotateAuthorization(ctx, a.ID, time.Hour); err != nil {
+		t.Fatalf("failed to rotate authorization: %v", err)
+	}
+
+	for _, token := range []string{"old", "new"} {
+		got, err := svc.FindAuthorizationByToken(ctx, token)
+		if err != nil {
+			t.Fatalf("expected token %q to be found during the grace period: %v", token, err)
+		}
+		if got.ID != a.ID || got.Token != "new" {
+			t.Errorf("unexpected authorization found by token %q: %+v", token, got)
+		}
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := svc.FindAuthorizationByToken(ctx, "old"); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected the previous token to be not found after the grace period, got %v", err)
+	}
+
+	// Rotating again revokes the previous token.
+	now = now.Add(-30 * time.Minute)
+	svc.TokenGenerator = mock.NewTokenGenerator("newer", nil)
+	if _, err := svc.RotateAuthorization(ctx, a.ID, 0); err != nil {
+		t.Fatalf("failed to rotate authorization: %v", err)
+	}
+	for _, token := range []string{"old", "new"} {
+		if _, err := svc.FindAuthorizationByToken(ctx, token); influxdb.ErrorCode(err) != influxdb.ENotFound {
+			t.Errorf("expected token %q to be not found, got %v", token, err)
+		}
+	}
+	if _, err := svc.FindAuthorizationByToken(ctx, "newer"); err != nil {
+		t.Errorf("expected the new token to be found: %v", err)
+	}
+}
+
+func TestAuthorizationUseRecorder_Flush(t *testing.T) {
+	s, closeStore, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+	defer closeStore()
+
+	svc := kv.NewService(s)
+	ctx := context.Background()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing authorization service: %v", err)
+	}
+
+	a := &influxdb.Authorization{
+		ID:     influxdb.ID(1),
+		OrgID:  influxdb.ID(2),
+		UserID: influxdb.ID(3),
+		Token:  "tok",
+		Status: influxdb.Active,
+	}
+	if err := svc.PutAuthorization(ctx, a); err != nil {
+		t.Fatalf("failed to populate authorization: %v", err)
+	}
+
+	t0 := time.Date(2019, 7, 1, 0, 0, 0, 0, time.UTC)
+	r := kv.NewAuthorizationUseRecorder(svc)
+	r.RecordAuthorizationUse(a.ID, t0.Add(time.Minute))
+	r.RecordAuthorizationUse(a.ID, t0)
+	// The use of authorizations that do not exist is dropped.
+	r.RecordAuthorizationUse(influxdb.ID(4), t0)
+
+	got, err := svc.FindAuthorizationByID(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("failed to retrieve authorization: %v", err)
+	}
+	if got.LastUsedAt != nil {
+		t.Fatalf("expected the use to be written on flush, got %v", got.LastUsedAt)
+	}
+
+	if err := r.Flush(ctx); err != nil {
+		t.Fatalf("failed to flush: %v", err)
+	}
+
+	got, err = svc.FindAuthorizationByID(ctx, a.ID)
+	if err != nil {
+		t.Fatalf("failed to retrieve authorization: %v", err)
+	}
+	if got.LastUsedAt == nil || !got.LastUsedAt.Equal(t0.Add(time.Minute)) {
+		t.Errorf("expected last used at %v, got %v", t0.Add(time.Minute), got.LastUsedAt)
+	}
+}
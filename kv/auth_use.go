@@ -0,0 +1,116 @@
+package kv
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"go.uber.org/zap"
+)
+
+// DefaultAuthorizationUseFlushInterval is the default interval at which the
+// use of authorizations recorded is written.
+const DefaultAuthorizationUseFlushInterval = time.Minute
+
+var _ influxdb.AuthorizationUseRecorder = (*AuthorizationUseRecorder)(nil)
+
+// AuthorizationUseRecorder records the last use of authorizations.
+//
+// Authentication is on the path of every request, so the use is recorded in
+// memory, and periodically written to the LastUsedAt of the authorizations
+// in a single update.
+type AuthorizationUseRecorder struct {
+	Logger *zap.Logger
+
+	// FlushInterval is the interval at which the use recorded is written.
+	FlushInterval time.Duration
+
+	s *Service
+
+	mu   sync.Mutex
+	used map[influxdb.ID]time.Time
+
+	closing chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewAuthorizationUseRecorder returns a new AuthorizationUseRecorder that
+// writes the use recorded to the authorizations of s.
+func NewAuthorizationUseRecorder(s *Service) *AuthorizationUseRecorder {
+	return &AuthorizationUseRecorder{
+		Logger:        zap.NewNop(),
+		FlushInterval: DefaultAuthorizationUseFlushInterval,
+		s:             s,
+		used:          make(map[influxdb.ID]time.Time),
+	}
+}
+
+// Open starts writing the use recorded every FlushInterval.
+func (r *AuthorizationUseRecorder) Open(ctx context.Context) error {
+	if r.closing != nil {
+		return nil
+	}
+	r.closing = make(chan struct{})
+
+	ticker := time.NewTicker(r.FlushInterval)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.closing:
+				return
+			case <-ticker.C:
+				if err := r.Flush(context.Background()); err != nil {
+					r.Logger.Error("Failed to write the use of authorizations", zap.Error(err))
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Close stops writing the use recorded periodically, and writes the use
+// recorded since the last write.
+func (r *AuthorizationUseRecorder) Close() error {
+	if r.closing == nil {
+		return nil
+	}
+	close(r.closing)
+	r.wg.Wait()
+	r.closing = nil
+	return r.Flush(context.Background())
+}
+
+// RecordAuthorizationUse records that the authorization id authenticated a
+// request at t.
+func (r *AuthorizationUseRecorder) RecordAuthorizationUse(id influxdb.ID, t time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if last, ok := r.used[id]; !ok || t.After(last) {
+		r.used[id] = t
+	}
+}
+
+// Flush writes the use recorded since the last write. The use is recorded
+// again if it cannot be written.
+func (r *AuthorizationUseRecorder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	used := r.used
+	r.used = make(map[influxdb.ID]time.Time)
+	r.mu.Unlock()
+
+	if len(used) == 0 {
+		return nil
+	}
+
+	if err := r.s.SetAuthorizationsLastUsed(ctx, used); err != nil {
+		for id, t := range used {
+			r.RecordAuthorizationUse(id, t)
+		}
+		return err
+	}
+	return nil
+}
@@ -356,6 +356,13 @@ func (s *Service) CreateBucket(ctx context.Context, b *influxdb.Bucket) error {
 }
 
 func (s *Service) createBucket(ctx context.Context, tx Tx, b *influxdb.Bucket) error {
+	if err := b.ValidSchema(); err != nil {
+		return err
+	}
+	if err := b.ValidDownsampling(); err != nil {
+		return err
+	}
+
 	if b.OrganizationID.Valid() {
 		_, pe := s.findOrganizationByID(ctx, tx, b.OrganizationID)
 		if pe != nil {
@@ -394,6 +401,10 @@ func (s *Service) createBucket(ctx context.Context, tx Tx, b *influxdb.Bucket) e
 	if err := s.createBucketUserResourceMappings(ctx, tx, b); err != nil {
 		return err
 	}
+
+	if err := s.createBucketDBRPMapping(ctx, tx, b); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -572,6 +583,22 @@ func (s *Service) updateBucket(ctx context.Context, tx Tx, id influxdb.ID, upd i
 		b.RetentionPeriod = *upd.RetentionPeriod
 	}
 
+	if upd.ShardGroupDuration != nil {
+		b.ShardGroupDuration = *upd.ShardGroupDuration
+	}
+
+	if upd.MaxSeries != nil {
+		b.MaxSeries = *upd.MaxSeries
+	}
+
+	if err := upd.ApplySchema(b); err != nil {
+		return nil, err
+	}
+
+	if err := upd.ApplyDownsampling(b); err != nil {
+		return nil, err
+	}
+
 	if upd.Name != nil {
 		key, err := bucketIndexKey(b)
 		if err != nil {
@@ -662,6 +689,10 @@ func (s *Service) deleteBucket(ctx context.Context, tx Tx, id influxdb.ID) error
 		return err
 	}
 
+	if err := s.deleteBucketDBRPMappings(ctx, tx, id); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -0,0 +1,244 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+)
+
+var (
+	checkBucket = []byte("checksv1")
+)
+
+var _ influxdb.CheckService = (*Service)(nil)
+
+func (s *Service) initializeChecks(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(checkBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindCheckByID returns a single check by ID.
+func (s *Service) FindCheckByID(ctx context.Context, id influxdb.ID) (*influxdb.Check, error) {
+	var c *influxdb.Check
+	err := s.kv.View(func(tx Tx) error {
+		var err error
+		c, err = s.findCheckByID(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpFindCheckByID,
+			Err: err,
+		}
+	}
+	return c, nil
+}
+
+func (s *Service) findCheckByID(ctx context.Context, tx Tx, id influxdb.ID) (*influxdb.Check, error) {
+	encID, err := id.Encode()
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+
+	b, err := tx.Bucket(checkBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := b.Get(encID)
+	if IsNotFound(err) {
+		return nil, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  influxdb.ErrCheckNotFound,
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &influxdb.Check{}
+	if err := json.Unmarshal(v, c); err != nil {
+		return nil, &influxdb.Error{
+			Err: err,
+		}
+	}
+	return c, nil
+}
+
+// FindChecks returns a list of checks that match filter and the total count of matching checks.
+func (s *Service) FindChecks(ctx context.Context, filter influxdb.CheckFilter, opt ...influxdb.FindOptions) ([]*influxdb.Check, int, error) {
+	if filter.ID != nil {
+		c, err := s.FindCheckByID(ctx, *filter.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+		return []*influxdb.Check{c}, 1, nil
+	}
+
+	cs := []*influxdb.Check{}
+	err := s.kv.View(func(tx Tx) error {
+		b, err := tx.Bucket(checkBucket)
+		if err != nil {
+			return err
+		}
+
+		cur, err := b.Cursor()
+		if err != nil {
+			return err
+		}
+
+		for k, v := cur.First(); k != nil; k, v = cur.Next() {
+			c := &influxdb.Check{}
+			if err := json.Unmarshal(v, c); err != nil {
+				return err
+			}
+			if filter.OrganizationID != nil && c.OrganizationID != *filter.OrganizationID {
+				continue
+			}
+			if filter.Name != nil && c.Name != *filter.Name {
+				continue
+			}
+			cs = append(cs, c)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, &influxdb.Error{
+			Op:  influxdb.OpFindChecks,
+			Err: err,
+		}
+	}
+
+	if len(opt) > 0 {
+		cs = pageChecks(cs, opt[0])
+	}
+	return cs, len(cs), nil
+}
+
+func pageChecks(cs []*influxdb.Check, opt influxdb.FindOptions) []*influxdb.Check {
+	if opt.Descending {
+		for i, j := 0, len(cs)-1; i < j; i, j = i+1, j-1 {
+			cs[i], cs[j] = cs[j], cs[i]
+		}
+	}
+	if opt.Offset >= len(cs) {
+		return []*influxdb.Check{}
+	}
+	cs = cs[opt.Offset:]
+	if opt.Limit > 0 && opt.Limit < len(cs) {
+		cs = cs[:opt.Limit]
+	}
+	return cs
+}
+
+// CreateCheck creates a new check and sets c.ID with the new identifier.
+func (s *Service) CreateCheck(ctx context.Context, c *influxdb.Check) error {
+	if err := c.Valid(); err != nil {
+		return &influxdb.Error{
+			Op:  influxdb.OpCreateCheck,
+			Err: err,
+		}
+	}
+	if c.Status == "" {
+		c.Status = influxdb.Active
+	}
+
+	return s.kv.Update(func(tx Tx) error {
+		c.ID = s.IDGenerator.ID()
+		if err := s.putCheck(ctx, tx, c); err != nil {
+			return &influxdb.Error{
+				Op:  influxdb.OpCreateCheck,
+				Err: err,
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateCheck replaces the check with the ID id by c.
+func (s *Service) UpdateCheck(ctx context.Context, id influxdb.ID, c *influxdb.Check) (*influxdb.Check, error) {
+	if err := c.Valid(); err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpUpdateCheck,
+			Err: err,
+		}
+	}
+
+	err := s.kv.Update(func(tx Tx) error {
+		current, err := s.findCheckByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		c.ID = current.ID
+		c.OrganizationID = current.OrganizationID
+		c.AuthorizationID = current.AuthorizationID
+		if c.Status == "" {
+			c.Status = current.Status
+		}
+		return s.putCheck(ctx, tx, c)
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpUpdateCheck,
+			Err: err,
+		}
+	}
+	return c, nil
+}
+
+func (s *Service) putCheck(ctx context.Context, tx Tx, c *influxdb.Check) error {
+	v, err := json.Marshal(c)
+	if err != nil {
+		return &influxdb.Error{
+			Err: err,
+		}
+	}
+
+	encID, err := c.ID.Encode()
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+
+	b, err := tx.Bucket(checkBucket)
+	if err != nil {
+		return err
+	}
+	return b.Put(encID, v)
+}
+
+// DeleteCheck removes a check by ID.
+func (s *Service) DeleteCheck(ctx context.Context, id influxdb.ID) error {
+	err := s.kv.Update(func(tx Tx) error {
+		if _, err := s.findCheckByID(ctx, tx, id); err != nil {
+			return err
+		}
+
+		encID, err := id.Encode()
+		if err != nil {
+			return err
+		}
+
+		b, err := tx.Bucket(checkBucket)
+		if err != nil {
+			return err
+		}
+		return b.Delete(encID)
+	})
+	if err != nil {
+		return &influxdb.Error{
+			Op:  influxdb.OpDeleteCheck,
+			Err: err,
+		}
+	}
+	return nil
+}
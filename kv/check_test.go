@@ -0,0 +1,94 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestBoltCheckService(t *testing.T) {
+	s, closeFn, err := NewTestBoltStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+	defer closeFn()
+
+	testCheckService(s, t)
+}
+
+func TestInmemCheckService(t *testing.T) {
+	s, closeFn, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+	defer closeFn()
+
+	testCheckService(s, t)
+}
+
+func testCheckService(s kv.Store, t *testing.T) {
+	svc := kv.NewService(s)
+	svc.IDGenerator = mock.NewIDGenerator("020f755c3c082000", t)
+
+	ctx := context.Background()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing check service: %v", err)
+	}
+
+	orgID := influxdbtesting.MustIDBase16("020f755c3c082001")
+	max := 90.0
+	c := &influxdb.Check{
+		OrganizationID: orgID,
+		Name:           "cpu",
+		Type:           influxdb.ThresholdCheckType,
+		Query:          `from(bucket: "telegraf") |> range(start: -1m)`,
+		Every:          "1m",
+		Thresholds: []influxdb.CheckThreshold{
+			{Level: influxdb.CheckCrit, Min: &max},
+		},
+		AuthorizationID: influxdbtesting.MustIDBase16("020f755c3c082002"),
+	}
+
+	if err := svc.CreateCheck(ctx, &influxdb.Check{Name: "invalid"}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid check error, got %v", err)
+	}
+
+	if err := svc.CreateCheck(ctx, c); err != nil {
+		t.Fatalf("failed to create check: %v", err)
+	}
+	if c.ID != influxdbtesting.MustIDBase16("020f755c3c082000") || c.Status != influxdb.Active {
+		t.Fatalf("unexpected created check %+v", c)
+	}
+
+	cs, n, err := svc.FindChecks(ctx, influxdb.CheckFilter{OrganizationID: &orgID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]*influxdb.Check{c}, cs); diff != "" || n != 1 {
+		t.Fatalf("unexpected checks: -want/+got\n%s", diff)
+	}
+
+	upd := *c
+	upd.Name = "cpu usage"
+	upd.OrganizationID = influxdbtesting.MustIDBase16("020f755c3c082003")
+	upd.Status = influxdb.Inactive
+	got, err := svc.UpdateCheck(ctx, c.ID, &upd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "cpu usage" || got.Status != influxdb.Inactive || got.OrganizationID != orgID {
+		t.Fatalf("unexpected updated check %+v", got)
+	}
+
+	if err := svc.DeleteCheck(ctx, c.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.FindCheckByID(ctx, c.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected check not found error, got %v", err)
+	}
+}
@@ -0,0 +1,350 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+
+	"github.com/influxdata/influxdb"
+)
+
+var (
+	dbrpMappingBucket = []byte("dbrpmappingsv1")
+)
+
+var _ influxdb.DBRPMappingService = (*Service)(nil)
+
+func (s *Service) initializeDBRPMappings(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(dbrpMappingBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// dbrpMappingKey is the key of a mapping. The names of the cluster, database
+// and retention policy of a valid mapping cannot contain a slash.
+func dbrpMappingKey(cluster, db, rp string) []byte {
+	return []byte(path.Join(cluster, db, rp))
+}
+
+// FindBy returns the dbrp mapping of the cluster, database and retention policy.
+func (s *Service) FindBy(ctx context.Context, cluster, db, rp string) (*influxdb.DBRPMapping, error) {
+	var m *influxdb.DBRPMapping
+	err := s.kv.View(func(tx Tx) error {
+		var err error
+		m, err = s.findDBRPMapping(ctx, tx, cluster, db, rp)
+		return err
+	})
+
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  OpPrefix + influxdb.OpFindDBRPMapping,
+			Err: err,
+		}
+	}
+
+	return m, nil
+}
+
+func (s *Service) findDBRPMapping(ctx context.Context, tx Tx, cluster, db, rp string) (*influxdb.DBRPMapping, error) {
+	b, err := tx.Bucket(dbrpMappingBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := b.Get(dbrpMappingKey(cluster, db, rp))
+	if IsNotFound(err) {
+		return nil, influxdb.ErrDBRPMappingNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m := &influxdb.DBRPMapping{}
+	if err := json.Unmarshal(v, m); err != nil {
+		return nil, &influxdb.Error{
+			Err: err,
+		}
+	}
+
+	return m, nil
+}
+
+// Find returns the first dbrp mapping that matches filter.
+func (s *Service) Find(ctx context.Context, filter influxdb.DBRPMappingFilter) (*influxdb.DBRPMapping, error) {
+	if filter.Cluster == nil && filter.Database == nil && filter.RetentionPolicy == nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Op:   OpPrefix + influxdb.OpFindDBRPMapping,
+			Msg:  "no filter parameters provided",
+		}
+	}
+
+	ms, _, err := s.FindMany(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ms) == 0 {
+		return nil, &influxdb.Error{
+			Op:  OpPrefix + influxdb.OpFindDBRPMapping,
+			Err: influxdb.ErrDBRPMappingNotFound,
+		}
+	}
+
+	return ms[0], nil
+}
+
+// FindMany returns the dbrp mappings that match filter and their total count.
+func (s *Service) FindMany(ctx context.Context, filter influxdb.DBRPMappingFilter, opt ...influxdb.FindOptions) ([]*influxdb.DBRPMapping, int, error) {
+	if filter.Cluster != nil && filter.Database != nil && filter.RetentionPolicy != nil {
+		m, err := s.FindBy(ctx, *filter.Cluster, *filter.Database, *filter.RetentionPolicy)
+		if err != nil {
+			return nil, 0, err
+		}
+		if filter.Default != nil && *filter.Default != m.Default {
+			return []*influxdb.DBRPMapping{}, 0, nil
+		}
+		return []*influxdb.DBRPMapping{m}, 1, nil
+	}
+
+	ms := []*influxdb.DBRPMapping{}
+	err := s.kv.View(func(tx Tx) error {
+		var err error
+		ms, err = s.findDBRPMappings(ctx, tx, filter)
+		return err
+	})
+
+	if err != nil {
+		return nil, 0, &influxdb.Error{
+			Op:  OpPrefix + influxdb.OpFindDBRPMappings,
+			Err: err,
+		}
+	}
+
+	return ms, len(ms), nil
+}
+
+func (s *Service) findDBRPMappings(ctx context.Context, tx Tx, filter influxdb.DBRPMappingFilter) ([]*influxdb.DBRPMapping, error) {
+	ms := []*influxdb.DBRPMapping{}
+	err := s.forEachDBRPMapping(ctx, tx, func(m *influxdb.DBRPMapping) bool {
+		if (filter.Cluster == nil || *filter.Cluster == m.Cluster) &&
+			(filter.Database == nil || *filter.Database == m.Database) &&
+			(filter.RetentionPolicy == nil || *filter.RetentionPolicy == m.RetentionPolicy) &&
+			(filter.Default == nil || *filter.Default == m.Default) {
+			ms = append(ms, m)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ms, nil
+}
+
+// forEachDBRPMapping will iterate through all dbrp mappings while fn returns true.
+func (s *Service) forEachDBRPMapping(ctx context.Context, tx Tx, fn func(*influxdb.DBRPMapping) bool) error {
+	b, err := tx.Bucket(dbrpMappingBucket)
+	if err != nil {
+		return err
+	}
+
+	cur, err := b.Cursor()
+	if err != nil {
+		return err
+	}
+
+	for k, v := cur.First(); k != nil; k, v = cur.Next() {
+		m := &influxdb.DBRPMapping{}
+		if err := json.Unmarshal(v, m); err != nil {
+			return err
+		}
+		if !fn(m) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// Create creates a new dbrp mapping. Creating a mapping identical to an
+// existing one is not an error. A database has at most one default mapping.
+func (s *Service) Create(ctx context.Context, m *influxdb.DBRPMapping) error {
+	if err := m.Validate(); err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Op:   OpPrefix + influxdb.OpCreateDBRPMapping,
+			Err:  err,
+		}
+	}
+
+	err := s.kv.Update(func(tx Tx) error {
+		existing, err := s.findDBRPMapping(ctx, tx, m.Cluster, m.Database, m.RetentionPolicy)
+		if err != nil && influxdb.ErrorCode(err) != influxdb.ENotFound {
+			return err
+		}
+		if existing != nil && !existing.Equal(m) {
+			return influxdb.ErrDBRPMappingAlreadyExists
+		}
+		if m.Default {
+			if err := s.checkDefaultDBRPMapping(ctx, tx, m); err != nil {
+				return err
+			}
+		}
+
+		return s.putDBRPMapping(ctx, tx, m)
+	})
+
+	if err != nil {
+		return &influxdb.Error{
+			Op:  OpPrefix + influxdb.OpCreateDBRPMapping,
+			Err: err,
+		}
+	}
+
+	return nil
+}
+
+// checkDefaultDBRPMapping returns a conflict if another retention policy is
+// the default one of the database of m.
+func (s *Service) checkDefaultDBRPMapping(ctx context.Context, tx Tx, m *influxdb.DBRPMapping) error {
+	isDefault := true
+	ms, err := s.findDBRPMappings(ctx, tx, influxdb.DBRPMappingFilter{
+		Cluster:  &m.Cluster,
+		Database: &m.Database,
+		Default:  &isDefault,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, d := range ms {
+		if d.RetentionPolicy != m.RetentionPolicy {
+			return &influxdb.Error{
+				Code: influxdb.EConflict,
+				Msg:  fmt.Sprintf("retention policy %q is the default of database %q already", d.RetentionPolicy, d.Database),
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Service) putDBRPMapping(ctx context.Context, tx Tx, m *influxdb.DBRPMapping) error {
+	v, err := json.Marshal(m)
+	if err != nil {
+		return &influxdb.Error{
+			Err: err,
+		}
+	}
+
+	b, err := tx.Bucket(dbrpMappingBucket)
+	if err != nil {
+		return err
+	}
+
+	if err := b.Put(dbrpMappingKey(m.Cluster, m.Database, m.RetentionPolicy), v); err != nil {
+		return &influxdb.Error{
+			Err: err,
+		}
+	}
+
+	return nil
+}
+
+// Delete removes a dbrp mapping. Deleting a mapping that does not exist is not an error.
+func (s *Service) Delete(ctx context.Context, cluster, db, rp string) error {
+	err := s.kv.Update(func(tx Tx) error {
+		b, err := tx.Bucket(dbrpMappingBucket)
+		if err != nil {
+			return err
+		}
+		return b.Delete(dbrpMappingKey(cluster, db, rp))
+	})
+
+	if err != nil {
+		return &influxdb.Error{
+			Op:  OpPrefix + influxdb.OpDeleteDBRPMapping,
+			Err: err,
+		}
+	}
+
+	return nil
+}
+
+// createBucketDBRPMapping maps the database named after the bucket, and its
+// retention policy or autogen, to the bucket, so that the bucket can be used
+// with the 1.x compatible API. The mapping is the default one of the database
+// unless the database already has one. Nothing is mapped when the names are
+// not valid for a database, or when they are mapped already.
+//
+// Databases are not scoped by organization: the first bucket created with a
+// name claims the database of that name in the default cluster, and buckets
+// of the same name created later, in any organization, are left unmapped.
+// Such mappings can be deleted and recreated through the dbrp mapping API.
+func (s *Service) createBucketDBRPMapping(ctx context.Context, tx Tx, b *influxdb.Bucket) error {
+	m := &influxdb.DBRPMapping{
+		Cluster:         influxdb.DefaultDBRPCluster,
+		Database:        b.Name,
+		RetentionPolicy: b.RetentionPolicyName,
+		OrganizationID:  b.OrganizationID,
+		BucketID:        b.ID,
+	}
+	if m.RetentionPolicy == "" {
+		m.RetentionPolicy = influxdb.DefaultDBRPRetentionPolicy
+	}
+	if err := m.Validate(); err != nil {
+		return nil
+	}
+
+	_, err := s.findDBRPMapping(ctx, tx, m.Cluster, m.Database, m.RetentionPolicy)
+	if err == nil {
+		return nil
+	}
+	if influxdb.ErrorCode(err) != influxdb.ENotFound {
+		return err
+	}
+
+	isDefault := true
+	defaults, err := s.findDBRPMappings(ctx, tx, influxdb.DBRPMappingFilter{
+		Cluster:  &m.Cluster,
+		Database: &m.Database,
+		Default:  &isDefault,
+	})
+	if err != nil {
+		return err
+	}
+	m.Default = len(defaults) == 0
+
+	return s.putDBRPMapping(ctx, tx, m)
+}
+
+// deleteBucketDBRPMappings removes the dbrp mappings of a bucket.
+func (s *Service) deleteBucketDBRPMappings(ctx context.Context, tx Tx, bucketID influxdb.ID) error {
+	var keys [][]byte
+	err := s.forEachDBRPMapping(ctx, tx, func(m *influxdb.DBRPMapping) bool {
+		if m.BucketID == bucketID {
+			keys = append(keys, dbrpMappingKey(m.Cluster, m.Database, m.RetentionPolicy))
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	b, err := tx.Bucket(dbrpMappingBucket)
+	if err != nil {
+		return err
+	}
+
+	for _, k := range keys {
+		if err := b.Delete(k); err != nil {
+			return &influxdb.Error{
+				Err: err,
+			}
+		}
+	}
+
+	return nil
+}
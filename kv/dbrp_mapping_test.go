@@ -0,0 +1,136 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kv"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestBoltDBRPMappingService(t *testing.T) {
+	t.Run("CreateDBRPMapping", func(t *testing.T) { influxdbtesting.CreateDBRPMapping(initBoltDBRPMappingService, t) })
+	t.Run("FindDBRPMappingByKey", func(t *testing.T) { influxdbtesting.FindDBRPMappingByKey(initBoltDBRPMappingService, t) })
+	t.Run("FindDBRPMappings", func(t *testing.T) { influxdbtesting.FindDBRPMappings(initBoltDBRPMappingService, t) })
+	t.Run("FindDBRPMapping", func(t *testing.T) { influxdbtesting.FindDBRPMapping(initBoltDBRPMappingService, t) })
+	t.Run("DeleteDBRPMapping", func(t *testing.T) { influxdbtesting.DeleteDBRPMapping(initBoltDBRPMappingService, t) })
+}
+
+func TestInmemDBRPMappingService(t *testing.T) {
+	t.Run("CreateDBRPMapping", func(t *testing.T) { influxdbtesting.CreateDBRPMapping(initInmemDBRPMappingService, t) })
+	t.Run("FindDBRPMappingByKey", func(t *testing.T) { influxdbtesting.FindDBRPMappingByKey(initInmemDBRPMappingService, t) })
+	t.Run("FindDBRPMappings", func(t *testing.T) { influxdbtesting.FindDBRPMappings(initInmemDBRPMappingService, t) })
+	t.Run("FindDBRPMapping", func(t *testing.T) { influxdbtesting.FindDBRPMapping(initInmemDBRPMappingService, t) })
+	t.Run("DeleteDBRPMapping", func(t *testing.T) { influxdbtesting.DeleteDBRPMapping(initInmemDBRPMappingService, t) })
+}
+
+func initBoltDBRPMappingService(f influxdbtesting.DBRPMappingFields, t *testing.T) (influxdb.DBRPMappingService, func()) {
+	s, closeBolt, err := NewTestBoltStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+
+	svc, closeSvc := initDBRPMappingService(s, f, t)
+	return svc, func() {
+		closeSvc()
+		closeBolt()
+	}
+}
+
+func initInmemDBRPMappingService(f influxdbtesting.DBRPMappingFields, t *testing.T) (influxdb.DBRPMappingService, func()) {
+	s, closeBolt, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+
+	svc, closeSvc := initDBRPMappingService(s, f, t)
+	return svc, func() {
+		closeSvc()
+		closeBolt()
+	}
+}
+
+func initDBRPMappingService(s kv.Store, f influxdbtesting.DBRPMappingFields, t *testing.T) (influxdb.DBRPMappingService, func()) {
+	svc := kv.NewService(s)
+
+	ctx := context.Background()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing dbrp mapping service: %v", err)
+	}
+	if err := f.Populate(ctx, svc); err != nil {
+		t.Fatal(err)
+	}
+	return svc, func() {
+		if err := influxdbtesting.CleanupDBRPMappings(ctx, svc); err != nil {
+			t.Logf("failed to remove dbrp mappings: %v", err)
+		}
+	}
+}
+
+func TestService_BucketDBRPMappings(t *testing.T) {
+	s, closeStore, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+	defer closeStore()
+
+	svc := kv.NewService(s)
+
+	ctx := context.Background()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	o1 := &influxdb.Organization{Name: "o1"}
+	o2 := &influxdb.Organization{Name: "o2"}
+	for _, o := range []*influxdb.Organization{o1, o2} {
+		if err := svc.CreateOrganization(ctx, o); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	telegraf := &influxdb.Bucket{OrganizationID: o1.ID, Name: "telegraf"}
+	weekly := &influxdb.Bucket{OrganizationID: o1.ID, Name: "telegraf/weekly"}
+	monthly := &influxdb.Bucket{OrganizationID: o1.ID, Name: "monthly", RetentionPolicyName: "one_month"}
+	other := &influxdb.Bucket{OrganizationID: o2.ID, Name: "telegraf"}
+	for _, b := range []*influxdb.Bucket{telegraf, weekly, monthly, other} {
+		if err := svc.CreateBucket(ctx, b); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m, err := svc.FindBy(ctx, influxdb.DefaultDBRPCluster, "telegraf", influxdb.DefaultDBRPRetentionPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The database is mapped to the bucket of the organization that created it first.
+	exp := &influxdb.DBRPMapping{
+		Cluster:         influxdb.DefaultDBRPCluster,
+		Database:        "telegraf",
+		RetentionPolicy: influxdb.DefaultDBRPRetentionPolicy,
+		Default:         true,
+		OrganizationID:  o1.ID,
+		BucketID:        telegraf.ID,
+	}
+	if !m.Equal(exp) {
+		t.Fatalf("unexpected mapping of bucket: got %+v, want %+v", m, exp)
+	}
+
+	if _, err := svc.FindBy(ctx, influxdb.DefaultDBRPCluster, "monthly", "one_month"); err != nil {
+		t.Fatalf("expected bucket to be mapped with its retention policy: %v", err)
+	}
+
+	// A bucket name that is not valid as a database name is not mapped.
+	if _, n, err := svc.FindMany(ctx, influxdb.DBRPMappingFilter{}); err != nil || n != 2 {
+		t.Fatalf("expected 2 mappings, got %d: %v", n, err)
+	}
+
+	if err := svc.DeleteBucket(ctx, telegraf.ID); err != nil {
+		t.Fatal(err)
+	}
+	_, err = svc.FindBy(ctx, influxdb.DefaultDBRPCluster, "telegraf", influxdb.DefaultDBRPRetentionPolicy)
+	if influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected mapping of deleted bucket to be removed; got %v", err)
+	}
+}
@@ -0,0 +1,243 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+)
+
+var (
+	notificationEndpointBucket = []byte("notificationendpointsv1")
+)
+
+var _ influxdb.NotificationEndpointService = (*Service)(nil)
+
+func (s *Service) initializeNotificationEndpoints(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(notificationEndpointBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindNotificationEndpointByID returns a single notification endpoint by ID.
+func (s *Service) FindNotificationEndpointByID(ctx context.Context, id influxdb.ID) (*influxdb.NotificationEndpoint, error) {
+	var e *influxdb.NotificationEndpoint
+	err := s.kv.View(func(tx Tx) error {
+		var err error
+		e, err = s.findNotificationEndpointByID(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpFindNotificationEndpointByID,
+			Err: err,
+		}
+	}
+	return e, nil
+}
+
+func (s *Service) findNotificationEndpointByID(ctx context.Context, tx Tx, id influxdb.ID) (*influxdb.NotificationEndpoint, error) {
+	encID, err := id.Encode()
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+
+	b, err := tx.Bucket(notificationEndpointBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := b.Get(encID)
+	if IsNotFound(err) {
+		return nil, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  influxdb.ErrNotificationEndpointNotFound,
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	e := &influxdb.NotificationEndpoint{}
+	if err := json.Unmarshal(v, e); err != nil {
+		return nil, &influxdb.Error{
+			Err: err,
+		}
+	}
+	return e, nil
+}
+
+// FindNotificationEndpoints returns a list of notification endpoints that match filter and the total count of matching notification endpoints.
+func (s *Service) FindNotificationEndpoints(ctx context.Context, filter influxdb.NotificationEndpointFilter, opt ...influxdb.FindOptions) ([]*influxdb.NotificationEndpoint, int, error) {
+	if filter.ID != nil {
+		e, err := s.FindNotificationEndpointByID(ctx, *filter.ID)
+		if err != nil {
+			return nil, 0, err
+		}
+		return []*influxdb.NotificationEndpoint{e}, 1, nil
+	}
+
+	es := []*influxdb.NotificationEndpoint{}
+	err := s.kv.View(func(tx Tx) error {
+		b, err := tx.Bucket(notificationEndpointBucket)
+		if err != nil {
+			return err
+		}
+
+		cur, err := b.Cursor()
+		if err != nil {
+			return err
+		}
+
+		for k, v := cur.First(); k != nil; k, v = cur.Next() {
+			e := &influxdb.NotificationEndpoint{}
+			if err := json.Unmarshal(v, e); err != nil {
+				return err
+			}
+			if filter.OrganizationID != nil && e.OrganizationID != *filter.OrganizationID {
+				continue
+			}
+			if filter.Name != nil && e.Name != *filter.Name {
+				continue
+			}
+			es = append(es, e)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, &influxdb.Error{
+			Op:  influxdb.OpFindNotificationEndpoints,
+			Err: err,
+		}
+	}
+
+	if len(opt) > 0 {
+		es = pageNotificationEndpoints(es, opt[0])
+	}
+	return es, len(es), nil
+}
+
+func pageNotificationEndpoints(es []*influxdb.NotificationEndpoint, opt influxdb.FindOptions) []*influxdb.NotificationEndpoint {
+	if opt.Descending {
+		for i, j := 0, len(es)-1; i < j; i, j = i+1, j-1 {
+			es[i], es[j] = es[j], es[i]
+		}
+	}
+	if opt.Offset >= len(es) {
+		return []*influxdb.NotificationEndpoint{}
+	}
+	es = es[opt.Offset:]
+	if opt.Limit > 0 && opt.Limit < len(es) {
+		es = es[:opt.Limit]
+	}
+	return es
+}
+
+// CreateNotificationEndpoint creates a new notification endpoint and sets e.ID with the new identifier.
+func (s *Service) CreateNotificationEndpoint(ctx context.Context, e *influxdb.NotificationEndpoint) error {
+	if err := e.Valid(); err != nil {
+		return &influxdb.Error{
+			Op:  influxdb.OpCreateNotificationEndpoint,
+			Err: err,
+		}
+	}
+	if e.Status == "" {
+		e.Status = influxdb.Active
+	}
+
+	return s.kv.Update(func(tx Tx) error {
+		e.ID = s.IDGenerator.ID()
+		if err := s.putNotificationEndpoint(ctx, tx, e); err != nil {
+			return &influxdb.Error{
+				Op:  influxdb.OpCreateNotificationEndpoint,
+				Err: err,
+			}
+		}
+		return nil
+	})
+}
+
+// UpdateNotificationEndpoint replaces the notification endpoint with the ID id by e.
+func (s *Service) UpdateNotificationEndpoint(ctx context.Context, id influxdb.ID, e *influxdb.NotificationEndpoint) (*influxdb.NotificationEndpoint, error) {
+	if err := e.Valid(); err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpUpdateNotificationEndpoint,
+			Err: err,
+		}
+	}
+
+	err := s.kv.Update(func(tx Tx) error {
+		current, err := s.findNotificationEndpointByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		e.ID = current.ID
+		e.OrganizationID = current.OrganizationID
+		if e.Status == "" {
+			e.Status = current.Status
+		}
+		return s.putNotificationEndpoint(ctx, tx, e)
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpUpdateNotificationEndpoint,
+			Err: err,
+		}
+	}
+	return e, nil
+}
+
+func (s *Service) putNotificationEndpoint(ctx context.Context, tx Tx, e *influxdb.NotificationEndpoint) error {
+	v, err := json.Marshal(e)
+	if err != nil {
+		return &influxdb.Error{
+			Err: err,
+		}
+	}
+
+	encID, err := e.ID.Encode()
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+
+	b, err := tx.Bucket(notificationEndpointBucket)
+	if err != nil {
+		return err
+	}
+	return b.Put(encID, v)
+}
+
+// DeleteNotificationEndpoint removes a notification endpoint by ID.
+func (s *Service) DeleteNotificationEndpoint(ctx context.Context, id influxdb.ID) error {
+	err := s.kv.Update(func(tx Tx) error {
+		if _, err := s.findNotificationEndpointByID(ctx, tx, id); err != nil {
+			return err
+		}
+
+		encID, err := id.Encode()
+		if err != nil {
+			return err
+		}
+
+		b, err := tx.Bucket(notificationEndpointBucket)
+		if err != nil {
+			return err
+		}
+		return b.Delete(encID)
+	})
+	if err != nil {
+		return &influxdb.Error{
+			Op:  influxdb.OpDeleteNotificationEndpoint,
+			Err: err,
+		}
+	}
+	return nil
+}
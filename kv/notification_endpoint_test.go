@@ -0,0 +1,86 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kv"
+	"github.com/influxdata/influxdb/mock"
+	influxdbtesting "github.com/influxdata/influxdb/testing"
+)
+
+func TestBoltNotificationEndpointService(t *testing.T) {
+	s, closeFn, err := NewTestBoltStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+	defer closeFn()
+
+	testNotificationEndpointService(s, t)
+}
+
+func TestInmemNotificationEndpointService(t *testing.T) {
+	s, closeFn, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+	defer closeFn()
+
+	testNotificationEndpointService(s, t)
+}
+
+func testNotificationEndpointService(s kv.Store, t *testing.T) {
+	svc := kv.NewService(s)
+	svc.IDGenerator = mock.NewIDGenerator("020f755c3c082000", t)
+
+	ctx := context.Background()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing notification endpoint service: %v", err)
+	}
+
+	orgID := influxdbtesting.MustIDBase16("020f755c3c082001")
+	e := &influxdb.NotificationEndpoint{
+		OrganizationID: orgID,
+		Name:           "ops",
+		Type:           influxdb.SlackEndpointType,
+		URLSecret:      "slack-webhook",
+	}
+
+	if err := svc.CreateNotificationEndpoint(ctx, &influxdb.NotificationEndpoint{Name: "ops", Type: influxdb.HTTPEndpointType}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid notification endpoint error, got %v", err)
+	}
+
+	if err := svc.CreateNotificationEndpoint(ctx, e); err != nil {
+		t.Fatalf("failed to create notification endpoint: %v", err)
+	}
+
+	name := "ops"
+	es, n, err := svc.FindNotificationEndpoints(ctx, influxdb.NotificationEndpointFilter{Name: &name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]*influxdb.NotificationEndpoint{e}, es); diff != "" || n != 1 {
+		t.Fatalf("unexpected notification endpoints: -want/+got\n%s", diff)
+	}
+
+	upd := *e
+	upd.Type = influxdb.HTTPEndpointType
+	upd.URLSecret = ""
+	upd.URL = "http://localhost:8080/alerts"
+	got, err := svc.UpdateNotificationEndpoint(ctx, e.ID, &upd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.URL != upd.URL || got.Status != influxdb.Active || got.OrganizationID != orgID {
+		t.Fatalf("unexpected updated notification endpoint %+v", got)
+	}
+
+	if err := svc.DeleteNotificationEndpoint(ctx, e.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.FindNotificationEndpointByID(ctx, e.ID); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected notification endpoint not found error, got %v", err)
+	}
+}
@@ -450,7 +450,7 @@ func (s *Service) deleteOrganization(ctx context.Context, tx Tx, id influxdb.ID)
 		}
 	}
 
-	return nil
+	return s.deleteOrgLimits(ctx, tx, id)
 }
 
 //func (s *Service) deleteOrganizationsBuckets(ctx context.Context, tx Tx, id influxdb.ID) error {
@@ -593,6 +593,18 @@ func (s *Service) FindResourceOrganizationID(ctx context.Context, rt influxdb.Re
 			return influxdb.InvalidID(), err
 		}
 		return r.OrgID, nil
+	case influxdb.ChecksResourceType:
+		r, err := s.FindCheckByID(ctx, id)
+		if err != nil {
+			return influxdb.InvalidID(), err
+		}
+		return r.OrganizationID, nil
+	case influxdb.NotificationEndpointsResourceType:
+		r, err := s.FindNotificationEndpointByID(ctx, id)
+		if err != nil {
+			return influxdb.InvalidID(), err
+		}
+		return r.OrganizationID, nil
 	}
 
 	return influxdb.InvalidID(), &influxdb.Error{
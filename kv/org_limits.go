@@ -0,0 +1,150 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/influxdata/influxdb"
+)
+
+var (
+	orgLimitsBucket = []byte("orglimitsv1")
+)
+
+var _ influxdb.OrgLimitsService = (*Service)(nil)
+
+func (s *Service) initializeOrgLimits(ctx context.Context, tx Tx) error {
+	if _, err := tx.Bucket(orgLimitsBucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindOrgLimits returns the limits of an organization.
+func (s *Service) FindOrgLimits(ctx context.Context, orgID influxdb.ID) (*influxdb.OrgLimits, error) {
+	var l *influxdb.OrgLimits
+	err := s.kv.View(func(tx Tx) error {
+		var err error
+		l, err = s.findOrgLimits(ctx, tx, orgID)
+		return err
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpFindOrgLimits,
+			Err: err,
+		}
+	}
+	return l, nil
+}
+
+func (s *Service) findOrgLimits(ctx context.Context, tx Tx, orgID influxdb.ID) (*influxdb.OrgLimits, error) {
+	if _, err := s.findOrganizationByID(ctx, tx, orgID); err != nil {
+		return nil, err
+	}
+
+	key, err := orgID.Encode()
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+
+	b, err := tx.Bucket(orgLimitsBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := b.Get(key)
+	if IsNotFound(err) {
+		return &influxdb.OrgLimits{OrgID: orgID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	l := &influxdb.OrgLimits{}
+	if err := json.Unmarshal(v, l); err != nil {
+		return nil, &influxdb.Error{
+			Err: err,
+		}
+	}
+	return l, nil
+}
+
+// UpdateOrgLimits updates the limits of an organization.
+func (s *Service) UpdateOrgLimits(ctx context.Context, orgID influxdb.ID, upd influxdb.OrgLimitsUpdate) (*influxdb.OrgLimits, error) {
+	var l *influxdb.OrgLimits
+	err := s.kv.Update(func(tx Tx) error {
+		var err error
+		l, err = s.updateOrgLimits(ctx, tx, orgID, upd)
+		return err
+	})
+	if err != nil {
+		return nil, &influxdb.Error{
+			Op:  influxdb.OpUpdateOrgLimits,
+			Err: err,
+		}
+	}
+	return l, nil
+}
+
+func (s *Service) updateOrgLimits(ctx context.Context, tx Tx, orgID influxdb.ID, upd influxdb.OrgLimitsUpdate) (*influxdb.OrgLimits, error) {
+	l, err := s.findOrgLimits(ctx, tx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := upd.Apply(l); err != nil {
+		return nil, err
+	}
+
+	v, err := json.Marshal(l)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Err: err,
+		}
+	}
+
+	key, err := orgID.Encode()
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+
+	b, err := tx.Bucket(orgLimitsBucket)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.Put(key, v); err != nil {
+		return nil, &influxdb.Error{
+			Err: err,
+		}
+	}
+	return l, nil
+}
+
+func (s *Service) deleteOrgLimits(ctx context.Context, tx Tx, orgID influxdb.ID) error {
+	key, err := orgID.Encode()
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Err:  err,
+		}
+	}
+
+	b, err := tx.Bucket(orgLimitsBucket)
+	if err != nil {
+		return err
+	}
+
+	if err := b.Delete(key); err != nil && !IsNotFound(err) {
+		return &influxdb.Error{
+			Err: err,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/kv"
+)
+
+func TestBoltOrgLimitsService(t *testing.T) {
+	s, closeFn, err := NewTestBoltStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+	defer closeFn()
+
+	testOrgLimitsService(s, t)
+}
+
+func TestInmemOrgLimitsService(t *testing.T) {
+	s, closeFn, err := NewTestInmemStore()
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+	defer closeFn()
+
+	testOrgLimitsService(s, t)
+}
+
+func testOrgLimitsService(s kv.Store, t *testing.T) {
+	svc := kv.NewService(s)
+
+	ctx := context.Background()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing org limits service: %v", err)
+	}
+
+	org := &influxdb.Organization{Name: "org"}
+	if err := svc.CreateOrganization(ctx, org); err != nil {
+		t.Fatalf("failed to create organization: %v", err)
+	}
+
+	l, err := svc.FindOrgLimits(ctx, org.ID)
+	if err != nil {
+		t.Fatalf("failed to find org limits: %v", err)
+	}
+	if diff := cmp.Diff(l, &influxdb.OrgLimits{OrgID: org.ID}); diff != "" {
+		t.Errorf("unexpected default limits -got/+want:\n%s", diff)
+	}
+
+	writes, buckets := 10, 2
+	l, err = svc.UpdateOrgLimits(ctx, org.ID, influxdb.OrgLimitsUpdate{
+		WritesPerSecond: &writes,
+		MaxBuckets:      &buckets,
+	})
+	if err != nil {
+		t.Fatalf("failed to update org limits: %v", err)
+	}
+	want := &influxdb.OrgLimits{OrgID: org.ID, WritesPerSecond: 10, MaxBuckets: 2}
+	if diff := cmp.Diff(l, want); diff != "" {
+		t.Errorf("unexpected updated limits -got/+want:\n%s", diff)
+	}
+
+	queries := 4
+	if _, err := svc.UpdateOrgLimits(ctx, org.ID, influxdb.OrgLimitsUpdate{ConcurrentQueries: &queries}); err != nil {
+		t.Fatalf("failed to update org limits: %v", err)
+	}
+	want.ConcurrentQueries = 4
+	l, err = svc.FindOrgLimits(ctx, org.ID)
+	if err != nil {
+		t.Fatalf("failed to find org limits: %v", err)
+	}
+	if diff := cmp.Diff(l, want); diff != "" {
+		t.Errorf("unexpected limits -got/+want:\n%s", diff)
+	}
+
+	negative := -1
+	_, err = svc.UpdateOrgLimits(ctx, org.ID, influxdb.OrgLimitsUpdate{MaxBuckets: &negative})
+	if got := influxdb.ErrorCode(err); got != influxdb.EInvalid {
+		t.Errorf("expected invalid limits to be rejected, got %v", err)
+	}
+
+	if err := svc.DeleteOrganization(ctx, org.ID); err != nil {
+		t.Fatalf("failed to delete organization: %v", err)
+	}
+	_, err = svc.FindOrgLimits(ctx, org.ID)
+	if got := influxdb.ErrorCode(err); got != influxdb.ENotFound {
+		t.Errorf("expected limits of deleted organization to be not found, got %v", err)
+	}
+}
@@ -44,6 +44,10 @@ func NewService(kv Store) *Service {
 // Initialize creates Buckets needed.
 func (s *Service) Initialize(ctx context.Context) error {
 	return s.kv.Update(func(tx Tx) error {
+		if err := s.initializeAudit(ctx, tx); err != nil {
+			return err
+		}
+
 		if err := s.initializeAuths(ctx, tx); err != nil {
 			return err
 		}
@@ -52,10 +56,18 @@ func (s *Service) Initialize(ctx context.Context) error {
 			return err
 		}
 
+		if err := s.initializeChecks(ctx, tx); err != nil {
+			return err
+		}
+
 		if err := s.initializeDashboards(ctx, tx); err != nil {
 			return err
 		}
 
+		if err := s.initializeDBRPMappings(ctx, tx); err != nil {
+			return err
+		}
+
 		if err := s.initializeKVLog(ctx, tx); err != nil {
 			return err
 		}
@@ -64,6 +76,10 @@ func (s *Service) Initialize(ctx context.Context) error {
 			return err
 		}
 
+		if err := s.initializeNotificationEndpoints(ctx, tx); err != nil {
+			return err
+		}
+
 		if err := s.initializeOnboarding(ctx, tx); err != nil {
 			return err
 		}
@@ -72,6 +88,10 @@ func (s *Service) Initialize(ctx context.Context) error {
 			return err
 		}
 
+		if err := s.initializeOrgLimits(ctx, tx); err != nil {
+			return err
+		}
+
 		if err := s.initializePasswords(ctx, tx); err != nil {
 			return err
 		}
@@ -0,0 +1,252 @@
+package ldap
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// The subset of the basic encoding rules of ASN.1 used by LDAP messages
+// (RFC 4511 section 5.1): definite lengths, and tags below 31.
+
+const (
+	classUniversal   byte = 0x00
+	classApplication byte = 0x40
+	classContext     byte = 0x80
+
+	constructed byte = 0x20
+)
+
+const (
+	tagBoolean     byte = 0x01
+	tagInteger     byte = 0x02
+	tagOctetString byte = 0x04
+	tagEnumerated  byte = 0x0a
+	tagSequence    byte = 0x10
+	tagSet         byte = 0x11
+)
+
+// maxPacketSize bounds the size of the packets read, so that a misbehaving
+// server cannot make us allocate arbitrary amounts of memory.
+const maxPacketSize = 16 << 20
+
+var errInvalidPacket = errors.New("invalid ldap packet")
+
+// packet is an element of a BER encoding. Primitive packets have a value,
+// and constructed packets have children.
+type packet struct {
+	class       byte
+	constructed bool
+	tag         byte
+
+	value    []byte
+	children []*packet
+}
+
+func newPacket(class byte, tag byte, children ...*packet) *packet {
+	return &packet{
+		class:       class,
+		constructed: true,
+		tag:         tag,
+		children:    children,
+	}
+}
+
+func newSequence(children ...*packet) *packet {
+	return newPacket(classUniversal, tagSequence, children...)
+}
+
+func newSet(children ...*packet) *packet {
+	return newPacket(classUniversal, tagSet, children...)
+}
+
+func newPrimitive(class byte, tag byte, value []byte) *packet {
+	return &packet{
+		class: class,
+		tag:   tag,
+		value: value,
+	}
+}
+
+func newOctetString(s string) *packet {
+	return newPrimitive(classUniversal, tagOctetString, []byte(s))
+}
+
+func newBoolean(b bool) *packet {
+	v := byte(0x00)
+	if b {
+		v = 0xff
+	}
+	return newPrimitive(classUniversal, tagBoolean, []byte{v})
+}
+
+func newInteger(class byte, tag byte, i int64) *packet {
+	return newPrimitive(class, tag, encodeInteger(i))
+}
+
+func encodeInteger(i int64) []byte {
+	n := 1
+	for v := i; v > 127 || v < -128; v >>= 8 {
+		n++
+	}
+	b := make([]byte, n)
+	for j := n - 1; j >= 0; j-- {
+		b[j] = byte(i)
+		i >>= 8
+	}
+	return b
+}
+
+// int decodes the value of the packet as an INTEGER or ENUMERATED.
+func (p *packet) int() (int64, error) {
+	if p.constructed || len(p.value) == 0 || len(p.value) > 8 {
+		return 0, errInvalidPacket
+	}
+	i := int64(int8(p.value[0]))
+	for _, b := range p.value[1:] {
+		i = i<<8 | int64(b)
+	}
+	return i, nil
+}
+
+// string decodes the value of the packet as an OCTET STRING.
+func (p *packet) string() (string, error) {
+	if p.constructed {
+		return "", errInvalidPacket
+	}
+	return string(p.value), nil
+}
+
+// is reports whether the packet has the class and tag.
+func (p *packet) is(class byte, tag byte) bool {
+	return p.class == class && p.tag == tag
+}
+
+// bytes encodes the packet.
+func (p *packet) bytes() []byte {
+	content := p.value
+	if p.constructed {
+		content = nil
+		for _, c := range p.children {
+			content = append(content, c.bytes()...)
+		}
+	}
+
+	id := p.class | p.tag
+	if p.constructed {
+		id |= constructed
+	}
+
+	b := []byte{id}
+	b = append(b, encodeLength(len(content))...)
+	return append(b, content...)
+}
+
+func encodeLength(n int) []byte {
+	if n < 0x80 {
+		return []byte{byte(n)}
+	}
+	var b []byte
+	for ; n > 0; n >>= 8 {
+		b = append([]byte{byte(n)}, b...)
+	}
+	return append([]byte{0x80 | byte(len(b))}, b...)
+}
+
+// readPacket reads the next packet from r.
+func readPacket(r *bufio.Reader) (*packet, error) {
+	id, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := readLength(r)
+	if err != nil {
+		return nil, err
+	}
+
+	content := make([]byte, n)
+	if _, err := io.ReadFull(r, content); err != nil {
+		return nil, err
+	}
+
+	return decodePacket(id, content)
+}
+
+func readLength(r io.ByteReader) (int, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	if b < 0x80 {
+		return int(b), nil
+	}
+
+	// Indefinite lengths are not allowed in LDAP.
+	size := int(b &^ 0x80)
+	if size == 0 || size > 4 {
+		return 0, errInvalidPacket
+	}
+
+	n := 0
+	for i := 0; i < size; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		n = n<<8 | int(b)
+	}
+	if n > maxPacketSize {
+		return 0, fmt.Errorf("ldap packet of %d bytes is too large", n)
+	}
+	return n, nil
+}
+
+func decodePacket(id byte, content []byte) (*packet, error) {
+	if id&0x1f == 0x1f {
+		// High tag numbers are not used by LDAP.
+		return nil, errInvalidPacket
+	}
+
+	p := &packet{
+		class:       id & 0xc0,
+		constructed: id&constructed != 0,
+		tag:         id & 0x1f,
+	}
+	if !p.constructed {
+		p.value = content
+		return p, nil
+	}
+
+	for len(content) > 0 {
+		c, rest, err := parsePacket(content)
+		if err != nil {
+			return nil, err
+		}
+		p.children = append(p.children, c)
+		content = rest
+	}
+	return p, nil
+}
+
+// parsePacket decodes the packet at the start of b, and returns the bytes following it.
+func parsePacket(b []byte) (*packet, []byte, error) {
+	r := bytes.NewReader(b[1:])
+	n, err := readLength(r)
+	if err != nil {
+		return nil, nil, errInvalidPacket
+	}
+
+	start := len(b) - r.Len()
+	if n > r.Len() {
+		return nil, nil, errInvalidPacket
+	}
+
+	p, err := decodePacket(b[0], b[start:start+n])
+	if err != nil {
+		return nil, nil, err
+	}
+	return p, b[start+n:], nil
+}
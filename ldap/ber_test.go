@@ -0,0 +1,66 @@
+package ldap
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPacket_Integer(t *testing.T) {
+	for _, i := range []int64{0, 1, 127, 128, 255, 256, -1, -128, -129, 1 << 40} {
+		p := newInteger(classUniversal, tagInteger, i)
+		got, err := readPacket(bufio.NewReader(bytes.NewReader(p.bytes())))
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		v, err := got.int()
+		if err != nil {
+			t.Fatalf("%d: %v", i, err)
+		}
+		if v != i {
+			t.Errorf("expected %d, got %d", i, v)
+		}
+	}
+}
+
+func TestPacket_RoundTrip(t *testing.T) {
+	long := strings.Repeat("x", 70000)
+	p := newSequence(
+		newInteger(classUniversal, tagInteger, 7),
+		newPacket(classApplication, opSearchResultEntry,
+			newOctetString("uid=alice"),
+			newSequence(newSequence(newOctetString("description"), newSet(newOctetString(long)))),
+		),
+	)
+
+	got, err := readPacket(bufio.NewReader(bytes.NewReader(p.bytes())))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.is(classUniversal, tagSequence) || len(got.children) != 2 {
+		t.Fatalf("unexpected packet %+v", got)
+	}
+	e, err := decodeEntry(got.children[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.DN != "uid=alice" || len(e.Attributes["description"]) != 1 || e.Attributes["description"][0] != long {
+		t.Errorf("unexpected entry %s", e.DN)
+	}
+}
+
+func TestReadPacket_Invalid(t *testing.T) {
+	tests := map[string][]byte{
+		"indefinite length":         {0x30, 0x80, 0x00, 0x00},
+		"truncated child":           {0x30, 0x03, 0x04, 0x05, 0x61},
+		"high tag number":           {0x1f, 0x01, 0x00},
+		"too large":                 {0x04, 0x84, 0x7f, 0xff, 0xff, 0xff},
+		"truncated length of child": {0x30, 0x02, 0x04, 0x82},
+	}
+	for name, b := range tests {
+		if _, err := readPacket(bufio.NewReader(bytes.NewReader(b))); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
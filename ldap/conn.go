@@ -0,0 +1,298 @@
+package ldap
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Operations of LDAP messages (RFC 4511 section 4.2 onwards).
+const (
+	opBindRequest       byte = 0
+	opBindResponse      byte = 1
+	opUnbindRequest     byte = 2
+	opSearchRequest     byte = 3
+	opSearchResultEntry byte = 4
+	opSearchResultDone  byte = 5
+	opExtendedResponse  byte = 24
+)
+
+// Result codes of LDAP operations.
+const (
+	resultSuccess            = 0
+	resultNoSuchObject       = 32
+	resultInvalidCredentials = 49
+)
+
+// scopeWholeSubtree is the scope of searches of all the entries beneath their base.
+const scopeWholeSubtree = 2
+
+// filterEqualityMatch is the filter of searches for entries with an attribute equal to a value.
+const filterEqualityMatch byte = 3
+
+// resultError is the result of an LDAP operation that did not succeed.
+type resultError struct {
+	code    int64
+	message string
+}
+
+func (e *resultError) Error() string {
+	if e.message == "" {
+		return fmt.Sprintf("ldap result code %d", e.code)
+	}
+	return fmt.Sprintf("ldap result code %d: %s", e.code, e.message)
+}
+
+// isResult reports whether err is the result code of an LDAP operation.
+func isResult(err error, code int64) bool {
+	e, ok := err.(*resultError)
+	return ok && e.code == code
+}
+
+// entry is an entry returned by a search.
+type entry struct {
+	DN         string
+	Attributes map[string][]string
+}
+
+// conn is a connection to an LDAP server. Operations on a conn are not
+// safe for concurrent use.
+type conn struct {
+	c       net.Conn
+	r       *bufio.Reader
+	timeout time.Duration
+	id      int64
+}
+
+// dial connects to the LDAP server of the URL. The scheme of the URL is either
+// ldap, or ldaps for connections over TLS.
+func dial(ctx context.Context, rawurl string, tlsConfig *tls.Config, timeout time.Duration) (*conn, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &net.Dialer{Timeout: timeout}
+	var c net.Conn
+	switch strings.ToLower(u.Scheme) {
+	case "ldap":
+		c, err = d.DialContext(ctx, "tcp", hostPort(u, "389"))
+	case "ldaps":
+		c, err = tls.DialWithDialer(d, "tcp", hostPort(u, "636"), tlsConfig)
+	default:
+		return nil, fmt.Errorf("unsupported ldap url scheme %q", u.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &conn{
+		c:       c,
+		r:       bufio.NewReader(c),
+		timeout: timeout,
+	}, nil
+}
+
+func hostPort(u *url.URL, defaultPort string) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	return net.JoinHostPort(u.Hostname(), defaultPort)
+}
+
+// Close unbinds and closes the connection.
+func (c *conn) Close() error {
+	c.id++
+	msg := newSequence(
+		newInteger(classUniversal, tagInteger, c.id),
+		newPrimitive(classApplication, opUnbindRequest, nil),
+	)
+	c.c.SetWriteDeadline(time.Now().Add(c.timeout))
+	c.c.Write(msg.bytes())
+	return c.c.Close()
+}
+
+// Bind authenticates the connection with the password of the DN.
+func (c *conn) Bind(ctx context.Context, dn, password string) error {
+	req := newPacket(classApplication, opBindRequest,
+		newInteger(classUniversal, tagInteger, 3),
+		newOctetString(dn),
+		newPrimitive(classContext, 0, []byte(password)),
+	)
+
+	res, err := c.roundTrip(ctx, req, opBindResponse)
+	if err != nil {
+		return err
+	}
+	return result(res[len(res)-1])
+}
+
+// Search returns the entries beneath base, within the scope, whose attribute
+// equals the value. Only the attributes listed are returned.
+func (c *conn) Search(ctx context.Context, base string, scope int64, attribute, value string, attributes ...string) ([]entry, error) {
+	attrs := newSequence()
+	for _, a := range attributes {
+		attrs.children = append(attrs.children, newOctetString(a))
+	}
+	if len(attributes) == 0 {
+		// No attributes (RFC 4511 section 4.5.1.8).
+		attrs.children = append(attrs.children, newOctetString("1.1"))
+	}
+
+	req := newPacket(classApplication, opSearchRequest,
+		newOctetString(base),
+		newInteger(classUniversal, tagEnumerated, scope),
+		newInteger(classUniversal, tagEnumerated, 0), // never dereference aliases
+		newInteger(classUniversal, tagInteger, 0),    // no size limit
+		newInteger(classUniversal, tagInteger, 0),    // no time limit
+		newBoolean(false),
+		newPacket(classContext, filterEqualityMatch,
+			newOctetString(attribute),
+			newOctetString(value),
+		),
+		attrs,
+	)
+
+	res, err := c.roundTrip(ctx, req, opSearchResultDone)
+	if err != nil {
+		return nil, err
+	}
+	if err := result(res[len(res)-1]); err != nil {
+		return nil, err
+	}
+
+	var entries []entry
+	for _, op := range res[:len(res)-1] {
+		if !op.is(classApplication, opSearchResultEntry) {
+			continue
+		}
+		e, err := decodeEntry(op)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// roundTrip sends the request, and returns the operations of the responses
+// to it up to and including the one of the last operation.
+func (c *conn) roundTrip(ctx context.Context, req *packet, last byte) ([]*packet, error) {
+	deadline := time.Now().Add(c.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := c.c.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	c.id++
+	msg := newSequence(newInteger(classUniversal, tagInteger, c.id), req)
+	if _, err := c.c.Write(msg.bytes()); err != nil {
+		return nil, err
+	}
+
+	var ops []*packet
+	for {
+		res, err := readPacket(c.r)
+		if err != nil {
+			return nil, err
+		}
+		if !res.is(classUniversal, tagSequence) || len(res.children) < 2 {
+			return nil, errInvalidPacket
+		}
+
+		id, err := res.children[0].int()
+		if err != nil {
+			return nil, err
+		}
+		op := res.children[1]
+		if id == 0 && op.is(classApplication, opExtendedResponse) {
+			// Notice of disconnection (RFC 4511 section 4.4.1).
+			if err := result(op); err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("ldap server disconnected")
+		}
+		if id != c.id {
+			continue
+		}
+
+		ops = append(ops, op)
+		if op.is(classApplication, last) {
+			return ops, nil
+		}
+	}
+}
+
+// result decodes the LDAPResult of the operation.
+func result(op *packet) error {
+	if len(op.children) < 3 {
+		return errInvalidPacket
+	}
+	code, err := op.children[0].int()
+	if err != nil {
+		return err
+	}
+	if code == resultSuccess {
+		return nil
+	}
+	msg, _ := op.children[2].string()
+	return &resultError{code: code, message: msg}
+}
+
+func decodeEntry(op *packet) (entry, error) {
+	if len(op.children) < 2 {
+		return entry{}, errInvalidPacket
+	}
+	dn, err := op.children[0].string()
+	if err != nil {
+		return entry{}, err
+	}
+
+	e := entry{
+		DN:         dn,
+		Attributes: make(map[string][]string),
+	}
+	for _, a := range op.children[1].children {
+		if len(a.children) < 2 {
+			return entry{}, errInvalidPacket
+		}
+		name, err := a.children[0].string()
+		if err != nil {
+			return entry{}, err
+		}
+		for _, v := range a.children[1].children {
+			s, err := v.string()
+			if err != nil {
+				return entry{}, err
+			}
+			e.Attributes[name] = append(e.Attributes[name], s)
+		}
+	}
+	return e, nil
+}
+
+// escapeDN escapes the value of an attribute of a DN (RFC 4514 section 2.4).
+func escapeDN(v string) string {
+	var b strings.Builder
+	for i, r := range v {
+		switch {
+		case strings.ContainsRune(`,+"\<>;=`, r),
+			i == 0 && (r == ' ' || r == '#'),
+			i == len(v)-1 && r == ' ':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == 0:
+			b.WriteString(`\00`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
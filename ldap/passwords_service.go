@@ -0,0 +1,432 @@
+// Package ldap authenticates users against an LDAP directory.
+package ldap
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"go.uber.org/zap"
+)
+
+// Defaults of the configuration of the directory.
+const (
+	DefaultUserAttribute        = "uid"
+	DefaultGroupMemberAttribute = "member"
+	DefaultGroupNameAttribute   = "cn"
+	DefaultTimeout              = 10 * time.Second
+)
+
+// ErrIncorrectPassword is returned when users cannot be authenticated. It
+// does not tell apart unknown users from incorrect passwords.
+var ErrIncorrectPassword = &influxdb.Error{
+	Code: influxdb.EForbidden,
+	Msg:  "your username or password is incorrect",
+}
+
+// ErrPasswordManagedByDirectory is returned when setting the password of a user of the directory.
+var ErrPasswordManagedByDirectory = &influxdb.Error{
+	Code: influxdb.EMethodNotAllowed,
+	Msg:  "the password of the user is managed by the ldap directory",
+}
+
+// GroupMapping grants the members of a group of the directory a role in an organization.
+type GroupMapping struct {
+	// Group is the name of the group of the directory.
+	Group string
+	// Org is the name of the organization.
+	Org string
+	// Role is either owner or member.
+	Role influxdb.UserType
+}
+
+// ParseGroupMapping parses a mapping written as group:org:role.
+func ParseGroupMapping(s string) (GroupMapping, error) {
+	i, j := strings.Index(s, ":"), strings.LastIndex(s, ":")
+	if i <= 0 || j == i || j == len(s)-1 {
+		return GroupMapping{}, fmt.Errorf("invalid ldap group mapping %q; expected group:org:role", s)
+	}
+
+	m := GroupMapping{
+		Group: s[:i],
+		Org:   s[i+1 : j],
+		Role:  influxdb.UserType(s[j+1:]),
+	}
+	if m.Role != influxdb.Owner && m.Role != influxdb.Member {
+		return GroupMapping{}, fmt.Errorf("invalid role %q of ldap group mapping; expected owner or member", m.Role)
+	}
+	return m, nil
+}
+
+// Config is the configuration of the directory users authenticate against.
+type Config struct {
+	// URL is the URL of the directory, such as ldap://localhost:389 or ldaps://localhost:636.
+	URL string
+	// InsecureSkipVerify skips the verification of the certificate of ldaps directories.
+	InsecureSkipVerify bool
+	// Timeout bounds each operation on the directory.
+	Timeout time.Duration
+
+	// BindDN and BindPassword authenticate the searches for users and groups.
+	// Searches are anonymous when BindDN is empty.
+	BindDN       string
+	BindPassword string
+
+	// UserDN is the template of the DN of users, where %s is replaced with
+	// their name, such as uid=%s,ou=people,dc=example,dc=com. When it is
+	// empty, users are searched beneath UserSearchBase by UserAttribute.
+	UserDN         string
+	UserSearchBase string
+	UserAttribute  string
+
+	// GroupSearchBase is where the groups of users are searched, by
+	// GroupMemberAttribute holding the DN of users. Groups are named by
+	// GroupNameAttribute. Groups are not looked up when it is empty.
+	GroupSearchBase      string
+	GroupMemberAttribute string
+	GroupNameAttribute   string
+	// Groups grant the members of groups roles in organizations. The roles of
+	// users in the organizations of the mappings are kept in sync with the
+	// groups of users each time they authenticate with the directory.
+	Groups []GroupMapping
+
+	// CacheTTL is how long an authentication with the directory is reused
+	// for. Users authenticate with the directory every time when it is zero.
+	CacheTTL time.Duration
+
+	// LocalUsers authenticate with their local passwords rather than with
+	// the directory, such as the user created during onboarding.
+	LocalUsers []string
+}
+
+var _ influxdb.PasswordsService = (*PasswordsService)(nil)
+
+// PasswordsService authenticates users with bind requests to an LDAP directory.
+// Users authenticated for the first time are created, and their roles in
+// organizations follow their groups.
+type PasswordsService struct {
+	Config Config
+	Logger *zap.Logger
+
+	// LocalPasswordsService holds the passwords of the local users.
+	LocalPasswordsService      influxdb.PasswordsService
+	UserService                influxdb.UserService
+	OrganizationService        influxdb.OrganizationService
+	UserResourceMappingService influxdb.UserResourceMappingService
+
+	// Now returns the current time; it is replaced in tests.
+	Now func() time.Time
+
+	mu    sync.Mutex
+	key   []byte
+	cache map[string]cachedAuthentication
+}
+
+type cachedAuthentication struct {
+	digest  []byte
+	expires time.Time
+}
+
+// NewPasswordsService returns a PasswordsService of the directory of the config.
+func NewPasswordsService(c Config, local influxdb.PasswordsService, us influxdb.UserService, os influxdb.OrganizationService, urms influxdb.UserResourceMappingService) *PasswordsService {
+	if c.UserAttribute == "" {
+		c.UserAttribute = DefaultUserAttribute
+	}
+	if c.GroupMemberAttribute == "" {
+		c.GroupMemberAttribute = DefaultGroupMemberAttribute
+	}
+	if c.GroupNameAttribute == "" {
+		c.GroupNameAttribute = DefaultGroupNameAttribute
+	}
+	if c.Timeout == 0 {
+		c.Timeout = DefaultTimeout
+	}
+
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+
+	return &PasswordsService{
+		Config:                     c,
+		Logger:                     zap.NewNop(),
+		LocalPasswordsService:      local,
+		UserService:                us,
+		OrganizationService:        os,
+		UserResourceMappingService: urms,
+		Now:                        time.Now,
+		key:                        key,
+		cache:                      make(map[string]cachedAuthentication),
+	}
+}
+
+func (s *PasswordsService) isLocal(name string) bool {
+	for _, u := range s.Config.LocalUsers {
+		if u == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SetPassword sets the password of local users. The passwords of the users of
+// the directory cannot be set.
+func (s *PasswordsService) SetPassword(ctx context.Context, name string, password string) error {
+	if !s.isLocal(name) {
+		return ErrPasswordManagedByDirectory
+	}
+	return s.LocalPasswordsService.SetPassword(ctx, name, password)
+}
+
+// CompareAndSetPassword sets the password of local users. The passwords of
+// the users of the directory cannot be set.
+func (s *PasswordsService) CompareAndSetPassword(ctx context.Context, name string, old string, new string) error {
+	if !s.isLocal(name) {
+		return ErrPasswordManagedByDirectory
+	}
+	return s.LocalPasswordsService.CompareAndSetPassword(ctx, name, old, new)
+}
+
+// ComparePassword authenticates the user with the directory, or with the
+// local password of local users.
+func (s *PasswordsService) ComparePassword(ctx context.Context, name string, password string) error {
+	if s.isLocal(name) {
+		return s.LocalPasswordsService.ComparePassword(ctx, name, password)
+	}
+
+	// Binds with an empty password are unauthenticated binds that succeed
+	// whatever the DN (RFC 4513 section 5.1.2).
+	if name == "" || password == "" {
+		return ErrIncorrectPassword
+	}
+
+	digest := s.digest(name, password)
+	if s.cached(name, digest) {
+		return nil
+	}
+
+	groups, err := s.authenticate(ctx, name, password)
+	if err != nil {
+		return err
+	}
+
+	u, err := s.findOrCreateUser(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if err := s.syncGroups(ctx, u, groups); err != nil {
+		return err
+	}
+
+	s.store(name, digest)
+	return nil
+}
+
+func (s *PasswordsService) digest(name, password string) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(name))
+	h.Write([]byte{0})
+	h.Write([]byte(password))
+	return h.Sum(nil)
+}
+
+func (s *PasswordsService) cached(name string, digest []byte) bool {
+	if s.Config.CacheTTL <= 0 {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, ok := s.cache[name]
+	if !ok {
+		return false
+	}
+	if !s.Now().Before(a.expires) {
+		delete(s.cache, name)
+		return false
+	}
+	return hmac.Equal(a.digest, digest)
+}
+
+func (s *PasswordsService) store(name string, digest []byte) {
+	if s.Config.CacheTTL <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache[name] = cachedAuthentication{
+		digest:  digest,
+		expires: s.Now().Add(s.Config.CacheTTL),
+	}
+}
+
+// authenticate binds as the user, and returns the names of the groups of the user.
+func (s *PasswordsService) authenticate(ctx context.Context, name, password string) ([]string, error) {
+	c, err := dial(ctx, s.Config.URL, &tls.Config{InsecureSkipVerify: s.Config.InsecureSkipVerify}, s.Config.Timeout)
+	if err != nil {
+		return nil, unavailableError(err)
+	}
+	defer c.Close()
+
+	if s.Config.BindDN != "" {
+		if err := c.Bind(ctx, s.Config.BindDN, s.Config.BindPassword); err != nil {
+			return nil, unavailableError(err)
+		}
+	}
+
+	dn, err := s.userDN(ctx, c, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.Bind(ctx, dn, password); err != nil {
+		if isResult(err, resultInvalidCredentials) {
+			return nil, ErrIncorrectPassword
+		}
+		return nil, unavailableError(err)
+	}
+
+	if s.Config.GroupSearchBase == "" {
+		return nil, nil
+	}
+
+	// Search the groups with the privileges of the bind DN rather than of the user.
+	if s.Config.BindDN != "" {
+		if err := c.Bind(ctx, s.Config.BindDN, s.Config.BindPassword); err != nil {
+			return nil, unavailableError(err)
+		}
+	}
+
+	entries, err := c.Search(ctx, s.Config.GroupSearchBase, scopeWholeSubtree, s.Config.GroupMemberAttribute, dn, s.Config.GroupNameAttribute)
+	if err != nil && !isResult(err, resultNoSuchObject) {
+		return nil, unavailableError(err)
+	}
+
+	var groups []string
+	for _, e := range entries {
+		groups = append(groups, e.Attributes[s.Config.GroupNameAttribute]...)
+	}
+	return groups, nil
+}
+
+func (s *PasswordsService) userDN(ctx context.Context, c *conn, name string) (string, error) {
+	if s.Config.UserDN != "" {
+		return fmt.Sprintf(s.Config.UserDN, escapeDN(name)), nil
+	}
+
+	entries, err := c.Search(ctx, s.Config.UserSearchBase, scopeWholeSubtree, s.Config.UserAttribute, name)
+	if isResult(err, resultNoSuchObject) {
+		return "", ErrIncorrectPassword
+	}
+	if err != nil {
+		return "", unavailableError(err)
+	}
+	if len(entries) != 1 {
+		if len(entries) > 1 {
+			s.Logger.Warn("Ambiguous ldap user", zap.String("user", name), zap.Int("entries", len(entries)))
+		}
+		return "", ErrIncorrectPassword
+	}
+	return entries[0].DN, nil
+}
+
+func unavailableError(err error) *influxdb.Error {
+	return &influxdb.Error{
+		Code: influxdb.EUnavailable,
+		Msg:  "unable to authenticate with the ldap directory",
+		Err:  err,
+	}
+}
+
+func (s *PasswordsService) findOrCreateUser(ctx context.Context, name string) (*influxdb.User, error) {
+	u, err := s.UserService.FindUser(ctx, influxdb.UserFilter{Name: &name})
+	if err == nil {
+		return u, nil
+	}
+	if influxdb.ErrorCode(err) != influxdb.ENotFound {
+		return nil, err
+	}
+
+	u = &influxdb.User{Name: name}
+	if err := s.UserService.CreateUser(ctx, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// syncGroups grants the user the roles of its groups in the organizations of
+// the group mappings, and revokes the other roles in those organizations.
+func (s *PasswordsService) syncGroups(ctx context.Context, u *influxdb.User, groups []string) error {
+	member := make(map[string]bool, len(groups))
+	for _, g := range groups {
+		member[g] = true
+	}
+
+	orgs := make(map[string]bool)
+	roles := make(map[string]influxdb.UserType)
+	for _, m := range s.Config.Groups {
+		orgs[m.Org] = true
+		if member[m.Group] && roles[m.Org] != influxdb.Owner {
+			roles[m.Org] = m.Role
+		}
+	}
+
+	for name := range orgs {
+		o, err := s.OrganizationService.FindOrganization(ctx, influxdb.OrganizationFilter{Name: &name})
+		if influxdb.ErrorCode(err) == influxdb.ENotFound {
+			s.Logger.Warn("Organization of ldap group mapping not found", zap.String("org", name))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := s.syncRole(ctx, u, o, roles[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncRole makes the role the only role of the user in the organization.
+// The user has no role in the organization when it is empty.
+func (s *PasswordsService) syncRole(ctx context.Context, u *influxdb.User, o *influxdb.Organization, role influxdb.UserType) error {
+	ms, _, err := s.UserResourceMappingService.FindUserResourceMappings(ctx, influxdb.UserResourceMappingFilter{
+		UserID:       u.ID,
+		ResourceType: influxdb.OrgsResourceType,
+		ResourceID:   o.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Users have at most one role in an organization.
+	for _, m := range ms {
+		if m.UserType == role {
+			return nil
+		}
+		if err := s.UserResourceMappingService.DeleteUserResourceMapping(ctx, o.ID, u.ID); err != nil {
+			return err
+		}
+	}
+
+	if role == "" {
+		return nil
+	}
+
+	return s.UserResourceMappingService.CreateUserResourceMapping(ctx, &influxdb.UserResourceMapping{
+		UserID:       u.ID,
+		UserType:     role,
+		ResourceType: influxdb.OrgsResourceType,
+		ResourceID:   o.ID,
+	})
+}
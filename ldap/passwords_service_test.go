@@ -0,0 +1,388 @@
+package ldap
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/inmem"
+	"github.com/influxdata/influxdb/kv"
+)
+
+const (
+	aliceDN   = "uid=alice,ou=people,dc=example,dc=com"
+	bobDN     = "uid=bob,ou=people,dc=example,dc=com"
+	serviceDN = "cn=influxd,ou=services,dc=example,dc=com"
+	adminsDN  = "cn=admins,ou=groups,dc=example,dc=com"
+	devsDN    = "cn=devs,ou=groups,dc=example,dc=com"
+)
+
+func newTestDirectory(t *testing.T) *testServer {
+	return newTestServer(t, map[string]map[string][]string{
+		aliceDN: {
+			"uid":          {"alice"},
+			"userPassword": {"alice-password"},
+		},
+		bobDN: {
+			"uid":          {"bob"},
+			"userPassword": {"bob-password"},
+		},
+		serviceDN: {
+			"userPassword": {"service-password"},
+		},
+		adminsDN: {
+			"cn":     {"admins"},
+			"member": {aliceDN},
+		},
+		devsDN: {
+			"cn":     {"devs"},
+			"member": {aliceDN, bobDN},
+		},
+	})
+}
+
+func newTestService(t *testing.T, c Config) (*PasswordsService, *kv.Service) {
+	t.Helper()
+
+	ctx := context.Background()
+	svc := kv.NewService(inmem.NewKVStore())
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"o", "p"} {
+		if err := svc.CreateOrganization(ctx, &influxdb.Organization{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := svc.CreateUser(ctx, &influxdb.User{Name: "admin"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.SetPassword(ctx, "admin", "admin-password"); err != nil {
+		t.Fatal(err)
+	}
+
+	return NewPasswordsService(c, svc, svc, svc, svc), svc
+}
+
+// roles returns the roles of the user in the organizations, keyed by the name of the organization.
+func roles(t *testing.T, svc *kv.Service, name string) map[string]influxdb.UserType {
+	t.Helper()
+
+	ctx := context.Background()
+	u, err := svc.FindUser(ctx, influxdb.UserFilter{Name: &name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ms, _, err := svc.FindUserResourceMappings(ctx, influxdb.UserResourceMappingFilter{
+		UserID:       u.ID,
+		ResourceType: influxdb.OrgsResourceType,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rs := make(map[string]influxdb.UserType)
+	for _, m := range ms {
+		o, err := svc.FindOrganizationByID(ctx, m.ResourceID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rs[o.Name] = m.UserType
+	}
+	return rs
+}
+
+func TestPasswordsService_ComparePassword(t *testing.T) {
+	dir := newTestDirectory(t)
+	defer dir.Close()
+
+	groups := []GroupMapping{
+		{Group: "admins", Org: "o", Role: influxdb.Owner},
+		{Group: "devs", Org: "o", Role: influxdb.Member},
+		{Group: "devs", Org: "p", Role: influxdb.Member},
+	}
+
+	tests := []struct {
+		name     string
+		config   Config
+		user     string
+		password string
+		wantCode string
+		wantRole map[string]influxdb.UserType
+	}{
+		{
+			name: "bind with the dn template",
+			config: Config{
+				UserDN:          "uid=%s,ou=people,dc=example,dc=com",
+				GroupSearchBase: "ou=groups,dc=example,dc=com",
+				Groups:          groups,
+			},
+			user:     "alice",
+			password: "alice-password",
+			wantRole: map[string]influxdb.UserType{"o": influxdb.Owner, "p": influxdb.Member},
+		},
+		{
+			name: "bind with the dn searched by the service account",
+			config: Config{
+				BindDN:          serviceDN,
+				BindPassword:    "service-password",
+				UserSearchBase:  "ou=people,dc=example,dc=com",
+				GroupSearchBase: "ou=groups,dc=example,dc=com",
+				Groups:          groups,
+			},
+			user:     "bob",
+			password: "bob-password",
+			wantRole: map[string]influxdb.UserType{"o": influxdb.Member, "p": influxdb.Member},
+		},
+		{
+			name: "groups are not synced without a search base",
+			config: Config{
+				UserDN: "uid=%s,ou=people,dc=example,dc=com",
+				Groups: groups,
+			},
+			user:     "alice",
+			password: "alice-password",
+			wantRole: map[string]influxdb.UserType{},
+		},
+		{
+			name:     "incorrect password",
+			config:   Config{UserDN: "uid=%s,ou=people,dc=example,dc=com"},
+			user:     "alice",
+			password: "bob-password",
+			wantCode: influxdb.EForbidden,
+		},
+		{
+			name:     "empty password",
+			config:   Config{UserDN: "uid=%s,ou=people,dc=example,dc=com"},
+			user:     "alice",
+			password: "",
+			wantCode: influxdb.EForbidden,
+		},
+		{
+			name: "unknown user",
+			config: Config{
+				BindDN:         serviceDN,
+				BindPassword:   "service-password",
+				UserSearchBase: "ou=people,dc=example,dc=com",
+			},
+			user:     "carol",
+			password: "carol-password",
+			wantCode: influxdb.EForbidden,
+		},
+		{
+			name: "incorrect password of the service account",
+			config: Config{
+				BindDN:         serviceDN,
+				BindPassword:   "wrong",
+				UserSearchBase: "ou=people,dc=example,dc=com",
+			},
+			user:     "bob",
+			password: "bob-password",
+			wantCode: influxdb.EUnavailable,
+		},
+		{
+			name:     "local users use their local password",
+			config:   Config{UserDN: "uid=%s,ou=people,dc=example,dc=com", LocalUsers: []string{"admin"}},
+			user:     "admin",
+			password: "admin-password",
+			wantRole: map[string]influxdb.UserType{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.URL = dir.URL()
+			s, svc := newTestService(t, tt.config)
+
+			err := s.ComparePassword(context.Background(), tt.user, tt.password)
+			if code := influxdb.ErrorCode(err); code != tt.wantCode {
+				t.Fatalf("expected error code %q, got %q: %v", tt.wantCode, code, err)
+			}
+			if tt.wantCode != "" {
+				return
+			}
+
+			if got := roles(t, svc, tt.user); !reflect.DeepEqual(got, tt.wantRole) {
+				t.Errorf("expected roles %v, got %v", tt.wantRole, got)
+			}
+		})
+	}
+}
+
+func TestPasswordsService_SyncGroups(t *testing.T) {
+	dir := newTestDirectory(t)
+	defer dir.Close()
+
+	s, svc := newTestService(t, Config{
+		URL:             dir.URL(),
+		UserDN:          "uid=%s,ou=people,dc=example,dc=com",
+		GroupSearchBase: "ou=groups,dc=example,dc=com",
+		Groups: []GroupMapping{
+			{Group: "admins", Org: "o", Role: influxdb.Owner},
+			{Group: "devs", Org: "o", Role: influxdb.Member},
+			{Group: "devs", Org: "missing", Role: influxdb.Member},
+		},
+	})
+	ctx := context.Background()
+
+	// Roles granted outside of the organizations of the mappings are kept.
+	if err := s.ComparePassword(ctx, "alice", "alice-password"); err != nil {
+		t.Fatal(err)
+	}
+	u, err := svc.FindUser(ctx, influxdb.UserFilter{Name: strPtr("alice")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := svc.FindOrganization(ctx, influxdb.OrganizationFilter{Name: strPtr("p")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.CreateUserResourceMapping(ctx, &influxdb.UserResourceMapping{
+		UserID:       u.ID,
+		UserType:     influxdb.Owner,
+		ResourceType: influxdb.OrgsResourceType,
+		ResourceID:   p.ID,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		admins []string
+		devs   []string
+		want   map[string]influxdb.UserType
+	}{
+		{
+			admins: []string{aliceDN},
+			devs:   []string{aliceDN},
+			want:   map[string]influxdb.UserType{"o": influxdb.Owner, "p": influxdb.Owner},
+		},
+		{
+			devs: []string{aliceDN},
+			want: map[string]influxdb.UserType{"o": influxdb.Member, "p": influxdb.Owner},
+		},
+		{
+			want: map[string]influxdb.UserType{"p": influxdb.Owner},
+		},
+		{
+			admins: []string{aliceDN},
+			want:   map[string]influxdb.UserType{"o": influxdb.Owner, "p": influxdb.Owner},
+		},
+	}
+
+	for i, step := range steps {
+		dir.SetAttribute(adminsDN, "member", step.admins...)
+		dir.SetAttribute(devsDN, "member", step.devs...)
+
+		if err := s.ComparePassword(ctx, "alice", "alice-password"); err != nil {
+			t.Fatal(err)
+		}
+		if got := roles(t, svc, "alice"); !reflect.DeepEqual(got, step.want) {
+			t.Errorf("step %d: expected roles %v, got %v", i, step.want, got)
+		}
+	}
+}
+
+func TestPasswordsService_Cache(t *testing.T) {
+	dir := newTestDirectory(t)
+	defer dir.Close()
+
+	s, _ := newTestService(t, Config{
+		URL:      dir.URL(),
+		UserDN:   "uid=%s,ou=people,dc=example,dc=com",
+		CacheTTL: time.Minute,
+	})
+	now := time.Now()
+	s.Now = func() time.Time { return now }
+	ctx := context.Background()
+
+	if err := s.ComparePassword(ctx, "alice", "alice-password"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ComparePassword(ctx, "alice", "alice-password"); err != nil {
+		t.Fatal(err)
+	}
+	if binds := dir.Binds(); binds != 1 {
+		t.Errorf("expected the authentication to be cached, got %d binds", binds)
+	}
+
+	// Other passwords are not authenticated by the cache.
+	if err := s.ComparePassword(ctx, "alice", "bob-password"); influxdb.ErrorCode(err) != influxdb.EForbidden {
+		t.Errorf("expected incorrect password, got %v", err)
+	}
+	if binds := dir.Binds(); binds != 2 {
+		t.Errorf("expected incorrect password to bind, got %d binds", binds)
+	}
+
+	now = now.Add(time.Minute)
+	if err := s.ComparePassword(ctx, "alice", "alice-password"); err != nil {
+		t.Fatal(err)
+	}
+	if binds := dir.Binds(); binds != 3 {
+		t.Errorf("expected the cached authentication to expire, got %d binds", binds)
+	}
+}
+
+func TestPasswordsService_SetPassword(t *testing.T) {
+	s, _ := newTestService(t, Config{LocalUsers: []string{"admin"}})
+	ctx := context.Background()
+
+	if err := s.SetPassword(ctx, "alice", "new-password"); influxdb.ErrorCode(err) != influxdb.EMethodNotAllowed {
+		t.Errorf("expected password of directory user not to be set, got %v", err)
+	}
+	if err := s.CompareAndSetPassword(ctx, "alice", "alice-password", "new-password"); influxdb.ErrorCode(err) != influxdb.EMethodNotAllowed {
+		t.Errorf("expected password of directory user not to be set, got %v", err)
+	}
+
+	if err := s.CompareAndSetPassword(ctx, "admin", "admin-password", "new-password"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ComparePassword(ctx, "admin", "new-password"); err != nil {
+		t.Errorf("expected local password to be set, got %v", err)
+	}
+}
+
+func TestParseGroupMapping(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    GroupMapping
+		wantErr bool
+	}{
+		{s: "admins:o:owner", want: GroupMapping{Group: "admins", Org: "o", Role: influxdb.Owner}},
+		{s: "devs:my:org:member", want: GroupMapping{Group: "devs", Org: "my:org", Role: influxdb.Member}},
+		{s: "devs:o:admin", wantErr: true},
+		{s: "devs:member", wantErr: true},
+		{s: ":o:member", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseGroupMapping(tt.s)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseGroupMapping(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseGroupMapping(%q) = %+v, want %+v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestEscapeDN(t *testing.T) {
+	tests := map[string]string{
+		"alice":         "alice",
+		"smith, john":   `smith\, john`,
+		"a=b+c":         `a\=b\+c`,
+		" #lead trail ": `\ #lead trail\ `,
+		"#hash":         `\#hash`,
+	}
+	for in, want := range tests {
+		if got := escapeDN(in); got != want {
+			t.Errorf("escapeDN(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
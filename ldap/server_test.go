@@ -0,0 +1,176 @@
+package ldap
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testServer is an in-process stand-in for an LDAP directory. It supports
+// simple binds, and searches by equality of an attribute.
+type testServer struct {
+	ln net.Listener
+
+	mu      sync.Mutex
+	entries map[string]map[string][]string
+	binds   int
+}
+
+// newTestServer serves the entries, keyed by DN. The userPassword attribute
+// of entries is the password they bind with.
+func newTestServer(t *testing.T, entries map[string]map[string][]string) *testServer {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &testServer{
+		ln:      ln,
+		entries: entries,
+	}
+	go s.serve()
+	return s
+}
+
+func (s *testServer) URL() string {
+	return "ldap://" + s.ln.Addr().String()
+}
+
+func (s *testServer) Close() {
+	s.ln.Close()
+}
+
+// Binds returns the number of bind requests served.
+func (s *testServer) Binds() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.binds
+}
+
+// SetAttribute replaces the values of an attribute of an entry.
+func (s *testServer) SetAttribute(dn, attr string, values ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[dn][attr] = values
+}
+
+func (s *testServer) serve() {
+	for {
+		c, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.serveConn(c)
+	}
+}
+
+func (s *testServer) serveConn(c net.Conn) {
+	defer c.Close()
+
+	r := bufio.NewReader(c)
+	for {
+		msg, err := readPacket(r)
+		if err != nil || len(msg.children) < 2 {
+			return
+		}
+		id, _ := msg.children[0].int()
+		op := msg.children[1]
+
+		var res []*packet
+		switch {
+		case op.is(classApplication, opBindRequest):
+			res = []*packet{s.bind(op)}
+		case op.is(classApplication, opSearchRequest):
+			res = s.search(op)
+		default:
+			return
+		}
+
+		for _, p := range res {
+			if _, err := c.Write(newSequence(newInteger(classUniversal, tagInteger, id), p).bytes()); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func testResult(op byte, code int64) *packet {
+	return newPacket(classApplication, op,
+		newInteger(classUniversal, tagEnumerated, code),
+		newOctetString(""),
+		newOctetString(""),
+	)
+}
+
+func (s *testServer) bind(op *packet) *packet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.binds++
+
+	dn, _ := op.children[1].string()
+	password := string(op.children[2].value)
+
+	e, ok := s.entries[dn]
+	if !ok || len(e["userPassword"]) == 0 || e["userPassword"][0] != password {
+		return testResult(opBindResponse, resultInvalidCredentials)
+	}
+	return testResult(opBindResponse, resultSuccess)
+}
+
+func (s *testServer) search(op *packet) []*packet {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	base, _ := op.children[0].string()
+	filter := op.children[6]
+	attr, _ := filter.children[0].string()
+	value, _ := filter.children[1].string()
+
+	var attributes []string
+	for _, a := range op.children[7].children {
+		name, _ := a.string()
+		attributes = append(attributes, name)
+	}
+
+	baseFound := false
+	var res []*packet
+	for dn, e := range s.entries {
+		if !strings.HasSuffix(dn, base) {
+			continue
+		}
+		baseFound = true
+
+		matches := false
+		for _, v := range e[attr] {
+			if v == value {
+				matches = true
+			}
+		}
+		if !matches {
+			continue
+		}
+
+		attrs := newSequence()
+		for _, name := range attributes {
+			vals, ok := e[name]
+			if !ok {
+				continue
+			}
+			set := newSet()
+			for _, v := range vals {
+				set.children = append(set.children, newOctetString(v))
+			}
+			attrs.children = append(attrs.children, newSequence(newOctetString(name), set))
+		}
+		res = append(res, newPacket(classApplication, opSearchResultEntry, newOctetString(dn), attrs))
+	}
+
+	if !baseFound {
+		return []*packet{testResult(opSearchResultDone, resultNoSuchObject)}
+	}
+	return append(res, testResult(opSearchResultDone, resultSuccess))
+}
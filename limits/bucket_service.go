@@ -0,0 +1,58 @@
+package limits
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb"
+)
+
+var _ influxdb.BucketService = (*BucketService)(nil)
+
+// BucketService wraps an influxdb.BucketService and enforces the maximum
+// number of buckets of organizations.
+type BucketService struct {
+	influxdb.BucketService
+
+	orgs   influxdb.OrganizationService
+	limits influxdb.OrgLimitsService
+}
+
+// NewBucketService returns a new BucketService creating the buckets of s
+// while the organizations of orgs have fewer than their limit of buckets.
+func NewBucketService(s influxdb.BucketService, orgs influxdb.OrganizationService, l influxdb.OrgLimitsService) *BucketService {
+	return &BucketService{
+		BucketService: s,
+		orgs:          orgs,
+		limits:        l,
+	}
+}
+
+// CreateBucket creates a bucket, unless its organization reached its limit
+// of buckets.
+func (s *BucketService) CreateBucket(ctx context.Context, b *influxdb.Bucket) error {
+	orgID := b.OrganizationID
+	if !orgID.Valid() {
+		o, err := s.orgs.FindOrganization(ctx, influxdb.OrganizationFilter{Name: &b.Organization})
+		if err != nil {
+			return err
+		}
+		orgID = o.ID
+	}
+
+	l, err := s.limits.FindOrgLimits(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	if l.MaxBuckets > 0 {
+		_, n, err := s.BucketService.FindBuckets(ctx, influxdb.BucketFilter{OrganizationID: &orgID})
+		if err != nil {
+			return err
+		}
+		if n >= l.MaxBuckets {
+			return influxdb.NewLimitError("limits/CreateBucket", 0, "organization %s reached its limit of %d buckets", orgID, l.MaxBuckets)
+		}
+	}
+
+	return s.BucketService.CreateBucket(ctx, b)
+}
@@ -0,0 +1,58 @@
+package limits_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/limits"
+	"github.com/influxdata/influxdb/mock"
+)
+
+func TestBucketService_CreateBucket(t *testing.T) {
+	const orgID = influxdb.ID(1)
+
+	var buckets []*influxdb.Bucket
+	bs := mock.NewBucketService()
+	bs.FindBucketsFn = func(ctx context.Context, filter influxdb.BucketFilter, opts ...influxdb.FindOptions) ([]*influxdb.Bucket, int, error) {
+		if filter.OrganizationID == nil || *filter.OrganizationID != orgID {
+			t.Fatalf("unexpected filter %+v", filter)
+		}
+		return buckets, len(buckets), nil
+	}
+	bs.CreateBucketFn = func(ctx context.Context, b *influxdb.Bucket) error {
+		buckets = append(buckets, b)
+		return nil
+	}
+
+	orgs := mock.NewOrganizationService()
+	orgs.FindOrganizationF = func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+		return &influxdb.Organization{ID: orgID, Name: *filter.Name}, nil
+	}
+
+	l := mock.NewOrgLimitsService()
+	l.FindOrgLimitsFn = func(ctx context.Context, id influxdb.ID) (*influxdb.OrgLimits, error) {
+		return &influxdb.OrgLimits{OrgID: id, MaxBuckets: 2}, nil
+	}
+
+	s := limits.NewBucketService(bs, orgs, l)
+	ctx := context.Background()
+	if err := s.CreateBucket(ctx, &influxdb.Bucket{OrganizationID: orgID, Name: "a"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The organization is found by name if its ID is not set.
+	if err := s.CreateBucket(ctx, &influxdb.Bucket{Organization: "org", Name: "b"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := s.CreateBucket(ctx, &influxdb.Bucket{OrganizationID: orgID, Name: "c"})
+	if got := influxdb.ErrorCode(err); got != influxdb.ETooManyRequests {
+		t.Fatalf("expected bucket limit error, got %v", err)
+	}
+	if influxdb.RetryAfter(err) != 0 {
+		t.Errorf("unexpected retry after %v", influxdb.RetryAfter(err))
+	}
+	if len(buckets) != 2 {
+		t.Errorf("unexpected number of buckets created: %d", len(buckets))
+	}
+}
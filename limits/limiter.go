@@ -0,0 +1,219 @@
+// Package limits enforces the limits of the usage of organizations.
+package limits
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"golang.org/x/time/rate"
+)
+
+// queryRetryAfter is the delay after which a query refused because of the
+// concurrent queries of its organization may be retried.
+const queryRetryAfter = time.Second
+
+var (
+	_ influxdb.OrgLimitsService = (*Limiter)(nil)
+	_ influxdb.OrgLimiter       = (*Limiter)(nil)
+)
+
+// Limiter enforces the rate and concurrency limits of organizations.
+//
+// Limiter wraps the service storing the limits, and the limits it enforces
+// are updated when they are updated through it.
+type Limiter struct {
+	influxdb.OrgLimitsService
+
+	mu   sync.Mutex
+	orgs map[influxdb.ID]*orgLimiter
+
+	now func() time.Time
+}
+
+// orgLimiter is the state of the limits of an organization.
+type orgLimiter struct {
+	limits influxdb.OrgLimits
+
+	writes  *rate.Limiter // nil if unlimited
+	bytes   *rate.Limiter // nil if unlimited
+	queries int
+}
+
+// NewLimiter returns a new Limiter enforcing the limits of the organizations
+// stored by s.
+func NewLimiter(s influxdb.OrgLimitsService) *Limiter {
+	return &Limiter{
+		OrgLimitsService: s,
+		orgs:             make(map[influxdb.ID]*orgLimiter),
+		now:              time.Now,
+	}
+}
+
+// UpdateOrgLimits updates the limits of an organization, and starts enforcing them.
+func (l *Limiter) UpdateOrgLimits(ctx context.Context, orgID influxdb.ID, upd influxdb.OrgLimitsUpdate) (*influxdb.OrgLimits, error) {
+	limits, err := l.OrgLimitsService.UpdateOrgLimits(ctx, orgID, upd)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if o, ok := l.orgs[orgID]; ok {
+		o.setLimits(*limits)
+	}
+	return limits, nil
+}
+
+// LimitWrite returns an error if the organization exceeded its limits of
+// writes or bytes written per second.
+func (l *Limiter) LimitWrite(ctx context.Context, orgID influxdb.ID, n int64) error {
+	o, err := l.org(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	var writes *rate.Reservation
+	if o.writes != nil {
+		writes = o.writes.ReserveN(now, 1)
+		if d := writes.DelayFrom(now); d > 0 {
+			writes.CancelAt(now)
+			return influxdb.NewLimitError("limits/LimitWrite", d, "organization %s exceeded its limit of %d writes per second", orgID, o.limits.WritesPerSecond)
+		}
+	}
+
+	if o.bytes != nil {
+		// A write of an unknown size is refused while the bytes written
+		// exceed the limit.
+		k := 0
+		if n > 0 {
+			k = o.burst(n)
+		}
+		bytes := o.bytes.ReserveN(now, k)
+		if d := bytes.DelayFrom(now); d > 0 {
+			bytes.CancelAt(now)
+			if writes != nil {
+				writes.CancelAt(now)
+			}
+			return influxdb.NewLimitError("limits/LimitWrite", d, "organization %s exceeded its limit of %d bytes written per second", orgID, o.limits.WriteBytesPerSecond)
+		}
+		// The bytes of a write larger than the burst are all counted, and
+		// the following writes are refused until they are compensated for.
+		if n > int64(k) {
+			o.countBytes(now, n-int64(k))
+		}
+	}
+	return nil
+}
+
+// CountWriteBytes counts n bytes written by the organization. The writes of the
+// organization are refused until they are compensated for if they exceed its limit.
+func (l *Limiter) CountWriteBytes(ctx context.Context, orgID influxdb.ID, n int64) {
+	o, err := l.org(ctx, orgID)
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if o.bytes == nil {
+		return
+	}
+	o.countBytes(l.now(), n)
+}
+
+// LimitQuery returns an error if the organization is executing as many queries
+// as its limit of concurrent queries.
+func (l *Limiter) LimitQuery(ctx context.Context, orgID influxdb.ID) (func(), error) {
+	o, err := l.org(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if max := o.limits.ConcurrentQueries; max > 0 && o.queries >= max {
+		return nil, influxdb.NewLimitError("limits/LimitQuery", queryRetryAfter, "organization %s exceeded its limit of %d concurrent queries", orgID, max)
+	}
+	o.queries++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			o.queries--
+			l.mu.Unlock()
+		})
+	}, nil
+}
+
+// org returns the state of the limits of the organization, loading its limits
+// the first time.
+func (l *Limiter) org(ctx context.Context, orgID influxdb.ID) (*orgLimiter, error) {
+	l.mu.Lock()
+	o, ok := l.orgs[orgID]
+	l.mu.Unlock()
+	if ok {
+		return o, nil
+	}
+
+	limits, err := l.OrgLimitsService.FindOrgLimits(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	// The limits may have been loaded concurrently.
+	if o, ok := l.orgs[orgID]; ok {
+		return o, nil
+	}
+	o = &orgLimiter{}
+	o.setLimits(*limits)
+	l.orgs[orgID] = o
+	return o, nil
+}
+
+// setLimits sets the limits of the organization. The rates are reset if their
+// limits changed.
+func (o *orgLimiter) setLimits(limits influxdb.OrgLimits) {
+	if limits.WritesPerSecond != o.limits.WritesPerSecond || o.writes == nil {
+		o.writes = nil
+		if n := limits.WritesPerSecond; n > 0 {
+			o.writes = rate.NewLimiter(rate.Limit(n), n)
+		}
+	}
+	if limits.WriteBytesPerSecond != o.limits.WriteBytesPerSecond || o.bytes == nil {
+		o.bytes = nil
+		if n := limits.WriteBytesPerSecond; n > 0 {
+			o.bytes = rate.NewLimiter(rate.Limit(n), int(n))
+		}
+	}
+	o.limits = limits
+}
+
+// countBytes reserves n bytes of the rate of bytes written, in reservations
+// of at most its burst.
+func (o *orgLimiter) countBytes(now time.Time, n int64) {
+	for n > 0 {
+		k := o.burst(n)
+		o.bytes.ReserveN(now, k)
+		n -= int64(k)
+	}
+}
+
+// burst returns n, or the burst of the rate of bytes written if it is smaller.
+// A rate.Limiter never allows more than its burst at once.
+func (o *orgLimiter) burst(n int64) int {
+	if b := o.bytes.Burst(); n > int64(b) {
+		return b
+	}
+	return int(n)
+}
@@ -0,0 +1,170 @@
+package limits
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+)
+
+type orgLimitsService map[influxdb.ID]*influxdb.OrgLimits
+
+func (s orgLimitsService) FindOrgLimits(ctx context.Context, orgID influxdb.ID) (*influxdb.OrgLimits, error) {
+	l, ok := s[orgID]
+	if !ok {
+		return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: "organization not found"}
+	}
+	c := *l
+	return &c, nil
+}
+
+func (s orgLimitsService) UpdateOrgLimits(ctx context.Context, orgID influxdb.ID, upd influxdb.OrgLimitsUpdate) (*influxdb.OrgLimits, error) {
+	l, err := s.FindOrgLimits(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if err := upd.Apply(l); err != nil {
+		return nil, err
+	}
+	s[orgID] = l
+	return l, nil
+}
+
+func newTestLimiter(t *testing.T, limits influxdb.OrgLimits) (*Limiter, *time.Time) {
+	t.Helper()
+	now := time.Unix(0, 0)
+	l := NewLimiter(orgLimitsService{limits.OrgID: &limits})
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestLimiter_LimitWrite(t *testing.T) {
+	orgID := influxdb.ID(1)
+	ctx := context.Background()
+
+	t.Run("writes per second", func(t *testing.T) {
+		l, now := newTestLimiter(t, influxdb.OrgLimits{OrgID: orgID, WritesPerSecond: 2})
+		for i := 0; i < 2; i++ {
+			if err := l.LimitWrite(ctx, orgID, 10); err != nil {
+				t.Fatalf("unexpected error on write %d: %v", i, err)
+			}
+		}
+
+		err := l.LimitWrite(ctx, orgID, 10)
+		if got := influxdb.ErrorCode(err); got != influxdb.ETooManyRequests {
+			t.Fatalf("expected write to be limited, got %v", err)
+		}
+		if got, want := influxdb.RetryAfter(err), 500*time.Millisecond; got != want {
+			t.Errorf("unexpected retry after %v, want %v", got, want)
+		}
+
+		*now = now.Add(500 * time.Millisecond)
+		if err := l.LimitWrite(ctx, orgID, 10); err != nil {
+			t.Fatalf("unexpected error after waiting: %v", err)
+		}
+	})
+
+	t.Run("bytes per second", func(t *testing.T) {
+		l, now := newTestLimiter(t, influxdb.OrgLimits{OrgID: orgID, WriteBytesPerSecond: 100})
+		if err := l.LimitWrite(ctx, orgID, 80); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		err := l.LimitWrite(ctx, orgID, 80)
+		if got := influxdb.ErrorCode(err); got != influxdb.ETooManyRequests {
+			t.Fatalf("expected write to be limited, got %v", err)
+		}
+
+		*now = now.Add(time.Second)
+		l.CountWriteBytes(ctx, orgID, 250)
+		if err := l.LimitWrite(ctx, orgID, -1); influxdb.ErrorCode(err) != influxdb.ETooManyRequests {
+			t.Fatalf("expected write of unknown size to be limited, got %v", err)
+		}
+
+		*now = now.Add(2 * time.Second)
+		if err := l.LimitWrite(ctx, orgID, -1); err != nil {
+			t.Fatalf("unexpected error after waiting: %v", err)
+		}
+	})
+
+	t.Run("bytes of writes larger than the burst", func(t *testing.T) {
+		l, now := newTestLimiter(t, influxdb.OrgLimits{OrgID: orgID, WriteBytesPerSecond: 100})
+		if err := l.LimitWrite(ctx, orgID, 500); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// All the bytes of the write are counted, not only its burst.
+		*now = now.Add(3 * time.Second)
+		err := l.LimitWrite(ctx, orgID, 10)
+		if got := influxdb.ErrorCode(err); got != influxdb.ETooManyRequests {
+			t.Fatalf("expected write to be limited, got %v", err)
+		}
+		if got, want := influxdb.RetryAfter(err), 1100*time.Millisecond; got != want {
+			t.Errorf("unexpected retry after %v, want %v", got, want)
+		}
+
+		*now = now.Add(1100 * time.Millisecond)
+		if err := l.LimitWrite(ctx, orgID, 10); err != nil {
+			t.Fatalf("unexpected error after waiting: %v", err)
+		}
+	})
+
+	t.Run("updated limits", func(t *testing.T) {
+		l, _ := newTestLimiter(t, influxdb.OrgLimits{OrgID: orgID, WritesPerSecond: 1})
+		if err := l.LimitWrite(ctx, orgID, 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := l.LimitWrite(ctx, orgID, 0); err == nil {
+			t.Fatal("expected write to be limited")
+		}
+
+		unlimited := 0
+		if _, err := l.UpdateOrgLimits(ctx, orgID, influxdb.OrgLimitsUpdate{WritesPerSecond: &unlimited}); err != nil {
+			t.Fatalf("unexpected error updating limits: %v", err)
+		}
+		if err := l.LimitWrite(ctx, orgID, 0); err != nil {
+			t.Fatalf("unexpected error after removing limit: %v", err)
+		}
+	})
+
+	t.Run("unknown organization", func(t *testing.T) {
+		l, _ := newTestLimiter(t, influxdb.OrgLimits{OrgID: orgID})
+		err := l.LimitWrite(ctx, influxdb.ID(2), 0)
+		if got := influxdb.ErrorCode(err); got != influxdb.ENotFound {
+			t.Fatalf("expected not found error, got %v", err)
+		}
+	})
+}
+
+func TestLimiter_LimitQuery(t *testing.T) {
+	orgID := influxdb.ID(1)
+	ctx := context.Background()
+
+	l, _ := newTestLimiter(t, influxdb.OrgLimits{OrgID: orgID, ConcurrentQueries: 2})
+	done1, err := l.LimitQuery(ctx, orgID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := l.LimitQuery(ctx, orgID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = l.LimitQuery(ctx, orgID)
+	if got := influxdb.ErrorCode(err); got != influxdb.ETooManyRequests {
+		t.Fatalf("expected query to be limited, got %v", err)
+	}
+	if got := influxdb.RetryAfter(err); got != queryRetryAfter {
+		t.Errorf("unexpected retry after %v", got)
+	}
+
+	// Calling done more than once releases a single query.
+	done1()
+	done1()
+	if _, err := l.LimitQuery(ctx, orgID); err != nil {
+		t.Fatalf("unexpected error after query is done: %v", err)
+	}
+	if _, err := l.LimitQuery(ctx, orgID); err == nil {
+		t.Fatal("expected query to be limited")
+	}
+}
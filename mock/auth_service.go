@@ -2,6 +2,7 @@ package mock
 
 import (
 	"context"
+	"time"
 
 	platform "github.com/influxdata/influxdb"
 	"go.uber.org/zap"
@@ -22,6 +23,7 @@ type AuthorizationService struct {
 	CreateAuthorizationFn      func(context.Context, *platform.Authorization) error
 	DeleteAuthorizationFn      func(context.Context, platform.ID) error
 	SetAuthorizationStatusFn   func(context.Context, platform.ID, platform.Status) error
+	RotateAuthorizationFn      func(context.Context, platform.ID, time.Duration) (*platform.Authorization, error)
 }
 
 // NewAuthorizationService returns a mock AuthorizationService where its methods will return
@@ -36,6 +38,9 @@ func NewAuthorizationService() *AuthorizationService {
 		CreateAuthorizationFn:    func(context.Context, *platform.Authorization) error { return nil },
 		DeleteAuthorizationFn:    func(context.Context, platform.ID) error { return nil },
 		SetAuthorizationStatusFn: func(context.Context, platform.ID, platform.Status) error { return nil },
+		RotateAuthorizationFn: func(context.Context, platform.ID, time.Duration) (*platform.Authorization, error) {
+			return nil, nil
+		},
 	}
 }
 
@@ -66,3 +71,8 @@ func (s *AuthorizationService) DeleteAuthorization(ctx context.Context, id platf
 func (s *AuthorizationService) SetAuthorizationStatus(ctx context.Context, id platform.ID, status platform.Status) error {
 	return s.SetAuthorizationStatusFn(ctx, id, status)
 }
+
+// RotateAuthorization issues a new token for the authorization.
+func (s *AuthorizationService) RotateAuthorization(ctx context.Context, id platform.ID, grace time.Duration) (*platform.Authorization, error) {
+	return s.RotateAuthorizationFn(ctx, id, grace)
+}
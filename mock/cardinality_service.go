@@ -0,0 +1,33 @@
+package mock
+
+import (
+	"context"
+
+	platform "github.com/influxdata/influxdb"
+)
+
+var _ platform.CardinalityService = (*CardinalityService)(nil)
+
+// CardinalityService is a mock implementation of platform.CardinalityService.
+type CardinalityService struct {
+	BucketCardinalityFn func(ctx context.Context, orgID, bucketID platform.ID) (*platform.BucketCardinality, error)
+}
+
+// NewCardinalityService returns a mock CardinalityService where its methods
+// return the cardinality of empty buckets.
+func NewCardinalityService() *CardinalityService {
+	return &CardinalityService{
+		BucketCardinalityFn: func(ctx context.Context, orgID, bucketID platform.ID) (*platform.BucketCardinality, error) {
+			return &platform.BucketCardinality{
+				OrganizationID: orgID,
+				BucketID:       bucketID,
+				Measurements:   []platform.MeasurementCardinality{},
+			}, nil
+		},
+	}
+}
+
+// BucketCardinality returns the series cardinality of a bucket.
+func (s *CardinalityService) BucketCardinality(ctx context.Context, orgID, bucketID platform.ID) (*platform.BucketCardinality, error) {
+	return s.BucketCardinalityFn(ctx, orgID, bucketID)
+}
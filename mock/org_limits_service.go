@@ -0,0 +1,42 @@
+package mock
+
+import (
+	"context"
+
+	platform "github.com/influxdata/influxdb"
+)
+
+var _ platform.OrgLimitsService = (*OrgLimitsService)(nil)
+
+// OrgLimitsService is a mock implementation of platform.OrgLimitsService.
+type OrgLimitsService struct {
+	FindOrgLimitsFn   func(ctx context.Context, orgID platform.ID) (*platform.OrgLimits, error)
+	UpdateOrgLimitsFn func(ctx context.Context, orgID platform.ID, upd platform.OrgLimitsUpdate) (*platform.OrgLimits, error)
+}
+
+// NewOrgLimitsService returns a mock OrgLimitsService where its methods
+// return the zero limits.
+func NewOrgLimitsService() *OrgLimitsService {
+	return &OrgLimitsService{
+		FindOrgLimitsFn: func(ctx context.Context, orgID platform.ID) (*platform.OrgLimits, error) {
+			return &platform.OrgLimits{OrgID: orgID}, nil
+		},
+		UpdateOrgLimitsFn: func(ctx context.Context, orgID platform.ID, upd platform.OrgLimitsUpdate) (*platform.OrgLimits, error) {
+			l := &platform.OrgLimits{OrgID: orgID}
+			if err := upd.Apply(l); err != nil {
+				return nil, err
+			}
+			return l, nil
+		},
+	}
+}
+
+// FindOrgLimits returns the limits of an organization.
+func (s *OrgLimitsService) FindOrgLimits(ctx context.Context, orgID platform.ID) (*platform.OrgLimits, error) {
+	return s.FindOrgLimitsFn(ctx, orgID)
+}
+
+// UpdateOrgLimits updates the limits of an organization.
+func (s *OrgLimitsService) UpdateOrgLimits(ctx context.Context, orgID platform.ID, upd platform.OrgLimitsUpdate) (*platform.OrgLimits, error) {
+	return s.UpdateOrgLimitsFn(ctx, orgID, upd)
+}
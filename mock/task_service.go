@@ -20,6 +20,10 @@ type TaskService struct {
 	CancelRunFn    func(context.Context, platform.ID, platform.ID) error
 	RetryRunFn     func(context.Context, platform.ID, platform.ID) (*platform.Run, error)
 	ForceRunFn     func(context.Context, platform.ID, int64) (*platform.Run, error)
+
+	CreateBackfillFn func(context.Context, platform.ID, int64, int64) (*platform.Backfill, error)
+	FindBackfillsFn  func(context.Context, platform.ID) ([]*platform.Backfill, int, error)
+	CancelBackfillFn func(context.Context, platform.ID, platform.ID) error
 }
 
 func (s *TaskService) FindTaskByID(ctx context.Context, id platform.ID) (*platform.Task, error) {
@@ -65,3 +69,15 @@ func (s *TaskService) RetryRun(ctx context.Context, taskID, runID platform.ID) (
 func (s *TaskService) ForceRun(ctx context.Context, taskID platform.ID, scheduledFor int64) (*platform.Run, error) {
 	return s.ForceRunFn(ctx, taskID, scheduledFor)
 }
+
+func (s *TaskService) CreateBackfill(ctx context.Context, taskID platform.ID, start, stop int64) (*platform.Backfill, error) {
+	return s.CreateBackfillFn(ctx, taskID, start, stop)
+}
+
+func (s *TaskService) FindBackfills(ctx context.Context, taskID platform.ID) ([]*platform.Backfill, int, error) {
+	return s.FindBackfillsFn(ctx, taskID)
+}
+
+func (s *TaskService) CancelBackfill(ctx context.Context, taskID, backfillID platform.ID) error {
+	return s.CancelBackfillFn(ctx, taskID, backfillID)
+}
@@ -348,16 +348,53 @@ func ValidPrecision(precision string) bool {
 // NOTE: to minimize heap allocations, the returned Points will refer to subslices of buf.
 // This can have the unintended effect preventing buf from being garbage collected.
 func ParsePointsWithPrecision(buf []byte, defaultTime time.Time, precision string) ([]Point, error) {
-	points := make([]Point, 0, bytes.Count(buf, []byte{'\n'})+1)
+	points, _, errs := ParsePointsWithLines(buf, defaultTime, precision)
+	if len(errs) > 0 {
+		failed := make([]string, 0, len(errs))
+		for _, err := range errs {
+			failed = append(failed, err.Error())
+		}
+		return points, fmt.Errorf("%s", strings.Join(failed, "\n"))
+	}
+	return points, nil
+}
+
+// LineError is the error parsing a line of line protocol.
+type LineError struct {
+	Line int    // The number of the line, starting at 1.
+	Text string // The line, without its trailing newline.
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("unable to parse '%s': %v", e.Text, e.Err)
+}
+
+// ParsePointsWithLines is similar to ParsePointsWithPrecision, but also returns
+// the number of the line of each point, and an error for each line that could
+// not be parsed.
+//
+// NOTE: to minimize heap allocations, the returned Points will refer to subslices of buf.
+// This can have the unintended effect preventing buf from being garbage collected.
+func ParsePointsWithLines(buf []byte, defaultTime time.Time, precision string) ([]Point, []int, []*LineError) {
+	n := bytes.Count(buf, []byte{'\n'}) + 1
 	var (
+		points = make([]Point, 0, n)
+		lines  = make([]int, 0, n)
+		failed []*LineError
 		pos    int
+		line   int
+		next   = 1
 		block  []byte
-		failed []string
 	)
 	for pos < len(buf) {
 		pos, block = scanLine(buf, pos)
 		pos++
 
+		// quoted string fields may span several lines
+		line = next
+		next += bytes.Count(block, []byte{'\n'}) + 1
+
 		if len(block) == 0 {
 			continue
 		}
@@ -381,17 +418,14 @@ func ParsePointsWithPrecision(buf []byte, defaultTime time.Time, precision strin
 
 		pt, err := parsePoint(block[start:], defaultTime, precision)
 		if err != nil {
-			failed = append(failed, fmt.Sprintf("unable to parse '%s': %v", string(block[start:]), err))
+			failed = append(failed, &LineError{Line: line, Text: string(block[start:]), Err: err})
 		} else {
 			points = append(points, pt)
+			lines = append(lines, line)
 		}
 
 	}
-	if len(failed) > 0 {
-		return points, fmt.Errorf("%s", strings.Join(failed, "\n"))
-	}
-	return points, nil
-
+	return points, lines, failed
 }
 
 func parsePoint(buf []byte, defaultTime time.Time, precision string) (Point, error) {
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"time"
+)
+
+const (
+	// DefaultScanBatchSize is the default maximum number of points of the
+	// batches read by a PointsScanner.
+	DefaultScanBatchSize = 5000
+
+	// MaxScanLineSize is the maximum size in bytes of a line read by a
+	// PointsScanner. Scanning fails with bufio.ErrTooLong on longer lines.
+	MaxScanLineSize = 16 * 1024 * 1024
+
+	// scanChunkSize is the initial size of the buffer of a PointsScanner.
+	scanChunkSize = 64 * 1024
+)
+
+// PointsScanner reads line protocol in batches of points, so that it does not
+// have to be read in memory all at once.
+//
+// Successive calls to Scan parse the next batch of lines of the reader. The
+// lines that cannot be parsed are reported by LineErrors, and do not end the
+// scan.
+type PointsScanner struct {
+	scanner     *bufio.Scanner
+	defaultTime time.Time
+	precision   string
+	batchSize   int
+
+	line   int // The number of the next line.
+	points []Point
+	lines  []int
+	errs   []*LineError
+}
+
+// NewPointsScanner returns a new PointsScanner reading r in batches of at most
+// batchSize points, or DefaultScanBatchSize if batchSize is not positive.
+func NewPointsScanner(r io.Reader, defaultTime time.Time, precision string, batchSize int) *PointsScanner {
+	if batchSize <= 0 {
+		batchSize = DefaultScanBatchSize
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, scanChunkSize), MaxScanLineSize)
+	scanner.Split(scanLines)
+
+	return &PointsScanner{
+		scanner:     scanner,
+		defaultTime: defaultTime,
+		precision:   precision,
+		batchSize:   batchSize,
+		line:        1,
+	}
+}
+
+// Scan reads the next batch of at most batchSize lines that are not empty or
+// comments. It returns false when the end of the reader is reached or reading
+// fails, in which case Err returns the error.
+func (s *PointsScanner) Scan() bool {
+	s.points, s.lines, s.errs = nil, nil, nil
+
+	// The points refer to subslices of buf, and the scanner reuses its buffer,
+	// so the lines are copied in buf, which is never overwritten.
+	var buf []byte
+	for len(s.points)+len(s.errs) < s.batchSize && s.scanner.Scan() {
+		block := s.scanner.Bytes()
+		line := s.line
+		s.line += bytes.Count(block, []byte{'\n'}) + 1
+
+		buf = append(buf, block...)
+		points, _, errs := ParsePointsWithLines(buf[len(buf)-len(block):], s.defaultTime, s.precision)
+		for range points {
+			s.lines = append(s.lines, line)
+		}
+		s.points = append(s.points, points...)
+		for _, err := range errs {
+			err.Line = line
+			s.errs = append(s.errs, err)
+		}
+	}
+	return len(s.points) > 0 || len(s.errs) > 0
+}
+
+// Points returns the points of the batch.
+func (s *PointsScanner) Points() []Point { return s.points }
+
+// Lines returns the number of the line of each point of the batch.
+func (s *PointsScanner) Lines() []int { return s.lines }
+
+// LineErrors returns the errors of the lines of the batch that could not be parsed.
+func (s *PointsScanner) LineErrors() []*LineError { return s.errs }
+
+// Line returns the number of the line following the lines read.
+func (s *PointsScanner) Line() int { return s.line }
+
+// Err returns the error reading the lines, if any.
+func (s *PointsScanner) Err() error { return s.scanner.Err() }
+
+// scanLines is a bufio.SplitFunc splitting line protocol into lines. Unlike
+// bufio.ScanLines, it does not split quoted string fields spanning several lines.
+func scanLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	// scanLine only skips escaped characters followed by another byte, so the
+	// end of a line is only known once the next two bytes have been read.
+	i, block := scanLine(data, 0)
+	if atEOF {
+		if i < len(data) {
+			return i + 1, block, nil
+		}
+		return len(data), data, nil
+	}
+	if i+2 < len(data) {
+		return i + 1, block, nil
+	}
+	// Request more data.
+	return 0, nil, nil
+}
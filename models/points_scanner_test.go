@@ -0,0 +1,67 @@
+package models_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/influxdata/influxdb/models"
+)
+
+func TestPointsScanner(t *testing.T) {
+	buf := "# comment\n" +
+		"cpu value=1 1\n" +
+		"\n" +
+		"cpu value=\n" +
+		"log msg=\"a\nb\" 2\n" +
+		"cpu,host\\ name=a\\\nb value=3 3\n" +
+		"cpu,host value=4 4\n" +
+		"cpu value=5 5\n"
+
+	now := time.Now()
+	expPoints, expLines, expErrs := models.ParsePointsWithLines([]byte(buf), now, "ns")
+
+	for _, batchSize := range []int{1, 2, 3, 100} {
+		var (
+			points []string
+			lines  []int
+			errs   []int
+		)
+		s := models.NewPointsScanner(iotest.OneByteReader(strings.NewReader(buf)), now, "ns", batchSize)
+		for s.Scan() {
+			if n := len(s.Points()) + len(s.LineErrors()); n > batchSize {
+				t.Fatalf("unexpected batch size: got %d, exp at most %d", n, batchSize)
+			}
+			for _, p := range s.Points() {
+				points = append(points, p.String())
+			}
+			lines = append(lines, s.Lines()...)
+			for _, err := range s.LineErrors() {
+				errs = append(errs, err.Line)
+			}
+		}
+		if err := s.Err(); err != nil {
+			t.Fatal(err)
+		}
+
+		var exp []string
+		for _, p := range expPoints {
+			exp = append(exp, p.String())
+		}
+		if !reflect.DeepEqual(points, exp) {
+			t.Errorf("batch size %d: unexpected points:\ngot  %q\nexp %q", batchSize, points, exp)
+		}
+		if !reflect.DeepEqual(lines, expLines) {
+			t.Errorf("batch size %d: unexpected lines: got %v, exp %v", batchSize, lines, expLines)
+		}
+		var expErrLines []int
+		for _, err := range expErrs {
+			expErrLines = append(expErrLines, err.Line)
+		}
+		if !reflect.DeepEqual(errs, expErrLines) {
+			t.Errorf("batch size %d: unexpected error lines: got %v, exp %v", batchSize, errs, expErrLines)
+		}
+	}
+}
@@ -1822,6 +1822,39 @@ func TestParsePointsWithPrecision(t *testing.T) {
 	}
 }
 
+func TestParsePointsWithLines(t *testing.T) {
+	buf := "# comment\n" +
+		"cpu value=1 1\n" +
+		"\n" +
+		"cpu value=\n" +
+		"log msg=\"a\nb\" 2\n" +
+		"cpu,host value=3 3\n" +
+		"cpu value=4 4"
+
+	points, lines, errs := models.ParsePointsWithLines([]byte(buf), time.Now(), "ns")
+	if got, exp := len(points), 3; got != exp {
+		t.Fatalf("unexpected number of points: got %d, exp %d", got, exp)
+	}
+	if exp := []int{2, 5, 8}; !reflect.DeepEqual(lines, exp) {
+		t.Errorf("unexpected lines: got %v, exp %v", lines, exp)
+	}
+
+	if got, exp := len(errs), 2; got != exp {
+		t.Fatalf("unexpected number of errors: got %d, exp %d", got, exp)
+	}
+	for i, exp := range []struct {
+		line int
+		text string
+	}{
+		{line: 4, text: "cpu value="},
+		{line: 7, text: "cpu,host value=3 3"},
+	} {
+		if errs[i].Line != exp.line || errs[i].Text != exp.text {
+			t.Errorf("unexpected error %d: got line %d %q, exp line %d %q", i, errs[i].Line, errs[i].Text, exp.line, exp.text)
+		}
+	}
+}
+
 func TestParsePointsWithPrecisionNoTime(t *testing.T) {
 	line := `cpu,host=serverA,region=us-east value=1.0`
 	tm, _ := time.Parse(time.RFC3339Nano, "2000-01-01T12:34:56.789012345Z")
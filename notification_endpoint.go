@@ -0,0 +1,161 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// ErrNotificationEndpointNotFound is the error msg for a missing notification endpoint.
+const ErrNotificationEndpointNotFound = "notification endpoint not found"
+
+// ops for notification endpoints error.
+const (
+	OpFindNotificationEndpointByID = "FindNotificationEndpointByID"
+	OpFindNotificationEndpoints    = "FindNotificationEndpoints"
+	OpCreateNotificationEndpoint   = "CreateNotificationEndpoint"
+	OpUpdateNotificationEndpoint   = "UpdateNotificationEndpoint"
+	OpDeleteNotificationEndpoint   = "DeleteNotificationEndpoint"
+)
+
+// NotificationEndpointService describes a service for managing notification endpoints.
+type NotificationEndpointService interface {
+	// FindNotificationEndpointByID returns a single notification endpoint by ID.
+	FindNotificationEndpointByID(ctx context.Context, id ID) (*NotificationEndpoint, error)
+
+	// FindNotificationEndpoints returns a list of notification endpoints that match filter
+	// and the total count of matching notification endpoints.
+	// Additional options provide pagination & sorting.
+	FindNotificationEndpoints(ctx context.Context, filter NotificationEndpointFilter, opt ...FindOptions) ([]*NotificationEndpoint, int, error)
+
+	// CreateNotificationEndpoint creates a new notification endpoint and sets e.ID with the new identifier.
+	CreateNotificationEndpoint(ctx context.Context, e *NotificationEndpoint) error
+
+	// UpdateNotificationEndpoint replaces the notification endpoint with the
+	// ID id by e. The organization of the endpoint cannot be changed.
+	UpdateNotificationEndpoint(ctx context.Context, id ID, e *NotificationEndpoint) (*NotificationEndpoint, error)
+
+	// DeleteNotificationEndpoint removes a notification endpoint by ID.
+	DeleteNotificationEndpoint(ctx context.Context, id ID) error
+}
+
+// NotificationEndpointType is the kind of the requests sent to a notification endpoint.
+type NotificationEndpointType string
+
+// Notification endpoint types.
+const (
+	// HTTPEndpointType sends the statuses of checks as JSON to a webhook.
+	HTTPEndpointType NotificationEndpointType = "http"
+	// SlackEndpointType sends the statuses of checks as Slack messages to an
+	// incoming webhook.
+	SlackEndpointType NotificationEndpointType = "slack"
+)
+
+// NotificationEndpoint is a webhook the changes of the statuses of checks are sent to.
+type NotificationEndpoint struct {
+	ID             ID                       `json:"id,omitempty"`
+	OrganizationID ID                       `json:"orgID,omitempty"`
+	Name           string                   `json:"name"`
+	Description    string                   `json:"description,omitempty"`
+	Type           NotificationEndpointType `json:"type"`
+	Status         Status                   `json:"status"`
+
+	// URL is the URL of the webhook, or URLSecret the key of the secret of
+	// the organization holding it, such as that of a Slack webhook.
+	URL       string `json:"url,omitempty"`
+	URLSecret string `json:"urlSecret,omitempty"`
+
+	// Method and Headers are the method, POST by default, and the headers
+	// of the requests sent to http endpoints.
+	Method  string            `json:"method,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// TokenSecret is the key of the secret of the organization sent as a
+	// bearer token to http endpoints.
+	TokenSecret string `json:"tokenSecret,omitempty"`
+
+	// Channel overrides the default channel of a Slack webhook.
+	Channel string `json:"channel,omitempty"`
+}
+
+// Valid returns an error if the notification endpoint is not valid.
+func (e *NotificationEndpoint) Valid() error {
+	if e.Name == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "notification endpoint name is empty",
+		}
+	}
+	if e.Status != "" {
+		if err := e.Status.Valid(); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case e.URL == "" && e.URLSecret == "":
+		return &Error{
+			Code: EInvalid,
+			Msg:  "notification endpoint has neither a url nor a url secret",
+		}
+	case e.URL != "" && e.URLSecret != "":
+		return &Error{
+			Code: EInvalid,
+			Msg:  "notification endpoint has both a url and a url secret",
+		}
+	case e.URL != "":
+		if _, err := url.Parse(e.URL); err != nil {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "invalid notification endpoint url",
+				Err:  err,
+			}
+		}
+	}
+
+	switch e.Type {
+	case HTTPEndpointType:
+		switch e.Method {
+		case "", "POST", "PUT":
+		default:
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("notification endpoint method must be POST or PUT, got %q", e.Method),
+			}
+		}
+	case SlackEndpointType:
+	default:
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("unknown notification endpoint type %q", e.Type),
+		}
+	}
+	return nil
+}
+
+// NotificationEndpointFilter represents a set of filter that restrict the returned results.
+type NotificationEndpointFilter struct {
+	ID             *ID
+	OrganizationID *ID
+	Name           *string
+}
+
+// QueryParams implements PagingFilter.
+//
+// It converts NotificationEndpointFilter fields to url query params.
+func (f NotificationEndpointFilter) QueryParams() map[string][]string {
+	qp := url.Values{}
+	if f.ID != nil {
+		qp.Add("id", f.ID.String())
+	}
+
+	if f.OrganizationID != nil {
+		qp.Add("orgID", f.OrganizationID.String())
+	}
+
+	if f.Name != nil {
+		qp.Add("name", *f.Name)
+	}
+
+	return qp
+}
@@ -0,0 +1,143 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ops for org limits
+const (
+	OpFindOrgLimits   = "FindOrgLimits"
+	OpUpdateOrgLimits = "UpdateOrgLimits"
+)
+
+// OrgLimits are the limits of the usage of an organization. A zero limit is
+// unlimited.
+type OrgLimits struct {
+	OrgID ID `json:"orgID"`
+
+	// WritesPerSecond is the number of write requests per second.
+	WritesPerSecond int `json:"writesPerSecond"`
+	// WriteBytesPerSecond is the number of bytes written per second.
+	WriteBytesPerSecond int64 `json:"writeBytesPerSecond"`
+	// ConcurrentQueries is the number of queries executed at once.
+	ConcurrentQueries int `json:"concurrentQueries"`
+	// MaxBuckets is the number of buckets of the organization.
+	MaxBuckets int `json:"maxBuckets"`
+	// MaxSeries is the series cardinality of the buckets of the organization.
+	MaxSeries int64 `json:"maxSeries"`
+}
+
+// Valid returns an error if the limits are invalid.
+func (l *OrgLimits) Valid() error {
+	if l.WritesPerSecond < 0 || l.WriteBytesPerSecond < 0 || l.ConcurrentQueries < 0 || l.MaxBuckets < 0 || l.MaxSeries < 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "limits cannot be negative",
+		}
+	}
+	return nil
+}
+
+// OrgLimitsUpdate represents updates to the limits of an organization.
+// Only fields which are set are updated.
+type OrgLimitsUpdate struct {
+	WritesPerSecond     *int   `json:"writesPerSecond,omitempty"`
+	WriteBytesPerSecond *int64 `json:"writeBytesPerSecond,omitempty"`
+	ConcurrentQueries   *int   `json:"concurrentQueries,omitempty"`
+	MaxBuckets          *int   `json:"maxBuckets,omitempty"`
+	MaxSeries           *int64 `json:"maxSeries,omitempty"`
+}
+
+// Apply applies the update to the limits l, and returns an error if the
+// updated limits are invalid.
+func (u OrgLimitsUpdate) Apply(l *OrgLimits) error {
+	if u.WritesPerSecond != nil {
+		l.WritesPerSecond = *u.WritesPerSecond
+	}
+	if u.WriteBytesPerSecond != nil {
+		l.WriteBytesPerSecond = *u.WriteBytesPerSecond
+	}
+	if u.ConcurrentQueries != nil {
+		l.ConcurrentQueries = *u.ConcurrentQueries
+	}
+	if u.MaxBuckets != nil {
+		l.MaxBuckets = *u.MaxBuckets
+	}
+	if u.MaxSeries != nil {
+		l.MaxSeries = *u.MaxSeries
+	}
+	return l.Valid()
+}
+
+// OrgLimitsService is a service for managing the limits of organizations.
+type OrgLimitsService interface {
+	// FindOrgLimits returns the limits of an organization. The limits of an
+	// organization that were never set are all zero.
+	FindOrgLimits(ctx context.Context, orgID ID) (*OrgLimits, error)
+
+	// UpdateOrgLimits updates the limits of an organization.
+	UpdateOrgLimits(ctx context.Context, orgID ID, upd OrgLimitsUpdate) (*OrgLimits, error)
+}
+
+// OrgLimiter enforces the rate and concurrency limits of organizations.
+type OrgLimiter interface {
+	// LimitWrite returns an error if the organization cannot make a write
+	// request of n bytes now. The size of the write is not limited if n is
+	// negative, in which case its bytes must be counted with CountWriteBytes.
+	LimitWrite(ctx context.Context, orgID ID, n int64) error
+
+	// CountWriteBytes counts n bytes written by the organization, without
+	// limiting them.
+	CountWriteBytes(ctx context.Context, orgID ID, n int64)
+
+	// LimitQuery returns an error if the organization cannot start another
+	// query now. Otherwise, done must be called once the query is done.
+	LimitQuery(ctx context.Context, orgID ID) (done func(), err error)
+}
+
+// LimitError is the error of an operation exceeding a limit of an organization.
+type LimitError struct {
+	Msg string
+
+	// RetryAfter is the delay after which the operation may be allowed, or
+	// zero if it is not allowed until the limit is raised.
+	RetryAfter time.Duration
+}
+
+// Error implements the error interface.
+func (e *LimitError) Error() string {
+	return e.Msg
+}
+
+// NewLimitError returns the error with the code ETooManyRequests of the
+// operation op exceeding a limit of an organization.
+func NewLimitError(op string, retryAfter time.Duration, format string, a ...interface{}) error {
+	msg := fmt.Sprintf(format, a...)
+	return &Error{
+		Code: ETooManyRequests,
+		Op:   op,
+		Msg:  msg,
+		Err: &LimitError{
+			Msg:        msg,
+			RetryAfter: retryAfter,
+		},
+	}
+}
+
+// RetryAfter returns the delay after which the operation that failed with
+// err may be retried, if it failed because of a limit of an organization.
+func RetryAfter(err error) time.Duration {
+	for err != nil {
+		switch e := err.(type) {
+		case *LimitError:
+			return e.RetryAfter
+		case *Error:
+			err = e.Err
+		default:
+			return 0
+		}
+	}
+	return 0
+}
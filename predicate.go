@@ -0,0 +1,11 @@
+package influxdb
+
+// Predicate is something that can match on a series key.
+type Predicate interface {
+	// Matches returns true if the series key matches the predicate.
+	Matches(key []byte) bool
+
+	// Marshal returns a binary representation of the predicate that can be
+	// stored, e.g. in the WAL, and decoded again later.
+	Marshal() ([]byte, error)
+}
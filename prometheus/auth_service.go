@@ -124,6 +124,20 @@ func (s *AuthorizationService) SetAuthorizationStatus(ctx context.Context, id pl
 	return s.AuthorizationService.SetAuthorizationStatus(ctx, id, status)
 }
 
+// RotateAuthorization issues a new token for the authorization, records function call latency, and counts function calls.
+func (s *AuthorizationService) RotateAuthorization(ctx context.Context, id platform.ID, grace time.Duration) (a *platform.Authorization, err error) {
+	defer func(start time.Time) {
+		labels := prometheus.Labels{
+			"method": "RotateAuthorization",
+			"error":  fmt.Sprint(err != nil),
+		}
+		s.requestCount.With(labels).Add(1)
+		s.requestDuration.With(labels).Observe(time.Since(start).Seconds())
+	}(time.Now())
+
+	return s.AuthorizationService.RotateAuthorization(ctx, id, grace)
+}
+
 // PrometheusCollectors returns all authorization service prometheus collectors.
 func (s *AuthorizationService) PrometheusCollectors() []prometheus.Collector {
 	return []prometheus.Collector{
@@ -0,0 +1,552 @@
+// Package arrowipc encodes the results of queries as Apache Arrow IPC
+// streams, and decodes them.
+//
+// Each table of a result is encoded as its own stream, with a schema
+// holding the columns of the table and one record batch for each chunk of
+// rows. The metadata of the schema holds the name of the result and the
+// index of the table within it; the metadata of the fields of the group key
+// holds their values. An error of the query ends the response with a stream
+// whose schema has no fields, and whose metadata holds the error.
+package arrowipc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/iocounter"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/influxdb/query"
+)
+
+const (
+	// DialectType is the type of the Arrow dialect.
+	DialectType = "arrow"
+
+	// ContentType is the media type of Arrow IPC streams.
+	ContentType = "application/vnd.apache.arrow.stream"
+)
+
+// The keys of the metadata of schemas and fields.
+const (
+	resultKey = "influxdb.result"
+	tableKey  = "influxdb.table"
+	errorKey  = "influxdb.error"
+	groupKey  = "influxdb.group"
+	valueKey  = "influxdb.value"
+)
+
+// AddDialectMappings adds the Arrow dialect mappings.
+func AddDialectMappings(mappings flux.DialectMappings) error {
+	return mappings.Add(DialectType, func() flux.Dialect {
+		return new(Dialect)
+	})
+}
+
+// Dialect describes the output format of queries as Arrow IPC streams.
+type Dialect struct{}
+
+// SetHeaders sets the content type of the response.
+func (d *Dialect) SetHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("Transfer-Encoding", "chunked")
+}
+
+// Encoder returns the encoder of the results.
+func (d *Dialect) Encoder() flux.MultiResultEncoder {
+	return new(MultiResultEncoder)
+}
+
+// DialectType returns the type of the dialect.
+func (d *Dialect) DialectType() flux.DialectType {
+	return DialectType
+}
+
+var fieldTypes = map[flux.ColType]fieldType{
+	flux.TBool:   {typ: typeBool},
+	flux.TInt:    {typ: typeInt, bitWidth: 64, signed: true},
+	flux.TUInt:   {typ: typeInt, bitWidth: 64},
+	flux.TFloat:  {typ: typeFloatingPoint},
+	flux.TString: {typ: typeUtf8},
+	flux.TTime:   {typ: typeTimestamp},
+}
+
+func colType(t fieldType) flux.ColType {
+	for ct, ft := range fieldTypes {
+		if ft == t {
+			return ct
+		}
+	}
+	return flux.TInvalid
+}
+
+// MultiResultEncoder encodes results as Arrow IPC streams.
+type MultiResultEncoder struct{}
+
+// Encode writes the results to w. The error of the results, if any, is
+// encoded as the last stream.
+func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (int64, error) {
+	wc := &iocounter.Writer{Writer: w}
+
+	for results.More() {
+		res := results.Next()
+		table := 0
+		err := res.Tables().Do(func(tbl flux.Table) error {
+			if err := encodeTable(wc, res.Name(), table, tbl); err != nil {
+				return err
+			}
+			table++
+			return nil
+		})
+		if err != nil {
+			if flux.IsEncoderError(err) {
+				return wc.Count(), err
+			}
+			return wc.Count(), encodeError(wc, err)
+		}
+
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+
+	if err := results.Err(); err != nil {
+		return wc.Count(), encodeError(wc, err)
+	}
+	return wc.Count(), nil
+}
+
+// encoderError is an error writing the response, rather than of the query.
+type encoderError struct {
+	err error
+}
+
+func (e *encoderError) Error() string {
+	return e.err.Error()
+}
+
+func (e *encoderError) IsEncoderError() bool {
+	return true
+}
+
+func encodeError(w io.Writer, err error) error {
+	s := &schema{
+		metadata: map[string]string{errorKey: err.Error()},
+	}
+	if err := writeSchema(w, s); err != nil {
+		return err
+	}
+	return writeEOS(w)
+}
+
+func encodeTable(w io.Writer, result string, table int, tbl flux.Table) error {
+	s := &schema{
+		fields: make([]field, len(tbl.Cols())),
+		metadata: map[string]string{
+			resultKey: result,
+			tableKey:  strconv.Itoa(table),
+		},
+	}
+	for j, c := range tbl.Cols() {
+		s.fields[j] = field{
+			name: c.Label,
+			typ:  fieldTypes[c.Type],
+		}
+		if k := execute.ColIdx(c.Label, tbl.Key().Cols()); k >= 0 {
+			s.fields[j].metadata = map[string]string{groupKey: "true"}
+			if v := tbl.Key().Value(k); !v.IsNull() {
+				s.fields[j].metadata[valueKey] = formatValue(v)
+			}
+		}
+	}
+	if err := writeSchema(w, s); err != nil {
+		return &encoderError{err: err}
+	}
+
+	err := tbl.Do(func(cr flux.ColReader) error {
+		rb, body := encodeRecordBatch(cr)
+		if err := writeRecordBatch(w, rb, body); err != nil {
+			return &encoderError{err: err}
+		}
+		return nil
+	})
+	if err != nil && flux.IsEncoderError(err) {
+		return err
+	}
+
+	// The stream is ended before the error of the table, if any, is encoded.
+	if err := writeEOS(w); err != nil {
+		return &encoderError{err: err}
+	}
+	return err
+}
+
+// encodeRecordBatch returns the record batch of the rows of cr, and its body.
+func encodeRecordBatch(cr flux.ColReader) (*recordBatch, []byte) {
+	var (
+		n    = cr.Len()
+		rb   = &recordBatch{length: int64(n)}
+		body bytes.Buffer
+	)
+	addBuffer := func(b []byte) {
+		rb.buffers = append(rb.buffers, buffer{
+			offset: int64(body.Len()),
+			length: int64(len(b)),
+		})
+		body.Write(b)
+		body.Write(make([]byte, padding(len(b))))
+	}
+
+	for j, c := range cr.Cols() {
+		var (
+			valid = make([]byte, (n+7)/8)
+			nulls = 0
+			data  []byte
+			strs  []byte
+		)
+		isNull := func(i int) bool {
+			if execute.ValueForRow(cr, i, j).IsNull() {
+				nulls++
+				return true
+			}
+			valid[i/8] |= 1 << uint(i%8)
+			return false
+		}
+
+		switch c.Type {
+		case flux.TBool:
+			data = make([]byte, (n+7)/8)
+			vs := cr.Bools(j)
+			for i := 0; i < n; i++ {
+				if !isNull(i) && vs.Value(i) {
+					data[i/8] |= 1 << uint(i%8)
+				}
+			}
+		case flux.TInt, flux.TUInt, flux.TFloat, flux.TTime:
+			data = make([]byte, 8*n)
+			for i := 0; i < n; i++ {
+				if isNull(i) {
+					continue
+				}
+				var u uint64
+				switch c.Type {
+				case flux.TInt:
+					u = uint64(cr.Ints(j).Value(i))
+				case flux.TUInt:
+					u = cr.UInts(j).Value(i)
+				case flux.TFloat:
+					u = math.Float64bits(cr.Floats(j).Value(i))
+				case flux.TTime:
+					u = uint64(cr.Times(j).Value(i))
+				}
+				binary.LittleEndian.PutUint64(data[8*i:], u)
+			}
+		case flux.TString:
+			data = make([]byte, 4*(n+1))
+			vs := cr.Strings(j)
+			for i := 0; i < n; i++ {
+				if !isNull(i) {
+					strs = append(strs, vs.Value(i)...)
+				}
+				binary.LittleEndian.PutUint32(data[4*(i+1):], uint32(len(strs)))
+			}
+		}
+
+		rb.nodes = append(rb.nodes, fieldNode{
+			length:    int64(n),
+			nullCount: int64(nulls),
+		})
+		addBuffer(valid)
+		addBuffer(data)
+		if c.Type == flux.TString {
+			addBuffer(strs)
+		}
+	}
+	return rb, body.Bytes()
+}
+
+// formatValue formats the value of a column of the group key.
+func formatValue(v values.Value) string {
+	switch v.Type() {
+	case flux.SemanticType(flux.TBool):
+		return strconv.FormatBool(v.Bool())
+	case flux.SemanticType(flux.TInt):
+		return strconv.FormatInt(v.Int(), 10)
+	case flux.SemanticType(flux.TUInt):
+		return strconv.FormatUint(v.UInt(), 10)
+	case flux.SemanticType(flux.TFloat):
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	case flux.SemanticType(flux.TTime):
+		return strconv.FormatInt(int64(v.Time()), 10)
+	}
+	return v.Str()
+}
+
+// parseValue parses the value of a column of the group key.
+func parseValue(s string, t flux.ColType) (values.Value, error) {
+	switch t {
+	case flux.TBool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, err
+		}
+		return values.NewBool(b), nil
+	case flux.TInt:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return values.NewInt(i), nil
+	case flux.TUInt:
+		u, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return values.NewUInt(u), nil
+	case flux.TFloat:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, err
+		}
+		return values.NewFloat(f), nil
+	case flux.TTime:
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return values.NewTime(values.Time(i)), nil
+	}
+	return values.NewString(s), nil
+}
+
+// MultiResultDecoder decodes Arrow IPC streams into results.
+type MultiResultDecoder struct{}
+
+// NewMultiResultDecoder returns a decoder of Arrow IPC streams.
+func NewMultiResultDecoder() *MultiResultDecoder {
+	return new(MultiResultDecoder)
+}
+
+// Decode reads the results from r. The results are read in full before
+// they are returned; the error encoded in the response is returned by the
+// Err method of the results.
+func (d *MultiResultDecoder) Decode(r io.ReadCloser) (flux.ResultIterator, error) {
+	defer r.Close()
+
+	var (
+		names   []string
+		tables  = make(map[string][]flux.Table)
+		readErr error
+	)
+	for {
+		result, tbl, err := decodeStream(r)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if tbl == nil {
+			readErr = errors.New(result)
+			break
+		}
+
+		if _, ok := tables[result]; !ok {
+			names = append(names, result)
+		}
+		tables[result] = append(tables[result], tbl)
+	}
+
+	results := make([]flux.Result, len(names))
+	for i, name := range names {
+		results[i] = query.NewResult(name, tables[name])
+	}
+	return &resultIterator{
+		ResultIterator: flux.NewSliceResultIterator(results),
+		err:            readErr,
+	}, nil
+}
+
+// decodeStream decodes the table of the next stream of r, and the name of
+// its result. If the stream holds an error, the table is nil and the error
+// is returned in place of the name.
+func decodeStream(r io.Reader) (string, flux.Table, error) {
+	msg, err := readMessage(r)
+	if err == errEOS {
+		return "", nil, io.ErrUnexpectedEOF
+	} else if err != nil {
+		return "", nil, err
+	}
+	if msg.headerType != headerSchema {
+		return "", nil, fmt.Errorf("expected arrow schema message, got message type %d", msg.headerType)
+	}
+	s, err := readSchema(msg.header)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if msg, ok := s.metadata[errorKey]; ok {
+		if _, err := readMessage(r); err != errEOS {
+			return "", nil, fmt.Errorf("expected end of arrow stream: %v", err)
+		}
+		return msg, nil, nil
+	}
+
+	var (
+		cols    = make([]flux.ColMeta, len(s.fields))
+		keyCols []flux.ColMeta
+		keyVals []values.Value
+	)
+	for j, f := range s.fields {
+		cols[j] = flux.ColMeta{Label: f.name, Type: colType(f.typ)}
+		if f.metadata[groupKey] != "true" {
+			continue
+		}
+
+		v := values.NewNull(flux.SemanticType(cols[j].Type))
+		if s, ok := f.metadata[valueKey]; ok {
+			if v, err = parseValue(s, cols[j].Type); err != nil {
+				return "", nil, err
+			}
+		}
+		keyCols = append(keyCols, cols[j])
+		keyVals = append(keyVals, v)
+	}
+
+	b := execute.NewColListTableBuilder(execute.NewGroupKey(keyCols, keyVals), &memory.Allocator{})
+	for _, c := range cols {
+		if _, err := b.AddCol(c); err != nil {
+			return "", nil, err
+		}
+	}
+
+	for {
+		msg, err := readMessage(r)
+		if err == errEOS {
+			break
+		} else if err != nil {
+			return "", nil, unexpectedEOF(err)
+		}
+		if msg.headerType != headerRecordBatch {
+			return "", nil, fmt.Errorf("expected arrow record batch message, got message type %d", msg.headerType)
+		}
+		if err := decodeRecordBatch(b, readRecordBatch(msg.header), msg.body); err != nil {
+			return "", nil, err
+		}
+	}
+
+	tbl, err := b.Table()
+	if err != nil {
+		return "", nil, err
+	}
+	return s.metadata[resultKey], tbl, nil
+}
+
+// decodeRecordBatch appends the rows of the record batch rb to b.
+func decodeRecordBatch(b *execute.ColListTableBuilder, rb *recordBatch, body []byte) error {
+	buffers := rb.buffers
+	next := func() ([]byte, error) {
+		if len(buffers) == 0 {
+			return nil, errors.New("arrow record batch has too few buffers")
+		}
+		buf := buffers[0]
+		buffers = buffers[1:]
+		if buf.offset < 0 || buf.length < 0 || buf.offset+buf.length > int64(len(body)) {
+			return nil, errors.New("arrow buffer is out of the bounds of the body of its message")
+		}
+		return body[buf.offset : buf.offset+buf.length], nil
+	}
+
+	if len(rb.nodes) != len(b.Cols()) {
+		return fmt.Errorf("arrow record batch has %d columns, expected %d", len(rb.nodes), len(b.Cols()))
+	}
+	for j, c := range b.Cols() {
+		n := int(rb.nodes[j].length)
+		valid, err := next()
+		if err != nil {
+			return err
+		}
+		data, err := next()
+		if err != nil {
+			return err
+		}
+		var strs []byte
+		if c.Type == flux.TString {
+			if strs, err = next(); err != nil {
+				return err
+			}
+		}
+
+		size := 8 * n
+		switch c.Type {
+		case flux.TBool:
+			size = (n + 7) / 8
+		case flux.TString:
+			size = 4 * (n + 1)
+		}
+		if len(data) < size || (len(valid) > 0 && len(valid) < (n+7)/8) {
+			return fmt.Errorf("arrow buffers of column %q are too short", c.Label)
+		}
+
+		for i := 0; i < n; i++ {
+			if len(valid) > 0 && valid[i/8]&(1<<uint(i%8)) == 0 {
+				if err := b.AppendNil(j); err != nil {
+					return err
+				}
+				continue
+			}
+
+			var v values.Value
+			switch c.Type {
+			case flux.TBool:
+				v = values.NewBool(data[i/8]&(1<<uint(i%8)) != 0)
+			case flux.TInt:
+				v = values.NewInt(int64(binary.LittleEndian.Uint64(data[8*i:])))
+			case flux.TUInt:
+				v = values.NewUInt(binary.LittleEndian.Uint64(data[8*i:]))
+			case flux.TFloat:
+				v = values.NewFloat(math.Float64frombits(binary.LittleEndian.Uint64(data[8*i:])))
+			case flux.TTime:
+				v = values.NewTime(values.Time(binary.LittleEndian.Uint64(data[8*i:])))
+			case flux.TString:
+				start := binary.LittleEndian.Uint32(data[4*i:])
+				end := binary.LittleEndian.Uint32(data[4*(i+1):])
+				if start > end || int(end) > len(strs) {
+					return fmt.Errorf("arrow offsets of column %q are out of bounds", c.Label)
+				}
+				v = values.NewString(string(strs[start:end]))
+			}
+			if err := b.AppendValue(j, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// resultIterator reports the error encoded in a response.
+type resultIterator struct {
+	flux.ResultIterator
+	err error
+}
+
+func (r *resultIterator) Err() error {
+	return r.err
+}
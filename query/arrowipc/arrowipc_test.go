@@ -0,0 +1,110 @@
+package arrowipc_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"math"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/influxdb/query/arrowipc"
+)
+
+func TestMultiResultEncoder_RoundTrip(t *testing.T) {
+	results := []flux.Result{
+		&executetest.Result{
+			Nm: "_result",
+			Tbls: []*executetest.Table{
+				{
+					KeyCols: []string{"_measurement", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "ok", Type: flux.TBool},
+						{Label: "n", Type: flux.TInt},
+						{Label: "u", Type: flux.TUInt},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), "cpu", "a", 1.5, true, int64(-1), uint64(1)},
+						{execute.Time(2), "cpu", "a", math.Inf(1), false, int64(2), uint64(2)},
+						{execute.Time(3), "cpu", "a", nil, nil, nil, nil},
+					},
+				},
+				{
+					KeyCols:   []string{"_measurement", "host"},
+					KeyValues: []interface{}{"cpu", "b"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "_value", Type: flux.TFloat},
+					},
+				},
+			},
+		},
+		&executetest.Result{
+			Nm: "other",
+			Tbls: []*executetest.Table{
+				{
+					ColMeta: []flux.ColMeta{
+						{Label: "_value", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{"x\ny"},
+					},
+				},
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	enc := new(arrowipc.Dialect).Encoder()
+	if _, err := enc.Encode(&buf, flux.NewSliceResultIterator(results)); err != nil {
+		t.Fatal(err)
+	}
+	ri, err := arrowipc.NewMultiResultDecoder().Decode(ioutil.NopCloser(&buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []flux.Result
+	for ri.More() {
+		got = append(got, ri.Next())
+	}
+	if err := ri.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, err := executetest.EqualResults(results, got); !ok {
+		t.Fatal(err)
+	}
+}
+
+func TestMultiResultEncoder_Error(t *testing.T) {
+	results := []flux.Result{
+		&executetest.Result{
+			Nm:  "_result",
+			Err: errors.New("expected error"),
+		},
+	}
+
+	var buf bytes.Buffer
+	enc := new(arrowipc.Dialect).Encoder()
+	if _, err := enc.Encode(&buf, flux.NewSliceResultIterator(results)); err != nil {
+		t.Fatal(err)
+	}
+	ri, err := arrowipc.NewMultiResultDecoder().Decode(ioutil.NopCloser(&buf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for ri.More() {
+		ri.Next()
+	}
+	if err := ri.Err(); err == nil || err.Error() != "expected error" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,431 @@
+package arrowipc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+)
+
+// This file writes and reads the encapsulated messages of the Arrow IPC
+// streaming format. The flatbuffers tables are those of the Schema.fbs and
+// Message.fbs files of the Arrow format, at metadata version V4.
+
+const (
+	// continuation is the marker preceding the length of each message.
+	continuation = 0xFFFFFFFF
+
+	metadataV4 = 3
+)
+
+// The types of the header of messages.
+const (
+	headerSchema      = 1
+	headerRecordBatch = 3
+)
+
+// The types of fields.
+const (
+	typeInt           = 2
+	typeFloatingPoint = 3
+	typeUtf8          = 5
+	typeBool          = 6
+	typeTimestamp     = 10
+)
+
+const (
+	precisionDouble = 2
+	unitNanosecond  = 3
+)
+
+// fieldType is the Arrow type of a field.
+type fieldType struct {
+	typ      byte
+	bitWidth int32
+	signed   bool
+}
+
+// field is a field of a schema.
+type field struct {
+	name     string
+	typ      fieldType
+	metadata map[string]string
+}
+
+// schema is the header of the first message of a stream.
+type schema struct {
+	fields   []field
+	metadata map[string]string
+}
+
+// fieldNode is the length and number of nulls of a column of a record batch.
+type fieldNode struct {
+	length    int64
+	nullCount int64
+}
+
+// buffer is the location of a buffer in the body of a record batch.
+type buffer struct {
+	offset int64
+	length int64
+}
+
+// recordBatch is the header of the messages holding the rows of a stream.
+type recordBatch struct {
+	length  int64
+	nodes   []fieldNode
+	buffers []buffer
+}
+
+// writeSchema writes the schema message s.
+func writeSchema(w io.Writer, s *schema) error {
+	b := flatbuffers.NewBuilder(1024)
+
+	fields := make([]flatbuffers.UOffsetT, len(s.fields))
+	for i, f := range s.fields {
+		fields[i] = buildField(b, &f)
+	}
+	fieldsVec := buildOffsets(b, fields)
+	metadata := buildMetadata(b, s.metadata)
+
+	b.StartObject(3)
+	b.PrependUOffsetTSlot(1, fieldsVec, 0)
+	b.PrependUOffsetTSlot(2, metadata, 0)
+	header := b.EndObject()
+
+	return writeMessage(w, b, headerSchema, header, nil)
+}
+
+// writeRecordBatch writes the record batch message rb, with the body body.
+func writeRecordBatch(w io.Writer, rb *recordBatch, body []byte) error {
+	b := flatbuffers.NewBuilder(1024)
+
+	b.StartVector(16, len(rb.nodes), 8)
+	for i := len(rb.nodes) - 1; i >= 0; i-- {
+		b.Prep(8, 16)
+		b.PrependInt64(rb.nodes[i].nullCount)
+		b.PrependInt64(rb.nodes[i].length)
+	}
+	nodes := b.EndVector(len(rb.nodes))
+
+	b.StartVector(16, len(rb.buffers), 8)
+	for i := len(rb.buffers) - 1; i >= 0; i-- {
+		b.Prep(8, 16)
+		b.PrependInt64(rb.buffers[i].length)
+		b.PrependInt64(rb.buffers[i].offset)
+	}
+	buffers := b.EndVector(len(rb.buffers))
+
+	b.StartObject(3)
+	b.PrependInt64Slot(0, rb.length, 0)
+	b.PrependUOffsetTSlot(1, nodes, 0)
+	b.PrependUOffsetTSlot(2, buffers, 0)
+	header := b.EndObject()
+
+	return writeMessage(w, b, headerRecordBatch, header, body)
+}
+
+// writeEOS writes the end of a stream.
+func writeEOS(w io.Writer) error {
+	var eos [8]byte
+	binary.LittleEndian.PutUint32(eos[:4], continuation)
+	_, err := w.Write(eos[:])
+	return err
+}
+
+func writeMessage(w io.Writer, b *flatbuffers.Builder, headerType byte, header flatbuffers.UOffsetT, body []byte) error {
+	b.StartObject(5)
+	b.PrependInt16Slot(0, metadataV4, 0)
+	b.PrependUint8Slot(1, headerType, 0)
+	b.PrependUOffsetTSlot(2, header, 0)
+	b.PrependInt64Slot(3, int64(len(body)), 0)
+	b.Finish(b.EndObject())
+
+	meta := b.FinishedBytes()
+	var prefix [8]byte
+	binary.LittleEndian.PutUint32(prefix[:4], continuation)
+	binary.LittleEndian.PutUint32(prefix[4:], uint32(len(meta)+padding(len(meta))))
+
+	if _, err := w.Write(prefix[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write(meta); err != nil {
+		return err
+	}
+	if _, err := w.Write(make([]byte, padding(len(meta)))); err != nil {
+		return err
+	}
+	_, err := w.Write(body)
+	return err
+}
+
+func buildField(b *flatbuffers.Builder, f *field) flatbuffers.UOffsetT {
+	name := b.CreateString(f.name)
+
+	var typ flatbuffers.UOffsetT
+	switch f.typ.typ {
+	case typeInt:
+		b.StartObject(2)
+		b.PrependInt32Slot(0, f.typ.bitWidth, 0)
+		b.PrependBoolSlot(1, f.typ.signed, false)
+		typ = b.EndObject()
+	case typeFloatingPoint:
+		b.StartObject(1)
+		b.PrependInt16Slot(0, precisionDouble, 0)
+		typ = b.EndObject()
+	case typeTimestamp:
+		tz := b.CreateString("UTC")
+		b.StartObject(2)
+		b.PrependInt16Slot(0, unitNanosecond, 0)
+		b.PrependUOffsetTSlot(1, tz, 0)
+		typ = b.EndObject()
+	default:
+		b.StartObject(0)
+		typ = b.EndObject()
+	}
+
+	children := buildOffsets(b, nil)
+	metadata := buildMetadata(b, f.metadata)
+
+	b.StartObject(7)
+	b.PrependUOffsetTSlot(0, name, 0)
+	b.PrependBoolSlot(1, true, false)
+	b.PrependUint8Slot(2, f.typ.typ, 0)
+	b.PrependUOffsetTSlot(3, typ, 0)
+	b.PrependUOffsetTSlot(5, children, 0)
+	b.PrependUOffsetTSlot(6, metadata, 0)
+	return b.EndObject()
+}
+
+func buildMetadata(b *flatbuffers.Builder, metadata map[string]string) flatbuffers.UOffsetT {
+	if len(metadata) == 0 {
+		return 0
+	}
+
+	kvs := make([]flatbuffers.UOffsetT, 0, len(metadata))
+	for _, k := range sortedKeys(metadata) {
+		key := b.CreateString(k)
+		value := b.CreateString(metadata[k])
+		b.StartObject(2)
+		b.PrependUOffsetTSlot(0, key, 0)
+		b.PrependUOffsetTSlot(1, value, 0)
+		kvs = append(kvs, b.EndObject())
+	}
+	return buildOffsets(b, kvs)
+}
+
+func buildOffsets(b *flatbuffers.Builder, offs []flatbuffers.UOffsetT) flatbuffers.UOffsetT {
+	b.StartVector(4, len(offs), 4)
+	for i := len(offs) - 1; i >= 0; i-- {
+		b.PrependUOffsetT(offs[i])
+	}
+	return b.EndVector(len(offs))
+}
+
+// padding returns the number of bytes padding n bytes to a multiple of 8.
+func padding(n int) int {
+	return (8 - n%8) % 8
+}
+
+// errEOS is returned by readMessage at the end of a stream.
+var errEOS = errors.New("end of stream")
+
+// message is a message read from a stream.
+type message struct {
+	headerType byte
+	header     fbTable
+	body       []byte
+}
+
+// readMessage reads the next message of a stream. It returns io.EOF if r
+// has no more streams, and errEOS at the end of a stream.
+func readMessage(r io.Reader) (*message, error) {
+	var prefix [4]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		return nil, err
+	}
+	size := binary.LittleEndian.Uint32(prefix[:])
+	if size == continuation {
+		if _, err := io.ReadFull(r, prefix[:]); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		size = binary.LittleEndian.Uint32(prefix[:])
+	}
+	if size == 0 {
+		return nil, errEOS
+	}
+
+	meta := make([]byte, size)
+	if _, err := io.ReadFull(r, meta); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+
+	msg := fbTable{flatbuffers.Table{
+		Bytes: meta,
+		Pos:   flatbuffers.GetUOffsetT(meta),
+	}}
+	if v := msg.GetInt16Slot(slot(0), 0); v != metadataV4 {
+		return nil, fmt.Errorf("unsupported arrow metadata version %d", v)
+	}
+	header, ok := msg.table(2)
+	if !ok {
+		return nil, errors.New("arrow message has no header")
+	}
+
+	body := make([]byte, msg.GetInt64Slot(slot(3), 0))
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+
+	return &message{
+		headerType: msg.GetUint8Slot(slot(1), 0),
+		header:     header,
+		body:       body,
+	}, nil
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// readSchema reads the schema of the header of a message.
+func readSchema(t fbTable) (*schema, error) {
+	s := &schema{
+		metadata: readMetadata(t, 2),
+	}
+	for _, ft := range t.tables(1) {
+		name, _ := ft.string(0)
+		f := field{
+			name:     name,
+			metadata: readMetadata(ft, 6),
+		}
+
+		f.typ.typ = ft.GetUint8Slot(slot(2), 0)
+		typ, ok := ft.table(3)
+		if !ok {
+			return nil, fmt.Errorf("arrow field %q has no type", name)
+		}
+		switch f.typ.typ {
+		case typeInt:
+			f.typ.bitWidth = typ.GetInt32Slot(slot(0), 0)
+			f.typ.signed = typ.GetBoolSlot(slot(1), false)
+			if f.typ.bitWidth != 64 {
+				return nil, fmt.Errorf("unsupported bit width %d of arrow field %q", f.typ.bitWidth, name)
+			}
+		case typeFloatingPoint:
+			if p := typ.GetInt16Slot(slot(0), 0); p != precisionDouble {
+				return nil, fmt.Errorf("unsupported precision %d of arrow field %q", p, name)
+			}
+		case typeTimestamp:
+			if u := typ.GetInt16Slot(slot(0), 0); u != unitNanosecond {
+				return nil, fmt.Errorf("unsupported time unit %d of arrow field %q", u, name)
+			}
+		case typeUtf8, typeBool:
+		default:
+			return nil, fmt.Errorf("unsupported type %d of arrow field %q", f.typ.typ, name)
+		}
+		s.fields = append(s.fields, f)
+	}
+	return s, nil
+}
+
+// readRecordBatch reads the record batch of the header of a message.
+func readRecordBatch(t fbTable) *recordBatch {
+	rb := &recordBatch{
+		length: t.GetInt64Slot(slot(0), 0),
+	}
+	for _, pos := range t.structs(1, 16) {
+		rb.nodes = append(rb.nodes, fieldNode{
+			length:    t.GetInt64(pos),
+			nullCount: t.GetInt64(pos + 8),
+		})
+	}
+	for _, pos := range t.structs(2, 16) {
+		rb.buffers = append(rb.buffers, buffer{
+			offset: t.GetInt64(pos),
+			length: t.GetInt64(pos + 8),
+		})
+	}
+	return rb
+}
+
+func readMetadata(t fbTable, s int) map[string]string {
+	kvs := t.tables(s)
+	if len(kvs) == 0 {
+		return nil
+	}
+	metadata := make(map[string]string, len(kvs))
+	for _, kv := range kvs {
+		k, _ := kv.string(0)
+		v, _ := kv.string(1)
+		metadata[k] = v
+	}
+	return metadata
+}
+
+// fbTable reads the fields of a flatbuffers table by their slot.
+type fbTable struct {
+	flatbuffers.Table
+}
+
+func slot(s int) flatbuffers.VOffsetT {
+	return flatbuffers.VOffsetT(4 + 2*s)
+}
+
+func (t fbTable) offset(s int) flatbuffers.UOffsetT {
+	return flatbuffers.UOffsetT(t.Offset(slot(s)))
+}
+
+func (t fbTable) table(s int) (fbTable, bool) {
+	o := t.offset(s)
+	if o == 0 {
+		return fbTable{}, false
+	}
+	return fbTable{flatbuffers.Table{
+		Bytes: t.Bytes,
+		Pos:   t.Indirect(o + t.Pos),
+	}}, true
+}
+
+func (t fbTable) string(s int) (string, bool) {
+	o := t.offset(s)
+	if o == 0 {
+		return "", false
+	}
+	return t.String(o + t.Pos), true
+}
+
+func (t fbTable) tables(s int) []fbTable {
+	o := t.offset(s)
+	if o == 0 {
+		return nil
+	}
+	start, n := t.Vector(o), t.VectorLen(o)
+	ts := make([]fbTable, n)
+	for i := range ts {
+		ts[i] = fbTable{flatbuffers.Table{
+			Bytes: t.Bytes,
+			Pos:   t.Indirect(start + flatbuffers.UOffsetT(4*i)),
+		}}
+	}
+	return ts
+}
+
+// structs returns the positions of the structs of size size of a vector.
+func (t fbTable) structs(s, size int) []flatbuffers.UOffsetT {
+	o := t.offset(s)
+	if o == 0 {
+		return nil
+	}
+	start, n := t.Vector(o), t.VectorLen(o)
+	ps := make([]flatbuffers.UOffsetT, n)
+	for i := range ps {
+		ps[i] = start + flatbuffers.UOffsetT(size*i)
+	}
+	return ps
+}
@@ -25,6 +25,10 @@ type Compiler struct {
 	Query   string     `json:"query"`
 	Now     *time.Time `json:"now,omitempty"`
 
+	// BucketID is the bucket read by the measurements of the query without
+	// a database when DB is empty.
+	BucketID platform.ID `json:"bucketID,omitempty"`
+
 	dbrpMappingSvc platform.DBRPMappingService
 }
 
@@ -48,6 +52,7 @@ func (c *Compiler) Compile(ctx context.Context) (*flux.Spec, error) {
 			Cluster:                c.Cluster,
 			DefaultDatabase:        c.DB,
 			DefaultRetentionPolicy: c.RP,
+			DefaultBucketID:        c.BucketID,
 			Now:                    now,
 		},
 	)
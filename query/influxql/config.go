@@ -2,6 +2,8 @@ package influxql
 
 import (
 	"time"
+
+	platform "github.com/influxdata/influxdb"
 )
 
 // Config modifies the behavior of the Transpiler.
@@ -10,4 +12,8 @@ type Config struct {
 	DefaultRetentionPolicy string
 	Now                    time.Time
 	Cluster                string
+
+	// DefaultBucketID is the bucket read by the measurements without a
+	// database when there is no default database.
+	DefaultBucketID platform.ID
 }
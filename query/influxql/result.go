@@ -1,6 +1,7 @@
 package influxql
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,10 +11,16 @@ import (
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/execute"
 	"github.com/influxdata/flux/iocounter"
+	"github.com/influxdata/influxdb/models"
 )
 
 // MultiResultEncoder encodes results as InfluxQL JSON format.
-type MultiResultEncoder struct{}
+type MultiResultEncoder struct {
+	// Encoding is the format of the output; defaults to JSON.
+	Encoding EncodingFormat
+	// TimeFormat is the format of the timestamps; defaults to RFC3339Nano.
+	TimeFormat TimeFormat
+}
 
 // Encode writes a collection of results to the influxdb 1.X http response format.
 // Expectations/Assumptions:
@@ -154,7 +161,7 @@ func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (i
 						vs := cr.Times(idx)
 						for i := 0; i < vs.Len(); i++ {
 							if vs.IsValid(i) {
-								values[i][j] = execute.Time(vs.Value(i)).Time().Format(time.RFC3339Nano)
+								values[i][j] = e.formatTime(execute.Time(vs.Value(i)))
 							}
 						}
 					default:
@@ -182,9 +189,126 @@ func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (i
 		resp.error(err)
 	}
 
-	err := json.NewEncoder(wc).Encode(resp)
+	var err error
+	switch e.Encoding {
+	case JSON:
+		err = json.NewEncoder(wc).Encode(resp)
+	case JSONPretty:
+		enc := json.NewEncoder(wc)
+		enc.SetIndent("", "    ")
+		err = enc.Encode(resp)
+	case CSV:
+		err = encodeCSV(wc, &resp)
+	default:
+		err = fmt.Errorf("unsupported encoding format: %d", e.Encoding)
+	}
 	return wc.Count(), err
 }
+
+// formatTime formats t as a string for RFC3339Nano, or as an integer number of
+// units since the unix epoch for every other time format.
+func (e *MultiResultEncoder) formatTime(t execute.Time) interface{} {
+	var d time.Duration
+	switch e.TimeFormat {
+	case Hour:
+		d = time.Hour
+	case Minute:
+		d = time.Minute
+	case Second:
+		d = time.Second
+	case Millisecond:
+		d = time.Millisecond
+	case Microsecond:
+		d = time.Microsecond
+	case Nanosecond:
+		d = time.Nanosecond
+	default:
+		return t.Time().Format(time.RFC3339Nano)
+	}
+	return int64(t) / int64(d)
+}
+
+// encodeCSV writes the response in the influxdb 1.X CSV format. Every row starts
+// with the series name and its tags, and a new header is written whenever the
+// columns change.
+func encodeCSV(w io.Writer, resp *Response) error {
+	cw := csv.NewWriter(w)
+	if resp.Err != "" {
+		_ = cw.Write([]string{"error"})
+		_ = cw.Write([]string{resp.Err})
+		cw.Flush()
+		return cw.Error()
+	}
+
+	var header []string
+	for _, result := range resp.Results {
+		if result.Err != "" {
+			_ = cw.Write([]string{"error"})
+			_ = cw.Write([]string{result.Err})
+			header = nil
+			continue
+		}
+
+		for _, row := range result.Series {
+			if !columnsEqual(header, row.Columns) {
+				if header != nil {
+					// Separate the series with an empty line.
+					_ = cw.Write(nil)
+				}
+				header = append([]string{"name", "tags"}, row.Columns...)
+				_ = cw.Write(header)
+			}
+
+			var tags string
+			if len(row.Tags) > 0 {
+				tags = string(models.NewTags(row.Tags).HashKey()[1:])
+			}
+
+			record := make([]string, len(row.Columns)+2)
+			record[0], record[1] = row.Name, tags
+			for _, values := range row.Values {
+				for i, v := range values {
+					record[i+2] = formatCSVValue(v)
+				}
+				_ = cw.Write(record)
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func columnsEqual(header, columns []string) bool {
+	if len(header) != len(columns)+2 {
+		return false
+	}
+	for i, c := range columns {
+		if header[i+2] != c {
+			return false
+		}
+	}
+	return true
+}
+
+func formatCSVValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
 func NewMultiResultEncoder() *MultiResultEncoder {
 	return new(MultiResultEncoder)
 }
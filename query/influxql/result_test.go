@@ -106,6 +106,89 @@ func TestMultiResultEncoder_Encode(t *testing.T) {
 	}
 }
 
+func TestMultiResultEncoder_EncodeFormats(t *testing.T) {
+	newResults := func() flux.ResultIterator {
+		return flux.NewSliceResultIterator(
+			[]flux.Result{&executetest.Result{
+				Nm: "0",
+				Tbls: []*executetest.Table{
+					{
+						KeyCols: []string{"_measurement", "host"},
+						ColMeta: []flux.ColMeta{
+							{Label: "_time", Type: flux.TTime},
+							{Label: "_measurement", Type: flux.TString},
+							{Label: "host", Type: flux.TString},
+							{Label: "value", Type: flux.TFloat},
+						},
+						Data: [][]interface{}{
+							{ts("2018-05-24T09:00:00Z"), "m0", "server01", float64(2)},
+							{ts("2018-05-24T09:00:10Z"), "m0", "server01", float64(2.5)},
+						},
+					},
+					{
+						KeyCols: []string{"_measurement"},
+						ColMeta: []flux.ColMeta{
+							{Label: "_time", Type: flux.TTime},
+							{Label: "_measurement", Type: flux.TString},
+							{Label: "count", Type: flux.TInt},
+						},
+						Data: [][]interface{}{
+							{ts("2018-05-24T09:00:00Z"), "m1", int64(4)},
+						},
+					},
+				},
+			}},
+		)
+	}
+
+	for _, tt := range []struct {
+		name string
+		enc  *influxql.MultiResultEncoder
+		out  string
+	}{
+		{
+			name: "JSON epoch ms",
+			enc:  &influxql.MultiResultEncoder{TimeFormat: influxql.Millisecond},
+			out:  `{"results":[{"statement_id":0,"series":[{"name":"m0","tags":{"host":"server01"},"columns":["time","value"],"values":[[1527152400000,2],[1527152410000,2.5]]},{"name":"m1","columns":["time","count"],"values":[[1527152400000,4]]}]}]}` + "\n",
+		},
+		{
+			name: "CSV",
+			enc:  &influxql.MultiResultEncoder{Encoding: influxql.CSV},
+			out: "name,tags,time,value\n" +
+				"m0,host=server01,2018-05-24T09:00:00Z,2\n" +
+				"m0,host=server01,2018-05-24T09:00:10Z,2.5\n" +
+				"\n" +
+				"name,tags,time,count\n" +
+				"m1,,2018-05-24T09:00:00Z,4\n",
+		},
+		{
+			name: "CSV epoch s",
+			enc:  &influxql.MultiResultEncoder{Encoding: influxql.CSV, TimeFormat: influxql.Second},
+			out: "name,tags,time,value\n" +
+				"m0,host=server01,1527152400,2\n" +
+				"m0,host=server01,1527152410,2.5\n" +
+				"\n" +
+				"name,tags,time,count\n" +
+				"m1,,1527152400,4\n",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			n, err := tt.enc.Encode(&buf, newResults())
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got, exp := buf.String(), tt.out; got != exp {
+				t.Fatalf("unexpected output:\nexp=%s\ngot=%s", exp, got)
+			}
+			if g, w := n, int64(len(tt.out)); g != w {
+				t.Errorf("unexpected encoding count -want/+got:\n%s", cmp.Diff(w, g))
+			}
+		})
+	}
+}
+
 type resultErrorIterator struct {
 	Error string
 }
@@ -134,7 +134,7 @@ func (t *transpilerState) transpileShowTagValues(ctx context.Context, stmt *infl
 	// the default retention policy when evaluating which bucket we are querying and we do not have to consult
 	// the sources in the statement.
 	if stmt.Database == "" {
-		if t.config.DefaultDatabase == "" {
+		if t.config.DefaultDatabase == "" && !t.config.DefaultBucketID.Valid() {
 			return nil, errDatabaseNameRequired
 		}
 		stmt.Database = t.config.DefaultDatabase
@@ -645,6 +645,9 @@ func (t *transpilerState) from(m *influxql.Measurement) (ast.Expression, error)
 	db, rp := m.Database, m.RetentionPolicy
 	if db == "" {
 		if t.config.DefaultDatabase == "" {
+			if t.config.DefaultBucketID.Valid() {
+				return fromBucketID(t.config.DefaultBucketID), nil
+			}
 			return nil, errors.New("database is required")
 		}
 		db = t.config.DefaultDatabase
@@ -669,7 +672,11 @@ func (t *transpilerState) from(m *influxql.Measurement) (ast.Expression, error)
 	if err != nil {
 		return nil, err
 	}
+	return fromBucketID(mapping.BucketID), nil
+}
 
+// fromBucketID returns a call to from reading the bucket with the ID id.
+func fromBucketID(id platform.ID) ast.Expression {
 	return &ast.CallExpression{
 		Callee: &ast.Identifier{
 			Name: "from",
@@ -682,13 +689,13 @@ func (t *transpilerState) from(m *influxql.Measurement) (ast.Expression, error)
 							Name: "bucketID",
 						},
 						Value: &ast.StringLiteral{
-							Value: mapping.BucketID.String(),
+							Value: id.String(),
 						},
 					},
 				},
 			},
 		},
-	}, nil
+	}
 }
 
 func (t *transpilerState) assignment(expr ast.Expression) *ast.Identifier {
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/influxdata/flux/ast"
 	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/mock"
 	"github.com/influxdata/influxdb/query/influxql"
@@ -406,3 +407,30 @@ func TestTranspiler_Compile(t *testing.T) {
 		})
 	}
 }
+
+func TestTranspiler_DefaultBucketID(t *testing.T) {
+	bucketID := platformtesting.MustIDBase16("cccccccccccccccc")
+	transpiler := influxql.NewTranspilerWithConfig(
+		dbrpMappingSvc,
+		influxql.Config{
+			DefaultBucketID: bucketID,
+		},
+	)
+
+	pkg, err := transpiler.Transpile(context.Background(), `SELECT value FROM cpu`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ast.Format(pkg); !strings.Contains(got, `bucketID: "cccccccccccccccc"`) {
+		t.Errorf("expected query to read the default bucket, got:\n%s", got)
+	}
+
+	// Measurements with a database still read the bucket it is mapped to.
+	pkg, err = transpiler.Transpile(context.Background(), `SELECT value FROM db0.autogen.cpu`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ast.Format(pkg); !strings.Contains(got, `bucketID: "bbbbbbbbbbbbbbbb"`) {
+		t.Errorf("expected query to read the mapped bucket, got:\n%s", got)
+	}
+}
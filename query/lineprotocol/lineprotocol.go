@@ -0,0 +1,404 @@
+// Package lineprotocol encodes the results of queries as line protocol, so
+// that they can be written into another bucket, and decodes them.
+//
+// Each row of a table is encoded as a point. The measurement and time of
+// the point are the values of the _measurement and _time columns. If the
+// table has a _field column, the point has a single field named by it, with
+// the value of the _value column; otherwise the table is taken to be pivoted
+// and each column that is not a string or a time is a field of the point.
+// The other string columns, but _start and _stop, are the tags of the point.
+//
+// An error of the query ends the response with a comment holding it:
+//
+//	# error: ...
+package lineprotocol
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/iocounter"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/query"
+)
+
+const (
+	// DialectType is the type of the line protocol dialect.
+	DialectType = "lineprotocol"
+
+	// ContentType is the media type of line protocol responses.
+	ContentType = "text/plain; charset=utf-8"
+
+	// errorPrefix is the prefix of the comment holding the error of a query.
+	errorPrefix = "# error: "
+
+	// DefaultResultName is the name of the result of decoded points.
+	DefaultResultName = "_result"
+)
+
+// excludedTags are the string columns of tables that are not tags.
+var excludedTags = []string{
+	execute.DefaultStartColLabel,
+	execute.DefaultStopColLabel,
+	execute.DefaultTimeColLabel,
+	execute.DefaultValueColLabel,
+	"_measurement",
+	"_field",
+}
+
+// AddDialectMappings adds the line protocol dialect mappings.
+func AddDialectMappings(mappings flux.DialectMappings) error {
+	return mappings.Add(DialectType, func() flux.Dialect {
+		return new(Dialect)
+	})
+}
+
+// Dialect describes the output format of queries as line protocol.
+type Dialect struct{}
+
+// SetHeaders sets the content type of the response.
+func (d *Dialect) SetHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("Transfer-Encoding", "chunked")
+}
+
+// Encoder returns the encoder of the results.
+func (d *Dialect) Encoder() flux.MultiResultEncoder {
+	return new(MultiResultEncoder)
+}
+
+// DialectType returns the type of the dialect.
+func (d *Dialect) DialectType() flux.DialectType {
+	return DialectType
+}
+
+// MultiResultEncoder encodes results as line protocol.
+type MultiResultEncoder struct{}
+
+// Encode writes the points of the results to w. The error of the results,
+// if any, is encoded as the last line.
+func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (int64, error) {
+	wc := &iocounter.Writer{Writer: w}
+	bw := bufio.NewWriter(wc)
+
+	for results.More() {
+		res := results.Next()
+		err := res.Tables().Do(func(tbl flux.Table) error {
+			return encodeTable(bw, tbl)
+		})
+		if err != nil {
+			if flux.IsEncoderError(err) {
+				return wc.Count(), err
+			}
+			return wc.Count(), encodeError(bw, err)
+		}
+
+		if err := bw.Flush(); err != nil {
+			return wc.Count(), err
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+
+	if err := results.Err(); err != nil {
+		return wc.Count(), encodeError(bw, err)
+	}
+	return wc.Count(), bw.Flush()
+}
+
+// encoderError is an error writing the response, rather than of the query.
+type encoderError struct {
+	err error
+}
+
+func (e *encoderError) Error() string {
+	return e.err.Error()
+}
+
+func (e *encoderError) IsEncoderError() bool {
+	return true
+}
+
+func encodeError(w *bufio.Writer, err error) error {
+	msg := strings.Replace(err.Error(), "\n", " ", -1)
+	if _, err := w.WriteString(errorPrefix + msg + "\n"); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func encodeTable(w *bufio.Writer, tbl flux.Table) error {
+	cols := tbl.Cols()
+	var (
+		measurementIdx = execute.ColIdx("_measurement", cols)
+		timeIdx        = execute.ColIdx(execute.DefaultTimeColLabel, cols)
+		fieldIdx       = execute.ColIdx("_field", cols)
+		valueIdx       = execute.ColIdx(execute.DefaultValueColLabel, cols)
+		tagIdxs        []int
+		fieldIdxs      []int
+	)
+	if measurementIdx < 0 || cols[measurementIdx].Type != flux.TString {
+		return errors.New("cannot encode table as line protocol: no _measurement column of type string")
+	}
+	if timeIdx < 0 || cols[timeIdx].Type != flux.TTime {
+		return errors.New("cannot encode table as line protocol: no _time column of type time")
+	}
+	if fieldIdx >= 0 && (cols[fieldIdx].Type != flux.TString || valueIdx < 0) {
+		return errors.New("cannot encode table as line protocol: no _value column for the _field column")
+	}
+
+	for j, c := range cols {
+		switch {
+		case c.Type == flux.TString && !execute.ContainsStr(excludedTags, c.Label):
+			tagIdxs = append(tagIdxs, j)
+		case fieldIdx < 0 && c.Type != flux.TString && c.Type != flux.TTime:
+			fieldIdxs = append(fieldIdxs, j)
+		}
+	}
+
+	var buf []byte
+	return tbl.Do(func(cr flux.ColReader) error {
+		for i := 0; i < cr.Len(); i++ {
+			measurement := execute.ValueForRow(cr, i, measurementIdx)
+			ts := execute.ValueForRow(cr, i, timeIdx)
+			if measurement.IsNull() || ts.IsNull() {
+				continue
+			}
+
+			tags := make(map[string]string, len(tagIdxs))
+			for _, j := range tagIdxs {
+				if v := execute.ValueForRow(cr, i, j); !v.IsNull() && v.Str() != "" {
+					tags[cols[j].Label] = v.Str()
+				}
+			}
+
+			fields := make(models.Fields)
+			if fieldIdx >= 0 {
+				f := execute.ValueForRow(cr, i, fieldIdx)
+				if v := execute.ValueForRow(cr, i, valueIdx); !f.IsNull() && !v.IsNull() {
+					fields[f.Str()] = fieldValue(v)
+				}
+			} else {
+				for _, j := range fieldIdxs {
+					if v := execute.ValueForRow(cr, i, j); !v.IsNull() {
+						fields[cols[j].Label] = fieldValue(v)
+					}
+				}
+			}
+			if len(fields) == 0 {
+				continue
+			}
+
+			p, err := models.NewPoint(measurement.Str(), models.NewTags(tags), fields, ts.Time().Time())
+			if err != nil {
+				return fmt.Errorf("cannot encode row as line protocol: %v", err)
+			}
+			buf = append(p.AppendString(buf[:0]), '\n')
+			if _, err := w.Write(buf); err != nil {
+				return &encoderError{err: err}
+			}
+		}
+		return nil
+	})
+}
+
+func fieldValue(v values.Value) interface{} {
+	switch v.Type() {
+	case flux.SemanticType(flux.TBool):
+		return v.Bool()
+	case flux.SemanticType(flux.TInt):
+		return v.Int()
+	case flux.SemanticType(flux.TUInt):
+		return v.UInt()
+	case flux.SemanticType(flux.TFloat):
+		return v.Float()
+	}
+	return v.Str()
+}
+
+// MultiResultDecoder decodes line protocol into results.
+type MultiResultDecoder struct{}
+
+// NewMultiResultDecoder returns a decoder of line protocol.
+func NewMultiResultDecoder() *MultiResultDecoder {
+	return new(MultiResultDecoder)
+}
+
+// Decode reads the points from r into a single result, with a table for
+// each series and field. The error encoded in the response is returned by
+// the Err method of the results.
+func (d *MultiResultDecoder) Decode(r io.ReadCloser) (flux.ResultIterator, error) {
+	defer r.Close()
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var readErr error
+	// The error, if any, is the last line of the response.
+	data = bytes.TrimRight(data, "\n")
+	if i := bytes.LastIndexByte(data, '\n') + 1; bytes.HasPrefix(data[i:], []byte(errorPrefix)) {
+		readErr = errors.New(string(data[i+len(errorPrefix):]))
+		data = data[:i]
+	}
+
+	points, err := models.ParsePoints(data)
+	if err != nil {
+		return nil, err
+	}
+
+	tables, err := decodePoints(points)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []flux.Result
+	if len(tables) > 0 {
+		results = append(results, query.NewResult(DefaultResultName, tables))
+	}
+	return &resultIterator{
+		ResultIterator: flux.NewSliceResultIterator(results),
+		err:            readErr,
+	}, nil
+}
+
+// decodePoints returns the tables of the series and fields of points, in
+// the order they first appear.
+func decodePoints(points []models.Point) ([]flux.Table, error) {
+	var (
+		keys     []string
+		builders = make(map[string]*execute.ColListTableBuilder)
+	)
+	for _, p := range points {
+		tags := p.Tags()
+		iter := p.FieldIterator()
+		for iter.Next() {
+			var (
+				typ flux.ColType
+				v   values.Value
+			)
+			switch iter.Type() {
+			case models.Float:
+				f, err := iter.FloatValue()
+				if err != nil {
+					return nil, err
+				}
+				typ, v = flux.TFloat, values.NewFloat(f)
+			case models.Integer:
+				i, err := iter.IntegerValue()
+				if err != nil {
+					return nil, err
+				}
+				typ, v = flux.TInt, values.NewInt(i)
+			case models.Unsigned:
+				u, err := iter.UnsignedValue()
+				if err != nil {
+					return nil, err
+				}
+				typ, v = flux.TUInt, values.NewUInt(u)
+			case models.Boolean:
+				b, err := iter.BooleanValue()
+				if err != nil {
+					return nil, err
+				}
+				typ, v = flux.TBool, values.NewBool(b)
+			case models.String:
+				typ, v = flux.TString, values.NewString(iter.StringValue())
+			default:
+				continue
+			}
+
+			field := string(iter.FieldKey())
+			key := string(p.Key()) + "\x00" + field + "\x00" + typ.String()
+			b, ok := builders[key]
+			if !ok {
+				var err error
+				if b, err = newBuilder(string(p.Name()), field, tags, typ); err != nil {
+					return nil, err
+				}
+				builders[key] = b
+				keys = append(keys, key)
+			}
+
+			if err := b.AppendTime(0, values.ConvertTime(p.Time())); err != nil {
+				return nil, err
+			}
+			if err := b.AppendValue(1, v); err != nil {
+				return nil, err
+			}
+			for j := range b.Key().Cols() {
+				if err := b.AppendValue(j+2, b.Key().Value(j)); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	tables := make([]flux.Table, len(keys))
+	for i, key := range keys {
+		tbl, err := builders[key].Table()
+		if err != nil {
+			return nil, err
+		}
+		tables[i] = tbl
+	}
+	return tables, nil
+}
+
+// newBuilder returns the builder of the table of a series and field, with
+// the columns _time, _value, _field, _measurement and the tags of the series.
+func newBuilder(measurement, field string, tags models.Tags, typ flux.ColType) (*execute.ColListTableBuilder, error) {
+	keyCols := []flux.ColMeta{
+		{Label: "_field", Type: flux.TString},
+		{Label: "_measurement", Type: flux.TString},
+	}
+	keyVals := []values.Value{
+		values.NewString(field),
+		values.NewString(measurement),
+	}
+
+	keys := make([]string, 0, len(tags))
+	for _, t := range tags {
+		keys = append(keys, string(t.Key))
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		keyCols = append(keyCols, flux.ColMeta{Label: k, Type: flux.TString})
+		keyVals = append(keyVals, values.NewString(tags.GetString(k)))
+	}
+
+	b := execute.NewColListTableBuilder(execute.NewGroupKey(keyCols, keyVals), &memory.Allocator{})
+	cols := append([]flux.ColMeta{
+		{Label: execute.DefaultTimeColLabel, Type: flux.TTime},
+		{Label: execute.DefaultValueColLabel, Type: typ},
+	}, keyCols...)
+	for _, c := range cols {
+		if _, err := b.AddCol(c); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// resultIterator reports the error encoded in a response.
+type resultIterator struct {
+	flux.ResultIterator
+	err error
+}
+
+func (r *resultIterator) Err() error {
+	return r.err
+}
@@ -0,0 +1,208 @@
+package lineprotocol_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/influxdb/query/lineprotocol"
+)
+
+func encode(t *testing.T, results []flux.Result) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	enc := new(lineprotocol.Dialect).Encoder()
+	if _, err := enc.Encode(&buf, flux.NewSliceResultIterator(results)); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestMultiResultEncoder_Encode(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []flux.Result
+		want    string
+	}{
+		{
+			name: "fields",
+			results: []flux.Result{
+				&executetest.Result{
+					Nm: "_result",
+					Tbls: []*executetest.Table{
+						{
+							KeyCols: []string{"_start", "_stop", "_field", "_measurement", "host"},
+							ColMeta: []flux.ColMeta{
+								{Label: "_start", Type: flux.TTime},
+								{Label: "_stop", Type: flux.TTime},
+								{Label: "_time", Type: flux.TTime},
+								{Label: "_value", Type: flux.TFloat},
+								{Label: "_field", Type: flux.TString},
+								{Label: "_measurement", Type: flux.TString},
+								{Label: "host", Type: flux.TString},
+							},
+							Data: [][]interface{}{
+								{execute.Time(0), execute.Time(10), execute.Time(1), 1.5, "usage", "cpu", "a"},
+								{execute.Time(0), execute.Time(10), execute.Time(2), nil, "usage", "cpu", "a"},
+								{execute.Time(0), execute.Time(10), execute.Time(3), 2.0, "usage", "cpu", "a"},
+							},
+						},
+						{
+							KeyCols: []string{"_field", "_measurement"},
+							ColMeta: []flux.ColMeta{
+								{Label: "_time", Type: flux.TTime},
+								{Label: "_value", Type: flux.TString},
+								{Label: "_field", Type: flux.TString},
+								{Label: "_measurement", Type: flux.TString},
+							},
+							Data: [][]interface{}{
+								{execute.Time(1), "a b", "msg", "log"},
+							},
+						},
+					},
+				},
+			},
+			want: "cpu,host=a usage=1.5 1\n" +
+				"cpu,host=a usage=2 3\n" +
+				"log msg=\"a b\" 1\n",
+		},
+		{
+			name: "pivoted",
+			results: []flux.Result{
+				&executetest.Result{
+					Nm: "_result",
+					Tbls: []*executetest.Table{
+						{
+							KeyCols: []string{"_measurement"},
+							ColMeta: []flux.ColMeta{
+								{Label: "_time", Type: flux.TTime},
+								{Label: "_measurement", Type: flux.TString},
+								{Label: "host", Type: flux.TString},
+								{Label: "free", Type: flux.TInt},
+								{Label: "ok", Type: flux.TBool},
+							},
+							Data: [][]interface{}{
+								{execute.Time(1), "mem", "a", int64(10), true},
+								{execute.Time(2), "mem", "a", nil, false},
+							},
+						},
+					},
+				},
+			},
+			want: "mem,host=a free=10i,ok=true 1\n" +
+				"mem,host=a ok=false 2\n",
+		},
+		{
+			name: "no measurement",
+			results: []flux.Result{
+				&executetest.Result{
+					Nm: "_result",
+					Tbls: []*executetest.Table{
+						{
+							ColMeta: []flux.ColMeta{
+								{Label: "_time", Type: flux.TTime},
+								{Label: "_value", Type: flux.TFloat},
+							},
+							Data: [][]interface{}{
+								{execute.Time(1), 1.0},
+							},
+						},
+					},
+				},
+			},
+			want: "# error: cannot encode table as line protocol: no _measurement column of type string\n",
+		},
+		{
+			name: "error",
+			results: []flux.Result{
+				&executetest.Result{
+					Nm:  "_result",
+					Err: errors.New("expected error"),
+				},
+			},
+			want: "# error: expected error\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := encode(t, tt.results); got != tt.want {
+				t.Fatalf("unexpected line protocol: got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMultiResultDecoder_Decode(t *testing.T) {
+	data := "cpu,host=a usage=1.5,n=1i 1\n" +
+		"cpu,host=a usage=2 3\n" +
+		"log msg=\"a b\" 1\n" +
+		"# error: expected error\n"
+
+	ri, err := lineprotocol.NewMultiResultDecoder().Decode(ioutil.NopCloser(bytes.NewBufferString(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []flux.Result
+	for ri.More() {
+		got = append(got, ri.Next())
+	}
+	if err := ri.Err(); err == nil || err.Error() != "expected error" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []flux.Result{
+		&executetest.Result{
+			Nm: "_result",
+			Tbls: []*executetest.Table{
+				{
+					KeyCols: []string{"_field", "_measurement", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TFloat},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), 1.5, "usage", "cpu", "a"},
+						{execute.Time(3), 2.0, "usage", "cpu", "a"},
+					},
+				},
+				{
+					KeyCols: []string{"_field", "_measurement", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TInt},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), int64(1), "n", "cpu", "a"},
+					},
+				},
+				{
+					KeyCols: []string{"_field", "_measurement"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_value", Type: flux.TString},
+						{Label: "_field", Type: flux.TString},
+						{Label: "_measurement", Type: flux.TString},
+					},
+					Data: [][]interface{}{
+						{execute.Time(1), "a b", "msg", "log"},
+					},
+				},
+			},
+		},
+	}
+	if ok, err := executetest.EqualResults(want, got); !ok {
+		t.Fatal(err)
+	}
+}
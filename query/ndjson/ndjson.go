@@ -0,0 +1,478 @@
+// Package ndjson encodes the results of queries as newline-delimited JSON
+// tables, and decodes them.
+//
+// Each line is a JSON object holding rows of a table of a result:
+//
+//	{"result":"_result","table":0,"columns":[{"label":"_value","datatype":"double","group":false}],"key":{},"rows":[[1.5]]}
+//
+// Large tables are encoded over consecutive lines with the same result and
+// table. An error of the query ends the response with a line holding it:
+//
+//	{"error":"..."}
+package ndjson
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/iocounter"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/influxdb/query"
+)
+
+const (
+	// DialectType is the type of the newline-delimited JSON dialect.
+	DialectType = "ndjson"
+
+	// ContentType is the media type of newline-delimited JSON responses.
+	ContentType = "application/x-ndjson"
+)
+
+// AddDialectMappings adds the newline-delimited JSON dialect mappings.
+func AddDialectMappings(mappings flux.DialectMappings) error {
+	return mappings.Add(DialectType, func() flux.Dialect {
+		return new(Dialect)
+	})
+}
+
+// Dialect describes the output format of queries as newline-delimited JSON tables.
+type Dialect struct{}
+
+// SetHeaders sets the content type of the response.
+func (d *Dialect) SetHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("Transfer-Encoding", "chunked")
+}
+
+// Encoder returns the encoder of the results.
+func (d *Dialect) Encoder() flux.MultiResultEncoder {
+	return new(MultiResultEncoder)
+}
+
+// DialectType returns the type of the dialect.
+func (d *Dialect) DialectType() flux.DialectType {
+	return DialectType
+}
+
+// column is the metadata of a column of a table.
+type column struct {
+	Label    string `json:"label"`
+	Datatype string `json:"datatype"`
+	Group    bool   `json:"group"`
+}
+
+// line is a line of a response.
+type line struct {
+	Result  string                 `json:"result"`
+	Table   int                    `json:"table"`
+	Columns []column               `json:"columns"`
+	Key     map[string]interface{} `json:"key"`
+	Rows    [][]interface{}        `json:"rows"`
+}
+
+// errorLine is the last line of a response to a query that failed.
+type errorLine struct {
+	Error string `json:"error"`
+}
+
+// decodedLine is a line of a response, with its values to be decoded by
+// the types of its columns.
+type decodedLine struct {
+	Result  string                     `json:"result"`
+	Table   int                        `json:"table"`
+	Columns []column                   `json:"columns"`
+	Key     map[string]json.RawMessage `json:"key"`
+	Rows    [][]json.RawMessage        `json:"rows"`
+	Error   string                     `json:"error"`
+}
+
+var datatypes = map[flux.ColType]string{
+	flux.TBool:   "boolean",
+	flux.TInt:    "long",
+	flux.TUInt:   "unsignedLong",
+	flux.TFloat:  "double",
+	flux.TString: "string",
+	flux.TTime:   "dateTime:RFC3339Nano",
+}
+
+func colType(datatype string) (flux.ColType, error) {
+	for t, d := range datatypes {
+		if d == datatype {
+			return t, nil
+		}
+	}
+	return flux.TInvalid, fmt.Errorf("unknown datatype %q", datatype)
+}
+
+// MultiResultEncoder encodes results as newline-delimited JSON tables.
+type MultiResultEncoder struct{}
+
+// Encode writes the results to w. The error of the results, if any, is
+// encoded as the last line.
+func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (int64, error) {
+	wc := &iocounter.Writer{Writer: w}
+	enc := json.NewEncoder(wc)
+
+	for results.More() {
+		res := results.Next()
+		table := 0
+		err := res.Tables().Do(func(tbl flux.Table) error {
+			if err := encodeTable(enc, res.Name(), table, tbl); err != nil {
+				return err
+			}
+			table++
+			return nil
+		})
+		if err != nil {
+			if flux.IsEncoderError(err) {
+				return wc.Count(), err
+			}
+			return wc.Count(), enc.Encode(errorLine{Error: err.Error()})
+		}
+
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+
+	if err := results.Err(); err != nil {
+		return wc.Count(), enc.Encode(errorLine{Error: err.Error()})
+	}
+	return wc.Count(), nil
+}
+
+// encoderError is an error writing the response, rather than of the query.
+type encoderError struct {
+	err error
+}
+
+func (e *encoderError) Error() string {
+	return e.err.Error()
+}
+
+func (e *encoderError) IsEncoderError() bool {
+	return true
+}
+
+func encodeTable(enc *json.Encoder, result string, table int, tbl flux.Table) error {
+	cols := make([]column, len(tbl.Cols()))
+	for j, c := range tbl.Cols() {
+		cols[j] = column{
+			Label:    c.Label,
+			Datatype: datatypes[c.Type],
+			Group:    execute.ContainsStr(keyLabels(tbl.Key()), c.Label),
+		}
+	}
+
+	key := make(map[string]interface{}, len(tbl.Key().Cols()))
+	for j, c := range tbl.Key().Cols() {
+		key[c.Label] = encodeValue(tbl.Key().Value(j))
+	}
+
+	l := line{
+		Result:  result,
+		Table:   table,
+		Columns: cols,
+		Key:     key,
+	}
+
+	empty := true
+	err := tbl.Do(func(cr flux.ColReader) error {
+		l.Rows = make([][]interface{}, cr.Len())
+		for i := range l.Rows {
+			row := make([]interface{}, len(cols))
+			for j := range cols {
+				row[j] = encodeValue(execute.ValueForRow(cr, i, j))
+			}
+			l.Rows[i] = row
+		}
+		empty = false
+		if err := enc.Encode(l); err != nil {
+			return &encoderError{err: err}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Empty tables are encoded without rows, for their columns and key.
+	if empty {
+		l.Rows = [][]interface{}{}
+		if err := enc.Encode(l); err != nil {
+			return &encoderError{err: err}
+		}
+	}
+	return nil
+}
+
+func keyLabels(key flux.GroupKey) []string {
+	labels := make([]string, len(key.Cols()))
+	for j, c := range key.Cols() {
+		labels[j] = c.Label
+	}
+	return labels
+}
+
+// encodeValue returns the JSON value of v. Times are encoded as RFC3339
+// strings, and floats that are not finite as strings.
+func encodeValue(v values.Value) interface{} {
+	if v.IsNull() {
+		return nil
+	}
+	switch v.Type() {
+	case flux.SemanticType(flux.TBool):
+		return v.Bool()
+	case flux.SemanticType(flux.TInt):
+		return v.Int()
+	case flux.SemanticType(flux.TUInt):
+		return v.UInt()
+	case flux.SemanticType(flux.TFloat):
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return strconv.FormatFloat(f, 'f', -1, 64)
+		}
+		return f
+	case flux.SemanticType(flux.TString):
+		return v.Str()
+	case flux.SemanticType(flux.TTime):
+		return v.Time().Time().Format(time.RFC3339Nano)
+	}
+	return nil
+}
+
+// decodeValue decodes the JSON value raw of a column of type t.
+func decodeValue(raw json.RawMessage, t flux.ColType) (values.Value, error) {
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return values.NewNull(flux.SemanticType(t)), nil
+	}
+
+	switch t {
+	case flux.TBool:
+		var b bool
+		if err := json.Unmarshal(raw, &b); err != nil {
+			return nil, err
+		}
+		return values.NewBool(b), nil
+	case flux.TInt:
+		i, err := strconv.ParseInt(string(raw), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return values.NewInt(i), nil
+	case flux.TUInt:
+		u, err := strconv.ParseUint(string(raw), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return values.NewUInt(u), nil
+	case flux.TFloat:
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return nil, err
+			}
+			return values.NewFloat(f), nil
+		}
+		var f float64
+		if err := json.Unmarshal(raw, &f); err != nil {
+			return nil, err
+		}
+		return values.NewFloat(f), nil
+	case flux.TString:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		return values.NewString(s), nil
+	case flux.TTime:
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		ts, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, err
+		}
+		return values.NewTime(values.ConvertTime(ts)), nil
+	}
+	return nil, fmt.Errorf("unsupported column type %v", t)
+}
+
+// MultiResultDecoder decodes newline-delimited JSON tables into results.
+type MultiResultDecoder struct{}
+
+// NewMultiResultDecoder returns a decoder of newline-delimited JSON tables.
+func NewMultiResultDecoder() *MultiResultDecoder {
+	return new(MultiResultDecoder)
+}
+
+// Decode reads the results from r. The results are read in full before
+// they are returned; the error encoded in the response is returned by the
+// Err method of the results.
+func (d *MultiResultDecoder) Decode(r io.ReadCloser) (flux.ResultIterator, error) {
+	defer r.Close()
+
+	var (
+		dec     = decoder{}
+		br      = bufio.NewReader(r)
+		readErr error
+	)
+	for {
+		b, err := br.ReadBytes('\n')
+		if len(bytes.TrimSpace(b)) > 0 {
+			var l decodedLine
+			if err := json.Unmarshal(b, &l); err != nil {
+				return nil, err
+			}
+			if l.Error != "" {
+				readErr = errors.New(l.Error)
+				break
+			}
+			if err := dec.add(&l); err != nil {
+				return nil, err
+			}
+		}
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	results, err := dec.results()
+	if err != nil {
+		return nil, err
+	}
+	return &resultIterator{
+		ResultIterator: flux.NewSliceResultIterator(results),
+		err:            readErr,
+	}, nil
+}
+
+// decoder builds the tables of the lines of a response.
+type decoder struct {
+	names   []string
+	tables  map[string][]flux.Table
+	builder *execute.ColListTableBuilder
+	types   []flux.ColType
+	result  string
+	table   int
+}
+
+func (d *decoder) add(l *decodedLine) error {
+	if d.builder == nil || l.Result != d.result || l.Table != d.table {
+		if err := d.flush(); err != nil {
+			return err
+		}
+		if err := d.start(l); err != nil {
+			return err
+		}
+	}
+
+	for _, row := range l.Rows {
+		if len(row) != len(d.types) {
+			return fmt.Errorf("row has %d values, expected %d", len(row), len(d.types))
+		}
+		for j, raw := range row {
+			v, err := decodeValue(raw, d.types[j])
+			if err != nil {
+				return err
+			}
+			if err := d.builder.AppendValue(j, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// start starts the table of the line l.
+func (d *decoder) start(l *decodedLine) error {
+	var (
+		cols    = make([]flux.ColMeta, len(l.Columns))
+		keyCols []flux.ColMeta
+		keyVals []values.Value
+	)
+	d.types = make([]flux.ColType, len(l.Columns))
+	for j, c := range l.Columns {
+		t, err := colType(c.Datatype)
+		if err != nil {
+			return err
+		}
+		cols[j] = flux.ColMeta{Label: c.Label, Type: t}
+		d.types[j] = t
+
+		if c.Group {
+			v, err := decodeValue(l.Key[c.Label], t)
+			if err != nil {
+				return err
+			}
+			keyCols = append(keyCols, cols[j])
+			keyVals = append(keyVals, v)
+		}
+	}
+
+	d.builder = execute.NewColListTableBuilder(execute.NewGroupKey(keyCols, keyVals), &memory.Allocator{})
+	for _, c := range cols {
+		if _, err := d.builder.AddCol(c); err != nil {
+			return err
+		}
+	}
+	d.result, d.table = l.Result, l.Table
+	return nil
+}
+
+// flush adds the table being built to its result.
+func (d *decoder) flush() error {
+	if d.builder == nil {
+		return nil
+	}
+	tbl, err := d.builder.Table()
+	if err != nil {
+		return err
+	}
+
+	if d.tables == nil {
+		d.tables = make(map[string][]flux.Table)
+	}
+	if _, ok := d.tables[d.result]; !ok {
+		d.names = append(d.names, d.result)
+	}
+	d.tables[d.result] = append(d.tables[d.result], tbl)
+	d.builder = nil
+	return nil
+}
+
+func (d *decoder) results() ([]flux.Result, error) {
+	if err := d.flush(); err != nil {
+		return nil, err
+	}
+	results := make([]flux.Result, len(d.names))
+	for i, name := range d.names {
+		results[i] = query.NewResult(name, d.tables[name])
+	}
+	return results, nil
+}
+
+// resultIterator reports the error encoded in a response.
+type resultIterator struct {
+	flux.ResultIterator
+	err error
+}
+
+func (r *resultIterator) Err() error {
+	return r.err
+}
@@ -0,0 +1,36 @@
+package query
+
+import (
+	"github.com/influxdata/flux"
+)
+
+// NewResult returns a result named name holding the tables ts, such as the
+// tables decoded from the response of a query.
+func NewResult(name string, ts []flux.Table) flux.Result {
+	return &tableResult{
+		name:   name,
+		tables: ts,
+	}
+}
+
+type tableResult struct {
+	name   string
+	tables []flux.Table
+}
+
+func (r *tableResult) Name() string {
+	return r.name
+}
+
+func (r *tableResult) Tables() flux.TableIterator {
+	return r
+}
+
+func (r *tableResult) Do(f func(flux.Table) error) error {
+	for _, t := range r.tables {
+		if err := f(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
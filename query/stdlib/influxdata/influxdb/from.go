@@ -2,6 +2,8 @@ package influxdb
 
 import (
 	"fmt"
+	"math"
+	"strings"
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/ast"
@@ -43,6 +45,18 @@ func init() {
 		FromDistinctRule{},
 		MergeFromGroupRule{},
 		FromKeysRule{},
+		PushDownAggregateRule{Kind: universe.MinKind},
+		PushDownAggregateRule{Kind: universe.MaxKind},
+		PushDownAggregateRule{Kind: universe.FirstKind},
+		PushDownAggregateRule{Kind: universe.LastKind},
+		PushDownAggregateRule{Kind: universe.MeanKind},
+		PushDownWindowAggregateRule{Kind: universe.CountKind},
+		PushDownWindowAggregateRule{Kind: universe.SumKind},
+		PushDownWindowAggregateRule{Kind: universe.MinKind},
+		PushDownWindowAggregateRule{Kind: universe.MaxKind},
+		PushDownWindowAggregateRule{Kind: universe.FirstKind},
+		PushDownWindowAggregateRule{Kind: universe.LastKind},
+		PushDownWindowAggregateRule{Kind: universe.MeanKind},
 	)
 	execute.RegisterSource(PhysicalFromKind, createFromSource)
 }
@@ -172,6 +186,15 @@ type PhysicalFromProcedureSpec struct {
 
 	AggregateSet    bool
 	AggregateMethod string
+
+	// AggregateWindowSet indicates that the aggregate is applied to each
+	// window of a series rather than to the whole series.
+	AggregateWindowSet bool
+	AggregateWindow    plan.WindowSpec
+
+	// AggregateWindowCreateEmpty indicates that a row is produced for the
+	// windows without points, as an aggregate of an empty window would.
+	AggregateWindowCreateEmpty bool
 }
 
 func (PhysicalFromProcedureSpec) Kind() plan.ProcedureKind {
@@ -209,6 +232,10 @@ func (s *PhysicalFromProcedureSpec) Copy() plan.ProcedureSpec {
 	ns.AggregateSet = s.AggregateSet
 	ns.AggregateMethod = s.AggregateMethod
 
+	ns.AggregateWindowSet = s.AggregateWindowSet
+	ns.AggregateWindow = s.AggregateWindow
+	ns.AggregateWindowCreateEmpty = s.AggregateWindowCreateEmpty
+
 	return ns
 }
 
@@ -280,6 +307,9 @@ func (rule MergeFromRangeRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool,
 	from := node.Predecessors()[0]
 	fromSpec := from.ProcedureSpec().(*PhysicalFromProcedureSpec)
 	rangeSpec := node.ProcedureSpec().(*universe.RangeProcedureSpec)
+	if fromSpec.AggregateSet {
+		return node, false, nil
+	}
 	fromRange := fromSpec.Copy().(*PhysicalFromProcedureSpec)
 
 	// Set new bounds to `range` bounds initially
@@ -591,6 +621,7 @@ func (MergeFromGroupRule) Rewrite(groupNode plan.PlanNode) (plan.PlanNode, bool,
 	fromSpec := fromNode.ProcedureSpec().(*PhysicalFromProcedureSpec)
 
 	if fromSpec.GroupingSet ||
+		fromSpec.AggregateSet ||
 		fromSpec.LimitSet ||
 		groupSpec.GroupMode != flux.GroupModeBy {
 		return groupNode, false, nil
@@ -645,6 +676,195 @@ func (FromKeysRule) Rewrite(keysNode plan.PlanNode) (plan.PlanNode, bool, error)
 	return keysNode, true, nil
 }
 
+// PushDownAggregateRule pushes an aggregate or selector of the _value column
+// into a bounded `from`, so that each series is reduced in the storage layer.
+type PushDownAggregateRule struct {
+	Kind plan.ProcedureKind
+}
+
+func (rule PushDownAggregateRule) Name() string {
+	return "PushDownAggregateRule_" + string(rule.Kind)
+}
+
+func (rule PushDownAggregateRule) Pattern() plan.Pattern {
+	return plan.Pat(rule.Kind, plan.Pat(PhysicalFromKind))
+}
+
+func (rule PushDownAggregateRule) Rewrite(aggNode plan.PlanNode) (plan.PlanNode, bool, error) {
+	fromNode := aggNode.Predecessors()[0]
+	fromSpec := fromNode.ProcedureSpec().(*PhysicalFromProcedureSpec)
+
+	// Storage aggregates each series separately, which matches the default
+	// grouping of `from` only.
+	if !fromSpec.BoundsSet ||
+		fromSpec.AggregateSet ||
+		fromSpec.GroupingSet ||
+		fromSpec.LimitSet ||
+		fromSpec.DescendingSet ||
+		fromSpec.WindowSet {
+		return aggNode, false, nil
+	}
+
+	if !isValueColumnAggregate(aggNode.ProcedureSpec()) {
+		return aggNode, false, nil
+	}
+
+	newFromSpec := fromSpec.Copy().(*PhysicalFromProcedureSpec)
+	newFromSpec.AggregateSet = true
+	newFromSpec.AggregateMethod = string(rule.Kind)
+	merged, err := plan.MergeToPhysicalPlanNode(aggNode, fromNode, newFromSpec)
+	if err != nil {
+		return nil, false, err
+	}
+	return merged, true, nil
+}
+
+// isValueColumnAggregate reports whether spec only operates on the _value column.
+func isValueColumnAggregate(spec plan.ProcedureSpec) bool {
+	isValue := func(col string) bool {
+		return col == "" || col == execute.DefaultValueColLabel
+	}
+
+	switch spec := spec.(type) {
+	case *universe.MinProcedureSpec:
+		return isValue(spec.Column)
+	case *universe.MaxProcedureSpec:
+		return isValue(spec.Column)
+	case *universe.FirstProcedureSpec:
+		return isValue(spec.Column)
+	case *universe.LastProcedureSpec:
+		return isValue(spec.Column)
+	case *universe.MeanProcedureSpec:
+		return len(spec.Columns) == 1 && isValue(spec.Columns[0])
+	case *universe.CountProcedureSpec:
+		return len(spec.Columns) == 1 && isValue(spec.Columns[0])
+	case *universe.SumProcedureSpec:
+		return len(spec.Columns) == 1 && isValue(spec.Columns[0])
+	}
+	return false
+}
+
+// isSelectorKind reports whether the procedures of kind select a point of
+// their input, so they produce no rows for an empty window.
+func isSelectorKind(kind plan.ProcedureKind) bool {
+	switch kind {
+	case universe.MinKind, universe.MaxKind, universe.FirstKind, universe.LastKind:
+		return true
+	}
+	return false
+}
+
+// PushDownWindowAggregateRule pushes the chain produced by `aggregateWindow`
+// into a bounded `from`:
+//
+//	from |> window(every) |> agg() |> duplicate(column: "_stop", as: "_time") |> window(every: inf)
+//
+// Storage then produces a point per window of each series, whose time is
+// the end of its window.
+type PushDownWindowAggregateRule struct {
+	Kind plan.ProcedureKind
+}
+
+func (rule PushDownWindowAggregateRule) Name() string {
+	return "PushDownWindowAggregateRule_" + string(rule.Kind)
+}
+
+func (rule PushDownWindowAggregateRule) Pattern() plan.Pattern {
+	return plan.Pat(universe.WindowKind,
+		plan.Pat(universe.SchemaMutationKind,
+			plan.Pat(rule.Kind,
+				plan.Pat(universe.WindowKind,
+					plan.Pat(PhysicalFromKind)))))
+}
+
+func (rule PushDownWindowAggregateRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool, error) {
+	dupNode := node.Predecessors()[0]
+	aggNode := dupNode.Predecessors()[0]
+	windowNode := aggNode.Predecessors()[0]
+	fromNode := windowNode.Predecessors()[0]
+
+	// The intermediate results must not be used elsewhere in the plan.
+	if !hasSingleSuccessor(dupNode, aggNode, windowNode, fromNode) {
+		return node, false, nil
+	}
+
+	fromSpec := fromNode.ProcedureSpec().(*PhysicalFromProcedureSpec)
+	if !fromSpec.BoundsSet ||
+		fromSpec.AggregateSet ||
+		fromSpec.GroupingSet ||
+		fromSpec.LimitSet ||
+		fromSpec.DescendingSet ||
+		fromSpec.WindowSet {
+		return node, false, nil
+	}
+
+	windowSpec := windowNode.ProcedureSpec().(*universe.WindowProcedureSpec)
+	if !isDefaultWindow(windowSpec) ||
+		windowSpec.Window.Every <= 0 ||
+		windowSpec.Window.Every == infinity ||
+		windowSpec.Window.Period != windowSpec.Window.Every {
+		return node, false, nil
+	}
+
+	if !isValueColumnAggregate(aggNode.ProcedureSpec()) {
+		return node, false, nil
+	}
+
+	dupSpec := dupNode.ProcedureSpec().(*universe.SchemaMutationProcedureSpec)
+	if len(dupSpec.Mutations) != 1 {
+		return node, false, nil
+	}
+	if dup, ok := dupSpec.Mutations[0].(*universe.DuplicateOpSpec); !ok ||
+		dup.Column != execute.DefaultStopColLabel ||
+		dup.As != execute.DefaultTimeColLabel {
+		return node, false, nil
+	}
+
+	spec := node.ProcedureSpec().(*universe.WindowProcedureSpec)
+	if !isDefaultWindow(spec) || spec.Window.Every != infinity {
+		return node, false, nil
+	}
+
+	newFromSpec := fromSpec.Copy().(*PhysicalFromProcedureSpec)
+	newFromSpec.AggregateSet = true
+	newFromSpec.AggregateMethod = string(rule.Kind)
+	newFromSpec.AggregateWindowSet = true
+	newFromSpec.AggregateWindow = windowSpec.Window
+	// Storage does not produce points for empty windows, for which selectors
+	// produce no rows either. The rows of the other aggregates are added
+	// after the read.
+	newFromSpec.AggregateWindowCreateEmpty = windowSpec.CreateEmpty && !isSelectorKind(rule.Kind)
+
+	return plan.CreatePhysicalNode(mergedNodeID(fromNode, node), newFromSpec), true, nil
+}
+
+// infinity is the duration of `window(every: inf)`.
+const infinity = flux.Duration(math.MaxInt64)
+
+// isDefaultWindow reports whether spec uses the default columns.
+func isDefaultWindow(spec *universe.WindowProcedureSpec) bool {
+	return spec.TimeColumn == execute.DefaultTimeColLabel &&
+		spec.StartColumn == execute.DefaultStartColLabel &&
+		spec.StopColumn == execute.DefaultStopColLabel
+}
+
+// hasSingleSuccessor reports whether the results of each of nodes are only
+// used by one other node.
+func hasSingleSuccessor(nodes ...plan.PlanNode) bool {
+	for _, n := range nodes {
+		if len(n.Successors()) != 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// mergedNodeID returns the ID of the node that replaces the chain of nodes
+// from fromNode to node.
+func mergedNodeID(fromNode, node plan.PlanNode) plan.NodeID {
+	return plan.NodeID("merged_" + strings.TrimPrefix(string(fromNode.ID()), "merged_") + "_" + string(node.ID()))
+}
+
 func createFromSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
 	spec := prSpec.(*PhysicalFromProcedureSpec)
 	var w execute.Window
@@ -678,37 +898,38 @@ func createFromSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execu
 	}
 	orgID := req.OrganizationID
 
-	var bucketID platform.ID
-	// Determine bucketID
-	switch {
-	case spec.Bucket != "":
-		b, ok := deps.BucketLookup.Lookup(orgID, spec.Bucket)
-		if !ok {
-			return nil, fmt.Errorf("could not find bucket %q", spec.Bucket)
-		}
-		bucketID = b
-	case len(spec.BucketID) != 0:
-		err := bucketID.DecodeFromString(spec.BucketID)
-		if err != nil {
-			return nil, err
-		}
+	bucketID, err := lookupBucketID(deps, orgID, spec.Bucket, spec.BucketID)
+	if err != nil {
+		return nil, err
+	}
+
+	var windowEvery, windowOffset int64
+	var windowCreateEmpty bool
+	if spec.AggregateWindowSet {
+		windowEvery = int64(spec.AggregateWindow.Every)
+		windowOffset = int64(spec.AggregateWindow.Offset)
+		windowCreateEmpty = spec.AggregateWindowCreateEmpty
 	}
 
 	return NewSource(
 		dsid,
 		deps.Reader,
 		ReadSpec{
-			OrganizationID:  orgID,
-			BucketID:        bucketID,
-			Predicate:       spec.Filter,
-			PointsLimit:     spec.PointsLimit,
-			SeriesLimit:     spec.SeriesLimit,
-			SeriesOffset:    spec.SeriesOffset,
-			Descending:      spec.Descending,
-			OrderByTime:     spec.OrderByTime,
-			GroupMode:       ToGroupMode(spec.GroupMode),
-			GroupKeys:       spec.GroupKeys,
-			AggregateMethod: spec.AggregateMethod,
+			OrganizationID:    orgID,
+			BucketID:          bucketID,
+			Predicate:         spec.Filter,
+			Scope:             readScope(req, orgID, bucketID),
+			PointsLimit:       spec.PointsLimit,
+			SeriesLimit:       spec.SeriesLimit,
+			SeriesOffset:      spec.SeriesOffset,
+			Descending:        spec.Descending,
+			OrderByTime:       spec.OrderByTime,
+			GroupMode:         ToGroupMode(spec.GroupMode),
+			GroupKeys:         spec.GroupKeys,
+			AggregateMethod:   spec.AggregateMethod,
+			WindowEvery:       windowEvery,
+			WindowOffset:      windowOffset,
+			WindowCreateEmpty: windowCreateEmpty,
 		},
 		*bounds,
 		w,
@@ -716,6 +937,41 @@ func createFromSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execu
 	), nil
 }
 
+// lookupBucketID returns the ID of the bucket identified by either its
+// name or its ID.
+func lookupBucketID(deps Dependencies, orgID platform.ID, bucket, bucketID string) (platform.ID, error) {
+	var id platform.ID
+	switch {
+	case bucket != "":
+		b, ok := deps.BucketLookup.Lookup(orgID, bucket)
+		if !ok {
+			return 0, fmt.Errorf("could not find bucket %q", bucket)
+		}
+		id = b
+	case len(bucketID) != 0:
+		if err := id.DecodeFromString(bucketID); err != nil {
+			return 0, err
+		}
+	}
+	return id, nil
+}
+
+// readScope returns the predicate restricting the series of the bucket that
+// the authorization of req may read.
+func readScope(req *query.Request, orgID, bucketID platform.ID) string {
+	if req.Authorization == nil {
+		return ""
+	}
+	return req.Authorization.Predicate(platform.Permission{
+		Action: platform.ReadAction,
+		Resource: platform.Resource{
+			Type:  platform.BucketsResourceType,
+			OrgID: &orgID,
+			ID:    &bucketID,
+		},
+	})
+}
+
 func InjectFromDependencies(depsMap execute.Dependencies, deps Dependencies) error {
 	if err := deps.Validate(); err != nil {
 		return err
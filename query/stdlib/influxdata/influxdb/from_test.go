@@ -2,6 +2,7 @@ package influxdb_test
 
 import (
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
@@ -846,6 +847,235 @@ func TestFromKeysRule(t *testing.T) {
 	}
 }
 
+func TestPushDownAggregateRule(t *testing.T) {
+	bounded := &influxdb.PhysicalFromProcedureSpec{
+		BoundsSet: true,
+		Bounds: flux.Bounds{
+			Start: fluxTime(5),
+			Stop:  fluxTime(10),
+		},
+	}
+	aggregated := func(method string) *influxdb.PhysicalFromProcedureSpec {
+		spec := bounded.Copy().(*influxdb.PhysicalFromProcedureSpec)
+		spec.AggregateSet = true
+		spec.AggregateMethod = method
+		return spec
+	}
+
+	tests := []plantest.RuleTestCase{
+		{
+			Name:  "from max",
+			Rules: []plan.Rule{influxdb.PushDownAggregateRule{Kind: universe.MaxKind}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plan.CreatePhysicalNode("from", bounded),
+					plan.CreatePhysicalNode("max", &universe.MaxProcedureSpec{
+						SelectorConfig: execute.SelectorConfig{Column: "_value"},
+					}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plan.CreatePhysicalNode("merged_from_max", aggregated("max")),
+				},
+			},
+		},
+		{
+			Name:  "from mean",
+			Rules: []plan.Rule{influxdb.PushDownAggregateRule{Kind: universe.MeanKind}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plan.CreatePhysicalNode("from", bounded),
+					plan.CreatePhysicalNode("mean", &universe.MeanProcedureSpec{
+						AggregateConfig: execute.AggregateConfig{Columns: []string{"_value"}},
+					}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plan.CreatePhysicalNode("merged_from_mean", aggregated("mean")),
+				},
+			},
+		},
+		{
+			Name:  "from first on another column",
+			Rules: []plan.Rule{influxdb.PushDownAggregateRule{Kind: universe.FirstKind}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plan.CreatePhysicalNode("from", bounded),
+					plan.CreatePhysicalNode("first", &universe.FirstProcedureSpec{
+						SelectorConfig: execute.SelectorConfig{Column: "host"},
+					}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+				},
+			},
+			NoChange: true,
+		},
+		{
+			Name:  "from group last",
+			Rules: []plan.Rule{influxdb.PushDownAggregateRule{Kind: universe.LastKind}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plan.CreatePhysicalNode("from", &influxdb.PhysicalFromProcedureSpec{
+						BoundsSet:   true,
+						GroupingSet: true,
+						GroupMode:   flux.GroupModeBy,
+						GroupKeys:   []string{"host"},
+					}),
+					plan.CreatePhysicalNode("last", &universe.LastProcedureSpec{
+						SelectorConfig: execute.SelectorConfig{Column: "_value"},
+					}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+				},
+			},
+			NoChange: true,
+		},
+		{
+			Name:  "from min min",
+			Rules: []plan.Rule{influxdb.PushDownAggregateRule{Kind: universe.MinKind}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plan.CreatePhysicalNode("from", aggregated("min")),
+					plan.CreatePhysicalNode("min", &universe.MinProcedureSpec{
+						SelectorConfig: execute.SelectorConfig{Column: "_value"},
+					}),
+				},
+				Edges: [][2]int{
+					{0, 1},
+				},
+			},
+			NoChange: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			plantest.PhysicalRuleTestHelper(t, &tc)
+		})
+	}
+}
+
+func TestPushDownWindowAggregateRule(t *testing.T) {
+	bounded := &influxdb.PhysicalFromProcedureSpec{
+		BoundsSet: true,
+		Bounds: flux.Bounds{
+			Start: fluxTime(5),
+			Stop:  fluxTime(10),
+		},
+	}
+	window := func(every flux.Duration, createEmpty bool) *universe.WindowProcedureSpec {
+		return &universe.WindowProcedureSpec{
+			Window: plan.WindowSpec{
+				Every:  every,
+				Period: every,
+			},
+			TimeColumn:  execute.DefaultTimeColLabel,
+			StartColumn: execute.DefaultStartColLabel,
+			StopColumn:  execute.DefaultStopColLabel,
+			CreateEmpty: createEmpty,
+		}
+	}
+	duplicate := func(column string) *universe.SchemaMutationProcedureSpec {
+		return &universe.SchemaMutationProcedureSpec{
+			Mutations: []universe.SchemaMutation{
+				&universe.DuplicateOpSpec{Column: column, As: execute.DefaultTimeColLabel},
+			},
+		}
+	}
+	inf := flux.Duration(math.MaxInt64)
+	mean := &universe.MeanProcedureSpec{
+		AggregateConfig: execute.AggregateConfig{Columns: []string{"_value"}},
+	}
+	max := &universe.MaxProcedureSpec{
+		SelectorConfig: execute.SelectorConfig{Column: "_value"},
+	}
+	aggregateWindow := func(agg plan.PhysicalProcedureSpec, every flux.Duration, createEmpty bool, timeSrc string) *plantest.PlanSpec {
+		return &plantest.PlanSpec{
+			Nodes: []plan.PlanNode{
+				plan.CreatePhysicalNode("from", bounded),
+				plan.CreatePhysicalNode("window", window(every, createEmpty)),
+				plan.CreatePhysicalNode("agg", agg),
+				plan.CreatePhysicalNode("duplicate", duplicate(timeSrc)),
+				plan.CreatePhysicalNode("window2", window(inf, false)),
+			},
+			Edges: [][2]int{
+				{0, 1},
+				{1, 2},
+				{2, 3},
+				{3, 4},
+			},
+		}
+	}
+	windowAggregated := func(method string, every flux.Duration, createEmpty bool) *influxdb.PhysicalFromProcedureSpec {
+		spec := bounded.Copy().(*influxdb.PhysicalFromProcedureSpec)
+		spec.AggregateSet = true
+		spec.AggregateMethod = method
+		spec.AggregateWindowSet = true
+		spec.AggregateWindow = plan.WindowSpec{Every: every, Period: every}
+		spec.AggregateWindowCreateEmpty = createEmpty
+		return spec
+	}
+
+	tests := []plantest.RuleTestCase{
+		{
+			Name:   "aggregate window mean",
+			Rules:  []plan.Rule{influxdb.PushDownWindowAggregateRule{Kind: universe.MeanKind}},
+			Before: aggregateWindow(mean, flux.Duration(time.Minute), false, "_stop"),
+			After: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plan.CreatePhysicalNode("merged_from_window2", windowAggregated("mean", flux.Duration(time.Minute), false)),
+				},
+			},
+		},
+		{
+			Name:   "aggregate window max with empty windows",
+			Rules:  []plan.Rule{influxdb.PushDownWindowAggregateRule{Kind: universe.MaxKind}},
+			Before: aggregateWindow(max, flux.Duration(time.Minute), true, "_stop"),
+			After: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plan.CreatePhysicalNode("merged_from_window2", windowAggregated("max", flux.Duration(time.Minute), false)),
+				},
+			},
+		},
+		{
+			Name:   "aggregate window mean with empty windows",
+			Rules:  []plan.Rule{influxdb.PushDownWindowAggregateRule{Kind: universe.MeanKind}},
+			Before: aggregateWindow(mean, flux.Duration(time.Minute), true, "_stop"),
+			After: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plan.CreatePhysicalNode("merged_from_window2", windowAggregated("mean", flux.Duration(time.Minute), true)),
+				},
+			},
+		},
+		{
+			Name:   "aggregate window with start as time",
+			Rules:  []plan.Rule{influxdb.PushDownWindowAggregateRule{Kind: universe.MeanKind}},
+			Before: aggregateWindow(mean, flux.Duration(time.Minute), false, "_start"),
+			After:  aggregateWindow(mean, flux.Duration(time.Minute), false, "_start"),
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			plantest.PhysicalRuleTestHelper(t, &tc)
+		})
+	}
+}
+
 func TestFromRangeValidation(t *testing.T) {
 	testSpec := plantest.PlanSpec{
 		//       3
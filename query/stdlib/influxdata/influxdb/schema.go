@@ -0,0 +1,334 @@
+package influxdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/flux/values"
+	"github.com/influxdata/influxdb/query"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+)
+
+const (
+	ReadTagKeysKind   = "ReadTagKeysKind"
+	ReadTagValuesKind = "ReadTagValuesKind"
+)
+
+func init() {
+	plan.RegisterPhysicalRules(
+		PushDownReadTagKeysRule{},
+		PushDownReadTagValuesRule{},
+	)
+	execute.RegisterSource(ReadTagKeysKind, createReadTagKeysSource)
+	execute.RegisterSource(ReadTagValuesKind, createReadTagValuesSource)
+}
+
+// ReadTagKeysProcedureSpec reads the tag keys of the series of a bucket
+// from the storage index, rather than reading their points.
+type ReadTagKeysProcedureSpec struct {
+	plan.DefaultCost
+
+	Bucket   string
+	BucketID string
+
+	Bounds flux.Bounds
+	Filter *semantic.FunctionExpression
+}
+
+func (ReadTagKeysProcedureSpec) Kind() plan.ProcedureKind {
+	return ReadTagKeysKind
+}
+
+func (s *ReadTagKeysProcedureSpec) Copy() plan.ProcedureSpec {
+	ns := *s
+	if s.Filter != nil {
+		ns.Filter = s.Filter.Copy().(*semantic.FunctionExpression)
+	}
+	return &ns
+}
+
+// TimeBounds implements plan.BoundsAwareProcedureSpec.
+func (s *ReadTagKeysProcedureSpec) TimeBounds(predecessorBounds *plan.Bounds) *plan.Bounds {
+	return &plan.Bounds{
+		Start: values.ConvertTime(s.Bounds.Start.Time(s.Bounds.Now)),
+		Stop:  values.ConvertTime(s.Bounds.Stop.Time(s.Bounds.Now)),
+	}
+}
+
+// ReadTagValuesProcedureSpec reads the values of a tag of the series of a
+// bucket from the storage index, rather than reading their points.
+type ReadTagValuesProcedureSpec struct {
+	ReadTagKeysProcedureSpec
+	TagKey string
+}
+
+func (ReadTagValuesProcedureSpec) Kind() plan.ProcedureKind {
+	return ReadTagValuesKind
+}
+
+func (s *ReadTagValuesProcedureSpec) Copy() plan.ProcedureSpec {
+	return &ReadTagValuesProcedureSpec{
+		ReadTagKeysProcedureSpec: *s.ReadTagKeysProcedureSpec.Copy().(*ReadTagKeysProcedureSpec),
+		TagKey:                   s.TagKey,
+	}
+}
+
+// PushDownReadTagKeysRule replaces the chain used by `v1.tagKeys` with a
+// read of the tag keys from the storage index:
+//
+//	from |> range |> filter |> keys() |> keep(columns: ["_value"])
+type PushDownReadTagKeysRule struct{}
+
+func (PushDownReadTagKeysRule) Name() string {
+	return "PushDownReadTagKeysRule"
+}
+
+func (PushDownReadTagKeysRule) Pattern() plan.Pattern {
+	return plan.Pat(universe.SchemaMutationKind,
+		plan.Pat(universe.KeysKind,
+			plan.Pat(PhysicalFromKind)))
+}
+
+func (PushDownReadTagKeysRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool, error) {
+	keysNode := node.Predecessors()[0]
+	fromNode := keysNode.Predecessors()[0]
+
+	if !hasSingleSuccessor(keysNode, fromNode) || !isKeepValueColumn(node.ProcedureSpec()) {
+		return node, false, nil
+	}
+
+	keysSpec := keysNode.ProcedureSpec().(*universe.KeysProcedureSpec)
+	if keysSpec.Column != execute.DefaultValueColLabel {
+		return node, false, nil
+	}
+
+	fromSpec := fromNode.ProcedureSpec().(*PhysicalFromProcedureSpec)
+	if !canReadSchema(fromSpec) {
+		return node, false, nil
+	}
+
+	return plan.CreatePhysicalNode(mergedNodeID(fromNode, node), newReadTagKeysSpec(fromSpec)), true, nil
+}
+
+// PushDownReadTagValuesRule replaces the chain used by `v1.tagValues` with
+// a read of the tag values from the storage index:
+//
+//	from |> range |> filter |> group(columns: [tag]) |> distinct(column: tag) |> keep(columns: ["_value"])
+type PushDownReadTagValuesRule struct{}
+
+func (PushDownReadTagValuesRule) Name() string {
+	return "PushDownReadTagValuesRule"
+}
+
+func (PushDownReadTagValuesRule) Pattern() plan.Pattern {
+	return plan.Pat(universe.SchemaMutationKind,
+		plan.Pat(universe.DistinctKind,
+			plan.Pat(universe.GroupKind,
+				plan.Pat(PhysicalFromKind))))
+}
+
+func (PushDownReadTagValuesRule) Rewrite(node plan.PlanNode) (plan.PlanNode, bool, error) {
+	distinctNode := node.Predecessors()[0]
+	groupNode := distinctNode.Predecessors()[0]
+	fromNode := groupNode.Predecessors()[0]
+
+	if !hasSingleSuccessor(distinctNode, groupNode, fromNode) || !isKeepValueColumn(node.ProcedureSpec()) {
+		return node, false, nil
+	}
+
+	groupSpec := groupNode.ProcedureSpec().(*universe.GroupProcedureSpec)
+	if groupSpec.GroupMode != flux.GroupModeBy || len(groupSpec.GroupKeys) != 1 {
+		return node, false, nil
+	}
+
+	// Only tags are stored in the index.
+	tagKey := groupSpec.GroupKeys[0]
+	switch tagKey {
+	case execute.DefaultStartColLabel, execute.DefaultStopColLabel, execute.DefaultTimeColLabel, execute.DefaultValueColLabel:
+		return node, false, nil
+	}
+
+	distinctSpec := distinctNode.ProcedureSpec().(*universe.DistinctProcedureSpec)
+	if distinctSpec.Column != tagKey {
+		return node, false, nil
+	}
+
+	fromSpec := fromNode.ProcedureSpec().(*PhysicalFromProcedureSpec)
+	if !canReadSchema(fromSpec) {
+		return node, false, nil
+	}
+
+	spec := &ReadTagValuesProcedureSpec{
+		ReadTagKeysProcedureSpec: *newReadTagKeysSpec(fromSpec),
+		TagKey:                   tagKey,
+	}
+	return plan.CreatePhysicalNode(mergedNodeID(fromNode, node), spec), true, nil
+}
+
+// canReadSchema reports whether the series read by spec can be determined
+// from the index alone.
+func canReadSchema(spec *PhysicalFromProcedureSpec) bool {
+	// FromKeysRule and FromDistinctRule only read the series, which is
+	// compatible with a schema read.
+	seriesOnly := spec.PointsLimit == -1 && spec.SeriesLimit == 0 && spec.SeriesOffset == 0
+	return spec.BoundsSet &&
+		(!spec.LimitSet || seriesOnly) &&
+		!spec.AggregateSet &&
+		!spec.GroupingSet &&
+		!spec.DescendingSet &&
+		!spec.WindowSet
+}
+
+// isKeepValueColumn reports whether spec is `keep(columns: ["_value"])`.
+func isKeepValueColumn(spec plan.ProcedureSpec) bool {
+	s := spec.(*universe.SchemaMutationProcedureSpec)
+	if len(s.Mutations) != 1 {
+		return false
+	}
+	keep, ok := s.Mutations[0].(*universe.KeepOpSpec)
+	return ok && keep.Predicate == nil &&
+		len(keep.Columns) == 1 && keep.Columns[0] == execute.DefaultValueColLabel
+}
+
+func newReadTagKeysSpec(fromSpec *PhysicalFromProcedureSpec) *ReadTagKeysProcedureSpec {
+	spec := &ReadTagKeysProcedureSpec{
+		Bucket:   fromSpec.Bucket,
+		BucketID: fromSpec.BucketID,
+		Bounds:   fromSpec.Bounds,
+	}
+	if fromSpec.FilterSet {
+		spec.Filter = fromSpec.Filter.Copy().(*semantic.FunctionExpression)
+	}
+	return spec
+}
+
+func createReadTagKeysSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := prSpec.(*ReadTagKeysProcedureSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", prSpec)
+	}
+
+	deps, readSpec, bounds, err := schemaReadSpec(spec, a)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &schemaDecoder{
+		ctx: a.Context(),
+		read: func(ctx context.Context) (cursors.StringIterator, error) {
+			return deps.Reader.ReadTagKeys(ctx, readSpec, bounds.Start, bounds.Stop)
+		},
+		// keys() also reports the columns that storage adds to the group
+		// key of every series.
+		extra: []string{execute.DefaultStartColLabel, execute.DefaultStopColLabel},
+		alloc: a.Allocator(),
+	}
+	return execute.CreateSourceFromDecoder(d, dsid, a)
+}
+
+func createReadTagValuesSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID, a execute.Administration) (execute.Source, error) {
+	spec, ok := prSpec.(*ReadTagValuesProcedureSpec)
+	if !ok {
+		return nil, fmt.Errorf("invalid spec type %T", prSpec)
+	}
+
+	deps, readSpec, bounds, err := schemaReadSpec(&spec.ReadTagKeysProcedureSpec, a)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &schemaDecoder{
+		ctx: a.Context(),
+		read: func(ctx context.Context) (cursors.StringIterator, error) {
+			return deps.Reader.ReadTagValues(ctx, ReadTagValuesSpec{
+				ReadTagKeysSpec: readSpec,
+				TagKey:          spec.TagKey,
+			}, bounds.Start, bounds.Stop)
+		},
+		alloc: a.Allocator(),
+	}
+	return execute.CreateSourceFromDecoder(d, dsid, a)
+}
+
+// schemaReadSpec resolves the organization and bucket of spec and returns
+// the read spec and bounds of its schema read.
+func schemaReadSpec(spec *ReadTagKeysProcedureSpec, a execute.Administration) (Dependencies, ReadTagKeysSpec, *execute.Bounds, error) {
+	bounds := a.StreamContext().Bounds()
+	if bounds == nil {
+		return Dependencies{}, ReadTagKeysSpec{}, nil, errors.New("nil bounds passed to from")
+	}
+
+	deps := a.Dependencies()[FromKind].(Dependencies)
+	req := query.RequestFromContext(a.Context())
+	if req == nil {
+		return Dependencies{}, ReadTagKeysSpec{}, nil, errors.New("missing request on context")
+	}
+
+	bucketID, err := lookupBucketID(deps, req.OrganizationID, spec.Bucket, spec.BucketID)
+	if err != nil {
+		return Dependencies{}, ReadTagKeysSpec{}, nil, err
+	}
+
+	return deps, ReadTagKeysSpec{
+		OrganizationID: req.OrganizationID,
+		BucketID:       bucketID,
+		Predicate:      spec.Filter,
+		Scope:          readScope(req, req.OrganizationID, bucketID),
+	}, bounds, nil
+}
+
+// schemaDecoder produces a single table with a _value column of the strings
+// returned by read.
+type schemaDecoder struct {
+	ctx    context.Context
+	read   func(ctx context.Context) (cursors.StringIterator, error)
+	extra  []string
+	alloc  *memory.Allocator
+	values []string
+}
+
+func (d *schemaDecoder) Connect() error {
+	return nil
+}
+
+func (d *schemaDecoder) Fetch() (bool, error) {
+	itr, err := d.read(d.ctx)
+	if err != nil {
+		return false, err
+	}
+
+	d.values = append(cursors.StringIteratorToSlice(itr), d.extra...)
+	if len(d.extra) > 0 {
+		sort.Strings(d.values)
+	}
+	return false, nil
+}
+
+func (d *schemaDecoder) Decode() (flux.Table, error) {
+	b := execute.NewColListTableBuilder(execute.NewGroupKey(nil, nil), d.alloc)
+	if _, err := b.AddCol(flux.ColMeta{
+		Label: execute.DefaultValueColLabel,
+		Type:  flux.TString,
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, v := range d.values {
+		if err := b.AppendString(0, v); err != nil {
+			return nil, err
+		}
+	}
+	return b.Table()
+}
+
+func (d *schemaDecoder) Close() error {
+	return nil
+}
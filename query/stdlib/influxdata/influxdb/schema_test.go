@@ -0,0 +1,202 @@
+package influxdb_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/plan"
+	"github.com/influxdata/flux/plan/plantest"
+	"github.com/influxdata/flux/stdlib/universe"
+	"github.com/influxdata/influxdb/query/stdlib/influxdata/influxdb"
+)
+
+func keepValue() *universe.SchemaMutationProcedureSpec {
+	return &universe.SchemaMutationProcedureSpec{
+		Mutations: []universe.SchemaMutation{
+			&universe.KeepOpSpec{Columns: []string{execute.DefaultValueColLabel}},
+		},
+	}
+}
+
+func TestPushDownReadTagKeysRule(t *testing.T) {
+	bounded := &influxdb.PhysicalFromProcedureSpec{
+		FromProcedureSpec: influxdb.FromProcedureSpec{Bucket: "b"},
+		BoundsSet:         true,
+		Bounds: flux.Bounds{
+			Start: fluxTime(5),
+			Stop:  fluxTime(10),
+		},
+	}
+	seriesOnly := bounded.Copy().(*influxdb.PhysicalFromProcedureSpec)
+	seriesOnly.LimitSet = true
+	seriesOnly.PointsLimit = -1
+
+	tagKeys := func(from *influxdb.PhysicalFromProcedureSpec) *plantest.PlanSpec {
+		return &plantest.PlanSpec{
+			Nodes: []plan.PlanNode{
+				plan.CreatePhysicalNode("from", from),
+				plan.CreatePhysicalNode("keys", &universe.KeysProcedureSpec{Column: "_value"}),
+				plan.CreatePhysicalNode("keep", keepValue()),
+			},
+			Edges: [][2]int{
+				{0, 1},
+				{1, 2},
+			},
+		}
+	}
+
+	readTagKeys := &plantest.PlanSpec{
+		Nodes: []plan.PlanNode{
+			plan.CreatePhysicalNode("merged_from_keep", &influxdb.ReadTagKeysProcedureSpec{
+				Bucket: "b",
+				Bounds: bounded.Bounds,
+			}),
+		},
+	}
+
+	// Before and After are built separately, as a SchemaMutationProcedureSpec
+	// cannot be copied.
+	tests := []plantest.RuleTestCase{
+		{
+			Name:   "tag keys",
+			Rules:  []plan.Rule{influxdb.PushDownReadTagKeysRule{}},
+			Before: tagKeys(bounded),
+			After:  readTagKeys,
+		},
+		{
+			Name:   "tag keys of series only read",
+			Rules:  []plan.Rule{influxdb.PushDownReadTagKeysRule{}},
+			Before: tagKeys(seriesOnly),
+			After:  readTagKeys,
+		},
+		{
+			Name:   "tag keys with keys rule",
+			Rules:  []plan.Rule{influxdb.FromKeysRule{}, influxdb.PushDownReadTagKeysRule{}},
+			Before: tagKeys(bounded),
+			After:  readTagKeys,
+		},
+		{
+			Name:   "unbounded from",
+			Rules:  []plan.Rule{influxdb.PushDownReadTagKeysRule{}},
+			Before: tagKeys(&influxdb.PhysicalFromProcedureSpec{}),
+			After:  tagKeys(&influxdb.PhysicalFromProcedureSpec{}),
+		},
+		{
+			Name:  "keys of other column",
+			Rules: []plan.Rule{influxdb.PushDownReadTagKeysRule{}},
+			Before: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plan.CreatePhysicalNode("from", bounded),
+					plan.CreatePhysicalNode("keys", &universe.KeysProcedureSpec{Column: "k"}),
+					plan.CreatePhysicalNode("keep", keepValue()),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+				},
+			},
+			After: &plantest.PlanSpec{
+				Nodes: []plan.PlanNode{
+					plan.CreatePhysicalNode("from", bounded),
+					plan.CreatePhysicalNode("keys", &universe.KeysProcedureSpec{Column: "k"}),
+					plan.CreatePhysicalNode("keep", keepValue()),
+				},
+				Edges: [][2]int{
+					{0, 1},
+					{1, 2},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			plantest.PhysicalRuleTestHelper(t, &tc)
+		})
+	}
+}
+
+func TestPushDownReadTagValuesRule(t *testing.T) {
+	bounded := &influxdb.PhysicalFromProcedureSpec{
+		FromProcedureSpec: influxdb.FromProcedureSpec{Bucket: "b"},
+		BoundsSet:         true,
+		Bounds: flux.Bounds{
+			Start: fluxTime(5),
+			Stop:  fluxTime(10),
+		},
+	}
+	aggregated := bounded.Copy().(*influxdb.PhysicalFromProcedureSpec)
+	aggregated.AggregateSet = true
+	aggregated.AggregateMethod = universe.CountKind
+
+	tagValues := func(from *influxdb.PhysicalFromProcedureSpec, tag string) *plantest.PlanSpec {
+		return &plantest.PlanSpec{
+			Nodes: []plan.PlanNode{
+				plan.CreatePhysicalNode("from", from),
+				plan.CreatePhysicalNode("group", &universe.GroupProcedureSpec{
+					GroupMode: flux.GroupModeBy,
+					GroupKeys: []string{tag},
+				}),
+				plan.CreatePhysicalNode("distinct", &universe.DistinctProcedureSpec{Column: tag}),
+				plan.CreatePhysicalNode("keep", keepValue()),
+			},
+			Edges: [][2]int{
+				{0, 1},
+				{1, 2},
+				{2, 3},
+			},
+		}
+	}
+
+	readTagValues := func(tag string) *plantest.PlanSpec {
+		return &plantest.PlanSpec{
+			Nodes: []plan.PlanNode{
+				plan.CreatePhysicalNode("merged_from_keep", &influxdb.ReadTagValuesProcedureSpec{
+					ReadTagKeysProcedureSpec: influxdb.ReadTagKeysProcedureSpec{
+						Bucket: "b",
+						Bounds: bounded.Bounds,
+					},
+					TagKey: tag,
+				}),
+			},
+		}
+	}
+
+	tests := []plantest.RuleTestCase{
+		{
+			Name:   "tag values",
+			Rules:  []plan.Rule{influxdb.PushDownReadTagValuesRule{}},
+			Before: tagValues(bounded, "host"),
+			After:  readTagValues("host"),
+		},
+		{
+			Name:   "measurements",
+			Rules:  []plan.Rule{influxdb.PushDownReadTagValuesRule{}},
+			Before: tagValues(bounded, "_measurement"),
+			After:  readTagValues("_measurement"),
+		},
+		{
+			Name:   "values of time column",
+			Rules:  []plan.Rule{influxdb.PushDownReadTagValuesRule{}},
+			Before: tagValues(bounded, "_start"),
+			After:  tagValues(bounded, "_start"),
+		},
+		{
+			Name:   "aggregated from",
+			Rules:  []plan.Rule{influxdb.PushDownReadTagValuesRule{}},
+			Before: tagValues(aggregated, "host"),
+			After:  tagValues(aggregated, "host"),
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Parallel()
+			plantest.PhysicalRuleTestHelper(t, &tc)
+		})
+	}
+}
@@ -8,7 +8,9 @@ import (
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/memory"
 	"github.com/influxdata/flux/semantic"
+	"github.com/influxdata/flux/stdlib/universe"
 	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/tsdb/cursors"
 	"github.com/pkg/errors"
@@ -113,6 +115,12 @@ func (s *source) run(ctx context.Context) error {
 	//TODO(nathanielc): Pass through context to actual network I/O.
 	for tables, mark, ok := s.next(ctx); ok; tables, mark, ok = s.next(ctx) {
 		err := tables.Do(func(tbl flux.Table) error {
+			if s.readSpec.WindowCreateEmpty {
+				var err error
+				if tbl, err = s.fillEmptyWindows(tbl); err != nil {
+					return err
+				}
+			}
 			for _, t := range s.ts {
 				if err := t.Process(s.id, tbl); err != nil {
 					return err
@@ -143,6 +151,82 @@ func (s *source) run(ctx context.Context) error {
 	return nil
 }
 
+// fillEmptyWindows returns a copy of tbl, which holds a point per window of a
+// series, with a row for each window of the bounds without a point.
+func (s *source) fillEmptyWindows(tbl flux.Table) (flux.Table, error) {
+	if tbl.Empty() {
+		return tbl, nil
+	}
+
+	builder := execute.NewColListTableBuilder(tbl.Key(), &memory.Allocator{})
+	if err := execute.AddTableCols(tbl, builder); err != nil {
+		return nil, err
+	}
+	timeIdx := execute.ColIdx(execute.DefaultTimeColLabel, builder.Cols())
+	valueIdx := execute.ColIdx(execute.DefaultValueColLabel, builder.Cols())
+	if timeIdx < 0 || valueIdx < 0 {
+		return nil, fmt.Errorf("windowed aggregate without %s and %s columns", execute.DefaultTimeColLabel, execute.DefaultValueColLabel)
+	}
+
+	// The time of the point of a window is its stop, truncated to the bounds.
+	every, offset := s.readSpec.WindowEvery, s.readSpec.WindowOffset
+	start := int64(s.bounds.Start)
+	stop := start - ((start-offset)%every+every)%every + every
+	nextTime := func() execute.Time {
+		if t := execute.Time(stop); t < s.bounds.Stop {
+			return t
+		}
+		return s.bounds.Stop
+	}
+
+	// appendEmpty appends the rows of the windows whose time is before ts.
+	appendEmpty := func(ts execute.Time) error {
+		for ; stop-every < int64(s.bounds.Stop) && nextTime() < ts; stop += every {
+			for j, c := range builder.Cols() {
+				var err error
+				switch {
+				case j == timeIdx:
+					err = builder.AppendTime(j, nextTime())
+				case j == valueIdx && s.readSpec.AggregateMethod == universe.CountKind:
+					err = builder.AppendInt(j, 0)
+				case tbl.Key().HasCol(c.Label):
+					err = builder.AppendValue(j, tbl.Key().LabelValue(c.Label))
+				default:
+					err = builder.AppendNil(j)
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	err := tbl.Do(func(cr flux.ColReader) error {
+		times := cr.Times(timeIdx)
+		for i := 0; i < cr.Len(); i++ {
+			ts := execute.Time(times.Value(i))
+			if err := appendEmpty(ts); err != nil {
+				return err
+			}
+			if err := execute.AppendRecord(i, cr, builder); err != nil {
+				return err
+			}
+			for stop-every < int64(s.bounds.Stop) && nextTime() <= ts {
+				stop += every
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := appendEmpty(s.bounds.Stop + 1); err != nil {
+		return nil, err
+	}
+	return builder.Table()
+}
+
 func (s *source) next(ctx context.Context) (TableIterator, execute.Time, bool) {
 	if s.overflow {
 		return nil, 0, false
@@ -209,6 +293,10 @@ type ReadSpec struct {
 	OrganizationID platform.ID
 	BucketID       platform.ID
 
+	// Scope is the textual predicate restricting the series the read is
+	// authorized to see. It is ANDed with Predicate when it is not empty.
+	Scope string
+
 	RAMLimit     uint64
 	Hosts        []string
 	Predicate    *semantic.FunctionExpression
@@ -219,6 +307,16 @@ type ReadSpec struct {
 
 	AggregateMethod string
 
+	// WindowEvery and WindowOffset, in nanoseconds, split each series into
+	// windows that are aggregated separately by AggregateMethod.
+	WindowEvery  int64
+	WindowOffset int64
+
+	// WindowCreateEmpty adds a row for each window of a series without
+	// points, like the windows aggregateWindow creates empty. Its value is
+	// null, or 0 when AggregateMethod counts the points.
+	WindowCreateEmpty bool
+
 	// OrderByTime indicates that series reads should produce all
 	// series for a time before producing any series for a larger time.
 	// By default this is false meaning all values of time are produced for a given series,
@@ -236,8 +334,33 @@ type ReadSpec struct {
 	RetentionPolicy string // required by InfluxDB OSS
 }
 
+// ReadTagKeysSpec identifies the series whose tag keys are read.
+type ReadTagKeysSpec struct {
+	OrganizationID platform.ID
+	BucketID       platform.ID
+	Predicate      *semantic.FunctionExpression
+	// Scope is the textual predicate restricting the series the read is
+	// authorized to see. It is ANDed with Predicate when it is not empty.
+	Scope string
+}
+
+// ReadTagValuesSpec identifies the series whose values of TagKey are read.
+type ReadTagValuesSpec struct {
+	ReadTagKeysSpec
+	TagKey string
+}
+
 type Reader interface {
 	Read(ctx context.Context, rs ReadSpec, start, stop execute.Time) (TableIterator, error)
+
+	// ReadTagKeys returns the sorted tag keys of the series that match spec
+	// and have data within [start, stop).
+	ReadTagKeys(ctx context.Context, spec ReadTagKeysSpec, start, stop execute.Time) (cursors.StringIterator, error)
+
+	// ReadTagValues returns the sorted values of spec.TagKey for the series
+	// that match spec and have data within [start, stop).
+	ReadTagValues(ctx context.Context, spec ReadTagValuesSpec, start, stop execute.Time) (cursors.StringIterator, error)
+
 	Close()
 }
 
@@ -0,0 +1,154 @@
+package influxdb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/influxdb/query/stdlib/influxdata/influxdb"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+)
+
+// tableReader reads the same tables for every request.
+type tableReader struct {
+	influxdb.Reader
+	tables []*executetest.Table
+}
+
+func (r *tableReader) Read(ctx context.Context, rs influxdb.ReadSpec, start, stop execute.Time) (influxdb.TableIterator, error) {
+	return tableIterator(r.tables), nil
+}
+
+type tableIterator []*executetest.Table
+
+func (it tableIterator) Do(f func(flux.Table) error) error {
+	for _, tbl := range it {
+		if err := f(tbl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (it tableIterator) Statistics() cursors.CursorStats { return cursors.CursorStats{} }
+
+// tableCollector collects the tables processed by a transformation.
+type tableCollector struct {
+	execute.Transformation
+	tables []*executetest.Table
+	err    error
+}
+
+func (c *tableCollector) Process(id execute.DatasetID, tbl flux.Table) error {
+	t, err := executetest.ConvertTable(tbl)
+	if err != nil {
+		return err
+	}
+	c.tables = append(c.tables, t)
+	return nil
+}
+
+func (c *tableCollector) UpdateProcessingTime(id execute.DatasetID, t execute.Time) error { return nil }
+func (c *tableCollector) UpdateWatermark(id execute.DatasetID, t execute.Time) error      { return nil }
+func (c *tableCollector) Finish(id execute.DatasetID, err error)                          { c.err = err }
+
+func TestSource_WindowCreateEmpty(t *testing.T) {
+	bounds := execute.Bounds{Start: 0, Stop: 45}
+	cols := func(typ flux.ColType) []flux.ColMeta {
+		return []flux.ColMeta{
+			{Label: "_start", Type: flux.TTime},
+			{Label: "_stop", Type: flux.TTime},
+			{Label: "_time", Type: flux.TTime},
+			{Label: "_value", Type: typ},
+			{Label: "_measurement", Type: flux.TString},
+		}
+	}
+	keyCols := []string{"_start", "_stop", "_measurement"}
+
+	tests := []struct {
+		name   string
+		method string
+		read   *executetest.Table
+		want   *executetest.Table
+	}{
+		{
+			name:   "empty windows are null",
+			method: "mean",
+			read: &executetest.Table{
+				KeyCols: keyCols,
+				ColMeta: cols(flux.TFloat),
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(45), execute.Time(20), 1.0, "m"},
+					{execute.Time(0), execute.Time(45), execute.Time(40), 2.0, "m"},
+				},
+			},
+			want: &executetest.Table{
+				KeyCols: keyCols,
+				ColMeta: cols(flux.TFloat),
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(45), execute.Time(10), nil, "m"},
+					{execute.Time(0), execute.Time(45), execute.Time(20), 1.0, "m"},
+					{execute.Time(0), execute.Time(45), execute.Time(30), nil, "m"},
+					{execute.Time(0), execute.Time(45), execute.Time(40), 2.0, "m"},
+					{execute.Time(0), execute.Time(45), execute.Time(45), nil, "m"},
+				},
+			},
+		},
+		{
+			name:   "empty windows count no points",
+			method: "count",
+			read: &executetest.Table{
+				KeyCols: keyCols,
+				ColMeta: cols(flux.TInt),
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(45), execute.Time(20), int64(3), "m"},
+				},
+			},
+			want: &executetest.Table{
+				KeyCols: keyCols,
+				ColMeta: cols(flux.TInt),
+				Data: [][]interface{}{
+					{execute.Time(0), execute.Time(45), execute.Time(10), int64(0), "m"},
+					{execute.Time(0), execute.Time(45), execute.Time(20), int64(3), "m"},
+					{execute.Time(0), execute.Time(45), execute.Time(30), int64(0), "m"},
+					{execute.Time(0), execute.Time(45), execute.Time(40), int64(0), "m"},
+					{execute.Time(0), execute.Time(45), execute.Time(45), int64(0), "m"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := execute.Window{Every: execute.Duration(bounds.Stop), Period: execute.Duration(bounds.Stop)}
+			src := influxdb.NewSource(
+				executetest.RandomDatasetID(),
+				&tableReader{tables: []*executetest.Table{tt.read}},
+				influxdb.ReadSpec{
+					AggregateMethod:   tt.method,
+					WindowEvery:       10,
+					WindowCreateEmpty: true,
+				},
+				bounds,
+				w,
+				bounds.Start+execute.Time(w.Period),
+			)
+			c := &tableCollector{}
+			src.AddTransformation(c)
+			src.Run(context.Background())
+
+			if c.err != nil {
+				t.Fatal(c.err)
+			}
+			want := []*executetest.Table{tt.want}
+			executetest.NormalizeTables(want)
+			executetest.NormalizeTables(c.tables)
+			if !cmp.Equal(want, c.tables) {
+				t.Errorf("unexpected tables -want/+got\n%s", cmp.Diff(want, c.tables))
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/influxdata/flux/values"
 	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/query"
 	"github.com/influxdata/influxdb/storage"
 	"github.com/influxdata/influxdb/tsdb"
 )
@@ -245,6 +246,9 @@ func createToTransformation(id execute.DatasetID, mode execute.AccumulationMode,
 	if err != nil {
 		return nil, nil, err
 	}
+	if req := query.RequestFromContext(a.Context()); req != nil {
+		t.Authorization = req.Authorization
+	}
 	return t, d, nil
 }
 
@@ -255,6 +259,10 @@ type ToTransformation struct {
 	cache execute.TableBuilderCache
 	spec  *ToProcedureSpec
 	deps  ToDependencies
+
+	// Authorization is the authorization of the query. The points written
+	// must match the predicate of its write permission on the bucket, if any.
+	Authorization *platform.Authorization
 }
 
 // RetractTable retracts the table for the transformation for the `to` flux function.
@@ -395,6 +403,10 @@ type ToDependencies struct {
 	BucketLookup       BucketLookup
 	OrganizationLookup OrganizationLookup
 	PointsWriter       storage.PointsWriter
+
+	// ParsePredicate parses the predicate of a permission. The points are
+	// only written by authorizations restricted to a predicate when it is set.
+	ParsePredicate func(string) (platform.Predicate, error)
 }
 
 // Validate returns an error if any required field is unset.
@@ -467,6 +479,11 @@ func writeTable(t *ToTransformation, tbl flux.Table) error {
 		return err
 	}
 
+	scope, err := writeScope(d, t.Authorization, *orgID, *bucketID)
+	if err != nil {
+		return err
+	}
+
 	// cache tag columns
 	columns := tbl.Cols()
 	isTag := make([]bool, len(columns))
@@ -589,10 +606,49 @@ func writeTable(t *ToTransformation, tbl flux.Table) error {
 			}
 		}
 		points, err = tsdb.ExplodePoints(*orgID, *bucketID, points)
+		if err != nil {
+			return err
+		}
+
+		if scope != nil {
+			for _, p := range points {
+				if !scope.Matches(p.Key()) {
+					return errors.New("cannot write points outside the scope of the authorization")
+				}
+			}
+		}
 		return d.PointsWriter.WritePoints(context.TODO(), points)
 	})
 }
 
+// writeScope returns the predicate restricting the series of the bucket that
+// a may write, or nil if they are not restricted.
+func writeScope(d ToDependencies, a *platform.Authorization, orgID, bucketID platform.ID) (platform.Predicate, error) {
+	if a == nil {
+		return nil, nil
+	}
+	s := a.Predicate(platform.Permission{
+		Action: platform.WriteAction,
+		Resource: platform.Resource{
+			Type:  platform.BucketsResourceType,
+			OrgID: &orgID,
+			ID:    &bucketID,
+		},
+	})
+	if s == "" {
+		return nil, nil
+	}
+
+	if d.ParsePredicate == nil {
+		return nil, errors.New("writes of authorizations restricted to a predicate are not supported")
+	}
+	pred, err := d.ParsePredicate(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid predicate of authorization: %v", err)
+	}
+	return pred, nil
+}
+
 func defaultFieldMapping(er flux.ColReader, row int) (values.Object, error) {
 	fieldColumnIdx := execute.ColIdx(defaultFieldColLabel, er.Cols())
 	valueColumnIdx := execute.ColIdx(execute.DefaultValueColLabel, er.Cols())
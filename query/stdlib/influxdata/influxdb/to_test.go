@@ -17,7 +17,9 @@ import (
 	_ "github.com/influxdata/influxdb/query/builtin"
 	"github.com/influxdata/influxdb/query/querytest"
 	"github.com/influxdata/influxdb/query/stdlib/influxdata/influxdb"
+	"github.com/influxdata/influxdb/storage/reads"
 	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/tsm1"
 )
 
 func TestTo_Query(t *testing.T) {
@@ -629,3 +631,92 @@ func mockPoints(org, bucket platform.ID, pointdata string) []models.Point {
 
 	return exploded
 }
+
+func TestTo_Process_Scope(t *testing.T) {
+	oid, _ := (mockOrgLookup{}).Lookup(context.Background(), "my-org")
+	bid, _ := (mockBucketLookup{}).Lookup(oid, "my-bucket")
+
+	auth := &platform.Authorization{
+		Status: platform.Active,
+		Permissions: []platform.Permission{{
+			Action: platform.WriteAction,
+			Resource: platform.Resource{
+				Type:  platform.BucketsResourceType,
+				OrgID: &oid,
+				ID:    &bid,
+			},
+			Predicate: `_measurement="a"`,
+		}},
+	}
+
+	spec := &influxdb.ToProcedureSpec{
+		Spec: &influxdb.ToOpSpec{
+			Org:               "my-org",
+			Bucket:            "my-bucket",
+			TimeColumn:        "_time",
+			MeasurementColumn: "_measurement",
+		},
+	}
+	table := func(measurement string) flux.Table {
+		return executetest.MustCopyTable(&executetest.Table{
+			KeyCols: []string{"_measurement"},
+			ColMeta: []flux.ColMeta{
+				{Label: "_time", Type: flux.TTime},
+				{Label: "_measurement", Type: flux.TString},
+				{Label: "_field", Type: flux.TString},
+				{Label: "_value", Type: flux.TFloat},
+			},
+			Data: [][]interface{}{
+				{execute.Time(11), measurement, "_value", 2.0},
+			},
+		})
+	}
+	parsePredicate := func(s string) (platform.Predicate, error) {
+		pred, err := reads.ParsePredicate(s)
+		if err != nil {
+			return nil, err
+		}
+		return tsm1.NewProtobufPredicate(pred)
+	}
+
+	for _, tc := range []struct {
+		name        string
+		measurement string
+		parse       func(string) (platform.Predicate, error)
+		wantErr     bool
+	}{
+		{name: "inside the scope", measurement: "a", parse: parsePredicate},
+		{name: "outside the scope", measurement: "b", parse: parsePredicate, wantErr: true},
+		{name: "predicates not supported", measurement: "a", wantErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			deps := mockDependencies()
+			deps.ParsePredicate = tc.parse
+
+			d := executetest.NewDataset(executetest.RandomDatasetID())
+			c := execute.NewTableBuilderCache(executetest.UnlimitedAllocator)
+			c.SetTriggerSpec(execute.DefaultTriggerSpec)
+			tr, err := influxdb.NewToTransformation(d, c, spec, deps)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tr.Authorization = auth
+
+			err = tr.Process(executetest.RandomDatasetID(), table(tc.measurement))
+			if got := err != nil; got != tc.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			pw := deps.PointsWriter.(*mock.PointsWriter)
+			if tc.wantErr {
+				if len(pw.Points) != 0 {
+					t.Errorf("expected no points to be written, got %s", pointsToStr(pw.Points))
+				}
+				return
+			}
+			if got, want := pointsToStr(pw.Points), pointsToStr(mockPoints(oid, bid, "a _value=2.0 11")); got != want {
+				t.Errorf("unexpected points written -got/+want\n%s", cmp.Diff(got, want))
+			}
+		})
+	}
+}
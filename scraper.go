@@ -2,6 +2,8 @@ package influxdb
 
 import (
 	"context"
+	"fmt"
+	"time"
 )
 
 // ErrScraperTargetNotFound is the error msg for a missing scraper target.
@@ -24,6 +26,123 @@ type ScraperTarget struct {
 	URL      string      `json:"url"`
 	OrgID    ID          `json:"orgID,omitempty"`
 	BucketID ID          `json:"bucketID,omitempty"`
+
+	// Interval is the duration between scrapes of the target, such as "30s".
+	// The interval of the scheduler is used when it is empty.
+	Interval string `json:"interval,omitempty"`
+	// Timeout is the maximum duration of a scrape of the target.
+	// The timeout of the scheduler is used when it is empty.
+	Timeout string `json:"timeout,omitempty"`
+
+	// Username and PasswordSecret are the basic auth credentials of the target.
+	// PasswordSecret is the key of the secret of the organization holding the password.
+	Username       string `json:"username,omitempty"`
+	PasswordSecret string `json:"passwordSecret,omitempty"`
+	// BearerTokenSecret is the key of the secret of the organization holding
+	// the bearer token of the target.
+	BearerTokenSecret string `json:"bearerTokenSecret,omitempty"`
+
+	TLS  *ScraperTLSConfig  `json:"tls,omitempty"`
+	JSON *ScraperJSONConfig `json:"json,omitempty"`
+}
+
+// ScraperTLSConfig are the TLS options used to connect to a scraper target.
+type ScraperTLSConfig struct {
+	// CACert is the PEM encoded certificate authority of the target.
+	CACert string `json:"caCert,omitempty"`
+	// ClientCert is the PEM encoded client certificate presented to the target.
+	ClientCert string `json:"clientCert,omitempty"`
+	// ClientKeySecret is the key of the secret of the organization holding
+	// the PEM encoded private key of the client certificate.
+	ClientKeySecret    string `json:"clientKeySecret,omitempty"`
+	ServerName         string `json:"serverName,omitempty"`
+	InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty"`
+}
+
+// ScraperJSONConfig maps the values of a JSON document to a point.
+type ScraperJSONConfig struct {
+	// Measurement is the measurement of the point; the name of the target is used when it is empty.
+	Measurement string               `json:"measurement,omitempty"`
+	Tags        []ScraperJSONMapping `json:"tags,omitempty"`
+	Fields      []ScraperJSONMapping `json:"fields"`
+}
+
+// ScraperJSONMapping maps the value at Path of a JSON document to the tag or field Name.
+// Path is a dot separated list of object keys and array indexes, such as "stats.connections.0.active".
+type ScraperJSONMapping struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+// Validate returns an error if the scraper target is invalid.
+func (t ScraperTarget) Validate() error {
+	if !ValidScraperType(string(t.Type)) {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("invalid scraper type %q", t.Type),
+		}
+	}
+
+	for _, d := range []struct{ name, value string }{{"interval", t.Interval}, {"timeout", t.Timeout}} {
+		if d.value == "" {
+			continue
+		}
+		if v, err := time.ParseDuration(d.value); err != nil || v <= 0 {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("scraper %s must be a positive duration", d.name),
+			}
+		}
+	}
+
+	if t.PasswordSecret != "" && t.Username == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "scraper password secret requires a username",
+		}
+	}
+	if t.PasswordSecret != "" && t.BearerTokenSecret != "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "scraper may use only one of basic auth or a bearer token",
+		}
+	}
+
+	if t.Type == JSONScraperType {
+		if t.JSON == nil || len(t.JSON.Fields) == 0 {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "json scraper requires at least one field mapping",
+			}
+		}
+		for _, m := range append(append([]ScraperJSONMapping{}, t.JSON.Tags...), t.JSON.Fields...) {
+			if m.Name == "" || m.Path == "" {
+				return &Error{
+					Code: EInvalid,
+					Msg:  "json scraper mappings require a name and a path",
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// ScrapeInterval returns the interval of the target, or def if it has none.
+func (t ScraperTarget) ScrapeInterval(def time.Duration) time.Duration {
+	return parseScraperDuration(t.Interval, def)
+}
+
+// ScrapeTimeout returns the timeout of the target, or def if it has none.
+func (t ScraperTarget) ScrapeTimeout(def time.Duration) time.Duration {
+	return parseScraperDuration(t.Timeout, def)
+}
+
+func parseScraperDuration(s string, def time.Duration) time.Duration {
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return def
+	}
+	return d
 }
 
 // ScraperTargetStoreService defines the crud service for ScraperTarget.
@@ -49,12 +168,18 @@ type ScraperType string
 const (
 	// PrometheusScraperType parses metrics from a prometheus endpoint.
 	PrometheusScraperType = "prometheus"
+	// OpenMetricsScraperType parses metrics, and their exemplars, from an OpenMetrics endpoint.
+	OpenMetricsScraperType = "openmetrics"
+	// JSONScraperType maps the values of a JSON document to a point.
+	JSONScraperType = "json"
+	// StatsDScraperType parses metrics in the StatsD line format, one per line.
+	StatsDScraperType = "statsd"
 )
 
 // ValidScraperType returns true is the type string is valid
 func ValidScraperType(s string) bool {
 	switch s {
-	case PrometheusScraperType:
+	case PrometheusScraperType, OpenMetricsScraperType, JSONScraperType, StatsDScraperType:
 		return true
 	default:
 		return false
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	"go.uber.org/zap"
+)
+
+// AuditMeasurement is the measurement of the points written for audit events.
+const AuditMeasurement = "audit"
+
+// AuditService wraps an existing platform.AuditService implementation.
+//
+// AuditService also writes every recorded event as a point to a bucket, so
+// that the audit log can be queried, and its retention managed, like any
+// other time series.
+type AuditService struct {
+	platform.AuditService
+
+	Logger *zap.Logger
+
+	writer   PointsWriter
+	orgID    platform.ID
+	bucketID platform.ID
+}
+
+// NewAuditService returns a new AuditService that writes the events recorded
+// to s to the bucket bucketID of the organization orgID with w, which
+// typically will be an Engine.
+func NewAuditService(s platform.AuditService, w PointsWriter, orgID, bucketID platform.ID) *AuditService {
+	return &AuditService{
+		AuditService: s,
+		Logger:       zap.NewNop(),
+		writer:       w,
+		orgID:        orgID,
+		bucketID:     bucketID,
+	}
+}
+
+// RecordAuditEvent records the event e, and writes it as a point to the bucket.
+// The event is recorded even when the point cannot be written.
+func (s *AuditService) RecordAuditEvent(ctx context.Context, e *platform.AuditEvent) error {
+	if err := s.AuditService.RecordAuditEvent(ctx, e); err != nil {
+		return err
+	}
+
+	if err := s.writeEvent(ctx, e); err != nil {
+		s.Logger.Error("Failed to write audit event", zap.Stringer("id", e.ID), zap.Error(err))
+	}
+	return nil
+}
+
+func (s *AuditService) writeEvent(ctx context.Context, e *platform.AuditEvent) error {
+	tags := models.NewTags(map[string]string{
+		"action":       string(e.Action),
+		"resourceType": string(e.ResourceType),
+		"outcome":      string(e.Outcome),
+	})
+
+	fields := models.Fields{
+		"id": e.ID.String(),
+	}
+	for k, id := range map[string]platform.ID{
+		"userID":       e.UserID,
+		"authorizerID": e.AuthorizerID,
+		"resourceID":   e.ResourceID,
+		"orgID":        e.OrgID,
+	} {
+		if id.Valid() {
+			fields[k] = id.String()
+		}
+	}
+	if e.AuthorizerKind != "" {
+		fields["authorizerKind"] = e.AuthorizerKind
+	}
+	if e.Error != "" {
+		fields["error"] = e.Error
+	}
+
+	pt, err := models.NewPoint(AuditMeasurement, tags, fields, e.Time)
+	if err != nil {
+		return err
+	}
+
+	pts, err := tsdb.ExplodePoints(s.orgID, s.bucketID, []models.Point{pt})
+	if err != nil {
+		return err
+	}
+	return s.writer.WritePoints(ctx, pts)
+}
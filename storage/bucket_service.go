@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"errors"
+	"time"
 
 	platform "github.com/influxdata/influxdb"
 )
@@ -16,7 +17,8 @@ type BucketDeleter interface {
 //
 // BucketService ensures that when a bucket is deleted, all stored data
 // associated with the bucket is either removed, or marked to be removed via a
-// future compaction.
+// future compaction. It also sets the shard group durations and series limits
+// of buckets on the engine as they are created and updated.
 type BucketService struct {
 	inner  platform.BucketService
 	engine BucketDeleter
@@ -61,7 +63,12 @@ func (s *BucketService) CreateBucket(ctx context.Context, b *platform.Bucket) er
 	if s.inner == nil || s.engine == nil {
 		return errors.New("nil inner BucketService or Engine")
 	}
-	return s.inner.CreateBucket(ctx, b)
+	if err := s.inner.CreateBucket(ctx, b); err != nil {
+		return err
+	}
+	s.setShardGroupDuration(b.OrganizationID, b.ID, partitionDuration(b))
+	s.setSeriesLimit(b.OrganizationID, b.ID, b.MaxSeries)
+	return nil
 }
 
 // UpdateBucket updates a single bucket with changeset.
@@ -70,7 +77,13 @@ func (s *BucketService) UpdateBucket(ctx context.Context, id platform.ID, upd pl
 	if s.inner == nil || s.engine == nil {
 		return nil, errors.New("nil inner BucketService or Engine")
 	}
-	return s.inner.UpdateBucket(ctx, id, upd)
+	b, err := s.inner.UpdateBucket(ctx, id, upd)
+	if err != nil {
+		return nil, err
+	}
+	s.setShardGroupDuration(b.OrganizationID, b.ID, partitionDuration(b))
+	s.setSeriesLimit(b.OrganizationID, b.ID, b.MaxSeries)
+	return b, nil
 }
 
 // DeleteBucket removes a bucket by ID.
@@ -86,5 +99,26 @@ func (s *BucketService) DeleteBucket(ctx context.Context, bucketID platform.ID)
 	if err := s.engine.DeleteBucket(bucket.OrganizationID, bucketID); err != nil {
 		return err
 	}
-	return s.inner.DeleteBucket(ctx, bucketID)
+	if err := s.inner.DeleteBucket(ctx, bucketID); err != nil {
+		return err
+	}
+	s.setShardGroupDuration(bucket.OrganizationID, bucketID, 0)
+	s.setSeriesLimit(bucket.OrganizationID, bucketID, 0)
+	return nil
+}
+
+// setShardGroupDuration partitions the data of the bucket into shard groups of
+// duration d, if the engine supports shard groups.
+func (s *BucketService) setShardGroupDuration(orgID, bucketID platform.ID, d time.Duration) {
+	if sg, ok := s.engine.(ShardGroupSetter); ok {
+		sg.SetShardGroupDuration(orgID, bucketID, d)
+	}
+}
+
+// setSeriesLimit limits the series of the bucket to n, if the engine supports
+// series limits.
+func (s *BucketService) setSeriesLimit(orgID, bucketID platform.ID, n int64) {
+	if sl, ok := s.engine.(SeriesLimitSetter); ok {
+		sl.SetBucketMaxSeries(orgID, bucketID, n)
+	}
 }
@@ -3,6 +3,7 @@ package storage_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/inmem"
@@ -61,3 +62,99 @@ func (m *MockDeleter) DeleteBucket(orgID, bucketID platform.ID) error {
 	m.orgID, m.bucketID = orgID, bucketID
 	return nil
 }
+
+func TestBucketService_SeriesLimits(t *testing.T) {
+	inmemService := inmem.NewService()
+	org := &platform.Organization{}
+	if err := inmemService.CreateOrganization(context.TODO(), org); err != nil {
+		panic(err)
+	}
+
+	engine := &MockSeriesLimitSetter{limits: make(map[platform.ID]int64)}
+	service := storage.NewBucketService(inmemService, engine)
+
+	bucket := &platform.Bucket{OrganizationID: org.ID, Name: "b", MaxSeries: 10}
+	if err := service.CreateBucket(context.TODO(), bucket); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := engine.limits[bucket.ID], int64(10); got != exp {
+		t.Fatalf("got limit %d, expected %d", got, exp)
+	}
+
+	n := int64(20)
+	if _, err := service.UpdateBucket(context.TODO(), bucket.ID, platform.BucketUpdate{MaxSeries: &n}); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := engine.limits[bucket.ID], int64(20); got != exp {
+		t.Fatalf("got limit %d, expected %d", got, exp)
+	}
+
+	if err := service.DeleteBucket(context.TODO(), bucket.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := engine.limits[bucket.ID], int64(0); got != exp {
+		t.Fatalf("got limit %d, expected %d", got, exp)
+	}
+}
+
+type MockSeriesLimitSetter struct {
+	MockDeleter
+	limits map[platform.ID]int64
+}
+
+func (m *MockSeriesLimitSetter) SetBucketMaxSeries(orgID, bucketID platform.ID, n int64) {
+	m.limits[bucketID] = n
+}
+
+func TestBucketService_ShardGroups(t *testing.T) {
+	inmemService := inmem.NewService()
+	org := &platform.Organization{}
+	if err := inmemService.CreateOrganization(context.TODO(), org); err != nil {
+		panic(err)
+	}
+
+	engine := &MockShardGroupSetter{durations: make(map[platform.ID]time.Duration)}
+	service := storage.NewBucketService(inmemService, engine)
+
+	// The data of buckets with an infinite retention period is partitioned
+	// by the shard group duration they set.
+	bucket := &platform.Bucket{OrganizationID: org.ID, Name: "b", ShardGroupDuration: 2 * time.Hour}
+	if err := service.CreateBucket(context.TODO(), bucket); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := engine.durations[bucket.ID], 2*time.Hour; got != exp {
+		t.Fatalf("got duration %v, expected %v", got, exp)
+	}
+
+	d := time.Duration(0)
+	if _, err := service.UpdateBucket(context.TODO(), bucket.ID, platform.BucketUpdate{ShardGroupDuration: &d}); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := engine.durations[bucket.ID], time.Duration(0); got != exp {
+		t.Fatalf("got duration %v, expected %v", got, exp)
+	}
+
+	rp := 3 * time.Hour
+	if _, err := service.UpdateBucket(context.TODO(), bucket.ID, platform.BucketUpdate{RetentionPeriod: &rp}); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := engine.durations[bucket.ID], time.Hour; got != exp {
+		t.Fatalf("got duration %v, expected %v", got, exp)
+	}
+
+	if err := service.DeleteBucket(context.TODO(), bucket.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := engine.durations[bucket.ID], time.Duration(0); got != exp {
+		t.Fatalf("got duration %v, expected %v", got, exp)
+	}
+}
+
+type MockShardGroupSetter struct {
+	MockDeleter
+	durations map[platform.ID]time.Duration
+}
+
+func (m *MockShardGroupSetter) SetShardGroupDuration(orgID, bucketID platform.ID, d time.Duration) {
+	m.durations[bucketID] = d
+}
@@ -7,12 +7,14 @@ import (
 	"fmt"
 	"github.com/opentracing/opentracing-go"
 	"math"
+	"sort"
 	"sync"
 	"time"
 
 	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/logger"
 	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/estimator/hll"
 	"github.com/influxdata/influxdb/storage/wal"
 	"github.com/influxdata/influxdb/tsdb"
 	"github.com/influxdata/influxdb/tsdb/tsi1"
@@ -30,6 +32,8 @@ var timeBytes = []byte("time")
 // it's closed.
 var ErrEngineClosed = errors.New("engine is closed")
 
+var _ platform.CardinalityService = (*Engine)(nil)
+
 type Engine struct {
 	config   Config
 	path     string
@@ -44,6 +48,13 @@ type Engine struct {
 	wal               *wal.WAL
 	retentionEnforcer *retentionEnforcer
 
+	// maxSeries is the maximum number of series of the buckets with a limit,
+	// keyed by their encoded names. seriesLimitMu also serialises the creation
+	// of the series of those buckets, so that concurrent writes cannot exceed
+	// their limits.
+	seriesLimitMu sync.Mutex
+	maxSeries     map[string]int64
+
 	defaultMetricLabels prometheus.Labels
 
 	// Tracks all goroutines started by the Engine.
@@ -201,6 +212,10 @@ func (e *Engine) Open(ctx context.Context) (err error) {
 		return err
 	}
 
+	// Limit the series of the buckets before the WAL is replayed, so that the
+	// points dropped by the limits before are dropped again.
+	e.retentionEnforcer.syncSeriesLimits()
+
 	if err := e.replayWAL(); err != nil {
 		return err
 	}
@@ -249,7 +264,11 @@ func (e *Engine) replayWAL() error {
 			return err
 
 		case *wal.DeleteBucketRangeWALEntry:
-			return e.deleteBucketRangeLocked(en.OrgID, en.BucketID, en.Min, en.Max)
+			pred, err := tsm1.UnmarshalPredicate(en.Predicate)
+			if err != nil {
+				return err
+			}
+			return e.deleteBucketRangeLocked(en.OrgID, en.BucketID, en.Min, en.Max, pred)
 		}
 
 		return nil
@@ -291,6 +310,11 @@ func (e *Engine) runRetentionEnforcer() {
 	e.wg.Add(1)
 	go func() {
 		defer e.wg.Done()
+
+		// Partition the buckets into shard groups before the first check, so
+		// that their data is written to the files of their shard groups.
+		e.retentionEnforcer.syncShardGroups()
+
 		for {
 			// It's safe to read closing without a lock because it's never
 			// modified if this goroutine is active.
@@ -308,13 +332,16 @@ func (e *Engine) runRetentionEnforcer() {
 // Close closes the store and all underlying resources. It returns an error if
 // any of the underlying systems fail to close.
 func (e *Engine) Close() error {
-	e.mu.RLock()
+	// Closing under the write lock ensures no scan registers with the wait
+	// group once it is being waited on.
+	e.mu.Lock()
 	if e.closing == nil {
+		e.mu.Unlock()
 		return nil // Already closed
 	}
 
 	close(e.closing)
-	e.mu.RUnlock()
+	e.mu.Unlock()
 
 	// Wait for any other goroutines to finish.
 	e.wg.Wait()
@@ -364,31 +391,19 @@ func (e *Engine) WritePoints(ctx context.Context, points []models.Point) error {
 
 		if tags.Len() > 0 && bytes.Equal(tags[0].Key, tsdb.FieldKeyTagKeyBytes) && bytes.Equal(tags[0].Value, timeBytes) {
 			// Field key "time" is invalid
-			if collection.Reason == "" {
-				collection.Reason = fmt.Sprintf("invalid field key: input field %q is invalid", timeBytes)
-			}
-			collection.Dropped++
-			collection.DroppedKeys = append(collection.DroppedKeys, iter.Key())
+			collection.Drop(iter.Key(), fmt.Sprintf("invalid field key: input field %q is invalid", timeBytes))
 			continue
 		}
 
 		// Filter out any tags with key equal to "time": they are invalid.
 		if tags.Get(timeBytes) != nil {
-			if collection.Reason == "" {
-				collection.Reason = fmt.Sprintf("invalid tag key: input tag %q on measurement %q is invalid", timeBytes, iter.Name())
-			}
-			collection.Dropped++
-			collection.DroppedKeys = append(collection.DroppedKeys, iter.Key())
+			collection.Drop(iter.Key(), fmt.Sprintf("invalid tag key: input tag %q on measurement %q is invalid", timeBytes, iter.Name()))
 			continue
 		}
 
 		// Drop any series with invalid unicode characters in the key.
 		if e.config.ValidateKeys && !models.ValidKeyTokens(string(iter.Name()), tags) {
-			if collection.Reason == "" {
-				collection.Reason = fmt.Sprintf("key contains invalid unicode: %q", iter.Key())
-			}
-			collection.Dropped++
-			collection.DroppedKeys = append(collection.DroppedKeys, iter.Key())
+			collection.Drop(iter.Key(), fmt.Sprintf("key contains invalid unicode: %q", iter.Key()))
 			continue
 		}
 
@@ -425,7 +440,7 @@ func (e *Engine) writePointsLocked(collection *tsdb.SeriesCollection, values map
 	// but if it ever did, the errors could end up missing some data.
 
 	// Add new series to the index and series file.
-	if err := e.index.CreateSeriesListIfNotExists(collection); err != nil {
+	if err := e.createSeriesListIfNotExists(collection); err != nil {
 		return err
 	}
 
@@ -447,6 +462,84 @@ func (e *Engine) writePointsLocked(collection *tsdb.SeriesCollection, values map
 	return collection.PartialWriteError()
 }
 
+// createSeriesListIfNotExists adds the new series of the collection to the
+// index and series file. The points that would create series beyond the limits
+// of their buckets are dropped from the collection.
+func (e *Engine) createSeriesListIfNotExists(collection *tsdb.SeriesCollection) error {
+	e.seriesLimitMu.Lock()
+	if e.limitSeriesLocked(collection) {
+		defer e.seriesLimitMu.Unlock()
+	} else {
+		e.seriesLimitMu.Unlock()
+	}
+	return e.index.CreateSeriesListIfNotExists(collection)
+}
+
+// seriesAllowance is the number of series a bucket may still create.
+type seriesAllowance struct {
+	max       int64
+	remaining int64
+	created   map[string]struct{}
+}
+
+// limitSeriesLocked drops the points of the collection that would create
+// series beyond the limits of their buckets, and returns true if any of the
+// points belong to a bucket with a limit. It must be called under the
+// seriesLimitMu lock.
+func (e *Engine) limitSeriesLocked(collection *tsdb.SeriesCollection) bool {
+	if len(e.maxSeries) == 0 {
+		return false
+	}
+
+	var (
+		limited    bool
+		stats      tsi1.MeasurementCardinalityStats
+		allowances = make(map[string]*seriesAllowance)
+		j          = 0
+	)
+	for iter := collection.Iterator(); iter.Next(); {
+		name := iter.Name()
+		max, ok := e.maxSeries[string(name)]
+		if !ok {
+			collection.Copy(j, iter.Index())
+			j++
+			continue
+		}
+		limited = true
+
+		a, ok := allowances[string(name)]
+		if !ok {
+			if stats == nil {
+				stats = e.index.MeasurementCardinalityStats()
+			}
+			a = &seriesAllowance{
+				max:       max,
+				remaining: max - int64(stats[string(name)]),
+				created:   make(map[string]struct{}),
+			}
+			allowances[string(name)] = a
+		}
+
+		key := iter.Key()
+		if _, ok := a.created[string(key)]; !ok && !e.sfile.HasSeries(name, iter.Tags(), nil) {
+			if a.remaining <= 0 {
+				var encoded [16]byte
+				copy(encoded[:], name)
+				_, bucketID := tsdb.DecodeName(encoded)
+				collection.Drop(key, fmt.Sprintf("bucket %s exceeded its limit of %d series", bucketID, a.max))
+				continue
+			}
+			a.remaining--
+			a.created[string(key)] = struct{}{}
+		}
+
+		collection.Copy(j, iter.Index())
+		j++
+	}
+	collection.Truncate(j)
+	return limited
+}
+
 // AcquireSegments closes the current WAL segment, gets the set of all the currently closed
 // segments, and calls the callback. It does all of this under the lock on the engine.
 func (e *Engine) AcquireSegments(ctx context.Context, fn func(segs []string) error) error {
@@ -488,29 +581,80 @@ func (e *Engine) DeleteBucket(orgID, bucketID platform.ID) error {
 
 // DeleteBucketRange deletes an entire bucket from the storage engine.
 func (e *Engine) DeleteBucketRange(orgID, bucketID platform.ID, min, max int64) error {
+	return e.DeleteBucketRangePredicate(orgID, bucketID, min, max, nil)
+}
+
+// DeleteBucketRangePredicate deletes data in the time range of a bucket from the
+// storage engine. If pred is not nil, only series matching pred are deleted.
+func (e *Engine) DeleteBucketRangePredicate(orgID, bucketID platform.ID, min, max int64, pred platform.Predicate) error {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
 	if e.closing == nil {
 		return ErrEngineClosed
 	}
 
+	var predData []byte
+	if pred != nil {
+		var err error
+		if predData, err = pred.Marshal(); err != nil {
+			return err
+		}
+	}
+
 	// Add the delete to the WAL to be replayed if there is a crash or shutdown.
-	if _, err := e.wal.DeleteBucketRange(orgID, bucketID, min, max); err != nil {
+	if _, err := e.wal.DeleteBucketRangePredicate(orgID, bucketID, min, max, predData); err != nil {
 		return err
 	}
 
-	return e.deleteBucketRangeLocked(orgID, bucketID, min, max)
+	return e.deleteBucketRangeLocked(orgID, bucketID, min, max, pred)
 }
 
 // deleteBucketRangeLocked does the work of deleting a bucket range and must be called under
 // some sort of lock.
-func (e *Engine) deleteBucketRangeLocked(orgID, bucketID platform.ID, min, max int64) error {
+func (e *Engine) deleteBucketRangeLocked(orgID, bucketID platform.ID, min, max int64, pred platform.Predicate) error {
 	// TODO(edd): we need to clean up how we're encoding the prefix so that we
 	// don't have to remember to get it right everywhere we need to touch TSM data.
 	encoded := tsdb.EncodeName(orgID, bucketID)
 	name := models.EscapeMeasurement(encoded[:])
 
-	return e.engine.DeleteBucketRange(name, min, max)
+	return e.engine.DeleteBucketRangePredicate(name, min, max, pred)
+}
+
+// SetShardGroupDuration partitions the data of the bucket into shard groups of
+// duration d, so that expired data can be dropped a shard group at a time. A
+// duration of zero disables the partitioning of the bucket.
+func (e *Engine) SetShardGroupDuration(orgID, bucketID platform.ID, d time.Duration) {
+	encoded := tsdb.EncodeName(orgID, bucketID)
+	name := models.EscapeMeasurement(encoded[:])
+	e.engine.SetShardGroupDuration(name, d)
+}
+
+// SetBucketMaxSeries limits the number of series of the bucket to n. Points
+// that would create series beyond the limit are dropped. A limit of zero
+// removes the limit of the bucket.
+func (e *Engine) SetBucketMaxSeries(orgID, bucketID platform.ID, n int64) {
+	encoded := tsdb.EncodeName(orgID, bucketID)
+
+	e.seriesLimitMu.Lock()
+	defer e.seriesLimitMu.Unlock()
+	if n <= 0 {
+		delete(e.maxSeries, string(encoded[:]))
+		return
+	}
+	if e.maxSeries == nil {
+		e.maxSeries = make(map[string]int64)
+	}
+	e.maxSeries[string(encoded[:])] = n
+}
+
+// BucketMaxSeries returns the maximum number of series of the bucket, or zero
+// if the series of the bucket are not limited.
+func (e *Engine) BucketMaxSeries(orgID, bucketID platform.ID) int64 {
+	encoded := tsdb.EncodeName(orgID, bucketID)
+
+	e.seriesLimitMu.Lock()
+	defer e.seriesLimitMu.Unlock()
+	return e.maxSeries[string(encoded[:])]
 }
 
 // SeriesCardinality returns the number of series in the engine.
@@ -548,3 +692,137 @@ func (e *Engine) MeasurementCardinalityStats() tsi1.MeasurementCardinalityStats
 func (e *Engine) MeasurementStats() (tsm1.MeasurementStats, error) {
 	return e.engine.MeasurementStats()
 }
+
+// OrganizationSeriesCardinality returns the number of series in the buckets
+// of the organization.
+func (e *Engine) OrganizationSeriesCardinality(orgID platform.ID) int64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return 0
+	}
+
+	var n int64
+	for name, card := range e.index.MeasurementCardinalityStats() {
+		if len(name) != 16 {
+			continue
+		}
+		var encoded [16]byte
+		copy(encoded[:], name)
+		if org, _ := tsdb.DecodeName(encoded); org == orgID {
+			n += int64(card)
+		}
+	}
+	return n
+}
+
+// BucketSeriesN returns the number of series in the bucket.
+func (e *Engine) BucketSeriesN(orgID, bucketID platform.ID) int64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return 0
+	}
+
+	encoded := tsdb.EncodeName(orgID, bucketID)
+	return int64(e.index.MeasurementCardinalityStats()[string(encoded[:])])
+}
+
+// BucketCardinality returns the number of series of the bucket, and the
+// estimated number of series of each of its measurements.
+//
+// The series of the bucket are scanned without holding the lock of the
+// engine, so that the scan does not hold up writes, deletes and backups.
+// Closing the engine stops the scan instead.
+func (e *Engine) BucketCardinality(ctx context.Context, orgID, bucketID platform.ID) (*platform.BucketCardinality, error) {
+	e.mu.RLock()
+	if e.closing == nil {
+		e.mu.RUnlock()
+		return nil, ErrEngineClosed
+	}
+	closing, index, sfile := e.closing, e.index, e.sfile
+	select {
+	case <-closing:
+		e.mu.RUnlock()
+		return nil, ErrEngineClosed
+	default:
+	}
+	e.wg.Add(1)
+	e.mu.RUnlock()
+	defer e.wg.Done()
+
+	encoded := tsdb.EncodeName(orgID, bucketID)
+	name := encoded[:]
+	c := &platform.BucketCardinality{
+		OrganizationID: orgID,
+		BucketID:       bucketID,
+		Series:         int64(index.MeasurementCardinalityStats()[string(name)]),
+		MaxSeries:      e.BucketMaxSeries(orgID, bucketID),
+		Measurements:   []platform.MeasurementCardinality{},
+	}
+
+	itr, err := index.MeasurementSeriesIDIterator(name)
+	if err != nil {
+		return nil, err
+	} else if itr == nil {
+		return c, nil
+	}
+	defer itr.Close()
+
+	sketches := make(map[string]*hll.Plus)
+	for {
+		select {
+		case <-closing:
+			return nil, ErrEngineClosed
+		default:
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		elem, err := itr.Next()
+		if err != nil {
+			return nil, err
+		} else if elem.SeriesID.IsZero() {
+			break
+		}
+
+		key := sfile.SeriesKey(elem.SeriesID)
+		if key == nil {
+			continue
+		}
+		_, tags := tsdb.ParseSeriesKey(key)
+		m := string(tags.Get(tsdb.MeasurementTagKeyBytes))
+
+		sketch, ok := sketches[m]
+		if !ok {
+			sketch = hll.NewDefaultPlus()
+			sketches[m] = sketch
+		}
+		sketch.Add(key)
+	}
+
+	for m, sketch := range sketches {
+		c.Measurements = append(c.Measurements, platform.MeasurementCardinality{
+			Name:   m,
+			Series: int64(sketch.Count()),
+		})
+	}
+	sort.Slice(c.Measurements, func(i, j int) bool {
+		if c.Measurements[i].Series != c.Measurements[j].Series {
+			return c.Measurements[i].Series > c.Measurements[j].Series
+		}
+		return c.Measurements[i].Name < c.Measurements[j].Name
+	})
+	return c, nil
+}
+
+// HasSeries returns true if the engine contains the series of the name and tags.
+func (e *Engine) HasSeries(name []byte, tags models.Tags) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return false
+	}
+	return e.sfile.HasSeries(name, tags, nil)
+}
@@ -0,0 +1,409 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/tsdb/tsm1"
+	"github.com/opentracing/opentracing-go"
+)
+
+// backupLinksDirectoryName is the directory of a backup holding the links to
+// the files of the index and series file while they are copied.
+const backupLinksDirectoryName = "links"
+
+// CreateBackup creates a point-in-time backup of the data in the engine:
+//
+//   1) The cache is snapshotted, so that the backup includes the data written before now.
+//   2) Hard links to the TSM files are created in a new directory within the engine path.
+//      When since is not zero, only the TSM files modified after since are linked.
+//   3) The names of all of the TSM files, linked or not, are written to the
+//      manifest of the backup.
+//   4) Hard links to the files of the index and series file are created, and
+//      their sizes recorded, while writes are blocked.
+//   5) The index and series file are copied from the links into the index and
+//      _series subdirectories of the backup, once writes resume. Only the
+//      bytes of each file up to its size when it was linked are copied.
+//
+// It returns the ID of the backup, which is invalid once the process exits,
+// and the paths of its files relative to the backup directory.
+func (e *Engine) CreateBackup(ctx context.Context, since time.Time) (int, []string, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "Engine.CreateBackup")
+	defer span.Finish()
+
+	if err := e.engine.WriteSnapshot(ctx); err != nil {
+		return 0, nil, err
+	}
+
+	// Ensure the index and series file are not compacted while they are copied.
+	e.index.DisableCompactions()
+	defer e.index.EnableCompactions()
+	e.index.Wait()
+
+	e.sfile.DisableCompactions()
+	defer e.sfile.EnableCompactions()
+
+	path, index, sfile, err := e.linkBackup(ctx, since)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	id, err := strconv.Atoi(strings.TrimSuffix(filepath.Base(path), "."+tsm1.TmpTSMFileExtension))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if err := index.copyTo(filepath.Join(path, DefaultIndexDirectoryName)); err != nil {
+		return 0, nil, err
+	}
+	if err := sfile.copyTo(filepath.Join(path, DefaultSeriesFileDirectoryName)); err != nil {
+		return 0, nil, err
+	}
+	if err := os.Remove(filepath.Join(path, backupLinksDirectoryName)); err != nil {
+		return 0, nil, err
+	}
+
+	files, err := backupFiles(path)
+	if err != nil {
+		return 0, nil, err
+	}
+	return id, files, nil
+}
+
+// linkBackup creates the directory of a backup with the links to the TSM
+// files modified after since and its manifest, and links the files of the
+// index and series file to be copied into it. Writes and deletes are blocked
+// meanwhile, so that the TSM files, index and series file are consistent with
+// each other.
+func (e *Engine) linkBackup(ctx context.Context, since time.Time) (path string, index, sfile *dirSnapshot, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closing == nil {
+		return "", nil, nil, ErrEngineClosed
+	}
+
+	path, names, err := e.engine.FileStore.CreateSnapshotSince(ctx, since)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	if err := writeBackupManifest(filepath.Join(path, platform.BackupManifestFile), names); err != nil {
+		return "", nil, nil, err
+	}
+
+	if index, err = snapshotDir(e.index.Path(), filepath.Join(path, backupLinksDirectoryName, DefaultIndexDirectoryName)); err != nil {
+		return "", nil, nil, err
+	}
+	if sfile, err = snapshotDir(e.sfile.Path(), filepath.Join(path, backupLinksDirectoryName, DefaultSeriesFileDirectoryName)); err != nil {
+		return "", nil, nil, err
+	}
+	return path, index, sfile, nil
+}
+
+// FetchBackupFile writes the contents of a file of the backup to w.
+func (e *Engine) FetchBackupFile(ctx context.Context, backupID int, backupFile string, w io.Writer) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "Engine.FetchBackupFile")
+	defer span.Finish()
+
+	name := filepath.Clean(filepath.FromSlash(backupFile))
+	if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Op:   platform.OpFetchBackupFile,
+			Msg:  fmt.Sprintf("invalid backup file %q", backupFile),
+		}
+	}
+
+	f, err := os.Open(filepath.Join(e.InternalBackupPath(backupID), name))
+	if os.IsNotExist(err) {
+		return &platform.Error{
+			Code: platform.ENotFound,
+			Op:   platform.OpFetchBackupFile,
+			Msg:  fmt.Sprintf("backup file %q not found", backupFile),
+		}
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// RemoveBackup removes the backup and its files.
+func (e *Engine) RemoveBackup(ctx context.Context, backupID int) error {
+	path := e.InternalBackupPath(backupID)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return &platform.Error{
+			Code: platform.ENotFound,
+			Op:   platform.OpRemoveBackup,
+			Msg:  fmt.Sprintf("backup %d not found", backupID),
+		}
+	}
+	return os.RemoveAll(path)
+}
+
+// InternalBackupPath returns the directory of the files of the backup. The
+// directory is removed when the engine is next opened.
+func (e *Engine) InternalBackupPath(backupID int) string {
+	return filepath.Join(e.engine.Path(), fmt.Sprintf("%d.%s", backupID, tsm1.TmpTSMFileExtension))
+}
+
+// RestoreBackup restores the backups in dirs, which hold the files of backups
+// created by CreateBackup ordered from the oldest to the newest, into a new
+// engine at path. Only the TSM files listed in the manifest of the newest
+// backup are restored, each from the newest backup that holds it, so that
+// incremental backups can be restored on top of a full backup without
+// restoring the files that were compacted or deleted in between. The index
+// and series file are restored from the newest backup. The engine directories
+// must not exist or be empty.
+func RestoreBackup(path string, c Config, dirs ...string) error {
+	if len(dirs) == 0 {
+		return fmt.Errorf("no backup to restore")
+	}
+
+	last := dirs[len(dirs)-1]
+	names, err := readBackupManifest(filepath.Join(last, platform.BackupManifestFile))
+	if err != nil {
+		return err
+	}
+
+	enginePath := c.GetEnginePath(path)
+	indexPath := c.GetIndexPath(path)
+	sfilePath := c.GetSeriesFilePath(path)
+	for _, p := range []string{enginePath, indexPath, sfilePath, c.GetWALPath(path)} {
+		if err := ensureEmptyDir(p); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range names {
+		src, err := findBackupFile(name, dirs)
+		if err != nil {
+			return err
+		}
+		if err := copyFile(src, filepath.Join(enginePath, name)); err != nil {
+			return err
+		}
+	}
+
+	if err := copyDir(filepath.Join(last, DefaultIndexDirectoryName), indexPath); err != nil {
+		return err
+	}
+	return copyDir(filepath.Join(last, DefaultSeriesFileDirectoryName), sfilePath)
+}
+
+// writeBackupManifest writes the names of the TSM files of a backup to the
+// manifest at path.
+func writeBackupManifest(path string, names []string) error {
+	b, err := json.Marshal(backupManifest{Files: names})
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0666)
+}
+
+// readBackupManifest returns the names of the TSM files of the manifest at path.
+func readBackupManifest(path string) ([]string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read backup manifest: %v", err)
+	}
+
+	var m backupManifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("invalid backup manifest %s: %v", path, err)
+	}
+	for _, name := range m.Files {
+		if name != filepath.Base(name) || name == "." || name == ".." {
+			return nil, fmt.Errorf("invalid file %q in backup manifest %s", name, path)
+		}
+	}
+	return m.Files, nil
+}
+
+// backupManifest lists the TSM and tombstone files of the engine at the time
+// of a backup, including those of earlier backups.
+type backupManifest struct {
+	Files []string `json:"files"`
+}
+
+// findBackupFile returns the path of the file name in the newest of dirs
+// that holds it, since incremental backups may hold newer versions of the
+// tombstone files of a TSM file.
+func findBackupFile(name string, dirs []string) (string, error) {
+	for i := len(dirs) - 1; i >= 0; i-- {
+		path := filepath.Join(dirs[i], name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		} else if !os.IsNotExist(err) {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("file %s of the newest backup is missing from the backups", name)
+}
+
+// ensureEmptyDir creates the directory path if it does not exist, and returns
+// an error if it exists and is not empty.
+func ensureEmptyDir(path string) error {
+	fis, err := ioutil.ReadDir(path)
+	if os.IsNotExist(err) {
+		return os.MkdirAll(path, 0777)
+	} else if err != nil {
+		return err
+	} else if len(fis) > 0 {
+		return fmt.Errorf("cannot restore into %s: directory is not empty", path)
+	}
+	return nil
+}
+
+// backupFiles returns the paths of the files in the backup directory,
+// relative to it and in slash-separated form.
+func backupFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	return files, err
+}
+
+// copyDir copies the regular files of the directory src, and of its
+// subdirectories, into dst.
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, 0777)
+		} else if !info.Mode().IsRegular() {
+			return nil
+		}
+		return copyFile(path, target)
+	})
+}
+
+// dirSnapshot holds hard links to the files of a directory, and their sizes
+// at the time they were linked, so that the directory can be copied as it
+// was while it changes. The files are only appended to, or replaced by new
+// files, while compactions are disabled.
+type dirSnapshot struct {
+	dir   string           // The directory of the links.
+	sizes map[string]int64 // The size of each file, by path relative to dir.
+}
+
+// snapshotDir links the regular files of the directory src, and of its
+// subdirectories, into dir. The files are copied if they cannot be linked,
+// such as when they are on another device.
+func snapshotDir(src, dir string) (*dirSnapshot, error) {
+	s := &dirSnapshot{dir: dir, sizes: make(map[string]int64)}
+	err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, 0777)
+		} else if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		if err := os.Link(path, target); err != nil {
+			if err := copyFile(path, target); err != nil {
+				return err
+			}
+		}
+		s.sizes[rel] = info.Size()
+		return nil
+	})
+	return s, err
+}
+
+// copyTo copies the files of the snapshot into dst, as they were when they
+// were linked, and removes the links.
+func (s *dirSnapshot) copyTo(dst string) error {
+	err := filepath.Walk(s.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, 0777)
+		}
+		return copyFileN(path, target, s.sizes[rel])
+	})
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(s.dir)
+}
+
+func copyFile(src, dst string) error {
+	return copyFileN(src, dst, -1)
+}
+
+// copyFileN copies the first n bytes of the file src into dst, or all of it
+// if n is negative.
+func copyFileN(src, dst string, n int64) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader = in
+	if n >= 0 {
+		r = io.LimitReader(in, n)
+	}
+	if _, err := io.Copy(out, r); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDirSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "storage_dir_snapshot_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	src := filepath.Join(dir, "src")
+	if err := os.MkdirAll(filepath.Join(src, "sub"), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(src, "sub", "log"), []byte("entry 1\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := snapshotDir(src, filepath.Join(dir, "links"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The entries appended once the directory is snapshotted are not copied.
+	f, err := os.OpenFile(filepath.Join(src, "sub", "log"), os.O_APPEND|os.O_WRONLY, 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte("entry 2\n")); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	if err := s.copyTo(filepath.Join(dir, "dst")); err != nil {
+		t.Fatal(err)
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dir, "dst", "sub", "log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), "entry 1\n"; got != want {
+		t.Errorf("unexpected copy: got %q, want %q", got, want)
+	}
+
+	// The original is left as is, and the links are removed.
+	if b, err := ioutil.ReadFile(filepath.Join(src, "sub", "log")); err != nil || string(b) != "entry 1\nentry 2\n" {
+		t.Errorf("unexpected original: %q, %v", b, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "links")); !os.IsNotExist(err) {
+		t.Errorf("expected the links to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/storage"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+)
+
+func TestEngine_BackupRestore(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	write := func(host string, ts int64) {
+		t.Helper()
+		pt := models.MustNewPoint(
+			"cpu",
+			models.NewTags(map[string]string{"host": host}),
+			map[string]interface{}{"value": 1.0},
+			time.Unix(0, ts),
+		)
+		if err := engine.Write1xPoints([]models.Point{pt}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fetch := func(since time.Time) string {
+		t.Helper()
+		return fetchBackup(t, engine, since)
+	}
+
+	write("a", 1)
+	full := fetch(time.Time{})
+	defer os.RemoveAll(full)
+
+	since := time.Now()
+	time.Sleep(50 * time.Millisecond)
+
+	write("b", 2)
+	incr := fetch(since)
+	defer os.RemoveAll(incr)
+
+	if err := engine.FetchBackupFile(context.Background(), 1, "../../secret", ioutil.Discard); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid backup file error, got %v", err)
+	}
+
+	// Restore the full and the incremental backup into a new engine.
+	path, err := ioutil.TempDir("", "storage_restore_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+
+	if err := storage.RestoreBackup(path, storage.NewConfig(), full, incr); err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.RestoreBackup(path, storage.NewConfig(), full); err == nil {
+		t.Fatal("expected error restoring into a non-empty directory")
+	}
+
+	restored := storage.NewEngine(path, storage.NewConfig())
+	if err := restored.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer restored.Close()
+
+	if got, exp := restored.SeriesCardinality(), int64(2); got != exp {
+		t.Fatalf("got %d series, exp %d series in index", got, exp)
+	}
+
+	itr, err := restored.TagValues(context.Background(), engine.org, engine.bucket, "host", math.MinInt64+1, math.MaxInt64-1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := cursors.StringIteratorToSlice(itr), []string{"a", "b"}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected hosts with data: got %v, exp %v", got, exp)
+	}
+}
+
+// TestEngine_BackupRestore_Delete checks that the data deleted between a full
+// and an incremental backup is not restored.
+func TestEngine_BackupRestore_Delete(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	write := func(host string, ts int64) {
+		t.Helper()
+		pt := models.MustNewPoint(
+			"cpu",
+			models.NewTags(map[string]string{"host": host}),
+			map[string]interface{}{"value": 1.0},
+			time.Unix(0, ts),
+		)
+		if err := engine.Write1xPoints([]models.Point{pt}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("a", 1)
+	full := fetchBackup(t, engine, time.Time{})
+	defer os.RemoveAll(full)
+
+	since := time.Now()
+	time.Sleep(50 * time.Millisecond)
+
+	// The TSM file of the full backup only holds data of the bucket, so it is
+	// removed whole by the delete.
+	if err := engine.DeleteBucket(engine.org, engine.bucket); err != nil {
+		t.Fatal(err)
+	}
+	write("b", 2)
+	incr := fetchBackup(t, engine, since)
+	defer os.RemoveAll(incr)
+
+	path, err := ioutil.TempDir("", "storage_restore_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+
+	if err := storage.RestoreBackup(path, storage.NewConfig(), full, incr); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the TSM file of the incremental backup is restored.
+	tsmFiles := func(dir string) []string {
+		t.Helper()
+		files, err := filepath.Glob(filepath.Join(dir, "*.tsm"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range files {
+			files[i] = filepath.Base(files[i])
+		}
+		return files
+	}
+	if got, exp := tsmFiles(storage.NewConfig().GetEnginePath(path)), tsmFiles(incr); len(exp) != 1 || !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected restored TSM files: got %v, exp %v", got, exp)
+	}
+
+	restored := storage.NewEngine(path, storage.NewConfig())
+	if err := restored.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer restored.Close()
+
+	itr, err := restored.TagValues(context.Background(), engine.org, engine.bucket, "host", math.MinInt64+1, math.MaxInt64-1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := cursors.StringIteratorToSlice(itr), []string{"b"}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected hosts with data: got %v, exp %v", got, exp)
+	}
+}
+
+// fetchBackup creates a backup of the engine and fetches its files into a
+// new directory.
+func fetchBackup(t *testing.T, engine *Engine, since time.Time) string {
+	t.Helper()
+	id, files, err := engine.CreateBackup(context.Background(), since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer engine.RemoveBackup(context.Background(), id)
+
+	dir, err := ioutil.TempDir("", "storage_backup_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		var buf bytes.Buffer
+		if err := engine.FetchBackupFile(context.Background(), id, file, &buf); err != nil {
+			t.Fatal(err)
+		}
+
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, buf.Bytes(), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
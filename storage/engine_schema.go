@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"context"
+	"sort"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+	"github.com/influxdata/influxql"
+)
+
+// TagKeys returns an iterator over the sorted tag keys of the series in the
+// bucket that match predicate and have data within the time range [start, end].
+func (e *Engine) TagKeys(ctx context.Context, orgID, bucketID influxdb.ID, start, end int64, predicate influxql.Expr) (cursors.StringIterator, error) {
+	keys := make(map[string]struct{})
+	err := e.forEachSeries(ctx, orgID, bucketID, start, end, predicate, func(_ tsdb.SeriesIDTyped, tags models.Tags) error {
+		for _, tag := range tags {
+			keys[string(tag.Key)] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cursors.NewStringSliceIterator(sortedKeys(keys)), nil
+}
+
+// TagValues returns an iterator over the sorted values of tagKey for the
+// series in the bucket that match predicate and have data within the time
+// range [start, end].
+func (e *Engine) TagValues(ctx context.Context, orgID, bucketID influxdb.ID, tagKey string, start, end int64, predicate influxql.Expr) (cursors.StringIterator, error) {
+	key := []byte(tagKey)
+	values := make(map[string]struct{})
+	err := e.forEachSeries(ctx, orgID, bucketID, start, end, predicate, func(_ tsdb.SeriesIDTyped, tags models.Tags) error {
+		if v := tags.Get(key); len(v) > 0 {
+			values[string(v)] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cursors.NewStringSliceIterator(sortedKeys(values)), nil
+}
+
+// MeasurementNames returns an iterator over the sorted measurement names of
+// the series in the bucket that match predicate and have data within the time
+// range [start, end].
+func (e *Engine) MeasurementNames(ctx context.Context, orgID, bucketID influxdb.ID, start, end int64, predicate influxql.Expr) (cursors.StringIterator, error) {
+	return e.TagValues(ctx, orgID, bucketID, tsdb.MeasurementTagKey, start, end, predicate)
+}
+
+// MeasurementFields returns the fields and their types for the series of
+// measurement that match predicate and have data within the time range
+// [start, end]. The fields are sorted by key.
+func (e *Engine) MeasurementFields(ctx context.Context, orgID, bucketID influxdb.ID, measurement string, start, end int64, predicate influxql.Expr) ([]cursors.MeasurementField, error) {
+	cond := influxql.Expr(&influxql.BinaryExpr{
+		Op:  influxql.EQ,
+		LHS: &influxql.VarRef{Val: tsdb.MeasurementTagKey},
+		RHS: &influxql.StringLiteral{Val: measurement},
+	})
+	if predicate != nil {
+		cond = &influxql.BinaryExpr{Op: influxql.AND, LHS: cond, RHS: predicate}
+	}
+
+	fields := make(map[string]models.FieldType)
+	err := e.forEachSeries(ctx, orgID, bucketID, start, end, cond, func(id tsdb.SeriesIDTyped, tags models.Tags) error {
+		if f := tags.Get(tsdb.FieldKeyTagKeyBytes); len(f) > 0 {
+			fields[string(f)] = id.Type()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a := make([]cursors.MeasurementField, 0, len(fields))
+	for k, typ := range fields {
+		a = append(a, cursors.MeasurementField{Key: k, Type: typ})
+	}
+	sort.Slice(a, func(i, j int) bool { return a[i].Key < a[j].Key })
+	return a, nil
+}
+
+// BucketSeriesCardinality returns the number of series in the bucket that
+// match predicate and have data within the time range [start, end].
+func (e *Engine) BucketSeriesCardinality(ctx context.Context, orgID, bucketID influxdb.ID, start, end int64, predicate influxql.Expr) (int64, error) {
+	var n int64
+	err := e.forEachSeries(ctx, orgID, bucketID, start, end, predicate, func(tsdb.SeriesIDTyped, models.Tags) error {
+		n++
+		return nil
+	})
+	return n, err
+}
+
+// forEachSeries calls fn with the typed series ID and tags of each series in
+// the bucket that matches predicate. When the time range does not span all
+// time, series without data in [start, end] are skipped.
+func (e *Engine) forEachSeries(ctx context.Context, orgID, bucketID influxdb.ID, start, end int64, predicate influxql.Expr, fn func(id tsdb.SeriesIDTyped, tags models.Tags) error) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closing == nil {
+		return ErrEngineClosed
+	}
+
+	indexref, err := e.index.Acquire()
+	if err != nil {
+		return err
+	}
+	defer indexref.Release()
+
+	sfileref, err := e.sfile.Acquire()
+	if err != nil {
+		return err
+	}
+	defer sfileref.Release()
+
+	name := tsdb.EncodeName(orgID, bucketID)
+	sitr, err := e.index.MeasurementSeriesByExprIterator(name[:], predicate)
+	if err != nil {
+		return err
+	} else if sitr == nil {
+		return nil
+	}
+	defer sitr.Close()
+
+	allTime := start <= models.MinNanoTime && end >= models.MaxNanoTime
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		elem, err := sitr.Next()
+		if err != nil {
+			return err
+		} else if elem.SeriesID.IsZero() {
+			return nil
+		}
+
+		key := e.sfile.SeriesKey(elem.SeriesID)
+		if len(key) == 0 {
+			continue
+		}
+		sname, tags := tsdb.ParseSeriesKey(key)
+
+		if !allTime {
+			field := string(tags.Get(tsdb.FieldKeyTagKeyBytes))
+			if ok, err := e.engine.HasDataInRange(sname, tags, field, start, end); err != nil {
+				return err
+			} else if !ok {
+				continue
+			}
+		}
+
+		if err := fn(e.sfile.SeriesIDTypedBySeriesKey(key), tags); err != nil {
+			return err
+		}
+	}
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	a := make([]string, 0, len(m))
+	for k := range m {
+		a = append(a, k)
+	}
+	sort.Strings(a)
+	return a
+}
@@ -9,10 +9,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/storage"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
 	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+	"github.com/influxdata/influxdb/tsdb/tsm1"
+	"github.com/influxdata/influxql"
 )
 
 func TestEngine_WriteAndIndex(t *testing.T) {
@@ -132,7 +137,7 @@ func TestEngine_WriteAddNewField(t *testing.T) {
 
 	err := engine.Write1xPoints([]models.Point{pt})
 	if err != nil {
-		t.Fatalf(err.Error())
+		t.Fatal(err)
 	}
 
 	pt = models.MustNewPoint(
@@ -144,7 +149,7 @@ func TestEngine_WriteAddNewField(t *testing.T) {
 
 	err = engine.Write1xPoints([]models.Point{pt})
 	if err != nil {
-		t.Fatalf(err.Error())
+		t.Fatal(err)
 	}
 
 	if got, exp := engine.SeriesCardinality(), int64(2); got != exp {
@@ -166,7 +171,7 @@ func TestEngine_DeleteBucket(t *testing.T) {
 
 	err := engine.Write1xPoints([]models.Point{pt})
 	if err != nil {
-		t.Fatalf(err.Error())
+		t.Fatal(err)
 	}
 
 	pt = models.MustNewPoint(
@@ -179,7 +184,7 @@ func TestEngine_DeleteBucket(t *testing.T) {
 	// Same org, different bucket.
 	err = engine.Write1xPointsWithOrgBucket([]models.Point{pt}, "3131313131313131", "8888888888888888")
 	if err != nil {
-		t.Fatalf(err.Error())
+		t.Fatal(err)
 	}
 
 	if got, exp := engine.SeriesCardinality(), int64(3); got != exp {
@@ -197,6 +202,312 @@ func TestEngine_DeleteBucket(t *testing.T) {
 	}
 }
 
+func TestEngine_OrganizationSeriesCardinality(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	pt := models.MustNewPoint(
+		"cpu",
+		models.NewTags(map[string]string{"host": "server"}),
+		map[string]interface{}{"value": 1.0, "value2": 2.0},
+		time.Unix(1, 2),
+	)
+	if err := engine.Write1xPoints([]models.Point{pt}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Same org, different bucket.
+	if err := engine.Write1xPointsWithOrgBucket([]models.Point{pt}, "3131313131313131", "8888888888888888"); err != nil {
+		t.Fatal(err)
+	}
+	// Different org.
+	if err := engine.Write1xPointsWithOrgBucket([]models.Point{pt}, "9999999999999999", "8888888888888888"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, exp := engine.OrganizationSeriesCardinality(engine.org), int64(4); got != exp {
+		t.Fatalf("got %d series, exp %d series in organization", got, exp)
+	}
+
+	points, err := tsdb.ExplodePoints(engine.org, engine.bucket, []models.Point{pt})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !engine.HasSeries(points[0].Name(), points[0].Tags()) {
+		t.Fatal("expected series to exist")
+	}
+	points, err = tsdb.ExplodePoints(engine.org, influxdb.ID(1), []models.Point{pt})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if engine.HasSeries(points[0].Name(), points[0].Tags()) {
+		t.Fatal("expected series not to exist")
+	}
+}
+
+func TestEngine_BucketMaxSeries(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	engine.SetBucketMaxSeries(engine.org, engine.bucket, 3)
+
+	pt := func(host string) models.Point {
+		return models.MustNewPoint(
+			"cpu",
+			models.NewTags(map[string]string{"host": host}),
+			map[string]interface{}{"value": 1.0},
+			time.Unix(1, 2),
+		)
+	}
+	if err := engine.Write1xPoints([]models.Point{pt("a"), pt("b")}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only one new series may be created; existing series are still written.
+	err := engine.Write1xPoints([]models.Point{pt("a"), pt("c"), pt("d"), pt("e")})
+	pwe, ok := err.(tsdb.PartialWriteError)
+	if !ok {
+		t.Fatalf("got error %v, expected partial write error", err)
+	}
+	if got, exp := pwe.Dropped, 2; got != exp {
+		t.Fatalf("got %d dropped points, expected %d", got, exp)
+	}
+	if got, exp := pwe.Reason, fmt.Sprintf("bucket %s exceeded its limit of 3 series", engine.bucket); got != exp {
+		t.Fatalf("got reason %q, expected %q", got, exp)
+	}
+	if got, exp := engine.SeriesCardinality(), int64(3); got != exp {
+		t.Fatalf("got %d series, expected %d", got, exp)
+	}
+
+	// Other buckets are not limited.
+	if err := engine.Write1xPointsWithOrgBucket([]models.Point{pt("c"), pt("d")}, "3131313131313131", "8888888888888888"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Removing the limit allows new series again.
+	engine.SetBucketMaxSeries(engine.org, engine.bucket, 0)
+	if err := engine.Write1xPoints([]models.Point{pt("e")}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEngine_BucketCardinality(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	engine.SetBucketMaxSeries(engine.org, engine.bucket, 100)
+
+	var pts []models.Point
+	for i := 0; i < 10; i++ {
+		pts = append(pts, models.MustNewPoint(
+			"cpu",
+			models.NewTags(map[string]string{"host": fmt.Sprintf("server%d", i)}),
+			map[string]interface{}{"value": 1.0},
+			time.Unix(1, 2),
+		))
+	}
+	pts = append(pts, models.MustNewPoint(
+		"mem",
+		models.NewTags(map[string]string{"host": "server0"}),
+		map[string]interface{}{"free": 1.0, "used": 2.0},
+		time.Unix(1, 2),
+	))
+	if err := engine.Write1xPoints(pts); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := engine.BucketCardinality(context.Background(), engine.org, engine.bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := &influxdb.BucketCardinality{
+		OrganizationID: engine.org,
+		BucketID:       engine.bucket,
+		Series:         12,
+		MaxSeries:      100,
+		Measurements: []influxdb.MeasurementCardinality{
+			{Name: "cpu", Series: 10},
+			{Name: "mem", Series: 2},
+		},
+	}
+	if !cmp.Equal(c, exp) {
+		t.Fatalf("unexpected cardinality -got/+exp\n%s", cmp.Diff(c, exp))
+	}
+
+	// The engine can be closed while the series are scanned.
+	errC := make(chan error, 10)
+	for i := 0; i < cap(errC); i++ {
+		go func() {
+			_, scanErr := engine.BucketCardinality(context.Background(), engine.org, engine.bucket)
+			errC <- scanErr
+		}()
+	}
+	if err := engine.Engine.Close(); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < cap(errC); i++ {
+		if err := <-errC; err != nil && err != storage.ErrEngineClosed {
+			t.Fatalf("unexpected error scanning a closing engine: %v", err)
+		}
+	}
+	if _, err := engine.BucketCardinality(context.Background(), engine.org, engine.bucket); err != storage.ErrEngineClosed {
+		t.Fatalf("expected the closed engine to be reported, got %v", err)
+	}
+}
+
+func TestEngine_DeleteBucketRangePredicate(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	pts := []models.Point{
+		models.MustNewPoint(
+			"cpu",
+			models.NewTags(map[string]string{"host": "bad-box"}),
+			map[string]interface{}{"value": 1.0},
+			time.Unix(1, 2),
+		),
+		models.MustNewPoint(
+			"cpu",
+			models.NewTags(map[string]string{"host": "good-box"}),
+			map[string]interface{}{"value": 1.0},
+			time.Unix(1, 2),
+		),
+	}
+
+	if err := engine.Write1xPoints(pts); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, exp := engine.SeriesCardinality(), int64(2); got != exp {
+		t.Fatalf("got %d series, exp %d series in index", got, exp)
+	}
+
+	pred, err := tsm1.NewProtobufPredicate(&datatypes.Predicate{
+		Root: &datatypes.Node{
+			NodeType: datatypes.NodeTypeComparisonExpression,
+			Value:    &datatypes.Node_Comparison_{Comparison: datatypes.ComparisonEqual},
+			Children: []*datatypes.Node{
+				{NodeType: datatypes.NodeTypeTagRef, Value: &datatypes.Node_TagRefValue{TagRefValue: "host"}},
+				{NodeType: datatypes.NodeTypeLiteral, Value: &datatypes.Node_StringValue{StringValue: "bad-box"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := engine.DeleteBucketRangePredicate(engine.org, engine.bucket, math.MinInt64, math.MaxInt64, pred); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, exp := engine.SeriesCardinality(), int64(1); got != exp {
+		t.Fatalf("got %d series, exp %d series in index", got, exp)
+	}
+
+	// Replaying the WAL must apply the delete again.
+	engine.Engine.Close() // Don't remove the data
+	engine.MustOpen()
+
+	if got, exp := engine.SeriesCardinality(), int64(1); got != exp {
+		t.Fatalf("got %d series after reopen, exp %d series in index", got, exp)
+	}
+}
+
+func TestEngine_Schema(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	pts := []models.Point{
+		models.MustNewPoint(
+			"cpu",
+			models.NewTags(map[string]string{"host": "a"}),
+			map[string]interface{}{"value": 1.0},
+			time.Unix(0, 10),
+		),
+		models.MustNewPoint(
+			"cpu",
+			models.NewTags(map[string]string{"host": "b", "region": "west"}),
+			map[string]interface{}{"value": 2.0, "count": int64(3)},
+			time.Unix(0, 20),
+		),
+		models.MustNewPoint(
+			"mem",
+			models.NewTags(map[string]string{"host": "a"}),
+			map[string]interface{}{"free": int64(10)},
+			time.Unix(0, 30),
+		),
+	}
+	if err := engine.Write1xPoints(pts); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	hostB := &influxql.BinaryExpr{
+		Op:  influxql.EQ,
+		LHS: &influxql.VarRef{Val: "host"},
+		RHS: &influxql.StringLiteral{Val: "b"},
+	}
+
+	t.Run("tag keys", func(t *testing.T) {
+		itr, err := engine.TagKeys(ctx, engine.org, engine.bucket, models.MinNanoTime, models.MaxNanoTime, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, exp := cursors.StringIteratorToSlice(itr), []string{"_f", "_m", "host", "region"}; !cmp.Equal(got, exp) {
+			t.Errorf("unexpected tag keys; -got/+exp\n%s", cmp.Diff(got, exp))
+		}
+	})
+
+	t.Run("tag values in range", func(t *testing.T) {
+		itr, err := engine.TagValues(ctx, engine.org, engine.bucket, "host", 25, 40, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, exp := cursors.StringIteratorToSlice(itr), []string{"a"}; !cmp.Equal(got, exp) {
+			t.Errorf("unexpected tag values; -got/+exp\n%s", cmp.Diff(got, exp))
+		}
+	})
+
+	t.Run("measurement names with predicate", func(t *testing.T) {
+		itr, err := engine.MeasurementNames(ctx, engine.org, engine.bucket, models.MinNanoTime, models.MaxNanoTime, hostB)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, exp := cursors.StringIteratorToSlice(itr), []string{"cpu"}; !cmp.Equal(got, exp) {
+			t.Errorf("unexpected measurement names; -got/+exp\n%s", cmp.Diff(got, exp))
+		}
+	})
+
+	t.Run("measurement fields", func(t *testing.T) {
+		got, err := engine.MeasurementFields(ctx, engine.org, engine.bucket, "cpu", models.MinNanoTime, models.MaxNanoTime, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp := []cursors.MeasurementField{
+			{Key: "count", Type: models.Integer},
+			{Key: "value", Type: models.Float},
+		}
+		if !cmp.Equal(got, exp) {
+			t.Errorf("unexpected measurement fields; -got/+exp\n%s", cmp.Diff(got, exp))
+		}
+	})
+
+	t.Run("series cardinality", func(t *testing.T) {
+		got, err := engine.BucketSeriesCardinality(ctx, engine.org, engine.bucket, 0, 20, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exp := int64(3); got != exp {
+			t.Errorf("got %d series, exp %d", got, exp)
+		}
+	})
+}
+
 func TestEngine_OpenClose(t *testing.T) {
 	engine := NewDefaultEngine()
 	engine.MustOpen()
@@ -230,7 +541,7 @@ func TestEngineClose_RemoveIndex(t *testing.T) {
 
 	err := engine.Write1xPoints([]models.Point{pt})
 	if err != nil {
-		t.Fatalf(err.Error())
+		t.Fatal(err)
 	}
 
 	if got, exp := engine.SeriesCardinality(), int64(1); got != exp {
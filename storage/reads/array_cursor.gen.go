@@ -8,6 +8,7 @@ package reads
 
 import (
 	"errors"
+	"math"
 
 	"github.com/influxdata/influxdb/tsdb/cursors"
 )
@@ -209,6 +210,205 @@ func (c floatArraySumCursor) Next() *cursors.FloatArray {
 	}
 }
 
+type floatArrayMinCursor struct {
+	cursors.FloatArrayCursor
+	ts  [1]int64
+	vs  [1]float64
+	res *cursors.FloatArray
+}
+
+func newFloatArrayMinCursor(cur cursors.FloatArrayCursor) *floatArrayMinCursor {
+	return &floatArrayMinCursor{
+		FloatArrayCursor: cur,
+		res:              &cursors.FloatArray{},
+	}
+}
+
+func (c *floatArrayMinCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+// Next returns the smallest value and its timestamp. The earliest point is
+// selected if the smallest value occurs more than once.
+func (c *floatArrayMinCursor) Next() *cursors.FloatArray {
+	a := c.FloatArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	ts, min := a.Timestamps[0], a.Values[0]
+
+	for {
+		for i, v := range a.Values {
+			if v < min {
+				ts, min = a.Timestamps[i], v
+			}
+		}
+		a = c.FloatArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.ts[0] = ts
+			c.vs[0] = min
+			c.res.Timestamps = c.ts[:]
+			c.res.Values = c.vs[:]
+			return c.res
+		}
+	}
+}
+
+type floatArrayMaxCursor struct {
+	cursors.FloatArrayCursor
+	ts  [1]int64
+	vs  [1]float64
+	res *cursors.FloatArray
+}
+
+func newFloatArrayMaxCursor(cur cursors.FloatArrayCursor) *floatArrayMaxCursor {
+	return &floatArrayMaxCursor{
+		FloatArrayCursor: cur,
+		res:              &cursors.FloatArray{},
+	}
+}
+
+func (c *floatArrayMaxCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+// Next returns the largest value and its timestamp. The earliest point is
+// selected if the largest value occurs more than once.
+func (c *floatArrayMaxCursor) Next() *cursors.FloatArray {
+	a := c.FloatArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	ts, max := a.Timestamps[0], a.Values[0]
+
+	for {
+		for i, v := range a.Values {
+			if v > max {
+				ts, max = a.Timestamps[i], v
+			}
+		}
+		a = c.FloatArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.ts[0] = ts
+			c.vs[0] = max
+			c.res.Timestamps = c.ts[:]
+			c.res.Values = c.vs[:]
+			return c.res
+		}
+	}
+}
+
+type floatArrayMeanCursor struct {
+	cursors.FloatArrayCursor
+	ts  [1]int64
+	vs  [1]float64
+	res *cursors.FloatArray
+}
+
+func newFloatArrayMeanCursor(cur cursors.FloatArrayCursor) *floatArrayMeanCursor {
+	return &floatArrayMeanCursor{
+		FloatArrayCursor: cur,
+		res:              &cursors.FloatArray{},
+	}
+}
+
+func (c *floatArrayMeanCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+func (c *floatArrayMeanCursor) Next() *cursors.FloatArray {
+	a := c.FloatArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return &cursors.FloatArray{}
+	}
+
+	ts := a.Timestamps[0]
+	var sum float64
+	var count int64
+
+	for {
+		for _, v := range a.Values {
+			sum += float64(v)
+		}
+		count += int64(len(a.Values))
+		a = c.FloatArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.ts[0] = ts
+			c.vs[0] = sum / float64(count)
+			c.res.Timestamps = c.ts[:]
+			c.res.Values = c.vs[:]
+			return c.res
+		}
+	}
+}
+
+type floatArrayFirstCursor struct {
+	cursors.FloatArrayCursor
+	ts   [1]int64
+	vs   [1]float64
+	res  *cursors.FloatArray
+	done bool
+}
+
+func newFloatArrayFirstCursor(cur cursors.FloatArrayCursor) *floatArrayFirstCursor {
+	return &floatArrayFirstCursor{
+		FloatArrayCursor: cur,
+		res:              &cursors.FloatArray{},
+	}
+}
+
+func (c *floatArrayFirstCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+// Next returns the first point of the underlying cursor. The remaining points
+// are never read.
+func (c *floatArrayFirstCursor) Next() *cursors.FloatArray {
+	if c.done {
+		return &cursors.FloatArray{}
+	}
+	c.done = true
+
+	a := c.FloatArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	c.ts[0] = a.Timestamps[0]
+	c.vs[0] = a.Values[0]
+	c.res.Timestamps = c.ts[:]
+	c.res.Values = c.vs[:]
+	return c.res
+}
+
+type floatArrayLastCursor struct {
+	cursors.FloatArrayCursor
+	ts  [1]int64
+	vs  [1]float64
+	res *cursors.FloatArray
+}
+
+func newFloatArrayLastCursor(cur cursors.FloatArrayCursor) *floatArrayLastCursor {
+	return &floatArrayLastCursor{
+		FloatArrayCursor: cur,
+		res:              &cursors.FloatArray{},
+	}
+}
+
+func (c *floatArrayLastCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+func (c *floatArrayLastCursor) Next() *cursors.FloatArray {
+	a := c.FloatArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	for {
+		c.ts[0] = a.Timestamps[len(a.Timestamps)-1]
+		c.vs[0] = a.Values[len(a.Values)-1]
+		a = c.FloatArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.res.Timestamps = c.ts[:]
+			c.res.Values = c.vs[:]
+			return c.res
+		}
+	}
+}
+
 type integerFloatCountArrayCursor struct {
 	cursors.FloatArrayCursor
 }
@@ -237,6 +437,111 @@ func (c *integerFloatCountArrayCursor) Next() *cursors.IntegerArray {
 	}
 }
 
+// floatWindowArrayCursor splits the points of an underlying cursor into windows. Next
+// only returns the points of the current window and nextWindow advances to the
+// next window that contains points.
+type floatWindowArrayCursor struct {
+	cursors.FloatArrayCursor
+	every  int64
+	offset int64
+	stop   int64
+	a      *cursors.FloatArray
+	i      int
+	res    *cursors.FloatArray
+}
+
+func newFloatWindowArrayCursor(cur cursors.FloatArrayCursor, every, offset int64) *floatWindowArrayCursor {
+	return &floatWindowArrayCursor{
+		FloatArrayCursor: cur,
+		every:            every,
+		offset:           offset,
+		stop:             math.MinInt64,
+		a:                &cursors.FloatArray{},
+		res:              &cursors.FloatArray{},
+	}
+}
+
+func (c *floatWindowArrayCursor) Stats() cursors.CursorStats { return c.FloatArrayCursor.Stats() }
+
+// fill reads the next block of the underlying cursor once the current one
+// has been consumed. It returns false when the cursor is exhausted.
+func (c *floatWindowArrayCursor) fill() bool {
+	if c.i < c.a.Len() {
+		return true
+	}
+	c.a = c.FloatArrayCursor.Next()
+	c.i = 0
+	return c.a.Len() > 0
+}
+
+func (c *floatWindowArrayCursor) nextWindow() bool {
+	// Skip the points of the current window that were not read.
+	for c.fill() {
+		if ts := c.a.Timestamps[c.i]; ts >= c.stop {
+			c.stop = windowStop(ts, c.every, c.offset)
+			return true
+		}
+		c.i++
+	}
+	return false
+}
+
+func (c *floatWindowArrayCursor) windowStop() int64 { return c.stop }
+
+func (c *floatWindowArrayCursor) Next() *cursors.FloatArray {
+	if !c.fill() {
+		return c.a
+	}
+
+	j := c.i
+	for j < c.a.Len() && c.a.Timestamps[j] < c.stop {
+		j++
+	}
+	c.res.Timestamps = c.a.Timestamps[c.i:j]
+	c.res.Values = c.a.Values[c.i:j]
+	c.i = j
+	return c.res
+}
+
+// floatWindowAggregateArrayCursor produces a point for each window of a windowCursor. The value
+// of a point is the result of the aggregate over the window and its timestamp
+// is the end of the window.
+type floatWindowAggregateArrayCursor struct {
+	windowCursor
+	newCursor func() cursors.Cursor
+	end       int64
+	res       *cursors.FloatArray
+}
+
+func newFloatWindowAggregateArrayCursor(win windowCursor, newCursor func() cursors.Cursor, end int64) *floatWindowAggregateArrayCursor {
+	return &floatWindowAggregateArrayCursor{
+		windowCursor: win,
+		newCursor:    newCursor,
+		end:          end,
+		res:          cursors.NewFloatArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *floatWindowAggregateArrayCursor) Next() *cursors.FloatArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for c.res.Len() < MaxPointsPerBlock && c.nextWindow() {
+		a := c.newCursor().(cursors.FloatArrayCursor).Next()
+		if a.Len() == 0 {
+			continue
+		}
+
+		stop := c.windowStop()
+		if stop > c.end {
+			stop = c.end
+		}
+		c.res.Timestamps = append(c.res.Timestamps, stop)
+		c.res.Values = append(c.res.Values, a.Values[0])
+	}
+	return c.res
+}
+
 type floatEmptyArrayCursor struct {
 	res cursors.FloatArray
 }
@@ -438,69 +743,373 @@ func (c integerArraySumCursor) Next() *cursors.IntegerArray {
 	}
 }
 
-type integerIntegerCountArrayCursor struct {
+type integerArrayMinCursor struct {
 	cursors.IntegerArrayCursor
+	ts  [1]int64
+	vs  [1]int64
+	res *cursors.IntegerArray
 }
 
-func (c *integerIntegerCountArrayCursor) Stats() cursors.CursorStats {
-	return c.IntegerArrayCursor.Stats()
+func newIntegerArrayMinCursor(cur cursors.IntegerArrayCursor) *integerArrayMinCursor {
+	return &integerArrayMinCursor{
+		IntegerArrayCursor: cur,
+		res:                &cursors.IntegerArray{},
+	}
 }
 
-func (c *integerIntegerCountArrayCursor) Next() *cursors.IntegerArray {
+func (c *integerArrayMinCursor) Stats() cursors.CursorStats { return c.IntegerArrayCursor.Stats() }
+
+// Next returns the smallest value and its timestamp. The earliest point is
+// selected if the smallest value occurs more than once.
+func (c *integerArrayMinCursor) Next() *cursors.IntegerArray {
 	a := c.IntegerArrayCursor.Next()
 	if len(a.Timestamps) == 0 {
-		return &cursors.IntegerArray{}
+		return a
 	}
 
-	ts := a.Timestamps[0]
-	var acc int64
+	ts, min := a.Timestamps[0], a.Values[0]
+
 	for {
-		acc += int64(len(a.Timestamps))
+		for i, v := range a.Values {
+			if v < min {
+				ts, min = a.Timestamps[i], v
+			}
+		}
 		a = c.IntegerArrayCursor.Next()
 		if len(a.Timestamps) == 0 {
-			res := cursors.NewIntegerArrayLen(1)
-			res.Timestamps[0] = ts
-			res.Values[0] = acc
-			return res
+			c.ts[0] = ts
+			c.vs[0] = min
+			c.res.Timestamps = c.ts[:]
+			c.res.Values = c.vs[:]
+			return c.res
 		}
 	}
 }
 
-type integerEmptyArrayCursor struct {
-	res cursors.IntegerArray
+type integerArrayMaxCursor struct {
+	cursors.IntegerArrayCursor
+	ts  [1]int64
+	vs  [1]int64
+	res *cursors.IntegerArray
 }
 
-var IntegerEmptyArrayCursor cursors.IntegerArrayCursor = &integerEmptyArrayCursor{}
+func newIntegerArrayMaxCursor(cur cursors.IntegerArrayCursor) *integerArrayMaxCursor {
+	return &integerArrayMaxCursor{
+		IntegerArrayCursor: cur,
+		res:                &cursors.IntegerArray{},
+	}
+}
 
-func (c *integerEmptyArrayCursor) Err() error                  { return nil }
-func (c *integerEmptyArrayCursor) Close()                      {}
-func (c *integerEmptyArrayCursor) Stats() cursors.CursorStats  { return cursors.CursorStats{} }
-func (c *integerEmptyArrayCursor) Next() *cursors.IntegerArray { return &c.res }
+func (c *integerArrayMaxCursor) Stats() cursors.CursorStats { return c.IntegerArrayCursor.Stats() }
 
-// ********************
-// Unsigned Array Cursor
+// Next returns the largest value and its timestamp. The earliest point is
+// selected if the largest value occurs more than once.
+func (c *integerArrayMaxCursor) Next() *cursors.IntegerArray {
+	a := c.IntegerArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
 
-type unsignedArrayFilterCursor struct {
-	cursors.UnsignedArrayCursor
-	cond expression
-	m    *singleValue
-	res  *cursors.UnsignedArray
-	tmp  *cursors.UnsignedArray
-}
+	ts, max := a.Timestamps[0], a.Values[0]
 
-func newUnsignedFilterArrayCursor(cond expression) *unsignedArrayFilterCursor {
-	return &unsignedArrayFilterCursor{
-		cond: cond,
-		m:    &singleValue{},
-		res:  cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
-		tmp:  &cursors.UnsignedArray{},
+	for {
+		for i, v := range a.Values {
+			if v > max {
+				ts, max = a.Timestamps[i], v
+			}
+		}
+		a = c.IntegerArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.ts[0] = ts
+			c.vs[0] = max
+			c.res.Timestamps = c.ts[:]
+			c.res.Values = c.vs[:]
+			return c.res
+		}
 	}
 }
 
-func (c *unsignedArrayFilterCursor) reset(cur cursors.UnsignedArrayCursor) {
-	c.UnsignedArrayCursor = cur
-	c.tmp.Timestamps, c.tmp.Values = nil, nil
-}
+type integerArrayMeanCursor struct {
+	cursors.IntegerArrayCursor
+	ts  [1]int64
+	vs  [1]float64
+	res *cursors.FloatArray
+}
+
+func newIntegerArrayMeanCursor(cur cursors.IntegerArrayCursor) *integerArrayMeanCursor {
+	return &integerArrayMeanCursor{
+		IntegerArrayCursor: cur,
+		res:                &cursors.FloatArray{},
+	}
+}
+
+func (c *integerArrayMeanCursor) Stats() cursors.CursorStats { return c.IntegerArrayCursor.Stats() }
+
+func (c *integerArrayMeanCursor) Next() *cursors.FloatArray {
+	a := c.IntegerArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return &cursors.FloatArray{}
+	}
+
+	ts := a.Timestamps[0]
+	var sum float64
+	var count int64
+
+	for {
+		for _, v := range a.Values {
+			sum += float64(v)
+		}
+		count += int64(len(a.Values))
+		a = c.IntegerArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.ts[0] = ts
+			c.vs[0] = sum / float64(count)
+			c.res.Timestamps = c.ts[:]
+			c.res.Values = c.vs[:]
+			return c.res
+		}
+	}
+}
+
+type integerArrayFirstCursor struct {
+	cursors.IntegerArrayCursor
+	ts   [1]int64
+	vs   [1]int64
+	res  *cursors.IntegerArray
+	done bool
+}
+
+func newIntegerArrayFirstCursor(cur cursors.IntegerArrayCursor) *integerArrayFirstCursor {
+	return &integerArrayFirstCursor{
+		IntegerArrayCursor: cur,
+		res:                &cursors.IntegerArray{},
+	}
+}
+
+func (c *integerArrayFirstCursor) Stats() cursors.CursorStats { return c.IntegerArrayCursor.Stats() }
+
+// Next returns the first point of the underlying cursor. The remaining points
+// are never read.
+func (c *integerArrayFirstCursor) Next() *cursors.IntegerArray {
+	if c.done {
+		return &cursors.IntegerArray{}
+	}
+	c.done = true
+
+	a := c.IntegerArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	c.ts[0] = a.Timestamps[0]
+	c.vs[0] = a.Values[0]
+	c.res.Timestamps = c.ts[:]
+	c.res.Values = c.vs[:]
+	return c.res
+}
+
+type integerArrayLastCursor struct {
+	cursors.IntegerArrayCursor
+	ts  [1]int64
+	vs  [1]int64
+	res *cursors.IntegerArray
+}
+
+func newIntegerArrayLastCursor(cur cursors.IntegerArrayCursor) *integerArrayLastCursor {
+	return &integerArrayLastCursor{
+		IntegerArrayCursor: cur,
+		res:                &cursors.IntegerArray{},
+	}
+}
+
+func (c *integerArrayLastCursor) Stats() cursors.CursorStats { return c.IntegerArrayCursor.Stats() }
+
+func (c *integerArrayLastCursor) Next() *cursors.IntegerArray {
+	a := c.IntegerArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	for {
+		c.ts[0] = a.Timestamps[len(a.Timestamps)-1]
+		c.vs[0] = a.Values[len(a.Values)-1]
+		a = c.IntegerArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.res.Timestamps = c.ts[:]
+			c.res.Values = c.vs[:]
+			return c.res
+		}
+	}
+}
+
+type integerIntegerCountArrayCursor struct {
+	cursors.IntegerArrayCursor
+}
+
+func (c *integerIntegerCountArrayCursor) Stats() cursors.CursorStats {
+	return c.IntegerArrayCursor.Stats()
+}
+
+func (c *integerIntegerCountArrayCursor) Next() *cursors.IntegerArray {
+	a := c.IntegerArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return &cursors.IntegerArray{}
+	}
+
+	ts := a.Timestamps[0]
+	var acc int64
+	for {
+		acc += int64(len(a.Timestamps))
+		a = c.IntegerArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			res := cursors.NewIntegerArrayLen(1)
+			res.Timestamps[0] = ts
+			res.Values[0] = acc
+			return res
+		}
+	}
+}
+
+// integerWindowArrayCursor splits the points of an underlying cursor into windows. Next
+// only returns the points of the current window and nextWindow advances to the
+// next window that contains points.
+type integerWindowArrayCursor struct {
+	cursors.IntegerArrayCursor
+	every  int64
+	offset int64
+	stop   int64
+	a      *cursors.IntegerArray
+	i      int
+	res    *cursors.IntegerArray
+}
+
+func newIntegerWindowArrayCursor(cur cursors.IntegerArrayCursor, every, offset int64) *integerWindowArrayCursor {
+	return &integerWindowArrayCursor{
+		IntegerArrayCursor: cur,
+		every:              every,
+		offset:             offset,
+		stop:               math.MinInt64,
+		a:                  &cursors.IntegerArray{},
+		res:                &cursors.IntegerArray{},
+	}
+}
+
+func (c *integerWindowArrayCursor) Stats() cursors.CursorStats { return c.IntegerArrayCursor.Stats() }
+
+// fill reads the next block of the underlying cursor once the current one
+// has been consumed. It returns false when the cursor is exhausted.
+func (c *integerWindowArrayCursor) fill() bool {
+	if c.i < c.a.Len() {
+		return true
+	}
+	c.a = c.IntegerArrayCursor.Next()
+	c.i = 0
+	return c.a.Len() > 0
+}
+
+func (c *integerWindowArrayCursor) nextWindow() bool {
+	// Skip the points of the current window that were not read.
+	for c.fill() {
+		if ts := c.a.Timestamps[c.i]; ts >= c.stop {
+			c.stop = windowStop(ts, c.every, c.offset)
+			return true
+		}
+		c.i++
+	}
+	return false
+}
+
+func (c *integerWindowArrayCursor) windowStop() int64 { return c.stop }
+
+func (c *integerWindowArrayCursor) Next() *cursors.IntegerArray {
+	if !c.fill() {
+		return c.a
+	}
+
+	j := c.i
+	for j < c.a.Len() && c.a.Timestamps[j] < c.stop {
+		j++
+	}
+	c.res.Timestamps = c.a.Timestamps[c.i:j]
+	c.res.Values = c.a.Values[c.i:j]
+	c.i = j
+	return c.res
+}
+
+// integerWindowAggregateArrayCursor produces a point for each window of a windowCursor. The value
+// of a point is the result of the aggregate over the window and its timestamp
+// is the end of the window.
+type integerWindowAggregateArrayCursor struct {
+	windowCursor
+	newCursor func() cursors.Cursor
+	end       int64
+	res       *cursors.IntegerArray
+}
+
+func newIntegerWindowAggregateArrayCursor(win windowCursor, newCursor func() cursors.Cursor, end int64) *integerWindowAggregateArrayCursor {
+	return &integerWindowAggregateArrayCursor{
+		windowCursor: win,
+		newCursor:    newCursor,
+		end:          end,
+		res:          cursors.NewIntegerArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *integerWindowAggregateArrayCursor) Next() *cursors.IntegerArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for c.res.Len() < MaxPointsPerBlock && c.nextWindow() {
+		a := c.newCursor().(cursors.IntegerArrayCursor).Next()
+		if a.Len() == 0 {
+			continue
+		}
+
+		stop := c.windowStop()
+		if stop > c.end {
+			stop = c.end
+		}
+		c.res.Timestamps = append(c.res.Timestamps, stop)
+		c.res.Values = append(c.res.Values, a.Values[0])
+	}
+	return c.res
+}
+
+type integerEmptyArrayCursor struct {
+	res cursors.IntegerArray
+}
+
+var IntegerEmptyArrayCursor cursors.IntegerArrayCursor = &integerEmptyArrayCursor{}
+
+func (c *integerEmptyArrayCursor) Err() error                  { return nil }
+func (c *integerEmptyArrayCursor) Close()                      {}
+func (c *integerEmptyArrayCursor) Stats() cursors.CursorStats  { return cursors.CursorStats{} }
+func (c *integerEmptyArrayCursor) Next() *cursors.IntegerArray { return &c.res }
+
+// ********************
+// Unsigned Array Cursor
+
+type unsignedArrayFilterCursor struct {
+	cursors.UnsignedArrayCursor
+	cond expression
+	m    *singleValue
+	res  *cursors.UnsignedArray
+	tmp  *cursors.UnsignedArray
+}
+
+func newUnsignedFilterArrayCursor(cond expression) *unsignedArrayFilterCursor {
+	return &unsignedArrayFilterCursor{
+		cond: cond,
+		m:    &singleValue{},
+		res:  cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
+		tmp:  &cursors.UnsignedArray{},
+	}
+}
+
+func (c *unsignedArrayFilterCursor) reset(cur cursors.UnsignedArrayCursor) {
+	c.UnsignedArrayCursor = cur
+	c.tmp.Timestamps, c.tmp.Values = nil, nil
+}
 
 func (c *unsignedArrayFilterCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
 
@@ -578,88 +1187,287 @@ func (c *unsignedMultiShardArrayCursor) Next() *cursors.UnsignedArray {
 				continue
 			}
 		}
-		c.count += int64(a.Len())
-		if c.count > c.limit {
-			diff := c.count - c.limit
-			c.count -= diff
-			rem := int64(a.Len()) - diff
-			a.Timestamps = a.Timestamps[:rem]
-			a.Values = a.Values[:rem]
+		c.count += int64(a.Len())
+		if c.count > c.limit {
+			diff := c.count - c.limit
+			c.count -= diff
+			rem := int64(a.Len()) - diff
+			a.Timestamps = a.Timestamps[:rem]
+			a.Values = a.Values[:rem]
+		}
+		return a
+	}
+}
+
+func (c *unsignedMultiShardArrayCursor) nextArrayCursor() bool {
+	if len(c.itrs) == 0 {
+		return false
+	}
+
+	c.UnsignedArrayCursor.Close()
+
+	var itr cursors.CursorIterator
+	var cur cursors.Cursor
+	for cur == nil && len(c.itrs) > 0 {
+		itr, c.itrs = c.itrs[0], c.itrs[1:]
+		cur, _ = itr.Next(c.ctx, c.req)
+	}
+
+	var ok bool
+	if cur != nil {
+		var next cursors.UnsignedArrayCursor
+		next, ok = cur.(cursors.UnsignedArrayCursor)
+		if !ok {
+			cur.Close()
+			next = UnsignedEmptyArrayCursor
+			c.itrs = nil
+			c.err = errors.New("expected unsigned cursor")
+		} else {
+			if c.filter != nil {
+				c.filter.reset(next)
+				next = c.filter
+			}
+		}
+		c.UnsignedArrayCursor = next
+	} else {
+		c.UnsignedArrayCursor = UnsignedEmptyArrayCursor
+	}
+
+	return ok
+}
+
+type unsignedArraySumCursor struct {
+	cursors.UnsignedArrayCursor
+	ts  [1]int64
+	vs  [1]uint64
+	res *cursors.UnsignedArray
+}
+
+func newUnsignedArraySumCursor(cur cursors.UnsignedArrayCursor) *unsignedArraySumCursor {
+	return &unsignedArraySumCursor{
+		UnsignedArrayCursor: cur,
+		res:                 &cursors.UnsignedArray{},
+	}
+}
+
+func (c unsignedArraySumCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
+
+func (c unsignedArraySumCursor) Next() *cursors.UnsignedArray {
+	a := c.UnsignedArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	ts := a.Timestamps[0]
+	var acc uint64
+
+	for {
+		for _, v := range a.Values {
+			acc += v
+		}
+		a = c.UnsignedArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.ts[0] = ts
+			c.vs[0] = acc
+			c.res.Timestamps = c.ts[:]
+			c.res.Values = c.vs[:]
+			return c.res
+		}
+	}
+}
+
+type unsignedArrayMinCursor struct {
+	cursors.UnsignedArrayCursor
+	ts  [1]int64
+	vs  [1]uint64
+	res *cursors.UnsignedArray
+}
+
+func newUnsignedArrayMinCursor(cur cursors.UnsignedArrayCursor) *unsignedArrayMinCursor {
+	return &unsignedArrayMinCursor{
+		UnsignedArrayCursor: cur,
+		res:                 &cursors.UnsignedArray{},
+	}
+}
+
+func (c *unsignedArrayMinCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
+
+// Next returns the smallest value and its timestamp. The earliest point is
+// selected if the smallest value occurs more than once.
+func (c *unsignedArrayMinCursor) Next() *cursors.UnsignedArray {
+	a := c.UnsignedArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	ts, min := a.Timestamps[0], a.Values[0]
+
+	for {
+		for i, v := range a.Values {
+			if v < min {
+				ts, min = a.Timestamps[i], v
+			}
+		}
+		a = c.UnsignedArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.ts[0] = ts
+			c.vs[0] = min
+			c.res.Timestamps = c.ts[:]
+			c.res.Values = c.vs[:]
+			return c.res
+		}
+	}
+}
+
+type unsignedArrayMaxCursor struct {
+	cursors.UnsignedArrayCursor
+	ts  [1]int64
+	vs  [1]uint64
+	res *cursors.UnsignedArray
+}
+
+func newUnsignedArrayMaxCursor(cur cursors.UnsignedArrayCursor) *unsignedArrayMaxCursor {
+	return &unsignedArrayMaxCursor{
+		UnsignedArrayCursor: cur,
+		res:                 &cursors.UnsignedArray{},
+	}
+}
+
+func (c *unsignedArrayMaxCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
+
+// Next returns the largest value and its timestamp. The earliest point is
+// selected if the largest value occurs more than once.
+func (c *unsignedArrayMaxCursor) Next() *cursors.UnsignedArray {
+	a := c.UnsignedArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	ts, max := a.Timestamps[0], a.Values[0]
+
+	for {
+		for i, v := range a.Values {
+			if v > max {
+				ts, max = a.Timestamps[i], v
+			}
+		}
+		a = c.UnsignedArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.ts[0] = ts
+			c.vs[0] = max
+			c.res.Timestamps = c.ts[:]
+			c.res.Values = c.vs[:]
+			return c.res
+		}
+	}
+}
+
+type unsignedArrayMeanCursor struct {
+	cursors.UnsignedArrayCursor
+	ts  [1]int64
+	vs  [1]float64
+	res *cursors.FloatArray
+}
+
+func newUnsignedArrayMeanCursor(cur cursors.UnsignedArrayCursor) *unsignedArrayMeanCursor {
+	return &unsignedArrayMeanCursor{
+		UnsignedArrayCursor: cur,
+		res:                 &cursors.FloatArray{},
+	}
+}
+
+func (c *unsignedArrayMeanCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
+
+func (c *unsignedArrayMeanCursor) Next() *cursors.FloatArray {
+	a := c.UnsignedArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return &cursors.FloatArray{}
+	}
+
+	ts := a.Timestamps[0]
+	var sum float64
+	var count int64
+
+	for {
+		for _, v := range a.Values {
+			sum += float64(v)
+		}
+		count += int64(len(a.Values))
+		a = c.UnsignedArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.ts[0] = ts
+			c.vs[0] = sum / float64(count)
+			c.res.Timestamps = c.ts[:]
+			c.res.Values = c.vs[:]
+			return c.res
 		}
-		return a
 	}
 }
 
-func (c *unsignedMultiShardArrayCursor) nextArrayCursor() bool {
-	if len(c.itrs) == 0 {
-		return false
+type unsignedArrayFirstCursor struct {
+	cursors.UnsignedArrayCursor
+	ts   [1]int64
+	vs   [1]uint64
+	res  *cursors.UnsignedArray
+	done bool
+}
+
+func newUnsignedArrayFirstCursor(cur cursors.UnsignedArrayCursor) *unsignedArrayFirstCursor {
+	return &unsignedArrayFirstCursor{
+		UnsignedArrayCursor: cur,
+		res:                 &cursors.UnsignedArray{},
 	}
+}
 
-	c.UnsignedArrayCursor.Close()
+func (c *unsignedArrayFirstCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
 
-	var itr cursors.CursorIterator
-	var cur cursors.Cursor
-	for cur == nil && len(c.itrs) > 0 {
-		itr, c.itrs = c.itrs[0], c.itrs[1:]
-		cur, _ = itr.Next(c.ctx, c.req)
+// Next returns the first point of the underlying cursor. The remaining points
+// are never read.
+func (c *unsignedArrayFirstCursor) Next() *cursors.UnsignedArray {
+	if c.done {
+		return &cursors.UnsignedArray{}
 	}
+	c.done = true
 
-	var ok bool
-	if cur != nil {
-		var next cursors.UnsignedArrayCursor
-		next, ok = cur.(cursors.UnsignedArrayCursor)
-		if !ok {
-			cur.Close()
-			next = UnsignedEmptyArrayCursor
-			c.itrs = nil
-			c.err = errors.New("expected unsigned cursor")
-		} else {
-			if c.filter != nil {
-				c.filter.reset(next)
-				next = c.filter
-			}
-		}
-		c.UnsignedArrayCursor = next
-	} else {
-		c.UnsignedArrayCursor = UnsignedEmptyArrayCursor
+	a := c.UnsignedArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
 	}
 
-	return ok
+	c.ts[0] = a.Timestamps[0]
+	c.vs[0] = a.Values[0]
+	c.res.Timestamps = c.ts[:]
+	c.res.Values = c.vs[:]
+	return c.res
 }
 
-type unsignedArraySumCursor struct {
+type unsignedArrayLastCursor struct {
 	cursors.UnsignedArrayCursor
 	ts  [1]int64
 	vs  [1]uint64
 	res *cursors.UnsignedArray
 }
 
-func newUnsignedArraySumCursor(cur cursors.UnsignedArrayCursor) *unsignedArraySumCursor {
-	return &unsignedArraySumCursor{
+func newUnsignedArrayLastCursor(cur cursors.UnsignedArrayCursor) *unsignedArrayLastCursor {
+	return &unsignedArrayLastCursor{
 		UnsignedArrayCursor: cur,
 		res:                 &cursors.UnsignedArray{},
 	}
 }
 
-func (c unsignedArraySumCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
+func (c *unsignedArrayLastCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
 
-func (c unsignedArraySumCursor) Next() *cursors.UnsignedArray {
+func (c *unsignedArrayLastCursor) Next() *cursors.UnsignedArray {
 	a := c.UnsignedArrayCursor.Next()
 	if len(a.Timestamps) == 0 {
 		return a
 	}
 
-	ts := a.Timestamps[0]
-	var acc uint64
-
 	for {
-		for _, v := range a.Values {
-			acc += v
-		}
+		c.ts[0] = a.Timestamps[len(a.Timestamps)-1]
+		c.vs[0] = a.Values[len(a.Values)-1]
 		a = c.UnsignedArrayCursor.Next()
 		if len(a.Timestamps) == 0 {
-			c.ts[0] = ts
-			c.vs[0] = acc
 			c.res.Timestamps = c.ts[:]
 			c.res.Values = c.vs[:]
 			return c.res
@@ -695,6 +1503,111 @@ func (c *integerUnsignedCountArrayCursor) Next() *cursors.IntegerArray {
 	}
 }
 
+// unsignedWindowArrayCursor splits the points of an underlying cursor into windows. Next
+// only returns the points of the current window and nextWindow advances to the
+// next window that contains points.
+type unsignedWindowArrayCursor struct {
+	cursors.UnsignedArrayCursor
+	every  int64
+	offset int64
+	stop   int64
+	a      *cursors.UnsignedArray
+	i      int
+	res    *cursors.UnsignedArray
+}
+
+func newUnsignedWindowArrayCursor(cur cursors.UnsignedArrayCursor, every, offset int64) *unsignedWindowArrayCursor {
+	return &unsignedWindowArrayCursor{
+		UnsignedArrayCursor: cur,
+		every:               every,
+		offset:              offset,
+		stop:                math.MinInt64,
+		a:                   &cursors.UnsignedArray{},
+		res:                 &cursors.UnsignedArray{},
+	}
+}
+
+func (c *unsignedWindowArrayCursor) Stats() cursors.CursorStats { return c.UnsignedArrayCursor.Stats() }
+
+// fill reads the next block of the underlying cursor once the current one
+// has been consumed. It returns false when the cursor is exhausted.
+func (c *unsignedWindowArrayCursor) fill() bool {
+	if c.i < c.a.Len() {
+		return true
+	}
+	c.a = c.UnsignedArrayCursor.Next()
+	c.i = 0
+	return c.a.Len() > 0
+}
+
+func (c *unsignedWindowArrayCursor) nextWindow() bool {
+	// Skip the points of the current window that were not read.
+	for c.fill() {
+		if ts := c.a.Timestamps[c.i]; ts >= c.stop {
+			c.stop = windowStop(ts, c.every, c.offset)
+			return true
+		}
+		c.i++
+	}
+	return false
+}
+
+func (c *unsignedWindowArrayCursor) windowStop() int64 { return c.stop }
+
+func (c *unsignedWindowArrayCursor) Next() *cursors.UnsignedArray {
+	if !c.fill() {
+		return c.a
+	}
+
+	j := c.i
+	for j < c.a.Len() && c.a.Timestamps[j] < c.stop {
+		j++
+	}
+	c.res.Timestamps = c.a.Timestamps[c.i:j]
+	c.res.Values = c.a.Values[c.i:j]
+	c.i = j
+	return c.res
+}
+
+// unsignedWindowAggregateArrayCursor produces a point for each window of a windowCursor. The value
+// of a point is the result of the aggregate over the window and its timestamp
+// is the end of the window.
+type unsignedWindowAggregateArrayCursor struct {
+	windowCursor
+	newCursor func() cursors.Cursor
+	end       int64
+	res       *cursors.UnsignedArray
+}
+
+func newUnsignedWindowAggregateArrayCursor(win windowCursor, newCursor func() cursors.Cursor, end int64) *unsignedWindowAggregateArrayCursor {
+	return &unsignedWindowAggregateArrayCursor{
+		windowCursor: win,
+		newCursor:    newCursor,
+		end:          end,
+		res:          cursors.NewUnsignedArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *unsignedWindowAggregateArrayCursor) Next() *cursors.UnsignedArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for c.res.Len() < MaxPointsPerBlock && c.nextWindow() {
+		a := c.newCursor().(cursors.UnsignedArrayCursor).Next()
+		if a.Len() == 0 {
+			continue
+		}
+
+		stop := c.windowStop()
+		if stop > c.end {
+			stop = c.end
+		}
+		c.res.Timestamps = append(c.res.Timestamps, stop)
+		c.res.Values = append(c.res.Values, a.Values[0])
+	}
+	return c.res
+}
+
 type unsignedEmptyArrayCursor struct {
 	res cursors.UnsignedArray
 }
@@ -856,6 +1769,77 @@ func (c *stringMultiShardArrayCursor) nextArrayCursor() bool {
 	return ok
 }
 
+type stringArrayFirstCursor struct {
+	cursors.StringArrayCursor
+	ts   [1]int64
+	vs   [1]string
+	res  *cursors.StringArray
+	done bool
+}
+
+func newStringArrayFirstCursor(cur cursors.StringArrayCursor) *stringArrayFirstCursor {
+	return &stringArrayFirstCursor{
+		StringArrayCursor: cur,
+		res:               &cursors.StringArray{},
+	}
+}
+
+func (c *stringArrayFirstCursor) Stats() cursors.CursorStats { return c.StringArrayCursor.Stats() }
+
+// Next returns the first point of the underlying cursor. The remaining points
+// are never read.
+func (c *stringArrayFirstCursor) Next() *cursors.StringArray {
+	if c.done {
+		return &cursors.StringArray{}
+	}
+	c.done = true
+
+	a := c.StringArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	c.ts[0] = a.Timestamps[0]
+	c.vs[0] = a.Values[0]
+	c.res.Timestamps = c.ts[:]
+	c.res.Values = c.vs[:]
+	return c.res
+}
+
+type stringArrayLastCursor struct {
+	cursors.StringArrayCursor
+	ts  [1]int64
+	vs  [1]string
+	res *cursors.StringArray
+}
+
+func newStringArrayLastCursor(cur cursors.StringArrayCursor) *stringArrayLastCursor {
+	return &stringArrayLastCursor{
+		StringArrayCursor: cur,
+		res:               &cursors.StringArray{},
+	}
+}
+
+func (c *stringArrayLastCursor) Stats() cursors.CursorStats { return c.StringArrayCursor.Stats() }
+
+func (c *stringArrayLastCursor) Next() *cursors.StringArray {
+	a := c.StringArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	for {
+		c.ts[0] = a.Timestamps[len(a.Timestamps)-1]
+		c.vs[0] = a.Values[len(a.Values)-1]
+		a = c.StringArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.res.Timestamps = c.ts[:]
+			c.res.Values = c.vs[:]
+			return c.res
+		}
+	}
+}
+
 type integerStringCountArrayCursor struct {
 	cursors.StringArrayCursor
 }
@@ -884,6 +1868,111 @@ func (c *integerStringCountArrayCursor) Next() *cursors.IntegerArray {
 	}
 }
 
+// stringWindowArrayCursor splits the points of an underlying cursor into windows. Next
+// only returns the points of the current window and nextWindow advances to the
+// next window that contains points.
+type stringWindowArrayCursor struct {
+	cursors.StringArrayCursor
+	every  int64
+	offset int64
+	stop   int64
+	a      *cursors.StringArray
+	i      int
+	res    *cursors.StringArray
+}
+
+func newStringWindowArrayCursor(cur cursors.StringArrayCursor, every, offset int64) *stringWindowArrayCursor {
+	return &stringWindowArrayCursor{
+		StringArrayCursor: cur,
+		every:             every,
+		offset:            offset,
+		stop:              math.MinInt64,
+		a:                 &cursors.StringArray{},
+		res:               &cursors.StringArray{},
+	}
+}
+
+func (c *stringWindowArrayCursor) Stats() cursors.CursorStats { return c.StringArrayCursor.Stats() }
+
+// fill reads the next block of the underlying cursor once the current one
+// has been consumed. It returns false when the cursor is exhausted.
+func (c *stringWindowArrayCursor) fill() bool {
+	if c.i < c.a.Len() {
+		return true
+	}
+	c.a = c.StringArrayCursor.Next()
+	c.i = 0
+	return c.a.Len() > 0
+}
+
+func (c *stringWindowArrayCursor) nextWindow() bool {
+	// Skip the points of the current window that were not read.
+	for c.fill() {
+		if ts := c.a.Timestamps[c.i]; ts >= c.stop {
+			c.stop = windowStop(ts, c.every, c.offset)
+			return true
+		}
+		c.i++
+	}
+	return false
+}
+
+func (c *stringWindowArrayCursor) windowStop() int64 { return c.stop }
+
+func (c *stringWindowArrayCursor) Next() *cursors.StringArray {
+	if !c.fill() {
+		return c.a
+	}
+
+	j := c.i
+	for j < c.a.Len() && c.a.Timestamps[j] < c.stop {
+		j++
+	}
+	c.res.Timestamps = c.a.Timestamps[c.i:j]
+	c.res.Values = c.a.Values[c.i:j]
+	c.i = j
+	return c.res
+}
+
+// stringWindowAggregateArrayCursor produces a point for each window of a windowCursor. The value
+// of a point is the result of the aggregate over the window and its timestamp
+// is the end of the window.
+type stringWindowAggregateArrayCursor struct {
+	windowCursor
+	newCursor func() cursors.Cursor
+	end       int64
+	res       *cursors.StringArray
+}
+
+func newStringWindowAggregateArrayCursor(win windowCursor, newCursor func() cursors.Cursor, end int64) *stringWindowAggregateArrayCursor {
+	return &stringWindowAggregateArrayCursor{
+		windowCursor: win,
+		newCursor:    newCursor,
+		end:          end,
+		res:          cursors.NewStringArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *stringWindowAggregateArrayCursor) Next() *cursors.StringArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for c.res.Len() < MaxPointsPerBlock && c.nextWindow() {
+		a := c.newCursor().(cursors.StringArrayCursor).Next()
+		if a.Len() == 0 {
+			continue
+		}
+
+		stop := c.windowStop()
+		if stop > c.end {
+			stop = c.end
+		}
+		c.res.Timestamps = append(c.res.Timestamps, stop)
+		c.res.Values = append(c.res.Values, a.Values[0])
+	}
+	return c.res
+}
+
 type stringEmptyArrayCursor struct {
 	res cursors.StringArray
 }
@@ -1045,6 +2134,77 @@ func (c *booleanMultiShardArrayCursor) nextArrayCursor() bool {
 	return ok
 }
 
+type booleanArrayFirstCursor struct {
+	cursors.BooleanArrayCursor
+	ts   [1]int64
+	vs   [1]bool
+	res  *cursors.BooleanArray
+	done bool
+}
+
+func newBooleanArrayFirstCursor(cur cursors.BooleanArrayCursor) *booleanArrayFirstCursor {
+	return &booleanArrayFirstCursor{
+		BooleanArrayCursor: cur,
+		res:                &cursors.BooleanArray{},
+	}
+}
+
+func (c *booleanArrayFirstCursor) Stats() cursors.CursorStats { return c.BooleanArrayCursor.Stats() }
+
+// Next returns the first point of the underlying cursor. The remaining points
+// are never read.
+func (c *booleanArrayFirstCursor) Next() *cursors.BooleanArray {
+	if c.done {
+		return &cursors.BooleanArray{}
+	}
+	c.done = true
+
+	a := c.BooleanArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	c.ts[0] = a.Timestamps[0]
+	c.vs[0] = a.Values[0]
+	c.res.Timestamps = c.ts[:]
+	c.res.Values = c.vs[:]
+	return c.res
+}
+
+type booleanArrayLastCursor struct {
+	cursors.BooleanArrayCursor
+	ts  [1]int64
+	vs  [1]bool
+	res *cursors.BooleanArray
+}
+
+func newBooleanArrayLastCursor(cur cursors.BooleanArrayCursor) *booleanArrayLastCursor {
+	return &booleanArrayLastCursor{
+		BooleanArrayCursor: cur,
+		res:                &cursors.BooleanArray{},
+	}
+}
+
+func (c *booleanArrayLastCursor) Stats() cursors.CursorStats { return c.BooleanArrayCursor.Stats() }
+
+func (c *booleanArrayLastCursor) Next() *cursors.BooleanArray {
+	a := c.BooleanArrayCursor.Next()
+	if len(a.Timestamps) == 0 {
+		return a
+	}
+
+	for {
+		c.ts[0] = a.Timestamps[len(a.Timestamps)-1]
+		c.vs[0] = a.Values[len(a.Values)-1]
+		a = c.BooleanArrayCursor.Next()
+		if len(a.Timestamps) == 0 {
+			c.res.Timestamps = c.ts[:]
+			c.res.Values = c.vs[:]
+			return c.res
+		}
+	}
+}
+
 type integerBooleanCountArrayCursor struct {
 	cursors.BooleanArrayCursor
 }
@@ -1073,6 +2233,111 @@ func (c *integerBooleanCountArrayCursor) Next() *cursors.IntegerArray {
 	}
 }
 
+// booleanWindowArrayCursor splits the points of an underlying cursor into windows. Next
+// only returns the points of the current window and nextWindow advances to the
+// next window that contains points.
+type booleanWindowArrayCursor struct {
+	cursors.BooleanArrayCursor
+	every  int64
+	offset int64
+	stop   int64
+	a      *cursors.BooleanArray
+	i      int
+	res    *cursors.BooleanArray
+}
+
+func newBooleanWindowArrayCursor(cur cursors.BooleanArrayCursor, every, offset int64) *booleanWindowArrayCursor {
+	return &booleanWindowArrayCursor{
+		BooleanArrayCursor: cur,
+		every:              every,
+		offset:             offset,
+		stop:               math.MinInt64,
+		a:                  &cursors.BooleanArray{},
+		res:                &cursors.BooleanArray{},
+	}
+}
+
+func (c *booleanWindowArrayCursor) Stats() cursors.CursorStats { return c.BooleanArrayCursor.Stats() }
+
+// fill reads the next block of the underlying cursor once the current one
+// has been consumed. It returns false when the cursor is exhausted.
+func (c *booleanWindowArrayCursor) fill() bool {
+	if c.i < c.a.Len() {
+		return true
+	}
+	c.a = c.BooleanArrayCursor.Next()
+	c.i = 0
+	return c.a.Len() > 0
+}
+
+func (c *booleanWindowArrayCursor) nextWindow() bool {
+	// Skip the points of the current window that were not read.
+	for c.fill() {
+		if ts := c.a.Timestamps[c.i]; ts >= c.stop {
+			c.stop = windowStop(ts, c.every, c.offset)
+			return true
+		}
+		c.i++
+	}
+	return false
+}
+
+func (c *booleanWindowArrayCursor) windowStop() int64 { return c.stop }
+
+func (c *booleanWindowArrayCursor) Next() *cursors.BooleanArray {
+	if !c.fill() {
+		return c.a
+	}
+
+	j := c.i
+	for j < c.a.Len() && c.a.Timestamps[j] < c.stop {
+		j++
+	}
+	c.res.Timestamps = c.a.Timestamps[c.i:j]
+	c.res.Values = c.a.Values[c.i:j]
+	c.i = j
+	return c.res
+}
+
+// booleanWindowAggregateArrayCursor produces a point for each window of a windowCursor. The value
+// of a point is the result of the aggregate over the window and its timestamp
+// is the end of the window.
+type booleanWindowAggregateArrayCursor struct {
+	windowCursor
+	newCursor func() cursors.Cursor
+	end       int64
+	res       *cursors.BooleanArray
+}
+
+func newBooleanWindowAggregateArrayCursor(win windowCursor, newCursor func() cursors.Cursor, end int64) *booleanWindowAggregateArrayCursor {
+	return &booleanWindowAggregateArrayCursor{
+		windowCursor: win,
+		newCursor:    newCursor,
+		end:          end,
+		res:          cursors.NewBooleanArrayLen(MaxPointsPerBlock),
+	}
+}
+
+func (c *booleanWindowAggregateArrayCursor) Next() *cursors.BooleanArray {
+	c.res.Timestamps = c.res.Timestamps[:0]
+	c.res.Values = c.res.Values[:0]
+
+	for c.res.Len() < MaxPointsPerBlock && c.nextWindow() {
+		a := c.newCursor().(cursors.BooleanArrayCursor).Next()
+		if a.Len() == 0 {
+			continue
+		}
+
+		stop := c.windowStop()
+		if stop > c.end {
+			stop = c.end
+		}
+		c.res.Timestamps = append(c.res.Timestamps, stop)
+		c.res.Values = append(c.res.Values, a.Values[0])
+	}
+	return c.res
+}
+
 type booleanEmptyArrayCursor struct {
 	res cursors.BooleanArray
 }
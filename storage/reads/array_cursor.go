@@ -3,6 +3,7 @@ package reads
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/influxdata/influxdb/storage/reads/datatypes"
 	"github.com/influxdata/influxdb/tsdb/cursors"
@@ -16,20 +17,134 @@ func (v *singleValue) Value(key string) (interface{}, bool) {
 	return v.v, true
 }
 
-func newAggregateArrayCursor(ctx context.Context, agg *datatypes.Aggregate, cursor cursors.Cursor) cursors.Cursor {
+// newAggregateArrayCursor returns a cursor that applies agg to cursor. An
+// error is returned if agg does not support the type of cursor, rather than
+// silently dropping the series.
+func newAggregateArrayCursor(ctx context.Context, agg *datatypes.Aggregate, cursor cursors.Cursor) (cursors.Cursor, error) {
 	if cursor == nil {
-		return nil
+		return nil, nil
 	}
 
+	var cur cursors.Cursor
 	switch agg.Type {
 	case datatypes.AggregateTypeSum:
-		return newSumArrayCursor(cursor)
+		cur = newSumArrayCursor(cursor)
 	case datatypes.AggregateTypeCount:
-		return newCountArrayCursor(cursor)
+		cur = newCountArrayCursor(cursor)
+	case datatypes.AggregateTypeMin:
+		cur = newMinArrayCursor(cursor)
+	case datatypes.AggregateTypeMax:
+		cur = newMaxArrayCursor(cursor)
+	case datatypes.AggregateTypeFirst:
+		cur = newFirstArrayCursor(cursor)
+	case datatypes.AggregateTypeLast:
+		cur = newLastArrayCursor(cursor)
+	case datatypes.AggregateTypeMean:
+		cur = newMeanArrayCursor(cursor)
 	default:
 		// TODO(sgc): should be validated higher up
 		panic("invalid aggregate")
 	}
+
+	if cur == nil {
+		cursor.Close()
+		return nil, &UnsupportedAggregateTypeError{Aggregate: agg.Type, Type: arrayCursorType(cursor)}
+	}
+	return cur, nil
+}
+
+// UnsupportedAggregateTypeError is returned when an aggregate is applied to
+// a series whose values it does not support, such as the mean of strings.
+type UnsupportedAggregateTypeError struct {
+	Aggregate datatypes.Aggregate_AggregateType
+	Type      string
+}
+
+func (e *UnsupportedAggregateTypeError) Error() string {
+	return fmt.Sprintf("unsupported input type for %s aggregate: %s", strings.ToLower(e.Aggregate.String()), e.Type)
+}
+
+// arrayCursorType returns the name of the type of the values of cur.
+func arrayCursorType(cur cursors.Cursor) string {
+	switch cur.(type) {
+	case cursors.FloatArrayCursor:
+		return "float"
+	case cursors.IntegerArrayCursor:
+		return "integer"
+	case cursors.UnsignedArrayCursor:
+		return "unsigned"
+	case cursors.StringArrayCursor:
+		return "string"
+	case cursors.BooleanArrayCursor:
+		return "boolean"
+	default:
+		return fmt.Sprintf("%T", cur)
+	}
+}
+
+// newWindowAggregateArrayCursor returns a cursor that applies agg to each
+// window of cursor. The timestamp of each point is the end of its window,
+// truncated to end.
+func newWindowAggregateArrayCursor(ctx context.Context, agg *datatypes.Aggregate, window *datatypes.Window, end int64, cursor cursors.Cursor) (cursors.Cursor, error) {
+	if cursor == nil {
+		return nil, nil
+	}
+
+	win := newWindowArrayCursor(cursor, window.Every, window.Offset)
+	newCursor := func() cursors.Cursor {
+		cur, _ := newAggregateArrayCursor(ctx, agg, win)
+		return cur
+	}
+
+	cur, err := newAggregateArrayCursor(ctx, agg, win)
+	if err != nil {
+		return nil, err
+	}
+	switch cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatWindowAggregateArrayCursor(win, newCursor, end), nil
+	case cursors.IntegerArrayCursor:
+		return newIntegerWindowAggregateArrayCursor(win, newCursor, end), nil
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedWindowAggregateArrayCursor(win, newCursor, end), nil
+	case cursors.StringArrayCursor:
+		return newStringWindowAggregateArrayCursor(win, newCursor, end), nil
+	case cursors.BooleanArrayCursor:
+		return newBooleanWindowAggregateArrayCursor(win, newCursor, end), nil
+	default:
+		panic(fmt.Sprintf("unreachable: %T", cur))
+	}
+}
+
+// windowCursor is implemented by the cursors that split a series into windows.
+type windowCursor interface {
+	cursors.Cursor
+	nextWindow() bool
+	windowStop() int64
+}
+
+func newWindowArrayCursor(cur cursors.Cursor, every, offset int64) windowCursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatWindowArrayCursor(cur, every, offset)
+	case cursors.IntegerArrayCursor:
+		return newIntegerWindowArrayCursor(cur, every, offset)
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedWindowArrayCursor(cur, every, offset)
+	case cursors.StringArrayCursor:
+		return newStringWindowArrayCursor(cur, every, offset)
+	case cursors.BooleanArrayCursor:
+		return newBooleanWindowArrayCursor(cur, every, offset)
+	default:
+		panic(fmt.Sprintf("unreachable: %T", cur))
+	}
+}
+
+// windowStop returns the end of the window containing ts. Windows are every
+// nanoseconds long and shifted from the unix epoch by offset.
+func windowStop(ts, every, offset int64) int64 {
+	start := ts - ((ts-offset)%every+every)%every
+	return start + every
 }
 
 func newSumArrayCursor(cur cursors.Cursor) cursors.Cursor {
@@ -63,6 +178,91 @@ func newCountArrayCursor(cur cursors.Cursor) cursors.Cursor {
 	}
 }
 
+func newMinArrayCursor(cur cursors.Cursor) cursors.Cursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatArrayMinCursor(cur)
+	case cursors.IntegerArrayCursor:
+		return newIntegerArrayMinCursor(cur)
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedArrayMinCursor(cur)
+	default:
+		return nil
+	}
+}
+
+func newMaxArrayCursor(cur cursors.Cursor) cursors.Cursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatArrayMaxCursor(cur)
+	case cursors.IntegerArrayCursor:
+		return newIntegerArrayMaxCursor(cur)
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedArrayMaxCursor(cur)
+	default:
+		return nil
+	}
+}
+
+func newMeanArrayCursor(cur cursors.Cursor) cursors.Cursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatArrayMeanCursor(cur)
+	case cursors.IntegerArrayCursor:
+		return newIntegerArrayMeanCursor(cur)
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedArrayMeanCursor(cur)
+	default:
+		return nil
+	}
+}
+
+func newFirstArrayCursor(cur cursors.Cursor) cursors.Cursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatArrayFirstCursor(cur)
+	case cursors.IntegerArrayCursor:
+		return newIntegerArrayFirstCursor(cur)
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedArrayFirstCursor(cur)
+	case cursors.StringArrayCursor:
+		return newStringArrayFirstCursor(cur)
+	case cursors.BooleanArrayCursor:
+		return newBooleanArrayFirstCursor(cur)
+	default:
+		panic(fmt.Sprintf("unreachable: %T", cur))
+	}
+}
+
+func newLastArrayCursor(cur cursors.Cursor) cursors.Cursor {
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		return newFloatArrayLastCursor(cur)
+	case cursors.IntegerArrayCursor:
+		return newIntegerArrayLastCursor(cur)
+	case cursors.UnsignedArrayCursor:
+		return newUnsignedArrayLastCursor(cur)
+	case cursors.StringArrayCursor:
+		return newStringArrayLastCursor(cur)
+	case cursors.BooleanArrayCursor:
+		return newBooleanArrayLastCursor(cur)
+	default:
+		panic(fmt.Sprintf("unreachable: %T", cur))
+	}
+}
+
+// isSelectorAggregate reports whether agg selects an existing point, keeping its
+// timestamp, rather than computing a new value.
+func isSelectorAggregate(agg *datatypes.Aggregate) bool {
+	switch agg.Type {
+	case datatypes.AggregateTypeMin, datatypes.AggregateTypeMax,
+		datatypes.AggregateTypeFirst, datatypes.AggregateTypeLast:
+		return true
+	default:
+		return false
+	}
+}
+
 type cursorContext struct {
 	ctx   context.Context
 	req   *cursors.CursorRequest
@@ -158,6 +358,9 @@ func (m *multiShardArrayCursors) createCursor(row SeriesRow) cursors.Cursor {
 	}
 }
 
-func (m *multiShardArrayCursors) newAggregateCursor(ctx context.Context, agg *datatypes.Aggregate, cursor cursors.Cursor) cursors.Cursor {
+func (m *multiShardArrayCursors) newAggregateCursor(ctx context.Context, agg *datatypes.Aggregate, window *datatypes.Window, cursor cursors.Cursor) (cursors.Cursor, error) {
+	if window != nil && window.Every > 0 {
+		return newWindowAggregateArrayCursor(ctx, agg, window, m.req.EndTime, cursor)
+	}
 	return newAggregateArrayCursor(ctx, agg, cursor)
 }
@@ -69,10 +69,11 @@ func NewGroupResultSet(ctx context.Context, req *datatypes.ReadRequest, newCurso
 		g.sortFn = groupBySort
 		g.nextGroupFn = groupByNextGroup
 		g.rgc = groupByCursor{
-			ctx:  ctx,
-			mb:   g.mb,
-			agg:  req.Aggregate,
-			vals: make([][]byte, len(req.GroupKeys)),
+			ctx:    ctx,
+			mb:     g.mb,
+			agg:    req.Aggregate,
+			window: req.Window,
+			vals:   make([][]byte, len(req.GroupKeys)),
 		}
 
 	case datatypes.GroupNone:
@@ -184,11 +185,12 @@ func groupNoneNextGroup(g *groupResultSet) GroupCursor {
 
 	g.eof = true
 	return &groupNoneCursor{
-		ctx:  g.ctx,
-		mb:   g.mb,
-		agg:  g.agg,
-		cur:  cur,
-		keys: g.km.get(),
+		ctx:    g.ctx,
+		mb:     g.mb,
+		agg:    g.agg,
+		window: g.req.Window,
+		cur:    cur,
+		keys:   g.km.get(),
 	}
 }
 
@@ -291,15 +293,17 @@ func groupBySort(g *groupResultSet) (int, error) {
 }
 
 type groupNoneCursor struct {
-	ctx  context.Context
-	mb   multiShardCursors
-	agg  *datatypes.Aggregate
-	cur  SeriesCursor
-	row  SeriesRow
-	keys [][]byte
+	ctx    context.Context
+	mb     multiShardCursors
+	agg    *datatypes.Aggregate
+	window *datatypes.Window
+	cur    SeriesCursor
+	row    SeriesRow
+	keys   [][]byte
+	err    error
 }
 
-func (c *groupNoneCursor) Err() error                 { return nil }
+func (c *groupNoneCursor) Err() error                 { return c.err }
 func (c *groupNoneCursor) Tags() models.Tags          { return c.row.Tags }
 func (c *groupNoneCursor) Keys() [][]byte             { return c.keys }
 func (c *groupNoneCursor) PartitionKeyVals() [][]byte { return nil }
@@ -307,6 +311,10 @@ func (c *groupNoneCursor) Close()                     { c.cur.Close() }
 func (c *groupNoneCursor) Stats() cursors.CursorStats { return c.row.Query.Stats() }
 
 func (c *groupNoneCursor) Next() bool {
+	if c.err != nil {
+		return false
+	}
+
 	row := c.cur.Next()
 	if row == nil {
 		return false
@@ -320,34 +328,37 @@ func (c *groupNoneCursor) Next() bool {
 func (c *groupNoneCursor) Cursor() cursors.Cursor {
 	cur := c.mb.createCursor(c.row)
 	if c.agg != nil {
-		cur = c.mb.newAggregateCursor(c.ctx, c.agg, cur)
+		cur, c.err = c.mb.newAggregateCursor(c.ctx, c.agg, c.window, cur)
 	}
 	return cur
 }
 
 type groupByCursor struct {
-	ctx  context.Context
-	mb   multiShardCursors
-	agg  *datatypes.Aggregate
-	i    int
-	rows []*SeriesRow
-	keys [][]byte
-	vals [][]byte
+	ctx    context.Context
+	mb     multiShardCursors
+	agg    *datatypes.Aggregate
+	window *datatypes.Window
+	i      int
+	rows   []*SeriesRow
+	keys   [][]byte
+	vals   [][]byte
+	err    error
 }
 
 func (c *groupByCursor) reset(rows []*SeriesRow) {
 	c.i = 0
 	c.rows = rows
+	c.err = nil
 }
 
-func (c *groupByCursor) Err() error                 { return nil }
+func (c *groupByCursor) Err() error                 { return c.err }
 func (c *groupByCursor) Keys() [][]byte             { return c.keys }
 func (c *groupByCursor) PartitionKeyVals() [][]byte { return c.vals }
 func (c *groupByCursor) Tags() models.Tags          { return c.rows[c.i-1].Tags }
 func (c *groupByCursor) Close()                     {}
 
 func (c *groupByCursor) Next() bool {
-	if c.i < len(c.rows) {
+	if c.err == nil && c.i < len(c.rows) {
 		c.i++
 		return true
 	}
@@ -357,7 +368,7 @@ func (c *groupByCursor) Next() bool {
 func (c *groupByCursor) Cursor() cursors.Cursor {
 	cur := c.mb.createCursor(*c.rows[c.i-1])
 	if c.agg != nil {
-		cur = c.mb.newAggregateCursor(c.ctx, c.agg, cur)
+		cur, c.err = c.mb.newAggregateCursor(c.ctx, c.agg, c.window, cur)
 	}
 	return cur
 }
@@ -154,6 +154,27 @@ func (v *predicateExpressionPrinter) Visit(n *datatypes.Node) NodeVisitor {
 	}
 }
 
+// AndPredicates returns a predicate that matches the series matched by both a
+// and b. A nil predicate matches every series.
+func AndPredicates(a, b *datatypes.Predicate) *datatypes.Predicate {
+	if a == nil || a.Root == nil {
+		return b
+	} else if b == nil || b.Root == nil {
+		return a
+	}
+
+	return &datatypes.Predicate{
+		Root: &datatypes.Node{
+			NodeType: datatypes.NodeTypeLogicalExpression,
+			Value:    &datatypes.Node_Logical_{Logical: datatypes.LogicalAnd},
+			Children: []*datatypes.Node{
+				{NodeType: datatypes.NodeTypeParenExpression, Children: []*datatypes.Node{a.Root}},
+				{NodeType: datatypes.NodeTypeParenExpression, Children: []*datatypes.Node{b.Root}},
+			},
+		},
+	}
+}
+
 func toStoragePredicate(f *semantic.FunctionExpression) (*datatypes.Predicate, error) {
 	if f.Block.Parameters == nil || len(f.Block.Parameters.List) != 1 {
 		return nil, errors.New("storage predicate functions must have exactly one parameter")
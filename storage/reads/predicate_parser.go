@@ -0,0 +1,274 @@
+package reads
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+// ParsePredicate parses a textual predicate, such as
+//
+//	_measurement="cpu" AND (host="a" OR host=~/^b/)
+//
+// into a storage predicate. Tag keys are bare identifiers, values are double
+// quoted strings or slash delimited regular expressions and the supported
+// operators are =, !=, =~ and !~. AND binds tighter than OR. The special keys
+// _measurement and _field refer to the measurement and field of a series.
+func ParsePredicate(s string) (*datatypes.Predicate, error) {
+	p := &predicateParser{s: s}
+	p.next()
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.tok.typ != predicateTokenEOF {
+		return nil, fmt.Errorf("unexpected %s at position %d", p.tok, p.tok.pos)
+	}
+
+	return &datatypes.Predicate{Root: root}, nil
+}
+
+type predicateTokenType int
+
+const (
+	predicateTokenEOF predicateTokenType = iota
+	predicateTokenIllegal
+	predicateTokenIdent
+	predicateTokenString
+	predicateTokenRegex
+	predicateTokenOperator
+	predicateTokenAnd
+	predicateTokenOr
+	predicateTokenLParen
+	predicateTokenRParen
+)
+
+type predicateToken struct {
+	typ predicateTokenType
+	val string
+	pos int
+}
+
+func (t predicateToken) String() string {
+	switch t.typ {
+	case predicateTokenEOF:
+		return "end of predicate"
+	case predicateTokenString:
+		return fmt.Sprintf("string %q", t.val)
+	case predicateTokenRegex:
+		return fmt.Sprintf("regex /%s/", t.val)
+	default:
+		return fmt.Sprintf("%q", t.val)
+	}
+}
+
+type predicateParser struct {
+	s   string
+	pos int
+	tok predicateToken
+}
+
+func (p *predicateParser) next() {
+	p.tok = p.scan()
+}
+
+func (p *predicateParser) scan() predicateToken {
+	for p.pos < len(p.s) && unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+
+	start := p.pos
+	if p.pos >= len(p.s) {
+		return predicateToken{typ: predicateTokenEOF, pos: start}
+	}
+
+	switch c := p.s[p.pos]; {
+	case c == '(':
+		p.pos++
+		return predicateToken{typ: predicateTokenLParen, val: "(", pos: start}
+	case c == ')':
+		p.pos++
+		return predicateToken{typ: predicateTokenRParen, val: ")", pos: start}
+	case c == '=' || c == '!':
+		p.pos++
+		if p.pos < len(p.s) && (p.s[p.pos] == '~' || (c == '!' && p.s[p.pos] == '=')) {
+			p.pos++
+		} else if c == '!' {
+			return predicateToken{typ: predicateTokenIllegal, val: "!", pos: start}
+		}
+		return predicateToken{typ: predicateTokenOperator, val: p.s[start:p.pos], pos: start}
+	case c == '"' || c == '/':
+		return p.scanDelimited(c)
+	case isPredicateIdentChar(c):
+		for p.pos < len(p.s) && isPredicateIdentChar(p.s[p.pos]) {
+			p.pos++
+		}
+		val := p.s[start:p.pos]
+		switch strings.ToUpper(val) {
+		case "AND":
+			return predicateToken{typ: predicateTokenAnd, val: val, pos: start}
+		case "OR":
+			return predicateToken{typ: predicateTokenOr, val: val, pos: start}
+		}
+		return predicateToken{typ: predicateTokenIdent, val: val, pos: start}
+	default:
+		p.pos++
+		return predicateToken{typ: predicateTokenIllegal, val: string(c), pos: start}
+	}
+}
+
+// scanDelimited scans a string or regex delimited by delim. The delimiter may
+// be escaped within the value with a backslash.
+func (p *predicateParser) scanDelimited(delim byte) predicateToken {
+	start := p.pos
+	p.pos++
+
+	var buf strings.Builder
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		switch {
+		case c == '\\' && p.pos+1 < len(p.s) && (p.s[p.pos+1] == delim || (delim == '"' && p.s[p.pos+1] == '\\')):
+			buf.WriteByte(p.s[p.pos+1])
+			p.pos += 2
+		case c == delim:
+			p.pos++
+			if delim == '/' {
+				return predicateToken{typ: predicateTokenRegex, val: buf.String(), pos: start}
+			}
+			return predicateToken{typ: predicateTokenString, val: buf.String(), pos: start}
+		default:
+			buf.WriteByte(c)
+			p.pos++
+		}
+	}
+
+	return predicateToken{typ: predicateTokenIllegal, val: p.s[start:], pos: start}
+}
+
+func isPredicateIdentChar(c byte) bool {
+	return c == '_' || c == '-' || c == '.' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func (p *predicateParser) parseOr() (*datatypes.Node, error) {
+	return p.parseLogical(predicateTokenOr, datatypes.LogicalOr, p.parseAnd)
+}
+
+func (p *predicateParser) parseAnd() (*datatypes.Node, error) {
+	return p.parseLogical(predicateTokenAnd, datatypes.LogicalAnd, p.parsePrimary)
+}
+
+func (p *predicateParser) parseLogical(typ predicateTokenType, op datatypes.Node_Logical, operand func() (*datatypes.Node, error)) (*datatypes.Node, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.tok.typ == typ {
+		p.next()
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &datatypes.Node{
+			NodeType: datatypes.NodeTypeLogicalExpression,
+			Value:    &datatypes.Node_Logical_{Logical: op},
+			Children: []*datatypes.Node{left, right},
+		}
+	}
+
+	return left, nil
+}
+
+func (p *predicateParser) parsePrimary() (*datatypes.Node, error) {
+	if p.tok.typ == predicateTokenLParen {
+		p.next()
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.typ != predicateTokenRParen {
+			return nil, fmt.Errorf("expected \")\" at position %d, got %s", p.tok.pos, p.tok)
+		}
+		p.next()
+
+		return &datatypes.Node{
+			NodeType: datatypes.NodeTypeParenExpression,
+			Children: []*datatypes.Node{n},
+		}, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *predicateParser) parseComparison() (*datatypes.Node, error) {
+	if p.tok.typ != predicateTokenIdent {
+		return nil, fmt.Errorf("expected tag key at position %d, got %s", p.tok.pos, p.tok)
+	}
+
+	key := p.tok.val
+	switch key {
+	case measurementKey:
+		key = tsdb.MeasurementTagKey
+	case fieldKey:
+		key = tsdb.FieldKeyTagKey
+	}
+	p.next()
+
+	if p.tok.typ != predicateTokenOperator {
+		return nil, fmt.Errorf("expected comparison operator at position %d, got %s", p.tok.pos, p.tok)
+	}
+	op := p.tok.val
+	p.next()
+
+	var (
+		cmp datatypes.Node_Comparison
+		lit *datatypes.Node
+	)
+	switch op {
+	case "=", "!=":
+		if p.tok.typ != predicateTokenString {
+			return nil, fmt.Errorf("expected string at position %d, got %s", p.tok.pos, p.tok)
+		}
+		cmp = datatypes.ComparisonEqual
+		if op == "!=" {
+			cmp = datatypes.ComparisonNotEqual
+		}
+		lit = &datatypes.Node{
+			NodeType: datatypes.NodeTypeLiteral,
+			Value:    &datatypes.Node_StringValue{StringValue: p.tok.val},
+		}
+
+	case "=~", "!~":
+		if p.tok.typ != predicateTokenRegex {
+			return nil, fmt.Errorf("expected regex at position %d, got %s", p.tok.pos, p.tok)
+		}
+		if _, err := regexp.Compile(p.tok.val); err != nil {
+			return nil, fmt.Errorf("invalid regex at position %d: %v", p.tok.pos, err)
+		}
+		cmp = datatypes.ComparisonRegex
+		if op == "!~" {
+			cmp = datatypes.ComparisonNotRegex
+		}
+		lit = &datatypes.Node{
+			NodeType: datatypes.NodeTypeLiteral,
+			Value:    &datatypes.Node_RegexValue{RegexValue: p.tok.val},
+		}
+	}
+	p.next()
+
+	return &datatypes.Node{
+		NodeType: datatypes.NodeTypeComparisonExpression,
+		Value:    &datatypes.Node_Comparison_{Comparison: cmp},
+		Children: []*datatypes.Node{
+			{NodeType: datatypes.NodeTypeTagRef, Value: &datatypes.Node_TagRefValue{TagRefValue: key}},
+			lit,
+		},
+	}, nil
+}
@@ -0,0 +1,130 @@
+package reads_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb/storage/reads"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+)
+
+func TestParsePredicate(t *testing.T) {
+	cases := []struct {
+		n   string
+		s   string
+		e   string
+		err bool
+	}{
+		{
+			n: "equality",
+			s: `host="bad-box"`,
+			e: `'host' = "bad-box"`,
+		},
+		{
+			n: "measurement and field",
+			s: `_measurement="cpu" and _field!="usage"`,
+			e: `'_m' = "cpu" AND '_f' != "usage"`,
+		},
+		{
+			n: "precedence",
+			s: `a="1" OR b="2" AND c=~/^x\/y/`,
+			e: `'a' = "1" OR 'b' = "2" AND 'c' =~ /^x/y/`,
+		},
+		{
+			n: "parens",
+			s: `(a="1" OR b!~/2/) AND c="say \"hi\""`,
+			e: `( 'a' = "1" OR 'b' !~ /2/ ) AND 'c' = "say \"hi\""`,
+		},
+		{
+			n:   "missing value",
+			s:   `host=`,
+			err: true,
+		},
+		{
+			n:   "regex with equality",
+			s:   `host=/a/`,
+			err: true,
+		},
+		{
+			n:   "unbalanced parens",
+			s:   `(host="a"`,
+			err: true,
+		},
+		{
+			n:   "unterminated string",
+			s:   `host="a`,
+			err: true,
+		},
+		{
+			n:   "trailing tokens",
+			s:   `host="a" host="b"`,
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.n, func(t *testing.T) {
+			p, err := reads.ParsePredicate(tc.s)
+			if tc.err {
+				if err == nil {
+					t.Fatalf("expected error, got %s", reads.PredicateToExprString(p))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, wanted := reads.PredicateToExprString(p), tc.e; got != wanted {
+				t.Fatal("got:", got, "wanted:", wanted)
+			}
+		})
+	}
+}
+
+func TestAndPredicates(t *testing.T) {
+	parse := func(s string) *datatypes.Predicate {
+		if s == "" {
+			return nil
+		}
+		p, err := reads.ParsePredicate(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	cases := []struct {
+		n    string
+		a, b string
+		e    string
+	}{
+		{
+			n: "both",
+			a: `_measurement=~/^public_/`,
+			b: `a="1" OR b="2"`,
+			e: `( '_m' =~ /^public_/ ) AND ( 'a' = "1" OR 'b' = "2" )`,
+		},
+		{
+			n: "nil a",
+			b: `a="1"`,
+			e: `'a' = "1"`,
+		},
+		{
+			n: "nil b",
+			a: `a="1"`,
+			e: `'a' = "1"`,
+		},
+		{
+			n: "none",
+			e: `[none]`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.n, func(t *testing.T) {
+			p := reads.AndPredicates(parse(tc.a), parse(tc.b))
+			if got, wanted := reads.PredicateToExprString(p), tc.e; got != wanted {
+				t.Fatal("got:", got, "wanted:", wanted)
+			}
+		})
+	}
+}
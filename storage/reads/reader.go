@@ -9,6 +9,7 @@ import (
 	"github.com/gogo/protobuf/types"
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/semantic"
 	"github.com/influxdata/flux/values"
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/query/stdlib/influxdata/influxdb"
@@ -32,13 +33,9 @@ func NewReader(s Store) influxdb.Reader {
 }
 
 func (r *storeReader) Read(ctx context.Context, rs influxdb.ReadSpec, start, stop execute.Time) (influxdb.TableIterator, error) {
-	var predicate *datatypes.Predicate
-	if rs.Predicate != nil {
-		p, err := toStoragePredicate(rs.Predicate)
-		if err != nil {
-			return nil, err
-		}
-		predicate = p
+	predicate, err := readPredicate(rs.Predicate, rs.Scope)
+	if err != nil {
+		return nil, err
 	}
 
 	return &tableIterator{
@@ -50,6 +47,82 @@ func (r *storeReader) Read(ctx context.Context, rs influxdb.ReadSpec, start, sto
 	}, nil
 }
 
+func (r *storeReader) ReadTagKeys(ctx context.Context, spec influxdb.ReadTagKeysSpec, start, stop execute.Time) (cursors.StringIterator, error) {
+	var req datatypes.TagKeysRequest
+	var err error
+	if req.Source, req.Range, req.Predicate, err = r.schemaRequest(spec, start, stop); err != nil {
+		return nil, err
+	}
+	return r.s.TagKeys(ctx, &req)
+}
+
+func (r *storeReader) ReadTagValues(ctx context.Context, spec influxdb.ReadTagValuesSpec, start, stop execute.Time) (cursors.StringIterator, error) {
+	if spec.TagKey == measurementKey {
+		var req datatypes.MeasurementNamesRequest
+		var err error
+		if req.Source, req.Range, req.Predicate, err = r.schemaRequest(spec.ReadTagKeysSpec, start, stop); err != nil {
+			return nil, err
+		}
+		return r.s.MeasurementNames(ctx, &req)
+	}
+
+	var req datatypes.TagValuesRequest
+	var err error
+	if req.Source, req.Range, req.Predicate, err = r.schemaRequest(spec.ReadTagKeysSpec, start, stop); err != nil {
+		return nil, err
+	}
+	req.TagKey = spec.TagKey
+	return r.s.TagValues(ctx, &req)
+}
+
+// schemaRequest returns the source, time range and predicate shared by the
+// schema requests. The stop time is exclusive, whereas the end of the
+// request range is inclusive.
+func (r *storeReader) schemaRequest(spec influxdb.ReadTagKeysSpec, start, stop execute.Time) (*types.Any, datatypes.TimestampRange, *datatypes.Predicate, error) {
+	tr := datatypes.TimestampRange{Start: int64(start), End: int64(stop) - 1}
+
+	src, err := r.s.GetSource(influxdb.ReadSpec{
+		OrganizationID: spec.OrganizationID,
+		BucketID:       spec.BucketID,
+	})
+	if err != nil {
+		return nil, tr, nil, err
+	}
+
+	any, err := types.MarshalAny(src)
+	if err != nil {
+		return nil, tr, nil, err
+	}
+
+	predicate, err := readPredicate(spec.Predicate, spec.Scope)
+	if err != nil {
+		return nil, tr, nil, err
+	}
+	return any, tr, predicate, nil
+}
+
+// readPredicate returns the storage predicate of a read, restricted to the
+// series matching the textual scope predicate if it is not empty.
+func readPredicate(f *semantic.FunctionExpression, scope string) (*datatypes.Predicate, error) {
+	var predicate *datatypes.Predicate
+	if f != nil {
+		p, err := toStoragePredicate(f)
+		if err != nil {
+			return nil, err
+		}
+		predicate = p
+	}
+
+	if scope == "" {
+		return predicate, nil
+	}
+	p, err := ParsePredicate(scope)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scope of authorization: %v", err)
+	}
+	return AndPredicates(p, predicate), nil
+}
+
 func (r *storeReader) Close() {}
 
 type tableIterator struct {
@@ -94,8 +167,18 @@ func (bi *tableIterator) Do(f func(flux.Table) error) error {
 		return err
 	} else if agg != datatypes.AggregateTypeNone {
 		req.Aggregate = &datatypes.Aggregate{Type: agg}
+		if bi.readSpec.WindowEvery > 0 {
+			req.Window = &datatypes.Window{
+				Every:  bi.readSpec.WindowEvery,
+				Offset: bi.readSpec.WindowOffset,
+			}
+		}
 	}
 
+	// The tables of an aggregate that produces a single value per series have
+	// no time column, unless the aggregate selects a point or is windowed.
+	timeless := req.Aggregate != nil && req.Window == nil && !isSelectorAggregate(req.Aggregate)
+
 	switch {
 	case req.Group != datatypes.GroupAll:
 		rs, err := bi.s.GroupRead(bi.ctx, &req)
@@ -110,7 +193,7 @@ func (bi *tableIterator) Do(f func(flux.Table) error) error {
 		if req.Hints.NoPoints() {
 			return bi.handleGroupReadNoPoints(f, rs)
 		}
-		return bi.handleGroupRead(f, rs)
+		return bi.handleGroupRead(f, rs, timeless)
 
 	default:
 		rs, err := bi.s.Read(bi.ctx, &req)
@@ -125,11 +208,11 @@ func (bi *tableIterator) Do(f func(flux.Table) error) error {
 		if req.Hints.NoPoints() {
 			return bi.handleReadNoPoints(f, rs)
 		}
-		return bi.handleRead(f, rs)
+		return bi.handleRead(f, rs, timeless)
 	}
 }
 
-func (bi *tableIterator) handleRead(f func(flux.Table) error, rs ResultSet) error {
+func (bi *tableIterator) handleRead(f func(flux.Table) error, rs ResultSet, timeless bool) error {
 	// these resources must be closed if not nil on return
 	var (
 		cur   cursors.Cursor
@@ -176,6 +259,10 @@ READ:
 			panic(fmt.Sprintf("unreachable: %T", typedCur))
 		}
 
+		if timeless {
+			table = newAggregateTable(table)
+		}
+
 		cur = nil
 
 		if !table.Empty() {
@@ -243,7 +330,7 @@ READ:
 	return rs.Err()
 }
 
-func (bi *tableIterator) handleGroupRead(f func(flux.Table) error, rs GroupResultSet) error {
+func (bi *tableIterator) handleGroupRead(f func(flux.Table) error, rs GroupResultSet, timeless bool) error {
 	// these resources must be closed if not nil on return
 	var (
 		gc    GroupCursor
@@ -275,6 +362,9 @@ READ:
 		}
 
 		if cur == nil {
+			if err := gc.Err(); err != nil {
+				return err
+			}
 			gc.Close()
 			gc = rs.Next()
 			continue
@@ -302,6 +392,10 @@ READ:
 			panic(fmt.Sprintf("unreachable: %T", typedCur))
 		}
 
+		if timeless {
+			table = newAggregateTable(table)
+		}
+
 		// table owns these resources and is responsible for closing them
 		cur = nil
 		gc = nil
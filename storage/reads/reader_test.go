@@ -0,0 +1,76 @@
+package reads_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/influxdb/query/stdlib/influxdata/influxdb"
+	"github.com/influxdata/influxdb/storage/reads"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+)
+
+// tagKeysStore records the requests of tag keys.
+type tagKeysStore struct {
+	reads.Store
+	req *datatypes.TagKeysRequest
+}
+
+func (s *tagKeysStore) GetSource(rs influxdb.ReadSpec) (proto.Message, error) {
+	return &datatypes.ReadRequest{}, nil
+}
+
+func (s *tagKeysStore) TagKeys(ctx context.Context, req *datatypes.TagKeysRequest) (cursors.StringIterator, error) {
+	s.req = req
+	return cursors.EmptyStringIterator, nil
+}
+
+func TestReader_ReadTagKeys_Scope(t *testing.T) {
+	cases := []struct {
+		n     string
+		scope string
+		e     string
+		err   bool
+	}{
+		{
+			n: "not restricted",
+			e: `[none]`,
+		},
+		{
+			n:     "restricted",
+			scope: `_measurement=~/^public_/`,
+			e:     `'_m' =~ /^public_/`,
+		},
+		{
+			n:     "invalid scope",
+			scope: `_measurement=`,
+			err:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.n, func(t *testing.T) {
+			s := &tagKeysStore{}
+			r := reads.NewReader(s)
+			_, err := r.ReadTagKeys(context.Background(), influxdb.ReadTagKeysSpec{
+				OrganizationID: 1,
+				BucketID:       2,
+				Scope:          tc.scope,
+			}, execute.Time(0), execute.Time(10))
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, wanted := reads.PredicateToExprString(s.req.Predicate), tc.e; got != wanted {
+				t.Fatal("got:", got, "wanted:", wanted)
+			}
+		})
+	}
+}
@@ -10,27 +10,30 @@ import (
 
 type multiShardCursors interface {
 	createCursor(row SeriesRow) cursors.Cursor
-	newAggregateCursor(ctx context.Context, agg *datatypes.Aggregate, cursor cursors.Cursor) cursors.Cursor
+	newAggregateCursor(ctx context.Context, agg *datatypes.Aggregate, window *datatypes.Window, cursor cursors.Cursor) (cursors.Cursor, error)
 }
 
 type resultSet struct {
-	ctx context.Context
-	agg *datatypes.Aggregate
-	cur SeriesCursor
-	row SeriesRow
-	mb  multiShardCursors
+	ctx    context.Context
+	agg    *datatypes.Aggregate
+	window *datatypes.Window
+	cur    SeriesCursor
+	row    SeriesRow
+	mb     multiShardCursors
+	err    error
 }
 
 func NewResultSet(ctx context.Context, req *datatypes.ReadRequest, cur SeriesCursor) ResultSet {
 	return &resultSet{
-		ctx: ctx,
-		agg: req.Aggregate,
-		cur: cur,
-		mb:  newMultiShardArrayCursors(ctx, req.TimestampRange.Start, req.TimestampRange.End, !req.Descending, req.PointsLimit),
+		ctx:    ctx,
+		agg:    req.Aggregate,
+		window: req.Window,
+		cur:    cur,
+		mb:     newMultiShardArrayCursors(ctx, req.TimestampRange.Start, req.TimestampRange.End, !req.Descending, req.PointsLimit),
 	}
 }
 
-func (r *resultSet) Err() error { return nil }
+func (r *resultSet) Err() error { return r.err }
 
 // Close closes the result set. Close is idempotent.
 func (r *resultSet) Close() {
@@ -43,7 +46,7 @@ func (r *resultSet) Close() {
 
 // Next returns true if there are more results available.
 func (r *resultSet) Next() bool {
-	if r == nil {
+	if r == nil || r.err != nil {
 		return false
 	}
 
@@ -60,7 +63,7 @@ func (r *resultSet) Next() bool {
 func (r *resultSet) Cursor() cursors.Cursor {
 	cur := r.mb.createCursor(r.row)
 	if r.agg != nil {
-		cur = r.mb.newAggregateCursor(r.ctx, r.agg, cur)
+		cur, r.err = r.mb.newAggregateCursor(r.ctx, r.agg, r.window, cur)
 	}
 	return cur
 }
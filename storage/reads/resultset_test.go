@@ -0,0 +1,291 @@
+package reads_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/storage/reads"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+)
+
+func TestNewResultSet_Aggregate(t *testing.T) {
+	// Two blocks of points, so that the windows and aggregates span blocks.
+	blocks := []*cursors.IntegerArray{
+		{Timestamps: []int64{0, 10, 20, 30}, Values: []int64{3, 1, 4, 1}},
+		{Timestamps: []int64{40, 50, 90}, Values: []int64{5, 9, 2}},
+	}
+
+	tests := []struct {
+		name   string
+		agg    datatypes.Aggregate_AggregateType
+		window *datatypes.Window
+		end    int64
+		exp    interface{}
+	}{
+		{
+			name: "min",
+			agg:  datatypes.AggregateTypeMin,
+			exp:  &cursors.IntegerArray{Timestamps: []int64{10}, Values: []int64{1}},
+		},
+		{
+			name: "max",
+			agg:  datatypes.AggregateTypeMax,
+			exp:  &cursors.IntegerArray{Timestamps: []int64{50}, Values: []int64{9}},
+		},
+		{
+			name: "first",
+			agg:  datatypes.AggregateTypeFirst,
+			exp:  &cursors.IntegerArray{Timestamps: []int64{0}, Values: []int64{3}},
+		},
+		{
+			name: "last",
+			agg:  datatypes.AggregateTypeLast,
+			exp:  &cursors.IntegerArray{Timestamps: []int64{90}, Values: []int64{2}},
+		},
+		{
+			name: "mean",
+			agg:  datatypes.AggregateTypeMean,
+			exp:  &cursors.FloatArray{Timestamps: []int64{0}, Values: []float64{25.0 / 7}},
+		},
+		{
+			name:   "windowed count",
+			agg:    datatypes.AggregateTypeCount,
+			window: &datatypes.Window{Every: 25},
+			end:    100,
+			exp:    &cursors.IntegerArray{Timestamps: []int64{25, 50, 75, 100}, Values: []int64{3, 2, 1, 1}},
+		},
+		{
+			name:   "windowed max with offset",
+			agg:    datatypes.AggregateTypeMax,
+			window: &datatypes.Window{Every: 25, Offset: 5},
+			end:    95,
+			exp:    &cursors.IntegerArray{Timestamps: []int64{5, 30, 55, 95}, Values: []int64{3, 4, 9, 2}},
+		},
+		{
+			name:   "windowed first",
+			agg:    datatypes.AggregateTypeFirst,
+			window: &datatypes.Window{Every: 50},
+			end:    100,
+			exp:    &cursors.IntegerArray{Timestamps: []int64{50, 100}, Values: []int64{3, 9}},
+		},
+		{
+			name:   "windowed mean",
+			agg:    datatypes.AggregateTypeMean,
+			window: &datatypes.Window{Every: 50},
+			end:    100,
+			exp:    &cursors.FloatArray{Timestamps: []int64{50, 100}, Values: []float64{14.0 / 5, 5.5}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := newSeriesRows("m0,tag0=val00")
+			rows[0].Query = cursors.CursorIterators{&sliceCursorIterator{blocks: blocks}}
+
+			req := &datatypes.ReadRequest{
+				TimestampRange: datatypes.TimestampRange{End: tt.end},
+				PointsLimit:    math.MaxInt64,
+				Aggregate:      &datatypes.Aggregate{Type: tt.agg},
+				Window:         tt.window,
+			}
+			rs := reads.NewResultSet(context.Background(), req, &sliceSeriesCursor{rows: rows})
+			defer rs.Close()
+
+			if !rs.Next() {
+				t.Fatal("expected a series")
+			}
+
+			var got interface{}
+			switch cur := rs.Cursor().(type) {
+			case cursors.IntegerArrayCursor:
+				got = cur.Next()
+			case cursors.FloatArrayCursor:
+				got = cur.Next()
+			default:
+				t.Fatalf("unexpected cursor type %T", cur)
+			}
+
+			if !cmp.Equal(got, tt.exp) {
+				t.Errorf("unexpected points; -got/+exp\n%s", cmp.Diff(got, tt.exp))
+			}
+		})
+	}
+}
+
+func TestNewResultSet_AggregateUnsupportedType(t *testing.T) {
+	tests := []struct {
+		name   string
+		agg    datatypes.Aggregate_AggregateType
+		window *datatypes.Window
+		cur    func() cursors.Cursor
+		exp    string
+	}{
+		{
+			name: "min of strings",
+			agg:  datatypes.AggregateTypeMin,
+			cur:  newStringCursor,
+			exp:  "unsupported input type for min aggregate: string",
+		},
+		{
+			name: "max of booleans",
+			agg:  datatypes.AggregateTypeMax,
+			cur:  newBooleanCursor,
+			exp:  "unsupported input type for max aggregate: boolean",
+		},
+		{
+			name: "mean of strings",
+			agg:  datatypes.AggregateTypeMean,
+			cur:  newStringCursor,
+			exp:  "unsupported input type for mean aggregate: string",
+		},
+		{
+			name:   "windowed mean of booleans",
+			agg:    datatypes.AggregateTypeMean,
+			window: &datatypes.Window{Every: 10},
+			cur:    newBooleanCursor,
+			exp:    "unsupported input type for mean aggregate: boolean",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &datatypes.ReadRequest{
+				TimestampRange: datatypes.TimestampRange{End: 100},
+				PointsLimit:    math.MaxInt64,
+				Aggregate:      &datatypes.Aggregate{Type: tt.agg},
+				Window:         tt.window,
+			}
+
+			t.Run("series", func(t *testing.T) {
+				rows := newSeriesRows("m0,tag0=val00", "m0,tag0=val01")
+				for i := range rows {
+					rows[i].Query = cursors.CursorIterators{cursorIteratorFunc(tt.cur)}
+				}
+				rs := reads.NewResultSet(context.Background(), req, &sliceSeriesCursor{rows: rows})
+				defer rs.Close()
+
+				if !rs.Next() {
+					t.Fatal("expected a series")
+				}
+				if cur := rs.Cursor(); cur != nil {
+					t.Errorf("unexpected cursor %T", cur)
+				}
+				if rs.Next() {
+					t.Error("expected no more series after an error")
+				}
+				if err := rs.Err(); err == nil || err.Error() != tt.exp {
+					t.Errorf("unexpected error: got %v, exp %q", err, tt.exp)
+				}
+			})
+
+			t.Run("group", func(t *testing.T) {
+				newCursor := func() (reads.SeriesCursor, error) {
+					rows := newSeriesRows("m0,tag0=val00", "m0,tag0=val01")
+					for i := range rows {
+						rows[i].Query = cursors.CursorIterators{cursorIteratorFunc(tt.cur)}
+					}
+					return &sliceSeriesCursor{rows: rows}, nil
+				}
+
+				for _, group := range []datatypes.ReadRequest_Group{datatypes.GroupNone, datatypes.GroupBy} {
+					greq := *req
+					greq.Group = group
+					if group == datatypes.GroupBy {
+						greq.GroupKeys = []string{"tag0"}
+					}
+					rs := reads.NewGroupResultSet(context.Background(), &greq, newCursor)
+					gc := rs.Next()
+					if gc == nil || !gc.Next() {
+						t.Fatalf("expected a series in group %v", group)
+					}
+					if cur := gc.Cursor(); cur != nil {
+						t.Errorf("unexpected cursor %T in group %v", cur, group)
+					}
+					if gc.Next() {
+						t.Errorf("expected no more series after an error in group %v", group)
+					}
+					if err := gc.Err(); err == nil || err.Error() != tt.exp {
+						t.Errorf("unexpected error in group %v: got %v, exp %q", group, err, tt.exp)
+					}
+					gc.Close()
+					rs.Close()
+				}
+			})
+		})
+	}
+}
+
+// cursorIteratorFunc returns a new cursor for every request.
+type cursorIteratorFunc func() cursors.Cursor
+
+func (f cursorIteratorFunc) Next(ctx context.Context, r *cursors.CursorRequest) (cursors.Cursor, error) {
+	return f(), nil
+}
+
+func (f cursorIteratorFunc) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+func newStringCursor() cursors.Cursor {
+	return &stringArrayCursor{a: &cursors.StringArray{Timestamps: []int64{0, 10}, Values: []string{"a", "b"}}}
+}
+
+func newBooleanCursor() cursors.Cursor {
+	return &booleanArrayCursor{a: &cursors.BooleanArray{Timestamps: []int64{0, 10}, Values: []bool{true, false}}}
+}
+
+type stringArrayCursor struct {
+	a *cursors.StringArray
+}
+
+func (c *stringArrayCursor) Close()                     {}
+func (c *stringArrayCursor) Err() error                 { return nil }
+func (c *stringArrayCursor) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+func (c *stringArrayCursor) Next() *cursors.StringArray {
+	a := c.a
+	c.a = &cursors.StringArray{}
+	return a
+}
+
+type booleanArrayCursor struct {
+	a *cursors.BooleanArray
+}
+
+func (c *booleanArrayCursor) Close()                     {}
+func (c *booleanArrayCursor) Err() error                 { return nil }
+func (c *booleanArrayCursor) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+func (c *booleanArrayCursor) Next() *cursors.BooleanArray {
+	a := c.a
+	c.a = &cursors.BooleanArray{}
+	return a
+}
+
+type sliceCursorIterator struct {
+	blocks []*cursors.IntegerArray
+}
+
+func (s *sliceCursorIterator) Next(ctx context.Context, r *cursors.CursorRequest) (cursors.Cursor, error) {
+	return &sliceIntegerArrayCursor{blocks: s.blocks}, nil
+}
+
+func (s *sliceCursorIterator) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+type sliceIntegerArrayCursor struct {
+	blocks []*cursors.IntegerArray
+}
+
+func (c *sliceIntegerArrayCursor) Close()                     {}
+func (c *sliceIntegerArrayCursor) Err() error                 { return nil }
+func (c *sliceIntegerArrayCursor) Stats() cursors.CursorStats { return cursors.CursorStats{} }
+
+func (c *sliceIntegerArrayCursor) Next() *cursors.IntegerArray {
+	if len(c.blocks) == 0 {
+		return &cursors.IntegerArray{}
+	}
+	a := c.blocks[0]
+	c.blocks = c.blocks[1:]
+	return a
+}
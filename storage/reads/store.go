@@ -78,4 +78,22 @@ type Store interface {
 	Read(ctx context.Context, req *datatypes.ReadRequest) (ResultSet, error)
 	GroupRead(ctx context.Context, req *datatypes.ReadRequest) (GroupResultSet, error)
 	GetSource(rs influxdb.ReadSpec) (proto.Message, error)
+
+	// TagKeys returns the tag keys of the series that match the request.
+	TagKeys(ctx context.Context, req *datatypes.TagKeysRequest) (cursors.StringIterator, error)
+
+	// TagValues returns the values of req.TagKey for the series that match
+	// the request.
+	TagValues(ctx context.Context, req *datatypes.TagValuesRequest) (cursors.StringIterator, error)
+
+	// MeasurementNames returns the names of the measurements of the series
+	// that match the request.
+	MeasurementNames(ctx context.Context, req *datatypes.MeasurementNamesRequest) (cursors.StringIterator, error)
+
+	// MeasurementFields returns the fields and their types for the series of
+	// req.Measurement that match the request.
+	MeasurementFields(ctx context.Context, req *datatypes.MeasurementFieldsRequest) ([]cursors.MeasurementField, error)
+
+	// SeriesCardinality returns the number of series that match the request.
+	SeriesCardinality(ctx context.Context, req *datatypes.SeriesCardinalityRequest) (int64, error)
 }
@@ -253,6 +253,53 @@ func (t *groupTableNoPoints) Do(f func(flux.ColReader) error) error {
 
 func (t *groupTableNoPoints) Statistics() cursors.CursorStats { return cursors.CursorStats{} }
 
+// aggregateTable hides the _time column of a table, which matches the schema of
+// the tables produced by flux aggregates such as mean.
+type aggregateTable struct {
+	storageTable
+	cols []flux.ColMeta
+}
+
+func newAggregateTable(t storageTable) *aggregateTable {
+	cols := make([]flux.ColMeta, 0, len(t.Cols())-1)
+	cols = append(cols, t.Cols()[:timeColIdx]...)
+	cols = append(cols, t.Cols()[timeColIdx+1:]...)
+	return &aggregateTable{
+		storageTable: t,
+		cols:         cols,
+	}
+}
+
+func (t *aggregateTable) Cols() []flux.ColMeta { return t.cols }
+
+func (t *aggregateTable) Do(f func(flux.ColReader) error) error {
+	return t.storageTable.Do(func(cr flux.ColReader) error {
+		return f(&aggregateColReader{ColReader: cr, cols: t.cols})
+	})
+}
+
+// aggregateColReader maps the columns of an aggregateTable to the columns of
+// the underlying reader.
+type aggregateColReader struct {
+	flux.ColReader
+	cols []flux.ColMeta
+}
+
+func (cr *aggregateColReader) idx(j int) int {
+	if j >= timeColIdx {
+		return j + 1
+	}
+	return j
+}
+
+func (cr *aggregateColReader) Cols() []flux.ColMeta        { return cr.cols }
+func (cr *aggregateColReader) Bools(j int) *array.Boolean  { return cr.ColReader.Bools(cr.idx(j)) }
+func (cr *aggregateColReader) Ints(j int) *array.Int64     { return cr.ColReader.Ints(cr.idx(j)) }
+func (cr *aggregateColReader) UInts(j int) *array.Uint64   { return cr.ColReader.UInts(cr.idx(j)) }
+func (cr *aggregateColReader) Floats(j int) *array.Float64 { return cr.ColReader.Floats(cr.idx(j)) }
+func (cr *aggregateColReader) Strings(j int) *array.Binary { return cr.ColReader.Strings(cr.idx(j)) }
+func (cr *aggregateColReader) Times(j int) *array.Int64    { return cr.ColReader.Times(cr.idx(j)) }
+
 func (t *floatTable) toArrowBuffer(vs []float64) *array.Float64 {
 	return arrow.NewFloat(vs, &memory.Allocator{})
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/influxdata/influxdb/query/stdlib/influxdata/influxdb"
 	"github.com/influxdata/influxdb/storage"
 	"github.com/influxdata/influxdb/storage/reads"
+	"github.com/influxdata/influxdb/tsdb/tsm1"
 )
 
 // NewProxyQueryService returns a proxy query service based on the given queryController
@@ -47,5 +48,16 @@ func AddControllerConfigDependencies(
 		BucketLookup:       bucketLookupSvc,
 		OrganizationLookup: orgLookupSvc,
 		PointsWriter:       engine,
+		ParsePredicate:     parsePredicate,
 	})
 }
+
+// parsePredicate parses the predicate of a permission into one matching the
+// series keys of points.
+func parsePredicate(s string) (platform.Predicate, error) {
+	pred, err := reads.ParsePredicate(s)
+	if err != nil {
+		return nil, err
+	}
+	return tsm1.NewProtobufPredicate(pred)
+}
@@ -4,14 +4,19 @@ import (
 	"context"
 	"errors"
 	"math"
+	"sort"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
+	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/query/stdlib/influxdata/influxdb"
 	"github.com/influxdata/influxdb/storage"
 	"github.com/influxdata/influxdb/storage/reads"
 	"github.com/influxdata/influxdb/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+	"github.com/influxdata/influxql"
 )
 
 type store struct {
@@ -101,6 +106,142 @@ func (s *store) GroupRead(ctx context.Context, req *datatypes.ReadRequest) (read
 	return reads.NewGroupResultSet(ctx, req, newCursor), nil
 }
 
+func (s *store) TagKeys(ctx context.Context, req *datatypes.TagKeysRequest) (cursors.StringIterator, error) {
+	source, err := getSource(req.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	cond, err := schemaCondition(req.Predicate)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := schemaRange(req.Range)
+	itr, err := s.engine.TagKeys(ctx, platform.ID(source.OrganizationID), platform.ID(source.BucketID), start, end, cond)
+	if err != nil {
+		return nil, err
+	}
+
+	// The index stores the measurement and field as the _m and _f tags,
+	// which are presented to the caller as _measurement and _field.
+	keys := cursors.StringIteratorToSlice(itr)
+	for i, key := range keys {
+		switch key {
+		case tsdb.MeasurementTagKey:
+			keys[i] = measurementKey
+		case tsdb.FieldKeyTagKey:
+			keys[i] = fieldKey
+		}
+	}
+	sort.Strings(keys)
+	return cursors.NewStringSliceIterator(keys), nil
+}
+
+func (s *store) TagValues(ctx context.Context, req *datatypes.TagValuesRequest) (cursors.StringIterator, error) {
+	source, err := getSource(req.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	cond, err := schemaCondition(req.Predicate)
+	if err != nil {
+		return nil, err
+	}
+
+	tagKey := req.TagKey
+	switch tagKey {
+	case measurementKey:
+		tagKey = tsdb.MeasurementTagKey
+	case fieldKey:
+		tagKey = tsdb.FieldKeyTagKey
+	}
+
+	start, end := schemaRange(req.Range)
+	return s.engine.TagValues(ctx, platform.ID(source.OrganizationID), platform.ID(source.BucketID), tagKey, start, end, cond)
+}
+
+func (s *store) MeasurementNames(ctx context.Context, req *datatypes.MeasurementNamesRequest) (cursors.StringIterator, error) {
+	source, err := getSource(req.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	cond, err := schemaCondition(req.Predicate)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := schemaRange(req.Range)
+	return s.engine.MeasurementNames(ctx, platform.ID(source.OrganizationID), platform.ID(source.BucketID), start, end, cond)
+}
+
+func (s *store) MeasurementFields(ctx context.Context, req *datatypes.MeasurementFieldsRequest) ([]cursors.MeasurementField, error) {
+	source, err := getSource(req.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	cond, err := schemaCondition(req.Predicate)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := schemaRange(req.Range)
+	return s.engine.MeasurementFields(ctx, platform.ID(source.OrganizationID), platform.ID(source.BucketID), req.Measurement, start, end, cond)
+}
+
+func (s *store) SeriesCardinality(ctx context.Context, req *datatypes.SeriesCardinalityRequest) (int64, error) {
+	source, err := getSource(req.Source)
+	if err != nil {
+		return 0, err
+	}
+
+	cond, err := schemaCondition(req.Predicate)
+	if err != nil {
+		return 0, err
+	}
+
+	start, end := schemaRange(req.Range)
+	return s.engine.BucketSeriesCardinality(ctx, platform.ID(source.OrganizationID), platform.ID(source.BucketID), start, end, cond)
+}
+
+// schemaCondition converts the predicate of a schema request into an
+// expression that can be evaluated by the index. Comparisons of field values
+// are removed, as they cannot be answered without reading points.
+func schemaCondition(predicate *datatypes.Predicate) (influxql.Expr, error) {
+	root := predicate.GetRoot()
+	if root == nil {
+		return nil, nil
+	}
+
+	cond, err := reads.NodeToExpr(root, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if reads.HasFieldValueKey(cond) {
+		cond = influxql.Reduce(reads.RewriteExprRemoveFieldValue(influxql.CloneExpr(cond)), nil)
+		if reads.IsTrueBooleanLiteral(cond) {
+			cond = nil
+		}
+	}
+	return cond, nil
+}
+
+// schemaRange returns the time range of a schema request, where a zero
+// start or end leaves the range unbounded.
+func schemaRange(r datatypes.TimestampRange) (start, end int64) {
+	start, end = r.Start, r.End
+	if start == 0 {
+		start = models.MinNanoTime
+	}
+	if end == 0 {
+		end = models.MaxNanoTime
+	}
+	return start, end
+}
+
 // this is easier than fooling around with .proto files.
 
 type readSource struct {
@@ -121,12 +262,16 @@ func (s *store) GetSource(rs influxdb.ReadSpec) (proto.Message, error) {
 }
 
 func getReadSource(req *datatypes.ReadRequest) (*readSource, error) {
-	if req.ReadSource == nil {
+	return getSource(req.ReadSource)
+}
+
+func getSource(any *types.Any) (*readSource, error) {
+	if any == nil {
 		return nil, errors.New("missing read source")
 	}
 
 	var source readSource
-	if err := types.UnmarshalAny(req.ReadSource, &source); err != nil {
+	if err := types.UnmarshalAny(any, &source); err != nil {
 		return nil, err
 	}
 	return &source, nil
@@ -21,6 +21,25 @@ type Deleter interface {
 	DeleteBucketRange(orgID, bucketID platform.ID, min, max int64) error
 }
 
+// A PredicateDeleter implementation is capable of deleting the data matching a
+// predicate from a storage engine.
+type PredicateDeleter interface {
+	DeleteBucketRangePredicate(orgID, bucketID platform.ID, min, max int64, pred platform.Predicate) error
+}
+
+// A ShardGroupSetter implementation is capable of partitioning the data of a
+// bucket into shard groups, so that expired data can be dropped a shard group
+// at a time.
+type ShardGroupSetter interface {
+	SetShardGroupDuration(orgID, bucketID platform.ID, d time.Duration)
+}
+
+// A SeriesLimitSetter implementation is capable of limiting the number of
+// series of a bucket.
+type SeriesLimitSetter interface {
+	SetBucketMaxSeries(orgID, bucketID platform.ID, n int64)
+}
+
 // A BucketFinder is responsible for providing access to buckets via a filter.
 type BucketFinder interface {
 	FindBuckets(context.Context, platform.BucketFilter, ...platform.FindOptions) ([]*platform.Bucket, int, error)
@@ -77,6 +96,9 @@ func (s *retentionEnforcer) run() {
 		return
 	}
 
+	s.setShardGroups(buckets)
+	s.setSeriesLimits(buckets)
+
 	now := time.Now().UTC()
 	s.expireData(buckets, now)
 	s.metrics.CheckDuration.With(s.metrics.Labels()).Observe(time.Since(now).Seconds())
@@ -101,6 +123,12 @@ func (s *retentionEnforcer) expireData(buckets []*platform.Bucket, now time.Time
 		labels["bucket_id"] = b.ID.String()
 
 		max := now.Add(-b.RetentionPeriod).UnixNano()
+		if _, ok := s.Engine.(ShardGroupSetter); ok {
+			// Only delete whole shard groups, so that their files can be
+			// dropped rather than rewritten.
+			max = shardGroupStart(max, shardGroupDuration(b)) - 1
+		}
+
 		err := s.Engine.DeleteBucketRange(b.OrganizationID, b.ID, math.MinInt64, max)
 		if err != nil {
 			labels["status"] = "error"
@@ -114,6 +142,100 @@ func (s *retentionEnforcer) expireData(buckets []*platform.Bucket, now time.Time
 	}
 }
 
+// syncShardGroups sets the shard group durations of the buckets on the
+// storage engine.
+func (s *retentionEnforcer) syncShardGroups() {
+	if s == nil {
+		return // Not initialised
+	}
+
+	buckets, err := s.getBucketInformation()
+	if err != nil {
+		s.logger.Error("Unable to determine bucket information", zap.Error(err))
+		return
+	}
+	s.setShardGroups(buckets)
+}
+
+// setShardGroups sets the shard group durations of the buckets on the storage
+// engine, if it supports shard groups.
+func (s *retentionEnforcer) setShardGroups(buckets []*platform.Bucket) {
+	sg, ok := s.Engine.(ShardGroupSetter)
+	if !ok {
+		return
+	}
+
+	for _, b := range buckets {
+		sg.SetShardGroupDuration(b.OrganizationID, b.ID, partitionDuration(b))
+	}
+}
+
+// syncSeriesLimits sets the series limits of the buckets on the storage
+// engine.
+func (s *retentionEnforcer) syncSeriesLimits() {
+	if s == nil {
+		return // Not initialised
+	}
+
+	buckets, err := s.getBucketInformation()
+	if err != nil {
+		s.logger.Error("Unable to determine bucket information", zap.Error(err))
+		return
+	}
+	s.setSeriesLimits(buckets)
+}
+
+// setSeriesLimits sets the series limits of the buckets on the storage engine,
+// if it supports them.
+func (s *retentionEnforcer) setSeriesLimits(buckets []*platform.Bucket) {
+	sl, ok := s.Engine.(SeriesLimitSetter)
+	if !ok {
+		return
+	}
+
+	for _, b := range buckets {
+		sl.SetBucketMaxSeries(b.OrganizationID, b.ID, b.MaxSeries)
+	}
+}
+
+// partitionDuration returns the shard group duration the data of the bucket is
+// partitioned by, or zero if it is not partitioned. The data of buckets with
+// an infinite retention period is only partitioned if they set a shard group
+// duration, since it is never expired.
+func partitionDuration(b *platform.Bucket) time.Duration {
+	if b.RetentionPeriod == 0 && b.ShardGroupDuration <= 0 {
+		return 0
+	}
+	return shardGroupDuration(b)
+}
+
+// shardGroupDuration returns the shard group duration of the bucket. When the
+// bucket does not set one, the duration is derived from its retention period.
+func shardGroupDuration(b *platform.Bucket) time.Duration {
+	if b.ShardGroupDuration > 0 {
+		return b.ShardGroupDuration
+	}
+
+	switch {
+	case b.RetentionPeriod > 0 && b.RetentionPeriod < 2*24*time.Hour:
+		return time.Hour
+	case b.RetentionPeriod > 0 && b.RetentionPeriod <= 180*24*time.Hour:
+		return 24 * time.Hour
+	default:
+		return 7 * 24 * time.Hour
+	}
+}
+
+// shardGroupStart returns the start of the shard group of duration d that
+// contains the timestamp ts.
+func shardGroupStart(ts int64, d time.Duration) int64 {
+	start := ts - ts%int64(d)
+	if start > ts {
+		start -= int64(d)
+	}
+	return start
+}
+
 // getBucketInformation returns a slice of buckets to run retention on.
 func (s *retentionEnforcer) getBucketInformation() ([]*platform.Bucket, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), bucketAPITimeout)
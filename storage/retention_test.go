@@ -80,6 +80,49 @@ func TestRetentionService(t *testing.T) {
 	})
 }
 
+func TestRetentionService_ShardGroups(t *testing.T) {
+	engine := &ShardGroupTestEngine{TestEngine: NewTestEngine(), Durations: make(map[platform.ID]time.Duration)}
+	service := newRetentionEnforcer(engine, NewTestBucketFinder())
+	now := time.Date(2018, 4, 10, 23, 12, 33, 0, time.UTC)
+
+	buckets := []*platform.Bucket{
+		{OrganizationID: 1, ID: 1, RetentionPeriod: 3 * time.Hour},
+		{OrganizationID: 1, ID: 2, RetentionPeriod: 30 * 24 * time.Hour},
+		{OrganizationID: 1, ID: 3, RetentionPeriod: 3 * time.Hour, ShardGroupDuration: 10 * time.Minute},
+		{OrganizationID: 1, ID: 4},
+		{OrganizationID: 1, ID: 5, ShardGroupDuration: 2 * time.Hour},
+	}
+
+	service.setShardGroups(buckets)
+	expDurations := map[platform.ID]time.Duration{
+		1: time.Hour,
+		2: 24 * time.Hour,
+		3: 10 * time.Minute,
+		4: 0,
+		5: 2 * time.Hour,
+	}
+	if !reflect.DeepEqual(engine.Durations, expDurations) {
+		t.Fatalf("got durations %v, expected %v", engine.Durations, expDurations)
+	}
+
+	// Only whole shard groups are deleted.
+	gotTo := make(map[platform.ID]int64)
+	engine.DeleteBucketRangeFn = func(orgID, bucketID platform.ID, from, to int64) error {
+		gotTo[bucketID] = to
+		return nil
+	}
+	service.expireData(buckets, now)
+
+	expTo := map[platform.ID]int64{
+		1: time.Date(2018, 4, 10, 20, 0, 0, 0, time.UTC).UnixNano() - 1,
+		2: time.Date(2018, 3, 11, 0, 0, 0, 0, time.UTC).UnixNano() - 1,
+		3: time.Date(2018, 4, 10, 20, 10, 0, 0, time.UTC).UnixNano() - 1,
+	}
+	if !reflect.DeepEqual(gotTo, expTo) {
+		t.Fatalf("got deletes up to %v, expected %v", gotTo, expTo)
+	}
+}
+
 // genMeasurementName generates a random measurement name or panics.
 func genMeasurementName() []byte {
 	b := make([]byte, 16)
@@ -104,6 +147,15 @@ func (e *TestEngine) DeleteBucketRange(orgID, bucketID platform.ID, min, max int
 	return e.DeleteBucketRangeFn(orgID, bucketID, min, max)
 }
 
+type ShardGroupTestEngine struct {
+	*TestEngine
+	Durations map[platform.ID]time.Duration
+}
+
+func (e *ShardGroupTestEngine) SetShardGroupDuration(orgID, bucketID platform.ID, d time.Duration) {
+	e.Durations[bucketID] = d
+}
+
 type TestBucketFinder struct {
 	FindBucketsFn func(context.Context, platform.BucketFilter, ...platform.FindOptions) ([]*platform.Bucket, int, error)
 }
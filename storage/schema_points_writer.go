@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/pkg/bytesutil"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+// SchemaPointsWriter wraps an existing PointsWriter.
+//
+// SchemaPointsWriter drops the points written to buckets with an explicit
+// schema that do not conform to their measurement schemas, writes the other
+// points, and returns a tsdb.PartialWriteError describing the points dropped.
+type SchemaPointsWriter struct {
+	PointsWriter
+
+	buckets platform.BucketService
+}
+
+// NewSchemaPointsWriter returns a new SchemaPointsWriter that writes the
+// points conforming to the schemas of the buckets of s to w, which
+// typically will be an Engine.
+func NewSchemaPointsWriter(w PointsWriter, s platform.BucketService) *SchemaPointsWriter {
+	return &SchemaPointsWriter{
+		PointsWriter: w,
+		buckets:      s,
+	}
+}
+
+// WritePoints writes the points conforming to the schemas of their buckets.
+// The points must have been exploded by tsdb.ExplodePoints.
+func (w *SchemaPointsWriter) WritePoints(ctx context.Context, points []models.Point) error {
+	var (
+		buckets = make(map[[16]byte]*platform.Bucket)
+		valid   = points[:0:0]
+		dropped [][]byte
+		reasons = make(map[string]string)
+		reason  string
+	)
+	for _, p := range points {
+		var name [16]byte
+		copy(name[:], p.Name())
+
+		b, ok := buckets[name]
+		if !ok {
+			_, id := tsdb.DecodeName(name)
+			var err error
+			if b, err = w.buckets.FindBucketByID(ctx, id); err != nil && platform.ErrorCode(err) != platform.ENotFound {
+				return err
+			}
+			buckets[name] = b
+		}
+
+		if b == nil || !b.ExplicitSchema() {
+			valid = append(valid, p)
+			continue
+		}
+		if r := checkSchema(b, p); r != "" {
+			if reason == "" {
+				reason = r
+			}
+			key := p.Key()
+			dropped = append(dropped, key)
+			if _, ok := reasons[string(key)]; !ok {
+				reasons[string(key)] = r
+			}
+			continue
+		}
+		valid = append(valid, p)
+	}
+
+	if len(dropped) == 0 {
+		return w.PointsWriter.WritePoints(ctx, points)
+	}
+
+	var err error
+	if len(valid) > 0 {
+		err = w.PointsWriter.WritePoints(ctx, valid)
+	}
+	return mergePartialWriteError(err, reason, dropped, reasons)
+}
+
+// mergePartialWriteError returns the partial write error of the keys
+// dropped for their reasons, merged with err, the error writing the
+// other points.
+func mergePartialWriteError(err error, reason string, dropped [][]byte, reasons map[string]string) error {
+	pwe, ok := err.(tsdb.PartialWriteError)
+	if err != nil && !ok {
+		return err
+	}
+
+	for k, r := range pwe.DroppedReasons {
+		if _, ok := reasons[k]; !ok {
+			reasons[k] = r
+		}
+	}
+	keys := bytesutil.SortDedup(append(dropped, pwe.DroppedKeys...))
+	return tsdb.PartialWriteError{
+		Reason:         reason,
+		Dropped:        len(keys),
+		DroppedKeys:    keys,
+		DroppedReasons: reasons,
+	}
+}
+
+// checkSchema returns the reason the exploded point p does not conform to
+// the schema of the bucket b, or an empty string if it does.
+func checkSchema(b *platform.Bucket, p models.Point) string {
+	var (
+		measurement []byte
+		field       []byte
+		tags        []models.Tag
+	)
+	for _, t := range p.Tags() {
+		switch {
+		case bytes.Equal(t.Key, tsdb.MeasurementTagKeyBytes):
+			measurement = t.Value
+		case bytes.Equal(t.Key, tsdb.FieldKeyTagKeyBytes):
+			field = t.Value
+		default:
+			tags = append(tags, t)
+		}
+	}
+
+	s, ok := b.MeasurementSchema(string(measurement))
+	if !ok {
+		return fmt.Sprintf("measurement %q is not in the schema of bucket %q", measurement, b.Name)
+	}
+	for _, t := range tags {
+		if !s.HasTag(string(t.Key)) {
+			return fmt.Sprintf("tag %q is not in the schema of measurement %q", t.Key, measurement)
+		}
+	}
+
+	f, ok := s.Field(string(field))
+	if !ok {
+		return fmt.Sprintf("field %q is not in the schema of measurement %q", field, measurement)
+	}
+	iter := p.FieldIterator()
+	if !iter.Next() {
+		return fmt.Sprintf("point of measurement %q has no field", measurement)
+	}
+	if typ := schemaFieldType(iter.Type()); typ != f.Type {
+		return fmt.Sprintf("field %q of measurement %q has type %s, the schema requires %s", field, measurement, typ, f.Type)
+	}
+	return ""
+}
+
+func schemaFieldType(typ models.FieldType) platform.SchemaFieldType {
+	switch typ {
+	case models.Float:
+		return platform.SchemaFieldTypeFloat
+	case models.Integer:
+		return platform.SchemaFieldTypeInteger
+	case models.Unsigned:
+		return platform.SchemaFieldTypeUnsigned
+	case models.String:
+		return platform.SchemaFieldTypeString
+	case models.Boolean:
+		return platform.SchemaFieldTypeBoolean
+	}
+	return platform.SchemaFieldType(typ.String())
+}
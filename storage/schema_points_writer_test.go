@@ -0,0 +1,138 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/storage"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+func TestSchemaPointsWriter(t *testing.T) {
+	const (
+		orgID      = platform.ID(1)
+		implicitID = platform.ID(2)
+		explicitID = platform.ID(3)
+	)
+
+	buckets := mock.NewBucketService()
+	buckets.FindBucketByIDFn = func(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+		switch id {
+		case implicitID:
+			return &platform.Bucket{ID: id, Name: "implicit"}, nil
+		case explicitID:
+			return &platform.Bucket{
+				ID:         id,
+				Name:       "explicit",
+				SchemaType: platform.SchemaTypeExplicit,
+				MeasurementSchemas: []platform.MeasurementSchema{
+					{
+						Name: "cpu",
+						Tags: []string{"host"},
+						Fields: []platform.MeasurementSchemaField{
+							{Name: "usage", Type: platform.SchemaFieldTypeFloat},
+							{Name: "count", Type: platform.SchemaFieldTypeInteger},
+						},
+					},
+				},
+			}, nil
+		}
+		return nil, &platform.Error{Code: platform.ENotFound}
+	}
+
+	tests := []struct {
+		name    string
+		bucket  platform.ID
+		data    string
+		written int
+		dropped int
+		reason  string
+	}{
+		{
+			name:    "implicit schema",
+			bucket:  implicitID,
+			data:    "mem,region=west free=1i,x=\"y\" 1",
+			written: 2,
+		},
+		{
+			name:    "conforming points",
+			bucket:  explicitID,
+			data:    "cpu,host=a usage=1.5,count=3i 1\ncpu usage=2 2",
+			written: 3,
+		},
+		{
+			name:    "unknown measurement",
+			bucket:  explicitID,
+			data:    "cpu,host=a usage=1.5 1\nmem free=1i 1",
+			written: 1,
+			dropped: 1,
+			reason:  `measurement "mem" is not in the schema of bucket "explicit"`,
+		},
+		{
+			name:    "unknown tag",
+			bucket:  explicitID,
+			data:    "cpu,host=a,region=west usage=1.5 1",
+			dropped: 1,
+			reason:  `tag "region" is not in the schema of measurement "cpu"`,
+		},
+		{
+			name:    "unknown field",
+			bucket:  explicitID,
+			data:    "cpu,host=a usage=1.5,idle=2 1",
+			written: 1,
+			dropped: 1,
+			reason:  `field "idle" is not in the schema of measurement "cpu"`,
+		},
+		{
+			name:    "field type",
+			bucket:  explicitID,
+			data:    "cpu,host=a usage=1i 1\ncpu,host=b usage=2i 1",
+			dropped: 2,
+			reason:  `field "usage" of measurement "cpu" has type integer, the schema requires float`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points, err := models.ParsePointsString(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			exploded, err := tsdb.ExplodePoints(orgID, tt.bucket, points)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pw := &mock.PointsWriter{}
+			err = storage.NewSchemaPointsWriter(pw, buckets).WritePoints(context.Background(), exploded)
+			if tt.dropped == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+			} else {
+				pwe, ok := err.(tsdb.PartialWriteError)
+				if !ok {
+					t.Fatalf("expected partial write error, got %v", err)
+				}
+				if pwe.Dropped != tt.dropped || len(pwe.DroppedKeys) != tt.dropped {
+					t.Errorf("unexpected dropped points: got %d, want %d", pwe.Dropped, tt.dropped)
+				}
+				if pwe.Reason != tt.reason {
+					t.Errorf("unexpected reason: got %q, want %q", pwe.Reason, tt.reason)
+				}
+				for _, key := range pwe.DroppedKeys {
+					if pwe.DroppedReasons[string(key)] == "" {
+						t.Errorf("missing reason of dropped key %q", key)
+					}
+				}
+			}
+
+			if len(pw.Points) != tt.written {
+				t.Errorf("unexpected written points: got %d, want %d", len(pw.Points), tt.written)
+			}
+		})
+	}
+}
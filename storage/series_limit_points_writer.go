@@ -0,0 +1,206 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+// DefaultSeriesLimitRefreshInterval is the default interval at which the limits
+// of series of organizations, and the number of their series, are reloaded.
+const DefaultSeriesLimitRefreshInterval = 10 * time.Second
+
+// SeriesIndex is the index of the series written by a SeriesLimitPointsWriter.
+type SeriesIndex interface {
+	// OrganizationSeriesCardinality returns the number of series in the
+	// buckets of the organization.
+	OrganizationSeriesCardinality(orgID platform.ID) int64
+
+	// HasSeries returns true if the series of the name and tags exists.
+	HasSeries(name []byte, tags models.Tags) bool
+}
+
+// SeriesLimitPointsWriter wraps an existing PointsWriter.
+//
+// SeriesLimitPointsWriter drops the points that would create series beyond
+// the limit of series of their organizations, writes the other points, and
+// returns a tsdb.PartialWriteError describing the points dropped.
+type SeriesLimitPointsWriter struct {
+	PointsWriter
+
+	// RefreshInterval is the interval at which the limit of series of an
+	// organization, and the number of its series, are reloaded. The series
+	// created in between are counted as they are written.
+	RefreshInterval time.Duration
+
+	limits platform.OrgLimitsService
+	index  SeriesIndex
+
+	mu   sync.Mutex
+	orgs map[platform.ID]*orgSeries
+}
+
+// NewSeriesLimitPointsWriter returns a new SeriesLimitPointsWriter that writes
+// the points within the limits of series of l to w, which typically will be an
+// Engine indexing the series in idx.
+func NewSeriesLimitPointsWriter(w PointsWriter, l platform.OrgLimitsService, idx SeriesIndex) *SeriesLimitPointsWriter {
+	return &SeriesLimitPointsWriter{
+		PointsWriter:    w,
+		RefreshInterval: DefaultSeriesLimitRefreshInterval,
+		limits:          l,
+		index:           idx,
+		orgs:            make(map[platform.ID]*orgSeries),
+	}
+}
+
+// orgSeries is the number of series of an organization, and its limit.
+type orgSeries struct {
+	mu      sync.Mutex
+	max     int64 // 0 if unlimited
+	n       int64 // series indexed at the last refresh, and reserved since
+	pending int64 // series reserved by writes in progress
+	expires time.Time
+}
+
+// reserve reserves up to n series within the limit, and returns the number
+// of series reserved. They must be released with release once written.
+func (o *orgSeries) reserve(n int64) int64 {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if remaining := o.max - o.n; n > remaining {
+		n = remaining
+	}
+	if n < 0 {
+		n = 0
+	}
+	o.n += n
+	o.pending += n
+	return n
+}
+
+// release releases n series reserved by a write, once they are written.
+func (o *orgSeries) release(n int64) {
+	o.mu.Lock()
+	o.pending -= n
+	o.mu.Unlock()
+}
+
+// org returns the series of the organization and its limit, reloading them
+// if they are older than RefreshInterval.
+func (w *SeriesLimitPointsWriter) org(ctx context.Context, orgID platform.ID) (*orgSeries, int64, error) {
+	w.mu.Lock()
+	o, ok := w.orgs[orgID]
+	if !ok {
+		o = &orgSeries{}
+		w.orgs[orgID] = o
+	}
+	w.mu.Unlock()
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	now := time.Now()
+	if now.Before(o.expires) {
+		return o, o.max, nil
+	}
+
+	l, err := w.limits.FindOrgLimits(ctx, orgID)
+	if err != nil && platform.ErrorCode(err) != platform.ENotFound {
+		return nil, 0, err
+	}
+	o.max = 0
+	if l != nil {
+		o.max = l.MaxSeries
+	}
+	if o.max > 0 {
+		// The series of writes in progress may not be indexed yet, so they
+		// are counted until the next refresh.
+		o.n = w.index.OrganizationSeriesCardinality(orgID) + o.pending
+	}
+	o.expires = now.Add(w.RefreshInterval)
+	return o, o.max, nil
+}
+
+// WritePoints writes the points within the limits of series of their
+// organizations. The points must have been exploded by tsdb.ExplodePoints.
+func (w *SeriesLimitPointsWriter) WritePoints(ctx context.Context, points []models.Point) error {
+	var (
+		orgs    = make(map[platform.ID]*orgSeries)
+		max     = make(map[platform.ID]int64)
+		orgIDs  = make([]platform.ID, len(points))
+		created = make(map[platform.ID][]string)
+		isNew   = make(map[string]bool)
+	)
+	// Find the series the points would create in limited organizations.
+	for i, p := range points {
+		var name [16]byte
+		copy(name[:], p.Name())
+		orgID, _ := tsdb.DecodeName(name)
+		orgIDs[i] = orgID
+
+		if _, ok := orgs[orgID]; !ok {
+			o, n, err := w.org(ctx, orgID)
+			if err != nil {
+				return err
+			}
+			orgs[orgID], max[orgID] = o, n
+		}
+
+		if max[orgID] == 0 || w.index.HasSeries(p.Name(), p.Tags()) {
+			continue
+		}
+		key := string(p.Key())
+		if _, ok := isNew[key]; !ok {
+			isNew[key] = false
+			created[orgID] = append(created[orgID], key)
+		}
+	}
+
+	// Reserve the series within the limits of the organizations, in the
+	// order of the points. The series are reserved under the lock of their
+	// organization, so that concurrent writes do not exceed its limit.
+	for orgID, keys := range created {
+		o := orgs[orgID]
+		n := o.reserve(int64(len(keys)))
+		defer o.release(n)
+		for _, key := range keys[:n] {
+			isNew[key] = true
+		}
+	}
+
+	var (
+		valid   = points[:0:0]
+		dropped [][]byte
+		reasons = make(map[string]string)
+		reason  string
+	)
+	for i, p := range points {
+		key := p.Key()
+		if allowed, ok := isNew[string(key)]; !ok || allowed {
+			valid = append(valid, p)
+			continue
+		}
+
+		orgID := orgIDs[i]
+		r := fmt.Sprintf("organization %s exceeded its limit of %d series", orgID, max[orgID])
+		if reason == "" {
+			reason = r
+		}
+		dropped = append(dropped, key)
+		reasons[string(key)] = r
+	}
+
+	if len(dropped) == 0 {
+		return w.PointsWriter.WritePoints(ctx, points)
+	}
+
+	var err error
+	if len(valid) > 0 {
+		err = w.PointsWriter.WritePoints(ctx, valid)
+	}
+	return mergePartialWriteError(err, reason, dropped, reasons)
+}
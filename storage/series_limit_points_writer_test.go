@@ -0,0 +1,171 @@
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/mock"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/storage"
+	"github.com/influxdata/influxdb/tsdb"
+)
+
+type seriesIndex struct {
+	cardinality int64
+	series      map[string]bool
+	scans       int64
+}
+
+func (idx *seriesIndex) OrganizationSeriesCardinality(orgID platform.ID) int64 {
+	atomic.AddInt64(&idx.scans, 1)
+	return idx.cardinality
+}
+
+func (idx *seriesIndex) HasSeries(name []byte, tags models.Tags) bool {
+	return idx.series[string(models.MakeKey(name, tags))]
+}
+
+func TestSeriesLimitPointsWriter(t *testing.T) {
+	const (
+		limitedID   = platform.ID(1)
+		unlimitedID = platform.ID(2)
+		bucketID    = platform.ID(3)
+	)
+
+	limits := mock.NewOrgLimitsService()
+	limits.FindOrgLimitsFn = func(ctx context.Context, orgID platform.ID) (*platform.OrgLimits, error) {
+		if orgID == limitedID {
+			return &platform.OrgLimits{OrgID: orgID, MaxSeries: 3}, nil
+		}
+		return &platform.OrgLimits{OrgID: orgID}, nil
+	}
+
+	existing, err := models.ParsePointsString("cpu,host=a usage=1 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	existing, err = tsdb.ExplodePoints(limitedID, bucketID, existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		org     platform.ID
+		data    string
+		written int
+		dropped int
+	}{
+		{
+			name:    "unlimited organization",
+			org:     unlimitedID,
+			data:    "cpu,host=a usage=1 1\ncpu,host=b usage=1 1\ncpu,host=c usage=1 1\ncpu,host=d usage=1 1",
+			written: 4,
+		},
+		{
+			name:    "within limit",
+			org:     limitedID,
+			data:    "cpu,host=a usage=1 1\ncpu,host=b usage=1 1\ncpu,host=b usage=2 2",
+			written: 3,
+		},
+		{
+			name:    "exceeding limit",
+			org:     limitedID,
+			data:    "cpu,host=b usage=1 1\ncpu,host=c usage=1 1\ncpu,host=a usage=1 1\ncpu,host=b usage=2 2",
+			written: 3,
+			dropped: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points, err := models.ParsePointsString(tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			exploded, err := tsdb.ExplodePoints(tt.org, bucketID, points)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			idx := &seriesIndex{
+				cardinality: 2,
+				series:      map[string]bool{string(existing[0].Key()): true},
+			}
+			pw := &mock.PointsWriter{}
+			err = storage.NewSeriesLimitPointsWriter(pw, limits, idx).WritePoints(context.Background(), exploded)
+			if tt.dropped == 0 {
+				if err != nil {
+					t.Fatal(err)
+				}
+			} else {
+				pwe, ok := err.(tsdb.PartialWriteError)
+				if !ok {
+					t.Fatalf("expected partial write error, got %v", err)
+				}
+				if pwe.Dropped != tt.dropped {
+					t.Errorf("unexpected dropped points: got %d, want %d", pwe.Dropped, tt.dropped)
+				}
+				if want := "organization 0000000000000001 exceeded its limit of 3 series"; pwe.Reason != want {
+					t.Errorf("unexpected reason: got %q, want %q", pwe.Reason, want)
+				}
+			}
+
+			if len(pw.Points) != tt.written {
+				t.Errorf("unexpected written points: got %d, want %d", len(pw.Points), tt.written)
+			}
+		})
+	}
+}
+
+func TestSeriesLimitPointsWriter_Concurrent(t *testing.T) {
+	const (
+		orgID    = platform.ID(1)
+		bucketID = platform.ID(2)
+	)
+
+	var finds int64
+	limits := mock.NewOrgLimitsService()
+	limits.FindOrgLimitsFn = func(ctx context.Context, orgID platform.ID) (*platform.OrgLimits, error) {
+		atomic.AddInt64(&finds, 1)
+		return &platform.OrgLimits{OrgID: orgID, MaxSeries: 10}, nil
+	}
+	idx := &seriesIndex{}
+	pw := &mock.PointsWriter{}
+	w := storage.NewSeriesLimitPointsWriter(pw, limits, idx)
+
+	// The writers together cannot create more series than the limit.
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		points, err := models.ParsePointsString(fmt.Sprintf("cpu,host=%d usage=1 1", i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		points, err = tsdb.ExplodePoints(orgID, bucketID, points)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := w.WritePoints(context.Background(), points)
+			if _, ok := err.(tsdb.PartialWriteError); err != nil && !ok {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := len(pw.Points); got != 10 {
+		t.Errorf("unexpected written points: got %d, want 10", got)
+	}
+	// The limit and the number of series are loaded once per refresh interval.
+	if finds != 1 || idx.scans != 1 {
+		t.Errorf("expected the limits and series to be loaded once, got %d and %d", finds, idx.scans)
+	}
+}
@@ -0,0 +1,313 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/tsdb"
+	"github.com/influxdata/influxdb/tsdb/cursors"
+	"github.com/influxdata/influxql"
+	"go.uber.org/zap"
+)
+
+const (
+	// UsageMeasurement is the measurement of the points written for usage.
+	UsageMeasurement = "usage"
+
+	// DefaultUsageFlushInterval is the default interval at which the usage
+	// recorded is written to the usage bucket.
+	DefaultUsageFlushInterval = time.Minute
+)
+
+var (
+	_ platform.UsageService  = (*UsageService)(nil)
+	_ platform.UsageRecorder = (*UsageService)(nil)
+)
+
+// A UsageStore implementation is capable of reading the series of a bucket,
+// and of counting them.
+type UsageStore interface {
+	CreateSeriesCursor(ctx context.Context, req SeriesCursorRequest, cond influxql.Expr) (SeriesCursor, error)
+	CreateCursorIterator(ctx context.Context) (tsdb.CursorIterator, error)
+	BucketSeriesN(orgID, bucketID platform.ID) int64
+}
+
+// UsageService records the usage of organizations and buckets.
+//
+// UsageService counts the usage recorded in memory, and periodically writes
+// the counts as points to a bucket. The usage reported is read back from the
+// points of the bucket, so that its retention can be managed like any other
+// time series.
+//
+// The series usage of the buckets written is not counted, but read from the
+// store when the usage is written, and the latest value is reported.
+type UsageService struct {
+	Logger *zap.Logger
+
+	// FlushInterval is the interval at which the usage recorded is written.
+	FlushInterval time.Duration
+
+	writer   PointsWriter
+	store    UsageStore
+	orgID    platform.ID
+	bucketID platform.ID
+
+	mu     sync.Mutex
+	counts map[usageKey]map[platform.UsageMetric]int64
+
+	now     func() time.Time
+	closing chan struct{}
+	wg      sync.WaitGroup
+}
+
+// usageKey identifies the organization and bucket of usage. The bucket of
+// the usage of queries is zero.
+type usageKey struct {
+	orgID    platform.ID
+	bucketID platform.ID
+}
+
+// NewUsageService returns a new UsageService that writes the usage recorded to
+// the bucket bucketID of the organization orgID with w, and reads it from s.
+// Both typically will be an Engine.
+func NewUsageService(w PointsWriter, s UsageStore, orgID, bucketID platform.ID) *UsageService {
+	return &UsageService{
+		Logger:        zap.NewNop(),
+		FlushInterval: DefaultUsageFlushInterval,
+		writer:        w,
+		store:         s,
+		orgID:         orgID,
+		bucketID:      bucketID,
+		counts:        make(map[usageKey]map[platform.UsageMetric]int64),
+		now:           time.Now,
+	}
+}
+
+// Open starts writing the usage recorded every FlushInterval.
+func (s *UsageService) Open(ctx context.Context) error {
+	if s.closing != nil {
+		return nil
+	}
+	s.closing = make(chan struct{})
+
+	ticker := time.NewTicker(s.FlushInterval)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.closing:
+				return
+			case <-ticker.C:
+				if err := s.Flush(context.Background()); err != nil {
+					s.Logger.Error("Failed to write usage", zap.Error(err))
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Close stops writing the usage recorded periodically, and writes the usage
+// recorded since the last write.
+func (s *UsageService) Close() error {
+	if s.closing == nil {
+		return nil
+	}
+	close(s.closing)
+	s.wg.Wait()
+	s.closing = nil
+	return s.Flush(context.Background())
+}
+
+// RecordUsage adds the value of the usage u to the usage of its organization
+// and bucket since the last write. Series usage is ignored, as it is read
+// from the store.
+func (s *UsageService) RecordUsage(ctx context.Context, u platform.Usage) {
+	if u.OrganizationID == nil || u.Value == 0 || u.Type == platform.UsageSeries {
+		return
+	}
+	k := usageKey{orgID: *u.OrganizationID}
+	if u.BucketID != nil {
+		k.bucketID = *u.BucketID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.counts[k]
+	if !ok {
+		m = make(map[platform.UsageMetric]int64)
+		s.counts[k] = m
+	}
+	m[u.Type] += int64(u.Value)
+}
+
+// Flush writes the usage recorded since the last write as points to the bucket.
+// The usage is recorded again if the points cannot be written.
+func (s *UsageService) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	counts := s.counts
+	s.counts = make(map[usageKey]map[platform.UsageMetric]int64)
+	s.mu.Unlock()
+
+	if len(counts) == 0 {
+		return nil
+	}
+
+	now := s.now()
+	points := make([]models.Point, 0, len(counts))
+	for k, m := range counts {
+		tags := map[string]string{"orgID": k.orgID.String()}
+		if k.bucketID.Valid() {
+			tags["bucketID"] = k.bucketID.String()
+		}
+		fields := make(models.Fields, len(m)+1)
+		for metric, n := range m {
+			fields[string(metric)] = n
+		}
+		if k.bucketID.Valid() {
+			fields[string(platform.UsageSeries)] = s.store.BucketSeriesN(k.orgID, k.bucketID)
+		}
+
+		pt, err := models.NewPoint(UsageMeasurement, models.NewTags(tags), fields, now)
+		if err != nil {
+			return err
+		}
+		points = append(points, pt)
+	}
+
+	exploded, err := tsdb.ExplodePoints(s.orgID, s.bucketID, points)
+	if err == nil {
+		err = s.writer.WritePoints(ctx, exploded)
+	}
+	if err != nil {
+		s.mu.Lock()
+		for k, m := range counts {
+			for metric, n := range m {
+				if s.counts[k] == nil {
+					s.counts[k] = make(map[platform.UsageMetric]int64)
+				}
+				s.counts[k][metric] += n
+			}
+		}
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// GetUsage returns the usage written to the bucket matching the filter. The
+// usage of all organizations or buckets is summed if they are not filtered.
+// The series usage of each bucket is the latest value written in the range.
+func (s *UsageService) GetUsage(ctx context.Context, filter platform.UsageFilter) (map[platform.UsageMetric]*platform.Usage, error) {
+	usage := make(map[platform.UsageMetric]*platform.Usage, len(platform.UsageMetrics))
+	for _, metric := range platform.UsageMetrics {
+		usage[metric] = &platform.Usage{
+			OrganizationID: filter.OrgID,
+			BucketID:       filter.BucketID,
+			Type:           metric,
+		}
+	}
+
+	start, end := models.MinNanoTime, models.MaxNanoTime
+	if filter.Range != nil {
+		start, end = filter.Range.Start.UnixNano(), filter.Range.Stop.UnixNano()-1
+	}
+
+	sc, err := s.store.CreateSeriesCursor(ctx, SeriesCursorRequest{Name: tsdb.EncodeName(s.orgID, s.bucketID)}, nil)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInternal,
+			Op:   "storage/GetUsage",
+			Err:  err,
+		}
+	}
+	defer sc.Close()
+
+	itr, err := s.store.CreateCursorIterator(ctx)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInternal,
+			Op:   "storage/GetUsage",
+			Err:  err,
+		}
+	}
+
+	for {
+		row, err := sc.Next()
+		if err != nil {
+			return nil, &platform.Error{
+				Code: platform.EInternal,
+				Op:   "storage/GetUsage",
+				Err:  err,
+			}
+		}
+		if row == nil {
+			break
+		}
+
+		if !bytes.Equal(row.Tags.Get(tsdb.MeasurementTagKeyBytes), []byte(UsageMeasurement)) ||
+			!matchUsageTag(row.Tags, "orgID", filter.OrgID) ||
+			!matchUsageTag(row.Tags, "bucketID", filter.BucketID) {
+			continue
+		}
+
+		field := string(row.Tags.Get(tsdb.FieldKeyTagKeyBytes))
+		u, ok := usage[platform.UsageMetric(field)]
+		if !ok {
+			continue
+		}
+
+		c, err := itr.Next(ctx, &cursors.CursorRequest{
+			Name:      row.Name,
+			Tags:      row.Tags,
+			Field:     field,
+			Ascending: true,
+			StartTime: start,
+			EndTime:   end,
+		})
+		if err != nil {
+			return nil, &platform.Error{
+				Code: platform.EInternal,
+				Op:   "storage/GetUsage",
+				Err:  err,
+			}
+		}
+		ic, ok := c.(cursors.IntegerArrayCursor)
+		if !ok {
+			if c != nil {
+				c.Close()
+			}
+			continue
+		}
+		var sum, last int64
+		for a := ic.Next(); a.Len() > 0; a = ic.Next() {
+			for _, v := range a.Values {
+				sum += v
+			}
+			last = a.Values[a.Len()-1]
+		}
+		ic.Close()
+
+		if u.Type == platform.UsageSeries {
+			u.Value += float64(last)
+		} else {
+			u.Value += float64(sum)
+		}
+	}
+	return usage, nil
+}
+
+// matchUsageTag returns true if id is nil, or the value of the tag key of
+// the series is id.
+func matchUsageTag(tags models.Tags, key string, id *platform.ID) bool {
+	if id == nil {
+		return true
+	}
+	return string(tags.Get([]byte(key))) == id.String()
+}
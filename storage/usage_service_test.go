@@ -0,0 +1,97 @@
+package storage_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/storage"
+)
+
+func TestUsageService(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	var (
+		ctx     = context.Background()
+		orgA    = influxdb.ID(10)
+		orgB    = influxdb.ID(11)
+		bucketA = influxdb.ID(20)
+	)
+
+	s := storage.NewUsageService(engine.Engine, engine.Engine, engine.org, engine.bucket)
+	record := func(orgID influxdb.ID, bucketID *influxdb.ID, metric influxdb.UsageMetric, v float64) {
+		s.RecordUsage(ctx, influxdb.Usage{OrganizationID: &orgID, BucketID: bucketID, Type: metric, Value: v})
+	}
+
+	pt := models.MustNewPoint(
+		"cpu",
+		models.NewTags(map[string]string{"host": "server"}),
+		map[string]interface{}{"value": 1.0, "value2": 2.0},
+		time.Unix(1, 2),
+	)
+	if err := engine.Write1xPointsWithOrgBucket([]models.Point{pt}, orgA.String(), bucketA.String()); err != nil {
+		t.Fatal(err)
+	}
+
+	record(orgA, &bucketA, influxdb.UsageWriteRequestCount, 1)
+	record(orgA, &bucketA, influxdb.UsageSeries, 2)
+	record(orgA, &bucketA, influxdb.UsageWriteRequestBytes, 100)
+	record(orgA, &bucketA, influxdb.UsageWriteRequestBytes, 50)
+	record(orgA, nil, influxdb.UsageQueryRequestCount, 1)
+	record(orgB, nil, influxdb.UsageQueryRequestCount, 2)
+	if err := s.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Usage recorded later is added to the usage written before, except for
+	// the series, which are counted by the engine.
+	if err := engine.Write1xPointsWithOrgBucket([]models.Point{pt}, orgA.String(), bucketA.String()); err != nil {
+		t.Fatal(err)
+	}
+	record(orgA, &bucketA, influxdb.UsageWriteRequestCount, 1)
+	if err := s.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	usage, err := s.GetUsage(ctx, influxdb.UsageFilter{OrgID: &orgA})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for metric, want := range map[influxdb.UsageMetric]float64{
+		influxdb.UsageWriteRequestCount: 2,
+		influxdb.UsageWriteRequestBytes: 150,
+		influxdb.UsageQueryRequestCount: 1,
+		influxdb.UsageValues:            0,
+		influxdb.UsageSeries:            2,
+	} {
+		if got := usage[metric].Value; got != want {
+			t.Errorf("unexpected %s of org A: got %v, want %v", metric, got, want)
+		}
+	}
+
+	usage, err = s.GetUsage(ctx, influxdb.UsageFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := usage[influxdb.UsageQueryRequestCount].Value, float64(3); got != want {
+		t.Errorf("unexpected query count of all orgs: got %v, want %v", got, want)
+	}
+
+	usage, err = s.GetUsage(ctx, influxdb.UsageFilter{
+		OrgID: &orgA,
+		Range: &influxdb.Timespan{
+			Start: time.Now().Add(-2 * time.Hour),
+			Stop:  time.Now().Add(-time.Hour),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := usage[influxdb.UsageWriteRequestCount].Value; got != 0 {
+		t.Errorf("unexpected write count out of range: got %v", got)
+	}
+}
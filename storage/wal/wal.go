@@ -516,15 +516,23 @@ func (l *WAL) CloseSegment() error {
 // DeleteBucketRange deletes the data inside of the bucket between the two times, returning
 // the segment ID for the operation.
 func (l *WAL) DeleteBucketRange(orgID, bucketID influxdb.ID, min, max int64) (int, error) {
+	return l.DeleteBucketRangePredicate(orgID, bucketID, min, max, nil)
+}
+
+// DeleteBucketRangePredicate deletes the data inside of the bucket between the two times
+// that matches the marshaled predicate, returning the segment ID for the operation. A
+// nil predicate deletes all data in the range.
+func (l *WAL) DeleteBucketRangePredicate(orgID, bucketID influxdb.ID, min, max int64, pred []byte) (int, error) {
 	if !l.enabled {
 		return -1, nil
 	}
 
 	entry := &DeleteBucketRangeWALEntry{
-		OrgID:    orgID,
-		BucketID: bucketID,
-		Min:      min,
-		Max:      max,
+		OrgID:     orgID,
+		BucketID:  bucketID,
+		Min:       min,
+		Max:       max,
+		Predicate: pred,
 	}
 
 	id, err := l.writeToLog(entry)
@@ -991,6 +999,10 @@ type DeleteBucketRangeWALEntry struct {
 	OrgID    influxdb.ID
 	BucketID influxdb.ID
 	Min, Max int64
+
+	// Predicate is an optional marshaled predicate restricting the series
+	// that are deleted. It is empty when the whole range is deleted.
+	Predicate []byte
 }
 
 // MarshalBinary returns a binary representation of the entry in a new byte slice.
@@ -1001,7 +1013,7 @@ func (w *DeleteBucketRangeWALEntry) MarshalBinary() ([]byte, error) {
 
 // UnmarshalBinary deserializes the byte slice into w.
 func (w *DeleteBucketRangeWALEntry) UnmarshalBinary(b []byte) error {
-	if len(b) != 2*influxdb.IDLength+16 {
+	if len(b) < 2*influxdb.IDLength+16 {
 		return ErrWALCorrupt
 	}
 
@@ -1014,12 +1026,18 @@ func (w *DeleteBucketRangeWALEntry) UnmarshalBinary(b []byte) error {
 	w.Min = int64(binary.BigEndian.Uint64(b[2*influxdb.IDLength : 2*influxdb.IDLength+8]))
 	w.Max = int64(binary.BigEndian.Uint64(b[2*influxdb.IDLength+8 : 2*influxdb.IDLength+16]))
 
+	// Any remaining bytes are the marshaled predicate.
+	w.Predicate = nil
+	if rest := b[2*influxdb.IDLength+16:]; len(rest) > 0 {
+		w.Predicate = append([]byte(nil), rest...)
+	}
+
 	return nil
 }
 
 // MarshalSize returns the number of bytes the entry takes when marshaled.
 func (w *DeleteBucketRangeWALEntry) MarshalSize() int {
-	return 2*influxdb.IDLength + 16
+	return 2*influxdb.IDLength + 16 + len(w.Predicate)
 }
 
 // Encode converts the entry into a byte stream using b if it is large enough.
@@ -1043,6 +1061,7 @@ func (w *DeleteBucketRangeWALEntry) Encode(b []byte) ([]byte, error) {
 	copy(b[influxdb.IDLength:], bucketID)
 	binary.BigEndian.PutUint64(b[2*influxdb.IDLength:], uint64(w.Min))
 	binary.BigEndian.PutUint64(b[2*influxdb.IDLength+8:], uint64(w.Max))
+	copy(b[2*influxdb.IDLength+16:], w.Predicate)
 
 	return b[:sz], nil
 }
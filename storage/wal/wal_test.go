@@ -260,6 +260,53 @@ func TestWALWriter_DeleteBucketRange(t *testing.T) {
 	}
 }
 
+func TestWALWriter_DeleteBucketRangePredicate(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+	f := MustTempFile(dir)
+	w := NewWALSegmentWriter(f)
+
+	entry := &DeleteBucketRangeWALEntry{
+		OrgID:     influxdb.ID(1),
+		BucketID:  influxdb.ID(2),
+		Min:       3,
+		Max:       4,
+		Predicate: []byte("predicate"),
+	}
+
+	if err := w.Write(mustMarshalEntry(entry)); err != nil {
+		fatal(t, "write points", err)
+	}
+
+	if err := w.Flush(); err != nil {
+		fatal(t, "flush", err)
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		fatal(t, "seek", err)
+	}
+
+	r := NewWALSegmentReader(f)
+
+	if !r.Next() {
+		t.Fatalf("expected next, got false")
+	}
+
+	we, err := r.Read()
+	if err != nil {
+		fatal(t, "read entry", err)
+	}
+
+	e, ok := we.(*DeleteBucketRangeWALEntry)
+	if !ok {
+		t.Fatalf("expected DeleteBucketRangeWALEntry: got %#v", e)
+	}
+
+	if !reflect.DeepEqual(entry, e) {
+		t.Fatalf("expected %+v but got %+v", entry, e)
+	}
+}
+
 func TestWAL_ClosedSegments(t *testing.T) {
 	dir := MustTempDir()
 	defer os.RemoveAll(dir)
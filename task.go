@@ -34,6 +34,7 @@ type Task struct {
 	Every           string `json:"every,omitempty"`
 	Cron            string `json:"cron,omitempty"`
 	Offset          string `json:"offset,omitempty"`
+	CatchUp         string `json:"catchUp,omitempty"`
 	LatestCompleted string `json:"latestCompleted,omitempty"`
 	CreatedAt       string `json:"createdAt,omitempty"`
 	UpdatedAt       string `json:"updatedAt,omitempty"`
@@ -51,6 +52,25 @@ type Run struct {
 	Log          []Log  `json:"log"`
 }
 
+// Backfill is a request to run a task for each of its schedules within a time range of the past.
+// A backfill is reported until all of its runs have finished.
+type Backfill struct {
+	ID          ID     `json:"id,omitempty"`
+	TaskID      ID     `json:"taskID"`
+	Start       string `json:"start"`
+	Stop        string `json:"stop"`
+	RequestedAt string `json:"requestedAt,omitempty"`
+
+	// Runs is the number of runs of the task scheduled within the time range.
+	Runs int `json:"runs"`
+
+	// Completed is the number of runs that finished, whether they succeeded or not.
+	Completed int `json:"completed"`
+
+	// Running is the number of runs in progress.
+	Running int `json:"running"`
+}
+
 // Log represents a link to a log resource
 type Log struct {
 	Time    string `json:"time"`
@@ -98,6 +118,17 @@ type TaskService interface {
 	// ForceRun forces a run to occur with unix timestamp scheduledFor, to be executed as soon as possible.
 	// The value of scheduledFor may or may not align with the task's schedule.
 	ForceRun(ctx context.Context, taskID ID, scheduledFor int64) (*Run, error)
+
+	// CreateBackfill queues runs of the task for each of its schedules no earlier than start and no later than stop,
+	// Unix timestamps. A stop of zero is the time the backfill is created.
+	// The runs are executed as soon as possible, within the concurrency limit of the task.
+	CreateBackfill(ctx context.Context, taskID ID, start, stop int64) (*Backfill, error)
+
+	// FindBackfills returns the backfills of a task whose runs have not all finished, and their total count.
+	FindBackfills(ctx context.Context, taskID ID) ([]*Backfill, int, error)
+
+	// CancelBackfill drops the runs of a backfill that have not started, and cancels the ones in progress.
+	CancelBackfill(ctx context.Context, taskID, backfillID ID) error
 }
 
 // TaskCreate is the set of values to create a task.
@@ -154,6 +185,9 @@ func (t *TaskUpdate) UnmarshalJSON(data []byte) error {
 
 		Retry int64 `json:"retry,omitempty"`
 
+		// CatchUp is the catch-up policy of the task, i.e.: "skip" to skip the runs missed by the scheduler
+		CatchUp string `json:"catchUp,omitempty"`
+
 		Token string `json:"token,omitempty"`
 	}{}
 
@@ -166,6 +200,7 @@ func (t *TaskUpdate) UnmarshalJSON(data []byte) error {
 	t.Options.Offset = time.Duration(jo.Offset)
 	t.Options.Concurrency = jo.Concurrency
 	t.Options.Retry = jo.Retry
+	t.Options.CatchUp = jo.CatchUp
 	t.Flux = jo.Flux
 	t.Status = jo.Status
 	t.Token = jo.Token
@@ -192,6 +227,9 @@ func (t TaskUpdate) MarshalJSON() ([]byte, error) {
 
 		Retry int64 `json:"retry,omitempty"`
 
+		// CatchUp is the catch-up policy of the task.
+		CatchUp string `json:"catchUp,omitempty"`
+
 		Token string `json:"token,omitempty"`
 	}{}
 	jo.Name = t.Options.Name
@@ -200,6 +238,7 @@ func (t TaskUpdate) MarshalJSON() ([]byte, error) {
 	jo.Offset = flux.Duration(t.Options.Offset)
 	jo.Concurrency = t.Options.Concurrency
 	jo.Retry = t.Options.Retry
+	jo.CatchUp = t.Options.CatchUp
 	jo.Flux = t.Flux
 	jo.Status = t.Status
 	jo.Token = t.Token
@@ -232,7 +271,7 @@ func (t *TaskUpdate) UpdateFlux(oldFlux string) error {
 	if t.Options.Every != 0 && t.Options.Cron != "" {
 		return errors.New("cannot specify both every and cron")
 	}
-	op := make(map[string]ast.Expression, 4)
+	op := make(map[string]ast.Expression, 5)
 
 	if t.Options.Name != "" {
 		op["name"] = &ast.StringLiteral{Value: t.Options.Name}
@@ -248,6 +287,9 @@ func (t *TaskUpdate) UpdateFlux(oldFlux string) error {
 		d := ast.Duration{Magnitude: int64(t.Options.Offset), Unit: "ns"}
 		op["offset"] = &ast.DurationLiteral{Values: []ast.Duration{d}}
 	}
+	if t.Options.CatchUp != "" {
+		op["catchUp"] = &ast.StringLiteral{Value: t.Options.CatchUp}
+	}
 	if len(op) > 0 {
 		editFunc := func(opt *ast.OptionStatement) (ast.Expression, error) {
 			a, ok := opt.Assignment.(*ast.VariableAssignment)
@@ -272,6 +314,11 @@ func (t *TaskUpdate) UpdateFlux(oldFlux string) error {
 						delete(op, "offset")
 						p.Value = offset
 					}
+				case "catchUp":
+					if catchUp, ok := op["catchUp"]; ok && t.Options.CatchUp != "" {
+						delete(op, "catchUp")
+						p.Value = catchUp
+					}
 				case "every":
 					if every, ok := op["every"]; ok && t.Options.Every != 0 {
 						delete(op, "every")
@@ -237,6 +237,7 @@ func (s *Store) UpdateTask(ctx context.Context, req backend.UpdateTaskRequest) (
 			return err
 		}
 		stm.UpdatedAt = time.Now().Unix()
+		stm.UpdateOptions(op)
 		res.OldStatus = backend.TaskStatus(stm.Status)
 
 		if req.Status != "" {
@@ -652,6 +653,39 @@ func (s *Store) ManuallyRunTimeRange(_ context.Context, taskID platform.ID, star
 	return mRun, nil
 }
 
+func (s *Store) CancelManualRuns(_ context.Context, taskID, backfillID platform.ID) (bool, error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return false, err
+	}
+	var canceled bool
+
+	if err = s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		stmBytes := b.Bucket(taskMetaPath).Get(encodedID)
+		if stmBytes == nil {
+			return backend.ErrTaskNotFound
+		}
+		var stm backend.StoreTaskMeta
+		if err := stm.Unmarshal(stmBytes); err != nil {
+			return err
+		}
+		if canceled = stm.CancelManualRuns(backfillID); !canceled {
+			return nil
+		}
+
+		stmBytes, err := stm.Marshal()
+		if err != nil {
+			return err
+		}
+
+		return b.Bucket(taskMetaPath).Put(encodedID, stmBytes)
+	}); err != nil {
+		return false, err
+	}
+	return canceled, nil
+}
+
 // Close closes the store
 func (s *Store) Close() error {
 	return s.db.Close()
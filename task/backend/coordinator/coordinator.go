@@ -148,6 +148,20 @@ func (c *Coordinator) DeleteOrg(ctx context.Context, orgID platform.ID) error {
 	return c.Store.DeleteOrg(ctx, orgID)
 }
 
+func (c *Coordinator) ManuallyRunTimeRange(ctx context.Context, taskID platform.ID, start, end, requestedAt int64) (*backend.StoreTaskMetaManualRun, error) {
+	mr, err := c.Store.ManuallyRunTimeRange(ctx, taskID, start, end, requestedAt)
+	if err != nil {
+		return mr, err
+	}
+
+	// Start the requested runs without waiting for the next scheduled run of the task.
+	if err := c.sch.ScheduleManualRuns(taskID); err != nil && err != backend.ErrTaskNotClaimed {
+		return mr, err
+	}
+
+	return mr, nil
+}
+
 func (c *Coordinator) CancelRun(ctx context.Context, taskID, runID platform.ID) error {
 	return c.sch.CancelRun(ctx, taskID, runID)
 }
@@ -92,6 +92,10 @@ func (s *inmem) UpdateTask(_ context.Context, req UpdateTaskRequest) (UpdateTask
 			}
 		} else {
 			t.Script = req.Script
+			op, err = options.FromScript(t.Script)
+			if err != nil {
+				return res, err
+			}
 		}
 		t.Name = op.Name
 
@@ -109,6 +113,7 @@ func (s *inmem) UpdateTask(_ context.Context, req UpdateTaskRequest) (UpdateTask
 	}
 
 	stm.UpdatedAt = time.Now().Unix()
+	stm.UpdateOptions(op)
 	res.OldStatus = TaskStatus(stm.Status)
 
 	if req.Status != "" {
@@ -315,6 +320,23 @@ func (s *inmem) ManuallyRunTimeRange(_ context.Context, taskID platform.ID, star
 	return mr, nil
 }
 
+func (s *inmem) CancelManualRuns(_ context.Context, taskID, backfillID platform.ID) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stm, ok := s.meta[taskID]
+	if !ok {
+		return false, errors.New("task not found")
+	}
+
+	if !stm.CancelManualRuns(backfillID) {
+		return false, nil
+	}
+
+	s.meta[taskID] = stm
+	return true, nil
+}
+
 func (s *inmem) delete(ctx context.Context, id platform.ID, f func(StoreTask) platform.ID) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
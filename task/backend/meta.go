@@ -23,6 +23,7 @@ func NewStoreTaskMeta(req CreateTaskRequest, o options.Options) StoreTaskMeta {
 		EffectiveCron:   o.EffectiveCronString(),
 		Offset:          int32(o.Offset / time.Second),
 		AuthorizationID: uint64(req.AuthorizationID),
+		SkipMissedRuns:  o.SkipMissedRuns(),
 	}
 
 	if stm.Status == "" {
@@ -57,6 +58,15 @@ func (stm *StoreTaskMeta) AlignLatestCompleted() {
 
 }
 
+// UpdateOptions updates the fields of stm that are derived from the task options o,
+// after the script of the task changed.
+func (stm *StoreTaskMeta) UpdateOptions(o options.Options) {
+	stm.MaxConcurrency = int32(o.Concurrency)
+	stm.EffectiveCron = o.EffectiveCronString()
+	stm.Offset = int32(o.Offset / time.Second)
+	stm.SkipMissedRuns = o.SkipMissedRuns()
+}
+
 // FinishRun removes the run matching runID from m's CurrentlyRunning slice,
 // and if that run's Now value is greater than m's LatestCompleted value,
 // updates the value of LatestCompleted to the run's Now value.
@@ -130,6 +140,14 @@ func (stm *StoreTaskMeta) CreateNextRun(now int64, makeID func() (platform.ID, e
 		return RunCreation{}, RunNotYetDueError{DueAt: dueAt}
 	}
 
+	if stm.SkipMissedRuns {
+		// Skip the runs that were missed, up to the latest run that is due.
+		for next := sch.Next(nextScheduled); next.Unix()+int64(stm.Offset) <= now; next = sch.Next(next) {
+			nextScheduled = next
+		}
+		nextScheduledUnix = nextScheduled.Unix()
+	}
+
 	id, err := makeID()
 	if err != nil {
 		return RunCreation{}, err
@@ -171,6 +189,14 @@ func (stm *StoreTaskMeta) createNextRunFromQueue(now, nextDue int64, sch cron.Sc
 	}
 
 	runNow := sch.Next(time.Unix(latest, 0)).Unix()
+	if q.Start != q.End && runNow > q.End {
+		// No schedule is left within the time range. Drop the queue and move on to the next one.
+		stm.ManualRuns = append(stm.ManualRuns[:0], stm.ManualRuns[1:]...)
+		if len(stm.ManualRuns) == 0 {
+			return RunCreation{}, RunNotYetDueError{DueAt: nextDue}
+		}
+		return stm.createNextRunFromQueue(now, nextDue, sch, makeID)
+	}
 
 	// Already validated that we have room to create another run, in CreateNextRun.
 	id := platform.ID(q.RunID)
@@ -191,6 +217,7 @@ func (stm *StoreTaskMeta) createNextRunFromQueue(now, nextDue int64, sch cron.Sc
 		RangeStart:  q.Start,
 		RangeEnd:    q.End,
 		RequestedAt: q.RequestedAt,
+		BackfillID:  q.BackfillID,
 	})
 
 	if runNow >= q.End {
@@ -245,11 +272,7 @@ func (stm *StoreTaskMeta) ManuallyRunTimeRange(start, end, requestedAt int64, ma
 		return ErrManualQueueFull
 	}
 
-	lc := start - 1
-	if start == math.MinInt64 {
-		// Don't roll over in pathological case of starting at minimum int64.
-		lc = start
-	}
+	lc := stm.manualLatestCompleted(start, end)
 	for _, mr := range stm.ManualRuns {
 		if mr.Start == start && mr.End == end {
 			return RequestStillQueuedError{Start: start, End: end}
@@ -261,17 +284,159 @@ func (stm *StoreTaskMeta) ManuallyRunTimeRange(start, end, requestedAt int64, ma
 		LatestCompleted: lc,
 		RequestedAt:     requestedAt,
 	}
-	if start == end && makeID != nil {
+	if makeID != nil {
 		id, err := makeID()
 		if err != nil {
 			return err
 		}
-		run.RunID = uint64(id)
+		if start == end {
+			run.RunID = uint64(id)
+		} else {
+			run.BackfillID = uint64(id)
+		}
 	}
 	stm.ManualRuns = append(stm.ManualRuns, run)
 	return nil
 }
 
+// CancelManualRuns removes the manually run time range with the given backfill ID from the queue.
+// Runs already created from the time range are left running.
+//
+// If backfillID matched a time range, CancelManualRuns returns true. Otherwise it returns false.
+func (stm *StoreTaskMeta) CancelManualRuns(backfillID platform.ID) bool {
+	for i, mr := range stm.ManualRuns {
+		if platform.ID(mr.BackfillID) != backfillID {
+			continue
+		}
+
+		stm.ManualRuns = append(stm.ManualRuns[:i], stm.ManualRuns[i+1:]...)
+		return true
+	}
+	return false
+}
+
+// BackfillProgress is the progress of the runs of a manually run time range.
+type BackfillProgress struct {
+	BackfillID platform.ID
+
+	// Unix timestamps of the time range, and of when it was requested.
+	Start, End, RequestedAt int64
+
+	// Runs is the number of runs scheduled within the time range.
+	Runs int
+
+	// Completed is the number of runs that finished, whether they succeeded or not.
+	Completed int
+
+	// Running is the number of runs in progress.
+	Running int
+}
+
+// Backfills returns the progress of the manually run time ranges whose runs have not all finished yet,
+// in the order they were requested.
+func (stm *StoreTaskMeta) Backfills() ([]BackfillProgress, error) {
+	sch, err := cron.Parse(stm.EffectiveCron)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		bs     []BackfillProgress
+		index  = make(map[uint64]int)
+		latest = make(map[uint64]int64) // Latest run created from queued time ranges.
+	)
+	for _, mr := range stm.ManualRuns {
+		if mr.BackfillID == 0 {
+			continue
+		}
+		index[mr.BackfillID] = len(bs)
+		latest[mr.BackfillID] = mr.LatestCompleted
+		bs = append(bs, BackfillProgress{
+			BackfillID:  platform.ID(mr.BackfillID),
+			Start:       mr.Start,
+			End:         mr.End,
+			RequestedAt: mr.RequestedAt,
+		})
+	}
+	for _, r := range stm.CurrentlyRunning {
+		if r.BackfillID == 0 {
+			continue
+		}
+		i, ok := index[r.BackfillID]
+		if !ok {
+			// Every run of the time range was created, and the queue was dropped.
+			i = len(bs)
+			index[r.BackfillID] = i
+			bs = append(bs, BackfillProgress{
+				BackfillID:  platform.ID(r.BackfillID),
+				Start:       r.RangeStart,
+				End:         r.RangeEnd,
+				RequestedAt: r.RequestedAt,
+			})
+		}
+		bs[i].Running++
+		if lc, ok := latest[r.BackfillID]; ok && r.Now > lc {
+			latest[r.BackfillID] = r.Now
+		}
+	}
+
+	for i := range bs {
+		b := &bs[i]
+		lc := stm.manualLatestCompleted(b.Start, b.End)
+		b.Runs = countSchedules(sch, lc, b.End)
+
+		created := b.Runs
+		if l, ok := latest[uint64(b.BackfillID)]; ok {
+			// Runs are created in schedule order, so every run up to the latest is either finished or running.
+			created = countSchedules(sch, lc, l)
+		}
+		b.Completed = created - b.Running
+		if b.Completed < 0 {
+			b.Completed = 0
+		}
+	}
+	return bs, nil
+}
+
+// countSchedules returns the number of times scheduled by sch after the Unix timestamp after,
+// and no later than the Unix timestamp until.
+func countSchedules(sch cron.Schedule, after, until int64) int {
+	n := 0
+	for t := sch.Next(time.Unix(after, 0)); !t.IsZero() && t.Unix() <= until; t = sch.Next(t) {
+		n++
+	}
+	return n
+}
+
+// manualLatestCompleted returns the initial latest completed timestamp of a manually run time range.
+func (stm *StoreTaskMeta) manualLatestCompleted(start, end int64) int64 {
+	if start == math.MinInt64 {
+		// Don't roll over in pathological case of starting at minimum int64.
+		return start
+	}
+
+	lc := start - 1
+	if every, ok := stm.every(); ok && start != end {
+		// Align the runs of a time range on the task's schedule,
+		// the earliest run being the earliest schedule no earlier than start.
+		lc = time.Unix(lc, 0).Truncate(every).Unix()
+	}
+	return lc
+}
+
+// every returns the period of the task's schedule, if the task runs at a fixed period.
+func (stm *StoreTaskMeta) every() (time.Duration, bool) {
+	if !strings.HasPrefix(stm.EffectiveCron, "@every ") {
+		return 0, false
+	}
+
+	every, err := time.ParseDuration(strings.TrimPrefix(stm.EffectiveCron, "@every "))
+	if err != nil || every <= 0 {
+		return 0, false
+	}
+	return every, true
+}
+
 // Equal returns true if all of stm's fields compare equal to other.
 // Note that this method operates on values, unlike the other methods which operate on pointers.
 //
@@ -282,6 +447,7 @@ func (stm StoreTaskMeta) Equal(other StoreTaskMeta) bool {
 		stm.Status != other.Status ||
 		stm.EffectiveCron != other.EffectiveCron ||
 		stm.Offset != other.Offset ||
+		stm.SkipMissedRuns != other.SkipMissedRuns ||
 		len(stm.CurrentlyRunning) != len(other.CurrentlyRunning) ||
 		len(stm.ManualRuns) != len(other.ManualRuns) {
 		return false
@@ -295,7 +461,8 @@ func (stm StoreTaskMeta) Equal(other StoreTaskMeta) bool {
 			s.RunID != o.RunID ||
 			s.RangeStart != o.RangeStart ||
 			s.RangeEnd != o.RangeEnd ||
-			s.RequestedAt != o.RequestedAt {
+			s.RequestedAt != o.RequestedAt ||
+			s.BackfillID != o.BackfillID {
 			return false
 		}
 	}
@@ -306,7 +473,8 @@ func (stm StoreTaskMeta) Equal(other StoreTaskMeta) bool {
 		if s.Start != o.Start ||
 			s.End != o.End ||
 			s.LatestCompleted != o.LatestCompleted ||
-			s.RequestedAt != o.RequestedAt {
+			s.RequestedAt != o.RequestedAt ||
+			s.BackfillID != o.BackfillID {
 			return false
 		}
 	}
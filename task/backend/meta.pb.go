@@ -41,13 +41,16 @@ type StoreTaskMeta struct {
 	// The Authorization ID associated with the task.
 	AuthorizationID uint64                    `protobuf:"varint,9,opt,name=authorization_id,json=authorizationId,proto3" json:"authorization_id,omitempty"`
 	ManualRuns      []*StoreTaskMetaManualRun `protobuf:"bytes,16,rep,name=manual_runs,json=manualRuns,proto3" json:"manual_runs,omitempty"`
+	// skip_missed_runs is set when the task's catch-up policy skips the runs missed by the scheduler,
+	// executing only the latest due run instead.
+	SkipMissedRuns bool `protobuf:"varint,17,opt,name=skip_missed_runs,json=skipMissedRuns,proto3" json:"skip_missed_runs,omitempty"`
 }
 
 func (m *StoreTaskMeta) Reset()         { *m = StoreTaskMeta{} }
 func (m *StoreTaskMeta) String() string { return proto.CompactTextString(m) }
 func (*StoreTaskMeta) ProtoMessage()    {}
 func (*StoreTaskMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_meta_841ef32afee093f0, []int{0}
+	return fileDescriptor_meta_b4a06e8fc7b65dc4, []int{0}
 }
 func (m *StoreTaskMeta) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -146,6 +149,13 @@ func (m *StoreTaskMeta) GetManualRuns() []*StoreTaskMetaManualRun {
 	return nil
 }
 
+func (m *StoreTaskMeta) GetSkipMissedRuns() bool {
+	if m != nil {
+		return m.SkipMissedRuns
+	}
+	return false
+}
+
 type StoreTaskMetaRun struct {
 	// now is the unix timestamp of the "now" value for the run.
 	Now   int64  `protobuf:"varint,1,opt,name=now,proto3" json:"now,omitempty"`
@@ -158,13 +168,15 @@ type StoreTaskMetaRun struct {
 	// requested_at is the unix timestamp indicating when this run was requested.
 	// It is the same value as the "parent" StoreTaskMetaManualRun, if this run was the result of a manual request.
 	RequestedAt int64 `protobuf:"varint,6,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	// backfill_id is the backfill_id of the "parent" StoreTaskMetaManualRun, if this run was the result of a manual request.
+	BackfillID uint64 `protobuf:"varint,7,opt,name=backfill_id,json=backfillId,proto3" json:"backfill_id,omitempty"`
 }
 
 func (m *StoreTaskMetaRun) Reset()         { *m = StoreTaskMetaRun{} }
 func (m *StoreTaskMetaRun) String() string { return proto.CompactTextString(m) }
 func (*StoreTaskMetaRun) ProtoMessage()    {}
 func (*StoreTaskMetaRun) Descriptor() ([]byte, []int) {
-	return fileDescriptor_meta_841ef32afee093f0, []int{1}
+	return fileDescriptor_meta_b4a06e8fc7b65dc4, []int{1}
 }
 func (m *StoreTaskMetaRun) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -235,6 +247,13 @@ func (m *StoreTaskMetaRun) GetRequestedAt() int64 {
 	return 0
 }
 
+func (m *StoreTaskMetaRun) GetBackfillID() uint64 {
+	if m != nil {
+		return m.BackfillID
+	}
+	return 0
+}
+
 // StoreTaskMetaManualRun indicates a manually requested run for a time range.
 // It has a start and end pair of unix timestamps indicating the time range covered by the request.
 type StoreTaskMetaManualRun struct {
@@ -248,13 +267,15 @@ type StoreTaskMetaManualRun struct {
 	RequestedAt int64 `protobuf:"varint,4,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
 	// run_id is set ahead of time for retries of individual runs. Manually run time ranges do not receive an ID.
 	RunID uint64 `protobuf:"varint,5,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	// backfill_id identifies a manually run time range, so that it can be tracked and canceled.
+	BackfillID uint64 `protobuf:"varint,6,opt,name=backfill_id,json=backfillId,proto3" json:"backfill_id,omitempty"`
 }
 
 func (m *StoreTaskMetaManualRun) Reset()         { *m = StoreTaskMetaManualRun{} }
 func (m *StoreTaskMetaManualRun) String() string { return proto.CompactTextString(m) }
 func (*StoreTaskMetaManualRun) ProtoMessage()    {}
 func (*StoreTaskMetaManualRun) Descriptor() ([]byte, []int) {
-	return fileDescriptor_meta_841ef32afee093f0, []int{2}
+	return fileDescriptor_meta_b4a06e8fc7b65dc4, []int{2}
 }
 func (m *StoreTaskMetaManualRun) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -318,6 +339,13 @@ func (m *StoreTaskMetaManualRun) GetRunID() uint64 {
 	return 0
 }
 
+func (m *StoreTaskMetaManualRun) GetBackfillID() uint64 {
+	if m != nil {
+		return m.BackfillID
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*StoreTaskMeta)(nil), "com.influxdata.platform.task.backend.StoreTaskMeta")
 	proto.RegisterType((*StoreTaskMetaRun)(nil), "com.influxdata.platform.task.backend.StoreTaskMetaRun")
@@ -406,6 +434,18 @@ func (m *StoreTaskMeta) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if m.SkipMissedRuns {
+		dAtA[i] = 0x88
+		i++
+		dAtA[i] = 0x1
+		i++
+		if m.SkipMissedRuns {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i++
+	}
 	return i, nil
 }
 
@@ -454,6 +494,11 @@ func (m *StoreTaskMetaRun) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintMeta(dAtA, i, uint64(m.RequestedAt))
 	}
+	if m.BackfillID != 0 {
+		dAtA[i] = 0x38
+		i++
+		i = encodeVarintMeta(dAtA, i, uint64(m.BackfillID))
+	}
 	return i, nil
 }
 
@@ -497,6 +542,11 @@ func (m *StoreTaskMetaManualRun) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintMeta(dAtA, i, uint64(m.RunID))
 	}
+	if m.BackfillID != 0 {
+		dAtA[i] = 0x30
+		i++
+		i = encodeVarintMeta(dAtA, i, uint64(m.BackfillID))
+	}
 	return i, nil
 }
 
@@ -553,6 +603,9 @@ func (m *StoreTaskMeta) Size() (n int) {
 			n += 2 + l + sovMeta(uint64(l))
 		}
 	}
+	if m.SkipMissedRuns {
+		n += 3
+	}
 	return n
 }
 
@@ -580,6 +633,9 @@ func (m *StoreTaskMetaRun) Size() (n int) {
 	if m.RequestedAt != 0 {
 		n += 1 + sovMeta(uint64(m.RequestedAt))
 	}
+	if m.BackfillID != 0 {
+		n += 1 + sovMeta(uint64(m.BackfillID))
+	}
 	return n
 }
 
@@ -604,6 +660,9 @@ func (m *StoreTaskMetaManualRun) Size() (n int) {
 	if m.RunID != 0 {
 		n += 1 + sovMeta(uint64(m.RunID))
 	}
+	if m.BackfillID != 0 {
+		n += 1 + sovMeta(uint64(m.BackfillID))
+	}
 	return n
 }
 
@@ -883,6 +942,26 @@ func (m *StoreTaskMeta) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SkipMissedRuns", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SkipMissedRuns = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMeta(dAtA[iNdEx:])
@@ -1047,6 +1126,25 @@ func (m *StoreTaskMetaRun) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BackfillID", wireType)
+			}
+			m.BackfillID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BackfillID |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMeta(dAtA[iNdEx:])
@@ -1192,6 +1290,25 @@ func (m *StoreTaskMetaManualRun) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BackfillID", wireType)
+			}
+			m.BackfillID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMeta
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BackfillID |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMeta(dAtA[iNdEx:])
@@ -1318,42 +1435,46 @@ var (
 	ErrIntOverflowMeta   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("meta.proto", fileDescriptor_meta_841ef32afee093f0) }
+func init() { proto.RegisterFile("meta.proto", fileDescriptor_meta_b4a06e8fc7b65dc4) }
 
-var fileDescriptor_meta_841ef32afee093f0 = []byte{
-	// 543 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x93, 0x41, 0x6f, 0xd3, 0x30,
-	0x14, 0xc7, 0x1b, 0xd2, 0x74, 0xab, 0x4b, 0xd7, 0x60, 0xa6, 0x29, 0x02, 0x91, 0x66, 0x15, 0x88,
-	0x72, 0x09, 0x12, 0x48, 0x9c, 0x10, 0x52, 0x57, 0x38, 0xec, 0xb0, 0x8b, 0xc7, 0x09, 0x09, 0x45,
-	0x5e, 0xe2, 0x94, 0xa8, 0x89, 0x5d, 0x9c, 0x67, 0x68, 0xf9, 0x14, 0x7c, 0x14, 0xae, 0x7c, 0x03,
-	0x8e, 0x3b, 0x72, 0x9a, 0x50, 0xfb, 0x35, 0x38, 0x20, 0x3b, 0x69, 0xd9, 0x46, 0x0f, 0x68, 0xb7,
-	0xe7, 0xdf, 0x8b, 0x9f, 0xdf, 0xff, 0xff, 0x5e, 0x10, 0x2a, 0x18, 0xd0, 0x70, 0x26, 0x05, 0x08,
-	0xfc, 0x30, 0x16, 0x45, 0x98, 0xf1, 0x34, 0x57, 0xf3, 0x84, 0x6a, 0x9a, 0x53, 0x48, 0x85, 0x2c,
-	0x42, 0xa0, 0xe5, 0x34, 0x3c, 0xa3, 0xf1, 0x94, 0xf1, 0xe4, 0xde, 0xfe, 0x44, 0x4c, 0x84, 0xb9,
-	0xf0, 0x54, 0x47, 0xd5, 0xdd, 0xc1, 0x6f, 0x1b, 0x75, 0x4f, 0x41, 0x48, 0xf6, 0x96, 0x96, 0xd3,
-	0x13, 0x06, 0x14, 0x3f, 0x46, 0xbd, 0x82, 0xce, 0xa3, 0x58, 0xf0, 0x58, 0x49, 0xc9, 0x78, 0xbc,
-	0xf0, 0xac, 0xc0, 0x1a, 0x3a, 0x64, 0xaf, 0xa0, 0xf3, 0xf1, 0x5f, 0x8a, 0x9f, 0x20, 0x37, 0xa7,
-	0xc0, 0x4a, 0x88, 0x62, 0x51, 0xcc, 0x72, 0x06, 0x2c, 0xf1, 0x6e, 0x05, 0xd6, 0xd0, 0x26, 0xbd,
-	0x8a, 0x8f, 0xd7, 0x18, 0x1f, 0xa0, 0x56, 0x09, 0x14, 0x54, 0xe9, 0xd9, 0x81, 0x35, 0x6c, 0x93,
-	0xfa, 0x84, 0x63, 0x74, 0xa7, 0x2a, 0x07, 0xf9, 0x22, 0x92, 0x8a, 0xf3, 0x8c, 0x4f, 0xbc, 0x66,
-	0x60, 0x0f, 0x3b, 0xcf, 0x5e, 0x84, 0xff, 0xa3, 0x2a, 0xbc, 0xd2, 0x3b, 0x51, 0x9c, 0xb8, 0x9b,
-	0x82, 0xa4, 0xaa, 0x87, 0x1f, 0xa1, 0x3d, 0x96, 0xa6, 0x2c, 0x86, 0xec, 0x13, 0x8b, 0x62, 0x29,
-	0xb8, 0xe7, 0x98, 0x26, 0xba, 0x1b, 0x3a, 0x96, 0x82, 0xeb, 0x1e, 0x45, 0x9a, 0x96, 0x0c, 0xbc,
-	0x96, 0x91, 0x5b, 0x9f, 0xf0, 0x03, 0x84, 0x62, 0xc9, 0x28, 0xb0, 0x24, 0xa2, 0xe0, 0xed, 0x18,
-	0x81, 0xed, 0x9a, 0x8c, 0x4c, 0x5a, 0xcd, 0x92, 0x75, 0x7a, 0xb7, 0x4a, 0xd7, 0x64, 0x04, 0xf8,
-	0x15, 0x72, 0xa9, 0x82, 0x0f, 0x42, 0x66, 0x5f, 0x28, 0x64, 0x82, 0x47, 0x59, 0xe2, 0xb5, 0x03,
-	0x6b, 0xd8, 0x3c, 0xba, 0xbb, 0xbc, 0xe8, 0xf7, 0x46, 0x97, 0x73, 0xc7, 0xaf, 0x49, 0xef, 0xca,
-	0xc7, 0xc7, 0x09, 0x7e, 0x8f, 0x3a, 0x05, 0xe5, 0x8a, 0xe6, 0xda, 0x9e, 0xd2, 0x73, 0x8d, 0x37,
-	0x2f, 0x6f, 0xe0, 0xcd, 0x89, 0xa9, 0xa2, 0x1d, 0x42, 0xc5, 0x3a, 0x2c, 0x07, 0xdf, 0x2d, 0xe4,
-	0x5e, 0xb7, 0x10, 0xbb, 0xc8, 0xe6, 0xe2, 0xb3, 0x99, 0xba, 0x4d, 0x74, 0xa8, 0x09, 0xc8, 0x85,
-	0x99, 0x6e, 0x97, 0xe8, 0x10, 0x07, 0xa8, 0x25, 0x95, 0x51, 0x63, 0x1b, 0x35, 0xed, 0xe5, 0x45,
-	0xdf, 0x21, 0x4a, 0x6b, 0x70, 0xa4, 0xd2, 0x9d, 0xf7, 0x51, 0x47, 0x52, 0x3e, 0x61, 0x51, 0x09,
-	0x54, 0x82, 0xd7, 0x34, 0xd5, 0x90, 0x41, 0xa7, 0x9a, 0xe0, 0xfb, 0xa8, 0x5d, 0x7d, 0xc0, 0x78,
-	0x62, 0x46, 0x62, 0x93, 0x5d, 0x03, 0xde, 0xf0, 0x04, 0x1f, 0xa2, 0xdb, 0x92, 0x7d, 0x54, 0xac,
-	0xac, 0x8d, 0x6d, 0x99, 0x7c, 0x67, 0xc3, 0x46, 0x30, 0xf8, 0x66, 0xa1, 0x83, 0xed, 0x12, 0xf1,
-	0x3e, 0x72, 0xaa, 0x57, 0x2b, 0x0d, 0xd5, 0x41, 0xab, 0xd0, 0x4f, 0x55, 0x3b, 0xaa, 0xc3, 0xad,
-	0x2b, 0x6c, 0x6f, 0x5f, 0xe1, 0xeb, 0x0d, 0x35, 0xff, 0x69, 0xe8, 0x92, 0x27, 0xce, 0x76, 0x4f,
-	0x8e, 0x0e, 0x7f, 0x2c, 0x7d, 0xeb, 0x7c, 0xe9, 0x5b, 0xbf, 0x96, 0xbe, 0xf5, 0x75, 0xe5, 0x37,
-	0xce, 0x57, 0x7e, 0xe3, 0xe7, 0xca, 0x6f, 0xbc, 0xdb, 0xa9, 0x87, 0x76, 0xd6, 0x32, 0xff, 0xe5,
-	0xf3, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x74, 0xb0, 0xab, 0x79, 0xe1, 0x03, 0x00, 0x00,
+var fileDescriptor_meta_b4a06e8fc7b65dc4 = []byte{
+	// 596 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0xcf, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0x6b, 0x9c, 0xa4, 0xcd, 0x84, 0x26, 0xee, 0x52, 0x55, 0x16, 0x88, 0xc4, 0xad, 0x40,
+	0x98, 0x8b, 0x2b, 0x81, 0xc4, 0x09, 0x21, 0xa5, 0x2d, 0x87, 0x1c, 0x7a, 0xd9, 0x72, 0x42, 0x42,
+	0xd6, 0xd6, 0x5e, 0x07, 0x2b, 0xf6, 0x6e, 0xd8, 0x3f, 0xd0, 0xf2, 0x14, 0x3c, 0x05, 0xcf, 0xc2,
+	0xb1, 0x47, 0x4e, 0x15, 0xb8, 0x37, 0x9e, 0x02, 0xed, 0xda, 0x69, 0x69, 0x09, 0x02, 0x71, 0x9b,
+	0xfd, 0xcd, 0x78, 0x32, 0xdf, 0xb7, 0xb3, 0x01, 0x28, 0xa9, 0x22, 0xd1, 0x5c, 0x70, 0xc5, 0xd1,
+	0x83, 0x84, 0x97, 0x51, 0xce, 0xb2, 0x42, 0x9f, 0xa4, 0xc4, 0xd0, 0x82, 0xa8, 0x8c, 0x8b, 0x32,
+	0x52, 0x44, 0xce, 0xa2, 0x63, 0x92, 0xcc, 0x28, 0x4b, 0xef, 0x6e, 0x4e, 0xf9, 0x94, 0xdb, 0x0f,
+	0x76, 0x4d, 0x54, 0x7f, 0xbb, 0xf3, 0xb9, 0x05, 0xeb, 0x47, 0x8a, 0x0b, 0xfa, 0x8a, 0xc8, 0xd9,
+	0x21, 0x55, 0x04, 0x3d, 0x82, 0x41, 0x49, 0x4e, 0xe2, 0x84, 0xb3, 0x44, 0x0b, 0x41, 0x59, 0x72,
+	0xea, 0x3b, 0x81, 0x13, 0xb6, 0x71, 0xbf, 0x24, 0x27, 0xfb, 0x57, 0x14, 0x3d, 0x06, 0xaf, 0x20,
+	0x8a, 0x4a, 0x15, 0x27, 0xbc, 0x9c, 0x17, 0x54, 0xd1, 0xd4, 0xbf, 0x15, 0x38, 0xa1, 0x8b, 0x07,
+	0x35, 0xdf, 0x5f, 0x60, 0xb4, 0x05, 0x1d, 0xa9, 0x88, 0xd2, 0xd2, 0x77, 0x03, 0x27, 0xec, 0xe2,
+	0xe6, 0x84, 0x12, 0xd8, 0xa8, 0xdb, 0xa9, 0xe2, 0x34, 0x16, 0x9a, 0xb1, 0x9c, 0x4d, 0xfd, 0x56,
+	0xe0, 0x86, 0xbd, 0x27, 0xcf, 0xa2, 0x7f, 0x51, 0x15, 0x5d, 0x9b, 0x1d, 0x6b, 0x86, 0xbd, 0xcb,
+	0x86, 0xb8, 0xee, 0x87, 0x1e, 0x42, 0x9f, 0x66, 0x19, 0x4d, 0x54, 0xfe, 0x9e, 0xc6, 0x89, 0xe0,
+	0xcc, 0x6f, 0xdb, 0x21, 0xd6, 0x2f, 0xe9, 0xbe, 0xe0, 0xcc, 0xcc, 0xc8, 0xb3, 0x4c, 0x52, 0xe5,
+	0x77, 0xac, 0xdc, 0xe6, 0x84, 0xee, 0x03, 0x24, 0x82, 0x12, 0x45, 0xd3, 0x98, 0x28, 0x7f, 0xd5,
+	0x0a, 0xec, 0x36, 0x64, 0x6c, 0xd3, 0x7a, 0x9e, 0x2e, 0xd2, 0x6b, 0x75, 0xba, 0x21, 0x63, 0x85,
+	0x5e, 0x80, 0x47, 0xb4, 0x7a, 0xcb, 0x45, 0xfe, 0x91, 0xa8, 0x9c, 0xb3, 0x38, 0x4f, 0xfd, 0x6e,
+	0xe0, 0x84, 0xad, 0xbd, 0x3b, 0xd5, 0xf9, 0x68, 0x30, 0xfe, 0x35, 0x37, 0x39, 0xc0, 0x83, 0x6b,
+	0xc5, 0x93, 0x14, 0xbd, 0x81, 0x5e, 0x49, 0x98, 0x26, 0x85, 0xb1, 0x47, 0xfa, 0x9e, 0xf5, 0xe6,
+	0xf9, 0x7f, 0x78, 0x73, 0x68, 0xbb, 0x18, 0x87, 0xa0, 0x5c, 0x84, 0x12, 0x85, 0xe0, 0xc9, 0x59,
+	0x3e, 0x8f, 0xcb, 0x5c, 0x4a, 0x9a, 0xd6, 0xbf, 0xb1, 0x11, 0x38, 0xe1, 0x1a, 0xee, 0x1b, 0x7e,
+	0x68, 0xb1, 0xa9, 0xdc, 0xf9, 0xe1, 0x80, 0x77, 0xd3, 0x6c, 0xe4, 0x81, 0xcb, 0xf8, 0x07, 0xbb,
+	0x1f, 0x2e, 0x36, 0xa1, 0x21, 0x4a, 0x9c, 0xda, 0x3d, 0x58, 0xc7, 0x26, 0x44, 0x01, 0x74, 0x84,
+	0xb6, 0xba, 0x5d, 0xab, 0xbb, 0x5b, 0x9d, 0x8f, 0xda, 0x58, 0x1b, 0xb5, 0x6d, 0xa1, 0x8d, 0xc6,
+	0x11, 0xf4, 0x04, 0x61, 0x53, 0x1a, 0x4b, 0x45, 0x84, 0xf2, 0x5b, 0xb6, 0x1b, 0x58, 0x74, 0x64,
+	0x08, 0xba, 0x07, 0xdd, 0xba, 0x80, 0xb2, 0xd4, 0x5e, 0x9e, 0x8b, 0xd7, 0x2c, 0x78, 0xc9, 0x52,
+	0xb4, 0x0d, 0xb7, 0x05, 0x7d, 0xa7, 0xa9, 0x6c, 0xae, 0xa0, 0x63, 0xf3, 0xbd, 0x4b, 0x36, 0x56,
+	0x68, 0x17, 0x7a, 0xc6, 0x93, 0x2c, 0x2f, 0x0a, 0x33, 0xc7, 0xaa, 0x9d, 0xa3, 0x5f, 0x9d, 0x8f,
+	0x60, 0xaf, 0xc1, 0x93, 0x03, 0x0c, 0x8b, 0x92, 0x49, 0xba, 0xf3, 0xdd, 0x81, 0xad, 0xe5, 0xee,
+	0xa1, 0x4d, 0x68, 0xd7, 0x63, 0xd6, 0xa2, 0xeb, 0x83, 0x91, 0x6d, 0x66, 0xab, 0xd7, 0xdf, 0x84,
+	0x4b, 0x5f, 0x87, 0xbb, 0xfc, 0x75, 0xdc, 0x54, 0xd0, 0xfa, 0x5d, 0xc1, 0x95, 0x89, 0xed, 0x3f,
+	0x98, 0x78, 0x43, 0x63, 0xe7, 0x6f, 0x1a, 0xf7, 0xb6, 0xbf, 0x54, 0x43, 0xe7, 0xac, 0x1a, 0x3a,
+	0xdf, 0xaa, 0xa1, 0xf3, 0xe9, 0x62, 0xb8, 0x72, 0x76, 0x31, 0x5c, 0xf9, 0x7a, 0x31, 0x5c, 0x79,
+	0xbd, 0xda, 0x2c, 0xd0, 0x71, 0xc7, 0xfe, 0x47, 0x3c, 0xfd, 0x39, 0x00, 0x50, 0x5e, 0x82, 0xfd,
+	0x6d, 0x04, 0x00, 0x00,
 }
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/snowflake"
 	"github.com/influxdata/influxdb/task/backend"
@@ -213,6 +214,145 @@ func TestMeta_CreateNextRun_Delay(t *testing.T) {
 	}
 }
 
+func TestMeta_CreateNextRun_SkipMissedRuns(t *testing.T) {
+	stm := backend.StoreTaskMeta{
+		MaxConcurrency:  2,
+		Status:          "enabled",
+		EffectiveCron:   "* * * * *", // Every minute.
+		Offset:          5,
+		LatestCompleted: 60,
+		SkipMissedRuns:  true,
+	}
+
+	// The runs for 120 through 240 were missed.
+	rc, err := stm.CreateNextRun(310, makeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rc.Created.Now != 300 {
+		t.Fatalf("expected created run to have time 300, got %d", rc.Created.Now)
+	}
+	if rc.NextDue != 365 {
+		t.Fatalf("unexpected next run time: %d", rc.NextDue)
+	}
+
+	stm.SkipMissedRuns = false
+	stm.CurrentlyRunning = nil
+	rc, err = stm.CreateNextRun(310, makeID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rc.Created.Now != 120 {
+		t.Fatalf("expected created run to have time 120 when catching up, got %d", rc.Created.Now)
+	}
+}
+
+func TestMeta_ManuallyRunTimeRange_Every(t *testing.T) {
+	stm := backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		Status:          "enabled",
+		EffectiveCron:   "@every 1m0s",
+		LatestCompleted: 3000,
+	}
+
+	// Should run at 60 and 120, aligned on the schedule of the task.
+	if err := stm.ManuallyRunTimeRange(30, 150, 3005, makeID); err != nil {
+		t.Fatal(err)
+	}
+	if !platform.ID(stm.ManualRuns[0].BackfillID).Valid() {
+		t.Fatal("expected time range to have a backfill ID")
+	}
+
+	for _, exp := range []int64{60, 120} {
+		rc, err := stm.CreateNextRun(3010, makeID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rc.Created.Now != exp {
+			t.Fatalf("expected created now of %d, got %d", exp, rc.Created.Now)
+		}
+		if !stm.FinishRun(rc.Created.RunID) {
+			t.Fatal("expected run to finish")
+		}
+	}
+
+	// No schedule is left within the time range.
+	_, err := stm.CreateNextRun(3010, makeID)
+	if e, ok := err.(backend.RunNotYetDueError); !ok {
+		t.Fatalf("expected RunNotYetDueError, got %v (%T)", err, err)
+	} else if e.DueAt != 3060 {
+		t.Fatalf("expected run due at 3060, got %d", e.DueAt)
+	}
+	if len(stm.ManualRuns) != 0 {
+		t.Fatalf("expected the queue to be dropped, got %v", stm.ManualRuns)
+	}
+}
+
+func TestMeta_Backfills(t *testing.T) {
+	stm := backend.StoreTaskMeta{
+		MaxConcurrency:  2,
+		Status:          "enabled",
+		EffectiveCron:   "* * * * *", // Every minute.
+		LatestCompleted: 3000,
+	}
+
+	// A single run is not a backfill.
+	if err := stm.ManuallyRunTimeRange(60, 60, 3005, makeID); err != nil {
+		t.Fatal(err)
+	}
+	// Runs at 120, 180, 240 and 300.
+	if err := stm.ManuallyRunTimeRange(120, 300, 3005, makeID); err != nil {
+		t.Fatal(err)
+	}
+	backfillID := platform.ID(stm.ManualRuns[1].BackfillID)
+
+	createRuns := func(n int) []platform.ID {
+		t.Helper()
+		var ids []platform.ID
+		for i := 0; i < n; i++ {
+			rc, err := stm.CreateNextRun(3010, makeID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids = append(ids, rc.Created.RunID)
+		}
+		return ids
+	}
+	checkBackfills := func(exp ...backend.BackfillProgress) {
+		t.Helper()
+		bs, err := stm.Backfills()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !cmp.Equal(bs, exp) {
+			t.Fatalf("unexpected backfills -got/+exp\n%s", cmp.Diff(bs, exp))
+		}
+	}
+
+	// The single run at 60, and the run at 120.
+	running := createRuns(2)
+	checkBackfills(backend.BackfillProgress{BackfillID: backfillID, Start: 120, End: 300, RequestedAt: 3005, Runs: 4, Running: 1})
+
+	// The runs at 180 and 240.
+	stm.FinishRun(running[0])
+	stm.FinishRun(running[1])
+	running = createRuns(2)
+	checkBackfills(backend.BackfillProgress{BackfillID: backfillID, Start: 120, End: 300, RequestedAt: 3005, Runs: 4, Completed: 1, Running: 2})
+
+	// The run at 300, the last one of the backfill.
+	stm.FinishRun(running[0])
+	running = append(running[1:], createRuns(1)...)
+	checkBackfills(backend.BackfillProgress{BackfillID: backfillID, Start: 120, End: 300, RequestedAt: 3005, Runs: 4, Completed: 2, Running: 2})
+
+	if stm.CancelManualRuns(backfillID) {
+		t.Fatal("expected the queue of the backfill to be dropped already")
+	}
+
+	stm.FinishRun(running[0])
+	stm.FinishRun(running[1])
+	checkBackfills()
+}
+
 func TestMeta_ManuallyRunTimeRange(t *testing.T) {
 	now := time.Now().Unix()
 	stm := backend.StoreTaskMeta{
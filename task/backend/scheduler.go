@@ -123,6 +123,10 @@ type Scheduler interface {
 
 	// Cancel stops an executing run.
 	CancelRun(ctx context.Context, taskID, runID platform.ID) error
+
+	// ScheduleManualRuns begins the runs manually requested for the given task,
+	// without waiting for the next scheduled run of the task.
+	ScheduleManualRuns(taskID platform.ID) error
 }
 
 // TickSchedulerOption is a option you can use to modify the schedulers behavior.
@@ -368,6 +372,21 @@ func (s *TickScheduler) ReleaseTask(taskID platform.ID) error {
 	return nil
 }
 
+func (s *TickScheduler) ScheduleManualRuns(taskID platform.ID) error {
+	s.schedulerMu.Lock()
+	defer s.schedulerMu.Unlock()
+
+	ts, ok := s.taskSchedulers[taskID]
+	if !ok {
+		return ErrTaskNotClaimed
+	}
+
+	ts.SetQueue()
+	ts.Work()
+
+	return nil
+}
+
 func (s *TickScheduler) PrometheusCollectors() []prometheus.Collector {
 	return s.metrics.PrometheusCollectors()
 }
@@ -494,6 +513,13 @@ func (ts *taskScheduler) SetNextDue(nextDue int64, hasQueue bool, source int64)
 	ts.hasQueue = hasQueue
 }
 
+// SetQueue records that the task has a queue of manual runs.
+func (ts *taskScheduler) SetQueue() {
+	ts.nextDueMu.Lock()
+	defer ts.nextDueMu.Unlock()
+	ts.hasQueue = true
+}
+
 // A runner is one eligible "concurrency slot" for a given task.
 type runner struct {
 	state *uint32
@@ -599,6 +625,13 @@ func (r *runner) startFromWorking(now int64) {
 	}
 	ctx, cancel := context.WithCancel(r.ctx)
 	rc, err := r.desiredState.CreateNextRun(ctx, r.task.ID, now)
+	if e, ok := err.(RunNotYetDueError); ok {
+		// Nothing left in the queue of manual runs.
+		r.ts.SetNextDue(e.DueAt, false, now)
+		atomic.StoreUint32(r.state, runnerIdle)
+		cancel() // cancel to prevent context leak
+		return
+	}
 	if err != nil {
 		r.logger.Info("Failed to create run", zap.Error(err))
 		atomic.StoreUint32(r.state, runnerIdle)
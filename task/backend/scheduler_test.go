@@ -396,6 +396,51 @@ func TestScheduler_Queue(t *testing.T) {
 	}
 }
 
+func TestScheduler_ScheduleManualRuns(t *testing.T) {
+	t.Parallel()
+
+	d := mock.NewDesiredState()
+	e := mock.NewExecutor()
+	o := backend.NewScheduler(d, e, backend.NopLogWriter{}, 3059, backend.WithLogger(zaptest.NewLogger(t)))
+	o.Start(context.Background())
+	defer o.Stop()
+
+	task := &backend.StoreTask{
+		ID: platform.ID(1),
+	}
+	meta := &backend.StoreTaskMeta{
+		MaxConcurrency:  1,
+		EffectiveCron:   "* * * * *", // Every minute.
+		LatestCompleted: 3000,
+	}
+
+	d.SetTaskMeta(task.ID, *meta)
+	if err := o.ClaimTask(task, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	// Queue runs after the task was claimed, while its next run is not due.
+	meta.ManualRuns = []*backend.StoreTaskMetaManualRun{
+		{Start: 120, End: 240, LatestCompleted: 119, RequestedAt: 3001},
+	}
+	d.SetTaskMeta(task.ID, *meta)
+	if err := o.ScheduleManualRuns(task.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	cs, err := d.PollForNumberCreated(task.ID, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := cs[0]; c.Now != 120 {
+		t.Fatalf("expected run from queue at 120, got %d", c.Now)
+	}
+
+	if err := o.ScheduleManualRuns(platform.ID(2)); err != backend.ErrTaskNotClaimed {
+		t.Fatalf("expected ErrTaskNotClaimed for a task that was not claimed, got %v", err)
+	}
+}
+
 // pollForRunStatus tries a few times to find runs matching supplied conditions, before failing.
 func pollForRunStatus(t *testing.T, r backend.LogReader, taskID, orgID platform.ID, expCount, expIndex int, expStatus string) {
 	t.Helper()
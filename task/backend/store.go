@@ -257,6 +257,11 @@ type Store interface {
 	// ManuallyRunTimeRange must delegate to an underlying StoreTaskMeta's ManuallyRunTimeRange method.
 	ManuallyRunTimeRange(ctx context.Context, taskID platform.ID, start, end, requestedAt int64) (*StoreTaskMetaManualRun, error)
 
+	// CancelManualRuns removes the manually run time range with the given backfill ID from the queue of the task.
+	// It returns false if no queued time range matched the backfill ID.
+	// CancelManualRuns must delegate to an underlying StoreTaskMeta's CancelManualRuns method.
+	CancelManualRuns(ctx context.Context, taskID, backfillID platform.ID) (canceled bool, err error)
+
 	// DeleteOrg deletes the org.
 	DeleteOrg(ctx context.Context, orgID platform.ID) error
 
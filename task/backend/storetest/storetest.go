@@ -34,6 +34,7 @@ func NewStoreTest(name string, cf CreateStoreFunc, df DestroyStoreFunc, funcName
 			"CreateNextRun",
 			"FinishRun",
 			"ManuallyRunTimeRange",
+			"CancelManualRuns",
 		}
 	}
 	availableFuncs := map[string]TestFunc{
@@ -47,6 +48,7 @@ func NewStoreTest(name string, cf CreateStoreFunc, df DestroyStoreFunc, funcName
 		"CreateNextRun":        testStoreCreateNextRun,
 		"FinishRun":            testStoreFinishRun,
 		"ManuallyRunTimeRange": testStoreManuallyRunTimeRange,
+		"CancelManualRuns":     testStoreCancelManualRuns,
 		"DeleteOrg":            testStoreDeleteOrg,
 	}
 
@@ -973,6 +975,58 @@ from(bucket:"test") |> range(start:-1h)`
 	}
 }
 
+func testStoreCancelManualRuns(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
+	const script = `option task = {
+		name: "a task",
+		cron: "* * * * *",
+	}
+
+from(bucket:"test") |> range(start:-1h)`
+	s := create(t)
+	defer destroy(t, s)
+
+	taskID, err := s.CreateTask(context.Background(), backend.CreateTaskRequest{Org: 1, AuthorizationID: 3, Script: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := s.ManuallyRunTimeRange(context.Background(), taskID, 60, 600, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !platform.ID(first.BackfillID).Valid() {
+		t.Fatal("expected the time range to be assigned a backfill ID")
+	}
+	second, err := s.ManuallyRunTimeRange(context.Background(), taskID, 1200, 1800, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	canceled, err := s.CancelManualRuns(context.Background(), taskID, platform.ID(first.BackfillID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !canceled {
+		t.Fatal("expected the time range to be canceled")
+	}
+
+	meta, err := s.FindTaskMetaByID(context.Background(), taskID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(meta.ManualRuns) != 1 || meta.ManualRuns[0].BackfillID != second.BackfillID {
+		t.Fatalf("expected only the second time range to be queued, got %v", meta.ManualRuns)
+	}
+
+	canceled, err = s.CancelManualRuns(context.Background(), taskID, platform.ID(first.BackfillID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if canceled {
+		t.Fatal("expected a canceled time range not to be canceled again")
+	}
+}
+
 func testStoreDeleteOrg(t *testing.T, create CreateStoreFunc, destroy DestroyStoreFunc) {
 	s := create(t)
 	defer destroy(t, s)
@@ -162,6 +162,10 @@ func (s *Scheduler) CancelRun(_ context.Context, taskID, runID platform.ID) erro
 	return nil
 }
 
+func (s *Scheduler) ScheduleManualRuns(taskID platform.ID) error {
+	return nil
+}
+
 // DesiredState is a mock implementation of DesiredState (used by NewScheduler).
 type DesiredState struct {
 	mu sync.Mutex
@@ -28,6 +28,16 @@ func EnableScriptCacheForTest() {
 const maxConcurrency = 100
 const maxRetry = 10
 
+// Catch-up policies of a task, deciding what happens to the runs of a task
+// that were missed while the task could not be scheduled.
+const (
+	// CatchUpAll executes every missed run, in schedule order.
+	CatchUpAll = "all"
+
+	// CatchUpSkip skips the missed runs and executes only the latest due run.
+	CatchUpSkip = "skip"
+)
+
 // Options are the task-related options that can be specified in a Flux script.
 type Options struct {
 	// Name is a non optional name designator for each task.
@@ -47,6 +57,9 @@ type Options struct {
 	Concurrency int64 `json:"concurrency,omitempty"`
 
 	Retry int64 `json:"retry,omitempty"`
+
+	// CatchUp is the catch-up policy of the task, CatchUpAll when not set.
+	CatchUp string `json:"catchUp,omitempty"`
 }
 
 // Clear clears out all options in the options struct, it us useful if you wish to reuse it.
@@ -57,6 +70,7 @@ func (o *Options) Clear() {
 	o.Offset = 0
 	o.Concurrency = 0
 	o.Retry = 0
+	o.CatchUp = ""
 }
 
 func (o *Options) IsZero() bool {
@@ -65,7 +79,8 @@ func (o *Options) IsZero() bool {
 		o.Every == 0 &&
 		o.Offset == 0 &&
 		o.Concurrency == 0 &&
-		o.Retry == 0
+		o.Retry == 0 &&
+		o.CatchUp == ""
 }
 
 // FromScript extracts Options from a Flux script.
@@ -147,6 +162,13 @@ func FromScript(script string) (Options, error) {
 		opt.Retry = retryVal.Int()
 	}
 
+	if catchUpVal, ok := optObject.Get("catchUp"); ok {
+		if err := checkNature(catchUpVal.PolyType().Nature(), semantic.String); err != nil {
+			return opt, err
+		}
+		opt.CatchUp = catchUpVal.Str()
+	}
+
 	if err := opt.Validate(); err != nil {
 		return opt, err
 	}
@@ -202,6 +224,10 @@ func (o *Options) Validate() error {
 		errs = append(errs, fmt.Sprintf("retry exceeded max of %d", maxRetry))
 	}
 
+	if o.CatchUp != "" && o.CatchUp != CatchUpAll && o.CatchUp != CatchUpSkip {
+		errs = append(errs, fmt.Sprintf("catchUp must be one of %q or %q", CatchUpAll, CatchUpSkip))
+	}
+
 	if len(errs) == 0 {
 		return nil
 	}
@@ -224,6 +250,11 @@ func (o *Options) EffectiveCronString() string {
 	return ""
 }
 
+// SkipMissedRuns returns true when the runs missed by the task should not be executed.
+func (o *Options) SkipMissedRuns() bool {
+	return o.CatchUp == CatchUpSkip
+}
+
 // checkNature returns a clean error of got and expected dont match.
 func checkNature(got, exp semantic.Nature) error {
 	if got != exp {
@@ -31,6 +31,9 @@ func scriptGenerator(opt options.Options, body string) string {
 	if opt.Retry != 0 {
 		taskData = fmt.Sprintf("%s  retry: %d,\n", taskData, opt.Retry)
 	}
+	if opt.CatchUp != "" {
+		taskData = fmt.Sprintf("%s  catchUp: %q,\n", taskData, opt.CatchUp)
+	}
 	if body == "" {
 		body = `from(bucket: "test")
     |> range(start:-1h)`
@@ -52,7 +55,9 @@ func TestFromScript(t *testing.T) {
 		{script: scriptGenerator(options.Options{Name: "name", Cron: "* * * * *", Concurrency: 2, Retry: 3, Offset: -time.Minute}, ""), exp: options.Options{Name: "name", Cron: "* * * * *", Concurrency: 2, Retry: 3, Offset: -time.Minute}},
 		{script: scriptGenerator(options.Options{Name: "name", Every: 5 * time.Second}, ""), exp: options.Options{Name: "name", Every: 5 * time.Second, Concurrency: 1, Retry: 1}},
 		{script: scriptGenerator(options.Options{Name: "name", Cron: "* * * * *"}, ""), exp: options.Options{Name: "name", Cron: "* * * * *", Concurrency: 1, Retry: 1}},
+		{script: scriptGenerator(options.Options{Name: "name", Every: time.Hour, CatchUp: "skip"}, ""), exp: options.Options{Name: "name", Every: time.Hour, Concurrency: 1, Retry: 1, CatchUp: "skip"}},
 		{script: scriptGenerator(options.Options{Name: "name", Every: time.Hour, Cron: "* * * * *"}, ""), shouldErr: true},
+		{script: scriptGenerator(options.Options{Name: "name", Every: time.Hour, CatchUp: "some"}, ""), shouldErr: true},
 		{script: scriptGenerator(options.Options{Name: "name", Concurrency: 1000, Every: time.Hour}, ""), shouldErr: true},
 		{script: "option task = {\n  name: \"name\",\n  concurrency: 0,\n  every: 1m0s,\n\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
 		{script: "option task = {\n  name: \"name\",\n  concurrency: 1,\n  every: 1,\n\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
@@ -144,6 +149,12 @@ func TestValidate(t *testing.T) {
 	if err := bad.Validate(); err == nil {
 		t.Error("expected error for retry too large")
 	}
+
+	*bad = good
+	bad.CatchUp = "never"
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for unknown catch-up policy")
+	}
 }
 
 func TestEffectiveCronString(t *testing.T) {
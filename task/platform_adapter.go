@@ -172,6 +172,7 @@ func (p pAdapter) CreateTask(ctx context.Context, t platform.TaskCreate) (*platf
 	if opts.Offset != 0 {
 		task.Offset = opts.Offset.String()
 	}
+	task.CatchUp = opts.CatchUp
 
 	mapping := &platform.UserResourceMapping{
 		UserID:       auth.GetUserID(),
@@ -330,6 +331,126 @@ func (p pAdapter) CancelRun(ctx context.Context, taskID, runID platform.ID) erro
 	return p.rc.CancelRun(ctx, taskID, runID)
 }
 
+func (p pAdapter) CreateBackfill(ctx context.Context, taskID platform.ID, start, stop int64) (*platform.Backfill, error) {
+	requestedAt := time.Now()
+	if stop == 0 {
+		stop = requestedAt.Unix()
+	}
+	if stop <= start {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "backfill stop must be later than its start",
+		}
+	}
+	if stop > requestedAt.Unix() {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "backfill cannot extend past the current time",
+		}
+	}
+
+	m, err := p.s.ManuallyRunTimeRange(ctx, taskID, start, stop, requestedAt.Unix())
+	if err != nil {
+		return nil, err
+	}
+	backfillID := platform.ID(m.BackfillID)
+
+	bs, err := p.findBackfills(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range bs {
+		if b.ID != backfillID {
+			continue
+		}
+		if b.Runs == 0 {
+			if _, err := p.s.CancelManualRuns(ctx, taskID, backfillID); err != nil {
+				return nil, err
+			}
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  "the task is not scheduled within the backfill time range",
+			}
+		}
+		return b, nil
+	}
+
+	// The runs of the backfill all finished already.
+	return &platform.Backfill{
+		ID:          backfillID,
+		TaskID:      taskID,
+		Start:       time.Unix(start, 0).UTC().Format(time.RFC3339),
+		Stop:        time.Unix(stop, 0).UTC().Format(time.RFC3339),
+		RequestedAt: requestedAt.UTC().Format(time.RFC3339),
+	}, nil
+}
+
+func (p pAdapter) FindBackfills(ctx context.Context, taskID platform.ID) ([]*platform.Backfill, int, error) {
+	bs, err := p.findBackfills(ctx, taskID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return bs, len(bs), nil
+}
+
+func (p pAdapter) findBackfills(ctx context.Context, taskID platform.ID) ([]*platform.Backfill, error) {
+	m, err := p.s.FindTaskMetaByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := m.Backfills()
+	if err != nil {
+		return nil, err
+	}
+
+	bs := make([]*platform.Backfill, 0, len(progress))
+	for _, b := range progress {
+		bs = append(bs, &platform.Backfill{
+			ID:          b.BackfillID,
+			TaskID:      taskID,
+			Start:       time.Unix(b.Start, 0).UTC().Format(time.RFC3339),
+			Stop:        time.Unix(b.End, 0).UTC().Format(time.RFC3339),
+			RequestedAt: time.Unix(b.RequestedAt, 0).UTC().Format(time.RFC3339),
+			Runs:        b.Runs,
+			Completed:   b.Completed,
+			Running:     b.Running,
+		})
+	}
+	return bs, nil
+}
+
+func (p pAdapter) CancelBackfill(ctx context.Context, taskID, backfillID platform.ID) error {
+	m, err := p.s.FindTaskMetaByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	// Drop the runs that did not start first, so that no new run starts while canceling the running ones.
+	canceled, err := p.s.CancelManualRuns(ctx, taskID, backfillID)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range m.CurrentlyRunning {
+		if platform.ID(r.BackfillID) != backfillID {
+			continue
+		}
+		canceled = true
+		if err := p.rc.CancelRun(ctx, taskID, platform.ID(r.RunID)); err != nil && err != backend.ErrRunNotFound {
+			return err
+		}
+	}
+
+	if !canceled {
+		return &platform.Error{
+			Code: platform.ENotFound,
+			Msg:  "backfill not found",
+		}
+	}
+	return nil
+}
+
 var errTokenUnreadable = errors.New("token invalid or unreadable by the current user")
 
 // authorizationIDFromToken looks up the authorization ID from the given token,
@@ -398,6 +519,7 @@ func (p *pAdapter) toPlatformTask(ctx context.Context, t backend.StoreTask, m *b
 	if opts.Offset != 0 {
 		pt.Offset = opts.Offset.String()
 	}
+	pt.CatchUp = opts.CatchUp
 	if m != nil {
 		pt.Status = string(m.Status)
 		pt.LatestCompleted = time.Unix(m.LatestCompleted, 0).Format(time.RFC3339)
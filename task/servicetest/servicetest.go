@@ -724,6 +724,57 @@ func testTaskRuns(t *testing.T, sys *System) {
 		}
 	})
 
+	t.Run("Backfills", func(t *testing.T) {
+		t.Parallel()
+
+		ct := platform.TaskCreate{
+			OrganizationID: cr.OrgID,
+			Flux:           fmt.Sprintf(scriptFmt, 0),
+			Token:          cr.Token,
+		}
+		task, err := sys.ts.CreateTask(icontext.SetAuthorizer(sys.Ctx, cr.Authorizer()), ct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := sys.ts.CreateBackfill(sys.Ctx, task.ID, 240, 60); platform.ErrorCode(err) != platform.EInvalid {
+			t.Fatalf("expected backfill with stop before start to be rejected as invalid; got %v", err)
+		}
+
+		// The task runs every minute, so the range covers 4 schedules.
+		b, err := sys.ts.CreateBackfill(sys.Ctx, task.ID, 60, 240)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.TaskID != task.ID || b.Runs != 4 || b.Completed != 0 || b.Running != 0 {
+			t.Fatalf("unexpected backfill: %#v", b)
+		}
+
+		bs, n, err := sys.ts.FindBackfills(sys.Ctx, task.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 1 || len(bs) != 1 || bs[0].ID != b.ID || bs[0].Runs != 4 {
+			t.Fatalf("expected to find the created backfill, got: %#v", bs)
+		}
+
+		if err := sys.ts.CancelBackfill(sys.Ctx, task.ID, b.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		bs, n, err = sys.ts.FindBackfills(sys.Ctx, task.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 0 || len(bs) != 0 {
+			t.Fatalf("expected no backfill after canceling, got: %#v", bs)
+		}
+
+		if err := sys.ts.CancelBackfill(sys.Ctx, task.ID, b.ID); platform.ErrorCode(err) != platform.ENotFound {
+			t.Fatalf("expected canceling a canceled backfill to be not found; got %v", err)
+		}
+	})
+
 	t.Run("FindLogs", func(t *testing.T) {
 		t.Parallel()
 
@@ -8,8 +8,10 @@ import (
 
 	"github.com/influxdata/flux"
 	platform "github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/authorizer"
 	platcontext "github.com/influxdata/influxdb/context"
 	"github.com/influxdata/influxdb/query"
+	"go.uber.org/zap"
 )
 
 type authError struct {
@@ -25,15 +27,32 @@ func (ae *authError) AuthzError() error {
 var ErrFailedPermission = errors.New("unauthorized")
 
 type taskServiceValidator struct {
+	authorizer.Auditor
+
 	platform.TaskService
 	preAuth query.PreAuthorizer
 }
 
-func NewValidator(ts platform.TaskService, bs platform.BucketService) platform.TaskService {
-	return &taskServiceValidator{
+// ValidatorOption configures the task service validator.
+type ValidatorOption func(*taskServiceValidator)
+
+// WithAuditService records the creation, update and deletion of tasks to s,
+// and logs the events that cannot be recorded to log.
+func WithAuditService(s platform.AuditService, log *zap.Logger) ValidatorOption {
+	return func(ts *taskServiceValidator) {
+		ts.SetAuditService(s, log)
+	}
+}
+
+func NewValidator(ts platform.TaskService, bs platform.BucketService, opts ...ValidatorOption) platform.TaskService {
+	v := &taskServiceValidator{
 		TaskService: ts,
 		preAuth:     query.NewPreAuthorizer(bs),
 	}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
 }
 
 func (ts *taskServiceValidator) FindTaskByID(ctx context.Context, id platform.ID) (*platform.Task, error) {
@@ -81,7 +100,15 @@ func (ts *taskServiceValidator) FindTasks(ctx context.Context, filter platform.T
 	return tasks, len(tasks), nil
 }
 
-func (ts *taskServiceValidator) CreateTask(ctx context.Context, t platform.TaskCreate) (*platform.Task, error) {
+func (ts *taskServiceValidator) CreateTask(ctx context.Context, t platform.TaskCreate) (task *platform.Task, err error) {
+	defer func() {
+		var id platform.ID
+		if task != nil {
+			id = task.ID
+		}
+		ts.Record(ctx, platform.CreateAuditAction, platform.TasksResourceType, t.OrganizationID, id, &err)
+	}()
+
 	p, err := platform.NewPermission(platform.WriteAction, platform.TasksResourceType, t.OrganizationID)
 	if err != nil {
 		return nil, err
@@ -98,12 +125,18 @@ func (ts *taskServiceValidator) CreateTask(ctx context.Context, t platform.TaskC
 	return ts.TaskService.CreateTask(ctx, t)
 }
 
-func (ts *taskServiceValidator) UpdateTask(ctx context.Context, id platform.ID, upd platform.TaskUpdate) (*platform.Task, error) {
+func (ts *taskServiceValidator) UpdateTask(ctx context.Context, id platform.ID, upd platform.TaskUpdate) (_ *platform.Task, err error) {
+	var orgID platform.ID
+	defer func() {
+		ts.Record(ctx, platform.UpdateAuditAction, platform.TasksResourceType, orgID, id, &err)
+	}()
+
 	// Unauthenticated task lookup, to identify the task's organization.
 	task, err := ts.TaskService.FindTaskByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	orgID = task.OrganizationID
 
 	p, err := platform.NewPermissionAtID(id, platform.WriteAction, platform.TasksResourceType, task.OrganizationID)
 	if err != nil {
@@ -121,12 +154,18 @@ func (ts *taskServiceValidator) UpdateTask(ctx context.Context, id platform.ID,
 	return ts.TaskService.UpdateTask(ctx, id, upd)
 }
 
-func (ts *taskServiceValidator) DeleteTask(ctx context.Context, id platform.ID) error {
+func (ts *taskServiceValidator) DeleteTask(ctx context.Context, id platform.ID) (err error) {
+	var orgID platform.ID
+	defer func() {
+		ts.Record(ctx, platform.DeleteAuditAction, platform.TasksResourceType, orgID, id, &err)
+	}()
+
 	// Unauthenticated task lookup, to identify the task's organization.
 	task, err := ts.TaskService.FindTaskByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	orgID = task.OrganizationID
 
 	p, err := platform.NewPermissionAtID(id, platform.WriteAction, platform.TasksResourceType, task.OrganizationID)
 	if err != nil {
@@ -246,6 +285,63 @@ func (ts *taskServiceValidator) ForceRun(ctx context.Context, taskID platform.ID
 	return ts.TaskService.ForceRun(ctx, taskID, scheduledFor)
 }
 
+func (ts *taskServiceValidator) CreateBackfill(ctx context.Context, taskID platform.ID, start, stop int64) (*platform.Backfill, error) {
+	// Unauthenticated task lookup, to identify the task's organization.
+	task, err := ts.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := platform.NewPermissionAtID(taskID, platform.WriteAction, platform.TasksResourceType, task.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validatePermission(ctx, *p); err != nil {
+		return nil, err
+	}
+
+	return ts.TaskService.CreateBackfill(ctx, taskID, start, stop)
+}
+
+func (ts *taskServiceValidator) FindBackfills(ctx context.Context, taskID platform.ID) ([]*platform.Backfill, int, error) {
+	// Unauthenticated task lookup, to identify the task's organization.
+	task, err := ts.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	p, err := platform.NewPermissionAtID(taskID, platform.ReadAction, platform.TasksResourceType, task.OrganizationID)
+	if err != nil {
+		return nil, -1, err
+	}
+
+	if err := validatePermission(ctx, *p); err != nil {
+		return nil, -1, err
+	}
+
+	return ts.TaskService.FindBackfills(ctx, taskID)
+}
+
+func (ts *taskServiceValidator) CancelBackfill(ctx context.Context, taskID, backfillID platform.ID) error {
+	// Unauthenticated task lookup, to identify the task's organization.
+	task, err := ts.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	p, err := platform.NewPermissionAtID(taskID, platform.WriteAction, platform.TasksResourceType, task.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	if err := validatePermission(ctx, *p); err != nil {
+		return err
+	}
+
+	return ts.TaskService.CancelBackfill(ctx, taskID, backfillID)
+}
+
 func validatePermission(ctx context.Context, perm platform.Permission) error {
 	auth, err := platcontext.GetAuthorizer(ctx)
 	if err != nil {
@@ -253,7 +349,7 @@ func validatePermission(ctx context.Context, perm platform.Permission) error {
 	}
 
 	if !auth.Allowed(perm) {
-		return authError{error: ErrFailedPermission, perm: perm, auth: auth}
+		return &authError{error: ErrFailedPermission, perm: perm, auth: auth}
 	}
 
 	return nil
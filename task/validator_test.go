@@ -104,6 +104,15 @@ from(bucket:"holder") |> range(start:-5m) |> to(bucket:"holder", org:"thing")`,
 		ForceRunFn: func(context.Context, influxdb.ID, int64) (*influxdb.Run, error) {
 			return &run, nil
 		},
+		CreateBackfillFn: func(context.Context, influxdb.ID, int64, int64) (*influxdb.Backfill, error) {
+			return &influxdb.Backfill{TaskID: taskID}, nil
+		},
+		FindBackfillsFn: func(context.Context, influxdb.ID) ([]*influxdb.Backfill, int, error) {
+			return []*influxdb.Backfill{{TaskID: taskID}}, 1, nil
+		},
+		CancelBackfillFn: func(context.Context, influxdb.ID, influxdb.ID) error {
+			return nil
+		},
 	}
 }
 
@@ -571,6 +580,62 @@ from(bucket:"cows") |> range(start:-5m) |> to(bucket:"cows", org:"thing")`
 				return err
 			},
 		},
+		{
+			name: "CreateBackfill with bad auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: wrongOrgReadAllTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				_, err := svc.CreateBackfill(ctx, taskID, 0, 10000)
+				if err == nil {
+					return errors.New("returned no error with a invalid auth")
+				}
+				return nil
+			},
+		},
+		{
+			name: "CreateBackfill with task auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: orgWriteTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				_, err := svc.CreateBackfill(ctx, taskID, 0, 10000)
+				return err
+			},
+		},
+		{
+			name: "FindBackfills with bad auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: wrongOrgReadAllTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				_, _, err := svc.FindBackfills(ctx, taskID)
+				if err == nil {
+					return errors.New("returned no error with a invalid auth")
+				}
+				return nil
+			},
+		},
+		{
+			name: "FindBackfills with org auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: orgReadAllTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				_, _, err := svc.FindBackfills(ctx, taskID)
+				return err
+			},
+		},
+		{
+			name: "CancelBackfill with bad auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: wrongOrgReadAllTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				err := svc.CancelBackfill(ctx, taskID, 10)
+				if err == nil {
+					return errors.New("returned no error with a invalid auth")
+				}
+				return nil
+			},
+		},
+		{
+			name: "CancelBackfill with org auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: orgWriteAllTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				return svc.CancelBackfill(ctx, taskID, 10)
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -78,6 +78,20 @@ from(bucket:"x")
 			t.Fatalf("expected every to be 30s but was %s", op.Every)
 		}
 	})
+	t.Run("catch-up policy", func(t *testing.T) {
+		tu := &platform.TaskUpdate{}
+		tu.Options.CatchUp = "skip"
+		if err := tu.UpdateFlux(`option task = {every: 20s, name: "foo", catchUp: "all"} from(bucket:"x") |> range(start:-1h)`); err != nil {
+			t.Fatal(err)
+		}
+		op, err := options.FromScript(*tu.Flux)
+		if err != nil {
+			t.Error(err)
+		}
+		if op.CatchUp != "skip" {
+			t.Fatalf("expected catchUp to be skip but was %q", op.CatchUp)
+		}
+	})
 	t.Run("switching from every to cron", func(t *testing.T) {
 		tu := &platform.TaskUpdate{}
 		tu.Options.Cron = "* * * * *"
@@ -5,8 +5,10 @@ import (
 	"context"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	platform "github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/mock"
 )
@@ -73,6 +75,10 @@ func AuthorizationService(
 			name: "DeleteAuthorization",
 			fn:   DeleteAuthorization,
 		},
+		{
+			name: "RotateAuthorization",
+			fn:   RotateAuthorization,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1191,6 +1197,196 @@ func DeleteAuthorization(
 	}
 }
 
+// RotateAuthorization testing
+func RotateAuthorization(
+	init func(AuthorizationFields, *testing.T) (platform.AuthorizationService, string, func()),
+	t *testing.T,
+) {
+	type args struct {
+		ID    platform.ID
+		grace time.Duration
+	}
+	type wants struct {
+		err           error
+		authorization *platform.Authorization
+	}
+
+	// The token replaced, and when it expires, depend on the service.
+	ignorePrevious := cmpopts.IgnoreFields(platform.Authorization{}, "PreviousToken", "PreviousTokenExpiresAt")
+
+	tests := []struct {
+		name   string
+		fields AuthorizationFields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "rotating issues a new token",
+			fields: AuthorizationFields{
+				TokenGenerator: mock.NewTokenGenerator("rand3", nil),
+				Users: []*platform.User{
+					{
+						Name: "cooluser",
+						ID:   MustIDBase16(userOneID),
+					},
+				},
+				Orgs: []*platform.Organization{
+					{
+						Name: "o1",
+						ID:   MustIDBase16(orgOneID),
+					},
+				},
+				Authorizations: []*platform.Authorization{
+					{
+						ID:          MustIDBase16(authOneID),
+						UserID:      MustIDBase16(userOneID),
+						OrgID:       MustIDBase16(orgOneID),
+						Token:       "rand1",
+						Status:      platform.Active,
+						Permissions: allUsersPermission(MustIDBase16(orgOneID)),
+					},
+					{
+						ID:          MustIDBase16(authTwoID),
+						UserID:      MustIDBase16(userOneID),
+						OrgID:       MustIDBase16(orgOneID),
+						Token:       "rand2",
+						Status:      platform.Active,
+						Permissions: allUsersPermission(MustIDBase16(orgOneID)),
+					},
+				},
+			},
+			args: args{
+				ID: MustIDBase16(authOneID),
+			},
+			wants: wants{
+				authorization: &platform.Authorization{
+					ID:          MustIDBase16(authOneID),
+					UserID:      MustIDBase16(userOneID),
+					OrgID:       MustIDBase16(orgOneID),
+					Token:       "rand3",
+					Status:      platform.Active,
+					Permissions: allUsersPermission(MustIDBase16(orgOneID)),
+				},
+			},
+		},
+		{
+			name: "rotating with a grace period issues a new token",
+			fields: AuthorizationFields{
+				TokenGenerator: mock.NewTokenGenerator("rand3", nil),
+				Users: []*platform.User{
+					{
+						Name: "cooluser",
+						ID:   MustIDBase16(userOneID),
+					},
+				},
+				Orgs: []*platform.Organization{
+					{
+						Name: "o1",
+						ID:   MustIDBase16(orgOneID),
+					},
+				},
+				Authorizations: []*platform.Authorization{
+					{
+						ID:          MustIDBase16(authOneID),
+						UserID:      MustIDBase16(userOneID),
+						OrgID:       MustIDBase16(orgOneID),
+						Token:       "rand1",
+						Status:      platform.Inactive,
+						Permissions: createUsersPermission(MustIDBase16(orgOneID)),
+					},
+					{
+						ID:          MustIDBase16(authTwoID),
+						UserID:      MustIDBase16(userOneID),
+						OrgID:       MustIDBase16(orgOneID),
+						Token:       "rand2",
+						Status:      platform.Active,
+						Permissions: allUsersPermission(MustIDBase16(orgOneID)),
+					},
+				},
+			},
+			args: args{
+				ID:    MustIDBase16(authOneID),
+				grace: time.Hour,
+			},
+			wants: wants{
+				authorization: &platform.Authorization{
+					ID:          MustIDBase16(authOneID),
+					UserID:      MustIDBase16(userOneID),
+					OrgID:       MustIDBase16(orgOneID),
+					Token:       "rand3",
+					Status:      platform.Inactive,
+					Permissions: createUsersPermission(MustIDBase16(orgOneID)),
+				},
+			},
+		},
+		{
+			name: "rotating a missing authorization is not found",
+			fields: AuthorizationFields{
+				TokenGenerator: mock.NewTokenGenerator("rand3", nil),
+				Users: []*platform.User{
+					{
+						Name: "cooluser",
+						ID:   MustIDBase16(userOneID),
+					},
+				},
+				Orgs: []*platform.Organization{
+					{
+						Name: "o1",
+						ID:   MustIDBase16(orgOneID),
+					},
+				},
+				Authorizations: []*platform.Authorization{
+					{
+						ID:          MustIDBase16(authTwoID),
+						UserID:      MustIDBase16(userOneID),
+						OrgID:       MustIDBase16(orgOneID),
+						Token:       "rand2",
+						Status:      platform.Active,
+						Permissions: allUsersPermission(MustIDBase16(orgOneID)),
+					},
+				},
+			},
+			args: args{
+				ID: MustIDBase16(authOneID),
+			},
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.ENotFound,
+					Msg:  "authorization not found",
+					Op:   platform.OpRotateAuthorization,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, opPrefix, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			authorization, err := s.RotateAuthorization(ctx, tt.args.ID, tt.args.grace)
+			diffPlatformErrors(tt.name, err, tt.wants.err, opPrefix, t)
+
+			if diff := cmp.Diff(authorization, tt.wants.authorization, authorizationCmpOptions, ignorePrevious); diff != "" {
+				t.Errorf("authorization is different -got/+want\ndiff %s", diff)
+			}
+
+			if tt.wants.authorization == nil {
+				return
+			}
+
+			authorization, err = s.FindAuthorizationByID(ctx, tt.args.ID)
+			if err != nil {
+				t.Fatalf("failed to retrieve authorization: %v", err)
+			}
+			if diff := cmp.Diff(authorization, tt.wants.authorization, authorizationCmpOptions, ignorePrevious); diff != "" {
+				t.Errorf("authorization is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
 func allUsersPermission(orgID platform.ID) []platform.Permission {
 	return []platform.Permission{
 		{Action: platform.WriteAction, Resource: platform.Resource{Type: platform.UsersResourceType, OrgID: &orgID}},
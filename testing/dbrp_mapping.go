@@ -217,7 +217,7 @@ func CreateDBRPMapping(
 				},
 			},
 			wants: wants{
-				err: errors.New("dbrp mapping already exists"),
+				err: platform.ErrDBRPMappingAlreadyExists,
 				dbrpMappings: []*platform.DBRPMapping{
 					{
 						Cluster:         "cluster1",
@@ -230,6 +230,45 @@ func CreateDBRPMapping(
 				},
 			},
 		},
+		{
+			name: "error on create second default dbrpMapping of database",
+			fields: DBRPMappingFields{
+				DBRPMappings: []*platform.DBRPMapping{{
+					Cluster:         "cluster1",
+					Database:        "database1",
+					RetentionPolicy: "retention_policy1",
+					Default:         true,
+					OrganizationID:  MustIDBase16(dbrpOrg1ID),
+					BucketID:        MustIDBase16(dbrpBucket1ID),
+				}},
+			},
+			args: args{
+				dbrpMapping: &platform.DBRPMapping{
+					Cluster:         "cluster1",
+					Database:        "database1",
+					RetentionPolicy: "retention_policy2",
+					Default:         true,
+					OrganizationID:  MustIDBase16(dbrpOrg1ID),
+					BucketID:        MustIDBase16(dbrpBucket1ID),
+				},
+			},
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.EConflict,
+					Msg:  `retention policy "retention_policy1" is the default of database "database1" already`,
+				},
+				dbrpMappings: []*platform.DBRPMapping{
+					{
+						Cluster:         "cluster1",
+						Database:        "database1",
+						RetentionPolicy: "retention_policy1",
+						Default:         true,
+						OrganizationID:  MustIDBase16(dbrpOrg1ID),
+						BucketID:        MustIDBase16(dbrpBucket1ID),
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -238,15 +277,7 @@ func CreateDBRPMapping(
 			defer done()
 			ctx := context.Background()
 			err := s.Create(ctx, tt.args.dbrpMapping)
-			if (err != nil) != (tt.wants.err != nil) {
-				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
-			}
-
-			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
-				}
-			}
+			ErrorsEqual(t, err, tt.wants.err)
 
 			dbrpMappings, _, err := s.FindMany(ctx, platform.DBRPMappingFilter{})
 			if err != nil {
@@ -415,15 +446,7 @@ func FindDBRPMappings(
 			ctx := context.Background()
 
 			dbrpMappings, _, err := s.FindMany(ctx, tt.args.filter)
-			if (err != nil) != (tt.wants.err != nil) {
-				t.Fatalf("expected errors to be equal '%v' got '%v'", tt.wants.err, err)
-			}
-
-			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
-				}
-			}
+			ErrorsEqual(t, err, tt.wants.err)
 
 			if diff := cmp.Diff(dbrpMappings, tt.wants.dbrpMappings, dbrpMappingCmpOptions...); diff != "" {
 				t.Errorf("dbrpMappings are different -got/+want\ndiff %s", diff)
@@ -512,7 +535,7 @@ func FindDBRPMappingByKey(
 				RetentionPolicy: "retention_policyA",
 			},
 			wants: wants{
-				err: errors.New("dbrp mapping not found"),
+				err: platform.ErrDBRPMappingNotFound,
 			},
 		},
 	}
@@ -524,15 +547,7 @@ func FindDBRPMappingByKey(
 			ctx := context.Background()
 
 			dbrpMapping, err := s.FindBy(ctx, tt.args.Cluster, tt.args.Database, tt.args.RetentionPolicy)
-			if (err != nil) != (tt.wants.err != nil) {
-				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
-			}
-
-			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
-				}
-			}
+			ErrorsEqual(t, err, tt.wants.err)
 
 			if diff := cmp.Diff(dbrpMapping, tt.wants.dbrpMapping, dbrpMappingCmpOptions...); diff != "" {
 				t.Errorf("dbrpMappings are different -got/+want\ndiff %s", diff)
@@ -650,15 +665,7 @@ func FindDBRPMapping(
 			ctx := context.Background()
 
 			dbrpMapping, err := s.Find(ctx, tt.args.filter)
-			if (err != nil) != (tt.wants.err != nil) {
-				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
-			}
-
-			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
-				}
-			}
+			ErrorsEqual(t, err, tt.wants.err)
 
 			if diff := cmp.Diff(dbrpMapping, tt.wants.dbrpMapping, dbrpMappingCmpOptions...); diff != "" {
 				t.Errorf("dbrpMappings are different -got/+want\ndiff %s", diff)
@@ -780,15 +787,7 @@ func DeleteDBRPMapping(
 			defer done()
 			ctx := context.Background()
 			err := s.Delete(ctx, tt.args.Cluster, tt.args.Database, tt.args.RetentionPolicy)
-			if (err != nil) != (tt.wants.err != nil) {
-				t.Fatalf("expected error '%v' got '%v'", tt.wants.err, err)
-			}
-
-			if err != nil && tt.wants.err != nil {
-				if err.Error() != tt.wants.err.Error() {
-					t.Fatalf("expected error messages to match '%v' got '%v'", tt.wants.err, err.Error())
-				}
-			}
+			ErrorsEqual(t, err, tt.wants.err)
 
 			filter := platform.DBRPMappingFilter{}
 			dbrpMappings, _, err := s.FindMany(ctx, filter)
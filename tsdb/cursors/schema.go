@@ -0,0 +1,84 @@
+package cursors
+
+import "github.com/influxdata/influxdb/models"
+
+// StringIterator describes the behavior for enumerating a sequence of
+// string values.
+type StringIterator interface {
+	// Next advances the StringIterator to the next value. It returns false
+	// when there are no more values.
+	Next() bool
+
+	// Value returns the current value after a call to Next.
+	Value() string
+
+	// Stats returns stats on the points and values scanned.
+	Stats() CursorStats
+}
+
+// EmptyStringIterator is an implementation of StringIterator that returns
+// no values.
+var EmptyStringIterator StringIterator = &stringIterator{}
+
+type stringIterator struct{}
+
+func (*stringIterator) Next() bool         { return false }
+func (*stringIterator) Value() string      { return "" }
+func (*stringIterator) Stats() CursorStats { return CursorStats{} }
+
+// StringSliceIterator is an implementation of StringIterator over a slice of
+// strings.
+type StringSliceIterator struct {
+	s     []string
+	v     string
+	i     int
+	stats CursorStats
+}
+
+// NewStringSliceIterator returns a StringIterator over s.
+func NewStringSliceIterator(s []string) *StringSliceIterator {
+	return &StringSliceIterator{s: s, i: 0}
+}
+
+// NewStringSliceIteratorWithStats returns a StringIterator over s, which
+// reports stats as its Stats.
+func NewStringSliceIteratorWithStats(s []string, stats CursorStats) *StringSliceIterator {
+	return &StringSliceIterator{s: s, i: 0, stats: stats}
+}
+
+func (s *StringSliceIterator) Next() bool {
+	if s.i < len(s.s) {
+		s.v = s.s[s.i]
+		s.i++
+		return true
+	}
+	s.v = ""
+	return false
+}
+
+func (s *StringSliceIterator) Value() string {
+	return s.v
+}
+
+func (s *StringSliceIterator) Stats() CursorStats {
+	return s.stats
+}
+
+// StringIteratorToSlice reads all remaining values of i into a slice.
+func StringIteratorToSlice(i StringIterator) []string {
+	if i == nil {
+		return nil
+	}
+
+	var a []string
+	for i.Next() {
+		a = append(a, i.Value())
+	}
+	return a
+}
+
+// MeasurementField describes a field of a measurement and its type.
+type MeasurementField struct {
+	Key  string
+	Type models.FieldType
+}
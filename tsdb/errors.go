@@ -21,6 +21,10 @@ type PartialWriteError struct {
 
 	// A sorted slice of series keys that were dropped.
 	DroppedKeys [][]byte
+
+	// The reason each series key was dropped, keyed by the series key.
+	// It may be nil if only the first reason is known.
+	DroppedReasons map[string]string
 }
 
 func (e PartialWriteError) Error() string {
@@ -21,9 +21,10 @@ type SeriesCollection struct {
 	SeriesIDs  []SeriesID
 
 	// Keeps track of invalid entries.
-	Dropped     uint64
-	DroppedKeys [][]byte
-	Reason      string
+	Dropped        uint64
+	DroppedKeys    [][]byte
+	DroppedReasons map[string]string
+	Reason         string
 
 	// Used by the concurrent iterators to stage drops. Inefficient, but should be
 	// very infrequently used.
@@ -34,7 +35,7 @@ type SeriesCollection struct {
 type seriesCollectionState struct {
 	mu     sync.Mutex
 	reason string
-	index  map[int]struct{}
+	index  map[int]string
 }
 
 // NewSeriesCollection builds a SeriesCollection from a slice of points. It does some filtering
@@ -200,14 +201,33 @@ func (s *SeriesCollection) Advance(length int) {
 
 // InvalidateAll causes all of the entries to become invalid.
 func (s *SeriesCollection) InvalidateAll(reason string) {
+	for _, key := range s.Keys {
+		s.Drop(key, reason)
+	}
 	if s.Reason == "" {
 		s.Reason = reason
 	}
-	s.Dropped += uint64(len(s.Keys))
-	s.DroppedKeys = append(s.DroppedKeys, s.Keys...)
 	s.Truncate(0)
 }
 
+// Drop records the entry with the key as dropped for the reason. Only the first reason
+// is kept as the reason of the collection. It does not remove the entry, and it is not
+// safe for concurrent callers.
+func (s *SeriesCollection) Drop(key []byte, reason string) {
+	if s.Reason == "" {
+		s.Reason = reason
+	}
+	s.Dropped++
+	s.DroppedKeys = append(s.DroppedKeys, key)
+
+	if s.DroppedReasons == nil {
+		s.DroppedReasons = make(map[string]string)
+	}
+	if _, ok := s.DroppedReasons[string(key)]; !ok {
+		s.DroppedReasons[string(key)] = reason
+	}
+}
+
 // ApplyConcurrentDrops will remove all of the dropped values during concurrent iteration. It should
 // not be called concurrently with any calls to Invalid.
 func (s *SeriesCollection) ApplyConcurrentDrops() {
@@ -218,13 +238,12 @@ func (s *SeriesCollection) ApplyConcurrentDrops() {
 
 	length, j := s.Length(), 0
 	for i := 0; i < length; i++ {
-		if _, ok := state.index[i]; ok {
-			s.Dropped++
-
+		if reason, ok := state.index[i]; ok {
 			if i < len(s.Keys) {
-				s.DroppedKeys = append(s.DroppedKeys, s.Keys[i])
+				s.Drop(s.Keys[i], reason)
+			} else {
+				s.Dropped++
 			}
-
 			continue
 		}
 
@@ -266,9 +285,11 @@ func (s *SeriesCollection) invalidIndex(index int, reason string) {
 
 	state.mu.Lock()
 	if state.index == nil {
-		state.index = make(map[int]struct{})
+		state.index = make(map[int]string)
+	}
+	if _, ok := state.index[index]; !ok {
+		state.index[index] = reason
 	}
-	state.index[index] = struct{}{}
 	if state.reason == "" {
 		state.reason = reason
 	}
@@ -283,9 +304,10 @@ func (s *SeriesCollection) PartialWriteError() error {
 	}
 	droppedKeys := bytesutil.SortDedup(s.DroppedKeys)
 	return PartialWriteError{
-		Reason:      s.Reason,
-		Dropped:     len(droppedKeys),
-		DroppedKeys: droppedKeys,
+		Reason:         s.Reason,
+		Dropped:        len(droppedKeys),
+		DroppedKeys:    droppedKeys,
+		DroppedReasons: s.DroppedReasons,
 	}
 }
 
@@ -326,7 +348,7 @@ func (i SeriesCollectionIterator) Type() models.FieldType { return i.s.Types[i.i
 func (i SeriesCollectionIterator) SeriesID() SeriesID     { return i.s.SeriesIDs[i.index] }
 
 // Invalid flags the current entry as invalid, including it in the set of dropped keys and
-// recording a reason. Only the first reason is kept. This is safe for concurrent callers,
+// recording a reason. Only the first reason is kept as the reason of the collection. This is safe for concurrent callers,
 // but ApplyConcurrentDrops must be called after all iterators are finished.
 func (i *SeriesCollectionIterator) Invalid(reason string) {
 	i.s.invalidIndex(i.index, reason)
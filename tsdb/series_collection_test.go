@@ -1,6 +1,7 @@
 package tsdb
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
@@ -121,6 +122,11 @@ func TestSeriesCollection(t *testing.T) {
 			Reason:      "test reason",
 			Dropped:     3,
 			DroppedKeys: bs("ka", "kb", "kc"),
+			DroppedReasons: map[string]string{
+				"ka": "test reason",
+				"kb": "test reason",
+				"kc": "test reason",
+			},
 		})
 	})
 
@@ -130,7 +136,7 @@ func TestSeriesCollection(t *testing.T) {
 		// invalidate half the entries
 		for iter := collection.Iterator(); iter.Next(); {
 			if iter.Index()%2 == 0 {
-				iter.Invalid("test reason")
+				iter.Invalid(fmt.Sprintf("test reason %d", iter.Index()))
 			}
 		}
 
@@ -141,9 +147,13 @@ func TestSeriesCollection(t *testing.T) {
 		collection.ApplyConcurrentDrops()
 		assertEqual(t, "length", collection.Length(), 1)
 		assertEqual(t, "error", collection.PartialWriteError(), PartialWriteError{
-			Reason:      "test reason",
+			Reason:      "test reason 0",
 			Dropped:     2,
 			DroppedKeys: bs("ka", "kc"),
+			DroppedReasons: map[string]string{
+				"ka": "test reason 0",
+				"kc": "test reason 2",
+			},
 		})
 	})
 }
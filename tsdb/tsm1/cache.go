@@ -8,6 +8,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/storage/wal"
 	"github.com/influxdata/influxdb/tsdb"
@@ -550,6 +551,14 @@ func (c *Cache) Values(key []byte) Values {
 // with timestamps between min and max contained in the bucket identified
 // by name from the cache.
 func (c *Cache) DeleteBucketRange(name []byte, min, max int64) {
+	c.DeleteBucketRangePredicate(name, min, max, nil)
+}
+
+// DeleteBucketRangePredicate removes values for all keys containing points
+// with timestamps between min and max contained in the bucket identified
+// by name from the cache. If pred is not nil, only keys whose series key
+// matches pred are removed.
+func (c *Cache) DeleteBucketRangePredicate(name []byte, min, max int64, pred influxdb.Predicate) {
 	c.init()
 
 	// TODO(edd/jeff): find a way to optimize lock usage
@@ -564,6 +573,11 @@ func (c *Cache) DeleteBucketRange(name []byte, min, max int64) {
 		if !bytes.HasPrefix(k, name) {
 			return nil
 		}
+		if pred != nil {
+			if seriesKey, _ := SeriesAndFieldFromCompositeKey(k); !pred.Matches(seriesKey) {
+				return nil
+			}
+		}
 		total += uint64(e.size())
 
 		// if everything is being deleted, just stage it to be deleted and move on.
@@ -650,7 +664,12 @@ func (cl *CacheLoader) Load(cache *Cache) error {
 			encoded := tsdb.EncodeName(en.OrgID, en.BucketID)
 			name := models.EscapeMeasurement(encoded[:])
 
-			cache.DeleteBucketRange(name, en.Min, en.Max)
+			pred, err := UnmarshalPredicate(en.Predicate)
+			if err != nil {
+				return err
+			}
+
+			cache.DeleteBucketRangePredicate(name, en.Min, en.Max, pred)
 			return nil
 		}
 
@@ -864,3 +864,53 @@ func BenchmarkEntry_add(b *testing.B) {
 		}
 	})
 }
+
+func TestCache_SplitByShardGroup(t *testing.T) {
+	c := NewCache(0)
+	if err := c.Write([]byte("cpu,host=A#!~#value"), Values{NewValue(1, 1.0), NewValue(12, 2.0)}); err != nil {
+		t.Fatalf("failed to write: %v", err)
+	}
+	if err := c.Write([]byte("mem,host=A#!~#value"), Values{NewValue(1, 1.0), NewValue(15, 2.0)}); err != nil {
+		t.Fatalf("failed to write: %v", err)
+	}
+
+	// The cache is not copied if none of its buckets are partitioned.
+	groups, err := c.splitByShardGroup(func(name []byte) int64 { return 0 })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 1 || groups[shardGroup{}] != c {
+		t.Fatalf("expected the cache to be its only group, got %v", groups)
+	}
+
+	groups, err = c.splitByShardGroup(func(name []byte) int64 {
+		if string(name) == "cpu" {
+			return 10
+		}
+		return 0
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := map[shardGroup]int{
+		{}:                       2,
+		{name: "cpu", start: 0}:  1,
+		{name: "cpu", start: 10}: 1,
+	}
+	if len(groups) != len(exp) {
+		t.Fatalf("unexpected groups: got %v, exp %v", groups, exp)
+	}
+	for g, n := range exp {
+		sc := groups[g]
+		if sc == nil {
+			t.Fatalf("missing group %v", g)
+		}
+		var got int
+		for _, key := range sc.Keys() {
+			got += len(sc.Values(key))
+		}
+		if got != n {
+			t.Errorf("unexpected values in group %v: got %d, exp %d", g, got, n)
+		}
+	}
+}
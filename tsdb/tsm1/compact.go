@@ -697,6 +697,11 @@ type Compactor struct {
 	// RateLimit is the limit for disk writes for all concurrent compactions.
 	RateLimit limiter.Rate
 
+	// ShardGroupDuration returns the shard group duration in nanoseconds of
+	// the bucket with the escaped name, or zero if the bucket's files are not
+	// partitioned by time. Snapshots write each shard group to its own files.
+	ShardGroupDuration func(name []byte) int64
+
 	formatFileName FormatFileNameFunc
 	parseFileName  ParseFileNameFunc
 
@@ -848,19 +853,22 @@ func (c *Compactor) WriteSnapshot(ctx context.Context, cache *Cache) ([]string,
 		err   error
 	}
 
-	resC := make(chan res, concurrency)
-	for i := 0; i < concurrency; i++ {
+	resC := make(chan res, len(splits))
+	limit := limiter.NewFixed(concurrency)
+	for i := range splits {
 		go func(sp *Cache) {
-			iter := NewCacheKeyIterator(sp, MaxPointsPerBlock, intC)
-			files, err := c.writeNewFiles(c.FileStore.NextGeneration(), 0, nil, iter, throttle)
+			limit.Take()
+			defer limit.Release()
+
+			files, err := c.writeSnapshotSplit(sp, intC, throttle)
 			resC <- res{files: files, err: err}
 
 		}(splits[i])
 	}
 
 	var err error
-	files := make([]string, 0, concurrency)
-	for i := 0; i < concurrency; i++ {
+	files := make([]string, 0, len(splits))
+	for range splits {
 		result := <-resC
 		if result.err != nil {
 			err = result.err
@@ -885,6 +893,29 @@ func (c *Compactor) WriteSnapshot(ctx context.Context, cache *Cache) ([]string,
 	return files, err
 }
 
+// writeSnapshotSplit writes a split of the cache to new TSM files. Every shard
+// group of the split is written to files of its own generation.
+func (c *Compactor) writeSnapshotSplit(sp *Cache, intC chan struct{}, throttle bool) ([]string, error) {
+	groups := map[shardGroup]*Cache{{}: sp}
+	if c.ShardGroupDuration != nil {
+		var err error
+		if groups, err = sp.splitByShardGroup(c.ShardGroupDuration); err != nil {
+			return nil, err
+		}
+	}
+
+	var files []string
+	for _, g := range groups {
+		iter := NewCacheKeyIterator(g, MaxPointsPerBlock, intC)
+		newFiles, err := c.writeNewFiles(c.FileStore.NextGeneration(), 0, nil, iter, throttle)
+		files = append(files, newFiles...)
+		if err != nil {
+			return files, err
+		}
+	}
+	return files, nil
+}
+
 // compact writes multiple smaller TSM files into 1 or more larger files.
 func (c *Compactor) compact(fast bool, tsmFiles []string) ([]string, error) {
 	size := c.Size
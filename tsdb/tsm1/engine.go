@@ -157,6 +157,8 @@ type Engine struct {
 
 	scheduler   *scheduler
 	snapshotter Snapshotter
+
+	shardGroups shardGroups // Shard group durations of the partitioned buckets.
 }
 
 // NewEngine returns a new instance of Engine.
@@ -217,6 +219,8 @@ func NewEngine(path string, idx *tsi1.Index, config Config, options ...EngineOpt
 		snapshotter:                   new(noSnapshotter),
 	}
 
+	c.ShardGroupDuration = e.shardGroups.duration
+
 	for _, option := range options {
 		option(e)
 	}
@@ -922,6 +926,12 @@ func (e *Engine) compact(wg *sync.WaitGroup) {
 				e.compactionTracker.SetOptimiseQueue(uint64(len(level4Groups)))
 			}
 
+			// Don't merge the files of different shard groups.
+			level1Groups = e.splitByShardGroup(level1Groups)
+			level2Groups = e.splitByShardGroup(level2Groups)
+			level3Groups = e.splitByShardGroup(level3Groups)
+			level4Groups = e.splitByShardGroup(level4Groups)
+
 			// Update the level plan queue stats
 			e.compactionTracker.SetQueue(1, uint64(len(level1Groups)))
 			e.compactionTracker.SetQueue(2, uint64(len(level2Groups)))
@@ -1194,6 +1204,22 @@ func (e *Engine) KeyCursor(ctx context.Context, key []byte, t int64, ascending b
 	return e.FileStore.KeyCursor(ctx, key, t, ascending)
 }
 
+// HasDataInRange returns true if the series identified by name and tags has
+// values for field within the time range [min, max], either in the cache or
+// in the TSM files.
+func (e *Engine) HasDataInRange(name []byte, tags models.Tags, field string, min, max int64) (bool, error) {
+	key := models.AppendMakeKey(nil, name, tags)
+	key = append(key, keyFieldSeparatorBytes...)
+	key = append(key, field...)
+
+	for _, v := range e.Cache.Values(key) {
+		if ts := v.UnixNano(); ts >= min && ts <= max {
+			return true, nil
+		}
+	}
+	return e.FileStore.HasDataInRange(key, min, max)
+}
+
 // IteratorCost produces the cost of an iterator.
 func (e *Engine) IteratorCost(measurement string, opt query.IteratorOptions) (query.IteratorCost, error) {
 	// Determine if this measurement exists. If it does not, then no shards are
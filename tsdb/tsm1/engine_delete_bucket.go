@@ -5,6 +5,7 @@ import (
 	"math"
 	"sync"
 
+	"github.com/influxdata/influxdb"
 	"github.com/influxdata/influxdb/models"
 	"github.com/influxdata/influxdb/pkg/bytesutil"
 	"github.com/influxdata/influxdb/tsdb"
@@ -15,6 +16,14 @@ import (
 // and series file data associated with the bucket. The provided time range ensures
 // that only bucket data for that range is removed.
 func (e *Engine) DeleteBucketRange(name []byte, min, max int64) error {
+	return e.DeleteBucketRangePredicate(name, min, max, nil)
+}
+
+// DeleteBucketRangePredicate removes TSM data belonging to a bucket for the provided
+// time range. If pred is not nil, only series whose key matches pred are removed.
+// Series that have no data left after the delete are removed from the index and
+// series file.
+func (e *Engine) DeleteBucketRangePredicate(name []byte, min, max int64, pred influxdb.Predicate) error {
 	// TODO(jeff): we need to block writes to this prefix while deletes are in progress
 	// otherwise we can end up in a situation where we have staged data in the cache or
 	// WAL that was deleted from the index, or worse. This needs to happen at a higher
@@ -63,21 +72,75 @@ func (e *Engine) DeleteBucketRange(name []byte, min, max int64) error {
 	}
 	possiblyDead.keys = make(map[string]struct{})
 
+	// Files that only contain data of the bucket within the time range, such as
+	// the files of an expired shard group, are removed whole.
+	var dropped struct {
+		sync.Mutex
+		paths []string
+	}
+
 	if err := e.FileStore.Apply(func(r TSMFile) error {
-		return r.DeletePrefix(name, min, max, func(key []byte) {
+		if pred == nil && fileWithinRange(r, name, min, max) {
+			iter := r.Iterator(nil)
 			possiblyDead.Lock()
-			possiblyDead.keys[string(key)] = struct{}{}
+			for iter.Next() {
+				possiblyDead.keys[string(iter.Key())] = struct{}{}
+			}
 			possiblyDead.Unlock()
-		})
+			if err := iter.Err(); err != nil {
+				return err
+			}
+
+			dropped.Lock()
+			dropped.paths = append(dropped.paths, r.Path())
+			dropped.Unlock()
+			return nil
+		}
+
+		if pred == nil {
+			return r.DeletePrefix(name, min, max, func(key []byte) {
+				possiblyDead.Lock()
+				possiblyDead.keys[string(key)] = struct{}{}
+				possiblyDead.Unlock()
+			})
+		}
+
+		// Prefix tombstones cannot express a predicate, so collect the matching
+		// keys from the file and tombstone them individually.
+		keys, err := matchingKeys(r, name, pred)
+		if err != nil || len(keys) == 0 {
+			return err
+		}
+
+		if err := r.DeleteRange(keys, min, max); err != nil {
+			return err
+		}
+
+		possiblyDead.Lock()
+		for _, key := range keys {
+			possiblyDead.keys[string(key)] = struct{}{}
+		}
+		possiblyDead.Unlock()
+		return nil
 	}); err != nil {
 		return err
 	}
 
+	if err := e.FileStore.Replace(dropped.paths, nil); err != nil {
+		return err
+	}
+
 	var deleteKeys [][]byte
 
 	// ApplySerialEntryFn cannot return an error in this invocation.
 	_ = e.Cache.ApplyEntryFn(func(k []byte, _ *entry) error {
 		if bytes.HasPrefix(k, name) {
+			if pred != nil {
+				if seriesKey, _ := SeriesAndFieldFromCompositeKey(k); !pred.Matches(seriesKey) {
+					return nil
+				}
+			}
+
 			if deleteKeys == nil {
 				deleteKeys = make([][]byte, 0, 10000)
 			}
@@ -94,7 +157,7 @@ func (e *Engine) DeleteBucketRange(name []byte, min, max int64) error {
 	bytesutil.Sort(deleteKeys)
 
 	// Delete from the cache.
-	e.Cache.DeleteBucketRange(name, min, max)
+	e.Cache.DeleteBucketRangePredicate(name, min, max, pred)
 
 	// Now that all of the data is purged, we need to find if some keys are fully deleted
 	// and if so, remove them from the index.
@@ -147,7 +210,7 @@ func (e *Engine) DeleteBucketRange(name []byte, min, max int64) error {
 		// the deletes of the data in the tsm files.
 
 		// In this case the entire measurement (bucket) can be removed from the index.
-		if min == math.MinInt64 && max == math.MaxInt64 {
+		if min == math.MinInt64 && max == math.MaxInt64 && pred == nil {
 			// The TSI index and Series File do not store series data in escaped form.
 			name = models.UnescapeMeasurement(name)
 
@@ -212,3 +275,35 @@ func (e *Engine) DeleteBucketRange(name []byte, min, max int64) error {
 
 	return nil
 }
+
+// matchingKeys returns the sorted keys in r that begin with prefix and whose
+// series key matches pred.
+func matchingKeys(r TSMFile, prefix []byte, pred influxdb.Predicate) ([][]byte, error) {
+	var keys [][]byte
+
+	iter := r.Iterator(prefix)
+	for iter.Next() {
+		key := iter.Key()
+		if !bytes.HasPrefix(key, prefix) {
+			break
+		}
+
+		if seriesKey, _ := SeriesAndFieldFromCompositeKey(key); pred.Matches(seriesKey) {
+			keys = append(keys, append([]byte(nil), key...))
+		}
+	}
+
+	return keys, iter.Err()
+}
+
+// fileWithinRange returns true if all of the keys of the file belong to the
+// bucket name and all of its data is within the time range [min, max].
+func fileWithinRange(r TSMFile, name []byte, min, max int64) bool {
+	minKey, maxKey := r.KeyRange()
+	if !bytes.Equal(keyBucketName(minKey), name) || !bytes.Equal(keyBucketName(maxKey), name) {
+		return false
+	}
+
+	minTime, maxTime := r.TimeRange()
+	return minTime >= min && maxTime <= max
+}
@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"context"
 	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/tsdb/tsm1"
 )
 
 func TestEngine_DeleteBucket(t *testing.T) {
@@ -118,3 +121,83 @@ func TestEngine_DeleteBucket(t *testing.T) {
 		}
 	}
 }
+
+func TestEngine_DeleteBucketRangePredicate(t *testing.T) {
+	// Create a few points.
+	p1 := MustParsePointString("cpu,host=A value=1.1 1")
+	p2 := MustParsePointString("cpu,host=A value=1.2 2")
+	p3 := MustParsePointString("cpu,host=B value=1.3 3")
+	p4 := MustParsePointString("cpu,host=B value=1.4 4")
+	p5 := MustParsePointString("cpu,host=C value=1.5 5")
+	p6 := MustParsePointString("mem,host=B value=1.6 1")
+
+	e, err := NewEngine()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	if err := e.writePoints(p1, p2, p3, p4, p5, p6); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+
+	if err := e.WriteSnapshot(context.Background()); err != nil {
+		t.Fatalf("failed to snapshot: %s", err.Error())
+	}
+
+	pred, err := tsm1.NewProtobufPredicate(&datatypes.Predicate{
+		Root: &datatypes.Node{
+			NodeType: datatypes.NodeTypeComparisonExpression,
+			Value:    &datatypes.Node_Comparison_{Comparison: datatypes.ComparisonRegex},
+			Children: []*datatypes.Node{
+				{NodeType: datatypes.NodeTypeTagRef, Value: &datatypes.Node_TagRefValue{TagRefValue: "host"}},
+				{NodeType: datatypes.NodeTypeLiteral, Value: &datatypes.Node_RegexValue{RegexValue: "^[AB]$"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only delete part of the range of host=A, and all of host=B.
+	if err := e.DeleteBucketRangePredicate([]byte("cpu"), 2, 4, pred); err != nil {
+		t.Fatalf("failed to delete series: %v", err)
+	}
+
+	keys := e.FileStore.Keys()
+	exp := map[string]byte{
+		"cpu,host=A#!~#value": 0,
+		"cpu,host=C#!~#value": 0,
+		"mem,host=B#!~#value": 0,
+	}
+	if !reflect.DeepEqual(keys, exp) {
+		t.Fatalf("unexpected series in file store: %v != %v", keys, exp)
+	}
+
+	// The series for host=B should be gone from the index, the others should remain.
+	iter, err := e.index.MeasurementSeriesIDIterator([]byte("cpu"))
+	if err != nil {
+		t.Fatalf("iterator error: %v", err)
+	}
+	defer iter.Close()
+
+	var got []string
+	for {
+		elem, err := iter.Next()
+		if err != nil {
+			t.Fatal(err)
+		} else if elem.SeriesID.IsZero() {
+			break
+		}
+		_, tags := e.sfile.Series(elem.SeriesID)
+		got = append(got, tags.String())
+	}
+	sort.Strings(got)
+
+	if exp := []string{"[{host A}]", "[{host C}]"}; !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected series in index: %v != %v", got, exp)
+	}
+}
@@ -688,6 +688,44 @@ func (f *FileStore) Cost(key []byte, min, max int64) query.IteratorCost {
 	return f.cost(key, min, max)
 }
 
+// HasDataInRange returns true if any file contains values for key within the
+// time range [min, max] that have not been deleted by a tombstone.
+func (f *FileStore) HasDataInRange(key []byte, min, max int64) (bool, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	var entries []IndexEntry
+	var trbuf []TimeRange
+	var err error
+	for _, fd := range f.files {
+		if !fd.OverlapsTimeRange(min, max) {
+			continue
+		}
+
+		entries, err = fd.ReadEntries(key, entries)
+		if err != nil {
+			return false, err
+		}
+		trbuf = fd.TombstoneRange(key, trbuf[:0])
+
+	ENTRIES:
+		for _, ie := range entries {
+			if !ie.OverlapsTimeRange(min, max) {
+				continue
+			}
+
+			// Skip any blocks that only contain values that are tombstoned.
+			for _, t := range trbuf {
+				if t.Min <= ie.MinTime && t.Max >= ie.MaxTime {
+					continue ENTRIES
+				}
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // Reader returns a TSMReader for path if one is currently managed by the FileStore.
 // Otherwise it returns nil. If it returns a file, you must call Unref on it when
 // you are done, and never use it after that.
@@ -1104,6 +1142,15 @@ func (f *FileStore) locations(key []byte, t int64, ascending bool) []*location {
 // CreateSnapshot creates hardlinks for all tsm and tombstone files
 // in the path provided.
 func (f *FileStore) CreateSnapshot(ctx context.Context) (string, error) {
+	path, _, err := f.CreateSnapshotSince(ctx, time.Time{})
+	return path, err
+}
+
+// CreateSnapshotSince creates hardlinks for the tsm files that were modified,
+// or had tombstones written, after since along with their tombstone files. A
+// zero since creates hardlinks for all files. It also returns the names of
+// all of the tsm and tombstone files at the time of the snapshot, linked or not.
+func (f *FileStore) CreateSnapshotSince(ctx context.Context, since time.Time) (string, []string, error) {
 	span, _ := opentracing.StartSpanFromContext(ctx, "FileStore.CreateSnapshot")
 	defer span.Finish()
 
@@ -1112,8 +1159,17 @@ func (f *FileStore) CreateSnapshot(ctx context.Context) (string, error) {
 	f.mu.Lock()
 	// create a copy of the files slice and ensure they aren't closed out from
 	// under us, nor the slice mutated.
-	files := make([]TSMFile, len(f.files))
-	copy(files, f.files)
+	files := make([]TSMFile, 0, len(f.files))
+	var names []string
+	for _, tsmf := range f.files {
+		if since.IsZero() || tsmf.Stats().LastModified > since.UnixNano() {
+			files = append(files, tsmf)
+		}
+		names = append(names, filepath.Base(tsmf.Path()))
+		for _, tf := range tsmf.TombstoneFiles() {
+			names = append(names, filepath.Base(tf.Path))
+		}
+	}
 
 	for _, tsmf := range files {
 		tsmf.Ref()
@@ -1131,22 +1187,22 @@ func (f *FileStore) CreateSnapshot(ctx context.Context) (string, error) {
 	// mutable state.
 	err := os.Mkdir(tmpPath, 0777)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 	for _, tsmf := range files {
 		newpath := filepath.Join(tmpPath, filepath.Base(tsmf.Path()))
 		if err := os.Link(tsmf.Path(), newpath); err != nil {
-			return "", fmt.Errorf("error creating tsm hard link: %q", err)
+			return "", nil, fmt.Errorf("error creating tsm hard link: %q", err)
 		}
 		for _, tf := range tsmf.TombstoneFiles() {
 			newpath := filepath.Join(tmpPath, filepath.Base(tf.Path))
 			if err := os.Link(tf.Path, newpath); err != nil {
-				return "", fmt.Errorf("error creating tombstone hard link: %q", err)
+				return "", nil, fmt.Errorf("error creating tombstone hard link: %q", err)
 			}
 		}
 	}
 
-	return tmpPath, nil
+	return tmpPath, names, nil
 }
 
 // MeasurementStats returns the sum of all measurement stats within the store.
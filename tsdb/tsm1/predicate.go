@@ -0,0 +1,171 @@
+package tsm1
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	"github.com/influxdata/influxdb"
+	"github.com/influxdata/influxdb/models"
+	"github.com/influxdata/influxdb/storage/reads/datatypes"
+)
+
+// predicateVersionProtobuf is the leading byte of a marshaled protobuf predicate.
+// It allows the encoding to change in the future without breaking WAL replay.
+const predicateVersionProtobuf = 0x01
+
+// predicateMatcher reports whether a set of series tags match.
+type predicateMatcher func(tags models.Tags) bool
+
+// protobufPredicate is a Predicate built from the comparison structure of a
+// storage protobuf predicate. Only tag comparisons are supported as the
+// predicate is evaluated against series keys, not values.
+type protobufPredicate struct {
+	pred  *datatypes.Predicate
+	match predicateMatcher
+}
+
+// NewProtobufPredicate returns a Predicate that matches based on the comparison
+// structure described by the incoming protobuf.
+func NewProtobufPredicate(pred *datatypes.Predicate) (influxdb.Predicate, error) {
+	if pred == nil || pred.Root == nil {
+		return nil, fmt.Errorf("predicate has no root node")
+	}
+
+	match, err := compilePredicateNode(pred.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	return &protobufPredicate{pred: pred, match: match}, nil
+}
+
+// UnmarshalPredicate decodes a predicate previously encoded with Marshal.
+func UnmarshalPredicate(data []byte) (influxdb.Predicate, error) {
+	if len(data) == 0 {
+		return nil, nil
+	} else if data[0] != predicateVersionProtobuf {
+		return nil, fmt.Errorf("unknown predicate version: %d", data[0])
+	}
+
+	pred := new(datatypes.Predicate)
+	if err := pred.Unmarshal(data[1:]); err != nil {
+		return nil, err
+	}
+	return NewProtobufPredicate(pred)
+}
+
+// Matches returns true if the series key matches the predicate.
+func (p *protobufPredicate) Matches(key []byte) bool {
+	_, tags := models.ParseKeyBytes(key)
+	return p.match(tags)
+}
+
+// Marshal returns a binary representation of the predicate.
+func (p *protobufPredicate) Marshal() ([]byte, error) {
+	data, err := p.pred.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{predicateVersionProtobuf}, data...), nil
+}
+
+func compilePredicateNode(n *datatypes.Node) (predicateMatcher, error) {
+	switch n.NodeType {
+	case datatypes.NodeTypeLogicalExpression:
+		if len(n.Children) == 0 {
+			return nil, fmt.Errorf("logical expression has no children")
+		}
+
+		children := make([]predicateMatcher, 0, len(n.Children))
+		for _, c := range n.Children {
+			m, err := compilePredicateNode(c)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, m)
+		}
+
+		if n.GetLogical() == datatypes.LogicalOr {
+			return func(tags models.Tags) bool {
+				for _, m := range children {
+					if m(tags) {
+						return true
+					}
+				}
+				return false
+			}, nil
+		}
+
+		return func(tags models.Tags) bool {
+			for _, m := range children {
+				if !m(tags) {
+					return false
+				}
+			}
+			return true
+		}, nil
+
+	case datatypes.NodeTypeParenExpression:
+		if len(n.Children) != 1 {
+			return nil, fmt.Errorf("paren expression expects one child")
+		}
+		return compilePredicateNode(n.Children[0])
+
+	case datatypes.NodeTypeComparisonExpression:
+		return compilePredicateComparison(n)
+
+	default:
+		return nil, fmt.Errorf("unsupported predicate node type: %v", n.NodeType)
+	}
+}
+
+func compilePredicateComparison(n *datatypes.Node) (predicateMatcher, error) {
+	if len(n.Children) != 2 {
+		return nil, fmt.Errorf("comparison expression expects two children")
+	}
+
+	lhs, rhs := n.Children[0], n.Children[1]
+	if lhs.NodeType != datatypes.NodeTypeTagRef {
+		return nil, fmt.Errorf("comparison must have a tag reference on the left hand side")
+	} else if rhs.NodeType != datatypes.NodeTypeLiteral {
+		return nil, fmt.Errorf("comparison must have a literal on the right hand side")
+	}
+	key := []byte(lhs.GetTagRefValue())
+
+	switch cmp := n.GetComparison(); cmp {
+	case datatypes.ComparisonEqual, datatypes.ComparisonNotEqual, datatypes.ComparisonStartsWith:
+		val, ok := rhs.Value.(*datatypes.Node_StringValue)
+		if !ok {
+			return nil, fmt.Errorf("tag comparisons require a string literal")
+		}
+		lit := []byte(val.StringValue)
+
+		switch cmp {
+		case datatypes.ComparisonEqual:
+			return func(tags models.Tags) bool { return bytes.Equal(tags.Get(key), lit) }, nil
+		case datatypes.ComparisonNotEqual:
+			return func(tags models.Tags) bool { return !bytes.Equal(tags.Get(key), lit) }, nil
+		default:
+			return func(tags models.Tags) bool { return bytes.HasPrefix(tags.Get(key), lit) }, nil
+		}
+
+	case datatypes.ComparisonRegex, datatypes.ComparisonNotRegex:
+		val, ok := rhs.Value.(*datatypes.Node_RegexValue)
+		if !ok {
+			return nil, fmt.Errorf("regex comparisons require a regex literal")
+		}
+		re, err := regexp.Compile(val.RegexValue)
+		if err != nil {
+			return nil, err
+		}
+
+		if cmp == datatypes.ComparisonRegex {
+			return func(tags models.Tags) bool { return re.Match(tags.Get(key)) }, nil
+		}
+		return func(tags models.Tags) bool { return !re.Match(tags.Get(key)) }, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported comparison operator for series predicate: %v", cmp)
+	}
+}
@@ -0,0 +1,233 @@
+package tsm1
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Shard groups partition the TSM files of a bucket by time. When a bucket has
+// a shard group duration, snapshots write the points of each shard group to
+// their own files and compactions never merge files of different shard groups.
+// Expired shard groups can then be removed by unlinking whole files rather
+// than by writing tombstones and rewriting the files that contain them.
+
+// shardGroups holds the shard group duration of each bucket, keyed by the
+// escaped bucket name that prefixes the TSM keys of the bucket.
+type shardGroups struct {
+	mu        sync.RWMutex
+	durations map[string]int64
+}
+
+// set sets the shard group duration of the bucket name. A duration of zero
+// removes the partitioning of the bucket.
+func (s *shardGroups) set(name []byte, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if d <= 0 {
+		delete(s.durations, string(name))
+		return
+	}
+	if s.durations == nil {
+		s.durations = make(map[string]int64)
+	}
+	s.durations[string(name)] = int64(d)
+}
+
+// duration returns the shard group duration in nanoseconds of the bucket
+// name, or zero if the bucket is not partitioned.
+func (s *shardGroups) duration(name []byte) int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.durations[string(name)]
+}
+
+// SetShardGroupDuration sets the duration of the shard groups of the bucket
+// whose TSM keys are prefixed by the escaped name. Data written from then on
+// is partitioned into files by shard group. A duration of zero disables the
+// partitioning of the bucket.
+func (e *Engine) SetShardGroupDuration(name []byte, d time.Duration) {
+	e.shardGroups.set(name, d)
+}
+
+// ShardGroupDuration returns the shard group duration of the bucket whose TSM
+// keys are prefixed by the escaped name, or zero if the bucket is not
+// partitioned.
+func (e *Engine) ShardGroupDuration(name []byte) time.Duration {
+	return time.Duration(e.shardGroups.duration(name))
+}
+
+// shardGroup identifies the partition of TSM files that a point belongs to.
+// The zero value is the partition of the points of unpartitioned buckets.
+type shardGroup struct {
+	name  string
+	start int64
+}
+
+// keyBucketName returns the escaped bucket name of the TSM key, which ends at
+// the first unescaped comma.
+func keyBucketName(key []byte) []byte {
+	for i := 0; i < len(key); i++ {
+		switch key[i] {
+		case '\\':
+			i++
+		case ',':
+			return key[:i]
+		}
+	}
+	return key
+}
+
+// groupStart returns the start of the shard group of duration d containing ts.
+func groupStart(ts, d int64) int64 {
+	start := ts - ts%d
+	if ts < 0 && start != ts {
+		start -= d
+	}
+	return start
+}
+
+// errStopApply stops the iteration over the entries of a cache.
+var errStopApply = errors.New("stop apply")
+
+// splitByShardGroup splits the entries of the cache into a cache per shard
+// group, using duration to look up the shard group duration of a bucket. The
+// cache itself is returned as the only group if none of its buckets are
+// partitioned.
+func (c *Cache) splitByShardGroup(duration func(name []byte) int64) (map[shardGroup]*Cache, error) {
+	partitioned := false
+	_ = c.store.applySerial(func(key []byte, _ *entry) error {
+		if duration(keyBucketName(key)) > 0 {
+			partitioned = true
+			return errStopApply
+		}
+		return nil
+	})
+	if !partitioned {
+		return map[shardGroup]*Cache{{}: c}, nil
+	}
+
+	caches := make(map[shardGroup]*Cache)
+	cacheFor := func(g shardGroup) *Cache {
+		sc := caches[g]
+		if sc == nil {
+			store, _ := newring(ringShards)
+			sc = &Cache{store: store}
+			caches[g] = sc
+		}
+		return sc
+	}
+
+	err := c.store.applySerial(func(key []byte, e *entry) error {
+		name := keyBucketName(key)
+		d := duration(name)
+
+		e.mu.RLock()
+		values := e.values
+		e.mu.RUnlock()
+
+		if d <= 0 {
+			_, err := cacheFor(shardGroup{}).store.write(key, values)
+			return err
+		}
+
+		groups := make(map[int64]Values)
+		for _, v := range values {
+			start := groupStart(v.UnixNano(), d)
+			groups[start] = append(groups[start], v)
+		}
+		for start, vals := range groups {
+			if _, err := cacheFor(shardGroup{name: string(name), start: start}).store.write(key, vals); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return caches, err
+}
+
+// splitByShardGroup splits each compaction group into groups of the files of
+// a single shard group, so that compactions do not merge the files of
+// different shard groups. Groups that were split and are left with the files
+// of a single generation are released, as compacting them would only rewrite
+// the same data.
+func (e *Engine) splitByShardGroup(groups []CompactionGroup) []CompactionGroup {
+	if len(groups) == 0 {
+		return groups
+	}
+
+	stats := make(map[string]FileStat)
+	for _, st := range e.FileStore.Stats() {
+		stats[st.Path] = st
+	}
+
+	var (
+		split    []CompactionGroup
+		released []CompactionGroup
+	)
+	for _, grp := range groups {
+		var (
+			order []shardGroup
+			parts = make(map[shardGroup]CompactionGroup)
+		)
+		for _, path := range grp {
+			g := e.fileShardGroup(stats[path])
+			if _, ok := parts[g]; !ok {
+				order = append(order, g)
+			}
+			parts[g] = append(parts[g], path)
+		}
+
+		if len(order) == 1 {
+			split = append(split, grp)
+			continue
+		}
+
+		for _, g := range order {
+			if e.generations(parts[g]) < 2 {
+				released = append(released, parts[g])
+				continue
+			}
+			split = append(split, parts[g])
+		}
+	}
+
+	e.CompactionPlan.Release(released)
+	return split
+}
+
+// fileShardGroup returns the shard group of the file described by st, or the
+// zero shardGroup when the file contains the data of unpartitioned buckets or
+// of several shard groups.
+func (e *Engine) fileShardGroup(st FileStat) shardGroup {
+	name := keyBucketName(st.MinKey)
+	if len(name) == 0 || !bytes.Equal(name, keyBucketName(st.MaxKey)) {
+		return shardGroup{}
+	}
+
+	d := e.shardGroups.duration(name)
+	if d <= 0 {
+		return shardGroup{}
+	}
+
+	start := groupStart(st.MinTime, d)
+	if start != groupStart(st.MaxTime, d) {
+		return shardGroup{}
+	}
+	return shardGroup{name: string(name), start: start}
+}
+
+// generations returns the number of distinct generations of the files.
+func (e *Engine) generations(files []string) int {
+	gens := make(map[int]struct{})
+	for _, path := range files {
+		gen, _, err := e.FileStore.parseFileName(filepath.Base(path))
+		if err != nil {
+			return len(files)
+		}
+		gens[gen] = struct{}{}
+	}
+	return len(gens)
+}
@@ -0,0 +1,95 @@
+package tsm1_test
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestEngine_ShardGroups(t *testing.T) {
+	e, err := NewEngine()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	// Partition cpu into shard groups of 10ns, and leave mem unpartitioned.
+	e.SetShardGroupDuration([]byte("cpu"), 10)
+
+	if err := e.writePoints(
+		MustParsePointString("cpu,host=A value=1.1 1"),
+		MustParsePointString("cpu,host=A value=1.2 5"),
+		MustParsePointString("cpu,host=A value=1.3 12"),
+		MustParsePointString("cpu,host=B value=1.4 25"),
+		MustParsePointString("mem,host=A value=1.5 1"),
+		MustParsePointString("mem,host=A value=1.6 15"),
+	); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+
+	if err := e.WriteSnapshot(context.Background()); err != nil {
+		t.Fatalf("failed to snapshot: %s", err.Error())
+	}
+
+	files := func() []string {
+		var a []string
+		for _, st := range e.FileStore.Stats() {
+			if st.HasTombstone {
+				t.Fatalf("unexpected tombstone for %s", st.Path)
+			}
+			a = append(a, fmt.Sprintf("%s %s %d %d", st.MinKey, st.MaxKey, st.MinTime, st.MaxTime))
+		}
+		sort.Strings(a)
+		return a
+	}
+
+	exp := []string{
+		"cpu,host=A#!~#value cpu,host=A#!~#value 1 5",
+		"cpu,host=A#!~#value cpu,host=A#!~#value 12 12",
+		"cpu,host=B#!~#value cpu,host=B#!~#value 25 25",
+		"mem,host=A#!~#value mem,host=A#!~#value 1 15",
+	}
+	if got := files(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected files: got %v, exp %v", got, exp)
+	}
+
+	// Dropping the first two shard groups of cpu removes their files whole.
+	if err := e.DeleteBucketRange([]byte("cpu"), math.MinInt64, 19); err != nil {
+		t.Fatalf("failed to delete: %v", err)
+	}
+
+	exp = []string{
+		"cpu,host=B#!~#value cpu,host=B#!~#value 25 25",
+		"mem,host=A#!~#value mem,host=A#!~#value 1 15",
+	}
+	if got := files(); !reflect.DeepEqual(got, exp) {
+		t.Fatalf("unexpected files: got %v, exp %v", got, exp)
+	}
+
+	// The series of host=A should be gone from the index.
+	iter, err := e.index.MeasurementSeriesIDIterator([]byte("cpu"))
+	if err != nil {
+		t.Fatalf("iterator error: %v", err)
+	}
+	defer iter.Close()
+
+	var n int
+	for {
+		elem, err := iter.Next()
+		if err != nil {
+			t.Fatal(err)
+		} else if elem.SeriesID.IsZero() {
+			break
+		}
+		n++
+	}
+	if n != 1 {
+		t.Fatalf("unexpected series count: got %d, exp 1", n)
+	}
+}
@@ -16,7 +16,9 @@ const (
 
 	// UsageValues is the name of the metrics for tracking the number of values.
 	UsageValues UsageMetric = "usage_values"
-	// UsageSeries is the name of the metrics for tracking the number of series written.
+	// UsageSeries is the name of the metrics for tracking the number of series
+	// of buckets. Unlike the other metrics it is not a sum, but the number of
+	// series at the time of the latest write.
 	UsageSeries UsageMetric = "usage_series"
 
 	// UsageQueryRequestCount is the name of the metrics for tracking query request count.
@@ -38,6 +40,23 @@ type UsageService interface {
 	GetUsage(ctx context.Context, filter UsageFilter) (map[UsageMetric]*Usage, error)
 }
 
+// UsageRecorder records the usage of organizations and buckets.
+type UsageRecorder interface {
+	// RecordUsage adds the value of the usage u to its metric for its
+	// organization and bucket. The bucket of the usage of queries is nil.
+	RecordUsage(ctx context.Context, u Usage)
+}
+
+// UsageMetrics are all the metrics of usage.
+var UsageMetrics = []UsageMetric{
+	UsageWriteRequestCount,
+	UsageWriteRequestBytes,
+	UsageValues,
+	UsageSeries,
+	UsageQueryRequestCount,
+	UsageQueryRequestBytes,
+}
+
 // UsageFilter is used to filter usage.
 type UsageFilter struct {
 	OrgID    *ID
@@ -8,6 +8,10 @@ import (
 type User struct {
 	ID   ID     `json:"id,omitempty"`
 	Name string `json:"name"`
+
+	// OAuthID is the identity of the user at the OAuth2 provider the user
+	// signs in through. Users without one cannot sign in through OAuth2.
+	OAuthID string `json:"oauthID,omitempty"`
 }
 
 // Ops for user errors and op log.